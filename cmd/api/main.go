@@ -7,10 +7,12 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
 	"github.com/danielyang21/GoBattleServer/internal/database"
+	"github.com/danielyang21/GoBattleServer/internal/domain"
 	"github.com/danielyang21/GoBattleServer/internal/handler"
 	"github.com/danielyang21/GoBattleServer/internal/repository"
 	"github.com/danielyang21/GoBattleServer/internal/service"
@@ -31,12 +33,113 @@ func main() {
 	userRepo := repository.NewPostgresUserRepository(pool)
 	speciesRepo := repository.NewPostgresPokemonSpeciesRepository(pool)
 	pokemonRepo := repository.NewPostgresUserPokemonRepository(pool)
+	receiptRepo := repository.NewPostgresRollReceiptRepository(pool)
+	bannerRepo := repository.NewPostgresBannerRepository(pool)
+	pityRepo := repository.NewPostgresPityRepository(pool)
+	seedRepo := repository.NewPostgresFairSeedRepository(pool)
+	pullRepo := repository.NewPostgresGachaPullRepository(pool)
+	listingRepo := repository.NewPostgresMarketListingRepository(pool)
+	marketTxRepo := repository.NewPostgresMarketTransactionRepository(pool)
+	auctionRepo := repository.NewPostgresAuctionRepository(pool)
+	notificationRepo := repository.NewPostgresNotificationRepository(pool)
+	tradeRepo := repository.NewPostgresTradeRepository(pool)
+	battleRepo := repository.NewPostgresBattleRepository(pool)
+	escrowRepo := repository.NewPostgresEscrowRepository(pool)
+	candyRepo := repository.NewPostgresCandyRepository(pool)
+	auditRepo := repository.NewPostgresPokemonAuditRepository(pool)
+	evolutionRepo := repository.NewPostgresEvolutionRepository(pool)
+	learnsetRepo := repository.NewPostgresLearnsetRepository(pool)
+	itemRepo := repository.NewPostgresItemRepository(pool)
+	streakRepo := repository.NewPostgresLoginStreakRepository(pool)
+	questRepo := repository.NewPostgresQuestProgressRepository(pool)
+	pokedexRepo := repository.NewPostgresPokedexRepository(pool)
+	txManager := repository.NewPostgresTxManager(pool)
 
 	// Initialize services
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, receiptRepo, bannerRepo, pityRepo, seedRepo, pullRepo, txManager)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, tradeRepo, battleRepo, txManager)
+	auctionService := service.NewAuctionService(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo, tradeRepo, battleRepo, txManager)
+	valuationService := service.NewValuationService(marketTxRepo)
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, battleRepo, notificationRepo, txManager)
+	candyService := service.NewCandyService(pokemonRepo, candyRepo, auditRepo, listingRepo, tradeRepo, battleRepo, txManager)
+	releaseService := service.NewReleaseService(userRepo, pokemonRepo, listingRepo, tradeRepo, battleRepo, candyRepo, auditRepo, valuationService, txManager)
+	evolutionService := service.NewEvolutionService(userRepo, pokemonRepo, evolutionRepo, learnsetRepo, candyRepo, itemRepo, auditRepo, listingRepo, tradeRepo, battleRepo, txManager)
+	wildBattleService := service.NewWildBattleService(speciesRepo, pokemonRepo, listingRepo, tradeRepo, battleRepo, txManager)
+	trainingService := service.NewTrainingService(userRepo, pokemonRepo, itemRepo, auditRepo, listingRepo, tradeRepo, battleRepo, txManager)
+	abilityService := service.NewAbilityService(pokemonRepo, itemRepo, auditRepo, listingRepo, tradeRepo, battleRepo, txManager)
+	streakService := service.NewStreakService(userRepo, streakRepo, itemRepo, gachaService, txManager)
+	questService := service.NewQuestService(userRepo, questRepo, itemRepo, txManager)
+	pokedexService := service.NewPokedexService(userRepo, speciesRepo, pokedexRepo, itemRepo)
+	speciesService := service.NewSpeciesService(speciesRepo, learnsetRepo)
+
+	// Count pulls, battles, trades and sales toward quests, and species
+	// obtained and seen toward the Pokedex
+	events := service.EventRecorders{questService, pokedexService}
+	gachaService.SetEventRecorder(events)
+	marketService.SetEventRecorder(events)
+	auctionService.SetEventRecorder(events)
+	tradeService.SetEventRecorder(events)
+	evolutionService.SetEventRecorder(events)
+	wildBattleService.SetEventRecorder(events)
+	battleService.SetEventRecorder(events)
+
+	// Optional cool-down before newly acquired Pokemon can be traded (e.g. "24h")
+	if cooldown := os.Getenv("TRADE_COOLDOWN"); cooldown != "" {
+		d, err := time.ParseDuration(cooldown)
+		if err != nil {
+			log.Fatalf("Invalid TRADE_COOLDOWN: %v", err)
+		}
+		tradeService.SetAcquisitionCooldown(d)
+	}
+
+	// Optional chance that a pull is shiny (e.g. "0.001953125" for 1 in 512)
+	if rate := os.Getenv("SHINY_RATE"); rate != "" {
+		r, err := strconv.ParseFloat(rate, 64)
+		if err != nil {
+			log.Fatalf("Invalid SHINY_RATE: %v", err)
+		}
+		if err := domain.SetShinyRate(r); err != nil {
+			log.Fatalf("Invalid SHINY_RATE: %v", err)
+		}
+	}
+
+	// Optional timezone whose midnight starts a new check-in day (e.g. "America/New_York")
+	if tz := os.Getenv("STREAK_TIMEZONE"); tz != "" {
+		if err := domain.SetCheckInTimezone(tz); err != nil {
+			log.Fatalf("Invalid STREAK_TIMEZONE: %v", err)
+		}
+	}
+
+	// Refund wagers held by battles that were running when the server last stopped
+	recovered, err := battleService.RecoverEscrows(context.Background())
+	if err != nil {
+		log.Printf("Failed to recover some battle escrows: %v", err)
+	}
+	if recovered > 0 {
+		log.Printf("Refunded %d battle escrows left from the last run", recovered)
+	}
+
+	// Settle auctions, expire trade offers and abandon inactive battles in
+	// the background until shutdown
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go auctionService.RunSettlementScheduler(schedulerCtx, domain.AuctionSettleEvery)
+	go tradeService.RunExpiryScheduler(schedulerCtx, domain.TradeExpireEvery)
+	go battleService.RunAbandonmentSweeper(schedulerCtx, domain.BattleSweepEvery, domain.BattleInactivityTimeout)
+
+	// Reload the species pulls are drawn from on SIGHUP, e.g. after an import
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			gachaService.RefreshSpecies()
+			log.Println("🔄 Species reloaded")
+		}
+	}()
 
 	// Initialize router
-	router := handler.NewRouter(userRepo, gachaService)
+	router := handler.NewRouter(userRepo, gachaService, marketService, auctionService, notificationRepo, tradeService, valuationService, releaseService, candyService, evolutionService, wildBattleService, trainingService, abilityService, streakService, questService, pokedexService, speciesService)
 
 	// Setup routes with middleware
 	httpHandler := router.SetupRoutes()
@@ -73,6 +176,7 @@ func main() {
 	<-quit
 
 	log.Println("🛑 Shutting down server...")
+	stopScheduler()
 
 	// Graceful shutdown with 30 second timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -50,7 +51,28 @@ func main() {
 	log.Println("   /roll    - Buy premium rolls with coins")
 	log.Println("   /balance - Check your coin balance")
 	log.Println("   /box     - View your Pokemon collection")
+	log.Println("   /market  - Buy and sell Pokemon with other players")
+	log.Println("   /auction - Auction Pokemon to the highest bidder")
+	log.Println("   /trade - Trade Pokemon and coins with another player")
+	log.Println("   /release - Release or bulk sell Pokemon for coins")
+	log.Println("   /candy - Spend species candy on IVs and natures")
+	log.Println("   /evolve - Check and evolve a Pokemon")
+	log.Println("   /wild - Battle a wild Pokemon for experience")
+	log.Println("   /train - Train a Pokemon's EVs with coins or vitamins")
+	log.Println("   /ability - Switch a Pokemon's ability with an Ability Capsule")
+	log.Println("   /banners - List the gacha banners running now")
+	log.Println("   /streak - View your daily check-in streak")
+	log.Println("   /checkin - Check in for today's streak reward")
+	log.Println("   /quests - View and claim daily, weekly and achievement quests")
+	log.Println("   /dex - View your Pokedex completion")
+	log.Println("   /pokedex - Look up a species' stats, matchups and moves")
 	log.Println()
+
+	// Deliver auction results and other notifications as DMs
+	pollerCtx, stopPoller := context.WithCancel(context.Background())
+	defer stopPoller()
+	go discordBot.RunNotificationPoller(pollerCtx, bot.NotificationPollInterval)
+
 	log.Println("Press CTRL+C to stop the bot")
 
 	// Wait for interrupt signal
@@ -59,6 +81,7 @@ func main() {
 	<-stop
 
 	log.Println("🛑 Shutting down bot...")
+	stopPoller()
 	if err := discordBot.Stop(); err != nil {
 		fmt.Printf("Error stopping bot: %v\n", err)
 	}
@@ -29,9 +29,15 @@ func main() {
 	userRepo := repository.NewPostgresUserRepository(pool)
 	speciesRepo := repository.NewPostgresPokemonSpeciesRepository(pool)
 	pokemonRepo := repository.NewPostgresUserPokemonRepository(pool)
+	receiptRepo := repository.NewPostgresRollReceiptRepository(pool)
+	bannerRepo := repository.NewPostgresBannerRepository(pool)
+	pityRepo := repository.NewPostgresPityRepository(pool)
+	seedRepo := repository.NewPostgresFairSeedRepository(pool)
+	pullRepo := repository.NewPostgresGachaPullRepository(pool)
+	txManager := repository.NewPostgresTxManager(pool)
 
 	// Initialize gacha service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, receiptRepo, bannerRepo, pityRepo, seedRepo, pullRepo, txManager)
 
 	// Example: Get or create a user
 	discordID := "123456789012345678"
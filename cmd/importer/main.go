@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/danielyang21/GoBattleServer/internal/database"
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/pokeapi"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+)
+
+func main() {
+	dir := flag.String("dir", "", "directory of the PokeAPI dump")
+	format := flag.String("format", "", "dump format, json or csv (detected when empty)")
+	rarityRule := flag.String("rarity-rule", "", "rarity rule, e.g. mythical=mythic,legendary=legendary,epic=530,rare=400,uncommon=300")
+	flag.Parse()
+
+	if *dir == "" {
+		log.Fatal("Usage: importer -dir <dump directory> [-format json|csv] [-rarity-rule <rule>]")
+	}
+
+	rule := domain.DefaultRarityRule()
+	if *rarityRule != "" {
+		var err error
+		if rule, err = domain.ParseRarityRule(*rarityRule); err != nil {
+			log.Fatalf("Failed to parse rarity rule: %v", err)
+		}
+	}
+
+	data, err := pokeapi.Load(*dir, *format)
+	if err != nil {
+		log.Fatalf("Failed to load dump: %v", err)
+	}
+	fmt.Printf("Read %d species and %d moves from %s\n", len(data.Species), len(data.Moves), *dir)
+
+	// Load database configuration
+	dbConfig := database.LoadConfigFromEnv()
+
+	// Create database connection pool
+	pool, err := database.NewPool(dbConfig)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer database.Close(pool)
+
+	importService := service.NewImportService(
+		repository.NewPostgresPokemonSpeciesRepository(pool),
+		repository.NewPostgresMoveRepository(pool),
+		repository.NewPostgresLearnsetRepository(pool),
+		repository.NewPostgresTxManager(pool),
+	)
+
+	result, err := importService.Import(context.Background(), data, rule)
+	if err != nil {
+		log.Fatalf("Failed to import: %v", err)
+	}
+
+	fmt.Printf("Imported %d species, %d moves and %d learnset moves\n", result.Species, result.Moves, result.LearnsetMoves)
+
+	rarities := make([]domain.Rarity, 0, len(result.ByRarity))
+	for rarity := range result.ByRarity {
+		rarities = append(rarities, rarity)
+	}
+	sort.Slice(rarities, func(i, j int) bool { return rarities[i].Value() < rarities[j].Value() })
+	for _, rarity := range rarities {
+		fmt.Printf("  %-10s %d\n", rarity, result.ByRarity[rarity])
+	}
+
+	if result.UnknownMoves > 0 {
+		fmt.Printf("Left %d learnset moves out that are in neither the dump nor the database\n", result.UnknownMoves)
+	}
+	for _, skip := range result.Skipped {
+		fmt.Printf("Skipped %s %d (%s): %s\n", skip.Kind, skip.ID, skip.Name, skip.Reason)
+	}
+}
@@ -0,0 +1,60 @@
+package bot
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// abilityCommand defines /ability
+var abilityCommand = &discordgo.ApplicationCommand{
+	Name:        "ability",
+	Description: "Switch a Pokemon to its other regular ability with an Ability Capsule",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "pokemon_id",
+			Description: "ID of the Pokemon (shown in /box)",
+			Required:    true,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "slot",
+			Description: "Ability slot to switch to",
+			Required:    true,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{Name: "Slot 1", Value: 1},
+				{Name: "Slot 2", Value: 2},
+			},
+		},
+	},
+}
+
+// handleAbility handles the /ability command
+func (b *Bot) handleAbility(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	user, err := b.apiClient.GetOrCreateUser(interactionUserID(i))
+	if err != nil {
+		b.sendError(s, i, "Failed to get user: "+err.Error())
+		return
+	}
+
+	options := optionMap(i.ApplicationCommandData().Options)
+	result, err := b.apiClient.ChangeAbility(user.ID, options["pokemon_id"].StringValue(), int(options["slot"].IntValue()))
+	if err != nil {
+		b.sendError(s, i, "❌ Failed to change ability: "+err.Error())
+		return
+	}
+
+	p := result.Pokemon
+	previous := abilityLabel(Pokemon{Ability: result.PreviousAbility})
+	b.sendEmbed(s, i, &discordgo.MessageEmbed{
+		Title: "💊 Ability Changed",
+		Description: fmt.Sprintf("%s **%s**\n**Ability:** %s → %s",
+			getRarityEmoji(p.Species.Rarity), pokemonLabel(p), previous, abilityLabel(p)),
+		Color: 0x1abc9c,
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -43,14 +45,26 @@ type User struct {
 }
 
 type Pokemon struct {
-	ID             string  `json:"id"`
-	Species        Species `json:"species"`
-	Nature         string  `json:"nature"`
-	Level          int     `json:"level"`
-	IVs            IVs     `json:"ivs"`
-	Stats          Stats   `json:"stats"`
-	IVPercentage   float64 `json:"iv_percentage"`
-	EstimatedValue int     `json:"estimated_value"`
+	ID               string  `json:"id"`
+	Species          Species `json:"species"`
+	Nature           string  `json:"nature"`
+	IsShiny          bool    `json:"is_shiny"`
+	Gender           string  `json:"gender"`
+	Form             *Form   `json:"form,omitempty"`
+	SpriteURL        string  `json:"sprite_url"`
+	Ability          string  `json:"ability"`
+	AbilitySlot      int     `json:"ability_slot"`
+	HiddenAbility    bool    `json:"hidden_ability"`
+	Level            int     `json:"level"`
+	Experience       int     `json:"experience"`
+	ExperienceToNext int     `json:"experience_to_next"`
+	IVs              IVs     `json:"ivs"`
+	EVs              IVs     `json:"evs"`
+	EVSpread         string  `json:"ev_spread"`
+	Stats            Stats   `json:"stats"`
+	IVPercentage     float64 `json:"iv_percentage"`
+	EstimatedValue   int     `json:"estimated_value"`
+	ValueSource      string  `json:"value_source"` // "market" or "formula"
 }
 
 type Species struct {
@@ -59,6 +73,11 @@ type Species struct {
 	Rarity string `json:"rarity"`
 }
 
+type Form struct {
+	Name  string   `json:"name"`
+	Types []string `json:"types"`
+}
+
 type IVs struct {
 	HP        int `json:"hp"`
 	Attack    int `json:"attack"`
@@ -142,16 +161,21 @@ func (c *APIClient) GetOrCreateUser(discordID string) (*User, error) {
 	return c.RegisterUser(discordID)
 }
 
-func (c *APIClient) DailyRoll(userID string) ([]Pokemon, error) {
+// DailyRoll claims the daily roll. The idempotency key should identify the
+// Discord event, so a redelivered command returns the original pull.
+func (c *APIClient) DailyRoll(userID, idempotencyKey string) ([]Pokemon, error) {
 	reqBody, _ := json.Marshal(map[string]string{
 		"user_id": userID,
 	})
 
-	resp, err := c.httpClient.Post(
-		c.baseURL+"/api/gacha/daily-roll",
-		"application/json",
-		bytes.NewBuffer(reqBody),
-	)
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+"/api/gacha/daily-roll", bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -179,10 +203,13 @@ func (c *APIClient) DailyRoll(userID string) ([]Pokemon, error) {
 	return result.Pokemons, nil
 }
 
-func (c *APIClient) PremiumRoll(userID string, count int) ([]Pokemon, error) {
+// PremiumRoll buys rolls on a banner, or on the standard pool when bannerID
+// is empty
+func (c *APIClient) PremiumRoll(userID string, count int, bannerID string) (*PremiumRollResult, error) {
 	reqBody, _ := json.Marshal(map[string]interface{}{
-		"user_id": userID,
-		"count":   count,
+		"user_id":   userID,
+		"count":     count,
+		"banner_id": bannerID,
 	})
 
 	resp, err := c.httpClient.Post(
@@ -206,40 +233,805 @@ func (c *APIClient) PremiumRoll(userID string, count int) ([]Pokemon, error) {
 		return nil, fmt.Errorf("%s: %s", apiResp.Error.Code, apiResp.Error.Message)
 	}
 
+	var result PremiumRollResult
+	if err := json.Unmarshal(apiResp.Data, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+type PityProgress struct {
+	Rarity           string `json:"rarity"`
+	Pulls            int    `json:"pulls"`
+	SoftPity         int    `json:"soft_pity"`
+	HardPity         int    `json:"hard_pity"`
+	PullsToGuarantee int    `json:"pulls_to_guarantee"`
+}
+
+type Pity struct {
+	BannerID string         `json:"banner_id"`
+	Progress []PityProgress `json:"progress"`
+}
+
+type PremiumRollResult struct {
+	Pokemons []Pokemon `json:"pokemons"`
+	Count    int       `json:"count"`
+	Pity     *Pity     `json:"pity"`
+}
+
+// BoxSearch holds optional box filters and paging. Zero values are left out.
+type BoxSearch struct {
+	Rarity string
+	Sort   string
+	Limit  int
+	Cursor string
+}
+
+// BoxPage is one page of a user's collection
+type BoxPage struct {
+	Pokemons   []Pokemon `json:"pokemons"`
+	Count      int       `json:"count"`
+	Total      int       `json:"total"`
+	NextCursor string    `json:"next_cursor"`
+}
+
+func (c *APIClient) GetUserBox(userID string, search BoxSearch) (*BoxPage, error) {
+	params := url.Values{}
+	if search.Rarity != "" {
+		params.Set("rarity", search.Rarity)
+	}
+	if search.Sort != "" {
+		params.Set("sort", search.Sort)
+	}
+	if search.Limit > 0 {
+		params.Set("limit", strconv.Itoa(search.Limit))
+	}
+	if search.Cursor != "" {
+		params.Set("cursor", search.Cursor)
+	}
+
+	var page BoxPage
+	if err := c.doJSON(http.MethodGet, "/api/users/"+userID+"/pokemon?"+params.Encode(), nil, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+type Listing struct {
+	ID       string  `json:"id"`
+	SellerID string  `json:"seller_id"`
+	Price    int     `json:"price"`
+	Fee      int     `json:"fee"`
+	Type     string  `json:"type"`
+	Status   string  `json:"status"`
+	ListedAt string  `json:"listed_at"`
+	Pokemon  Pokemon `json:"pokemon"`
+}
+
+type MarketTransaction struct {
+	ID          string `json:"id"`
+	ListingID   string `json:"listing_id"`
+	BuyerID     string `json:"buyer_id"`
+	SellerID    string `json:"seller_id"`
+	Price       int    `json:"price"`
+	Fee         int    `json:"fee"`
+	CompletedAt string `json:"completed_at"`
+}
+
+// MarketSearch holds optional market filters. Zero values are left out.
+type MarketSearch struct {
+	Species  string
+	Rarity   string
+	MinIV    float64
+	MaxPrice int
+	SellerID string
+}
+
+func (c *APIClient) SearchListings(search MarketSearch) ([]Listing, error) {
+	params := url.Values{}
+	if search.Species != "" {
+		params.Set("species", search.Species)
+	}
+	if search.Rarity != "" {
+		params.Set("rarity", search.Rarity)
+	}
+	if search.MinIV > 0 {
+		params.Set("min_iv", strconv.FormatFloat(search.MinIV, 'f', -1, 64))
+	}
+	if search.MaxPrice > 0 {
+		params.Set("max_price", strconv.Itoa(search.MaxPrice))
+	}
+	if search.SellerID != "" {
+		params.Set("seller_id", search.SellerID)
+	}
+
 	var result struct {
-		Pokemons []Pokemon `json:"pokemons"`
+		Listings []Listing `json:"listings"`
 		Count    int       `json:"count"`
 	}
-	if err := json.Unmarshal(apiResp.Data, &result); err != nil {
+	if err := c.doJSON(http.MethodGet, "/api/market/listings?"+params.Encode(), nil, &result); err != nil {
 		return nil, err
 	}
 
-	return result.Pokemons, nil
+	return result.Listings, nil
 }
 
-func (c *APIClient) GetUserPokemon(userID string) ([]Pokemon, error) {
-	resp, err := c.httpClient.Get(c.baseURL + "/api/users/" + userID + "/pokemon")
+func (c *APIClient) CreateListing(userID, pokemonID string, price int) (*Listing, error) {
+	var listing Listing
+	err := c.doJSON(http.MethodPost, "/api/market/listings", map[string]interface{}{
+		"user_id":    userID,
+		"pokemon_id": pokemonID,
+		"price":      price,
+	}, &listing)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var apiResp APIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+	return &listing, nil
+}
+
+func (c *APIClient) CancelListing(userID, listingID string) error {
+	return c.doJSON(http.MethodPost, "/api/market/listings/"+listingID+"/cancel", map[string]string{
+		"user_id": userID,
+	}, nil)
+}
+
+func (c *APIClient) BuyListing(userID, listingID string) (*MarketTransaction, error) {
+	var transaction MarketTransaction
+	err := c.doJSON(http.MethodPost, "/api/market/listings/"+listingID+"/buy", map[string]string{
+		"user_id": userID,
+	}, &transaction)
+	if err != nil {
 		return nil, err
 	}
 
-	if !apiResp.Success {
-		return nil, fmt.Errorf("%s: %s", apiResp.Error.Code, apiResp.Error.Message)
+	return &transaction, nil
+}
+
+type PriceReport struct {
+	SpeciesID int `json:"species_id"`
+	Valuation *struct {
+		MedianPrice int `json:"median_price"`
+		SampleSize  int `json:"sample_size"`
+	} `json:"valuation"`
+	History []struct {
+		Start       string `json:"start"`
+		MedianPrice int    `json:"median_price"`
+		MinPrice    int    `json:"min_price"`
+		MaxPrice    int    `json:"max_price"`
+		Volume      int    `json:"volume"`
+	} `json:"history"`
+}
+
+func (c *APIClient) GetPrices(speciesID, days int) (*PriceReport, error) {
+	var report PriceReport
+	path := fmt.Sprintf("/api/market/prices/%d?days=%d", speciesID, days)
+	if err := c.doJSON(http.MethodGet, path, nil, &report); err != nil {
+		return nil, err
 	}
 
+	return &report, nil
+}
+
+type Auction struct {
+	Listing         Listing `json:"listing"`
+	StartingPrice   int     `json:"starting_price"`
+	MinIncrement    int     `json:"min_increment"`
+	CurrentBid      int     `json:"current_bid"`
+	MinimumBid      int     `json:"minimum_bid"`
+	HighestBidderID *string `json:"highest_bidder_id"`
+	BidCount        int     `json:"bid_count"`
+	EndsAt          string  `json:"ends_at"`
+}
+
+func (c *APIClient) ListAuctions() ([]Auction, error) {
 	var result struct {
-		Pokemons []Pokemon `json:"pokemons"`
+		Auctions []Auction `json:"auctions"`
 		Count    int       `json:"count"`
 	}
-	if err := json.Unmarshal(apiResp.Data, &result); err != nil {
+	if err := c.doJSON(http.MethodGet, "/api/market/auctions", nil, &result); err != nil {
 		return nil, err
 	}
 
-	return result.Pokemons, nil
-}
\ No newline at end of file
+	return result.Auctions, nil
+}
+
+func (c *APIClient) CreateAuction(userID, pokemonID string, startingPrice, minIncrement, durationMinutes int) (*Auction, error) {
+	var auction Auction
+	err := c.doJSON(http.MethodPost, "/api/market/auctions", map[string]interface{}{
+		"user_id":          userID,
+		"pokemon_id":       pokemonID,
+		"starting_price":   startingPrice,
+		"min_increment":    minIncrement,
+		"duration_minutes": durationMinutes,
+	}, &auction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auction, nil
+}
+
+func (c *APIClient) PlaceBid(userID, auctionID string, amount int) (*Auction, error) {
+	var auction Auction
+	err := c.doJSON(http.MethodPost, "/api/market/auctions/"+auctionID+"/bids", map[string]interface{}{
+		"user_id": userID,
+		"amount":  amount,
+	}, &auction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auction, nil
+}
+
+func (c *APIClient) CancelAuction(userID, auctionID string) error {
+	return c.doJSON(http.MethodPost, "/api/market/auctions/"+auctionID+"/cancel", map[string]string{
+		"user_id": userID,
+	}, nil)
+}
+
+type Trade struct {
+	ID                  string   `json:"id"`
+	ProposerID          string   `json:"proposer_id"`
+	RecipientID         string   `json:"recipient_id"`
+	ProposerPokemonIDs  []string `json:"proposer_pokemon_ids"`
+	RecipientPokemonIDs []string `json:"recipient_pokemon_ids"`
+	ProposerCoins       int      `json:"proposer_coins"`
+	RecipientCoins      int      `json:"recipient_coins"`
+	ProposerConfirmed   bool     `json:"proposer_confirmed"`
+	RecipientConfirmed  bool     `json:"recipient_confirmed"`
+	Status              string   `json:"status"`
+	CreatedAt           string   `json:"created_at"`
+	ExpiresAt           string   `json:"expires_at"`
+}
+
+type TradeOffer struct {
+	RecipientID         string   `json:"recipient_id"`
+	ProposerPokemonIDs  []string `json:"proposer_pokemon_ids"`
+	RecipientPokemonIDs []string `json:"recipient_pokemon_ids"`
+	ProposerCoins       int      `json:"proposer_coins"`
+	RecipientCoins      int      `json:"recipient_coins"`
+}
+
+func (c *APIClient) ProposeTrade(userID string, offer TradeOffer) (*Trade, error) {
+	var trade Trade
+	err := c.doJSON(http.MethodPost, "/api/trades", map[string]interface{}{
+		"user_id":               userID,
+		"recipient_id":          offer.RecipientID,
+		"proposer_pokemon_ids":  offer.ProposerPokemonIDs,
+		"recipient_pokemon_ids": offer.RecipientPokemonIDs,
+		"proposer_coins":        offer.ProposerCoins,
+		"recipient_coins":       offer.RecipientCoins,
+	}, &trade)
+	if err != nil {
+		return nil, err
+	}
+
+	return &trade, nil
+}
+
+func (c *APIClient) ListTrades(userID string) ([]Trade, error) {
+	var result struct {
+		Trades []Trade `json:"trades"`
+		Count  int     `json:"count"`
+	}
+	if err := c.doJSON(http.MethodGet, "/api/trades?user_id="+url.QueryEscape(userID), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Trades, nil
+}
+
+func (c *APIClient) ConfirmTrade(userID, tradeID string) (*Trade, error) {
+	var trade Trade
+	err := c.doJSON(http.MethodPost, "/api/trades/"+tradeID+"/confirm", map[string]string{
+		"user_id": userID,
+	}, &trade)
+	if err != nil {
+		return nil, err
+	}
+
+	return &trade, nil
+}
+
+func (c *APIClient) CancelTrade(userID, tradeID string) error {
+	return c.doJSON(http.MethodPost, "/api/trades/"+tradeID+"/cancel", map[string]string{
+		"user_id": userID,
+	}, nil)
+}
+
+type ReleaseResult struct {
+	Released []struct {
+		Pokemon Pokemon `json:"pokemon"`
+		Payout  int     `json:"payout"`
+		Candy   int     `json:"candy"`
+	} `json:"released"`
+	Protected []struct {
+		Pokemon Pokemon `json:"pokemon"`
+		Reason  string  `json:"reason"`
+	} `json:"protected"`
+	Count       int  `json:"count"`
+	TotalPayout int  `json:"total_payout"`
+	DryRun      bool `json:"dry_run"`
+}
+
+// BulkSellFilter selects the Pokemon /release bulk sells
+type BulkSellFilter struct {
+	Rarity         string  `json:"rarity,omitempty"`
+	SpeciesID      int     `json:"species_id,omitempty"`
+	MaxIVPercent   float64 `json:"max_iv_percent,omitempty"`
+	KeepPerSpecies int     `json:"keep_per_species,omitempty"`
+}
+
+func (c *APIClient) ReleasePokemon(userID string, pokemonIDs []string, dryRun bool) (*ReleaseResult, error) {
+	var result ReleaseResult
+	err := c.doJSON(http.MethodPost, "/api/pokemon/release", map[string]interface{}{
+		"user_id":     userID,
+		"pokemon_ids": pokemonIDs,
+		"dry_run":     dryRun,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *APIClient) BulkSell(userID string, filter BulkSellFilter, dryRun bool) (*ReleaseResult, error) {
+	var result ReleaseResult
+	err := c.doJSON(http.MethodPost, "/api/pokemon/bulk-sell", map[string]interface{}{
+		"user_id":          userID,
+		"rarity":           filter.Rarity,
+		"species_id":       filter.SpeciesID,
+		"max_iv_percent":   filter.MaxIVPercent,
+		"keep_per_species": filter.KeepPerSpecies,
+		"dry_run":          dryRun,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+type CandyBalance struct {
+	SpeciesID int `json:"species_id"`
+	Amount    int `json:"amount"`
+}
+
+func (c *APIClient) GetCandy(userID string) ([]CandyBalance, error) {
+	var result struct {
+		Candy []CandyBalance `json:"candy"`
+	}
+	if err := c.doJSON(http.MethodGet, "/api/users/"+userID+"/candy", nil, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Candy, nil
+}
+
+func (c *APIClient) RerollIVs(userID, pokemonID string, stats []string) (*Pokemon, error) {
+	var pokemon Pokemon
+	err := c.doJSON(http.MethodPost, "/api/pokemon/"+pokemonID+"/reroll-ivs", map[string]interface{}{
+		"user_id": userID,
+		"stats":   stats,
+	}, &pokemon)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pokemon, nil
+}
+
+func (c *APIClient) RaiseIVs(userID, pokemonID string, stats []string, points int) (*Pokemon, error) {
+	var pokemon Pokemon
+	err := c.doJSON(http.MethodPost, "/api/pokemon/"+pokemonID+"/raise-ivs", map[string]interface{}{
+		"user_id": userID,
+		"stats":   stats,
+		"points":  points,
+	}, &pokemon)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pokemon, nil
+}
+
+func (c *APIClient) MintNature(userID, pokemonID, nature string) (*Pokemon, error) {
+	var pokemon Pokemon
+	err := c.doJSON(http.MethodPost, "/api/pokemon/"+pokemonID+"/mint", map[string]interface{}{
+		"user_id": userID,
+		"nature":  nature,
+	}, &pokemon)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pokemon, nil
+}
+
+type EVTraining struct {
+	Pokemon Pokemon `json:"pokemon"`
+	Cost    struct {
+		Coins    int            `json:"coins"`
+		Vitamins map[string]int `json:"vitamins"`
+	} `json:"cost"`
+}
+
+func (c *APIClient) TrainEVs(userID, pokemonID, spread, payment string) (*EVTraining, error) {
+	var result EVTraining
+	err := c.doJSON(http.MethodPost, "/api/pokemon/"+pokemonID+"/evs", map[string]interface{}{
+		"user_id": userID,
+		"spread":  spread,
+		"payment": payment,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+type FeaturedSpecies struct {
+	SpeciesID int     `json:"species_id"`
+	Name      string  `json:"name"`
+	Weight    float64 `json:"weight"`
+}
+
+type Banner struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	StartsAt    string             `json:"starts_at"`
+	EndsAt      *string            `json:"ends_at"`
+	SpeciesPool []int              `json:"species_pool"`
+	Featured    []FeaturedSpecies  `json:"featured"`
+	Rates       map[string]float64 `json:"rates"`
+}
+
+func (c *APIClient) ListBanners() ([]Banner, error) {
+	var result struct {
+		Banners []Banner `json:"banners"`
+		Count   int      `json:"count"`
+	}
+	if err := c.doJSON(http.MethodGet, "/api/banners", nil, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Banners, nil
+}
+
+type AbilityChange struct {
+	Pokemon         Pokemon `json:"pokemon"`
+	PreviousAbility string  `json:"previous_ability"`
+}
+
+func (c *APIClient) ChangeAbility(userID, pokemonID string, slot int) (*AbilityChange, error) {
+	var result AbilityChange
+	err := c.doJSON(http.MethodPost, "/api/pokemon/"+pokemonID+"/ability", map[string]interface{}{
+		"user_id": userID,
+		"slot":    slot,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+type StreakReward struct {
+	Day       int            `json:"day"`
+	Coins     int            `json:"coins"`
+	Items     map[string]int `json:"items"`
+	Pokemon   string         `json:"pokemon"`
+	Milestone bool           `json:"milestone"`
+}
+
+type StreakStatus struct {
+	Current       int           `json:"current"`
+	Longest       int           `json:"longest"`
+	TotalCheckIns int           `json:"total_check_ins"`
+	CheckedIn     bool          `json:"checked_in"`
+	NextCheckIn   string        `json:"next_check_in"`
+	BreaksAt      *string       `json:"breaks_at"`
+	NextReward    StreakReward  `json:"next_reward"`
+	NextMilestone *StreakReward `json:"next_milestone"`
+	Timezone      string        `json:"timezone"`
+}
+
+type CheckInResult struct {
+	Streak  StreakStatus `json:"streak"`
+	Reward  StreakReward `json:"reward"`
+	Pokemon *Pokemon     `json:"pokemon"`
+	Coins   int          `json:"coins"`
+}
+
+func (c *APIClient) GetStreak(userID string) (*StreakStatus, error) {
+	var result StreakStatus
+	if err := c.doJSON(http.MethodGet, "/api/users/"+userID+"/streak", nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *APIClient) CheckIn(userID string) (*CheckInResult, error) {
+	var result CheckInResult
+	if err := c.doJSON(http.MethodPost, "/api/users/"+userID+"/check-in", nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+type QuestReward struct {
+	Coins int            `json:"coins"`
+	Items map[string]int `json:"items"`
+}
+
+type Quest struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Period      string      `json:"period"`
+	Goal        int         `json:"goal"`
+	Reward      QuestReward `json:"reward"`
+	EndsAt      *string     `json:"ends_at"`
+	Progress    int         `json:"progress"`
+	Completed   bool        `json:"completed"`
+	Claimed     bool        `json:"claimed"`
+}
+
+type QuestClaim struct {
+	Quest          Quest       `json:"quest"`
+	Reward         QuestReward `json:"reward"`
+	AlreadyClaimed bool        `json:"already_claimed"`
+	Coins          int         `json:"coins"`
+}
+
+func (c *APIClient) ListQuests(userID string) ([]Quest, error) {
+	var result struct {
+		Quests []Quest `json:"quests"`
+		Count  int     `json:"count"`
+	}
+	if err := c.doJSON(http.MethodGet, "/api/users/"+userID+"/quests", nil, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Quests, nil
+}
+
+func (c *APIClient) ClaimQuest(userID, questID string) (*QuestClaim, error) {
+	var result QuestClaim
+	if err := c.doJSON(http.MethodPost, "/api/users/"+userID+"/quests/"+url.PathEscape(questID)+"/claim", nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+type PokedexCompletion struct {
+	Total   int     `json:"total"`
+	Seen    int     `json:"seen"`
+	Owned   int     `json:"owned"`
+	Percent float64 `json:"percent"`
+}
+
+type PokedexMilestone struct {
+	Owned   int            `json:"owned"`
+	Coins   int            `json:"coins"`
+	Items   map[string]int `json:"items"`
+	Reached bool           `json:"reached"`
+	Granted bool           `json:"granted"`
+}
+
+type PokedexSpecies struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Rarity string `json:"rarity"`
+	Seen   bool   `json:"seen"`
+	Owned  bool   `json:"owned"`
+}
+
+type Pokedex struct {
+	Overall       PokedexCompletion             `json:"overall"`
+	ByRarity      map[string]*PokedexCompletion `json:"by_rarity"`
+	ByType        map[string]*PokedexCompletion `json:"by_type"`
+	Milestones    []PokedexMilestone            `json:"milestones"`
+	NextMilestone *PokedexMilestone             `json:"next_milestone"`
+	Species       []PokedexSpecies              `json:"species"`
+}
+
+func (c *APIClient) GetPokedex(userID string) (*Pokedex, error) {
+	var result Pokedex
+	if err := c.doJSON(http.MethodGet, "/api/users/"+userID+"/pokedex", nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+type SpeciesAbility struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Description string `json:"description"`
+	Hidden      bool   `json:"hidden"`
+}
+
+type TypeMatchup struct {
+	Multipliers map[string]float64 `json:"multipliers"`
+	Weaknesses  []string           `json:"weaknesses"`
+	Resistances []string           `json:"resistances"`
+	Immunities  []string           `json:"immunities"`
+}
+
+type SpeciesDetails struct {
+	ID             int              `json:"id"`
+	Name           string           `json:"name"`
+	Type1          string           `json:"type1"`
+	Type2          string           `json:"type2"`
+	Rarity         string           `json:"rarity"`
+	BaseStats      Stats            `json:"base_stats"`
+	BaseStatTotal  int              `json:"base_stat_total"`
+	SpriteURL      string           `json:"sprite_url"`
+	ShinySpriteURL string           `json:"shiny_sprite_url"`
+	Matchups       TypeMatchup      `json:"matchups"`
+	Learnset       []Move           `json:"learnset"`
+	Abilities      []SpeciesAbility `json:"abilities"`
+}
+
+// GetSpecies looks up a catalog species by Pokedex number or name
+func (c *APIClient) GetSpecies(idOrName string) (*SpeciesDetails, error) {
+	var result SpeciesDetails
+	if err := c.doJSON(http.MethodGet, "/api/species/"+url.PathEscape(idOrName), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+type EvolutionOption struct {
+	Species    Species  `json:"species"`
+	CandyCost  int      `json:"candy_cost"`
+	CoinCost   int      `json:"coin_cost"`
+	Item       string   `json:"item"`
+	BattleWins int      `json:"battle_wins"`
+	Unmet      []string `json:"unmet"`
+	CanEvolve  bool     `json:"can_evolve"`
+}
+
+type Move struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Power int    `json:"power"`
+}
+
+type EvolveResult struct {
+	Pokemon       Pokemon `json:"pokemon"`
+	FromSpecies   Species `json:"from_species"`
+	UnlockedMoves []Move  `json:"unlocked_moves"`
+}
+
+func (c *APIClient) ListEvolutions(userID, pokemonID string) ([]EvolutionOption, error) {
+	var result struct {
+		Evolutions []EvolutionOption `json:"evolutions"`
+	}
+	if err := c.doJSON(http.MethodGet, "/api/pokemon/"+pokemonID+"/evolutions?user_id="+userID, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Evolutions, nil
+}
+
+func (c *APIClient) Evolve(userID, pokemonID string, speciesID int) (*EvolveResult, error) {
+	var result EvolveResult
+	err := c.doJSON(http.MethodPost, "/api/pokemon/"+pokemonID+"/evolve", map[string]interface{}{
+		"user_id":    userID,
+		"species_id": speciesID,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+type ExperienceGain struct {
+	Experience   int `json:"experience"`
+	Level        int `json:"level"`
+	LevelsGained int `json:"levels_gained"`
+}
+
+type WildBattleResult struct {
+	Pokemon    Pokemon        `json:"pokemon"`
+	Wild       Species        `json:"wild"`
+	WildLevel  int            `json:"wild_level"`
+	Format     string         `json:"format"`
+	Won        bool           `json:"won"`
+	Turns      int            `json:"turns"`
+	Experience ExperienceGain `json:"experience"`
+}
+
+func (c *APIClient) WildBattle(userID, pokemonID, format string) (*WildBattleResult, error) {
+	var result WildBattleResult
+	err := c.doJSON(http.MethodPost, "/api/pokemon/"+pokemonID+"/wild-battle", map[string]interface{}{
+		"user_id": userID,
+		"format":  format,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+type Notification struct {
+	ID          string  `json:"id"`
+	UserID      string  `json:"user_id"`
+	DiscordID   string  `json:"discord_id"`
+	Type        string  `json:"type"`
+	Title       string  `json:"title"`
+	Message     string  `json:"message"`
+	ReferenceID *string `json:"reference_id"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+func (c *APIClient) PendingNotifications() ([]Notification, error) {
+	var result struct {
+		Notifications []Notification `json:"notifications"`
+		Count         int            `json:"count"`
+	}
+	if err := c.doJSON(http.MethodGet, "/api/notifications/pending", nil, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Notifications, nil
+}
+
+func (c *APIClient) MarkNotificationDelivered(notificationID string) error {
+	return c.doJSON(http.MethodPost, "/api/notifications/"+notificationID+"/delivered", nil, nil)
+}
+
+// doJSON sends a request with an optional JSON body and decodes the response data into out
+func (c *APIClient) doJSON(method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		reqBody, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewBuffer(reqBody)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var apiResp APIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return err
+	}
+
+	if !apiResp.Success {
+		return fmt.Errorf("%s: %s", apiResp.Error.Code, apiResp.Error.Message)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(apiResp.Data, out)
+}
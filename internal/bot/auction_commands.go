@@ -0,0 +1,227 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// auctionCommand defines /auction and its subcommands
+var auctionCommand = &discordgo.ApplicationCommand{
+	Name:        "auction",
+	Description: "Auction Pokemon to the highest bidder",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "browse",
+			Description: "View auctions ending soonest",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "start",
+			Description: "Auction one of your Pokemon",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "pokemon_id",
+					Description: "ID of the Pokemon (shown in /box)",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "starting_price",
+					Description: "Lowest first bid in coins",
+					Required:    true,
+					MinValue:    func() *float64 { v := 1.0; return &v }(),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "minutes",
+					Description: "How long the auction runs (5 minutes to 7 days)",
+					Required:    true,
+					MinValue:    func() *float64 { v := 5.0; return &v }(),
+					MaxValue:    7 * 24 * 60,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "min_increment",
+					Description: "Smallest raise over the current bid (default 10 coins)",
+					MinValue:    func() *float64 { v := 1.0; return &v }(),
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "bid",
+			Description: "Bid on an auction",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "auction_id",
+					Description: "ID of the auction",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "amount",
+					Description: "Your bid in coins (held until you're outbid)",
+					Required:    true,
+					MinValue:    func() *float64 { v := 1.0; return &v }(),
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "cancel",
+			Description: "Cancel one of your auctions (only before the first bid)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "auction_id",
+					Description: "ID of the auction",
+					Required:    true,
+				},
+			},
+		},
+	},
+}
+
+// defaultBidIncrement is used when /auction start is not given a minimum increment
+const defaultBidIncrement = 10
+
+// handleAuction handles the /auction command
+func (b *Bot) handleAuction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	discordID := i.Member.User.ID
+
+	// Get user
+	user, err := b.apiClient.GetOrCreateUser(discordID)
+	if err != nil {
+		b.sendError(s, i, "Failed to get user: "+err.Error())
+		return
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := optionMap(subcommand.Options)
+
+	switch subcommand.Name {
+	case "browse":
+		b.sendAuctions(s, i)
+	case "start":
+		increment := defaultBidIncrement
+		if opt, ok := options["min_increment"]; ok {
+			increment = int(opt.IntValue())
+		}
+
+		auction, err := b.apiClient.CreateAuction(
+			user.ID,
+			options["pokemon_id"].StringValue(),
+			int(options["starting_price"].IntValue()),
+			increment,
+			int(options["minutes"].IntValue()),
+		)
+		if err != nil {
+			b.sendError(s, i, "❌ Failed to start auction: "+err.Error())
+			return
+		}
+
+		species := auction.Listing.Pokemon.Species
+		b.sendEmbed(s, i, &discordgo.MessageEmbed{
+			Title: "🔨 Auction Started!",
+			Description: fmt.Sprintf(
+				"%s **%s** is up for auction starting at **%d coins** (+%d per bid).\nEnds %s\n**Auction ID:** `%s`",
+				getRarityEmoji(species.Rarity), species.Name,
+				auction.StartingPrice, auction.MinIncrement, discordTimestamp(auction.EndsAt), auction.Listing.ID,
+			),
+			Color: 0xe67e22,
+		})
+	case "bid":
+		auction, err := b.apiClient.PlaceBid(user.ID, options["auction_id"].StringValue(), int(options["amount"].IntValue()))
+		if err != nil {
+			if strings.Contains(err.Error(), "insufficient") {
+				b.sendError(s, i, "❌ You don't have enough coins for this bid.")
+			} else {
+				b.sendError(s, i, "❌ Failed to place bid: "+err.Error())
+			}
+			return
+		}
+
+		b.sendEmbed(s, i, &discordgo.MessageEmbed{
+			Title: "💸 Bid Placed!",
+			Description: fmt.Sprintf(
+				"You're the highest bidder on **%s** at **%d coins**. Your coins are held until you're outbid or the auction ends %s.",
+				auction.Listing.Pokemon.Species.Name, auction.CurrentBid, discordTimestamp(auction.EndsAt),
+			),
+			Color: 0x00ff00,
+		})
+	case "cancel":
+		if err := b.apiClient.CancelAuction(user.ID, options["auction_id"].StringValue()); err != nil {
+			b.sendError(s, i, "❌ Failed to cancel auction: "+err.Error())
+			return
+		}
+		b.sendEmbed(s, i, &discordgo.MessageEmbed{
+			Title:       "🚫 Auction Cancelled",
+			Description: "Your Pokemon has been taken off the market.",
+			Color:       0x95a5a6,
+		})
+	}
+}
+
+// sendAuctions shows the auctions ending soonest
+func (b *Bot) sendAuctions(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	auctions, err := b.apiClient.ListAuctions()
+	if err != nil {
+		b.sendError(s, i, "Failed to get auctions: "+err.Error())
+		return
+	}
+
+	if len(auctions) == 0 {
+		b.sendError(s, i, "No auctions are running right now.")
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:  "🔨 Auctions Ending Soon",
+		Color:  0xe67e22,
+		Fields: make([]*discordgo.MessageEmbedField, 0),
+	}
+
+	limit := 10
+	if len(auctions) < limit {
+		limit = len(auctions)
+	}
+
+	for _, a := range auctions[:limit] {
+		p := a.Listing.Pokemon
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: fmt.Sprintf("%s %s — next bid %d coins", getRarityEmoji(p.Species.Rarity), p.Species.Name, a.MinimumBid),
+			Value: fmt.Sprintf(
+				"**IVs:** %.1f%% | **Bids:** %d | Ends %s\n**Auction ID:** `%s`",
+				p.IVPercentage, a.BidCount, discordTimestamp(a.EndsAt), a.Listing.ID,
+			),
+			Inline: false,
+		})
+	}
+
+	if len(auctions) > limit {
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Showing %d of %d auctions", limit, len(auctions)),
+		}
+	}
+
+	b.sendEmbed(s, i, embed)
+}
+
+// discordTimestamp formats an RFC 3339 time as a relative Discord timestamp
+func discordTimestamp(value string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return fmt.Sprintf("<t:%d:R>", t.Unix())
+}
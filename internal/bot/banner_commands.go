@@ -0,0 +1,101 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// bannerRarities orders rates on a banner, rarest first
+var bannerRarities = []string{"mythic", "legendary", "epic", "rare", "uncommon", "common"}
+
+// bannersCommand defines /banners
+var bannersCommand = &discordgo.ApplicationCommand{
+	Name:        "banners",
+	Description: "List the gacha banners running now",
+}
+
+// handleBanners handles the /banners command
+func (b *Bot) handleBanners(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	banners, err := b.apiClient.ListBanners()
+	if err != nil {
+		b.sendError(s, i, "Failed to list banners: "+err.Error())
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:  "🎰 Active Banners",
+		Color:  0xe91e63,
+		Fields: make([]*discordgo.MessageEmbedField, 0, len(banners)),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Roll on a banner with /roll banner:<id>",
+		},
+	}
+
+	if len(banners) == 0 {
+		embed.Description = "No banners are running right now. /roll uses the standard pool."
+	}
+
+	for _, banner := range banners {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%s (`%s`)", banner.Name, banner.ID),
+			Value: bannerSummary(banner),
+		})
+	}
+
+	b.sendEmbed(s, i, embed)
+}
+
+// pityFooter describes progress toward each pity guarantee, or is empty
+// when the API left pity out
+func pityFooter(pity *Pity) string {
+	if pity == nil {
+		return ""
+	}
+
+	parts := make([]string, len(pity.Progress))
+	for i, progress := range pity.Progress {
+		parts[i] = fmt.Sprintf("%s %s %d/%d", getRarityEmoji(progress.Rarity),
+			strings.Title(progress.Rarity), progress.Pulls, progress.HardPity)
+	}
+	return "Pity: " + strings.Join(parts, " · ")
+}
+
+// bannerSummary describes a banner's schedule, rate-ups and rates
+func bannerSummary(banner Banner) string {
+	var lines []string
+	if banner.Description != "" {
+		lines = append(lines, banner.Description)
+	}
+
+	if banner.EndsAt != nil {
+		lines = append(lines, "**Ends:** "+discordTimestamp(*banner.EndsAt))
+	}
+
+	if len(banner.Featured) > 0 {
+		featured := make([]string, len(banner.Featured))
+		for i, species := range banner.Featured {
+			featured[i] = fmt.Sprintf("%s (%gx)", species.Name, species.Weight)
+		}
+		lines = append(lines, "**Rate-up:** "+strings.Join(featured, ", "))
+	}
+
+	if len(banner.SpeciesPool) > 0 {
+		lines = append(lines, fmt.Sprintf("**Pool:** %d species", len(banner.SpeciesPool)))
+	}
+
+	var rates []string
+	for _, rarity := range bannerRarities {
+		if rate, ok := banner.Rates[rarity]; ok && rate > 0 {
+			rates = append(rates, fmt.Sprintf("%s %.1f%%", getRarityEmoji(rarity), rate*100))
+		}
+	}
+	lines = append(lines, "**Rates:** "+strings.Join(rates, " "))
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,169 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ivStatChoices are the stats /candy reroll and raise can target
+var ivStatChoices = []*discordgo.ApplicationCommandOptionChoice{
+	{Name: "HP", Value: "hp"},
+	{Name: "Attack", Value: "attack"},
+	{Name: "Defense", Value: "defense"},
+	{Name: "Sp. Attack", Value: "sp_attack"},
+	{Name: "Sp. Defense", Value: "sp_defense"},
+	{Name: "Speed", Value: "speed"},
+}
+
+// candyCommand defines /candy and its subcommands
+var candyCommand = &discordgo.ApplicationCommand{
+	Name:        "candy",
+	Description: "Spend species candy from released duplicates",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "balance",
+			Description: "Show your candy for each species",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "reroll",
+			Description: "Re-roll one IV of a Pokemon (10 candy)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "pokemon_id",
+					Description: "ID of the Pokemon (shown in /box)",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "stat",
+					Description: "IV to re-roll",
+					Required:    true,
+					Choices:     ivStatChoices,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "raise",
+			Description: "Raise one IV of a Pokemon (3 candy per point)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "pokemon_id",
+					Description: "ID of the Pokemon (shown in /box)",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "stat",
+					Description: "IV to raise",
+					Required:    true,
+					Choices:     ivStatChoices,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "points",
+					Description: "Points to add, up to a maximum IV of 31",
+					Required:    true,
+					MinValue:    func() *float64 { v := 1.0; return &v }(),
+					MaxValue:    31,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "mint",
+			Description: "Change a Pokemon's nature (25 candy)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "pokemon_id",
+					Description: "ID of the Pokemon (shown in /box)",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "nature",
+					Description: "New nature, e.g. adamant or timid",
+					Required:    true,
+				},
+			},
+		},
+	},
+}
+
+// handleCandy handles the /candy command
+func (b *Bot) handleCandy(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	user, err := b.apiClient.GetOrCreateUser(interactionUserID(i))
+	if err != nil {
+		b.sendError(s, i, "Failed to get user: "+err.Error())
+		return
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := optionMap(subcommand.Options)
+
+	if subcommand.Name == "balance" {
+		b.sendCandyBalance(s, i, user.ID)
+		return
+	}
+
+	pokemonID := options["pokemon_id"].StringValue()
+	var pokemon *Pokemon
+	var title string
+	switch subcommand.Name {
+	case "reroll":
+		pokemon, err = b.apiClient.RerollIVs(user.ID, pokemonID, []string{options["stat"].StringValue()})
+		title = "🎲 IV Re-rolled"
+	case "raise":
+		pokemon, err = b.apiClient.RaiseIVs(user.ID, pokemonID, []string{options["stat"].StringValue()}, int(options["points"].IntValue()))
+		title = "📈 IV Raised"
+	case "mint":
+		pokemon, err = b.apiClient.MintNature(user.ID, pokemonID, strings.ToLower(options["nature"].StringValue()))
+		title = "🌿 Nature Changed"
+	}
+	if err != nil {
+		b.sendError(s, i, "❌ Failed to use candy: "+err.Error())
+		return
+	}
+
+	b.sendEmbed(s, i, &discordgo.MessageEmbed{
+		Title: title,
+		Description: fmt.Sprintf("%s **%s** · %s nature · %.1f%% IV\nHP %d / Atk %d / Def %d / SpA %d / SpD %d / Spe %d",
+			getRarityEmoji(pokemon.Species.Rarity), pokemon.Species.Name, pokemon.Nature, pokemon.IVPercentage,
+			pokemon.IVs.HP, pokemon.IVs.Attack, pokemon.IVs.Defense, pokemon.IVs.SpAttack, pokemon.IVs.SpDefense, pokemon.IVs.Speed),
+		Color: 0x00ff00,
+	})
+}
+
+// sendCandyBalance lists a user's candy by species
+func (b *Bot) sendCandyBalance(s *discordgo.Session, i *discordgo.InteractionCreate, userID string) {
+	balances, err := b.apiClient.GetCandy(userID)
+	if err != nil {
+		b.sendError(s, i, "❌ Failed to get candy: "+err.Error())
+		return
+	}
+
+	lines := make([]string, len(balances))
+	for n, balance := range balances {
+		lines[n] = fmt.Sprintf("🍬 **#%d**: %d candy", balance.SpeciesID, balance.Amount)
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "No candy yet. Release duplicates with /release to earn some.")
+	}
+
+	b.sendEmbed(s, i, &discordgo.MessageEmbed{
+		Title:       "🍬 Your Candy",
+		Description: strings.Join(lines, "\n"),
+		Color:       0xf1c40f,
+	})
+}
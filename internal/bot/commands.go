@@ -42,6 +42,12 @@ func (b *Bot) RegisterCommands() error {
 					MinValue:    func() *float64 { v := 1.0; return &v }(),
 					MaxValue:    10.0,
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "banner",
+					Description: "Banner to roll on (see /banners), the standard pool if empty",
+					Required:    false,
+				},
 			},
 		},
 		{
@@ -66,8 +72,35 @@ func (b *Bot) RegisterCommands() error {
 						{Name: "Common", Value: "common"},
 					},
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "sort",
+					Description: "Sort order (newest first by default)",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Newest", Value: "acquired"},
+						{Name: "IVs", Value: "iv"},
+						{Name: "Value", Value: "value"},
+						{Name: "Total Stats", Value: "stats"},
+					},
+				},
 			},
 		},
+		marketCommand,
+		auctionCommand,
+		tradeCommand,
+		releaseCommand,
+		candyCommand,
+		evolveCommand,
+		wildCommand,
+		trainCommand,
+		abilityCommand,
+		bannersCommand,
+		streakCommand,
+		checkInCommand,
+		questsCommand,
+		dexCommand,
+		pokedexCommand,
 	}
 
 	for _, cmd := range commands {
@@ -101,8 +134,13 @@ func (b *Bot) Stop() error {
 	return b.session.Close()
 }
 
-// handleInteraction handles slash command interactions
+// handleInteraction handles slash command and button interactions
 func (b *Bot) handleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type == discordgo.InteractionMessageComponent {
+		b.handleTradeButton(s, i)
+		return
+	}
+
 	if i.Type != discordgo.InteractionApplicationCommand {
 		return
 	}
@@ -116,6 +154,36 @@ func (b *Bot) handleInteraction(s *discordgo.Session, i *discordgo.InteractionCr
 		b.handleBalance(s, i)
 	case "box":
 		b.handleBox(s, i)
+	case "market":
+		b.handleMarket(s, i)
+	case "auction":
+		b.handleAuction(s, i)
+	case "trade":
+		b.handleTrade(s, i)
+	case "release":
+		b.handleRelease(s, i)
+	case "candy":
+		b.handleCandy(s, i)
+	case "evolve":
+		b.handleEvolve(s, i)
+	case "wild":
+		b.handleWild(s, i)
+	case "train":
+		b.handleTrain(s, i)
+	case "ability":
+		b.handleAbility(s, i)
+	case "banners":
+		b.handleBanners(s, i)
+	case "streak":
+		b.handleStreak(s, i)
+	case "checkin":
+		b.handleCheckIn(s, i)
+	case "quests":
+		b.handleQuests(s, i)
+	case "dex":
+		b.handleDex(s, i)
+	case "pokedex":
+		b.handlePokedex(s, i)
 	}
 }
 
@@ -135,7 +203,7 @@ func (b *Bot) handleDaily(s *discordgo.Session, i *discordgo.InteractionCreate)
 	}
 
 	// Perform daily roll
-	pokemons, err := b.apiClient.DailyRoll(user.ID)
+	pokemons, err := b.apiClient.DailyRoll(user.ID, i.ID)
 	if err != nil {
 		if strings.Contains(err.Error(), "cooldown") {
 			b.sendError(s, i, "⏰ You've already claimed your daily roll! Come back tomorrow.")
@@ -156,10 +224,10 @@ func (b *Bot) handleDaily(s *discordgo.Session, i *discordgo.InteractionCreate)
 	for i, p := range pokemons {
 		rarityEmoji := getRarityEmoji(p.Species.Rarity)
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name: fmt.Sprintf("%d. %s %s", i+1, rarityEmoji, p.Species.Name),
+			Name: fmt.Sprintf("%d. %s %s", i+1, rarityEmoji, pokemonLabel(p)),
 			Value: fmt.Sprintf(
-				"**Nature:** %s\n**IVs:** %.1f%% perfect\n**Value:** %d coins",
-				p.Nature, p.IVPercentage, p.EstimatedValue,
+				"**Nature:** %s\n**Ability:** %s\n**IVs:** %.1f%% perfect\n**Value:** %d coins",
+				p.Nature, abilityLabel(p), p.IVPercentage, p.EstimatedValue,
 			),
 			Inline: false,
 		})
@@ -178,8 +246,12 @@ func (b *Bot) handleRoll(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	})
 
 	discordID := i.Member.User.ID
-	options := i.ApplicationCommandData().Options
-	count := int(options[0].IntValue())
+	options := optionMap(i.ApplicationCommandData().Options)
+	count := int(options["count"].IntValue())
+	bannerID := ""
+	if option, ok := options["banner"]; ok {
+		bannerID = option.StringValue()
+	}
 
 	// Get user
 	user, err := b.apiClient.GetOrCreateUser(discordID)
@@ -196,11 +268,12 @@ func (b *Bot) handleRoll(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	}
 
 	// Perform premium roll
-	pokemons, err := b.apiClient.PremiumRoll(user.ID, count)
+	result, err := b.apiClient.PremiumRoll(user.ID, count, bannerID)
 	if err != nil {
 		b.sendError(s, i, "Failed to roll: "+err.Error())
 		return
 	}
+	pokemons := result.Pokemons
 
 	// Build embed
 	embed := &discordgo.MessageEmbed{
@@ -213,19 +286,21 @@ func (b *Bot) handleRoll(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	for i, p := range pokemons {
 		rarityEmoji := getRarityEmoji(p.Species.Rarity)
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name: fmt.Sprintf("%d. %s %s", i+1, rarityEmoji, p.Species.Name),
+			Name: fmt.Sprintf("%d. %s %s", i+1, rarityEmoji, pokemonLabel(p)),
 			Value: fmt.Sprintf(
-				"**Nature:** %s | **IVs:** %.1f%%\n**Value:** %d coins",
-				p.Nature, p.IVPercentage, p.EstimatedValue,
+				"**Nature:** %s | **IVs:** %.1f%%\n**Ability:** %s\n**Value:** %d coins",
+				p.Nature, p.IVPercentage, abilityLabel(p), p.EstimatedValue,
 			),
 			Inline: true,
 		})
 	}
 
+	footer := pityFooter(result.Pity)
 	if count >= 10 {
-		embed.Footer = &discordgo.MessageEmbedFooter{
-			Text: "🎁 10-roll bonus: Guaranteed Epic or better!",
-		}
+		footer = "🎁 10-roll bonus: Guaranteed Epic or better!\n" + footer
+	}
+	if footer != "" {
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: footer}
 	}
 
 	s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
@@ -265,6 +340,12 @@ func (b *Bot) handleBalance(s *discordgo.Session, i *discordgo.InteractionCreate
 			},
 		},
 	}
+	if streak, err := b.apiClient.GetStreak(user.ID); err == nil {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "🔥 Daily Streak",
+			Value: streakSummary(streak),
+		})
+	}
 
 	s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
 		Embeds: &[]*discordgo.MessageEmbed{embed},
@@ -286,98 +367,94 @@ func (b *Bot) handleBox(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		return
 	}
 
-	// Get Pokemon collection
-	pokemons, err := b.apiClient.GetUserPokemon(user.ID)
+	// Filter and sort on the server so the page is drawn from the whole collection
+	search := BoxSearch{Limit: boxPageSize}
+	options := optionMap(i.ApplicationCommandData().Options)
+	if opt, ok := options["rarity"]; ok {
+		search.Rarity = opt.StringValue()
+	}
+	if opt, ok := options["sort"]; ok {
+		search.Sort = opt.StringValue()
+	}
+
+	page, err := b.apiClient.GetUserBox(user.ID, search)
 	if err != nil {
 		b.sendError(s, i, "Failed to get Pokemon: "+err.Error())
 		return
 	}
 
-	// Filter by rarity if specified
-	var rarityFilter string
-	if len(i.ApplicationCommandData().Options) > 0 {
-		rarityFilter = i.ApplicationCommandData().Options[0].StringValue()
-	}
-
-	filtered := pokemons
-	if rarityFilter != "" {
-		filtered = make([]Pokemon, 0)
-		for _, p := range pokemons {
-			if p.Species.Rarity == rarityFilter {
-				filtered = append(filtered, p)
-			}
+	if page.Total == 0 {
+		if search.Rarity != "" {
+			b.sendError(s, i, fmt.Sprintf("You don't have any %s Pokemon.", search.Rarity))
+			return
 		}
-	}
-
-	if len(filtered) == 0 {
 		b.sendError(s, i, "You don't have any Pokemon in your collection yet! Use `/daily` to get started.")
 		return
 	}
 
-	// Calculate total value
-	totalValue := 0
-	rarityCounts := make(map[string]int)
-	for _, p := range pokemons {
-		totalValue += p.EstimatedValue
-		rarityCounts[p.Species.Rarity]++
-	}
+	embed := boxEmbed(page, search)
 
-	// Build embed
+	s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	})
+}
+
+// boxPageSize is how many Pokemon /box and !box show
+const boxPageSize = 10
+
+// boxSortLabels names the box sorts for embed titles
+var boxSortLabels = map[string]string{
+	"acquired": "newest first",
+	"iv":       "by IVs",
+	"value":    "by value",
+	"stats":    "by total stats",
+}
+
+// boxEmbed builds the embed for a page of a user's collection
+func boxEmbed(page *BoxPage, search BoxSearch) *discordgo.MessageEmbed {
 	title := "📦 Your Pokemon Collection"
-	if rarityFilter != "" {
-		title = fmt.Sprintf("📦 Your %s Pokemon", strings.Title(rarityFilter))
+	if search.Rarity != "" {
+		title = fmt.Sprintf("📦 Your %s Pokemon", strings.Title(search.Rarity))
 	}
 
-	embed := &discordgo.MessageEmbed{
-		Title:       title,
-		Description: fmt.Sprintf("**Total Pokemon:** %d\n**Collection Value:** %d coins", len(pokemons), totalValue),
-		Color:       0x3498db,
-		Fields:      make([]*discordgo.MessageEmbedField, 0),
+	sortLabel := boxSortLabels["acquired"]
+	if label, ok := boxSortLabels[search.Sort]; ok {
+		sortLabel = label
 	}
 
-	// Show rarity breakdown
-	rarityBreakdown := ""
-	for _, rarity := range []string{"mythic", "legendary", "epic", "rare", "uncommon", "common"} {
-		if count := rarityCounts[rarity]; count > 0 {
-			rarityBreakdown += fmt.Sprintf("%s **%s**: %d\n", getRarityEmoji(rarity), strings.Title(rarity), count)
+	marketValued := 0
+	for _, p := range page.Pokemons {
+		if p.ValueSource == "market" {
+			marketValued++
 		}
 	}
-	if rarityBreakdown != "" {
-		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   "📊 Rarity Breakdown",
-			Value:  rarityBreakdown,
-			Inline: false,
-		})
-	}
 
-	// Show top Pokemon (limit to 10)
-	limit := 10
-	if len(filtered) < limit {
-		limit = len(filtered)
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: fmt.Sprintf("**Total Pokemon:** %d\n**Sorted:** %s", page.Total, sortLabel),
+		Color:       0x3498db,
+		Fields:      make([]*discordgo.MessageEmbedField, 0, len(page.Pokemons)),
 	}
 
-	for i := 0; i < limit; i++ {
-		p := filtered[i]
+	for _, p := range page.Pokemons {
 		rarityEmoji := getRarityEmoji(p.Species.Rarity)
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name: fmt.Sprintf("%s %s", rarityEmoji, p.Species.Name),
+			Name: fmt.Sprintf("%s %s", rarityEmoji, pokemonLabel(p)),
 			Value: fmt.Sprintf(
-				"**Nature:** %s\n**IVs:** %.1f%%\n**Value:** %d coins",
-				p.Nature, p.IVPercentage, p.EstimatedValue,
+				"**Nature:** %s\n**Ability:** %s\n**IVs:** %.1f%%\n**Value:** %d coins\n**ID:** `%s`",
+				p.Nature, abilityLabel(p), p.IVPercentage, p.EstimatedValue, p.ID,
 			),
 			Inline: true,
 		})
 	}
 
-	if len(filtered) > limit {
-		embed.Footer = &discordgo.MessageEmbedFooter{
-			Text: fmt.Sprintf("Showing %d of %d Pokemon", limit, len(filtered)),
-		}
+	footer := fmt.Sprintf("%d of %d valued from recent sales", marketValued, len(page.Pokemons))
+	if page.Total > len(page.Pokemons) {
+		footer = fmt.Sprintf("Showing %d of %d Pokemon • %s", len(page.Pokemons), page.Total, footer)
 	}
+	embed.Footer = &discordgo.MessageEmbedFooter{Text: footer}
 
-	s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-		Embeds: &[]*discordgo.MessageEmbed{embed},
-	})
+	return embed
 }
 
 // sendError sends an error message
@@ -387,6 +464,37 @@ func (b *Bot) sendError(s *discordgo.Session, i *discordgo.InteractionCreate, me
 	})
 }
 
+// pokemonLabel names a Pokemon with its form, a sparkle when shiny and its
+// gender sign, e.g. "✨ Alola Raichu ♀"
+func pokemonLabel(p Pokemon) string {
+	label := p.Species.Name
+	if p.Form != nil {
+		label = strings.Title(p.Form.Name) + " " + label
+	}
+	if p.IsShiny {
+		label = "✨ " + label
+	}
+	switch p.Gender {
+	case "male":
+		label += " ♂"
+	case "female":
+		label += " ♀"
+	}
+	return label
+}
+
+// abilityLabel names a Pokemon's ability, e.g. "Thick Fat (Hidden)"
+func abilityLabel(p Pokemon) string {
+	if p.Ability == "" {
+		return "None"
+	}
+	label := strings.Title(strings.ReplaceAll(p.Ability, "_", " "))
+	if p.HiddenAbility {
+		label += " (Hidden)"
+	}
+	return label
+}
+
 // getRarityEmoji returns the emoji for a rarity
 func getRarityEmoji(rarity string) string {
 	switch rarity {
@@ -0,0 +1,137 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// evolveCommand defines /evolve and its subcommands
+var evolveCommand = &discordgo.ApplicationCommand{
+	Name:        "evolve",
+	Description: "Evolve your Pokemon",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "check",
+			Description: "Show what a Pokemon can evolve into and what is missing",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "pokemon_id",
+					Description: "ID of the Pokemon (shown in /box)",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "pokemon",
+			Description: "Evolve a Pokemon, paying its candy, coins and item",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "pokemon_id",
+					Description: "ID of the Pokemon (shown in /box)",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "species_id",
+					Description: "Species to evolve into, when there are several",
+					Required:    false,
+				},
+			},
+		},
+	},
+}
+
+// handleEvolve handles the /evolve command
+func (b *Bot) handleEvolve(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	user, err := b.apiClient.GetOrCreateUser(interactionUserID(i))
+	if err != nil {
+		b.sendError(s, i, "Failed to get user: "+err.Error())
+		return
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := optionMap(subcommand.Options)
+	pokemonID := options["pokemon_id"].StringValue()
+
+	if subcommand.Name == "check" {
+		b.sendEvolutions(s, i, user.ID, pokemonID)
+		return
+	}
+
+	speciesID := 0
+	if opt, ok := options["species_id"]; ok {
+		speciesID = int(opt.IntValue())
+	}
+
+	result, err := b.apiClient.Evolve(user.ID, pokemonID, speciesID)
+	if err != nil {
+		b.sendError(s, i, "❌ Failed to evolve: "+err.Error())
+		return
+	}
+
+	description := fmt.Sprintf("%s **%s** evolved into %s **%s**!",
+		getRarityEmoji(result.FromSpecies.Rarity), result.FromSpecies.Name,
+		getRarityEmoji(result.Pokemon.Species.Rarity), result.Pokemon.Species.Name)
+	if len(result.UnlockedMoves) > 0 {
+		names := make([]string, len(result.UnlockedMoves))
+		for n, move := range result.UnlockedMoves {
+			names[n] = move.Name
+		}
+		description += "\nNew moves: " + strings.Join(names, ", ")
+	}
+
+	b.sendEmbed(s, i, &discordgo.MessageEmbed{
+		Title:       "✨ Evolution!",
+		Description: description,
+		Color:       0x9b59b6,
+	})
+}
+
+// sendEvolutions lists a Pokemon's evolutions with their requirements
+func (b *Bot) sendEvolutions(s *discordgo.Session, i *discordgo.InteractionCreate, userID, pokemonID string) {
+	evolutions, err := b.apiClient.ListEvolutions(userID, pokemonID)
+	if err != nil {
+		b.sendError(s, i, "❌ Failed to get evolutions: "+err.Error())
+		return
+	}
+
+	lines := make([]string, len(evolutions))
+	for n, e := range evolutions {
+		costs := []string{fmt.Sprintf("%d 🍬", e.CandyCost)}
+		if e.CoinCost > 0 {
+			costs = append(costs, fmt.Sprintf("%d coins", e.CoinCost))
+		}
+		if e.Item != "" {
+			costs = append(costs, e.Item)
+		}
+		if e.BattleWins > 0 {
+			costs = append(costs, fmt.Sprintf("%d battle wins", e.BattleWins))
+		}
+
+		status := "✅ ready"
+		if !e.CanEvolve {
+			status = "❌ missing " + strings.Join(e.Unmet, ", ")
+		}
+		lines[n] = fmt.Sprintf("%s **%s** (#%d): %s · %s",
+			getRarityEmoji(e.Species.Rarity), e.Species.Name, e.Species.ID, strings.Join(costs, ", "), status)
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "This Pokemon does not evolve.")
+	}
+
+	b.sendEmbed(s, i, &discordgo.MessageEmbed{
+		Title:       "🧬 Evolutions",
+		Description: strings.Join(lines, "\n"),
+		Color:       0x9b59b6,
+	})
+}
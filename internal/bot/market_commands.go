@@ -0,0 +1,303 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// marketCommand defines /market and its subcommands
+var marketCommand = &discordgo.ApplicationCommand{
+	Name:        "market",
+	Description: "Buy and sell Pokemon with other players",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "search",
+			Description: "Search active listings",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "species",
+					Description: "Species name or Pokedex number",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "rarity",
+					Description: "Filter by rarity",
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Mythic", Value: "mythic"},
+						{Name: "Legendary", Value: "legendary"},
+						{Name: "Epic", Value: "epic"},
+						{Name: "Rare", Value: "rare"},
+						{Name: "Uncommon", Value: "uncommon"},
+						{Name: "Common", Value: "common"},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionNumber,
+					Name:        "min_iv",
+					Description: "Minimum IV percentage",
+					MinValue:    func() *float64 { v := 0.0; return &v }(),
+					MaxValue:    100.0,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "max_price",
+					Description: "Maximum price in coins",
+					MinValue:    func() *float64 { v := 1.0; return &v }(),
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "List one of your Pokemon for sale",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "pokemon_id",
+					Description: "ID of the Pokemon (shown in /box)",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "price",
+					Description: "Price in coins",
+					Required:    true,
+					MinValue:    func() *float64 { v := 1.0; return &v }(),
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "buy",
+			Description: "Buy a listing",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "listing_id",
+					Description: "ID of the listing",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "cancel",
+			Description: "Take one of your listings off the market",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "listing_id",
+					Description: "ID of the listing",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "mine",
+			Description: "View your active listings",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "price",
+			Description: "View a species' market value and recent sales",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "species_id",
+					Description: "Pokedex number",
+					Required:    true,
+					MinValue:    func() *float64 { v := 1.0; return &v }(),
+				},
+			},
+		},
+	},
+}
+
+// priceHistoryDays is how many days of sales /market price shows
+const priceHistoryDays = 7
+
+// handleMarket handles the /market command
+func (b *Bot) handleMarket(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	discordID := i.Member.User.ID
+
+	// Get user
+	user, err := b.apiClient.GetOrCreateUser(discordID)
+	if err != nil {
+		b.sendError(s, i, "Failed to get user: "+err.Error())
+		return
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := optionMap(subcommand.Options)
+
+	switch subcommand.Name {
+	case "search":
+		search := MarketSearch{}
+		if opt, ok := options["species"]; ok {
+			search.Species = opt.StringValue()
+		}
+		if opt, ok := options["rarity"]; ok {
+			search.Rarity = opt.StringValue()
+		}
+		if opt, ok := options["min_iv"]; ok {
+			search.MinIV = opt.FloatValue()
+		}
+		if opt, ok := options["max_price"]; ok {
+			search.MaxPrice = int(opt.IntValue())
+		}
+		b.sendListings(s, i, "🛒 Market Listings", search)
+	case "mine":
+		b.sendListings(s, i, "🏷️ Your Listings", MarketSearch{SellerID: user.ID})
+	case "price":
+		b.sendPrices(s, i, int(options["species_id"].IntValue()))
+	case "list":
+		listing, err := b.apiClient.CreateListing(user.ID, options["pokemon_id"].StringValue(), int(options["price"].IntValue()))
+		if err != nil {
+			b.sendError(s, i, "❌ Failed to list Pokemon: "+err.Error())
+			return
+		}
+		b.sendEmbed(s, i, &discordgo.MessageEmbed{
+			Title: "🏷️ Pokemon Listed!",
+			Description: fmt.Sprintf(
+				"%s **%s** is on the market for **%d coins**.\nYou'll receive **%d coins** after the %d coin fee.\n**Listing ID:** `%s`",
+				getRarityEmoji(listing.Pokemon.Species.Rarity), listing.Pokemon.Species.Name,
+				listing.Price, listing.Price-listing.Fee, listing.Fee, listing.ID,
+			),
+			Color: 0x3498db,
+		})
+	case "buy":
+		transaction, err := b.apiClient.BuyListing(user.ID, options["listing_id"].StringValue())
+		if err != nil {
+			if strings.Contains(err.Error(), "insufficient") {
+				b.sendError(s, i, "❌ You don't have enough coins for this listing.")
+			} else {
+				b.sendError(s, i, "❌ Failed to buy listing: "+err.Error())
+			}
+			return
+		}
+		b.sendEmbed(s, i, &discordgo.MessageEmbed{
+			Title:       "🤝 Purchase Complete!",
+			Description: fmt.Sprintf("You paid **%d coins**. The Pokemon is now in your `/box`.", transaction.Price),
+			Color:       0x00ff00,
+		})
+	case "cancel":
+		if err := b.apiClient.CancelListing(user.ID, options["listing_id"].StringValue()); err != nil {
+			b.sendError(s, i, "❌ Failed to cancel listing: "+err.Error())
+			return
+		}
+		b.sendEmbed(s, i, &discordgo.MessageEmbed{
+			Title:       "🚫 Listing Cancelled",
+			Description: "Your Pokemon has been taken off the market.",
+			Color:       0x95a5a6,
+		})
+	}
+}
+
+// sendPrices shows a species' market value and daily sales
+func (b *Bot) sendPrices(s *discordgo.Session, i *discordgo.InteractionCreate, speciesID int) {
+	report, err := b.apiClient.GetPrices(speciesID, priceHistoryDays)
+	if err != nil {
+		b.sendError(s, i, "Failed to get prices: "+err.Error())
+		return
+	}
+
+	description := "Not enough recent sales to value this species. Values fall back to the rarity formula."
+	if report.Valuation != nil {
+		description = fmt.Sprintf("**Market value:** %d coins at 50%% IVs\nBased on the last %d sales.",
+			report.Valuation.MedianPrice, report.Valuation.SampleSize)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("📈 Prices for #%d", speciesID),
+		Description: description,
+		Color:       0x3498db,
+	}
+
+	if len(report.History) > 0 {
+		var lines []string
+		for _, point := range report.History {
+			day := point.Start
+			if len(day) >= 10 {
+				day = day[:10]
+			}
+			lines = append(lines, fmt.Sprintf("`%s` median **%d** (%d–%d), %d sold",
+				day, point.MedianPrice, point.MinPrice, point.MaxPrice, point.Volume))
+		}
+		embed.Fields = []*discordgo.MessageEmbedField{{
+			Name:  fmt.Sprintf("Last %d days", priceHistoryDays),
+			Value: strings.Join(lines, "\n"),
+		}}
+	}
+
+	b.sendEmbed(s, i, embed)
+}
+
+// sendListings searches the market and shows the results
+func (b *Bot) sendListings(s *discordgo.Session, i *discordgo.InteractionCreate, title string, search MarketSearch) {
+	listings, err := b.apiClient.SearchListings(search)
+	if err != nil {
+		b.sendError(s, i, "Failed to search the market: "+err.Error())
+		return
+	}
+
+	if len(listings) == 0 {
+		b.sendError(s, i, "No listings found.")
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:  title,
+		Color:  0x3498db,
+		Fields: make([]*discordgo.MessageEmbedField, 0),
+	}
+
+	// Show the cheapest listings (limit to 10)
+	limit := 10
+	if len(listings) < limit {
+		limit = len(listings)
+	}
+
+	for _, l := range listings[:limit] {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: fmt.Sprintf("%s %s — %d coins", getRarityEmoji(l.Pokemon.Species.Rarity), l.Pokemon.Species.Name, l.Price),
+			Value: fmt.Sprintf(
+				"**Nature:** %s | **IVs:** %.1f%%\n**Listing ID:** `%s`",
+				l.Pokemon.Nature, l.Pokemon.IVPercentage, l.ID,
+			),
+			Inline: false,
+		})
+	}
+
+	if len(listings) > limit {
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Showing %d of %d listings", limit, len(listings)),
+		}
+	}
+
+	b.sendEmbed(s, i, embed)
+}
+
+// sendEmbed replaces the deferred response with an embed
+func (b *Bot) sendEmbed(s *discordgo.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) {
+	s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	})
+}
+
+// optionMap indexes command options by name
+func optionMap(options []*discordgo.ApplicationCommandInteractionDataOption) map[string]*discordgo.ApplicationCommandInteractionDataOption {
+	m := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		m[opt.Name] = opt
+	}
+	return m
+}
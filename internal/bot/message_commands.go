@@ -68,7 +68,7 @@ func (b *Bot) handleDailyMessage(s *discordgo.Session, m *discordgo.MessageCreat
 	}
 
 	// Perform daily roll
-	pokemons, err := b.apiClient.DailyRoll(user.ID)
+	pokemons, err := b.apiClient.DailyRoll(user.ID, m.ID)
 	if err != nil {
 		if strings.Contains(err.Error(), "cooldown") {
 			s.ChannelMessageSend(m.ChannelID, "⏰ You've already claimed your daily roll! Come back tomorrow.")
@@ -89,10 +89,10 @@ func (b *Bot) handleDailyMessage(s *discordgo.Session, m *discordgo.MessageCreat
 	for i, p := range pokemons {
 		rarityEmoji := getRarityEmoji(p.Species.Rarity)
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name: fmt.Sprintf("%d. %s %s", i+1, rarityEmoji, p.Species.Name),
+			Name: fmt.Sprintf("%d. %s %s", i+1, rarityEmoji, pokemonLabel(p)),
 			Value: fmt.Sprintf(
-				"**Nature:** %s\n**IVs:** %.1f%% perfect\n**Value:** %d coins",
-				p.Nature, p.IVPercentage, p.EstimatedValue,
+				"**Nature:** %s\n**Ability:** %s\n**IVs:** %.1f%% perfect\n**Value:** %d coins",
+				p.Nature, abilityLabel(p), p.IVPercentage, p.EstimatedValue,
 			),
 			Inline: false,
 		})
@@ -101,10 +101,10 @@ func (b *Bot) handleDailyMessage(s *discordgo.Session, m *discordgo.MessageCreat
 	s.ChannelMessageSendEmbed(m.ChannelID, embed)
 }
 
-// handleRollMessage handles !roll <count> command
+// handleRollMessage handles !roll <count> [banner] command
 func (b *Bot) handleRollMessage(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
 	if len(args) == 0 {
-		s.ChannelMessageSend(m.ChannelID, "❌ Usage: `!roll <count>` (e.g., `!roll 10`)")
+		s.ChannelMessageSend(m.ChannelID, "❌ Usage: `!roll <count> [banner]` (e.g., `!roll 10 legendary-birds`)")
 		return
 	}
 
@@ -114,6 +114,11 @@ func (b *Bot) handleRollMessage(s *discordgo.Session, m *discordgo.MessageCreate
 		return
 	}
 
+	bannerID := ""
+	if len(args) > 1 {
+		bannerID = args[1]
+	}
+
 	discordID := m.Author.ID
 
 	// Get user
@@ -132,11 +137,12 @@ func (b *Bot) handleRollMessage(s *discordgo.Session, m *discordgo.MessageCreate
 	}
 
 	// Perform premium roll
-	pokemons, err := b.apiClient.PremiumRoll(user.ID, count)
+	result, err := b.apiClient.PremiumRoll(user.ID, count, bannerID)
 	if err != nil {
 		s.ChannelMessageSend(m.ChannelID, "❌ Failed to roll: "+err.Error())
 		return
 	}
+	pokemons := result.Pokemons
 
 	// Build embed
 	embed := &discordgo.MessageEmbed{
@@ -149,19 +155,21 @@ func (b *Bot) handleRollMessage(s *discordgo.Session, m *discordgo.MessageCreate
 	for i, p := range pokemons {
 		rarityEmoji := getRarityEmoji(p.Species.Rarity)
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name: fmt.Sprintf("%d. %s %s", i+1, rarityEmoji, p.Species.Name),
+			Name: fmt.Sprintf("%d. %s %s", i+1, rarityEmoji, pokemonLabel(p)),
 			Value: fmt.Sprintf(
-				"**Nature:** %s | **IVs:** %.1f%%\n**Value:** %d coins",
-				p.Nature, p.IVPercentage, p.EstimatedValue,
+				"**Nature:** %s | **IVs:** %.1f%%\n**Ability:** %s\n**Value:** %d coins",
+				p.Nature, p.IVPercentage, abilityLabel(p), p.EstimatedValue,
 			),
 			Inline: true,
 		})
 	}
 
+	footer := pityFooter(result.Pity)
 	if count >= 10 {
-		embed.Footer = &discordgo.MessageEmbedFooter{
-			Text: "🎁 10-roll bonus: Guaranteed Epic or better!",
-		}
+		footer = "🎁 10-roll bonus: Guaranteed Epic or better!\n" + footer
+	}
+	if footer != "" {
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: footer}
 	}
 
 	s.ChannelMessageSendEmbed(m.ChannelID, embed)
@@ -195,11 +203,17 @@ func (b *Bot) handleBalanceMessage(s *discordgo.Session, m *discordgo.MessageCre
 			},
 		},
 	}
+	if streak, err := b.apiClient.GetStreak(user.ID); err == nil {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "🔥 Daily Streak",
+			Value: streakSummary(streak),
+		})
+	}
 
 	s.ChannelMessageSendEmbed(m.ChannelID, embed)
 }
 
-// handleBoxMessage handles !box [rarity] command
+// handleBoxMessage handles !box [rarity] [sort] command
 func (b *Bot) handleBoxMessage(s *discordgo.Session, m *discordgo.MessageCreate, args []string) {
 	discordID := m.Author.ID
 
@@ -210,96 +224,33 @@ func (b *Bot) handleBoxMessage(s *discordgo.Session, m *discordgo.MessageCreate,
 		return
 	}
 
-	// Get Pokemon collection
-	pokemons, err := b.apiClient.GetUserPokemon(user.ID)
+	// Arguments may come in either order
+	search := BoxSearch{Limit: boxPageSize}
+	for _, arg := range args {
+		arg = strings.ToLower(arg)
+		if _, ok := boxSortLabels[arg]; ok {
+			search.Sort = arg
+			continue
+		}
+		search.Rarity = arg
+	}
+
+	page, err := b.apiClient.GetUserBox(user.ID, search)
 	if err != nil {
 		s.ChannelMessageSend(m.ChannelID, "❌ Failed to get Pokemon: "+err.Error())
 		return
 	}
 
-	// Filter by rarity if specified
-	var rarityFilter string
-	if len(args) > 0 {
-		rarityFilter = strings.ToLower(args[0])
-	}
-
-	filtered := pokemons
-	if rarityFilter != "" {
-		filtered = make([]Pokemon, 0)
-		for _, p := range pokemons {
-			if p.Species.Rarity == rarityFilter {
-				filtered = append(filtered, p)
-			}
+	if page.Total == 0 {
+		if search.Rarity != "" {
+			s.ChannelMessageSend(m.ChannelID, fmt.Sprintf("You don't have any %s Pokemon.", search.Rarity))
+			return
 		}
-	}
-
-	if len(filtered) == 0 {
 		s.ChannelMessageSend(m.ChannelID, "You don't have any Pokemon in your collection yet! Use `!daily` to get started.")
 		return
 	}
 
-	// Calculate total value
-	totalValue := 0
-	rarityCounts := make(map[string]int)
-	for _, p := range pokemons {
-		totalValue += p.EstimatedValue
-		rarityCounts[p.Species.Rarity]++
-	}
-
-	// Build embed
-	title := "📦 Your Pokemon Collection"
-	if rarityFilter != "" {
-		title = fmt.Sprintf("📦 Your %s Pokemon", strings.Title(rarityFilter))
-	}
-
-	embed := &discordgo.MessageEmbed{
-		Title:       title,
-		Description: fmt.Sprintf("**Total Pokemon:** %d\n**Collection Value:** %d coins", len(pokemons), totalValue),
-		Color:       0x3498db,
-		Fields:      make([]*discordgo.MessageEmbedField, 0),
-	}
-
-	// Show rarity breakdown
-	rarityBreakdown := ""
-	for _, rarity := range []string{"mythic", "legendary", "epic", "rare", "uncommon", "common"} {
-		if count := rarityCounts[rarity]; count > 0 {
-			rarityBreakdown += fmt.Sprintf("%s **%s**: %d\n", getRarityEmoji(rarity), strings.Title(rarity), count)
-		}
-	}
-	if rarityBreakdown != "" {
-		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   "📊 Rarity Breakdown",
-			Value:  rarityBreakdown,
-			Inline: false,
-		})
-	}
-
-	// Show top Pokemon (limit to 10)
-	limit := 10
-	if len(filtered) < limit {
-		limit = len(filtered)
-	}
-
-	for i := 0; i < limit; i++ {
-		p := filtered[i]
-		rarityEmoji := getRarityEmoji(p.Species.Rarity)
-		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name: fmt.Sprintf("%s %s", rarityEmoji, p.Species.Name),
-			Value: fmt.Sprintf(
-				"**Nature:** %s\n**IVs:** %.1f%%\n**Value:** %d coins",
-				p.Nature, p.IVPercentage, p.EstimatedValue,
-			),
-			Inline: true,
-		})
-	}
-
-	if len(filtered) > limit {
-		embed.Footer = &discordgo.MessageEmbedFooter{
-			Text: fmt.Sprintf("Showing %d of %d Pokemon", limit, len(filtered)),
-		}
-	}
-
-	s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	s.ChannelMessageSendEmbed(m.ChannelID, boxEmbed(page, search))
 }
 
 // handleHelpMessage shows available commands
@@ -316,7 +267,7 @@ func (b *Bot) handleHelpMessage(s *discordgo.Session, m *discordgo.MessageCreate
 			},
 			{
 				Name:   fmt.Sprintf("%sroll <count>", MessageCommandPrefix),
-				Value:  "Buy premium rolls (1-10) with coins\nExample: `!roll 10`",
+				Value:  "Buy premium rolls (1-10) with coins, optionally on a banner\nExample: `!roll 10 legendary-birds`",
 				Inline: false,
 			},
 			{
@@ -325,8 +276,8 @@ func (b *Bot) handleHelpMessage(s *discordgo.Session, m *discordgo.MessageCreate
 				Inline: false,
 			},
 			{
-				Name:   fmt.Sprintf("%sbox [rarity]", MessageCommandPrefix),
-				Value:  "View your Pokemon collection\nExample: `!box epic`\nAlias: `!collection`",
+				Name:   fmt.Sprintf("%sbox [rarity] [sort]", MessageCommandPrefix),
+				Value:  "View your Pokemon collection, sorted by acquired, iv, value or stats\nExample: `!box epic iv`\nAlias: `!collection`",
 				Inline: false,
 			},
 			{
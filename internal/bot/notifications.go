@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// NotificationPollInterval is how often the bot checks the API for notifications
+const NotificationPollInterval = 10 * time.Second
+
+// RunNotificationPoller delivers pending notifications as DMs until ctx is cancelled
+func (b *Bot) RunNotificationPoller(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.deliverNotifications()
+		}
+	}
+}
+
+// deliverNotifications sends each pending notification and marks it delivered.
+// A notification that fails to send stays pending and is retried on the next poll.
+func (b *Bot) deliverNotifications() {
+	notifications, err := b.apiClient.PendingNotifications()
+	if err != nil {
+		log.Printf("notifications: failed to fetch: %v", err)
+		return
+	}
+
+	for _, n := range notifications {
+		channel, err := b.session.UserChannelCreate(n.DiscordID)
+		if err != nil {
+			log.Printf("notifications: failed to open DM with %s: %v", n.DiscordID, err)
+			continue
+		}
+
+		message := &discordgo.MessageSend{
+			Embeds: []*discordgo.MessageEmbed{{
+				Title:       n.Title,
+				Description: n.Message,
+				Color:       notificationColor(n.Type),
+			}},
+		}
+
+		// Trades waiting on this user get confirm and cancel buttons
+		if (n.Type == "trade_offer" || n.Type == "trade_confirmed") && n.ReferenceID != nil {
+			message.Components = []discordgo.MessageComponent{tradeButtons(*n.ReferenceID)}
+		}
+
+		_, err = b.session.ChannelMessageSendComplex(channel.ID, message)
+		if err != nil {
+			log.Printf("notifications: failed to send %s: %v", n.ID, err)
+			continue
+		}
+
+		if err := b.apiClient.MarkNotificationDelivered(n.ID); err != nil {
+			log.Printf("notifications: failed to mark %s delivered: %v", n.ID, err)
+		}
+	}
+}
+
+// notificationColor returns the embed color for a notification type
+func notificationColor(notificationType string) int {
+	switch notificationType {
+	case "auction_won", "auction_sold", "trade_completed":
+		return 0x00ff00
+	case "auction_outbid":
+		return 0xe67e22
+	case "trade_offer", "trade_confirmed":
+		return 0x3498db
+	default:
+		return 0x95a5a6
+	}
+}
@@ -0,0 +1,223 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// dexCommand defines /dex
+var dexCommand = &discordgo.ApplicationCommand{
+	Name:        "dex",
+	Description: "View your Pokedex completion and milestone rewards",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "rarity",
+			Description: "List the species of a rarity",
+			Required:    false,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{Name: "Mythic", Value: "mythic"},
+				{Name: "Legendary", Value: "legendary"},
+				{Name: "Epic", Value: "epic"},
+				{Name: "Rare", Value: "rare"},
+				{Name: "Uncommon", Value: "uncommon"},
+				{Name: "Common", Value: "common"},
+			},
+		},
+	},
+}
+
+// pokedexCommand defines /pokedex
+var pokedexCommand = &discordgo.ApplicationCommand{
+	Name:        "pokedex",
+	Description: "Look up a species' types, stats, matchups, abilities and moves",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "name",
+			Description: "Species name or Pokedex number",
+			Required:    true,
+		},
+	},
+}
+
+// handleDex handles the /dex command
+func (b *Bot) handleDex(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	user, err := b.apiClient.GetOrCreateUser(interactionUserID(i))
+	if err != nil {
+		b.sendError(s, i, "Failed to get user: "+err.Error())
+		return
+	}
+
+	dex, err := b.apiClient.GetPokedex(user.ID)
+	if err != nil {
+		b.sendError(s, i, "Failed to get Pokedex: "+err.Error())
+		return
+	}
+
+	if option, ok := optionMap(i.ApplicationCommandData().Options)["rarity"]; ok {
+		b.sendEmbed(s, i, dexRarityEmbed(dex, option.StringValue()))
+		return
+	}
+
+	var rarities []string
+	for _, rarity := range bannerRarities {
+		if c := dex.ByRarity[rarity]; c != nil {
+			rarities = append(rarities, fmt.Sprintf("%s %s: %s", getRarityEmoji(rarity), strings.Title(rarity), completionSummary(c)))
+		}
+	}
+
+	types := make([]string, 0, len(dex.ByType))
+	for t := range dex.ByType {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for n, t := range types {
+		types[n] = fmt.Sprintf("%s %d/%d", strings.Title(t), dex.ByType[t].Owned, dex.ByType[t].Total)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "📕 Pokedex",
+		Description: fmt.Sprintf("**%.1f%% complete** — %s", dex.Overall.Percent, completionSummary(&dex.Overall)),
+		Color:       0xe74c3c,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "By Rarity", Value: strings.Join(rarities, "\n")},
+			{Name: "By Type", Value: strings.Join(types, " · ")},
+		},
+		Footer: &discordgo.MessageEmbedFooter{Text: "Species stay in your Pokedex after you trade or release them · /dex rarity:<rarity> lists species"},
+	}
+	if dex.NextMilestone != nil {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: fmt.Sprintf("🎁 Next Milestone: %d Species", dex.NextMilestone.Owned),
+			Value: fmt.Sprintf("%s (%d to go)", questRewardSummary(QuestReward{Coins: dex.NextMilestone.Coins, Items: dex.NextMilestone.Items}),
+				dex.NextMilestone.Owned-dex.Overall.Owned),
+		})
+	}
+
+	b.sendEmbed(s, i, embed)
+}
+
+// dexRarityEmbed lists the species of a rarity, hiding the names of those
+// never seen
+func dexRarityEmbed(dex *Pokedex, rarity string) *discordgo.MessageEmbed {
+	var lines []string
+	for _, species := range dex.Species {
+		if species.Rarity != rarity {
+			continue
+		}
+		switch {
+		case species.Owned:
+			lines = append(lines, fmt.Sprintf("✅ #%03d %s", species.ID, strings.Title(species.Name)))
+		case species.Seen:
+			lines = append(lines, fmt.Sprintf("👁️ #%03d %s", species.ID, strings.Title(species.Name)))
+		default:
+			lines = append(lines, fmt.Sprintf("❔ #%03d ???", species.ID))
+		}
+	}
+
+	description := strings.Join(lines, "\n")
+	if len(description) > 4000 {
+		description = description[:strings.LastIndex(description[:4000], "\n")] + "\n…"
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("%s %s Pokedex", getRarityEmoji(rarity), strings.Title(rarity)),
+		Description: description,
+		Color:       0xe74c3c,
+	}
+	if c := dex.ByRarity[rarity]; c != nil {
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: completionSummary(c)}
+	}
+	return embed
+}
+
+// completionSummary describes how many species of a group were owned and
+// seen
+func completionSummary(c *PokedexCompletion) string {
+	return fmt.Sprintf("%d/%d owned, %d seen", c.Owned, c.Total, c.Seen)
+}
+
+// handlePokedex handles the /pokedex command
+func (b *Bot) handlePokedex(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	name := strings.TrimSpace(optionMap(i.ApplicationCommandData().Options)["name"].StringValue())
+	species, err := b.apiClient.GetSpecies(name)
+	if err != nil {
+		b.sendError(s, i, "Failed to look up species: "+err.Error())
+		return
+	}
+
+	types := strings.Title(species.Type1)
+	if species.Type2 != "" {
+		types += " / " + strings.Title(species.Type2)
+	}
+
+	stats := species.BaseStats
+	abilities := make([]string, len(species.Abilities))
+	for n, ability := range species.Abilities {
+		abilities[n] = "**" + ability.DisplayName + "**"
+		if ability.Hidden {
+			abilities[n] += " (hidden)"
+		}
+		if ability.Description != "" {
+			abilities[n] += " — " + ability.Description
+		}
+	}
+
+	moves := make([]string, len(species.Learnset))
+	for n, move := range species.Learnset {
+		moves[n] = move.Name
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("#%03d %s", species.ID, strings.Title(species.Name)),
+		Description: fmt.Sprintf("%s %s · %s", getRarityEmoji(species.Rarity), strings.Title(species.Rarity), types),
+		Color:       0xe74c3c,
+		Thumbnail:   &discordgo.MessageEmbedThumbnail{URL: species.SpriteURL},
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name: fmt.Sprintf("Base Stats (Total %d)", species.BaseStatTotal),
+				Value: fmt.Sprintf("HP %d · Atk %d · Def %d · SpA %d · SpD %d · Spe %d",
+					stats.HP, stats.Attack, stats.Defense, stats.SpAttack, stats.SpDefense, stats.Speed),
+			},
+			{Name: "Abilities", Value: orNone(strings.Join(abilities, "\n"))},
+			{Name: "Weak To", Value: matchupList(species.Matchups.Weaknesses, species.Matchups.Multipliers), Inline: true},
+			{Name: "Resists", Value: matchupList(species.Matchups.Resistances, species.Matchups.Multipliers), Inline: true},
+			{Name: "Immune To", Value: matchupList(species.Matchups.Immunities, nil), Inline: true},
+			{Name: fmt.Sprintf("Learnset (%d)", len(moves)), Value: orNone(strings.Join(moves, ", "))},
+		},
+	}
+
+	b.sendEmbed(s, i, embed)
+}
+
+// matchupList lists attacking types with their multipliers when they are
+// doubled, e.g. "Rock (4x), Water"
+func matchupList(types []string, multipliers map[string]float64) string {
+	labels := make([]string, len(types))
+	for n, t := range types {
+		labels[n] = strings.Title(t)
+		if m := multipliers[t]; m == 4 || m == 0.25 {
+			labels[n] += fmt.Sprintf(" (%gx)", m)
+		}
+	}
+	return orNone(strings.Join(labels, ", "))
+}
+
+// orNone stands in for an empty embed field value, which Discord rejects
+func orNone(value string) string {
+	if value == "" {
+		return "None"
+	}
+	return value
+}
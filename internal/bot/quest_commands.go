@@ -0,0 +1,146 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// questsCommand defines /quests and its subcommands
+var questsCommand = &discordgo.ApplicationCommand{
+	Name:        "quests",
+	Description: "View and claim daily, weekly and achievement quests",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "Show your quest progress",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "claim",
+			Description: "Claim the reward of a completed quest",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "quest",
+					Description: "ID of the quest (shown in /quests list)",
+					Required:    true,
+				},
+			},
+		},
+	},
+}
+
+// questSections are the headings /quests list groups quests under, in order
+var questSections = []struct {
+	period string
+	title  string
+}{
+	{"daily", "📅 Daily Quests"},
+	{"weekly", "🗓️ Weekly Quests"},
+	{"achievement", "🏆 Achievements"},
+}
+
+// handleQuests handles the /quests command
+func (b *Bot) handleQuests(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	user, err := b.apiClient.GetOrCreateUser(interactionUserID(i))
+	if err != nil {
+		b.sendError(s, i, "Failed to get user: "+err.Error())
+		return
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := optionMap(subcommand.Options)
+
+	if subcommand.Name == "list" {
+		b.sendQuestList(s, i, user.ID)
+		return
+	}
+
+	claim, err := b.apiClient.ClaimQuest(user.ID, options["quest"].StringValue())
+	if err != nil {
+		b.sendError(s, i, "❌ Failed to claim quest: "+err.Error())
+		return
+	}
+
+	title := "🎁 Quest Complete: " + claim.Quest.Name
+	if claim.AlreadyClaimed {
+		title = "✅ Already Claimed: " + claim.Quest.Name
+	}
+
+	b.sendEmbed(s, i, &discordgo.MessageEmbed{
+		Title:       title,
+		Description: questRewardSummary(claim.Reward),
+		Color:       0x2ecc71,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "💰 Balance", Value: fmt.Sprintf("%d coins", claim.Coins), Inline: true},
+		},
+	})
+}
+
+// sendQuestList shows a user's progress on every active quest, grouped by
+// period
+func (b *Bot) sendQuestList(s *discordgo.Session, i *discordgo.InteractionCreate, userID string) {
+	quests, err := b.apiClient.ListQuests(userID)
+	if err != nil {
+		b.sendError(s, i, "❌ Failed to get quests: "+err.Error())
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:  "📜 Quests",
+		Color:  0x9b59b6,
+		Footer: &discordgo.MessageEmbedFooter{Text: "Claim a completed quest with /quests claim"},
+	}
+	for _, section := range questSections {
+		var lines []string
+		var endsAt string
+		for _, quest := range quests {
+			if quest.Period != section.period {
+				continue
+			}
+			lines = append(lines, questLine(quest))
+			if quest.EndsAt != nil {
+				endsAt = *quest.EndsAt
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		if endsAt != "" {
+			lines = append(lines, "_Rotates "+discordTimestamp(endsAt)+"_")
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  section.title,
+			Value: strings.Join(lines, "\n"),
+		})
+	}
+
+	b.sendEmbed(s, i, embed)
+}
+
+// questLine describes a quest's progress and reward on one line
+func questLine(quest Quest) string {
+	mark := "⬜"
+	switch {
+	case quest.Claimed:
+		mark = "✅"
+	case quest.Completed:
+		mark = "🎁"
+	}
+	return fmt.Sprintf("%s **%s** (`%s`) — %s · %d/%d · %s",
+		mark, quest.Name, quest.ID, quest.Description, quest.Progress, quest.Goal, questRewardSummary(quest.Reward))
+}
+
+// questRewardSummary lists what claiming a quest gives
+func questRewardSummary(reward QuestReward) string {
+	parts := append([]string{fmt.Sprintf("%d coins", reward.Coins)}, itemCounts(reward.Items)...)
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,186 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// releaseCommand defines /release and its subcommands. Both preview what
+// would be released unless confirm is set.
+var releaseCommand = &discordgo.ApplicationCommand{
+	Name:        "release",
+	Description: "Release Pokemon back into the wild for coins",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "pokemon",
+			Description: "Release specific Pokemon",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "ids",
+					Description: "IDs of the Pokemon (shown in /box), separated by commas",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "confirm",
+					Description: "Release for real instead of previewing",
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "bulk",
+			Description: "Bulk sell every Pokemon matching the filters",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "rarity",
+					Description: "Only this rarity",
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Mythic", Value: "mythic"},
+						{Name: "Legendary", Value: "legendary"},
+						{Name: "Epic", Value: "epic"},
+						{Name: "Rare", Value: "rare"},
+						{Name: "Uncommon", Value: "uncommon"},
+						{Name: "Common", Value: "common"},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "species_id",
+					Description: "Only this Pokedex number",
+					MinValue:    func() *float64 { v := 1.0; return &v }(),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionNumber,
+					Name:        "below_iv",
+					Description: "Only Pokemon below this IV percentage",
+					MinValue:    func() *float64 { v := 0.0; return &v }(),
+					MaxValue:    100.0,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "keep",
+					Description: "Only duplicates beyond your best N of each species",
+					MinValue:    func() *float64 { v := 1.0; return &v }(),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "confirm",
+					Description: "Sell for real instead of previewing",
+				},
+			},
+		},
+	},
+}
+
+// maxReleaseLines is how many Pokemon a release message lists before summarising
+const maxReleaseLines = 10
+
+// protectionLabels explains why a Pokemon was kept
+var protectionLabels = map[string]string{
+	"favorite":  "favorite",
+	"listed":    "on the market",
+	"in_battle": "in a battle",
+	"in_trade":  "in a pending trade",
+}
+
+// handleRelease handles the /release command
+func (b *Bot) handleRelease(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	user, err := b.apiClient.GetOrCreateUser(interactionUserID(i))
+	if err != nil {
+		b.sendError(s, i, "Failed to get user: "+err.Error())
+		return
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := optionMap(subcommand.Options)
+	dryRun := true
+	if opt, ok := options["confirm"]; ok {
+		dryRun = !opt.BoolValue()
+	}
+
+	var result *ReleaseResult
+	switch subcommand.Name {
+	case "pokemon":
+		result, err = b.apiClient.ReleasePokemon(user.ID, splitIDs(options["ids"].StringValue()), dryRun)
+	case "bulk":
+		filter := BulkSellFilter{}
+		if opt, ok := options["rarity"]; ok {
+			filter.Rarity = opt.StringValue()
+		}
+		if opt, ok := options["species_id"]; ok {
+			filter.SpeciesID = int(opt.IntValue())
+		}
+		if opt, ok := options["below_iv"]; ok {
+			filter.MaxIVPercent = opt.FloatValue()
+		}
+		if opt, ok := options["keep"]; ok {
+			filter.KeepPerSpecies = int(opt.IntValue())
+		}
+		result, err = b.apiClient.BulkSell(user.ID, filter, dryRun)
+	}
+	if err != nil {
+		b.sendError(s, i, "❌ Failed to release Pokemon: "+err.Error())
+		return
+	}
+
+	b.sendEmbed(s, i, releaseEmbed(result))
+}
+
+// releaseEmbed describes a release, or its preview
+func releaseEmbed(result *ReleaseResult) *discordgo.MessageEmbed {
+	var lines []string
+	for n, released := range result.Released {
+		if n == maxReleaseLines {
+			lines = append(lines, fmt.Sprintf("…and %d more", len(result.Released)-maxReleaseLines))
+			break
+		}
+		p := released.Pokemon
+		lines = append(lines, fmt.Sprintf("%s **%s** (%.1f%% IV) → %d coins, %d 🍬",
+			getRarityEmoji(p.Species.Rarity), p.Species.Name, p.IVPercentage, released.Payout, released.Candy))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "No Pokemon matched.")
+	}
+
+	if len(result.Protected) > 0 {
+		lines = append(lines, "", "**Kept:**")
+		for n, protected := range result.Protected {
+			if n == maxReleaseLines {
+				lines = append(lines, fmt.Sprintf("…and %d more", len(result.Protected)-maxReleaseLines))
+				break
+			}
+			lines = append(lines, fmt.Sprintf("🛡️ **%s**: %s",
+				protected.Pokemon.Species.Name, protectionLabels[protected.Reason]))
+		}
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Description: strings.Join(lines, "\n"),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Total: %d coins for %d Pokemon", result.TotalPayout, result.Count),
+		},
+	}
+
+	if result.DryRun {
+		embed.Title = "👀 Release Preview"
+		embed.Color = 0x3498db
+		if result.Count > 0 {
+			embed.Footer.Text += " · run again with confirm:true to release"
+		}
+	} else {
+		embed.Title = "🌿 Pokemon Released"
+		embed.Color = 0x00ff00
+	}
+
+	return embed
+}
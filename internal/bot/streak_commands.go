@@ -0,0 +1,142 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// streakCommand defines /streak
+var streakCommand = &discordgo.ApplicationCommand{
+	Name:        "streak",
+	Description: "View your daily check-in streak and upcoming rewards",
+}
+
+// checkInCommand defines /checkin
+var checkInCommand = &discordgo.ApplicationCommand{
+	Name:        "checkin",
+	Description: "Check in for today's streak reward",
+}
+
+// handleStreak handles the /streak command
+func (b *Bot) handleStreak(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	user, err := b.apiClient.GetOrCreateUser(interactionUserID(i))
+	if err != nil {
+		b.sendError(s, i, "Failed to get user: "+err.Error())
+		return
+	}
+
+	streak, err := b.apiClient.GetStreak(user.ID)
+	if err != nil {
+		b.sendError(s, i, "Failed to get streak: "+err.Error())
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "🔥 Daily Streak",
+		Description: streakSummary(streak),
+		Color:       0xe67e22,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "🏆 Longest", Value: fmt.Sprintf("%d days", streak.Longest), Inline: true},
+			{Name: "📅 Check-ins", Value: fmt.Sprintf("%d", streak.TotalCheckIns), Inline: true},
+			{Name: fmt.Sprintf("🎁 Day %d Reward", streak.NextReward.Day), Value: rewardSummary(streak.NextReward)},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Days reset at midnight " + streak.Timezone + " · missing one day keeps your streak",
+		},
+	}
+	if streak.NextMilestone != nil {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("⭐ Next Milestone: Day %d", streak.NextMilestone.Day),
+			Value: rewardSummary(*streak.NextMilestone),
+		})
+	}
+
+	b.sendEmbed(s, i, embed)
+}
+
+// handleCheckIn handles the /checkin command
+func (b *Bot) handleCheckIn(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	user, err := b.apiClient.GetOrCreateUser(interactionUserID(i))
+	if err != nil {
+		b.sendError(s, i, "Failed to get user: "+err.Error())
+		return
+	}
+
+	result, err := b.apiClient.CheckIn(user.ID)
+	if err != nil {
+		b.sendError(s, i, "❌ Failed to check in: "+err.Error())
+		return
+	}
+
+	title := fmt.Sprintf("✅ Checked In: Day %d", result.Reward.Day)
+	if result.Reward.Milestone {
+		title = fmt.Sprintf("⭐ Milestone Reached: Day %d", result.Reward.Day)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: rewardSummary(result.Reward),
+		Color:       0x2ecc71,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "💰 Balance", Value: fmt.Sprintf("%d coins", result.Coins), Inline: true},
+			{Name: "🔥 Streak", Value: fmt.Sprintf("%d days (best %d)", result.Streak.Current, result.Streak.Longest), Inline: true},
+		},
+		Footer: &discordgo.MessageEmbedFooter{Text: "Check in again with /checkin after midnight " + result.Streak.Timezone},
+	}
+	if p := result.Pokemon; p != nil {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "🎉 Bonus Pokemon",
+			Value: fmt.Sprintf("%s **%s** (`%s`)", getRarityEmoji(p.Species.Rarity), pokemonLabel(*p), p.ID),
+		})
+	}
+
+	b.sendEmbed(s, i, embed)
+}
+
+// streakSummary describes a streak and when to check in next
+func streakSummary(streak *StreakStatus) string {
+	if streak.CheckedIn {
+		return fmt.Sprintf("**%d day streak** — checked in today. Next check-in %s.",
+			streak.Current, discordTimestamp(streak.NextCheckIn))
+	}
+	if streak.Current == 0 {
+		return "No streak yet — use /checkin to start one."
+	}
+	return fmt.Sprintf("**%d day streak** — use /checkin before %s to keep it.",
+		streak.Current, discordTimestamp(*streak.BreaksAt))
+}
+
+// rewardSummary lists what a check-in gives
+func rewardSummary(reward StreakReward) string {
+	parts := append([]string{fmt.Sprintf("%d coins", reward.Coins)}, itemCounts(reward.Items)...)
+	if reward.Pokemon != "" {
+		parts = append(parts, fmt.Sprintf("%s %s+ Pokemon", getRarityEmoji(reward.Pokemon), strings.Title(reward.Pokemon)))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// itemCounts lists items as "2x protein", sorted by item name
+func itemCounts(items map[string]int) []string {
+	names := make([]string, 0, len(items))
+	for item := range items {
+		names = append(names, item)
+	}
+	sort.Strings(names)
+
+	counts := make([]string, 0, len(names))
+	for _, item := range names {
+		counts = append(counts, fmt.Sprintf("%dx %s", items[item], item))
+	}
+	return counts
+}
@@ -0,0 +1,256 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// tradeCommand defines /trade and its subcommands
+var tradeCommand = &discordgo.ApplicationCommand{
+	Name:        "trade",
+	Description: "Trade Pokemon and coins directly with another player",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "offer",
+			Description: "Offer a trade to another player",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "Player to trade with",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "give",
+					Description: "IDs of your Pokemon to give, separated by commas",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "want",
+					Description: "IDs of their Pokemon you want, separated by commas",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "give_coins",
+					Description: "Coins you pay",
+					MinValue:    func() *float64 { v := 0.0; return &v }(),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "want_coins",
+					Description: "Coins they pay",
+					MinValue:    func() *float64 { v := 0.0; return &v }(),
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "pending",
+			Description: "View your open trade offers",
+		},
+	},
+}
+
+// Custom ID prefixes for the buttons attached to trade messages
+const (
+	tradeConfirmPrefix = "trade_confirm:"
+	tradeCancelPrefix  = "trade_cancel:"
+)
+
+// handleTrade handles the /trade command
+func (b *Bot) handleTrade(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	// Get user
+	user, err := b.apiClient.GetOrCreateUser(interactionUserID(i))
+	if err != nil {
+		b.sendError(s, i, "Failed to get user: "+err.Error())
+		return
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := optionMap(subcommand.Options)
+
+	switch subcommand.Name {
+	case "offer":
+		partner := options["user"].UserValue(s)
+		if partner.ID == interactionUserID(i) {
+			b.sendError(s, i, "❌ You can't trade with yourself.")
+			return
+		}
+
+		recipient, err := b.apiClient.GetOrCreateUser(partner.ID)
+		if err != nil {
+			b.sendError(s, i, "Failed to get user: "+err.Error())
+			return
+		}
+
+		offer := TradeOffer{RecipientID: recipient.ID}
+		if opt, ok := options["give"]; ok {
+			offer.ProposerPokemonIDs = splitIDs(opt.StringValue())
+		}
+		if opt, ok := options["want"]; ok {
+			offer.RecipientPokemonIDs = splitIDs(opt.StringValue())
+		}
+		if opt, ok := options["give_coins"]; ok {
+			offer.ProposerCoins = int(opt.IntValue())
+		}
+		if opt, ok := options["want_coins"]; ok {
+			offer.RecipientCoins = int(opt.IntValue())
+		}
+
+		trade, err := b.apiClient.ProposeTrade(user.ID, offer)
+		if err != nil {
+			b.sendError(s, i, "❌ Failed to offer trade: "+err.Error())
+			return
+		}
+
+		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Embeds: &[]*discordgo.MessageEmbed{{
+				Title: "🔄 Trade Offered!",
+				Description: fmt.Sprintf(
+					"Trade sent to <@%s>. Nothing changes hands until you **both** confirm.\nExpires %s\n**Trade ID:** `%s`",
+					partner.ID, discordTimestamp(trade.ExpiresAt), trade.ID,
+				),
+				Color: 0x3498db,
+			}},
+			Components: &[]discordgo.MessageComponent{tradeButtons(trade.ID)},
+		})
+	case "pending":
+		b.sendTrades(s, i, user.ID)
+	}
+}
+
+// handleTradeButton handles the confirm and cancel buttons on trade messages
+func (b *Bot) handleTradeButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	customID := i.MessageComponentData().CustomID
+
+	user, err := b.apiClient.GetOrCreateUser(interactionUserID(i))
+	if err != nil {
+		respondEphemeral(s, i, "Failed to get user: "+err.Error())
+		return
+	}
+
+	var result string
+	switch {
+	case strings.HasPrefix(customID, tradeConfirmPrefix):
+		trade, err := b.apiClient.ConfirmTrade(user.ID, strings.TrimPrefix(customID, tradeConfirmPrefix))
+		if err != nil {
+			respondEphemeral(s, i, "❌ Failed to confirm trade: "+err.Error())
+			return
+		}
+		if trade.Status == "completed" {
+			result = "✅ Trade complete! Check your `/box`."
+		} else {
+			result = "✅ You confirmed. Waiting for the other trader."
+		}
+	case strings.HasPrefix(customID, tradeCancelPrefix):
+		if err := b.apiClient.CancelTrade(user.ID, strings.TrimPrefix(customID, tradeCancelPrefix)); err != nil {
+			respondEphemeral(s, i, "❌ Failed to cancel trade: "+err.Error())
+			return
+		}
+		result = "🚫 Trade cancelled."
+	default:
+		return
+	}
+
+	// Replace the buttons with the outcome so they can't be pressed again
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    result,
+			Embeds:     i.Message.Embeds,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
+
+// sendTrades shows a user's open trade offers
+func (b *Bot) sendTrades(s *discordgo.Session, i *discordgo.InteractionCreate, userID string) {
+	trades, err := b.apiClient.ListTrades(userID)
+	if err != nil {
+		b.sendError(s, i, "Failed to get trades: "+err.Error())
+		return
+	}
+
+	if len(trades) == 0 {
+		b.sendError(s, i, "You have no open trade offers.")
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:  "🔄 Open Trades",
+		Color:  0x3498db,
+		Fields: make([]*discordgo.MessageEmbedField, 0),
+	}
+
+	for _, t := range trades {
+		direction := "Received"
+		if t.ProposerID == userID {
+			direction = "Sent"
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: fmt.Sprintf("%s — %d Pokemon + %d coins for %d Pokemon + %d coins",
+				direction, len(t.ProposerPokemonIDs), t.ProposerCoins, len(t.RecipientPokemonIDs), t.RecipientCoins),
+			Value: fmt.Sprintf("**Confirmed:** %s / %s | Expires %s\n**Trade ID:** `%s`",
+				confirmMark(t.ProposerConfirmed), confirmMark(t.RecipientConfirmed), discordTimestamp(t.ExpiresAt), t.ID),
+			Inline: false,
+		})
+	}
+
+	b.sendEmbed(s, i, embed)
+}
+
+// tradeButtons returns the confirm and cancel buttons for a trade
+func tradeButtons(tradeID string) discordgo.ActionsRow {
+	return discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.Button{Label: "Confirm", Style: discordgo.SuccessButton, CustomID: tradeConfirmPrefix + tradeID},
+			discordgo.Button{Label: "Cancel", Style: discordgo.DangerButton, CustomID: tradeCancelPrefix + tradeID},
+		},
+	}
+}
+
+// splitIDs parses a comma-separated list of IDs
+func splitIDs(value string) []string {
+	var ids []string
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func confirmMark(confirmed bool) string {
+	if confirmed {
+		return "✅"
+	}
+	return "⏳"
+}
+
+// interactionUserID returns the Discord ID of whoever triggered the
+// interaction. Member is only set in servers; DMs set User instead.
+func interactionUserID(i *discordgo.InteractionCreate) string {
+	if i.Member != nil {
+		return i.Member.User.ID
+	}
+	return i.User.ID
+}
+
+// respondEphemeral replies with a message only the user can see
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, message string) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: message,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
@@ -0,0 +1,83 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// trainCommand defines /train
+var trainCommand = &discordgo.ApplicationCommand{
+	Name:        "train",
+	Description: "Train a Pokemon's EVs (252 per stat, 510 in total)",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "pokemon_id",
+			Description: "ID of the Pokemon (shown in /box)",
+			Required:    true,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "evs",
+			Description: "EVs to add, e.g. 252 Atk / 4 SpD / 252 Spe",
+			Required:    true,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "payment",
+			Description: "Pay with coins (2 per EV) or vitamins (1 per 10 EVs)",
+			Required:    false,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{Name: "Coins", Value: "coins"},
+				{Name: "Vitamins", Value: "vitamins"},
+			},
+		},
+	},
+}
+
+// handleTrain handles the /train command
+func (b *Bot) handleTrain(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	user, err := b.apiClient.GetOrCreateUser(interactionUserID(i))
+	if err != nil {
+		b.sendError(s, i, "Failed to get user: "+err.Error())
+		return
+	}
+
+	options := optionMap(i.ApplicationCommandData().Options)
+	payment := "coins"
+	if opt, ok := options["payment"]; ok {
+		payment = opt.StringValue()
+	}
+
+	result, err := b.apiClient.TrainEVs(user.ID, options["pokemon_id"].StringValue(), options["evs"].StringValue(), payment)
+	if err != nil {
+		b.sendError(s, i, "❌ Failed to train: "+err.Error())
+		return
+	}
+
+	cost := fmt.Sprintf("%d coins", result.Cost.Coins)
+	if len(result.Cost.Vitamins) > 0 {
+		vitamins := make([]string, 0, len(result.Cost.Vitamins))
+		for vitamin, count := range result.Cost.Vitamins {
+			vitamins = append(vitamins, fmt.Sprintf("%d %s", count, vitamin))
+		}
+		sort.Strings(vitamins)
+		cost = strings.Join(vitamins, ", ")
+	}
+
+	p := result.Pokemon
+	b.sendEmbed(s, i, &discordgo.MessageEmbed{
+		Title: "🏋️ EVs Trained",
+		Description: fmt.Sprintf("%s **%s**\n**EVs:** %s\n**Stats:** %d HP / %d Atk / %d Def / %d SpA / %d SpD / %d Spe\n**Cost:** %s",
+			getRarityEmoji(p.Species.Rarity), p.Species.Name, p.EVSpread,
+			p.Stats.HP, p.Stats.Attack, p.Stats.Defense, p.Stats.SpAttack, p.Stats.SpDefense, p.Stats.Speed, cost),
+		Color: 0xe67e22,
+	})
+}
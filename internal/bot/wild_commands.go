@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// wildCommand defines /wild
+var wildCommand = &discordgo.ApplicationCommand{
+	Name:        "wild",
+	Description: "Battle a wild Pokemon for experience",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "pokemon_id",
+			Description: "ID of the Pokemon to send out (shown in /box)",
+			Required:    true,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "format",
+			Description: "Battle format",
+			Required:    false,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{Name: "Standard", Value: "standard"},
+				{Name: "Ranked (level 50)", Value: "ranked"},
+				{Name: "Little Cup (level 5 cap)", Value: "little_cup"},
+			},
+		},
+	},
+}
+
+// handleWild handles the /wild command
+func (b *Bot) handleWild(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	user, err := b.apiClient.GetOrCreateUser(interactionUserID(i))
+	if err != nil {
+		b.sendError(s, i, "Failed to get user: "+err.Error())
+		return
+	}
+
+	options := optionMap(i.ApplicationCommandData().Options)
+	format := ""
+	if opt, ok := options["format"]; ok {
+		format = opt.StringValue()
+	}
+
+	result, err := b.apiClient.WildBattle(user.ID, options["pokemon_id"].StringValue(), format)
+	if err != nil {
+		b.sendError(s, i, "❌ Failed to battle: "+err.Error())
+		return
+	}
+
+	outcome := "lost to"
+	if result.Won {
+		outcome = "defeated"
+	}
+	description := fmt.Sprintf("Your **%s** %s a wild %s **%s** (Lv. %d) in %d turns.\n+%d exp",
+		result.Pokemon.Species.Name, outcome, getRarityEmoji(result.Wild.Rarity), result.Wild.Name,
+		result.WildLevel, result.Turns, result.Experience.Experience)
+	if result.Experience.LevelsGained > 0 {
+		description += fmt.Sprintf("\n⬆️ Grew to level %d!", result.Experience.Level)
+	} else if result.Pokemon.ExperienceToNext > 0 {
+		description += fmt.Sprintf(" · %d to the next level", result.Pokemon.ExperienceToNext)
+	}
+
+	b.sendEmbed(s, i, &discordgo.MessageEmbed{
+		Title:       "🌿 Wild Battle",
+		Description: description,
+		Color:       0x2ecc71,
+	})
+}
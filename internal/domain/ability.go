@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 // AbilityTrigger represents when an ability triggers
 type AbilityTrigger string
 
@@ -112,6 +118,7 @@ type EffectCondition struct {
 
 	// Status conditions
 	RequiredStatus StatusCondition `json:"required_status"`
+	AnyStatus      bool            `json:"any_status"` // Any major status (Guts, Marvel Scale)
 
 	// Move conditions
 	RequiredMoveCategory MoveCategory  `json:"required_move_category"`
@@ -167,6 +174,9 @@ const (
 	AbilityGuts           = "guts"             // Attack x1.5 when statused
 	AbilityMarvelScale    = "marvel_scale"     // Defense x1.5 when statused
 	AbilityWonderGuard    = "wonder_guard"     // Only hit by super effective
+	AbilityCompoundEyes   = "compound_eyes"    // Accuracy x1.3
+	AbilitySuperLuck      = "super_luck"       // Raises critical hit ratio
+	AbilitySandVeil       = "sand_veil"        // Evasion x1.25 in a sandstorm
 )
 
 // IsPassiveAbility checks if an ability is always active
@@ -187,11 +197,209 @@ func IsPassiveAbility(ability string) bool {
 	return false
 }
 
-// GetAbilityByName returns ability data by name (stub for now, will be DB-backed)
+// EntryWeatherTurns is how long weather summoned by an ability lasts
+const EntryWeatherTurns = 5
+
+// pinchAbility boosts moves of one type by 1.5x at a third of max HP or less
+func pinchAbility(name, description string, moveType PokemonType) *Ability {
+	return &Ability{
+		Name:        name,
+		Description: description,
+		Trigger:     TriggerPassive,
+		Effects: []AbilityEffect{{
+			Type:             EffectDamageModifier,
+			Condition:        &EffectCondition{HPThreshold: 34, HPComparison: "below"},
+			DamageMultiplier: 1.5,
+			MoveTypes:        []PokemonType{moveType},
+		}},
+	}
+}
+
+// weatherAbility summons weather when the Pokemon enters battle
+func weatherAbility(name, description string, weather Weather) *Ability {
+	return &Ability{
+		Name:        name,
+		Description: description,
+		Trigger:     TriggerOnEntry,
+		Effects:     []AbilityEffect{{Type: EffectWeatherSet, Weather: weather}},
+	}
+}
+
+// abilities holds the abilities with battle effects. Abilities missing from
+// it can still be assigned to species but have no effect in battle.
+var abilities = map[string]*Ability{
+	AbilityOvergrow: pinchAbility(AbilityOvergrow, "Powers up Grass-type moves when HP is low", Grass),
+	AbilityBlaze:    pinchAbility(AbilityBlaze, "Powers up Fire-type moves when HP is low", Fire),
+	AbilityTorrent:  pinchAbility(AbilityTorrent, "Powers up Water-type moves when HP is low", Water),
+	AbilitySwarm:    pinchAbility(AbilitySwarm, "Powers up Bug-type moves when HP is low", Bug),
+	AbilityIntimidation: {
+		Name:        AbilityIntimidation,
+		Description: "Lowers the opponent's Attack on entry",
+		Trigger:     TriggerOnEntry,
+		Effects: []AbilityEffect{{
+			Type:       EffectStatBoost,
+			StatBoosts: []StatChange{{Stat: Attack, Stages: -1, Target: "opponent"}},
+		}},
+	},
+	AbilityLevitate: {
+		Name:        AbilityLevitate,
+		Description: "Gives full immunity to Ground-type moves",
+		Trigger:     TriggerPassive,
+		Effects:     []AbilityEffect{{Type: EffectMoveBlock, BlockedMoveTypes: []PokemonType{Ground}}},
+	},
+	AbilityThickFat: {
+		Name:        AbilityThickFat,
+		Description: "Halves damage from Fire- and Ice-type moves",
+		Trigger:     TriggerPassive,
+		Effects: []AbilityEffect{{
+			Type:             EffectDamageModifier,
+			DamageMultiplier: 0.5,
+			AffectedTypes:    []PokemonType{Fire, Ice},
+		}},
+	},
+	AbilitySturdy: {
+		Name:        AbilitySturdy,
+		Description: "Survives a knockout hit from full HP",
+		Trigger:     TriggerPassive,
+	},
+	AbilityAdaptability: {
+		Name:        AbilityAdaptability,
+		Description: "Powers up same-type moves to 2x",
+		Trigger:     TriggerPassive,
+	},
+	AbilityHugePower: {
+		Name:        AbilityHugePower,
+		Description: "Doubles Attack",
+		Trigger:     TriggerPassive,
+		Effects:     []AbilityEffect{{Type: EffectStatBoost, DamageMultiplier: 2}},
+	},
+	AbilityPurePower: {
+		Name:        AbilityPurePower,
+		Description: "Doubles Attack",
+		Trigger:     TriggerPassive,
+		Effects:     []AbilityEffect{{Type: EffectStatBoost, DamageMultiplier: 2}},
+	},
+	AbilityGuts: {
+		Name:        AbilityGuts,
+		Description: "Boosts Attack by 1.5x when statused, ignoring burn",
+		Trigger:     TriggerPassive,
+		Effects: []AbilityEffect{{
+			Type:             EffectStatBoost,
+			Condition:        &EffectCondition{AnyStatus: true},
+			DamageMultiplier: 1.5,
+		}},
+	},
+	AbilityMarvelScale: {
+		Name:        AbilityMarvelScale,
+		Description: "Boosts Defense by 1.5x when statused",
+		Trigger:     TriggerPassive,
+		Effects: []AbilityEffect{{
+			Type:             EffectStatBoost,
+			Condition:        &EffectCondition{AnyStatus: true, RequiredMoveCategory: Physical},
+			DamageMultiplier: 1.5,
+		}},
+	},
+	AbilityTechnician: {
+		Name:        AbilityTechnician,
+		Description: "Powers up moves of 60 power or less by 1.5x",
+		Trigger:     TriggerPassive,
+	},
+	AbilityMagicGuard: {
+		Name:        AbilityMagicGuard,
+		Description: "Only takes damage from attacks",
+		Trigger:     TriggerPassive,
+	},
+	AbilityCompoundEyes: {
+		Name:        AbilityCompoundEyes,
+		Description: "Boosts accuracy by 1.3x",
+		Trigger:     TriggerPassive,
+		Effects:     []AbilityEffect{{Type: EffectAccuracyModifier, AccuracyModifier: 1.3}},
+	},
+	AbilitySuperLuck: {
+		Name:        AbilitySuperLuck,
+		Description: "Raises the critical hit ratio",
+		Trigger:     TriggerPassive,
+		Effects:     []AbilityEffect{{Type: EffectCritRateModifier, CritRateStages: 1}},
+	},
+	AbilitySandVeil: {
+		Name:        AbilitySandVeil,
+		Description: "Boosts evasion in a sandstorm",
+		Trigger:     TriggerPassive,
+		Effects: []AbilityEffect{{
+			Type:            EffectAccuracyModifier,
+			Condition:       &EffectCondition{RequiredWeather: WeatherSandstorm},
+			EvasionModifier: 1.25,
+		}},
+	},
+	AbilityDrought:     weatherAbility(AbilityDrought, "Turns the sunlight harsh on entry", WeatherSun),
+	AbilityDrizzle:     weatherAbility(AbilityDrizzle, "Makes it rain on entry", WeatherRain),
+	AbilitySandStream:  weatherAbility(AbilitySandStream, "Whips up a sandstorm on entry", WeatherSandstorm),
+	AbilitySnowWarning: weatherAbility(AbilitySnowWarning, "Makes it snow on entry", WeatherSnow),
+}
+
+// GetAbilityByName returns the battle data of an ability, or nil if the
+// ability has no battle effect
 func GetAbilityByName(name string) *Ability {
-	// This will be replaced with database lookups
-	// For now, return nil
-	return nil
+	return abilities[name]
+}
+
+// AbilityDisplayName turns an ability name like "thick_fat" into "Thick Fat"
+func AbilityDisplayName(name string) string {
+	words := strings.Fields(strings.ReplaceAll(name, "_", " "))
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// ApplyEntryAbilities triggers both Pokemon's on-entry abilities, faster
+// Pokemon first so the slower one's weather wins
+func (b *BattleState) ApplyEntryAbilities() {
+	players := []*BattlePlayer{b.Player1, b.Player2}
+	opponents := map[*BattlePlayer]*BattlePlayer{b.Player1: b.Player2, b.Player2: b.Player1}
+	sort.SliceStable(players, func(i, j int) bool {
+		return players[i].Pokemon.Stats.Speed > players[j].Pokemon.Stats.Speed
+	})
+
+	for _, player := range players {
+		ability := GetAbilityByName(player.Pokemon.Ability)
+		if ability == nil || ability.Trigger != TriggerOnEntry {
+			continue
+		}
+
+		opponent := opponents[player].Pokemon
+		for _, effect := range ability.Effects {
+			switch effect.Type {
+			case EffectWeatherSet:
+				b.Weather = effect.Weather
+				b.WeatherTurns = EntryWeatherTurns
+				b.AddLogEntry("ability", weatherSetMessage(effect.Weather), map[string]interface{}{
+					"pokemon": player.Pokemon.Species.Name,
+					"ability": ability.Name,
+				})
+			case EffectStatBoost:
+				for _, change := range effect.StatBoosts {
+					target := player.Pokemon
+					if change.Target == "opponent" {
+						target = opponent
+					}
+					if target.StatStages.ApplyChange(change.Stat, change.Stages) == 0 {
+						continue
+					}
+					direction := "rose"
+					if change.Stages < 0 {
+						direction = "fell"
+					}
+					b.AddLogEntry("ability", fmt.Sprintf("%s's %s: %s's %s %s!",
+						player.Pokemon.Species.Name, AbilityDisplayName(ability.Name),
+						target.Species.Name, change.Stat, direction), map[string]interface{}{
+						"pokemon": player.Pokemon.Species.Name,
+						"ability": ability.Name,
+					})
+				}
+			}
+		}
+	}
 }
 
 // AppliesInBattle checks if an ability applies in the current battle context
@@ -249,6 +457,9 @@ func (c *EffectCondition) MeetsCondition(context *BattleContext) bool {
 	if c.RequiredStatus != "" && context.Status != c.RequiredStatus {
 		return false
 	}
+	if c.AnyStatus && (context.Status == "" || context.Status == StatusNone) {
+		return false
+	}
 
 	// Move category check
 	if c.RequiredMoveCategory != "" && context.MoveCategory != c.RequiredMoveCategory {
@@ -0,0 +1,72 @@
+package domain
+
+import "math/rand"
+
+const (
+	HiddenAbilityChance = 0.05              // Chance a new Pokemon gets its species' hidden ability
+	MaxRegularAbilities = 2                 // Regular ability slots per species
+	AbilityCapsule      = "ability-capsule" // Item that switches between regular abilities
+)
+
+// AbilitySlot is which of its species' abilities a Pokemon has
+type AbilitySlot int
+
+const (
+	AbilitySlot1      AbilitySlot = 1
+	AbilitySlot2      AbilitySlot = 2
+	HiddenAbilitySlot AbilitySlot = 3
+)
+
+// IsHidden checks if the slot is the hidden ability slot
+func (s AbilitySlot) IsHidden() bool {
+	return s == HiddenAbilitySlot
+}
+
+// Ability returns the species' ability in a slot, or an empty string if the
+// slot is empty
+func (s *PokemonSpecies) Ability(slot AbilitySlot) string {
+	if slot.IsHidden() {
+		return s.HiddenAbility
+	}
+	if slot < AbilitySlot1 || int(slot) > len(s.Abilities) {
+		return ""
+	}
+	return s.Abilities[slot-1]
+}
+
+// RollAbilitySlot picks a new Pokemon's ability slot: the hidden ability at
+// HiddenAbilityChance, otherwise one of the regular abilities at random
+func (s *PokemonSpecies) RollAbilitySlot(r *rand.Rand) AbilitySlot {
+	if s.HiddenAbility != "" && r.Float64() < HiddenAbilityChance {
+		return HiddenAbilitySlot
+	}
+	if len(s.Abilities) < 2 {
+		return AbilitySlot1
+	}
+	return AbilitySlot(r.Intn(len(s.Abilities))) + AbilitySlot1
+}
+
+// Ability returns the Pokemon's ability. A slot its species leaves empty,
+// such as after evolving into a species with one regular ability, falls
+// back to the first slot.
+func (p *UserPokemon) Ability() string {
+	if p.Species == nil {
+		return ""
+	}
+	if ability := p.Species.Ability(p.AbilitySlot); ability != "" {
+		return ability
+	}
+	return p.Species.Ability(AbilitySlot1)
+}
+
+// HasHiddenAbility checks if the Pokemon has its species' hidden ability
+func (p *UserPokemon) HasHiddenAbility() bool {
+	return p.AbilitySlot.IsHidden() && p.Species != nil && p.Species.HiddenAbility != ""
+}
+
+// AbilityChangeResult is a Pokemon after an Ability Capsule and the ability
+// it had before
+type AbilityChangeResult struct {
+	Pokemon         *UserPokemon `json:"pokemon"`
+	PreviousAbility string       `json:"previous_ability"`
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MinAuctionDuration = 5 * time.Minute    // Shortest auction a seller can start
+	MaxAuctionDuration = 7 * 24 * time.Hour // Longest auction a seller can start
+	AntiSnipeWindow    = time.Minute        // Bids this close to the end extend the auction
+	AuctionSettleEvery = 15 * time.Second   // How often the scheduler looks for ended auctions
+)
+
+// Auction is a market listing sold to the highest bidder when it ends.
+// Only the highest bid is held: its coins leave the bidder when placed and
+// are returned as soon as someone outbids them.
+type Auction struct {
+	ListingID       uuid.UUID      `json:"listing_id"`
+	Listing         *MarketListing `json:"listing"`
+	StartingPrice   int            `json:"starting_price"`
+	MinIncrement    int            `json:"min_increment"`
+	CurrentBid      int            `json:"current_bid"` // 0 until the first bid
+	HighestBidderID *uuid.UUID     `json:"highest_bidder_id"`
+	BidCount        int            `json:"bid_count"`
+	EndsAt          time.Time      `json:"ends_at"`
+}
+
+// AuctionBid records a single accepted bid
+type AuctionBid struct {
+	ID        uuid.UUID `json:"id"`
+	ListingID uuid.UUID `json:"listing_id"`
+	BidderID  uuid.UUID `json:"bidder_id"`
+	Amount    int       `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewAuction creates an auction listing for a Pokemon
+func NewAuction(pokemon *UserPokemon, startingPrice, minIncrement int, duration time.Duration) *Auction {
+	listing := NewMarketListing(pokemon, startingPrice)
+	listing.Type = ListingTypeAuction
+
+	return &Auction{
+		ListingID:     listing.ID,
+		Listing:       listing,
+		StartingPrice: startingPrice,
+		MinIncrement:  minIncrement,
+		EndsAt:        listing.ListedAt.Add(duration),
+	}
+}
+
+// SellerID returns the user who started the auction
+func (a *Auction) SellerID() uuid.UUID {
+	return a.Listing.SellerID
+}
+
+// IsActive checks if the auction has not been settled or cancelled
+func (a *Auction) IsActive() bool {
+	return a.Listing.IsActive()
+}
+
+// HasEnded checks if bidding is closed at the given time
+func (a *Auction) HasEnded(now time.Time) bool {
+	return !now.Before(a.EndsAt)
+}
+
+// HasBids checks if anyone has bid yet
+func (a *Auction) HasBids() bool {
+	return a.HighestBidderID != nil
+}
+
+// MinimumBid returns the lowest amount the next bid can be
+func (a *Auction) MinimumBid() int {
+	if !a.HasBids() {
+		return a.StartingPrice
+	}
+	return a.CurrentBid + a.MinIncrement
+}
+
+// ApplyBid makes a bid the highest one. A bid inside the anti-snipe window
+// pushes the end back so others get a full window to respond.
+// Returns true if the auction was extended.
+func (a *Auction) ApplyBid(bidderID uuid.UUID, amount int, now time.Time) bool {
+	a.CurrentBid = amount
+	a.HighestBidderID = &bidderID
+	a.BidCount++
+
+	if a.EndsAt.Sub(now) < AntiSnipeWindow {
+		a.EndsAt = now.Add(AntiSnipeWindow)
+		return true
+	}
+	return false
+}
+
+// Fee returns the coins burned when the auction sells at the current bid
+func (a *Auction) Fee() int {
+	return MarketFee(a.CurrentBid)
+}
+
+// SellerProceeds returns the coins the seller receives for the current bid
+func (a *Auction) SellerProceeds() int {
+	return a.CurrentBid - a.Fee()
+}
+
+// NewAuctionBid records a bid on an auction
+func NewAuctionBid(auction *Auction, bidderID uuid.UUID, amount int) *AuctionBid {
+	return &AuctionBid{
+		ID:        uuid.New(),
+		ListingID: auction.ListingID,
+		BidderID:  bidderID,
+		Amount:    amount,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewAuctionTransaction records the sale of an auction to its highest bidder
+func NewAuctionTransaction(auction *Auction) *MarketTransaction {
+	transaction := &MarketTransaction{
+		ID:          uuid.New(),
+		ListingID:   auction.ListingID,
+		BuyerID:     *auction.HighestBidderID,
+		SellerID:    auction.SellerID(),
+		Price:       auction.CurrentBid,
+		Fee:         auction.Fee(),
+		CompletedAt: time.Now(),
+	}
+	if auction.Listing != nil {
+		transaction.recordPokemon(auction.Listing.Pokemon)
+	}
+	return transaction
+}
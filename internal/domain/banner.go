@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Rarities lists every rarity from most to least common
+var Rarities = []Rarity{Common, Uncommon, Rare, Epic, Legendary, Mythic}
+
+// RateTable is the chance of pulling each rarity. Rates add up to 1.
+type RateTable map[Rarity]float64
+
+// DefaultRates returns the standard rate table
+func DefaultRates() RateTable {
+	rates := make(RateTable, len(Rarities))
+	for _, rarity := range Rarities {
+		rates[rarity] = rarity.DropRate()
+	}
+	return rates
+}
+
+// Total returns the sum of the rates
+func (t RateTable) Total() float64 {
+	total := 0.0
+	for _, rate := range t {
+		total += rate
+	}
+	return total
+}
+
+// Only returns the table limited to some rarities, scaled back up to a
+// total of 1. It is empty if none of the rarities have a rate.
+func (t RateTable) Only(rarities []Rarity) RateTable {
+	only := make(RateTable, len(rarities))
+	for _, rarity := range rarities {
+		if t[rarity] > 0 {
+			only[rarity] = t[rarity]
+		}
+	}
+
+	total := only.Total()
+	for rarity := range only {
+		only[rarity] /= total
+	}
+	return only
+}
+
+// From returns the total rate of a rarity and everything rarer
+func (t RateTable) From(min Rarity) float64 {
+	total := 0.0
+	for rarity, rate := range t {
+		if rarity.Value() >= min.Value() {
+			total += rate
+		}
+	}
+	return total
+}
+
+// WithShare returns the table with the rarity and everything rarer scaled
+// to a total of share, capped at 1, and the commoner rarities scaled to the
+// rest. It is unchanged if either side has no rate to scale.
+func (t RateTable) WithShare(min Rarity, share float64) RateTable {
+	share = math.Min(share, 1)
+	rarer, commoner := t.From(min), t.Total()-t.From(min)
+
+	scaled := make(RateTable, len(t))
+	for rarity, rate := range t {
+		scaled[rarity] = rate
+	}
+	if rarer <= 0 || (commoner <= 0 && share < 1) {
+		return scaled
+	}
+
+	for rarity, rate := range t {
+		if rarity.Value() >= min.Value() {
+			scaled[rarity] = rate * share / rarer
+		} else if commoner > 0 {
+			scaled[rarity] = rate * (1 - share) / commoner
+		}
+	}
+	return scaled
+}
+
+// Roll picks a rarity for a roll in [0, 1), rarest first
+func (t RateTable) Roll(roll float64) Rarity {
+	for i := len(Rarities) - 1; i >= 0; i-- {
+		rarity := Rarities[i]
+		if roll < t[rarity] {
+			return rarity
+		}
+		roll -= t[rarity]
+	}
+
+	// Rounding can leave a sliver past the last rate
+	for _, rarity := range Rarities {
+		if t[rarity] > 0 {
+			return rarity
+		}
+	}
+	return Common
+}
+
+// WithMinimum returns the odds of each rarity on a pull guaranteed at least
+// min, where anything commoner rolled is replaced by the guaranteed rarity
+func (t RateTable) WithMinimum(min, guaranteed Rarity) RateTable {
+	odds := make(RateTable, len(t))
+	for rarity, rate := range t {
+		if rarity.Value() < min.Value() {
+			odds[guaranteed] += rate
+		} else {
+			odds[rarity] += rate
+		}
+	}
+	return odds
+}
+
+// FeaturedSpecies is a rate-up species on a banner. Its weight is how many
+// times more likely it is than other species of its rarity.
+type FeaturedSpecies struct {
+	SpeciesID int     `json:"species_id"`
+	Name      string  `json:"name"`
+	Weight    float64 `json:"weight"`
+}
+
+// Banner is a gacha pool that runs for a limited time. It can limit which
+// species drop, feature some of them at a higher rate and change the rarity
+// rates.
+type Banner struct {
+	ID          string             `json:"id"` // Slug, e.g. "legendary-birds"
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	StartsAt    time.Time          `json:"starts_at"`
+	EndsAt      *time.Time         `json:"ends_at"`      // Nil runs forever
+	SpeciesPool []int              `json:"species_pool"` // Empty pools every species
+	Featured    []*FeaturedSpecies `json:"featured"`
+	Rates       RateTable          `json:"rates"` // Nil uses DefaultRates
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+// IsActive checks if the banner is running at a time
+func (b *Banner) IsActive(at time.Time) bool {
+	if at.Before(b.StartsAt) {
+		return false
+	}
+	return b.EndsAt == nil || at.Before(*b.EndsAt)
+}
+
+// RateTable returns the banner's rarity rates
+func (b *Banner) RateTable() RateTable {
+	if len(b.Rates) == 0 {
+		return DefaultRates()
+	}
+	return b.Rates
+}
+
+// Includes checks if a species can drop from the banner
+func (b *Banner) Includes(speciesID int) bool {
+	if len(b.SpeciesPool) == 0 {
+		return true
+	}
+	for _, id := range b.SpeciesPool {
+		if id == speciesID {
+			return true
+		}
+	}
+	return false
+}
+
+// Weight returns how many times more likely a species is than others of
+// its rarity: its featured weight, or 1
+func (b *Banner) Weight(speciesID int) float64 {
+	for _, featured := range b.Featured {
+		if featured.SpeciesID == speciesID {
+			return featured.Weight
+		}
+	}
+	return 1
+}
+
+// SpeciesWeight weighs a species for a SpeciesSampler over the banner: its
+// DropWeight times its featured weight, or 0 if it isn't in the pool
+func (b *Banner) SpeciesWeight(species *PokemonSpecies) float64 {
+	if !b.Includes(species.ID) {
+		return 0
+	}
+	return species.DropWeight * b.Weight(species.ID)
+}
@@ -36,6 +36,7 @@ type Battle struct {
 	Player1Pokemon uuid.UUID    `json:"player1_pokemon"` // Pokemon ID for 1v1
 	Player2Pokemon uuid.UUID    `json:"player2_pokemon"` // Pokemon ID for 1v1
 	WagerAmount    int          `json:"wager_amount"`    // Coins wagered
+	Format         string       `json:"format"`          // Battle format name
 	Status         BattleStatus `json:"status"`
 	WinnerID       *uuid.UUID   `json:"winner_id"`       // Winner's user ID
 	CurrentTurn    int          `json:"current_turn"`
@@ -79,11 +80,12 @@ type BattleState struct {
 
 // BattlePlayer represents a player's state in battle
 type BattlePlayer struct {
-	UserID         uuid.UUID         `json:"user_id"`
-	Pokemon        *BattlePokemon    `json:"pokemon"`         // Active Pokemon
-	LockedMove     *Move             `json:"locked_move"`     // For Choice items
-	LockedTurns    int               `json:"locked_turns"`    // Turns remaining locked
-	HasMoved       bool              `json:"has_moved"`       // Has moved this turn
+	UserID             uuid.UUID      `json:"user_id"`
+	Pokemon            *BattlePokemon `json:"pokemon"`              // Active Pokemon
+	LockedMove         *Move          `json:"locked_move"`          // For Choice items
+	LockedTurns        int            `json:"locked_turns"`         // Turns remaining locked
+	HasMoved           bool           `json:"has_moved"`            // Has moved this turn
+	SuperEffectiveHits int            `json:"super_effective_hits"` // Super-effective hits landed this battle
 }
 
 // BattlePokemon represents a Pokemon's state during battle
@@ -233,6 +235,7 @@ func NewBattle(player1ID, player2ID uuid.UUID, wagerAmount int) *Battle {
 		Player1ID:   player1ID,
 		Player2ID:   player2ID,
 		WagerAmount: wagerAmount,
+		Format:      FormatStandard,
 		Status:      BattleStatusWaitingForPlayers,
 		CurrentTurn: 0,
 		CreatedAt:   now,
@@ -299,6 +302,11 @@ func (b *BattleState) AddLogEntry(entryType, message string, data map[string]int
 	b.Log = append(b.Log, entry)
 }
 
+// IsDraw checks if both Pokemon fainted on the same turn
+func (b *BattleState) IsDraw() bool {
+	return b.Player1.Pokemon.Fainted && b.Player2.Pokemon.Fainted
+}
+
 // GetOpponent returns the opponent's battle player
 func (b *BattleState) GetOpponent(playerID uuid.UUID) *BattlePlayer {
 	if b.Player1.UserID == playerID {
@@ -323,6 +331,11 @@ func (b *Battle) IsCompleted() bool {
 	return b.Status == BattleStatusCompleted || b.Status == BattleStatusAbandoned
 }
 
+// UsesPokemon checks if either player has selected the Pokemon
+func (b *Battle) UsesPokemon(pokemonID uuid.UUID) bool {
+	return b.Player1Pokemon == pokemonID || b.Player2Pokemon == pokemonID
+}
+
 // CanPlayerJoin checks if a player can join the battle
 func (b *Battle) CanPlayerJoin(playerID uuid.UUID) bool {
 	return b.Status == BattleStatusWaitingForPlayers &&
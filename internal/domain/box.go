@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidBoxCursor is returned for a cursor that can't be parsed or
+// belongs to a different sort
+var ErrInvalidBoxCursor = errors.New("invalid box cursor")
+
+const (
+	DefaultBoxPageSize = 50  // Pokemon per page when no limit is given
+	MaxBoxPageSize     = 100 // Most Pokemon a page can hold
+)
+
+// BoxSort is what a box is ordered by
+type BoxSort string
+
+const (
+	BoxSortAcquired BoxSort = "acquired" // Acquisition date
+	BoxSortIV       BoxSort = "iv"       // IV percentage
+	BoxSortValue    BoxSort = "value"    // Formula value, before market prices
+	BoxSortStats    BoxSort = "stats"    // Total calculated stats
+)
+
+// IsValid checks if a box sort is known
+func (s BoxSort) IsValid() bool {
+	switch s {
+	case BoxSortAcquired, BoxSortIV, BoxSortValue, BoxSortStats:
+		return true
+	}
+	return false
+}
+
+// BoxFilter selects and orders a page of a user's box. Every criterion
+// that is set must match; unset criteria match everything.
+type BoxFilter struct {
+	Rarity       Rarity
+	SpeciesID    int
+	SpeciesName  string
+	Type         PokemonType // Either of the Pokemon's types, taking its form into account
+	Nature       Nature
+	MinIVPercent float64
+	Favorite     *bool
+	Shiny        *bool
+	Nickname     string     // Part of the nickname, ignoring case
+	Sort         BoxSort    // BoxSortAcquired when empty
+	Ascending    bool       // Boxes list the highest or newest Pokemon first unless set
+	Limit        int        // DefaultBoxPageSize when 0
+	Cursor       *BoxCursor // Continue after the Pokemon the cursor points at
+}
+
+// Matches checks if a Pokemon meets every criterion of the filter
+func (f *BoxFilter) Matches(p *UserPokemon) bool {
+	species := p.EffectiveSpecies()
+	switch {
+	case f.Rarity != "" && (species == nil || species.Rarity != f.Rarity):
+		return false
+	case f.SpeciesID != 0 && p.SpeciesID != f.SpeciesID:
+		return false
+	case f.SpeciesName != "" && (species == nil || !strings.EqualFold(species.Name, f.SpeciesName)):
+		return false
+	case f.Type != "" && (species == nil || (species.Type1 != f.Type && (species.Type2 == nil || *species.Type2 != f.Type))):
+		return false
+	case f.Nature != "" && p.Nature != f.Nature:
+		return false
+	case f.MinIVPercent > 0 && p.IVs.IVPercentage() < f.MinIVPercent:
+		return false
+	case f.Favorite != nil && p.IsFavorite != *f.Favorite:
+		return false
+	case f.Shiny != nil && p.IsShiny != *f.Shiny:
+		return false
+	case f.Nickname != "" && !strings.Contains(strings.ToLower(p.Nickname), strings.ToLower(f.Nickname)):
+		return false
+	}
+	return true
+}
+
+// BoxSortKey returns the value a Pokemon is ordered by in a sort.
+// Acquisition dates are in microseconds, the precision they are stored at.
+func (p *UserPokemon) BoxSortKey(sort BoxSort) int64 {
+	switch sort {
+	case BoxSortIV:
+		return int64(p.IVs.TotalIVs())
+	case BoxSortValue:
+		return int64(p.FormulaValue())
+	case BoxSortStats:
+		return int64(p.TotalStats())
+	}
+	return p.AcquiredAt.UnixMicro()
+}
+
+// BoxCursor points at the last Pokemon of a page. Pokemon are ordered by
+// their sort key, then by ID so ties keep a stable order across pages.
+type BoxCursor struct {
+	Sort BoxSort
+	Key  int64
+	ID   uuid.UUID
+}
+
+// NewBoxCursor points a cursor at a Pokemon
+func NewBoxCursor(sort BoxSort, p *UserPokemon) *BoxCursor {
+	return &BoxCursor{Sort: sort, Key: p.BoxSortKey(sort), ID: p.ID}
+}
+
+// String encodes the cursor for clients, who pass it back unchanged
+func (c *BoxCursor) String() string {
+	raw := fmt.Sprintf("%s:%d:%s", c.Sort, c.Key, c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseBoxCursor decodes a cursor from BoxCursor.String
+func ParseBoxCursor(encoded string) (*BoxCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidBoxCursor
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 || !BoxSort(parts[0]).IsValid() {
+		return nil, ErrInvalidBoxCursor
+	}
+	key, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidBoxCursor
+	}
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return nil, ErrInvalidBoxCursor
+	}
+
+	return &BoxCursor{Sort: BoxSort(parts[0]), Key: key, ID: id}, nil
+}
+
+// Precedes checks if the cursor comes before a Pokemon with the given
+// sort key and ID, i.e. the Pokemon belongs on a later page
+func (c *BoxCursor) Precedes(key int64, id uuid.UUID, ascending bool) bool {
+	cmp := 0
+	switch {
+	case key < c.Key:
+		cmp = -1
+	case key > c.Key:
+		cmp = 1
+	default:
+		cmp = bytes.Compare(id[:], c.ID[:])
+	}
+	if ascending {
+		return cmp > 0
+	}
+	return cmp < 0
+}
+
+// BoxPage is one page of a user's box
+type BoxPage struct {
+	Pokemon    []*UserPokemon
+	Total      int        // Pokemon matching the filter across every page
+	NextCursor *BoxCursor // Nil on the last page
+}
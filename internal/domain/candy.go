@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CandyPerRerolledIV = 10 // Candy to re-roll one IV
+	CandyPerIVPoint    = 3  // Candy to raise one IV by one point (hyper training)
+	CandyPerMint       = 25 // Candy to change a Pokemon's nature
+	MaxIV              = 31
+)
+
+// IVStats are the stat names accepted when choosing IVs to change
+var IVStats = []string{"hp", "attack", "defense", "sp_attack", "sp_defense", "speed"}
+
+// CandyForRelease returns the candy earned by releasing a Pokemon of the given rarity.
+// Rarer species are harder to duplicate, so their candy comes faster.
+func CandyForRelease(rarity Rarity) int {
+	return rarity.Value()
+}
+
+// CandyBalance is how much of one species' candy a user holds
+type CandyBalance struct {
+	UserID    uuid.UUID `json:"user_id"`
+	SpeciesID int       `json:"species_id"`
+	Amount    int       `json:"amount"`
+}
+
+// PokemonAuditAction is the kind of change recorded in the audit log
+type PokemonAuditAction string
+
+const (
+	AuditRelease        PokemonAuditAction = "release"         // Released for coins and candy
+	AuditIVReroll       PokemonAuditAction = "iv_reroll"       // IVs re-rolled with candy
+	AuditIVRaise        PokemonAuditAction = "iv_raise"        // IVs raised with candy
+	AuditNatureMint     PokemonAuditAction = "nature_mint"     // Nature changed with candy
+	AuditEvolve         PokemonAuditAction = "evolve"          // Evolved into another species
+	AuditEVTrain        PokemonAuditAction = "ev_train"        // EVs trained with coins or vitamins
+	AuditAbilityCapsule PokemonAuditAction = "ability_capsule" // Ability slot changed with an Ability Capsule
+)
+
+// PokemonTraits are the parts of a Pokemon that candy and training can change
+type PokemonTraits struct {
+	SpeciesID   int         `json:"species_id"`
+	IVs         IVs         `json:"ivs"`
+	EVs         EVs         `json:"evs"`
+	Nature      Nature      `json:"nature"`
+	AbilitySlot AbilitySlot `json:"ability_slot"`
+}
+
+// Traits snapshots a Pokemon's changeable traits
+func (p *UserPokemon) Traits() *PokemonTraits {
+	return &PokemonTraits{SpeciesID: p.SpeciesID, IVs: p.IVs, EVs: p.EVs, Nature: p.Nature, AbilitySlot: p.AbilitySlot}
+}
+
+// PokemonAuditEntry records one change to a Pokemon and the candy it moved.
+// Entries outlive the Pokemon, so a release is still on record afterwards.
+type PokemonAuditEntry struct {
+	ID         uuid.UUID          `json:"id"`
+	UserID     uuid.UUID          `json:"user_id"`
+	PokemonID  uuid.UUID          `json:"pokemon_id"`
+	SpeciesID  int                `json:"species_id"`
+	Action     PokemonAuditAction `json:"action"`
+	CandyDelta int                `json:"candy_delta"` // Negative when candy was spent
+	Before     *PokemonTraits     `json:"before"`
+	After      *PokemonTraits     `json:"after"` // Nil once released
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// NewPokemonAuditEntry records a change from before to the Pokemon's current traits
+func NewPokemonAuditEntry(pokemon *UserPokemon, action PokemonAuditAction, candyDelta int, before *PokemonTraits) *PokemonAuditEntry {
+	entry := &PokemonAuditEntry{
+		ID:         uuid.New(),
+		UserID:     pokemon.UserID,
+		PokemonID:  pokemon.ID,
+		SpeciesID:  pokemon.SpeciesID,
+		Action:     action,
+		CandyDelta: candyDelta,
+		Before:     before,
+		CreatedAt:  time.Now(),
+	}
+	if action != AuditRelease {
+		entry.After = pokemon.Traits()
+	}
+	return entry
+}
+
+// IV returns a pointer to the IV for a stat name in IVStats, or nil if unknown
+func (iv *IVs) IV(stat string) *int {
+	switch stat {
+	case "hp":
+		return &iv.HP
+	case "attack":
+		return &iv.Attack
+	case "defense":
+		return &iv.Defense
+	case "sp_attack":
+		return &iv.SpAttack
+	case "sp_defense":
+		return &iv.SpDefense
+	case "speed":
+		return &iv.Speed
+	default:
+		return nil
+	}
+}
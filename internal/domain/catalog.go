@@ -0,0 +1,99 @@
+package domain
+
+const (
+	SpeciesSortID    = "id"
+	SpeciesSortName  = "name"
+	SpeciesStatTotal = "total" // Sum of the six base stats
+)
+
+// SpeciesStats are the base stats the species catalog filters and sorts by:
+// each stat in IVStats and their total
+var SpeciesStats = []string{"hp", "attack", "defense", "sp_attack", "sp_defense", "speed", SpeciesStatTotal}
+
+// SpeciesSearchFilter narrows down the species catalog. Zero values mean "any".
+type SpeciesSearchFilter struct {
+	Type1      PokemonType
+	Type2      PokemonType
+	Rarity     Rarity
+	MinStats   map[string]int // Stat in SpeciesStats -> lowest base value
+	MaxStats   map[string]int // Stat in SpeciesStats -> highest base value
+	Sort       string         // SpeciesSortID, SpeciesSortName or a stat in SpeciesStats
+	Descending bool
+	Limit      int
+	Offset     int
+}
+
+// IsValidSpeciesSort checks if species can be sorted by a key
+func IsValidSpeciesSort(sort string) bool {
+	if sort == SpeciesSortID || sort == SpeciesSortName {
+		return true
+	}
+	_, ok := (&PokemonSpecies{}).BaseStat(sort)
+	return ok
+}
+
+// BaseStatTotal returns the sum of the species' six base stats
+func (s *PokemonSpecies) BaseStatTotal() int {
+	return s.BaseHP + s.BaseAttack + s.BaseDefense + s.BaseSpAttack + s.BaseSpDefense + s.BaseSpeed
+}
+
+// BaseStat returns a base stat by its name in SpeciesStats, or false if the
+// name is unknown
+func (s *PokemonSpecies) BaseStat(stat string) (int, bool) {
+	switch stat {
+	case "hp":
+		return s.BaseHP, true
+	case "attack":
+		return s.BaseAttack, true
+	case "defense":
+		return s.BaseDefense, true
+	case "sp_attack":
+		return s.BaseSpAttack, true
+	case "sp_defense":
+		return s.BaseSpDefense, true
+	case "speed":
+		return s.BaseSpeed, true
+	case SpeciesStatTotal:
+		return s.BaseStatTotal(), true
+	}
+	return 0, false
+}
+
+// SpeciesAbility describes one of a species' abilities
+type SpeciesAbility struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Description string `json:"description,omitempty"` // Empty if the ability has no battle effect
+	Hidden      bool   `json:"hidden"`
+}
+
+// AbilityList describes the species' regular abilities followed by its
+// hidden ability
+func (s *PokemonSpecies) AbilityList() []SpeciesAbility {
+	names := s.Abilities
+	if s.HiddenAbility != "" {
+		names = append(names[:len(names):len(names)], s.HiddenAbility)
+	}
+
+	list := make([]SpeciesAbility, len(names))
+	for i, name := range names {
+		list[i] = SpeciesAbility{
+			Name:        name,
+			DisplayName: AbilityDisplayName(name),
+			Hidden:      i == len(s.Abilities),
+		}
+		if ability := GetAbilityByName(name); ability != nil {
+			list[i].Description = ability.Description
+		}
+	}
+	return list
+}
+
+// SpeciesDetails is a catalog entry: a species with its type matchups,
+// learnset and abilities
+type SpeciesDetails struct {
+	Species   *PokemonSpecies  `json:"species"`
+	Matchups  *TypeMatchup     `json:"matchups"`
+	Learnset  []*Move          `json:"learnset"`
+	Abilities []SpeciesAbility `json:"abilities"`
+}
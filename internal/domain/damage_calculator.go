@@ -136,6 +136,12 @@ func (dc *DamageCalculator) CalculateDamage(ctx *DamageContext) *DamageResult {
 		finalDamage = 1
 	}
 
+	// Sturdy survives a knockout hit from full HP
+	if ctx.DefenderAbility != nil && ctx.DefenderAbility.Name == AbilitySturdy &&
+		ctx.Defender.CurrentHP == ctx.Defender.MaxHP && finalDamage >= ctx.Defender.CurrentHP {
+		finalDamage = ctx.Defender.CurrentHP - 1
+	}
+
 	result.Damage = finalDamage
 
 	// Apply damage to defender
@@ -194,6 +200,13 @@ func (dc *DamageCalculator) CheckAccuracy(ctx *DamageContext) bool {
 
 	if ctx.DefenderAbility != nil {
 		for _, effect := range ctx.DefenderAbility.Effects {
+			if effect.Condition != nil && !effect.Condition.MeetsCondition(&BattleContext{
+				CurrentHP: ctx.Defender.CurrentHP,
+				MaxHP:     ctx.Defender.MaxHP,
+				Weather:   ctx.Weather,
+			}) {
+				continue
+			}
 			if effect.EvasionModifier > 0 {
 				evasionMultiplier *= effect.EvasionModifier
 			}
@@ -253,6 +266,20 @@ func (dc *DamageCalculator) CalculateTypeEffectiveness(ctx *DamageContext) float
 		defenderType2 = ctx.Defender.Species.Type2
 	}
 
+	// Abilities that block a move type grant immunity (Levitate)
+	if ctx.DefenderAbility != nil {
+		for _, effect := range ctx.DefenderAbility.Effects {
+			if effect.Type != EffectMoveBlock {
+				continue
+			}
+			for _, t := range effect.BlockedMoveTypes {
+				if t == moveType {
+					return 0.0
+				}
+			}
+		}
+	}
+
 	return CalculateTypeEffectiveness(moveType, &defenderType1, defenderType2)
 }
 
@@ -285,7 +312,7 @@ func (dc *DamageCalculator) GetEffectiveAttackStat(ctx *DamageContext) int {
 	// Apply ability modifiers (Huge Power, Guts, etc.)
 	if ctx.AttackerAbility != nil {
 		for _, effect := range ctx.AttackerAbility.Effects {
-			if effect.Type == EffectDamageModifier && ctx.Move.Category == Physical {
+			if effect.Type == EffectStatBoost && effect.DamageMultiplier > 0 && ctx.Move.Category == Physical {
 				// Check conditions
 				if effect.Condition == nil || effect.Condition.MeetsCondition(&BattleContext{
 					CurrentHP:    ctx.Attacker.CurrentHP,
@@ -333,12 +360,13 @@ func (dc *DamageCalculator) GetEffectiveDefenseStat(ctx *DamageContext) int {
 	// Apply ability modifiers (Marvel Scale, etc.)
 	if ctx.DefenderAbility != nil {
 		for _, effect := range ctx.DefenderAbility.Effects {
-			if effect.Type == EffectDamageModifier {
+			if effect.Type == EffectStatBoost && effect.DamageMultiplier > 0 {
 				if effect.Condition == nil || effect.Condition.MeetsCondition(&BattleContext{
 					CurrentHP:    ctx.Defender.CurrentHP,
 					MaxHP:        ctx.Defender.MaxHP,
 					Status:       ctx.Defender.Status,
 					Weather:      ctx.Weather,
+					MoveCategory: ctx.Move.Category,
 				}) {
 					stat *= effect.DamageMultiplier
 				}
@@ -437,11 +465,17 @@ func (dc *DamageCalculator) GetAbilityModifier(ctx *DamageContext) float64 {
 	modifier := 1.0
 
 	if ctx.AttackerAbility == nil {
-		return modifier
+		return modifier * dc.GetDefenderAbilityModifier(ctx)
+	}
+
+	// Technician boosts weak moves
+	if ctx.AttackerAbility.Name == AbilityTechnician && ctx.Move.Power <= 60 {
+		modifier *= 1.5
 	}
 
 	for _, effect := range ctx.AttackerAbility.Effects {
-		if effect.Type != EffectDamageModifier {
+		// Effects with affected types reduce damage taken (Thick Fat)
+		if effect.Type != EffectDamageModifier || len(effect.AffectedTypes) > 0 {
 			continue
 		}
 
@@ -492,6 +526,13 @@ func (dc *DamageCalculator) GetAbilityModifier(ctx *DamageContext) float64 {
 		modifier *= effect.DamageMultiplier
 	}
 
+	return modifier * dc.GetDefenderAbilityModifier(ctx)
+}
+
+// GetDefenderAbilityModifier returns the defender's ability damage modifiers
+func (dc *DamageCalculator) GetDefenderAbilityModifier(ctx *DamageContext) float64 {
+	modifier := 1.0
+
 	// Check defender ability (resistances like Thick Fat)
 	if ctx.DefenderAbility != nil {
 		for _, effect := range ctx.DefenderAbility.Effects {
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EscrowStatus represents the lifecycle state of a battle wager escrow
+type EscrowStatus string
+
+const (
+	EscrowStatusHeld     EscrowStatus = "held"     // Wagers locked while the battle runs
+	EscrowStatusPaidOut  EscrowStatus = "paid_out" // Pot paid to the winner
+	EscrowStatusRefunded EscrowStatus = "refunded" // Wagers returned (draw, crash, abandonment)
+)
+
+const (
+	BattleInactivityTimeout = 10 * time.Minute // How long a battle may go without actions before it is abandoned
+	BattleSweepEvery        = time.Minute      // How often inactive battles are looked for
+)
+
+// BattleEscrow holds both players' wagers for the duration of a battle.
+// Coins only ever leave the escrow once: either the pot goes to the winner
+// or each player gets their stake back.
+type BattleEscrow struct {
+	BattleID  uuid.UUID    `json:"battle_id"`
+	Player1ID uuid.UUID    `json:"player1_id"`
+	Player2ID uuid.UUID    `json:"player2_id"`
+	Amount    int          `json:"amount"` // Stake per player
+	Status    EscrowStatus `json:"status"`
+	WinnerID  *uuid.UUID   `json:"winner_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	SettledAt *time.Time   `json:"settled_at"`
+}
+
+// NewBattleEscrow creates a held escrow for a battle's wager
+func NewBattleEscrow(battle *Battle) *BattleEscrow {
+	return &BattleEscrow{
+		BattleID:  battle.ID,
+		Player1ID: battle.Player1ID,
+		Player2ID: battle.Player2ID,
+		Amount:    battle.WagerAmount,
+		Status:    EscrowStatusHeld,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Pot returns the total coins held (both stakes)
+func (e *BattleEscrow) Pot() int {
+	return e.Amount * 2
+}
+
+// IsSettled checks if the escrow has already been paid out or refunded
+func (e *BattleEscrow) IsSettled() bool {
+	return e.Status == EscrowStatusPaidOut || e.Status == EscrowStatusRefunded
+}
+
+// HasPlayer checks if the user is one of the escrow's players
+func (e *BattleEscrow) HasPlayer(userID uuid.UUID) bool {
+	return e.Player1ID == userID || e.Player2ID == userID
+}
+
+// SettledAs checks if the escrow was already settled with the given outcome.
+// Used to make repeated settlement calls no-ops instead of errors.
+func (e *BattleEscrow) SettledAs(status EscrowStatus, winnerID *uuid.UUID) bool {
+	if e.Status != status {
+		return false
+	}
+	if status != EscrowStatusPaidOut {
+		return true
+	}
+	return e.WinnerID != nil && winnerID != nil && *e.WinnerID == *winnerID
+}
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	MaxEVPerStat = 252 // Most EVs one stat can hold
+	MaxTotalEVs  = 510 // Most EVs a Pokemon can hold across all stats
+
+	CoinsPerEV    = 2  // Coins to train one EV point
+	EVsPerVitamin = 10 // EV points one vitamin trains
+)
+
+// ErrInvalidEVSpread is returned when an EV spread cannot be parsed
+var ErrInvalidEVSpread = errors.New("invalid EV spread")
+
+// PokemonEVs represents Effort Values (0-252 per stat, 510 in total)
+type PokemonEVs struct {
+	HP        int `json:"hp"`
+	Attack    int `json:"attack"`
+	Defense   int `json:"defense"`
+	SpAttack  int `json:"sp_attack"`
+	SpDefense int `json:"sp_defense"`
+	Speed     int `json:"speed"`
+}
+
+// EVs is an alias for PokemonEVs, matching IVs
+type EVs = PokemonEVs
+
+// EVPayment is what EV training is paid with
+type EVPayment string
+
+const (
+	PayWithCoins    EVPayment = "coins"    // CoinsPerEV for every point
+	PayWithVitamins EVPayment = "vitamins" // One stat's vitamin per EVsPerVitamin points
+)
+
+// Vitamins are the items that train each stat's EVs
+var Vitamins = map[string]string{
+	"hp":         "hp-up",
+	"attack":     "protein",
+	"defense":    "iron",
+	"sp_attack":  "calcium",
+	"sp_defense": "zinc",
+	"speed":      "carbos",
+}
+
+// evLabels are the Showdown names of each stat, in IVStats order
+var evLabels = []string{"HP", "Atk", "Def", "SpA", "SpD", "Spe"}
+
+// Total returns the sum of all EVs
+func (ev *EVs) Total() int {
+	return ev.HP + ev.Attack + ev.Defense + ev.SpAttack + ev.SpDefense + ev.Speed
+}
+
+// EV returns a pointer to the EV for a stat name in IVStats, or nil if unknown
+func (ev *EVs) EV(stat string) *int {
+	switch stat {
+	case "hp":
+		return &ev.HP
+	case "attack":
+		return &ev.Attack
+	case "defense":
+		return &ev.Defense
+	case "sp_attack":
+		return &ev.SpAttack
+	case "sp_defense":
+		return &ev.SpDefense
+	case "speed":
+		return &ev.Speed
+	default:
+		return nil
+	}
+}
+
+// Add returns the EVs with other's points added to each stat
+func (ev EVs) Add(other EVs) EVs {
+	for _, stat := range IVStats {
+		*ev.EV(stat) += *other.EV(stat)
+	}
+	return ev
+}
+
+// String formats the EVs as a Showdown spread, e.g. "252 Atk / 4 SpD / 252 Spe".
+// Stats without EVs are left out.
+func (ev EVs) String() string {
+	var parts []string
+	for i, stat := range IVStats {
+		if points := *ev.EV(stat); points > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", points, evLabels[i]))
+		}
+	}
+	return strings.Join(parts, " / ")
+}
+
+// ParseEVs reads a Showdown spread such as "252 Atk / 4 SpD / 252 Spe",
+// with or without a leading "EVs:". Limits are left to validation.
+func ParseEVs(spread string) (EVs, error) {
+	var evs EVs
+	spread = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(spread), "EVs:"))
+	if spread == "" {
+		return evs, nil
+	}
+
+	for _, part := range strings.Split(spread, "/") {
+		fields := strings.Fields(part)
+		if len(fields) != 2 {
+			return EVs{}, fmt.Errorf("%w: %q", ErrInvalidEVSpread, strings.TrimSpace(part))
+		}
+
+		points, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return EVs{}, fmt.Errorf("%w: %q", ErrInvalidEVSpread, strings.TrimSpace(part))
+		}
+
+		stat := evStat(fields[1])
+		if stat == "" {
+			return EVs{}, fmt.Errorf("%w: unknown stat %q", ErrInvalidEVSpread, fields[1])
+		}
+		*evs.EV(stat) += points
+	}
+
+	return evs, nil
+}
+
+// evStat returns the stat name for a Showdown label or stat name
+func evStat(label string) string {
+	for i, l := range evLabels {
+		if strings.EqualFold(label, l) || strings.EqualFold(label, IVStats[i]) {
+			return IVStats[i]
+		}
+	}
+	return ""
+}
+
+// EVTrainingCost is what training a set of EVs costs
+type EVTrainingCost struct {
+	Coins    int            `json:"coins,omitempty"`
+	Vitamins map[string]int `json:"vitamins,omitempty"` // Vitamin item -> count
+}
+
+// TrainingCost returns the cost of training the EVs with a payment method.
+// Vitamins are whole items, so each stat rounds up to the next vitamin.
+func (ev EVs) TrainingCost(payment EVPayment) EVTrainingCost {
+	if payment != PayWithVitamins {
+		return EVTrainingCost{Coins: ev.Total() * CoinsPerEV}
+	}
+
+	cost := EVTrainingCost{Vitamins: make(map[string]int)}
+	for _, stat := range IVStats {
+		if points := *ev.EV(stat); points > 0 {
+			cost.Vitamins[Vitamins[stat]] = (points + EVsPerVitamin - 1) / EVsPerVitamin
+		}
+	}
+	return cost
+}
+
+// EVTrainingResult is a Pokemon after EV training and what it cost
+type EVTrainingResult struct {
+	Pokemon *UserPokemon   `json:"pokemon"`
+	Cost    EVTrainingCost `json:"cost"`
+}
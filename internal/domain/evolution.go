@@ -0,0 +1,92 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Evolution is one step of a species' evolution chain and what it takes.
+// Requirements left at zero (or an empty item) are not needed.
+type Evolution struct {
+	FromSpeciesID int             `json:"from_species_id"`
+	ToSpeciesID   int             `json:"to_species_id"`
+	ToSpecies     *PokemonSpecies `json:"to_species,omitempty"` // Populated when needed
+	CandyCost     int             `json:"candy_cost"`           // Candy of the evolving species
+	CoinCost      int             `json:"coin_cost"`
+	Item          string          `json:"item,omitempty"` // Consumed from the user's inventory
+	BattleWins    int             `json:"battle_wins"`    // Battles the Pokemon itself has won
+}
+
+// EvolutionRequirement names a requirement of an evolution
+type EvolutionRequirement string
+
+const (
+	RequireCandy      EvolutionRequirement = "candy"
+	RequireCoins      EvolutionRequirement = "coins"
+	RequireItem       EvolutionRequirement = "item"
+	RequireBattleWins EvolutionRequirement = "battle_wins"
+)
+
+// EvolutionProgress is what a user and their Pokemon have toward an evolution
+type EvolutionProgress struct {
+	Candy      int `json:"candy"`
+	Coins      int `json:"coins"`
+	Items      int `json:"items"` // Held count of the evolution's item
+	BattleWins int `json:"battle_wins"`
+}
+
+// Unmet returns the requirements progress does not cover yet
+func (e *Evolution) Unmet(progress EvolutionProgress) []EvolutionRequirement {
+	var unmet []EvolutionRequirement
+	if progress.Candy < e.CandyCost {
+		unmet = append(unmet, RequireCandy)
+	}
+	if progress.Coins < e.CoinCost {
+		unmet = append(unmet, RequireCoins)
+	}
+	if e.Item != "" && progress.Items < 1 {
+		unmet = append(unmet, RequireItem)
+	}
+	if progress.BattleWins < e.BattleWins {
+		unmet = append(unmet, RequireBattleWins)
+	}
+	return unmet
+}
+
+// EvolutionOption is an evolution available to a Pokemon and what is still missing
+type EvolutionOption struct {
+	Evolution *Evolution             `json:"evolution"`
+	Progress  EvolutionProgress      `json:"progress"`
+	Unmet     []EvolutionRequirement `json:"unmet"`
+}
+
+// CanEvolve checks if every requirement is met
+func (o *EvolutionOption) CanEvolve() bool {
+	return len(o.Unmet) == 0
+}
+
+// EvolutionResult is an evolved Pokemon and the moves it unlocked
+type EvolutionResult struct {
+	Pokemon       *UserPokemon    `json:"pokemon"`
+	FromSpecies   *PokemonSpecies `json:"from_species"`
+	UnlockedMoves []*Move         `json:"unlocked_moves"`
+}
+
+// InventoryItem is how many of an item a user holds
+type InventoryItem struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Item     string    `json:"item"`
+	Quantity int       `json:"quantity"`
+}
+
+// Evolve turns a Pokemon into the evolution's species. IVs, nature,
+// shininess, gender, nickname and level are kept; stats follow from the new
+// base stats. A form carries over when the new species has one of the same
+// name, so an Alolan Geodude becomes an Alolan Golem.
+func (p *UserPokemon) Evolve(species *PokemonSpecies) {
+	var form *PokemonForm
+	if p.Form != nil {
+		form = species.Form(p.Form.Name)
+	}
+
+	p.SpeciesID = species.ID
+	p.Species = species
+	p.SetForm(form)
+}
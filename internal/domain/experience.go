@@ -0,0 +1,140 @@
+package domain
+
+import "github.com/google/uuid"
+
+const (
+	MinLevel     = 1
+	MaxLevel     = 100
+	DefaultLevel = 50 // Level of newly rolled Pokemon
+
+	DefaultBaseExperience = 64 // Experience yield of species without one
+
+	// Losing a battle still teaches something: the loser's Pokemon earns
+	// this share of what a win would have given
+	LoserExperienceDivisor = 3
+)
+
+// GrowthRate is how much experience a species needs per level
+type GrowthRate string
+
+const (
+	GrowthFast       GrowthRate = "fast"
+	GrowthMediumFast GrowthRate = "medium_fast"
+	GrowthMediumSlow GrowthRate = "medium_slow"
+	GrowthSlow       GrowthRate = "slow"
+)
+
+// IsValid checks if the growth rate is known
+func (g GrowthRate) IsValid() bool {
+	switch g {
+	case GrowthFast, GrowthMediumFast, GrowthMediumSlow, GrowthSlow:
+		return true
+	default:
+		return false
+	}
+}
+
+// ExperienceForLevel returns the total experience needed to reach a level.
+// Unknown growth rates use the medium fast curve.
+func (g GrowthRate) ExperienceForLevel(level int) int {
+	if level <= MinLevel {
+		return 0
+	}
+	if level > MaxLevel {
+		level = MaxLevel
+	}
+
+	n := level
+	switch g {
+	case GrowthFast:
+		return 4 * n * n * n / 5
+	case GrowthMediumSlow:
+		return 6*n*n*n/5 - 15*n*n + 100*n - 140
+	case GrowthSlow:
+		return 5 * n * n * n / 4
+	default:
+		return n * n * n
+	}
+}
+
+// LevelForExperience returns the level reached with a total amount of experience
+func (g GrowthRate) LevelForExperience(experience int) int {
+	level := MinLevel
+	for level < MaxLevel && experience >= g.ExperienceForLevel(level+1) {
+		level++
+	}
+	return level
+}
+
+// ExperienceGain is the experience one Pokemon earned from a battle
+type ExperienceGain struct {
+	PokemonID    uuid.UUID `json:"pokemon_id"`
+	Experience   int       `json:"experience"`
+	Level        int       `json:"level"`
+	LevelsGained int       `json:"levels_gained"`
+}
+
+// BattleExperience returns the experience for beating a Pokemon of the given
+// species at the given level. A loss earns a share of it.
+func BattleExperience(defeated *PokemonSpecies, level int, won bool) int {
+	yield := defeated.BaseExperience
+	if yield <= 0 {
+		yield = DefaultBaseExperience
+	}
+
+	experience := yield * level / 7
+	if !won {
+		experience /= LoserExperienceDivisor
+	}
+	if experience < 1 {
+		experience = 1
+	}
+	return experience
+}
+
+// GainExperience adds experience and levels the Pokemon up. Experience stops
+// at what MaxLevel needs. Returns the number of levels gained.
+func (p *UserPokemon) GainExperience(amount int) int {
+	var rate GrowthRate
+	if p.Species != nil {
+		rate = p.Species.GrowthRate
+	}
+
+	p.Experience += amount
+	if max := rate.ExperienceForLevel(MaxLevel); p.Experience > max {
+		p.Experience = max
+	}
+
+	before := p.Level
+	if level := rate.LevelForExperience(p.Experience); level > p.Level {
+		p.Level = level
+	}
+	return p.Level - before
+}
+
+// ExperienceToNextLevel returns the experience still needed for the next level
+func (p *UserPokemon) ExperienceToNextLevel() int {
+	if p.Level >= MaxLevel || p.Species == nil {
+		return 0
+	}
+	return p.Species.GrowthRate.ExperienceForLevel(p.Level+1) - p.Experience
+}
+
+// AtLevel returns a copy of the Pokemon at another level, for battles
+// that scale levels. The copy's stats follow the new level.
+func (p *UserPokemon) AtLevel(level int) *UserPokemon {
+	scaled := *p
+	scaled.Level = level
+	return &scaled
+}
+
+// WildBattleResult is the outcome of a battle against a wild Pokemon
+type WildBattleResult struct {
+	Pokemon   *UserPokemon    `json:"pokemon"`
+	Wild      *PokemonSpecies `json:"wild"`
+	WildLevel int             `json:"wild_level"`
+	Format    string          `json:"format"`
+	Won       bool            `json:"won"`
+	Turns     int             `json:"turns"`
+	Gain      *ExperienceGain `json:"gain"`
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	mathrand "math/rand"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ServerSeedBytes = 32 // Random bytes in a server seed
+	ClientSeedBytes = 16 // Random bytes in a client seed the server picks
+)
+
+// FairSeed is the pair of seeds a player's provably fair pulls are derived
+// from. The server commits to its seed by publishing the hash before any
+// pull and only reveals the seed itself when the pair is rotated, so it
+// can't change the seed to suit itself and players can't predict pulls.
+type FairSeed struct {
+	ID             uuid.UUID  `json:"id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	ServerSeed     string     `json:"server_seed,omitempty"` // Secret until revealed
+	ServerSeedHash string     `json:"server_seed_hash"`
+	ClientSeed     string     `json:"client_seed"`
+	Nonce          int        `json:"nonce"` // Nonce of the next pull
+	CreatedAt      time.Time  `json:"created_at"`
+	RevealedAt     *time.Time `json:"revealed_at,omitempty"`
+}
+
+// NewFairSeed creates a seed pair with a fresh server seed. An empty client
+// seed is picked at random.
+func NewFairSeed(userID uuid.UUID, clientSeed string) (*FairSeed, error) {
+	serverSeed, err := randomHex(ServerSeedBytes)
+	if err != nil {
+		return nil, err
+	}
+	if clientSeed == "" {
+		if clientSeed, err = randomHex(ClientSeedBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	return &FairSeed{
+		ID:             uuid.New(),
+		UserID:         userID,
+		ServerSeed:     serverSeed,
+		ServerSeedHash: HashServerSeed(serverSeed),
+		ClientSeed:     clientSeed,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate seed: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashServerSeed returns the hex SHA-256 of a server seed, the commitment
+// players see before it is revealed
+func HashServerSeed(serverSeed string) string {
+	sum := sha256.Sum256([]byte(serverSeed))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsRevealed checks if the server seed has been revealed
+func (s *FairSeed) IsRevealed() bool {
+	return s.RevealedAt != nil
+}
+
+// Reveal marks the server seed as revealed. No more pulls use it.
+func (s *FairSeed) Reveal() {
+	now := time.Now()
+	s.RevealedAt = &now
+}
+
+// Public returns a copy safe to show the player: the server seed is left out
+// until it is revealed
+func (s *FairSeed) Public() *FairSeed {
+	public := *s
+	if !s.IsRevealed() {
+		public.ServerSeed = ""
+	}
+	return &public
+}
+
+// NextPull returns the proof for the next pull and the rand it is rolled
+// from, and moves on to the next nonce
+func (s *FairSeed) NextPull(bannerID string, rates RateTable, minRarity Rarity) (*FairProof, *mathrand.Rand) {
+	proof := &FairProof{
+		ServerSeedHash: s.ServerSeedHash,
+		ClientSeed:     s.ClientSeed,
+		Nonce:          s.Nonce,
+		BannerID:       bannerID,
+		Rates:          rates,
+		MinRarity:      minRarity,
+	}
+	s.Nonce++
+	return proof, NewFairRand(s.ServerSeed, s.ClientSeed, proof.Nonce)
+}
+
+// FairProof is everything needed, along with the revealed server seed, to
+// reproduce a provably fair pull
+type FairProof struct {
+	ServerSeedHash string    `json:"server_seed_hash"`
+	ClientSeed     string    `json:"client_seed"`
+	Nonce          int       `json:"nonce"`
+	BannerID       string    `json:"banner_id"` // Empty for the standard pool
+	Rates          RateTable `json:"rates"`     // Rates after pity
+	MinRarity      Rarity    `json:"min_rarity"`
+}
+
+// NewFairRand returns the rand a provably fair pull is rolled from. Its
+// stream is HMAC-SHA256 keyed with the server seed over
+// "clientSeed:nonce:block" for blocks 0, 1, 2 and so on, read as big-endian
+// 64-bit integers.
+func NewFairRand(serverSeed, clientSeed string, nonce int) *mathrand.Rand {
+	return mathrand.New(&fairSource{
+		mac:    hmac.New(sha256.New, []byte(serverSeed)),
+		prefix: fmt.Sprintf("%s:%d:", clientSeed, nonce),
+	})
+}
+
+// fairSource is the HMAC stream behind NewFairRand
+type fairSource struct {
+	mac    hash.Hash
+	prefix string
+	block  int
+	buf    []byte
+}
+
+func (s *fairSource) Uint64() uint64 {
+	if len(s.buf) < 8 {
+		s.mac.Reset()
+		s.mac.Write([]byte(fmt.Sprintf("%s%d", s.prefix, s.block)))
+		s.buf = s.mac.Sum(nil)
+		s.block++
+	}
+	v := binary.BigEndian.Uint64(s.buf)
+	s.buf = s.buf[8:]
+	return v
+}
+
+func (s *fairSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Seed does nothing: the stream is fixed by its seeds and nonce
+func (s *fairSource) Seed(int64) {}
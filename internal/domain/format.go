@@ -0,0 +1,53 @@
+package domain
+
+// BattleFormat sets the rules a battle is played under
+type BattleFormat struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	LevelCap    int    `json:"level_cap,omitempty"`   // Pokemon above the cap battle at the cap (0 = none)
+	ScaleLevel  int    `json:"scale_level,omitempty"` // Every Pokemon battles at this level (0 = own level)
+}
+
+const (
+	FormatStandard  = "standard"
+	FormatRanked    = "ranked"
+	FormatLittleCup = "little_cup"
+)
+
+// BattleFormats are the formats battles can be created with
+var BattleFormats = map[string]*BattleFormat{
+	FormatStandard: {
+		Name:        FormatStandard,
+		Description: "Pokemon battle at their own level",
+	},
+	FormatRanked: {
+		Name:        FormatRanked,
+		Description: "Every Pokemon is flattened to level 50",
+		ScaleLevel:  50,
+	},
+	FormatLittleCup: {
+		Name:        FormatLittleCup,
+		Description: "Pokemon battle at level 5 or below",
+		LevelCap:    5,
+	},
+}
+
+// GetBattleFormat returns a format by name. An empty name is the standard format.
+func GetBattleFormat(name string) (*BattleFormat, bool) {
+	if name == "" {
+		name = FormatStandard
+	}
+	format, exists := BattleFormats[name]
+	return format, exists
+}
+
+// EffectiveLevel returns the level a Pokemon battles at in this format
+func (f *BattleFormat) EffectiveLevel(level int) int {
+	if f.ScaleLevel > 0 {
+		return f.ScaleLevel
+	}
+	if f.LevelCap > 0 && level > f.LevelCap {
+		return f.LevelCap
+	}
+	return level
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidRarityRule is returned for a rarity rule that can't be parsed
+var ErrInvalidRarityRule = errors.New("invalid rarity rule")
+
+// RarityThreshold is the lowest base stat total of a rarity
+type RarityThreshold struct {
+	Rarity   Rarity
+	MinTotal int
+}
+
+// RarityRule assigns imported species a rarity. Mythical and legendary
+// species get their own rarity when one is set; every other species gets
+// the rarest threshold its base stat total reaches, or Common.
+type RarityRule struct {
+	Mythical   Rarity            // Empty to rank mythical species by stats
+	Legendary  Rarity            // Empty to rank legendary species by stats
+	Thresholds []RarityThreshold // Highest MinTotal first
+}
+
+// DefaultRarityRule keeps mythical and legendary species at the top and
+// ranks the rest by base stat total
+func DefaultRarityRule() RarityRule {
+	return RarityRule{
+		Mythical:  Mythic,
+		Legendary: Legendary,
+		Thresholds: []RarityThreshold{
+			{Rarity: Epic, MinTotal: 530},
+			{Rarity: Rare, MinTotal: 400},
+			{Rarity: Uncommon, MinTotal: 300},
+		},
+	}
+}
+
+// ParseRarityRule reads a rule such as
+// "mythical=mythic,legendary=legendary,epic=530,rare=400,uncommon=300".
+// mythical and legendary take a rarity, or "stats" to rank those species
+// by stats like the rest; each rarity takes its lowest base stat total.
+func ParseRarityRule(rule string) (RarityRule, error) {
+	var parsed RarityRule
+	for _, part := range strings.Split(rule, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.ToLower(strings.TrimSpace(value))
+		if !ok || key == "" || value == "" {
+			return RarityRule{}, fmt.Errorf("%w: %q", ErrInvalidRarityRule, strings.TrimSpace(part))
+		}
+
+		switch key {
+		case "mythical", "legendary":
+			rarity := Rarity(value)
+			if value == "stats" {
+				rarity = ""
+			} else if rarity.Value() == 0 {
+				return RarityRule{}, fmt.Errorf("%w: unknown rarity %q", ErrInvalidRarityRule, value)
+			}
+			if key == "mythical" {
+				parsed.Mythical = rarity
+			} else {
+				parsed.Legendary = rarity
+			}
+		default:
+			rarity := Rarity(key)
+			total, err := strconv.Atoi(value)
+			if rarity.Value() == 0 || err != nil || total < 0 {
+				return RarityRule{}, fmt.Errorf("%w: %q", ErrInvalidRarityRule, strings.TrimSpace(part))
+			}
+			parsed.Thresholds = append(parsed.Thresholds, RarityThreshold{Rarity: rarity, MinTotal: total})
+		}
+	}
+
+	sort.SliceStable(parsed.Thresholds, func(i, j int) bool {
+		return parsed.Thresholds[i].MinTotal > parsed.Thresholds[j].MinTotal
+	})
+	return parsed, nil
+}
+
+// Assign returns the rarity the rule gives a species
+func (r RarityRule) Assign(species *ImportedSpecies) Rarity {
+	switch {
+	case species.Mythical && r.Mythical != "":
+		return r.Mythical
+	case species.Legendary && r.Legendary != "":
+		return r.Legendary
+	}
+
+	total := species.Species.BaseStatTotal()
+	for _, threshold := range r.Thresholds {
+		if total >= threshold.MinTotal {
+			return threshold.Rarity
+		}
+	}
+	return Common
+}
+
+// ImportedSpecies is a species read from a data dump, before the importer
+// gives it a rarity and drop weight
+type ImportedSpecies struct {
+	Species   *PokemonSpecies
+	Legendary bool
+	Mythical  bool
+	Moves     []string // Names of the moves it can learn
+}
+
+// SpeciesImport is the species and moves read from a data dump
+type SpeciesImport struct {
+	Species []*ImportedSpecies
+	Moves   []*Move
+}
+
+// ImportSkip is a row the importer left out and why
+type ImportSkip struct {
+	Kind   string `json:"kind"` // "species" or "move"
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// ImportResult counts what an import wrote
+type ImportResult struct {
+	Species       int            `json:"species"`
+	Moves         int            `json:"moves"`
+	LearnsetMoves int            `json:"learnset_moves"`
+	UnknownMoves  int            `json:"unknown_moves"` // Learnset moves in neither the dump nor the database
+	ByRarity      map[Rarity]int `json:"by_rarity"`
+	Skipped       []ImportSkip   `json:"skipped"`
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ListingStatus represents the lifecycle state of a market listing
+type ListingStatus string
+
+const (
+	ListingStatusActive    ListingStatus = "active"
+	ListingStatusSold      ListingStatus = "sold"
+	ListingStatusCancelled ListingStatus = "cancelled"
+	ListingStatusExpired   ListingStatus = "expired" // Auction ended without bids
+)
+
+// ListingType distinguishes fixed-price listings from auctions
+type ListingType string
+
+const (
+	ListingTypeFixed   ListingType = "fixed"
+	ListingTypeAuction ListingType = "auction"
+)
+
+const (
+	MarketFeePercent = 5       // Share of each sale removed from the economy
+	MinListingPrice  = 10      // Lowest price a Pokemon can be listed at
+	MaxListingPrice  = 1000000 // Highest price a Pokemon can be listed at
+)
+
+// MarketListing represents a Pokemon offered for sale
+type MarketListing struct {
+	ID            uuid.UUID     `json:"id"`
+	SellerID      uuid.UUID     `json:"seller_id"`
+	UserPokemonID uuid.UUID     `json:"user_pokemon_id"`
+	Pokemon       *UserPokemon  `json:"pokemon,omitempty"` // Populated when needed
+	Price         int           `json:"price"`             // Starting price for auctions
+	Type          ListingType   `json:"type"`
+	Status        ListingStatus `json:"status"`
+	ListedAt      time.Time     `json:"listed_at"`
+}
+
+// MarketTransaction records a completed sale
+type MarketTransaction struct {
+	ID            uuid.UUID `json:"id"`
+	ListingID     uuid.UUID `json:"listing_id"`
+	BuyerID       uuid.UUID `json:"buyer_id"`
+	SellerID      uuid.UUID `json:"seller_id"`
+	Price         int       `json:"price"`          // Paid by the buyer
+	Fee           int       `json:"fee"`            // Burned, the seller receives Price - Fee
+	SpeciesID     int       `json:"species_id"`     // What was sold, kept for valuation
+	IVPercentage  float64   `json:"iv_percentage"`  // IV% at the time of sale
+	VariantFactor float64   `json:"variant_factor"` // Shiny and form value factor at the time of sale
+	CompletedAt   time.Time `json:"completed_at"`
+}
+
+// MarketSearchFilter narrows down active listings. Zero values mean "any".
+type MarketSearchFilter struct {
+	SellerID     uuid.UUID
+	SpeciesID    int
+	SpeciesName  string
+	Rarity       Rarity
+	MinIVPercent float64
+	MinPrice     int
+	MaxPrice     int
+	Limit        int
+	Offset       int
+}
+
+// NewMarketListing creates an active listing for a Pokemon
+func NewMarketListing(pokemon *UserPokemon, price int) *MarketListing {
+	return &MarketListing{
+		ID:            uuid.New(),
+		SellerID:      pokemon.UserID,
+		UserPokemonID: pokemon.ID,
+		Pokemon:       pokemon,
+		Price:         price,
+		Type:          ListingTypeFixed,
+		Status:        ListingStatusActive,
+		ListedAt:      time.Now(),
+	}
+}
+
+// IsActive checks if the listing can still be bought or cancelled
+func (l *MarketListing) IsActive() bool {
+	return l.Status == ListingStatusActive
+}
+
+// IsAuction checks if the listing is sold by bidding
+func (l *MarketListing) IsAuction() bool {
+	return l.Type == ListingTypeAuction
+}
+
+// Fee returns the coins burned when the listing sells
+func (l *MarketListing) Fee() int {
+	return MarketFee(l.Price)
+}
+
+// SellerProceeds returns the coins the seller receives after the fee
+func (l *MarketListing) SellerProceeds() int {
+	return l.Price - l.Fee()
+}
+
+// NewMarketTransaction records the sale of a listing
+func NewMarketTransaction(listing *MarketListing, buyerID uuid.UUID) *MarketTransaction {
+	transaction := &MarketTransaction{
+		ID:          uuid.New(),
+		ListingID:   listing.ID,
+		BuyerID:     buyerID,
+		SellerID:    listing.SellerID,
+		Price:       listing.Price,
+		Fee:         listing.Fee(),
+		CompletedAt: time.Now(),
+	}
+	transaction.recordPokemon(listing.Pokemon)
+	return transaction
+}
+
+// recordPokemon snapshots what was sold so later changes to the Pokemon
+// don't rewrite its price history
+func (t *MarketTransaction) recordPokemon(pokemon *UserPokemon) {
+	if pokemon == nil {
+		return
+	}
+	t.SpeciesID = pokemon.SpeciesID
+	t.IVPercentage = pokemon.IVs.IVPercentage()
+	t.VariantFactor = pokemon.VariantValueFactor()
+}
+
+// Sale returns the transaction as a valuation data point
+func (t *MarketTransaction) Sale() *MarketSale {
+	return &MarketSale{
+		TransactionID: t.ID,
+		SpeciesID:     t.SpeciesID,
+		Price:         t.Price,
+		IVPercentage:  t.IVPercentage,
+		VariantFactor: t.VariantFactor,
+		SoldAt:        t.CompletedAt,
+	}
+}
+
+// MarketFee calculates the fee for a sale price (rounded down)
+func MarketFee(price int) int {
+	return price * MarketFeePercent / 100
+}
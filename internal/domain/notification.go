@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationType identifies what a notification is about
+type NotificationType string
+
+const (
+	NotificationAuctionWon     NotificationType = "auction_won"
+	NotificationAuctionSold    NotificationType = "auction_sold"
+	NotificationAuctionExpired NotificationType = "auction_expired"
+	NotificationAuctionOutbid  NotificationType = "auction_outbid"
+	NotificationTradeOffer     NotificationType = "trade_offer"
+	NotificationTradeConfirmed NotificationType = "trade_confirmed"
+	NotificationTradeCompleted NotificationType = "trade_completed"
+	NotificationTradeCancelled NotificationType = "trade_cancelled"
+)
+
+// Notification is a message for a user, delivered by the Discord bot.
+// Notifications are written in the same transaction as the event they
+// describe, so a rolled back event never notifies anyone.
+type Notification struct {
+	ID          uuid.UUID        `json:"id"`
+	UserID      uuid.UUID        `json:"user_id"`
+	DiscordID   string           `json:"discord_id"` // Populated when listing undelivered notifications
+	Type        NotificationType `json:"type"`
+	Title       string           `json:"title"`
+	Message     string           `json:"message"`
+	ReferenceID *uuid.UUID       `json:"reference_id"` // Trade or listing the notification is about
+	CreatedAt   time.Time        `json:"created_at"`
+	DeliveredAt *time.Time       `json:"delivered_at"`
+}
+
+// NewNotification creates an undelivered notification
+func NewNotification(userID uuid.UUID, notificationType NotificationType, title, message string) *Notification {
+	return &Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      notificationType,
+		Title:     title,
+		Message:   message,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StandardBannerID is the banner ID pity is counted under for pulls on the
+// standard pool, daily rolls included
+const StandardBannerID = ""
+
+// PityRule raises the chance of pulling a rarity or rarer the longer a
+// player goes without one
+type PityRule struct {
+	Rarity   Rarity  `json:"rarity"`
+	SoftPity int     `json:"soft_pity"` // Pulls after which each pull raises the chance
+	HardPity int     `json:"hard_pity"` // Pull that is guaranteed the rarity
+	Step     float64 `json:"step"`      // Chance added per pull past soft pity
+}
+
+// PityRules are the pity counters every banner keeps, commonest rarity first
+var PityRules = []PityRule{
+	{Rarity: Epic, SoftPity: 15, HardPity: 25, Step: 0.08},
+	{Rarity: Legendary, SoftPity: 50, HardPity: 75, Step: 0.04},
+}
+
+// PityState is a player's pity on one banner: the pulls since their last
+// pull of each rule's rarity or rarer
+type PityState struct {
+	UserID    uuid.UUID      `json:"user_id"`
+	BannerID  string         `json:"banner_id"`
+	Pulls     map[Rarity]int `json:"pulls"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// NewPityState creates a player's pity on a banner with no pulls counted
+func NewPityState(userID uuid.UUID, bannerID string) *PityState {
+	return &PityState{
+		UserID:    userID,
+		BannerID:  bannerID,
+		Pulls:     make(map[Rarity]int, len(PityRules)),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// Rates returns the rate table for the next pull with pity applied. Past
+// soft pity a rule's rarity and everything rarer gain the rule's step for
+// each pull; at hard pity they are guaranteed.
+func (s *PityState) Rates(base RateTable) RateTable {
+	rates := base
+	for _, rule := range PityRules {
+		next := s.Pulls[rule.Rarity] + 1
+		switch {
+		case next >= rule.HardPity:
+			rates = rates.WithShare(rule.Rarity, 1)
+		case next > rule.SoftPity:
+			rates = rates.WithShare(rule.Rarity, rates.From(rule.Rarity)+rule.Step*float64(next-rule.SoftPity))
+		}
+	}
+	return rates
+}
+
+// Record counts a pull, resetting the counters it satisfies
+func (s *PityState) Record(rarity Rarity) {
+	if s.Pulls == nil {
+		s.Pulls = make(map[Rarity]int, len(PityRules))
+	}
+	for _, rule := range PityRules {
+		if rarity.Value() >= rule.Rarity.Value() {
+			s.Pulls[rule.Rarity] = 0
+		} else {
+			s.Pulls[rule.Rarity]++
+		}
+	}
+	s.UpdatedAt = time.Now()
+}
+
+// Counters returns a copy of the pulls counted toward each pity rule
+func (s *PityState) Counters() map[Rarity]int {
+	counters := make(map[Rarity]int, len(PityRules))
+	for _, rule := range PityRules {
+		counters[rule.Rarity] = s.Pulls[rule.Rarity]
+	}
+	return counters
+}
+
+// PityProgress is how close a player is to one pity rule
+type PityProgress struct {
+	Rarity           Rarity `json:"rarity"`
+	Pulls            int    `json:"pulls"` // Pulls since the last of this rarity or rarer
+	SoftPity         int    `json:"soft_pity"`
+	HardPity         int    `json:"hard_pity"`
+	PullsToGuarantee int    `json:"pulls_to_guarantee"` // Including the guaranteed pull
+}
+
+// Progress reports the player's progress toward each pity rule
+func (s *PityState) Progress() []PityProgress {
+	progress := make([]PityProgress, len(PityRules))
+	for i, rule := range PityRules {
+		pulls := s.Pulls[rule.Rarity]
+		progress[i] = PityProgress{
+			Rarity:           rule.Rarity,
+			Pulls:            pulls,
+			SoftPity:         rule.SoftPity,
+			HardPity:         rule.HardPity,
+			PullsToGuarantee: max(rule.HardPity-pulls, 1),
+		}
+	}
+	return progress
+}
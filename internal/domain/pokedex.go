@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PokedexEntry records a species a player has seen or owned. A species
+// stays owned after the player trades or releases every Pokemon of it.
+type PokedexEntry struct {
+	UserID    uuid.UUID  `json:"user_id"`
+	SpeciesID int        `json:"species_id"`
+	SeenAt    time.Time  `json:"seen_at"`  // First seen, in battle or by owning it
+	OwnedAt   *time.Time `json:"owned_at"` // First owned; nil if only seen
+}
+
+// IsOwned checks if the player has ever owned the species
+func (e *PokedexEntry) IsOwned() bool {
+	return e.OwnedAt != nil
+}
+
+// PokedexMilestone is a reward for owning a number of species
+type PokedexMilestone struct {
+	Owned int            `json:"owned"` // Species owned to reach it
+	Coins int            `json:"coins"`
+	Items map[string]int `json:"items,omitempty"`
+}
+
+// PokedexMilestones are the milestone rewards, fewest species first
+var PokedexMilestones = []PokedexMilestone{
+	{Owned: 10, Coins: 500},
+	{Owned: 25, Coins: 1000, Items: map[string]int{Vitamins["hp"]: 3}},
+	{Owned: 50, Coins: 2500, Items: map[string]int{AbilityCapsule: 1}},
+	{Owned: 100, Coins: 5000, Items: map[string]int{AbilityCapsule: 2}},
+	{Owned: 150, Coins: 10000, Items: map[string]int{AbilityCapsule: 3}},
+}
+
+// PokedexCompletion counts the species of a group a player has seen and
+// owned
+type PokedexCompletion struct {
+	Total   int     `json:"total"`
+	Seen    int     `json:"seen"`
+	Owned   int     `json:"owned"`
+	Percent float64 `json:"percent"` // Share of the species owned, to one decimal
+}
+
+// count adds a species to the group
+func (c *PokedexCompletion) count(entry *PokedexEntry) {
+	c.Total++
+	if entry != nil {
+		c.Seen++
+		if entry.IsOwned() {
+			c.Owned++
+		}
+	}
+	c.Percent = math.Round(1000*float64(c.Owned)/float64(c.Total)) / 10
+}
+
+// PokedexSpecies is a species in a player's Pokedex
+type PokedexSpecies struct {
+	ID      int          `json:"id"`
+	Name    string       `json:"name"`
+	Rarity  Rarity       `json:"rarity"`
+	Type1   PokemonType  `json:"type1"`
+	Type2   *PokemonType `json:"type2"`
+	Seen    bool         `json:"seen"`
+	Owned   bool         `json:"owned"`
+	OwnedAt *time.Time   `json:"owned_at"`
+}
+
+// PokedexMilestoneStatus is a milestone and whether a player has reached
+// it
+type PokedexMilestoneStatus struct {
+	PokedexMilestone
+	Reached bool `json:"reached"`
+	Granted bool `json:"granted"` // Whether its reward was paid
+}
+
+// Pokedex is a player's completion of every species, overall, by rarity
+// and by type. Dual-type species count toward both types.
+type Pokedex struct {
+	Overall       PokedexCompletion                  `json:"overall"`
+	ByRarity      map[Rarity]*PokedexCompletion      `json:"by_rarity"`
+	ByType        map[PokemonType]*PokedexCompletion `json:"by_type"`
+	Milestones    []PokedexMilestoneStatus           `json:"milestones"`
+	NextMilestone *PokedexMilestone                  `json:"next_milestone"` // Nil once every milestone is reached
+	Species       []*PokedexSpecies                  `json:"species"`
+}
+
+// NewPokedex builds a player's Pokedex from every species, their entries
+// and the milestones already granted to them
+func NewPokedex(species []*PokemonSpecies, entries []*PokedexEntry, granted []int) *Pokedex {
+	bySpecies := make(map[int]*PokedexEntry, len(entries))
+	owned := 0
+	for _, entry := range entries {
+		bySpecies[entry.SpeciesID] = entry
+		if entry.IsOwned() {
+			owned++
+		}
+	}
+
+	dex := &Pokedex{
+		ByRarity: make(map[Rarity]*PokedexCompletion),
+		ByType:   make(map[PokemonType]*PokedexCompletion),
+		Species:  make([]*PokedexSpecies, 0, len(species)),
+	}
+	for _, s := range species {
+		entry := bySpecies[s.ID]
+		dex.Overall.count(entry)
+		completion(dex.ByRarity, s.Rarity).count(entry)
+		completion(dex.ByType, s.Type1).count(entry)
+		if s.Type2 != nil {
+			completion(dex.ByType, *s.Type2).count(entry)
+		}
+
+		listed := &PokedexSpecies{ID: s.ID, Name: s.Name, Rarity: s.Rarity, Type1: s.Type1, Type2: s.Type2}
+		if entry != nil {
+			listed.Seen = true
+			listed.Owned = entry.IsOwned()
+			listed.OwnedAt = entry.OwnedAt
+		}
+		dex.Species = append(dex.Species, listed)
+	}
+
+	paid := make(map[int]bool, len(granted))
+	for _, g := range granted {
+		paid[g] = true
+	}
+	for i, milestone := range PokedexMilestones {
+		dex.Milestones = append(dex.Milestones, PokedexMilestoneStatus{
+			PokedexMilestone: milestone,
+			Reached:          owned >= milestone.Owned,
+			Granted:          paid[milestone.Owned],
+		})
+		if dex.NextMilestone == nil && owned < milestone.Owned {
+			dex.NextMilestone = &PokedexMilestones[i]
+		}
+	}
+	return dex
+}
+
+// completion returns the completion of a group, adding it if missing
+func completion[K comparable](groups map[K]*PokedexCompletion, key K) *PokedexCompletion {
+	c, exists := groups[key]
+	if !exists {
+		c = &PokedexCompletion{}
+		groups[key] = c
+	}
+	return c
+}
@@ -10,19 +10,26 @@ import (
 
 // PokemonSpecies represents static Pokemon data (from PokeAPI)
 type PokemonSpecies struct {
-	ID            int          `json:"id"`              // National Dex number
-	Name          string       `json:"name"`            // e.g., "pikachu"
-	Type1         PokemonType  `json:"type1"`           // Primary type
-	Type2         *PokemonType `json:"type2"`           // Secondary type (can be nil)
-	Rarity        Rarity       `json:"rarity"`          // Gacha rarity tier
-	BaseHP        int          `json:"base_hp"`         // Base stat
-	BaseAttack    int          `json:"base_attack"`     // Base stat
-	BaseDefense   int          `json:"base_defense"`    // Base stat
-	BaseSpAttack  int          `json:"base_sp_attack"`  // Base stat
-	BaseSpDefense int          `json:"base_sp_defense"` // Base stat
-	BaseSpeed     int          `json:"base_speed"`      // Base stat
-	SpriteURL     string       `json:"sprite_url"`      // Image URL
-	DropWeight    float64      `json:"drop_weight"`     // For weighted gacha rolls
+	ID             int            `json:"id"`               // National Dex number
+	Name           string         `json:"name"`             // e.g., "pikachu"
+	Type1          PokemonType    `json:"type1"`            // Primary type
+	Type2          *PokemonType   `json:"type2"`            // Secondary type (can be nil)
+	Rarity         Rarity         `json:"rarity"`           // Gacha rarity tier
+	BaseHP         int            `json:"base_hp"`          // Base stat
+	BaseAttack     int            `json:"base_attack"`      // Base stat
+	BaseDefense    int            `json:"base_defense"`     // Base stat
+	BaseSpAttack   int            `json:"base_sp_attack"`   // Base stat
+	BaseSpDefense  int            `json:"base_sp_defense"`  // Base stat
+	BaseSpeed      int            `json:"base_speed"`       // Base stat
+	SpriteURL      string         `json:"sprite_url"`       // Image URL
+	ShinySpriteURL string         `json:"shiny_sprite_url"` // Image URL when shiny
+	DropWeight     float64        `json:"drop_weight"`      // For weighted gacha rolls
+	GrowthRate     GrowthRate     `json:"growth_rate"`      // Experience curve
+	BaseExperience int            `json:"base_experience"`  // Experience yield when defeated
+	GenderRate     int            `json:"gender_rate"`      // Chance of being female in eighths, GenderlessRate if genderless
+	Abilities      []string       `json:"abilities"`        // Regular abilities, up to MaxRegularAbilities
+	HiddenAbility  string         `json:"hidden_ability"`   // Rolled at HiddenAbilityChance, empty if none
+	Forms          []*PokemonForm `json:"forms,omitempty"`  // Alternate and regional forms
 }
 
 // PokemonIVs represents Individual Values (0-31 for each stat)
@@ -40,29 +47,48 @@ type IVs = PokemonIVs
 
 // UserPokemon represents a unique Pokemon owned by a user
 type UserPokemon struct {
-	ID         uuid.UUID `json:"id"`
-	UserID     uuid.UUID `json:"user_id"`
-	SpeciesID  int       `json:"species_id"`
-	Species    *PokemonSpecies `json:"species,omitempty"` // Populated when needed
+	ID        uuid.UUID       `json:"id"`
+	UserID    uuid.UUID       `json:"user_id"`
+	SpeciesID int             `json:"species_id"`
+	Species   *PokemonSpecies `json:"species,omitempty"` // Populated when needed
 
 	// Individual Values (randomly generated on catch)
 	IVs IVs `json:"ivs"`
 
+	// Effort Values (start at zero, trained with coins or vitamins)
+	EVs EVs `json:"evs"`
+
 	// Nature affects stat multipliers
 	Nature Nature `json:"nature"`
 
-	// Level (default 50 for competitive play)
-	Level int `json:"level"`
+	// Rolled on catch alongside IVs and nature
+	IsShiny     bool         `json:"is_shiny"`
+	Gender      Gender       `json:"gender"`
+	FormID      *int         `json:"form_id,omitempty"`
+	Form        *PokemonForm `json:"form,omitempty"` // Nil for the base form
+	AbilitySlot AbilitySlot  `json:"ability_slot"`   // Which of the species' abilities it has
+
+	// Level (starts at 50, raised by battle experience)
+	Level      int `json:"level"`
+	Experience int `json:"experience"` // Total experience earned
 
 	// Metadata
 	AcquiredAt time.Time `json:"acquired_at"`
 	IsFavorite bool      `json:"is_favorite"`
 	Nickname   string    `json:"nickname,omitempty"`
+
+	// Value from recent market sales, set by the valuation service when
+	// there are enough sales of the species
+	MarketValue *int `json:"market_value,omitempty"`
 }
 
 // GenerateRandomIVs creates random IVs for a new Pokemon
 func GenerateRandomIVs() IVs {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return RollIVs(rand.New(rand.NewSource(time.Now().UnixNano())))
+}
+
+// RollIVs rolls IVs from r
+func RollIVs(r *rand.Rand) IVs {
 	return IVs{
 		HP:        r.Intn(32), // 0-31
 		Attack:    r.Intn(32),
@@ -75,8 +101,12 @@ func GenerateRandomIVs() IVs {
 
 // RandomNature returns a random nature
 func RandomNature() Nature {
+	return RollNature(rand.New(rand.NewSource(time.Now().UnixNano())))
+}
+
+// RollNature rolls a nature from r
+func RollNature(r *rand.Rand) Nature {
 	natures := AllNatures()
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	return natures[r.Intn(len(natures))]
 }
 
@@ -91,8 +121,8 @@ func (iv *IVs) IVPercentage() float64 {
 }
 
 // CalculateStat computes the actual stat value using the Pokemon formula
-// Formula: floor(floor((2 * Base + IV) * Level / 100 + 5) * Nature)
-func (p *UserPokemon) CalculateStat(baseStat, iv int, statName string) int {
+// Formula: floor(floor((2 * Base + IV + floor(EV / 4)) * Level / 100 + 5) * Nature)
+func (p *UserPokemon) CalculateStat(baseStat, iv, ev int, statName string) int {
 	if p.Species == nil {
 		return 0
 	}
@@ -101,20 +131,20 @@ func (p *UserPokemon) CalculateStat(baseStat, iv int, statName string) int {
 	natureMultiplier := p.Nature.GetMultiplier(statName)
 
 	// Pokemon stat formula for non-HP stats
-	stat := float64((2*baseStat+iv)*p.Level)/100.0 + 5.0
+	stat := float64((2*baseStat+iv+ev/4)*p.Level)/100.0 + 5.0
 	stat = math.Floor(stat) * natureMultiplier
 
 	return int(math.Floor(stat))
 }
 
 // CalculateHP computes HP using the special HP formula
-// Formula: floor((2 * Base + IV) * Level / 100) + Level + 10
+// Formula: floor((2 * Base + IV + floor(EV / 4)) * Level / 100) + Level + 10
 func (p *UserPokemon) CalculateHP() int {
 	if p.Species == nil {
 		return 0
 	}
 
-	hp := float64((2*p.Species.BaseHP+p.IVs.HP)*p.Level)/100.0 + float64(p.Level) + 10.0
+	hp := float64((2*p.EffectiveSpecies().BaseHP+p.IVs.HP+p.EVs.HP/4)*p.Level)/100.0 + float64(p.Level) + 10.0
 	return int(math.Floor(hp))
 }
 
@@ -137,39 +167,58 @@ func (p *UserPokemon) GetStats() Stats {
 		return Stats{}
 	}
 
+	// Forms have their own base stats
+	species := p.EffectiveSpecies()
+
 	return Stats{
 		HP:        p.CalculateHP(),
-		Attack:    p.CalculateStat(p.Species.BaseAttack, p.IVs.Attack, "attack"),
-		Defense:   p.CalculateStat(p.Species.BaseDefense, p.IVs.Defense, "defense"),
-		SpAttack:  p.CalculateStat(p.Species.BaseSpAttack, p.IVs.SpAttack, "sp_attack"),
-		SpDefense: p.CalculateStat(p.Species.BaseSpDefense, p.IVs.SpDefense, "sp_defense"),
-		Speed:     p.CalculateStat(p.Species.BaseSpeed, p.IVs.Speed, "speed"),
+		Attack:    p.CalculateStat(species.BaseAttack, p.IVs.Attack, p.EVs.Attack, "attack"),
+		Defense:   p.CalculateStat(species.BaseDefense, p.IVs.Defense, p.EVs.Defense, "defense"),
+		SpAttack:  p.CalculateStat(species.BaseSpAttack, p.IVs.SpAttack, p.EVs.SpAttack, "sp_attack"),
+		SpDefense: p.CalculateStat(species.BaseSpDefense, p.IVs.SpDefense, p.EVs.SpDefense, "sp_defense"),
+		Speed:     p.CalculateStat(species.BaseSpeed, p.IVs.Speed, p.EVs.Speed, "speed"),
 	}
 }
 
+// DisplayName returns the nickname if set, otherwise the species name
+func (p *UserPokemon) DisplayName() string {
+	if p.Nickname != "" {
+		return p.Nickname
+	}
+	if p.Species != nil {
+		return p.Species.Name
+	}
+	return "Pokemon"
+}
+
 // TotalStats returns the sum of all calculated stats
 func (p *UserPokemon) TotalStats() int {
 	stats := p.GetStats()
 	return stats.HP + stats.Attack + stats.Defense + stats.SpAttack + stats.SpDefense + stats.Speed
 }
 
-// EstimatedValue calculates the market value based on rarity, IVs, and nature
+// EstimatedValue returns the value from recent market sales when known,
+// otherwise the formula value
 func (p *UserPokemon) EstimatedValue() int {
+	if p.MarketValue != nil {
+		return *p.MarketValue
+	}
+	return p.FormulaValue()
+}
+
+// HasMarketValue checks if the value comes from market sales
+func (p *UserPokemon) HasMarketValue() bool {
+	return p.MarketValue != nil
+}
+
+// FormulaValue calculates the value based on rarity, IVs, nature, shininess and form
+func (p *UserPokemon) FormulaValue() int {
 	if p.Species == nil {
 		return 0
 	}
 
 	// Base value from rarity
-	baseValue := map[Rarity]int{
-		Common:    10,
-		Uncommon:  50,
-		Rare:      150,
-		Epic:      500,
-		Legendary: 2000,
-		Mythic:    10000,
-	}
-
-	base := baseValue[p.Species.Rarity]
+	base := p.Species.Rarity.BaseValue()
 
 	// Bonus for high IVs (up to 50% more for perfect IVs)
 	ivBonus := int(float64(base) * (p.IVs.IVPercentage() / 100.0) * 0.5)
@@ -181,20 +230,31 @@ func (p *UserPokemon) EstimatedValue() int {
 		natureBonus = int(float64(base) * 0.1) // 10% bonus for non-neutral nature
 	}
 
-	return base + ivBonus + natureBonus
+	return int(float64(base+ivBonus+natureBonus) * p.VariantValueFactor())
 }
 
-// NewUserPokemon creates a new Pokemon with random IVs and nature
+// NewUserPokemon creates a new Pokemon with random IVs, nature, shininess,
+// gender, form and ability
 func NewUserPokemon(userID uuid.UUID, species *PokemonSpecies) *UserPokemon {
-	return &UserPokemon{
+	return NewUserPokemonWithRand(userID, species, rand.New(rand.NewSource(time.Now().UnixNano())))
+}
+
+// NewUserPokemonWithRand creates a new Pokemon whose IVs, nature,
+// shininess, gender, form and ability are all rolled from r, in that order,
+// so the same r always gives the same Pokemon
+func NewUserPokemonWithRand(userID uuid.UUID, species *PokemonSpecies, r *rand.Rand) *UserPokemon {
+	pokemon := &UserPokemon{
 		ID:         uuid.New(),
 		UserID:     userID,
 		SpeciesID:  species.ID,
 		Species:    species,
-		IVs:        GenerateRandomIVs(),
-		Nature:     RandomNature(),
-		Level:      50, // Default competitive level
+		IVs:        RollIVs(r),
+		Nature:     RollNature(r),
+		Level:      DefaultLevel,
+		Experience: species.GrowthRate.ExperienceForLevel(DefaultLevel),
 		AcquiredAt: time.Now(),
 		IsFavorite: false,
 	}
+	pokemon.rollVariant(r)
+	return pokemon
 }
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RollType is the kind of roll a pull came from
+type RollType string
+
+const (
+	RollTypeDaily   RollType = "daily"
+	RollTypePremium RollType = "premium"
+)
+
+// GachaPull records one card of a roll: what was pulled, where, and the
+// odds it was pulled at
+type GachaPull struct {
+	ID        uuid.UUID      `json:"id"`
+	RollID    uuid.UUID      `json:"roll_id"` // Shared by the cards of one roll
+	UserID    uuid.UUID      `json:"user_id"`
+	PokemonID uuid.UUID      `json:"pokemon_id"`
+	BannerID  string         `json:"banner_id"` // Empty for the standard pool
+	RollType  RollType       `json:"roll_type"`
+	Rarity    Rarity         `json:"rarity"`
+	SpeciesID int            `json:"species_id"`
+	Species   string         `json:"species"`         // Species name
+	Pity      map[Rarity]int `json:"pity"`            // Pity counters before the pull
+	Odds      RateTable      `json:"odds"`            // Chance of each rarity after pity and guarantees
+	Nonce     *int           `json:"nonce,omitempty"` // Nonce of a provably fair pull
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// NewGachaPull records a pulled Pokemon
+func NewGachaPull(rollID uuid.UUID, pokemon *UserPokemon, bannerID string, rollType RollType, pity map[Rarity]int, odds RateTable) *GachaPull {
+	return &GachaPull{
+		ID:        uuid.New(),
+		RollID:    rollID,
+		UserID:    pokemon.UserID,
+		PokemonID: pokemon.ID,
+		BannerID:  bannerID,
+		RollType:  rollType,
+		Rarity:    pokemon.Species.Rarity,
+		SpeciesID: pokemon.SpeciesID,
+		Species:   pokemon.Species.Name,
+		Pity:      pity,
+		Odds:      odds,
+		CreatedAt: time.Now(),
+	}
+}
+
+// PullHistoryFilter narrows down a player's pulls. Zero values mean "any".
+type PullHistoryFilter struct {
+	BannerID *string // Empty string for the standard pool
+	RollType RollType
+	Rarity   Rarity
+	Limit    int
+	Offset   int
+}
+
+// PullStatsFilter narrows down the pulls a drop rate report covers. Zero
+// values mean "any".
+type PullStatsFilter struct {
+	BannerID *string // Empty string for the standard pool
+	RollType RollType
+	Since    time.Time
+}
+
+// RarityTally counts the pulls of a rarity against the odds they were
+// pulled at
+type RarityTally struct {
+	Rarity   Rarity
+	Pulls    int     // Pulls that gave the rarity
+	Expected float64 // Sum of the rarity's odds over every pull
+	Variance float64 // Sum of p(1-p) over every pull, with p the rarity's odds
+}
+
+const (
+	ReportConfidence = 0.95 // Confidence level of the report's intervals
+	reportZ          = 1.96 // Normal quantile for ReportConfidence
+
+	// RateAlertZScore is how many standard deviations observed pulls can be
+	// from the odds before a rarity is flagged as a likely rate bug
+	RateAlertZScore = 4.0
+)
+
+// RarityStats compares how often a rarity was pulled with how often it
+// should have been
+type RarityStats struct {
+	Rarity       Rarity  `json:"rarity"`
+	Pulls        int     `json:"pulls"`
+	ObservedRate float64 `json:"observed_rate"`
+	ExpectedRate float64 `json:"expected_rate"` // Average odds after pity and guarantees
+	CILow        float64 `json:"ci_low"`        // Wilson interval around the observed rate
+	CIHigh       float64 `json:"ci_high"`
+	WithinCI     bool    `json:"within_ci"` // Whether the expected rate is inside the interval
+	ZScore       float64 `json:"z_score"`   // Standard deviations the pulls are from the expected count
+	Alert        bool    `json:"alert"`     // |ZScore| of at least RateAlertZScore, or any miss of certain odds
+}
+
+// DropRateReport compares observed rarity frequencies with the odds the
+// pulls were made at
+type DropRateReport struct {
+	TotalPulls int           `json:"total_pulls"`
+	Confidence float64       `json:"confidence"`
+	Rarities   []RarityStats `json:"rarities"` // Commonest first
+}
+
+// NewDropRateReport builds a report over total pulls from their tallies.
+// Pity and guarantees change the odds from pull to pull, so the expected
+// rate is each pull's odds averaged, and the z-score uses the variance of
+// the sum of those pulls rather than of one fixed rate.
+func NewDropRateReport(total int, tallies []RarityTally) *DropRateReport {
+	report := &DropRateReport{
+		TotalPulls: total,
+		Confidence: ReportConfidence,
+		Rarities:   make([]RarityStats, 0, len(tallies)),
+	}
+	if total == 0 {
+		return report
+	}
+
+	n := float64(total)
+	for _, tally := range tallies {
+		observed := float64(tally.Pulls) / n
+		expected := tally.Expected / n
+		low, high := wilsonInterval(observed, n)
+
+		stats := RarityStats{
+			Rarity:       tally.Rarity,
+			Pulls:        tally.Pulls,
+			ObservedRate: observed,
+			ExpectedRate: expected,
+			CILow:        low,
+			CIHigh:       high,
+			WithinCI:     expected >= low && expected <= high,
+		}
+		if tally.Variance > 0 {
+			stats.ZScore = (float64(tally.Pulls) - tally.Expected) / math.Sqrt(tally.Variance)
+			stats.Alert = math.Abs(stats.ZScore) >= RateAlertZScore
+		} else {
+			// Every pull was certain to give the rarity or not to, so any
+			// difference is a bug
+			stats.Alert = math.Abs(float64(tally.Pulls)-tally.Expected) > 1e-6
+		}
+		report.Rarities = append(report.Rarities, stats)
+	}
+	return report
+}
+
+// wilsonInterval returns the Wilson score interval for a rate p observed
+// over n trials
+func wilsonInterval(p, n float64) (float64, float64) {
+	z2 := reportZ * reportZ
+	denom := 1 + z2/n
+	center := (p + z2/(2*n)) / denom
+	half := reportZ / denom * math.Sqrt(p*(1-p)/n+z2/(4*n*n))
+	return math.Max(0, center-half), math.Min(1, center+half)
+}
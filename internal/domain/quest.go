@@ -0,0 +1,261 @@
+package domain
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GameEventType is something a player did that quests can count
+type GameEventType string
+
+const (
+	EventPull              GameEventType = "pull"                // A card pulled in a daily or premium roll
+	EventBattleWon         GameEventType = "battle_won"          // A player or wild battle won
+	EventSuperEffectiveHit GameEventType = "super_effective_hit" // A super-effective hit landed in battle
+	EventTradeCompleted    GameEventType = "trade_completed"     // A trade gone through, for each side
+	EventMarketSale        GameEventType = "market_sale"         // A Pokemon sold on the market or at auction
+	EventPokemonObtained   GameEventType = "pokemon_obtained"    // A Pokemon of a species pulled, bought, traded for or evolved into
+	EventPokemonSeen       GameEventType = "pokemon_seen"        // A species met in battle
+)
+
+// GameEvent is one or more of the same thing a player did
+type GameEvent struct {
+	Type      GameEventType
+	UserID    uuid.UUID
+	Count     int
+	Rarity    Rarity // Rarity of a pulled Pokemon
+	SpeciesID int    // Species obtained or seen
+}
+
+// NewGameEvent creates an event counting count occurrences
+func NewGameEvent(eventType GameEventType, userID uuid.UUID, count int) GameEvent {
+	return GameEvent{Type: eventType, UserID: userID, Count: count}
+}
+
+// NewPullEvent creates an event for a recorded pull
+func NewPullEvent(pull *GachaPull) GameEvent {
+	return GameEvent{Type: EventPull, UserID: pull.UserID, Count: 1, Rarity: pull.Rarity}
+}
+
+// NewObtainedEvent creates an event for a player obtaining a Pokemon of a
+// species
+func NewObtainedEvent(userID uuid.UUID, speciesID int) GameEvent {
+	return GameEvent{Type: EventPokemonObtained, UserID: userID, Count: 1, SpeciesID: speciesID}
+}
+
+// NewSeenEvent creates an event for a player meeting a species in battle
+func NewSeenEvent(userID uuid.UUID, speciesID int) GameEvent {
+	return GameEvent{Type: EventPokemonSeen, UserID: userID, Count: 1, SpeciesID: speciesID}
+}
+
+// QuestPeriod is how long a quest runs before it rotates
+type QuestPeriod string
+
+const (
+	QuestDaily       QuestPeriod = "daily"
+	QuestWeekly      QuestPeriod = "weekly"
+	QuestAchievement QuestPeriod = "achievement" // Never rotates
+)
+
+const (
+	DailyQuestCount  = 3 // Daily quests active at a time
+	WeeklyQuestCount = 2 // Weekly quests active at a time
+)
+
+// QuestReward is what claiming a completed quest gives
+type QuestReward struct {
+	Coins int            `json:"coins"`
+	Items map[string]int `json:"items,omitempty"`
+}
+
+// Quest is a goal counted from one type of game event
+type Quest struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Period      QuestPeriod   `json:"period"`
+	Event       GameEventType `json:"event"`
+	MinRarity   Rarity        `json:"min_rarity,omitempty"` // Only pulls of this rarity or rarer count
+	Goal        int           `json:"goal"`
+	Reward      QuestReward   `json:"reward"`
+}
+
+// Counts returns how much an event adds to the quest's progress
+func (q *Quest) Counts(event GameEvent) int {
+	if event.Type != q.Event || event.Count <= 0 {
+		return 0
+	}
+	if q.MinRarity != "" && event.Rarity.Value() < q.MinRarity.Value() {
+		return 0
+	}
+	return event.Count
+}
+
+// DailyQuests is the pool DailyQuestCount quests are drawn from each day
+var DailyQuests = []*Quest{
+	{ID: "daily-pulls", Name: "Daily Roller", Description: "Pull 5 Pokemon", Period: QuestDaily,
+		Event: EventPull, Goal: 5, Reward: QuestReward{Coins: 100}},
+	{ID: "daily-rare-pull", Name: "Lucky Draw", Description: "Pull a Rare or better Pokemon", Period: QuestDaily,
+		Event: EventPull, MinRarity: Rare, Goal: 1, Reward: QuestReward{Coins: 150}},
+	{ID: "daily-battles", Name: "Warm-Up", Description: "Win 2 battles", Period: QuestDaily,
+		Event: EventBattleWon, Goal: 2, Reward: QuestReward{Coins: 150}},
+	{ID: "daily-super-effective", Name: "Type Advantage", Description: "Land 5 super-effective hits", Period: QuestDaily,
+		Event: EventSuperEffectiveHit, Goal: 5, Reward: QuestReward{Coins: 100, Items: map[string]int{Vitamins["attack"]: 1}}},
+	{ID: "daily-market-sale", Name: "Open for Business", Description: "Sell a Pokemon on the market", Period: QuestDaily,
+		Event: EventMarketSale, Goal: 1, Reward: QuestReward{Coins: 150}},
+	{ID: "daily-trade", Name: "Fair Exchange", Description: "Complete a trade", Period: QuestDaily,
+		Event: EventTradeCompleted, Goal: 1, Reward: QuestReward{Coins: 150}},
+}
+
+// WeeklyQuests is the pool WeeklyQuestCount quests are drawn from each week
+var WeeklyQuests = []*Quest{
+	{ID: "weekly-pulls", Name: "Big Spender", Description: "Pull 50 Pokemon", Period: QuestWeekly,
+		Event: EventPull, Goal: 50, Reward: QuestReward{Coins: 500}},
+	{ID: "weekly-epic-pulls", Name: "Epic Hunter", Description: "Pull 3 Epic or better Pokemon", Period: QuestWeekly,
+		Event: EventPull, MinRarity: Epic, Goal: 3, Reward: QuestReward{Coins: 750}},
+	{ID: "weekly-battles", Name: "Battle Hardened", Description: "Win 15 battles", Period: QuestWeekly,
+		Event: EventBattleWon, Goal: 15, Reward: QuestReward{Coins: 500, Items: map[string]int{Vitamins["attack"]: 2, Vitamins["speed"]: 2}}},
+	{ID: "weekly-super-effective", Name: "Type Master", Description: "Land 40 super-effective hits", Period: QuestWeekly,
+		Event: EventSuperEffectiveHit, Goal: 40, Reward: QuestReward{Coins: 500}},
+	{ID: "weekly-market-sales", Name: "Merchant", Description: "Sell 5 Pokemon on the market", Period: QuestWeekly,
+		Event: EventMarketSale, Goal: 5, Reward: QuestReward{Coins: 750}},
+	{ID: "weekly-trades", Name: "Networker", Description: "Complete 3 trades", Period: QuestWeekly,
+		Event: EventTradeCompleted, Goal: 3, Reward: QuestReward{Coins: 600}},
+}
+
+// Achievements are permanent quests, each completed once
+var Achievements = []*Quest{
+	{ID: "first-pull", Name: "First Catch", Description: "Pull your first Pokemon", Period: QuestAchievement,
+		Event: EventPull, Goal: 1, Reward: QuestReward{Coins: 100}},
+	{ID: "pulls-1000", Name: "Gotta Pull 'Em All", Description: "Pull 1000 Pokemon", Period: QuestAchievement,
+		Event: EventPull, Goal: 1000, Reward: QuestReward{Coins: 5000}},
+	{ID: "legendary-pull", Name: "Living Legend", Description: "Pull a Legendary or better Pokemon", Period: QuestAchievement,
+		Event: EventPull, MinRarity: Legendary, Goal: 1, Reward: QuestReward{Coins: 1000}},
+	{ID: "mythic-pull", Name: "Myth Made Real", Description: "Pull a Mythic Pokemon", Period: QuestAchievement,
+		Event: EventPull, MinRarity: Mythic, Goal: 1, Reward: QuestReward{Coins: 2500}},
+	{ID: "battles-100", Name: "Champion", Description: "Win 100 battles", Period: QuestAchievement,
+		Event: EventBattleWon, Goal: 100, Reward: QuestReward{Coins: 3000, Items: map[string]int{AbilityCapsule: 1}}},
+	{ID: "super-effective-500", Name: "Weakness Exploiter", Description: "Land 500 super-effective hits", Period: QuestAchievement,
+		Event: EventSuperEffectiveHit, Goal: 500, Reward: QuestReward{Coins: 2000}},
+	{ID: "trades-25", Name: "Trading Post", Description: "Complete 25 trades", Period: QuestAchievement,
+		Event: EventTradeCompleted, Goal: 25, Reward: QuestReward{Coins: 2000}},
+	{ID: "market-sales-50", Name: "Tycoon", Description: "Sell 50 Pokemon on the market", Period: QuestAchievement,
+		Event: EventMarketSale, Goal: 50, Reward: QuestReward{Coins: 2500, Items: map[string]int{AbilityCapsule: 1}}},
+}
+
+// ActiveQuest is a quest running now, with the period its progress counts
+// toward
+type ActiveQuest struct {
+	*Quest
+	PeriodKey string     `json:"period_key"` // Day or week the quest counts toward; empty for achievements
+	EndsAt    *time.Time `json:"ends_at"`    // When the quest rotates out; nil for achievements
+}
+
+// ActiveQuests returns the quests running at now: the day's and week's
+// rotation and every achievement. Days follow the check-in timezone and
+// weeks start on Monday. Every player gets the same rotation.
+func ActiveQuests(now time.Time) []*ActiveQuest {
+	day := CheckInDay(now)
+	dayEnds := NextCheckInDay(now)
+
+	y, m, d := day.Date()
+	monday := d - (int(day.Weekday())+6)%7
+	weekEnds := time.Date(y, m, monday+7, 0, 0, 0, 0, day.Location())
+	year, number := day.ISOWeek()
+
+	var active []*ActiveQuest
+	active = append(active, rotate(DailyQuests, DailyQuestCount, day.Format("2006-01-02"), &dayEnds)...)
+	active = append(active, rotate(WeeklyQuests, WeeklyQuestCount, fmt.Sprintf("%d-W%02d", year, number), &weekEnds)...)
+	for _, quest := range Achievements {
+		active = append(active, &ActiveQuest{Quest: quest})
+	}
+	return active
+}
+
+// FindActiveQuest returns the quest with the ID running at now
+func FindActiveQuest(id string, now time.Time) (*ActiveQuest, bool) {
+	for _, quest := range ActiveQuests(now) {
+		if quest.ID == id {
+			return quest, true
+		}
+	}
+	return nil, false
+}
+
+// rotate draws count quests from a pool, seeded by the period so the draw
+// is the same all period, keeping the pool's order
+func rotate(pool []*Quest, count int, periodKey string, endsAt *time.Time) []*ActiveQuest {
+	hash := fnv.New64a()
+	hash.Write([]byte(periodKey))
+	picked := make([]bool, len(pool))
+	for _, i := range rand.New(rand.NewSource(int64(hash.Sum64()))).Perm(len(pool))[:min(count, len(pool))] {
+		picked[i] = true
+	}
+
+	var active []*ActiveQuest
+	for i, quest := range pool {
+		if picked[i] {
+			active = append(active, &ActiveQuest{Quest: quest, PeriodKey: periodKey, EndsAt: endsAt})
+		}
+	}
+	return active
+}
+
+// QuestProgress is a player's progress on a quest in one period
+type QuestProgress struct {
+	UserID      uuid.UUID  `json:"user_id"`
+	QuestID     string     `json:"quest_id"`
+	PeriodKey   string     `json:"period_key"`
+	Progress    int        `json:"progress"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ClaimedAt   *time.Time `json:"claimed_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// NewQuestProgress creates progress on a quest with nothing counted yet
+func NewQuestProgress(userID uuid.UUID, questID, periodKey string) *QuestProgress {
+	return &QuestProgress{UserID: userID, QuestID: questID, PeriodKey: periodKey, UpdatedAt: time.Now()}
+}
+
+// IsCompleted checks if the quest's goal was reached
+func (p *QuestProgress) IsCompleted() bool {
+	return p.CompletedAt != nil
+}
+
+// IsClaimed checks if the quest's reward was claimed
+func (p *QuestProgress) IsClaimed() bool {
+	return p.ClaimedAt != nil
+}
+
+// QuestStatus is an active quest with a player's progress on it
+type QuestStatus struct {
+	*ActiveQuest
+	Progress  int  `json:"progress"`
+	Completed bool `json:"completed"`
+	Claimed   bool `json:"claimed"`
+}
+
+// NewQuestStatus combines an active quest with a player's progress, which
+// may be nil when they have none
+func NewQuestStatus(quest *ActiveQuest, progress *QuestProgress) *QuestStatus {
+	status := &QuestStatus{ActiveQuest: quest}
+	if progress != nil {
+		status.Progress = min(progress.Progress, quest.Goal)
+		status.Completed = progress.IsCompleted()
+		status.Claimed = progress.IsClaimed()
+	}
+	return status
+}
+
+// QuestClaim is the reward a claim gave. Claiming again returns the same
+// reward with AlreadyClaimed set and gives nothing more.
+type QuestClaim struct {
+	Quest          *QuestStatus `json:"quest"`
+	Reward         QuestReward  `json:"reward"`
+	AlreadyClaimed bool         `json:"already_claimed"`
+	Coins          int          `json:"coins"` // Balance after the claim
+}
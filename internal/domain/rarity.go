@@ -50,3 +50,24 @@ func (r Rarity) Value() int {
 		return 0
 	}
 }
+
+// BaseValue returns the coin value a Pokemon of this rarity starts from
+// before IV, nature and variant bonuses
+func (r Rarity) BaseValue() int {
+	switch r {
+	case Common:
+		return 10
+	case Uncommon:
+		return 50
+	case Rare:
+		return 150
+	case Epic:
+		return 500
+	case Legendary:
+		return 2000
+	case Mythic:
+		return 10000
+	default:
+		return 0
+	}
+}
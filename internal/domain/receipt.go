@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxIdempotencyKeyLength is the longest idempotency key a client may send
+const MaxIdempotencyKeyLength = 255
+
+// RollReceipt records the Pokemon a roll produced under a client's idempotency key,
+// so a retried request can be answered with the original pull
+type RollReceipt struct {
+	UserID     uuid.UUID   `json:"user_id"`
+	Key        string      `json:"key"`
+	PokemonIDs []uuid.UUID `json:"pokemon_ids"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// NewRollReceipt creates a receipt for a completed pull
+func NewRollReceipt(userID uuid.UUID, key string, pokemons []*UserPokemon) *RollReceipt {
+	ids := make([]uuid.UUID, len(pokemons))
+	for i, p := range pokemons {
+		ids[i] = p.ID
+	}
+
+	return &RollReceipt{
+		UserID:     userID,
+		Key:        key,
+		PokemonIDs: ids,
+		CreatedAt:  time.Now(),
+	}
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// ReleasePayoutPercent is the share of a Pokemon's value paid when it is released
+const ReleasePayoutPercent = 25
+
+// ReleaseProtection is why a Pokemon cannot be released right now
+type ReleaseProtection string
+
+const (
+	ProtectedFavorite ReleaseProtection = "favorite"  // Marked as a favorite
+	ProtectedListed   ReleaseProtection = "listed"    // Listed or auctioned on the market
+	ProtectedInBattle ReleaseProtection = "in_battle" // Selected for an unfinished battle
+	ProtectedInTrade  ReleaseProtection = "in_trade"  // Part of a pending trade offer
+)
+
+// ReleaseFilter selects Pokemon from a box to release. Every criterion
+// that is set must match; unset criteria match everything.
+type ReleaseFilter struct {
+	PokemonIDs     []uuid.UUID `json:"pokemon_ids,omitempty"`
+	Rarity         Rarity      `json:"rarity,omitempty"`
+	SpeciesID      int         `json:"species_id,omitempty"`
+	MaxIVPercent   float64     `json:"max_iv_percent,omitempty"`   // Only Pokemon below this IV%
+	KeepPerSpecies int         `json:"keep_per_species,omitempty"` // Only duplicates beyond the best N of a species
+}
+
+// IsEmpty checks if no criteria are set, which would select the whole box
+func (f *ReleaseFilter) IsEmpty() bool {
+	return len(f.PokemonIDs) == 0 && f.Rarity == "" && f.SpeciesID == 0 &&
+		f.MaxIVPercent == 0 && f.KeepPerSpecies == 0
+}
+
+// Select returns the Pokemon in a box that match the filter
+func (f *ReleaseFilter) Select(box []*UserPokemon) []*UserPokemon {
+	var duplicates map[uuid.UUID]bool
+	if f.KeepPerSpecies > 0 {
+		duplicates = Duplicates(box, f.KeepPerSpecies)
+	}
+
+	ids := make(map[uuid.UUID]bool, len(f.PokemonIDs))
+	for _, id := range f.PokemonIDs {
+		ids[id] = true
+	}
+
+	var selected []*UserPokemon
+	for _, p := range box {
+		if len(ids) > 0 && !ids[p.ID] {
+			continue
+		}
+		if f.Rarity != "" && (p.Species == nil || p.Species.Rarity != f.Rarity) {
+			continue
+		}
+		if f.SpeciesID != 0 && p.SpeciesID != f.SpeciesID {
+			continue
+		}
+		if f.MaxIVPercent > 0 && p.IVs.IVPercentage() >= f.MaxIVPercent {
+			continue
+		}
+		if duplicates != nil && !duplicates[p.ID] {
+			continue
+		}
+		selected = append(selected, p)
+	}
+
+	return selected
+}
+
+// Duplicates returns the Pokemon beyond the best keep of each species.
+// Higher IVs rank first and ties go to the Pokemon owned longest.
+func Duplicates(box []*UserPokemon, keep int) map[uuid.UUID]bool {
+	bySpecies := make(map[int][]*UserPokemon)
+	for _, p := range box {
+		bySpecies[p.SpeciesID] = append(bySpecies[p.SpeciesID], p)
+	}
+
+	duplicates := make(map[uuid.UUID]bool)
+	for _, pokemons := range bySpecies {
+		sort.SliceStable(pokemons, func(i, j int) bool {
+			if pokemons[i].IVs.TotalIVs() != pokemons[j].IVs.TotalIVs() {
+				return pokemons[i].IVs.TotalIVs() > pokemons[j].IVs.TotalIVs()
+			}
+			return pokemons[i].AcquiredAt.Before(pokemons[j].AcquiredAt)
+		})
+		for _, p := range pokemons[min(keep, len(pokemons)):] {
+			duplicates[p.ID] = true
+		}
+	}
+
+	return duplicates
+}
+
+// ReleasePayout returns the coins paid for releasing a Pokemon worth value
+func ReleasePayout(value int) int {
+	return value * ReleasePayoutPercent / 100
+}
+
+// ReleaseValue returns the value a release pays out on: the market value,
+// but never more than the formula value. Players can sell to each other at
+// any price, so a market value run up between friends can't be cashed out.
+func (p *UserPokemon) ReleaseValue() int {
+	return min(p.EstimatedValue(), p.FormulaValue())
+}
+
+// ReleasedPokemon is a Pokemon that was (or in a preview, would be) released
+type ReleasedPokemon struct {
+	Pokemon *UserPokemon `json:"pokemon"`
+	Payout  int          `json:"payout"`
+	Candy   int          `json:"candy"` // Candy of the Pokemon's species
+}
+
+// ProtectedPokemon is a Pokemon the filter matched but that was kept
+type ProtectedPokemon struct {
+	Pokemon *UserPokemon      `json:"pokemon"`
+	Reason  ReleaseProtection `json:"reason"`
+}
+
+// ReleaseResult is the outcome of a release or of its dry-run preview
+type ReleaseResult struct {
+	Released    []*ReleasedPokemon  `json:"released"`
+	Protected   []*ProtectedPokemon `json:"protected"`
+	TotalPayout int                 `json:"total_payout"`
+	DryRun      bool                `json:"dry_run"`
+}
@@ -0,0 +1,124 @@
+package domain
+
+import "math/rand"
+
+// AliasTable picks indexes in proportion to their weights in constant time,
+// using Vose's alias method. Building it takes linear time.
+type AliasTable struct {
+	prob  []float64
+	alias []int
+}
+
+// NewAliasTable builds a table over weights. Indexes with a weight of zero
+// or less are never picked. It returns nil if no weight is positive.
+func NewAliasTable(weights []float64) *AliasTable {
+	n := len(weights)
+	total := 0.0
+	for _, weight := range weights {
+		if weight > 0 {
+			total += weight
+		}
+	}
+	if total <= 0 {
+		return nil
+	}
+
+	// Scale so the average weight is 1, then pair each index below 1 with
+	// one above it to fill its column
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, weight := range weights {
+		if weight > 0 {
+			scaled[i] = weight * float64(n) / total
+		}
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	t := &AliasTable{prob: make([]float64, n), alias: make([]int, n)}
+	for len(small) > 0 && len(large) > 0 {
+		less, more := small[len(small)-1], large[len(large)-1]
+		small, large = small[:len(small)-1], large[:len(large)-1]
+
+		t.prob[less] = scaled[less]
+		t.alias[less] = more
+
+		scaled[more] += scaled[less] - 1
+		if scaled[more] < 1 {
+			small = append(small, more)
+		} else {
+			large = append(large, more)
+		}
+	}
+
+	// Whatever is left fills its own column, give or take rounding
+	for _, i := range append(small, large...) {
+		t.prob[i] = 1
+		t.alias[i] = i
+	}
+	return t
+}
+
+// Sample picks an index
+func (t *AliasTable) Sample(r *rand.Rand) int {
+	i := r.Intn(len(t.prob))
+	if r.Float64() < t.prob[i] {
+		return i
+	}
+	return t.alias[i]
+}
+
+// SpeciesSampler picks species of a rarity in proportion to their weights
+type SpeciesSampler struct {
+	species map[Rarity][]*PokemonSpecies
+	tables  map[Rarity]*AliasTable
+}
+
+// NewSpeciesSampler builds a sampler over species, weighing each with
+// weight. Species weighing zero or less are left out.
+func NewSpeciesSampler(species []*PokemonSpecies, weight func(*PokemonSpecies) float64) *SpeciesSampler {
+	s := &SpeciesSampler{
+		species: make(map[Rarity][]*PokemonSpecies),
+		tables:  make(map[Rarity]*AliasTable),
+	}
+
+	weights := make(map[Rarity][]float64)
+	for _, sp := range species {
+		if w := weight(sp); w > 0 {
+			s.species[sp.Rarity] = append(s.species[sp.Rarity], sp)
+			weights[sp.Rarity] = append(weights[sp.Rarity], w)
+		}
+	}
+	for rarity, w := range weights {
+		s.tables[rarity] = NewAliasTable(w)
+	}
+	return s
+}
+
+// SpeciesDropWeight weighs species by their DropWeight
+func SpeciesDropWeight(s *PokemonSpecies) float64 {
+	return s.DropWeight
+}
+
+// Rarities returns the rarities the sampler has species of, most common first
+func (s *SpeciesSampler) Rarities() []Rarity {
+	var rarities []Rarity
+	for _, rarity := range Rarities {
+		if s.tables[rarity] != nil {
+			rarities = append(rarities, rarity)
+		}
+	}
+	return rarities
+}
+
+// Pick picks a species of a rarity, or returns nil if there are none
+func (s *SpeciesSampler) Pick(r *rand.Rand, rarity Rarity) *PokemonSpecies {
+	table := s.tables[rarity]
+	if table == nil {
+		return nil
+	}
+	return s.species[rarity][table.Sample(r)]
+}
@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StreakGraceDays = 1  // Missed days in a row a streak survives
+	StreakCoinStep  = 20 // Coins the daily check-in bonus grows by each day of a streak
+	StreakCoinDays  = 7  // Day of a streak the daily bonus stops growing
+)
+
+// ErrInvalidTimezone is returned for a check-in timezone Go doesn't know
+var ErrInvalidTimezone = errors.New("unknown check-in timezone")
+
+// checkInLocation is the timezone whose midnight starts a new check-in day
+var checkInLocation = time.UTC
+
+// SetCheckInTimezone sets the timezone whose midnight starts a new check-in
+// day, by IANA name such as "America/New_York"
+func SetCheckInTimezone(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return ErrInvalidTimezone
+	}
+	checkInLocation = loc
+	return nil
+}
+
+// CheckInLocation returns the timezone check-in days follow
+func CheckInLocation() *time.Location {
+	return checkInLocation
+}
+
+// CheckInDay returns the start of the check-in day t falls on
+func CheckInDay(t time.Time) time.Time {
+	y, m, d := t.In(checkInLocation).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, checkInLocation)
+}
+
+// NextCheckInDay returns the start of the check-in day after the one t
+// falls on
+func NextCheckInDay(t time.Time) time.Time {
+	y, m, d := t.In(checkInLocation).Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, checkInLocation)
+}
+
+// checkInDaysBetween counts the calendar days from a to b in the check-in
+// timezone, ignoring daylight saving changes
+func checkInDaysBetween(a, b time.Time) int {
+	ay, am, ad := a.In(checkInLocation).Date()
+	by, bm, bd := b.In(checkInLocation).Date()
+	from := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	to := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// LoginStreak is a player's run of daily check-ins. Missing up to
+// StreakGraceDays days in a row keeps the streak going.
+type LoginStreak struct {
+	UserID        uuid.UUID  `json:"user_id"`
+	Current       int        `json:"current"` // Days in the streak as of the last check-in
+	Longest       int        `json:"longest"`
+	TotalCheckIns int        `json:"total_check_ins"`
+	LastCheckIn   *time.Time `json:"last_check_in"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// NewLoginStreak creates a streak for a player who never checked in
+func NewLoginStreak(userID uuid.UUID) *LoginStreak {
+	return &LoginStreak{UserID: userID, UpdatedAt: time.Now()}
+}
+
+// CheckedIn checks if the player already checked in on the day now falls on
+func (s *LoginStreak) CheckedIn(now time.Time) bool {
+	return s.LastCheckIn != nil && checkInDaysBetween(*s.LastCheckIn, now) <= 0
+}
+
+// Alive checks if checking in on the day now falls on continues the streak
+func (s *LoginStreak) Alive(now time.Time) bool {
+	return s.LastCheckIn != nil && checkInDaysBetween(*s.LastCheckIn, now) <= 1+StreakGraceDays
+}
+
+// CurrentAt returns the streak as of now: 0 once it has been broken
+func (s *LoginStreak) CurrentAt(now time.Time) int {
+	if !s.Alive(now) {
+		return 0
+	}
+	return s.Current
+}
+
+// CheckIn counts a check-in at now and returns the day of the streak it
+// was. It returns false if the player already checked in that day.
+func (s *LoginStreak) CheckIn(now time.Time) (int, bool) {
+	if s.CheckedIn(now) {
+		return s.Current, false
+	}
+
+	s.Current = s.CurrentAt(now) + 1
+	s.Longest = max(s.Longest, s.Current)
+	s.TotalCheckIns++
+	s.LastCheckIn = &now
+	s.UpdatedAt = now
+	return s.Current, true
+}
+
+// StreakMilestone is an extra reward on every day of a streak that is a
+// multiple of Every
+type StreakMilestone struct {
+	Every   int            `json:"every"`
+	Coins   int            `json:"coins"`
+	Items   map[string]int `json:"items,omitempty"`
+	Pokemon Rarity         `json:"pokemon,omitempty"` // A Pokemon of this rarity or rarer
+}
+
+// StreakMilestones are the milestone rewards, most frequent first
+var StreakMilestones = []StreakMilestone{
+	{Every: 7, Coins: 500, Pokemon: Rare},
+	{Every: 14, Items: map[string]int{AbilityCapsule: 1}},
+	{Every: 30, Coins: 2000, Pokemon: Epic},
+}
+
+// StreakReward is what a check-in on a day of a streak gives
+type StreakReward struct {
+	Day       int            `json:"day"`
+	Coins     int            `json:"coins"`
+	Items     map[string]int `json:"items,omitempty"`
+	Pokemon   Rarity         `json:"pokemon,omitempty"` // Guaranteed rarity of a free Pokemon, if any
+	Milestone bool           `json:"milestone"`
+}
+
+// StreakRewardFor returns the reward for a check-in on a day of a streak:
+// DailyLoginBonus growing by StreakCoinStep a day for StreakCoinDays days,
+// plus every milestone the day reaches. Milestones landing on the same day
+// add up, and the rarest guaranteed Pokemon is given.
+func StreakRewardFor(day int) StreakReward {
+	reward := StreakReward{
+		Day:   day,
+		Coins: DailyLoginBonus + StreakCoinStep*(min(day, StreakCoinDays)-1),
+	}
+
+	for _, milestone := range StreakMilestones {
+		if day%milestone.Every != 0 {
+			continue
+		}
+		reward.Milestone = true
+		reward.Coins += milestone.Coins
+		for item, count := range milestone.Items {
+			if reward.Items == nil {
+				reward.Items = make(map[string]int)
+			}
+			reward.Items[item] += count
+		}
+		if milestone.Pokemon != "" && milestone.Pokemon.Value() > reward.Pokemon.Value() {
+			reward.Pokemon = milestone.Pokemon
+		}
+	}
+	return reward
+}
+
+// StreakStatus is a player's streak as of now and what comes next
+type StreakStatus struct {
+	Current       int           `json:"current"` // 0 once the streak is broken
+	Longest       int           `json:"longest"`
+	TotalCheckIns int           `json:"total_check_ins"`
+	CheckedIn     bool          `json:"checked_in"` // Whether they checked in today
+	LastCheckIn   *time.Time    `json:"last_check_in"`
+	NextCheckIn   time.Time     `json:"next_check_in"` // When they can next check in
+	BreaksAt      *time.Time    `json:"breaks_at"`     // When the streak is lost without a check-in
+	NextReward    StreakReward  `json:"next_reward"`
+	NextMilestone *StreakReward `json:"next_milestone"` // Next milestone after the next reward
+	Timezone      string        `json:"timezone"`
+}
+
+// Status reports the streak as of now
+func (s *LoginStreak) Status(now time.Time) StreakStatus {
+	status := StreakStatus{
+		Current:       s.CurrentAt(now),
+		Longest:       s.Longest,
+		TotalCheckIns: s.TotalCheckIns,
+		CheckedIn:     s.CheckedIn(now),
+		LastCheckIn:   s.LastCheckIn,
+		NextCheckIn:   now,
+		Timezone:      checkInLocation.String(),
+	}
+
+	if status.CheckedIn {
+		status.NextCheckIn = NextCheckInDay(now)
+	}
+	next := status.Current + 1
+	status.NextReward = StreakRewardFor(next)
+
+	if status.Current > 0 {
+		breaksAt := *s.LastCheckIn
+		for i := 0; i < 2+StreakGraceDays; i++ {
+			breaksAt = NextCheckInDay(breaksAt)
+		}
+		status.BreaksAt = &breaksAt
+	}
+
+	for day := next + 1; day <= next+StreakMilestones[len(StreakMilestones)-1].Every; day++ {
+		if reward := StreakRewardFor(day); reward.Milestone {
+			status.NextMilestone = &reward
+			break
+		}
+	}
+	return status
+}
+
+// CheckInResult is what a daily check-in gave and the streak after it
+type CheckInResult struct {
+	Streak  StreakStatus `json:"streak"`
+	Reward  StreakReward `json:"reward"`
+	Pokemon *UserPokemon `json:"pokemon,omitempty"` // Milestone Pokemon, if any
+	Coins   int          `json:"coins"`             // Balance after the reward
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TradeStatus represents the lifecycle state of a trade offer
+type TradeStatus string
+
+const (
+	TradeStatusPending   TradeStatus = "pending"
+	TradeStatusCompleted TradeStatus = "completed"
+	TradeStatusCancelled TradeStatus = "cancelled"
+	TradeStatusExpired   TradeStatus = "expired"
+)
+
+const (
+	TradeOfferTTL    = 24 * time.Hour // How long an offer waits for both confirmations
+	MaxTradePokemon  = 6              // Most Pokemon each side can put in a trade
+	TradeExpireEvery = time.Minute    // How often stale offers are expired
+)
+
+// Trade is a direct swap between two players. The proposer offers Pokemon
+// and coins for the recipient's Pokemon and coins. Nothing moves until both
+// players have confirmed, and then everything moves at once.
+type Trade struct {
+	ID                  uuid.UUID   `json:"id"`
+	ProposerID          uuid.UUID   `json:"proposer_id"`
+	RecipientID         uuid.UUID   `json:"recipient_id"`
+	ProposerPokemonIDs  []uuid.UUID `json:"proposer_pokemon_ids"`  // Given by the proposer
+	RecipientPokemonIDs []uuid.UUID `json:"recipient_pokemon_ids"` // Given by the recipient
+	ProposerCoins       int         `json:"proposer_coins"`        // Paid by the proposer
+	RecipientCoins      int         `json:"recipient_coins"`       // Paid by the recipient
+	ProposerConfirmed   bool        `json:"proposer_confirmed"`
+	RecipientConfirmed  bool        `json:"recipient_confirmed"`
+	Status              TradeStatus `json:"status"`
+	CreatedAt           time.Time   `json:"created_at"`
+	ExpiresAt           time.Time   `json:"expires_at"`
+	CompletedAt         *time.Time  `json:"completed_at"`
+}
+
+// NewTrade creates a pending trade offer
+func NewTrade(proposerID, recipientID uuid.UUID, proposerPokemonIDs, recipientPokemonIDs []uuid.UUID, proposerCoins, recipientCoins int) *Trade {
+	now := time.Now()
+	return &Trade{
+		ID:                  uuid.New(),
+		ProposerID:          proposerID,
+		RecipientID:         recipientID,
+		ProposerPokemonIDs:  proposerPokemonIDs,
+		RecipientPokemonIDs: recipientPokemonIDs,
+		ProposerCoins:       proposerCoins,
+		RecipientCoins:      recipientCoins,
+		Status:              TradeStatusPending,
+		CreatedAt:           now,
+		ExpiresAt:           now.Add(TradeOfferTTL),
+	}
+}
+
+// IsPending checks if the trade is still waiting for confirmations
+func (t *Trade) IsPending() bool {
+	return t.Status == TradeStatusPending
+}
+
+// IsExpired checks if the offer has run out of time
+func (t *Trade) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// HasParticipant checks if the user is one of the two traders
+func (t *Trade) HasParticipant(userID uuid.UUID) bool {
+	return t.ProposerID == userID || t.RecipientID == userID
+}
+
+// OtherParticipant returns the trader on the other side from userID
+func (t *Trade) OtherParticipant(userID uuid.UUID) uuid.UUID {
+	if t.ProposerID == userID {
+		return t.RecipientID
+	}
+	return t.ProposerID
+}
+
+// Confirm records a participant's confirmation
+func (t *Trade) Confirm(userID uuid.UUID) {
+	if t.ProposerID == userID {
+		t.ProposerConfirmed = true
+	}
+	if t.RecipientID == userID {
+		t.RecipientConfirmed = true
+	}
+}
+
+// IsConfirmed checks if both sides have confirmed
+func (t *Trade) IsConfirmed() bool {
+	return t.ProposerConfirmed && t.RecipientConfirmed
+}
+
+// Includes checks if a Pokemon is part of the trade on either side
+func (t *Trade) Includes(pokemonID uuid.UUID) bool {
+	for _, ids := range [][]uuid.UUID{t.ProposerPokemonIDs, t.RecipientPokemonIDs} {
+		for _, id := range ids {
+			if id == pokemonID {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -226,6 +226,7 @@ func (tr *TurnResolver) ExecuteMove(state *BattleState, attacker, defender *Batt
 	// Type effectiveness message
 	if damageResult.Effectiveness > 1.0 {
 		resolved.Messages = append(resolved.Messages, "It's super effective!")
+		attacker.SuperEffectiveHits++
 	} else if damageResult.Effectiveness < 1.0 && damageResult.Effectiveness > 0 {
 		resolved.Messages = append(resolved.Messages, "It's not very effective...")
 	} else if damageResult.Effectiveness == 0 {
@@ -613,10 +614,10 @@ func (tr *TurnResolver) BuildDamageContext(state *BattleState, attacker, defende
 		Weather:         state.Weather,
 		Terrain:         state.Terrain,
 		Turn:            state.Turn,
-		// Abilities and items would be loaded here from database
-		AttackerAbility: nil,
+		AttackerAbility: GetAbilityByName(attacker.Pokemon.Ability),
+		DefenderAbility: GetAbilityByName(defender.Pokemon.Ability),
+		// Items would be loaded here from database
 		AttackerItem:    nil,
-		DefenderAbility: nil,
 		DefenderItem:    nil,
 	}
 }
@@ -636,6 +637,12 @@ func (tr *TurnResolver) DetermineWinner(state *BattleState) uuid.UUID {
 
 // GetWeatherSetMessage returns the message for weather being set
 func (tr *TurnResolver) GetWeatherSetMessage(weather Weather) string {
+	return weatherSetMessage(weather)
+}
+
+// weatherSetMessage returns the message for weather being set by a move or
+// an ability
+func weatherSetMessage(weather Weather) string {
 	switch weather {
 	case WeatherSun:
 		return "The sunlight turned harsh!"
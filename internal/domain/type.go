@@ -224,3 +224,34 @@ func IsValidType(t string) bool {
 	}
 	return false
 }
+
+// TypeMatchup is how much damage each attacking type deals to a species
+type TypeMatchup struct {
+	Multipliers map[PokemonType]float64 `json:"multipliers"` // Every type, 1 if neutral
+	Weaknesses  []PokemonType           `json:"weaknesses"`  // Types dealing 2x or 4x, in AllTypes order
+	Resistances []PokemonType           `json:"resistances"` // Types dealing 0.5x or 0.25x
+	Immunities  []PokemonType           `json:"immunities"`  // Types dealing no damage
+}
+
+// NewTypeMatchup computes the matchup chart of a single or dual type
+func NewTypeMatchup(type1 PokemonType, type2 *PokemonType) *TypeMatchup {
+	matchup := &TypeMatchup{
+		Multipliers: make(map[PokemonType]float64),
+		Weaknesses:  []PokemonType{},
+		Resistances: []PokemonType{},
+		Immunities:  []PokemonType{},
+	}
+	for _, attackType := range AllTypes() {
+		multiplier := CalculateTypeEffectiveness(attackType, &type1, type2)
+		matchup.Multipliers[attackType] = multiplier
+		switch {
+		case multiplier == 0:
+			matchup.Immunities = append(matchup.Immunities, attackType)
+		case multiplier < 1:
+			matchup.Resistances = append(matchup.Resistances, attackType)
+		case multiplier > 1:
+			matchup.Weaknesses = append(matchup.Weaknesses, attackType)
+		}
+	}
+	return matchup
+}
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ValuationWindow        = 30 * 24 * time.Hour // Sales older than this are ignored
+	MinSalesForValuation   = 5                   // Fewer sales fall back to the formula value
+	MaxValuationSales      = 50                  // Only the most recent sales count towards the median
+	ValuationOutlierFactor = 3.0                 // Sales this many times above or below the median are left out
+	ReferenceIVPercent     = 50.0                // IV% that species prices are quoted at
+	PriceHistoryBucket     = 24 * time.Hour      // One price history point per day
+)
+
+// MarketSale is a completed sale of one Pokemon, as used for valuation
+type MarketSale struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	SpeciesID     int       `json:"species_id"`
+	Price         int       `json:"price"`
+	IVPercentage  float64   `json:"iv_percentage"`  // IV% of the Pokemon when it sold
+	VariantFactor float64   `json:"variant_factor"` // Shiny and form value factor when it sold
+	SoldAt        time.Time `json:"sold_at"`
+}
+
+// variantFactor returns the sale's variant factor, treating sales recorded
+// before variants existed as plain Pokemon
+func (s *MarketSale) variantFactor() float64 {
+	if s.VariantFactor <= 0 {
+		return 1
+	}
+	return s.VariantFactor
+}
+
+// SpeciesValuation is the rolling median sale price of a species,
+// adjusted to ReferenceIVPercent
+type SpeciesValuation struct {
+	SpeciesID   int       `json:"species_id"`
+	MedianPrice int       `json:"median_price"` // At ReferenceIVPercent
+	SampleSize  int       `json:"sample_size"`
+	Since       time.Time `json:"since"` // Oldest sale counted
+}
+
+// PricePoint summarises the sales of one history bucket
+type PricePoint struct {
+	Start       time.Time `json:"start"`
+	MedianPrice int       `json:"median_price"` // Raw prices, not IV adjusted
+	MinPrice    int       `json:"min_price"`
+	MaxPrice    int       `json:"max_price"`
+	Volume      int       `json:"volume"`
+}
+
+// IVValueFactor returns how much more a Pokemon is worth than one with 0% IVs.
+// Perfect IVs are worth 50% more, matching the formula value.
+func IVValueFactor(ivPercentage float64) float64 {
+	return 1 + 0.5*ivPercentage/100
+}
+
+// NewSpeciesValuation computes a species' value from its sales, newest first.
+// Each price is scaled to ReferenceIVPercent and to a plain, base-form
+// Pokemon before taking the median, so a run of high-IV or shiny sales
+// doesn't inflate the price of average Pokemon. Sales more than
+// ValuationOutlierFactor times away from the median are then left out.
+// Returns nil if fewer than MinSalesForValuation sales are left.
+func NewSpeciesValuation(speciesID int, sales []*MarketSale) *SpeciesValuation {
+	if len(sales) > MaxValuationSales {
+		sales = sales[:MaxValuationSales]
+	}
+	if len(sales) < MinSalesForValuation {
+		return nil
+	}
+
+	reference := IVValueFactor(ReferenceIVPercent)
+	adjusted := make([]float64, len(sales))
+	for i, sale := range sales {
+		adjusted[i] = float64(sale.Price) * reference / IVValueFactor(sale.IVPercentage) / sale.variantFactor()
+	}
+
+	// Sales far from the median are likely coins moved between accounts
+	// rather than real prices, so they're left out
+	middle := median(adjusted)
+	kept := make([]float64, 0, len(adjusted))
+	var since time.Time
+	for i, price := range adjusted {
+		if price > middle*ValuationOutlierFactor || price < middle/ValuationOutlierFactor {
+			continue
+		}
+		kept = append(kept, price)
+		if since.IsZero() || sales[i].SoldAt.Before(since) {
+			since = sales[i].SoldAt
+		}
+	}
+	if len(kept) < MinSalesForValuation {
+		return nil
+	}
+
+	return &SpeciesValuation{
+		SpeciesID:   speciesID,
+		MedianPrice: int(math.Round(median(kept))),
+		SampleSize:  len(kept),
+		Since:       since,
+	}
+}
+
+// ValueAt returns the value of a Pokemon of this species with the given IV%
+func (v *SpeciesValuation) ValueAt(ivPercentage float64) int {
+	return int(math.Round(float64(v.MedianPrice) * IVValueFactor(ivPercentage) / IVValueFactor(ReferenceIVPercent)))
+}
+
+// ValueOf returns the value of a Pokemon of this species, from its IV%,
+// shininess and form
+func (v *SpeciesValuation) ValueOf(pokemon *UserPokemon) int {
+	return int(math.Round(float64(v.ValueAt(pokemon.IVs.IVPercentage())) * pokemon.VariantValueFactor()))
+}
+
+// BuildPriceHistory groups sales into buckets starting at from, oldest first.
+// Buckets without sales are left out.
+func BuildPriceHistory(sales []*MarketSale, from time.Time, bucket time.Duration) []PricePoint {
+	groups := make(map[int][]float64)
+	for _, sale := range sales {
+		if sale.SoldAt.Before(from) {
+			continue
+		}
+		index := int(sale.SoldAt.Sub(from) / bucket)
+		groups[index] = append(groups[index], float64(sale.Price))
+	}
+
+	indexes := make([]int, 0, len(groups))
+	for index := range groups {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	history := make([]PricePoint, 0, len(indexes))
+	for _, index := range indexes {
+		prices := groups[index]
+		sort.Float64s(prices)
+		history = append(history, PricePoint{
+			Start:       from.Add(time.Duration(index) * bucket),
+			MedianPrice: int(math.Round(median(prices))),
+			MinPrice:    int(prices[0]),
+			MaxPrice:    int(prices[len(prices)-1]),
+			Volume:      len(prices),
+		})
+	}
+
+	return history
+}
+
+// median returns the middle value, or the mean of the two middle values
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// PriceReport is a species' current valuation and price history
+type PriceReport struct {
+	SpeciesID int               `json:"species_id"`
+	Valuation *SpeciesValuation `json:"valuation"` // Nil until there are enough sales
+	History   []PricePoint      `json:"history"`
+}
@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+)
+
+const (
+	DefaultShinyRate = 1.0 / 512 // Chance that a new Pokemon is shiny
+
+	GenderlessRate = -1 // GenderRate of species that have no gender
+
+	ShinyValueFactor = 4.0 // Shiny Pokemon are worth this many times more
+	FormValueFactor  = 1.5 // Alternate forms are worth this many times more
+)
+
+// ErrInvalidShinyRate is returned when a shiny rate is not a probability
+var ErrInvalidShinyRate = errors.New("shiny rate must be between 0 and 1")
+
+// shinyRate is the chance that NewUserPokemon rolls a shiny
+var shinyRate = DefaultShinyRate
+
+// ShinyRate returns the chance that a new Pokemon is shiny
+func ShinyRate() float64 {
+	return shinyRate
+}
+
+// SetShinyRate changes the chance that a new Pokemon is shiny
+func SetShinyRate(rate float64) error {
+	if rate < 0 || rate > 1 {
+		return ErrInvalidShinyRate
+	}
+	shinyRate = rate
+	return nil
+}
+
+// Gender of a Pokemon, rolled from its species' GenderRate
+type Gender string
+
+const (
+	Male       Gender = "male"
+	Female     Gender = "female"
+	Genderless Gender = "genderless"
+)
+
+// IsValid checks if the gender is known
+func (g Gender) IsValid() bool {
+	switch g {
+	case Male, Female, Genderless:
+		return true
+	default:
+		return false
+	}
+}
+
+// Symbol returns the gender sign, or an empty string when genderless
+func (g Gender) Symbol() string {
+	switch g {
+	case Male:
+		return "♂"
+	case Female:
+		return "♀"
+	default:
+		return ""
+	}
+}
+
+// PokemonForm is an alternate or regional form of a species. A form
+// replaces the species' types, base stats and sprites.
+type PokemonForm struct {
+	ID             int          `json:"id"`
+	SpeciesID      int          `json:"species_id"`
+	Name           string       `json:"name"` // e.g., "alola"
+	Type1          PokemonType  `json:"type1"`
+	Type2          *PokemonType `json:"type2"`
+	BaseHP         int          `json:"base_hp"`
+	BaseAttack     int          `json:"base_attack"`
+	BaseDefense    int          `json:"base_defense"`
+	BaseSpAttack   int          `json:"base_sp_attack"`
+	BaseSpDefense  int          `json:"base_sp_defense"`
+	BaseSpeed      int          `json:"base_speed"`
+	SpriteURL      string       `json:"sprite_url"`
+	ShinySpriteURL string       `json:"shiny_sprite_url"`
+	RollChance     float64      `json:"roll_chance"` // Chance a pull of the species comes in this form
+}
+
+// Form returns the species' form with the given name, or nil if it has none
+func (s *PokemonSpecies) Form(name string) *PokemonForm {
+	for _, form := range s.Forms {
+		if strings.EqualFold(form.Name, name) {
+			return form
+		}
+	}
+	return nil
+}
+
+// RollGender picks a gender following the species' gender ratio
+func (s *PokemonSpecies) RollGender(r *rand.Rand) Gender {
+	if s.GenderRate == GenderlessRate {
+		return Genderless
+	}
+	if r.Intn(8) < s.GenderRate {
+		return Female
+	}
+	return Male
+}
+
+// RollForm picks one of the species' forms by their roll chances, or nil
+// for the base form
+func (s *PokemonSpecies) RollForm(r *rand.Rand) *PokemonForm {
+	roll := r.Float64()
+	for _, form := range s.Forms {
+		if roll < form.RollChance {
+			return form
+		}
+		roll -= form.RollChance
+	}
+	return nil
+}
+
+// RollShiny decides whether a new Pokemon is shiny at the configured rate
+func RollShiny(r *rand.Rand) bool {
+	return r.Float64() < shinyRate
+}
+
+// rollVariant sets a new Pokemon's shininess, gender, form and ability
+func (p *UserPokemon) rollVariant(r *rand.Rand) {
+	p.IsShiny = RollShiny(r)
+	p.Gender = p.Species.RollGender(r)
+	p.SetForm(p.Species.RollForm(r))
+	p.AbilitySlot = p.Species.RollAbilitySlot(r)
+}
+
+// SetForm changes the Pokemon's form, or returns it to the base form when nil
+func (p *UserPokemon) SetForm(form *PokemonForm) {
+	p.Form = form
+	p.FormID = nil
+	if form != nil {
+		id := form.ID
+		p.FormID = &id
+	}
+}
+
+// FormName returns the name of the Pokemon's form, or an empty string for
+// the base form
+func (p *UserPokemon) FormName() string {
+	if p.Form == nil {
+		return ""
+	}
+	return p.Form.Name
+}
+
+// EffectiveSpecies returns the species with the Pokemon's form applied,
+// which is what its stats, types and sprites come from
+func (p *UserPokemon) EffectiveSpecies() *PokemonSpecies {
+	if p.Species == nil || p.Form == nil {
+		return p.Species
+	}
+
+	species := *p.Species
+	species.Type1 = p.Form.Type1
+	species.Type2 = p.Form.Type2
+	species.BaseHP = p.Form.BaseHP
+	species.BaseAttack = p.Form.BaseAttack
+	species.BaseDefense = p.Form.BaseDefense
+	species.BaseSpAttack = p.Form.BaseSpAttack
+	species.BaseSpDefense = p.Form.BaseSpDefense
+	species.BaseSpeed = p.Form.BaseSpeed
+	species.SpriteURL = p.Form.SpriteURL
+	species.ShinySpriteURL = p.Form.ShinySpriteURL
+	return &species
+}
+
+// SpriteURL returns the Pokemon's sprite, taking its form and shininess
+// into account
+func (p *UserPokemon) SpriteURL() string {
+	species := p.EffectiveSpecies()
+	if species == nil {
+		return ""
+	}
+	if p.IsShiny && species.ShinySpriteURL != "" {
+		return species.ShinySpriteURL
+	}
+	return species.SpriteURL
+}
+
+// VariantValueFactor returns how much more the Pokemon is worth for being
+// shiny or in an alternate form
+func (p *UserPokemon) VariantValueFactor() float64 {
+	factor := 1.0
+	if p.IsShiny {
+		factor *= ShinyValueFactor
+	}
+	if p.Form != nil {
+		factor *= FormValueFactor
+	}
+	return factor
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/google/uuid"
+)
+
+type AbilityHandler struct {
+	abilityService   *service.AbilityService
+	valuationService *service.ValuationService
+}
+
+func NewAbilityHandler(abilityService *service.AbilityService, valuationService *service.ValuationService) *AbilityHandler {
+	return &AbilityHandler{
+		abilityService:   abilityService,
+		valuationService: valuationService,
+	}
+}
+
+type ChangeAbilityRequest struct {
+	UserID string `json:"user_id"`
+	Slot   int    `json:"slot"` // Regular ability slot to switch to, 1 or 2
+}
+
+type ChangeAbilityResponse struct {
+	Pokemon         PokemonRollResponse `json:"pokemon"`
+	PreviousAbility string              `json:"previous_ability"`
+}
+
+// POST /api/pokemon/{id}/ability
+func (h *AbilityHandler) ChangeAbility(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 {
+		RespondNotFound(w, "Route not found")
+		return
+	}
+
+	pokemonID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		RespondBadRequest(w, "Invalid Pokemon ID format")
+		return
+	}
+
+	var req ChangeAbilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	result, err := h.abilityService.ChangeAbility(r.Context(), userID, pokemonID, domain.AbilitySlot(req.Slot))
+	if err != nil {
+		switch {
+		case errors.Is(err, validators.ErrInvalidAbilitySlot),
+			errors.Is(err, validators.ErrEmptyAbilitySlot),
+			errors.Is(err, validators.ErrSameAbility),
+			errors.Is(err, validators.ErrHiddenAbilityLocked):
+			RespondBadRequest(w, err.Error())
+		case errors.Is(err, service.ErrNotPokemonOwner):
+			RespondError(w, http.StatusForbidden, ErrCodeForbidden, err.Error())
+		case errors.Is(err, service.ErrNoAbilityCapsule):
+			RespondError(w, http.StatusPaymentRequired, ErrCodeInsufficientItems, err.Error())
+		case errors.Is(err, service.ErrPokemonLocked):
+			RespondConflict(w, err.Error())
+		case strings.Contains(err.Error(), "not found"):
+			RespondNotFound(w, err.Error())
+		default:
+			RespondInternalError(w, "Failed to change ability")
+		}
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, ChangeAbilityResponse{
+		Pokemon:         pokemonsToResponse(r.Context(), h.valuationService, []*domain.UserPokemon{result.Pokemon})[0],
+		PreviousAbility: result.PreviousAbility,
+	})
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/google/uuid"
+)
+
+type AuctionHandler struct {
+	auctionService *service.AuctionService
+}
+
+func NewAuctionHandler(auctionService *service.AuctionService) *AuctionHandler {
+	return &AuctionHandler{
+		auctionService: auctionService,
+	}
+}
+
+type CreateAuctionRequest struct {
+	UserID          string `json:"user_id"`
+	PokemonID       string `json:"pokemon_id"`
+	StartingPrice   int    `json:"starting_price"`
+	MinIncrement    int    `json:"min_increment"`
+	DurationMinutes int    `json:"duration_minutes"`
+}
+
+type PlaceBidRequest struct {
+	UserID string `json:"user_id"`
+	Amount int    `json:"amount"`
+}
+
+type AuctionResponse struct {
+	Listing         ListingResponse `json:"listing"`
+	StartingPrice   int             `json:"starting_price"`
+	MinIncrement    int             `json:"min_increment"`
+	CurrentBid      int             `json:"current_bid"`
+	MinimumBid      int             `json:"minimum_bid"`
+	HighestBidderID *string         `json:"highest_bidder_id"`
+	BidCount        int             `json:"bid_count"`
+	EndsAt          string          `json:"ends_at"`
+}
+
+type AuctionBidResponse struct {
+	ID        string `json:"id"`
+	BidderID  string `json:"bidder_id"`
+	Amount    int    `json:"amount"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Auctions routes /api/market/auctions and /api/market/auctions/{id}[/bids|/cancel]
+func (h *AuctionHandler) Auctions(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case len(pathParts) == 3 && r.Method == http.MethodGet:
+		h.ListAuctions(w, r)
+	case len(pathParts) == 3 && r.Method == http.MethodPost:
+		h.CreateAuction(w, r)
+	case len(pathParts) == 4 && r.Method == http.MethodGet:
+		h.GetAuction(w, r, pathParts[3])
+	case len(pathParts) == 5 && pathParts[4] == "bids" && r.Method == http.MethodPost:
+		h.PlaceBid(w, r, pathParts[3])
+	case len(pathParts) == 5 && pathParts[4] == "cancel" && r.Method == http.MethodPost:
+		h.CancelAuction(w, r, pathParts[3])
+	case len(pathParts) <= 5:
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+	default:
+		RespondNotFound(w, "Route not found")
+	}
+}
+
+// GET /api/market/auctions?limit=&offset=
+func (h *AuctionHandler) ListAuctions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit, err := parseIntParam(query.Get("limit"))
+	if err != nil || limit > 100 {
+		RespondBadRequest(w, "limit must be between 0 and 100")
+		return
+	}
+
+	offset, err := parseIntParam(query.Get("offset"))
+	if err != nil {
+		RespondBadRequest(w, "offset must be a non-negative integer")
+		return
+	}
+
+	auctions, err := h.auctionService.ListAuctions(r.Context(), limit, offset)
+	if err != nil {
+		RespondInternalError(w, "Failed to list auctions")
+		return
+	}
+
+	response := make([]AuctionResponse, len(auctions))
+	for i, a := range auctions {
+		response[i] = auctionToResponse(a)
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"auctions": response,
+		"count":    len(response),
+	})
+}
+
+// POST /api/market/auctions
+func (h *AuctionHandler) CreateAuction(w http.ResponseWriter, r *http.Request) {
+	var req CreateAuctionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	pokemonID, err := uuid.Parse(req.PokemonID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid Pokemon ID format")
+		return
+	}
+
+	duration := time.Duration(req.DurationMinutes) * time.Minute
+	auction, err := h.auctionService.CreateAuction(r.Context(), userID, pokemonID, req.StartingPrice, req.MinIncrement, duration)
+	if err != nil {
+		respondMarketError(w, err, "Failed to create auction")
+		return
+	}
+
+	RespondJSON(w, http.StatusCreated, auctionToResponse(auction))
+}
+
+// GET /api/market/auctions/{id}
+func (h *AuctionHandler) GetAuction(w http.ResponseWriter, r *http.Request, listingIDStr string) {
+	listingID, err := uuid.Parse(listingIDStr)
+	if err != nil {
+		RespondBadRequest(w, "Invalid auction ID format")
+		return
+	}
+
+	auction, err := h.auctionService.GetAuction(r.Context(), listingID)
+	if err != nil {
+		respondMarketError(w, err, "Failed to retrieve auction")
+		return
+	}
+
+	bids, err := h.auctionService.ListBids(r.Context(), listingID)
+	if err != nil {
+		RespondInternalError(w, "Failed to retrieve bids")
+		return
+	}
+
+	bidResponses := make([]AuctionBidResponse, len(bids))
+	for i, b := range bids {
+		bidResponses[i] = AuctionBidResponse{
+			ID:        b.ID.String(),
+			BidderID:  b.BidderID.String(),
+			Amount:    b.Amount,
+			CreatedAt: b.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"auction": auctionToResponse(auction),
+		"bids":    bidResponses,
+	})
+}
+
+// POST /api/market/auctions/{id}/bids
+func (h *AuctionHandler) PlaceBid(w http.ResponseWriter, r *http.Request, listingIDStr string) {
+	listingID, err := uuid.Parse(listingIDStr)
+	if err != nil {
+		RespondBadRequest(w, "Invalid auction ID format")
+		return
+	}
+
+	var req PlaceBidRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	auction, err := h.auctionService.PlaceBid(r.Context(), userID, listingID, req.Amount)
+	if err != nil {
+		respondMarketError(w, err, "Failed to place bid")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, auctionToResponse(auction))
+}
+
+// POST /api/market/auctions/{id}/cancel
+func (h *AuctionHandler) CancelAuction(w http.ResponseWriter, r *http.Request, listingIDStr string) {
+	listingID, userID, ok := parseListingAction(w, r, listingIDStr)
+	if !ok {
+		return
+	}
+
+	if err := h.auctionService.CancelAuction(r.Context(), userID, listingID); err != nil {
+		respondMarketError(w, err, "Failed to cancel auction")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]string{
+		"id":     listingID.String(),
+		"status": string(domain.ListingStatusCancelled),
+	})
+}
+
+// auctionToResponse converts an auction to response format
+func auctionToResponse(a *domain.Auction) AuctionResponse {
+	response := AuctionResponse{
+		Listing:       listingToResponse(a.Listing),
+		StartingPrice: a.StartingPrice,
+		MinIncrement:  a.MinIncrement,
+		CurrentBid:    a.CurrentBid,
+		MinimumBid:    a.MinimumBid(),
+		BidCount:      a.BidCount,
+		EndsAt:        a.EndsAt.UTC().Format(time.RFC3339),
+	}
+
+	if a.HighestBidderID != nil {
+		bidder := a.HighestBidderID.String()
+		response.HighestBidderID = &bidder
+	}
+
+	return response
+}
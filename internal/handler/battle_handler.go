@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/google/uuid"
+)
+
+type BattleHandler struct {
+	wildBattleService *service.WildBattleService
+	valuationService  *service.ValuationService
+}
+
+func NewBattleHandler(wildBattleService *service.WildBattleService, valuationService *service.ValuationService) *BattleHandler {
+	return &BattleHandler{
+		wildBattleService: wildBattleService,
+		valuationService:  valuationService,
+	}
+}
+
+type WildBattleRequest struct {
+	UserID string `json:"user_id"`
+	Format string `json:"format"` // Optional, defaults to standard
+}
+
+type WildBattleResponse struct {
+	Pokemon    PokemonRollResponse   `json:"pokemon"`
+	Wild       SpeciesResponse       `json:"wild"`
+	WildLevel  int                   `json:"wild_level"`
+	Format     string                `json:"format"`
+	Won        bool                  `json:"won"`
+	Turns      int                   `json:"turns"`
+	Experience domain.ExperienceGain `json:"experience"`
+}
+
+// GET /api/battles/formats
+func (h *BattleHandler) GetFormats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	formats := make([]*domain.BattleFormat, 0, len(domain.BattleFormats))
+	for _, format := range domain.BattleFormats {
+		formats = append(formats, format)
+	}
+	sort.Slice(formats, func(i, j int) bool { return formats[i].Name < formats[j].Name })
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"formats": formats,
+		"count":   len(formats),
+	})
+}
+
+// POST /api/pokemon/{id}/wild-battle
+func (h *BattleHandler) WildBattle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 {
+		RespondNotFound(w, "Route not found")
+		return
+	}
+
+	pokemonID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		RespondBadRequest(w, "Invalid Pokemon ID format")
+		return
+	}
+
+	var req WildBattleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	result, err := h.wildBattleService.Battle(r.Context(), userID, pokemonID, req.Format)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnknownFormat):
+			RespondBadRequest(w, err.Error())
+		case errors.Is(err, service.ErrNotPokemonOwner):
+			RespondError(w, http.StatusForbidden, ErrCodeForbidden, err.Error())
+		case errors.Is(err, service.ErrPokemonLocked):
+			RespondConflict(w, err.Error())
+		case strings.Contains(err.Error(), "not found"):
+			RespondNotFound(w, err.Error())
+		default:
+			RespondInternalError(w, "Failed to battle wild pokemon")
+		}
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, WildBattleResponse{
+		Pokemon:    pokemonsToResponse(r.Context(), h.valuationService, []*domain.UserPokemon{result.Pokemon})[0],
+		Wild:       speciesToResponse(result.Wild),
+		WildLevel:  result.WildLevel,
+		Format:     result.Format,
+		Won:        result.Won,
+		Turns:      result.Turns,
+		Experience: *result.Gain,
+	})
+}
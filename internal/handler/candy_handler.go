@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/google/uuid"
+)
+
+type CandyHandler struct {
+	candyService     *service.CandyService
+	valuationService *service.ValuationService
+}
+
+func NewCandyHandler(candyService *service.CandyService, valuationService *service.ValuationService) *CandyHandler {
+	return &CandyHandler{
+		candyService:     candyService,
+		valuationService: valuationService,
+	}
+}
+
+type IVChangeRequest struct {
+	UserID string   `json:"user_id"`
+	Stats  []string `json:"stats"`
+	Points int      `json:"points"` // Raise only
+}
+
+type MintRequest struct {
+	UserID string `json:"user_id"`
+	Nature string `json:"nature"`
+}
+
+type AuditEntryResponse struct {
+	ID         string                `json:"id"`
+	Action     string                `json:"action"`
+	CandyDelta int                   `json:"candy_delta"`
+	Before     *domain.PokemonTraits `json:"before"`
+	After      *domain.PokemonTraits `json:"after"`
+	CreatedAt  string                `json:"created_at"`
+}
+
+// GET /api/users/{user_id}/candy
+func (h *CandyHandler) GetCandy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 {
+		RespondNotFound(w, "Route not found")
+		return
+	}
+
+	userID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	balances, err := h.candyService.ListCandy(r.Context(), userID)
+	if err != nil {
+		RespondInternalError(w, "Failed to retrieve candy")
+		return
+	}
+	if balances == nil {
+		balances = []*domain.CandyBalance{}
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"candy": balances,
+		"count": len(balances),
+	})
+}
+
+// PokemonActions routes /api/pokemon/{id}/reroll-ivs|raise-ivs|mint|history
+func (h *CandyHandler) PokemonActions(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 {
+		RespondNotFound(w, "Route not found")
+		return
+	}
+
+	pokemonID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		RespondBadRequest(w, "Invalid Pokemon ID format")
+		return
+	}
+
+	switch {
+	case pathParts[3] == "history" && r.Method == http.MethodGet:
+		h.GetHistory(w, r, pokemonID)
+	case pathParts[3] == "reroll-ivs" && r.Method == http.MethodPost:
+		h.changeIVs(w, r, pokemonID, false)
+	case pathParts[3] == "raise-ivs" && r.Method == http.MethodPost:
+		h.changeIVs(w, r, pokemonID, true)
+	case pathParts[3] == "mint" && r.Method == http.MethodPost:
+		h.Mint(w, r, pokemonID)
+	case pathParts[3] == "history", pathParts[3] == "reroll-ivs", pathParts[3] == "raise-ivs", pathParts[3] == "mint":
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+	default:
+		RespondNotFound(w, "Route not found")
+	}
+}
+
+// POST /api/pokemon/{id}/reroll-ivs and /api/pokemon/{id}/raise-ivs
+func (h *CandyHandler) changeIVs(w http.ResponseWriter, r *http.Request, pokemonID uuid.UUID, raise bool) {
+	var req IVChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	var pokemon *domain.UserPokemon
+	if raise {
+		pokemon, err = h.candyService.RaiseIVs(r.Context(), userID, pokemonID, req.Stats, req.Points)
+	} else {
+		pokemon, err = h.candyService.RerollIVs(r.Context(), userID, pokemonID, req.Stats)
+	}
+	if err != nil {
+		respondCandyError(w, err)
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, pokemonsToResponse(r.Context(), h.valuationService, []*domain.UserPokemon{pokemon})[0])
+}
+
+// POST /api/pokemon/{id}/mint
+func (h *CandyHandler) Mint(w http.ResponseWriter, r *http.Request, pokemonID uuid.UUID) {
+	var req MintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	pokemon, err := h.candyService.MintNature(r.Context(), userID, pokemonID, domain.Nature(req.Nature))
+	if err != nil {
+		respondCandyError(w, err)
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, pokemonsToResponse(r.Context(), h.valuationService, []*domain.UserPokemon{pokemon})[0])
+}
+
+// GET /api/pokemon/{id}/history?limit=
+func (h *CandyHandler) GetHistory(w http.ResponseWriter, r *http.Request, pokemonID uuid.UUID) {
+	limit, err := parseIntParam(r.URL.Query().Get("limit"))
+	if err != nil || limit > service.MaxAuditHistory {
+		RespondBadRequest(w, "limit must be between 0 and 100")
+		return
+	}
+
+	entries, err := h.candyService.GetHistory(r.Context(), pokemonID, limit)
+	if err != nil {
+		RespondInternalError(w, "Failed to retrieve history")
+		return
+	}
+
+	response := make([]AuditEntryResponse, len(entries))
+	for i, e := range entries {
+		response[i] = AuditEntryResponse{
+			ID:         e.ID.String(),
+			Action:     string(e.Action),
+			CandyDelta: e.CandyDelta,
+			Before:     e.Before,
+			After:      e.After,
+			CreatedAt:  e.CreatedAt.UTC().Format(time.RFC3339),
+		}
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"history": response,
+		"count":   len(response),
+	})
+}
+
+// respondCandyError maps candy errors to HTTP responses
+func respondCandyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, validators.ErrNoIVStats),
+		errors.Is(err, validators.ErrUnknownIVStat),
+		errors.Is(err, validators.ErrDuplicateIVStat),
+		errors.Is(err, validators.ErrInvalidIVPoints),
+		errors.Is(err, validators.ErrInvalidIV),
+		errors.Is(err, validators.ErrInvalidNature),
+		errors.Is(err, service.ErrSameNature):
+		RespondBadRequest(w, err.Error())
+	case errors.Is(err, service.ErrNotPokemonOwner):
+		RespondError(w, http.StatusForbidden, ErrCodeForbidden, err.Error())
+	case errors.Is(err, service.ErrInsufficientCandy):
+		RespondError(w, http.StatusPaymentRequired, ErrCodeInsufficientCandy, err.Error())
+	case errors.Is(err, service.ErrPokemonLocked):
+		RespondConflict(w, err.Error())
+	case strings.Contains(err.Error(), "not found"):
+		RespondNotFound(w, err.Error())
+	default:
+		RespondInternalError(w, "Failed to update pokemon")
+	}
+}
@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/google/uuid"
+)
+
+type EvolutionHandler struct {
+	evolutionService *service.EvolutionService
+	valuationService *service.ValuationService
+}
+
+func NewEvolutionHandler(evolutionService *service.EvolutionService, valuationService *service.ValuationService) *EvolutionHandler {
+	return &EvolutionHandler{
+		evolutionService: evolutionService,
+		valuationService: valuationService,
+	}
+}
+
+type EvolveRequest struct {
+	UserID    string `json:"user_id"`
+	SpeciesID int    `json:"species_id"` // Optional when there is only one evolution
+}
+
+type EvolutionOptionResponse struct {
+	Species    SpeciesResponse          `json:"species"`
+	CandyCost  int                      `json:"candy_cost"`
+	CoinCost   int                      `json:"coin_cost"`
+	Item       string                   `json:"item,omitempty"`
+	BattleWins int                      `json:"battle_wins"`
+	Progress   domain.EvolutionProgress `json:"progress"`
+	Unmet      []string                 `json:"unmet"`
+	CanEvolve  bool                     `json:"can_evolve"`
+}
+
+type MoveResponse struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Category string `json:"category"`
+	Power    int    `json:"power"`
+	Accuracy int    `json:"accuracy"`
+	PP       int    `json:"pp"`
+	Priority int    `json:"priority"`
+}
+
+type EvolveResponse struct {
+	Pokemon       PokemonRollResponse `json:"pokemon"`
+	FromSpecies   SpeciesResponse     `json:"from_species"`
+	UnlockedMoves []MoveResponse      `json:"unlocked_moves"`
+}
+
+// GET /api/users/{user_id}/items
+func (h *EvolutionHandler) GetItems(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 {
+		RespondNotFound(w, "Route not found")
+		return
+	}
+
+	userID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	items, err := h.evolutionService.ListItems(r.Context(), userID)
+	if err != nil {
+		RespondInternalError(w, "Failed to retrieve items")
+		return
+	}
+	if items == nil {
+		items = []*domain.InventoryItem{}
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"items": items,
+		"count": len(items),
+	})
+}
+
+// PokemonActions routes /api/pokemon/{id}/evolutions|evolve|moves
+func (h *EvolutionHandler) PokemonActions(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 {
+		RespondNotFound(w, "Route not found")
+		return
+	}
+
+	pokemonID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		RespondBadRequest(w, "Invalid Pokemon ID format")
+		return
+	}
+
+	switch {
+	case pathParts[3] == "evolutions" && r.Method == http.MethodGet:
+		h.GetEvolutions(w, r, pokemonID)
+	case pathParts[3] == "evolve" && r.Method == http.MethodPost:
+		h.Evolve(w, r, pokemonID)
+	case pathParts[3] == "moves" && r.Method == http.MethodGet:
+		h.GetMoves(w, r, pokemonID)
+	case pathParts[3] == "evolutions", pathParts[3] == "evolve", pathParts[3] == "moves":
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+	default:
+		RespondNotFound(w, "Route not found")
+	}
+}
+
+// GET /api/pokemon/{id}/evolutions?user_id=
+func (h *EvolutionHandler) GetEvolutions(w http.ResponseWriter, r *http.Request, pokemonID uuid.UUID) {
+	userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	options, err := h.evolutionService.ListEvolutions(r.Context(), userID, pokemonID)
+	if err != nil {
+		respondEvolutionError(w, err)
+		return
+	}
+
+	response := make([]EvolutionOptionResponse, len(options))
+	for i, option := range options {
+		e := option.Evolution
+		unmet := make([]string, len(option.Unmet))
+		for j, requirement := range option.Unmet {
+			unmet[j] = string(requirement)
+		}
+		response[i] = EvolutionOptionResponse{
+			Species:    speciesToResponse(e.ToSpecies),
+			CandyCost:  e.CandyCost,
+			CoinCost:   e.CoinCost,
+			Item:       e.Item,
+			BattleWins: e.BattleWins,
+			Progress:   option.Progress,
+			Unmet:      unmet,
+			CanEvolve:  option.CanEvolve(),
+		}
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"evolutions": response,
+		"count":      len(response),
+	})
+}
+
+// POST /api/pokemon/{id}/evolve
+func (h *EvolutionHandler) Evolve(w http.ResponseWriter, r *http.Request, pokemonID uuid.UUID) {
+	var req EvolveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	if req.SpeciesID < 0 {
+		RespondBadRequest(w, "species_id must be positive")
+		return
+	}
+
+	result, err := h.evolutionService.Evolve(r.Context(), userID, pokemonID, req.SpeciesID)
+	if err != nil {
+		respondEvolutionError(w, err)
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, EvolveResponse{
+		Pokemon:       pokemonsToResponse(r.Context(), h.valuationService, []*domain.UserPokemon{result.Pokemon})[0],
+		FromSpecies:   speciesToResponse(result.FromSpecies),
+		UnlockedMoves: movesToResponse(result.UnlockedMoves),
+	})
+}
+
+// GET /api/pokemon/{id}/moves
+func (h *EvolutionHandler) GetMoves(w http.ResponseWriter, r *http.Request, pokemonID uuid.UUID) {
+	moves, err := h.evolutionService.ListMoves(r.Context(), pokemonID)
+	if err != nil {
+		respondEvolutionError(w, err)
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"moves": movesToResponse(moves),
+		"count": len(moves),
+	})
+}
+
+func speciesToResponse(s *domain.PokemonSpecies) SpeciesResponse {
+	return SpeciesResponse{
+		ID:     s.ID,
+		Name:   s.Name,
+		Rarity: string(s.Rarity),
+	}
+}
+
+func movesToResponse(moves []*domain.Move) []MoveResponse {
+	response := make([]MoveResponse, len(moves))
+	for i, m := range moves {
+		response[i] = MoveResponse{
+			ID:       m.ID,
+			Name:     m.Name,
+			Type:     string(m.Type),
+			Category: string(m.Category),
+			Power:    m.Power,
+			Accuracy: m.Accuracy,
+			PP:       m.PP,
+			Priority: m.Priority,
+		}
+	}
+	return response
+}
+
+// respondEvolutionError maps evolution errors to HTTP responses
+func respondEvolutionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrNoEvolution),
+		errors.Is(err, service.ErrEvolutionChoice):
+		RespondBadRequest(w, err.Error())
+	case errors.Is(err, service.ErrNotPokemonOwner):
+		RespondError(w, http.StatusForbidden, ErrCodeForbidden, err.Error())
+	case errors.Is(err, service.ErrEvolutionRequirements):
+		RespondError(w, http.StatusConflict, ErrCodeRequirementsNotMet, err.Error())
+	case errors.Is(err, service.ErrPokemonLocked):
+		RespondConflict(w, err.Error())
+	case strings.Contains(err.Error(), "not found"):
+		RespondNotFound(w, err.Error())
+	default:
+		RespondInternalError(w, "Failed to evolve pokemon")
+	}
+}
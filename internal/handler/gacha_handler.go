@@ -1,22 +1,30 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
 	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
 	"github.com/google/uuid"
 )
 
 type GachaHandler struct {
-	gachaService *service.GachaService
+	gachaService     *service.GachaService
+	valuationService *service.ValuationService
 }
 
-func NewGachaHandler(gachaService *service.GachaService) *GachaHandler {
+func NewGachaHandler(gachaService *service.GachaService, valuationService *service.ValuationService) *GachaHandler {
 	return &GachaHandler{
-		gachaService: gachaService,
+		gachaService:     gachaService,
+		valuationService: valuationService,
 	}
 }
 
@@ -25,19 +33,58 @@ type DailyRollRequest struct {
 }
 
 type PremiumRollRequest struct {
-	UserID string `json:"user_id"`
-	Count  int    `json:"count"`
+	UserID   string `json:"user_id"`
+	Count    int    `json:"count"`
+	BannerID string `json:"banner_id,omitempty"` // Empty rolls on the standard pool
+	Fair     bool   `json:"fair,omitempty"`      // Roll provably fair pulls from the user's seed pair
+}
+
+type RotateFairSeedRequest struct {
+	ClientSeed string `json:"client_seed,omitempty"` // Empty picks one at random
+}
+
+type VerifyPullRequest struct {
+	ServerSeed string `json:"server_seed"`
+	domain.FairProof
+}
+
+type BannerResponse struct {
+	ID          string                    `json:"id"`
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	StartsAt    time.Time                 `json:"starts_at"`
+	EndsAt      *time.Time                `json:"ends_at"`      // Nil runs forever
+	SpeciesPool []int                     `json:"species_pool"` // Empty pools every species
+	Featured    []*domain.FeaturedSpecies `json:"featured"`
+	Rates       domain.RateTable          `json:"rates"`
+}
+
+type PityResponse struct {
+	BannerID string                `json:"banner_id"` // Empty for the standard pool
+	Progress []domain.PityProgress `json:"progress"`
 }
 
 type PokemonRollResponse struct {
-	ID            string          `json:"id"`
-	Species       SpeciesResponse `json:"species"`
-	Nature        string          `json:"nature"`
-	Level         int             `json:"level"`
-	IVs           IVsResponse     `json:"ivs"`
-	Stats         StatsResponse   `json:"stats"`
-	IVPercentage  float64         `json:"iv_percentage"`
-	EstimatedValue int            `json:"estimated_value"`
+	ID               string          `json:"id"`
+	Species          SpeciesResponse `json:"species"`
+	Nature           string          `json:"nature"`
+	IsShiny          bool            `json:"is_shiny"`
+	Gender           string          `json:"gender"`
+	Form             *FormResponse   `json:"form,omitempty"` // Nil for the base form
+	SpriteURL        string          `json:"sprite_url"`
+	Ability          string          `json:"ability"`
+	AbilitySlot      int             `json:"ability_slot"`
+	HiddenAbility    bool            `json:"hidden_ability"` // Whether the ability is the species' hidden one
+	Level            int             `json:"level"`
+	Experience       int             `json:"experience"`
+	ExperienceToNext int             `json:"experience_to_next"`
+	IVs              IVsResponse     `json:"ivs"`
+	EVs              IVsResponse     `json:"evs"`
+	EVSpread         string          `json:"ev_spread"` // Showdown notation, e.g. "252 Atk / 4 SpD"
+	Stats            StatsResponse   `json:"stats"`
+	IVPercentage     float64         `json:"iv_percentage"`
+	EstimatedValue   int             `json:"estimated_value"`
+	ValueSource      string          `json:"value_source"` // "market" or "formula"
 }
 
 type SpeciesResponse struct {
@@ -46,6 +93,11 @@ type SpeciesResponse struct {
 	Rarity string `json:"rarity"`
 }
 
+type FormResponse struct {
+	Name  string   `json:"name"`
+	Types []string `json:"types"`
+}
+
 type IVsResponse struct {
 	HP        int `json:"hp"`
 	Attack    int `json:"attack"`
@@ -83,10 +135,17 @@ func (h *GachaHandler) DailyRoll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Retries with the same key get the original pull back
+	idempotencyKey := r.Header.Get("Idempotency-Key")
+	if len(idempotencyKey) > domain.MaxIdempotencyKeyLength {
+		RespondBadRequest(w, "Idempotency key is too long")
+		return
+	}
+
 	// Perform daily roll
-	pokemons, err := h.gachaService.DailyRoll(r.Context(), userID)
+	pokemons, err := h.gachaService.DailyRollWithKey(r.Context(), userID, idempotencyKey)
 	if err != nil {
-		if strings.Contains(err.Error(), "cooldown") || strings.Contains(err.Error(), "already rolled") {
+		if errors.Is(err, service.ErrAlreadyRolledToday) {
 			RespondError(w, http.StatusTooManyRequests, ErrCodeCooldownActive, err.Error())
 			return
 		}
@@ -94,15 +153,17 @@ func (h *GachaHandler) DailyRoll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := make([]PokemonRollResponse, len(pokemons))
-	for i, p := range pokemons {
-		response[i] = pokemonToResponse(p)
-	}
+	response := pokemonsToResponse(r.Context(), h.valuationService, pokemons)
 
-	RespondJSON(w, http.StatusOK, map[string]interface{}{
+	data := map[string]interface{}{
 		"pokemons": response,
 		"count":    len(response),
-	})
+	}
+	if pity := h.pityResponse(r.Context(), userID, domain.StandardBannerID); pity != nil {
+		data["pity"] = pity
+	}
+
+	RespondJSON(w, http.StatusOK, data)
 }
 
 // POST /api/gacha/premium-roll
@@ -129,8 +190,22 @@ func (h *GachaHandler) PremiumRoll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pokemons, err := h.gachaService.PremiumRoll(r.Context(), userID, req.Count)
+	var pokemons []*domain.UserPokemon
+	var proofs []*domain.FairProof
+	if req.Fair {
+		pokemons, proofs, err = h.gachaService.FairPremiumRoll(r.Context(), userID, req.BannerID, req.Count)
+	} else {
+		pokemons, err = h.gachaService.PremiumRollOnBanner(r.Context(), userID, req.BannerID, req.Count)
+	}
 	if err != nil {
+		if errors.Is(err, repository.ErrBannerNotFound) {
+			RespondNotFound(w, "Banner not found")
+			return
+		}
+		if errors.Is(err, service.ErrBannerNotActive) {
+			RespondBadRequest(w, err.Error())
+			return
+		}
 		if strings.Contains(err.Error(), "insufficient") {
 			RespondError(w, http.StatusPaymentRequired, ErrCodeInsufficientCoins, err.Error())
 			return
@@ -139,18 +214,349 @@ func (h *GachaHandler) PremiumRoll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := make([]PokemonRollResponse, len(pokemons))
-	for i, p := range pokemons {
-		response[i] = pokemonToResponse(p)
-	}
+	response := pokemonsToResponse(r.Context(), h.valuationService, pokemons)
 
-	RespondJSON(w, http.StatusOK, map[string]interface{}{
+	data := map[string]interface{}{
 		"pokemons": response,
 		"count":    len(response),
 		"cost":     req.Count * 100,
+	}
+	if proofs != nil {
+		data["proofs"] = proofs
+	}
+	if pity := h.pityResponse(r.Context(), userID, req.BannerID); pity != nil {
+		data["pity"] = pity
+	}
+
+	RespondJSON(w, http.StatusOK, data)
+}
+
+// GET /api/users/{user_id}/pity?banner_id=
+func (h *GachaHandler) GetPity(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 {
+		RespondNotFound(w, "Route not found")
+		return
+	}
+
+	userID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	pity := h.pityResponse(r.Context(), userID, r.URL.Query().Get("banner_id"))
+	if pity == nil {
+		RespondInternalError(w, "Failed to retrieve pity")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, pity)
+}
+
+// GET /api/users/{user_id}/pulls?banner_id=&roll_type=&rarity=&limit=&offset=
+func (h *GachaHandler) GetPullHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 {
+		RespondNotFound(w, "Route not found")
+		return
+	}
+
+	userID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := domain.PullHistoryFilter{
+		BannerID: bannerIDParam(query),
+		RollType: domain.RollType(query.Get("roll_type")),
+		Rarity:   domain.Rarity(strings.ToLower(query.Get("rarity"))),
+	}
+
+	if !validRollType(filter.RollType) {
+		RespondBadRequest(w, "roll_type must be daily or premium")
+		return
+	}
+	if filter.Rarity != "" && !validators.ValidateRarity(filter.Rarity) {
+		RespondBadRequest(w, "Invalid rarity")
+		return
+	}
+	for param, dest := range map[string]*int{
+		"limit":  &filter.Limit,
+		"offset": &filter.Offset,
+	} {
+		if *dest, err = parseIntParam(query.Get(param)); err != nil {
+			RespondBadRequest(w, param+" must be a non-negative integer")
+			return
+		}
+	}
+	if filter.Limit > 100 {
+		RespondBadRequest(w, "limit must be at most 100")
+		return
+	}
+
+	pulls, err := h.gachaService.GetPullHistory(r.Context(), userID, filter)
+	if err != nil {
+		RespondInternalError(w, "Failed to retrieve pull history")
+		return
+	}
+	if pulls == nil {
+		pulls = []*domain.GachaPull{}
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"pulls": pulls,
+		"count": len(pulls),
 	})
 }
 
+// GET /api/gacha/stats?banner_id=&roll_type=&since=
+func (h *GachaHandler) GetDropRateReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := domain.PullStatsFilter{
+		BannerID: bannerIDParam(query),
+		RollType: domain.RollType(query.Get("roll_type")),
+	}
+
+	if !validRollType(filter.RollType) {
+		RespondBadRequest(w, "roll_type must be daily or premium")
+		return
+	}
+	if since := query.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			RespondBadRequest(w, "since must be an RFC 3339 time")
+			return
+		}
+		filter.Since = t
+	}
+
+	report, err := h.gachaService.GetDropRateReport(r.Context(), filter)
+	if err != nil {
+		RespondInternalError(w, "Failed to build drop rate report")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, report)
+}
+
+// bannerIDParam reads an optional banner_id filter. An empty banner_id
+// means the standard pool, so it is only left out when it is missing.
+func bannerIDParam(query url.Values) *string {
+	if !query.Has("banner_id") {
+		return nil
+	}
+	bannerID := query.Get("banner_id")
+	return &bannerID
+}
+
+// validRollType checks for an empty or known roll type
+func validRollType(rollType domain.RollType) bool {
+	return rollType == "" || rollType == domain.RollTypeDaily || rollType == domain.RollTypePremium
+}
+
+// pityResponse reports a user's progress toward pity on a banner, or nil if
+// it can't be loaded. Roll responses leave it out rather than fail a pull
+// that already went through.
+func (h *GachaHandler) pityResponse(ctx context.Context, userID uuid.UUID, bannerID string) *PityResponse {
+	pity, err := h.gachaService.GetPity(ctx, userID, bannerID)
+	if err != nil {
+		return nil
+	}
+	return &PityResponse{BannerID: pity.BannerID, Progress: pity.Progress()}
+}
+
+// GET /api/users/{user_id}/fair-seed
+// POST /api/users/{user_id}/fair-seed/rotate
+func (h *GachaHandler) FairSeedActions(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 || len(pathParts) > 5 {
+		RespondNotFound(w, "Route not found")
+		return
+	}
+
+	userID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	if len(pathParts) == 5 {
+		if pathParts[4] != "rotate" {
+			RespondNotFound(w, "Route not found")
+			return
+		}
+		h.rotateFairSeed(w, r, userID)
+		return
+	}
+	h.getFairSeed(w, r, userID)
+}
+
+// recentRevealedSeeds is how many revealed seed pairs GetFairSeed lists
+const recentRevealedSeeds = 10
+
+func (h *GachaHandler) getFairSeed(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	if r.Method != http.MethodGet {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	active, err := h.gachaService.GetFairSeed(r.Context(), userID)
+	if err != nil {
+		RespondInternalError(w, "Failed to retrieve fair seed")
+		return
+	}
+
+	revealed, err := h.gachaService.ListRevealedSeeds(r.Context(), userID, recentRevealedSeeds)
+	if err != nil {
+		RespondInternalError(w, "Failed to retrieve fair seed")
+		return
+	}
+	if revealed == nil {
+		revealed = []*domain.FairSeed{}
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"active":   active,
+		"revealed": revealed,
+	})
+}
+
+func (h *GachaHandler) rotateFairSeed(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	if r.Method != http.MethodPost {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	var req RotateFairSeedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	revealed, active, err := h.gachaService.RotateFairSeed(r.Context(), userID, req.ClientSeed)
+	if err != nil {
+		if errors.Is(err, validators.ErrInvalidClientSeed) {
+			RespondBadRequest(w, err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrUserNotFound) {
+			RespondNotFound(w, "User not found")
+			return
+		}
+		RespondInternalError(w, "Failed to rotate fair seed")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"revealed": revealed,
+		"active":   active,
+	})
+}
+
+// POST /api/gacha/verify
+func (h *GachaHandler) VerifyPull(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	var req VerifyPullRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	pokemon, err := h.gachaService.VerifyPull(r.Context(), &req.FairProof, req.ServerSeed)
+	if err != nil {
+		if errors.Is(err, repository.ErrBannerNotFound) {
+			RespondNotFound(w, "Banner not found")
+			return
+		}
+		if errors.Is(err, service.ErrSeedMismatch) ||
+			errors.Is(err, validators.ErrInvalidServerSeed) ||
+			errors.Is(err, validators.ErrInvalidClientSeed) ||
+			errors.Is(err, validators.ErrInvalidNonce) ||
+			errors.Is(err, validators.ErrInvalidRarity) ||
+			errors.Is(err, validators.ErrInvalidBannerRates) {
+			RespondBadRequest(w, err.Error())
+			return
+		}
+		RespondInternalError(w, "Failed to verify pull")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"server_seed_hash": domain.HashServerSeed(req.ServerSeed),
+		"pokemon":          pokemonToResponse(pokemon),
+	})
+}
+
+// GET /api/banners
+func (h *GachaHandler) GetBanners(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	banners, err := h.gachaService.ListActiveBanners(r.Context())
+	if err != nil {
+		RespondInternalError(w, "Failed to list banners")
+		return
+	}
+
+	response := make([]BannerResponse, len(banners))
+	for i, banner := range banners {
+		response[i] = bannerToResponse(banner)
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"banners": response,
+		"count":   len(response),
+	})
+}
+
+// bannerToResponse converts a banner, filling in the standard rates when it
+// has none of its own
+func bannerToResponse(b *domain.Banner) BannerResponse {
+	pool := b.SpeciesPool
+	if pool == nil {
+		pool = []int{}
+	}
+	featured := b.Featured
+	if featured == nil {
+		featured = []*domain.FeaturedSpecies{}
+	}
+
+	return BannerResponse{
+		ID:          b.ID,
+		Name:        b.Name,
+		Description: b.Description,
+		StartsAt:    b.StartsAt,
+		EndsAt:      b.EndsAt,
+		SpeciesPool: pool,
+		Featured:    featured,
+		Rates:       b.RateTable(),
+	}
+}
+
 // Helper function to convert Pokemon to response format
 func pokemonToResponse(p *domain.UserPokemon) PokemonRollResponse {
 	stats := p.GetStats()
@@ -162,10 +568,20 @@ func pokemonToResponse(p *domain.UserPokemon) PokemonRollResponse {
 			Name:   p.Species.Name,
 			Rarity: string(p.Species.Rarity),
 		},
-		Nature:         string(p.Nature),
-		Level:          p.Level,
-		IVPercentage:   p.IVs.IVPercentage(),
-		EstimatedValue: p.EstimatedValue(),
+		Nature:           string(p.Nature),
+		IsShiny:          p.IsShiny,
+		Gender:           string(p.Gender),
+		Form:             formToResponse(p.Form),
+		SpriteURL:        p.SpriteURL(),
+		Ability:          p.Ability(),
+		AbilitySlot:      int(p.AbilitySlot),
+		HiddenAbility:    p.HasHiddenAbility(),
+		Level:            p.Level,
+		Experience:       p.Experience,
+		ExperienceToNext: p.ExperienceToNextLevel(),
+		IVPercentage:     p.IVs.IVPercentage(),
+		EstimatedValue:   p.EstimatedValue(),
+		ValueSource:      valueSource(p),
 		IVs: IVsResponse{
 			HP:        p.IVs.HP,
 			Attack:    p.IVs.Attack,
@@ -174,6 +590,15 @@ func pokemonToResponse(p *domain.UserPokemon) PokemonRollResponse {
 			SpDefense: p.IVs.SpDefense,
 			Speed:     p.IVs.Speed,
 		},
+		EVs: IVsResponse{
+			HP:        p.EVs.HP,
+			Attack:    p.EVs.Attack,
+			Defense:   p.EVs.Defense,
+			SpAttack:  p.EVs.SpAttack,
+			SpDefense: p.EVs.SpDefense,
+			Speed:     p.EVs.Speed,
+		},
+		EVSpread: p.EVs.String(),
 		Stats: StatsResponse{
 			HP:        stats.HP,
 			Attack:    stats.Attack,
@@ -183,4 +608,25 @@ func pokemonToResponse(p *domain.UserPokemon) PokemonRollResponse {
 			Speed:     stats.Speed,
 		},
 	}
-}
\ No newline at end of file
+}
+
+// formToResponse converts a Pokemon's form, or returns nil for the base form
+func formToResponse(form *domain.PokemonForm) *FormResponse {
+	if form == nil {
+		return nil
+	}
+
+	types := []string{string(form.Type1)}
+	if form.Type2 != nil {
+		types = append(types, string(*form.Type2))
+	}
+	return &FormResponse{Name: form.Name, Types: types}
+}
+
+// valueSource reports whether a Pokemon's value comes from market sales
+func valueSource(p *domain.UserPokemon) string {
+	if p.HasMarketValue() {
+		return "market"
+	}
+	return "formula"
+}
@@ -0,0 +1,327 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/google/uuid"
+)
+
+type MarketHandler struct {
+	marketService *service.MarketService
+}
+
+func NewMarketHandler(marketService *service.MarketService) *MarketHandler {
+	return &MarketHandler{
+		marketService: marketService,
+	}
+}
+
+type CreateListingRequest struct {
+	UserID    string `json:"user_id"`
+	PokemonID string `json:"pokemon_id"`
+	Price     int    `json:"price"`
+}
+
+type ListingActionRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type ListingResponse struct {
+	ID       string              `json:"id"`
+	SellerID string              `json:"seller_id"`
+	Price    int                 `json:"price"`
+	Fee      int                 `json:"fee"`
+	Type     string              `json:"type"`
+	Status   string              `json:"status"`
+	ListedAt string              `json:"listed_at"`
+	Pokemon  PokemonRollResponse `json:"pokemon"`
+}
+
+type MarketTransactionResponse struct {
+	ID          string `json:"id"`
+	ListingID   string `json:"listing_id"`
+	BuyerID     string `json:"buyer_id"`
+	SellerID    string `json:"seller_id"`
+	Price       int    `json:"price"`
+	Fee         int    `json:"fee"`
+	CompletedAt string `json:"completed_at"`
+}
+
+// Listings routes /api/market/listings and /api/market/listings/{id}[/buy|/cancel]
+func (h *MarketHandler) Listings(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case len(pathParts) == 3 && r.Method == http.MethodGet:
+		h.SearchListings(w, r)
+	case len(pathParts) == 3 && r.Method == http.MethodPost:
+		h.CreateListing(w, r)
+	case len(pathParts) == 4 && r.Method == http.MethodGet:
+		h.GetListing(w, r, pathParts[3])
+	case len(pathParts) == 5 && pathParts[4] == "buy" && r.Method == http.MethodPost:
+		h.BuyListing(w, r, pathParts[3])
+	case len(pathParts) == 5 && pathParts[4] == "cancel" && r.Method == http.MethodPost:
+		h.CancelListing(w, r, pathParts[3])
+	case len(pathParts) <= 5:
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+	default:
+		RespondNotFound(w, "Route not found")
+	}
+}
+
+// GET /api/market/listings?species=&rarity=&min_iv=&min_price=&max_price=&seller_id=&limit=&offset=
+func (h *MarketHandler) SearchListings(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.MarketSearchFilter{
+		Rarity: domain.Rarity(strings.ToLower(query.Get("rarity"))),
+	}
+
+	if filter.Rarity != "" && !validators.ValidateRarity(filter.Rarity) {
+		RespondBadRequest(w, "Invalid rarity")
+		return
+	}
+
+	// Species can be given as a Pokedex number or a name
+	if species := query.Get("species"); species != "" {
+		if id, err := strconv.Atoi(species); err == nil {
+			filter.SpeciesID = id
+		} else {
+			filter.SpeciesName = species
+		}
+	}
+
+	if sellerID := query.Get("seller_id"); sellerID != "" {
+		id, err := uuid.Parse(sellerID)
+		if err != nil {
+			RespondBadRequest(w, "Invalid seller ID format")
+			return
+		}
+		filter.SellerID = id
+	}
+
+	var err error
+	if filter.MinIVPercent, err = parseFloatParam(query.Get("min_iv")); err != nil {
+		RespondBadRequest(w, "min_iv must be a number")
+		return
+	}
+	for param, dest := range map[string]*int{
+		"min_price": &filter.MinPrice,
+		"max_price": &filter.MaxPrice,
+		"limit":     &filter.Limit,
+		"offset":    &filter.Offset,
+	} {
+		if *dest, err = parseIntParam(query.Get(param)); err != nil {
+			RespondBadRequest(w, param+" must be a non-negative integer")
+			return
+		}
+	}
+
+	if filter.Limit > 100 {
+		RespondBadRequest(w, "limit must be at most 100")
+		return
+	}
+
+	listings, err := h.marketService.SearchListings(r.Context(), filter)
+	if err != nil {
+		RespondInternalError(w, "Failed to search listings")
+		return
+	}
+
+	response := make([]ListingResponse, len(listings))
+	for i, l := range listings {
+		response[i] = listingToResponse(l)
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"listings": response,
+		"count":    len(response),
+	})
+}
+
+// POST /api/market/listings
+func (h *MarketHandler) CreateListing(w http.ResponseWriter, r *http.Request) {
+	var req CreateListingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	pokemonID, err := uuid.Parse(req.PokemonID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid Pokemon ID format")
+		return
+	}
+
+	listing, err := h.marketService.CreateListing(r.Context(), userID, pokemonID, req.Price)
+	if err != nil {
+		respondMarketError(w, err, "Failed to create listing")
+		return
+	}
+
+	RespondJSON(w, http.StatusCreated, listingToResponse(listing))
+}
+
+// GET /api/market/listings/{id}
+func (h *MarketHandler) GetListing(w http.ResponseWriter, r *http.Request, listingIDStr string) {
+	listingID, err := uuid.Parse(listingIDStr)
+	if err != nil {
+		RespondBadRequest(w, "Invalid listing ID format")
+		return
+	}
+
+	listing, err := h.marketService.GetListing(r.Context(), listingID)
+	if err != nil {
+		respondMarketError(w, err, "Failed to retrieve listing")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, listingToResponse(listing))
+}
+
+// POST /api/market/listings/{id}/buy
+func (h *MarketHandler) BuyListing(w http.ResponseWriter, r *http.Request, listingIDStr string) {
+	listingID, userID, ok := parseListingAction(w, r, listingIDStr)
+	if !ok {
+		return
+	}
+
+	transaction, err := h.marketService.BuyListing(r.Context(), userID, listingID)
+	if err != nil {
+		respondMarketError(w, err, "Failed to buy listing")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, MarketTransactionResponse{
+		ID:          transaction.ID.String(),
+		ListingID:   transaction.ListingID.String(),
+		BuyerID:     transaction.BuyerID.String(),
+		SellerID:    transaction.SellerID.String(),
+		Price:       transaction.Price,
+		Fee:         transaction.Fee,
+		CompletedAt: transaction.CompletedAt.Format("2006-01-02T15:04:05Z"),
+	})
+}
+
+// POST /api/market/listings/{id}/cancel
+func (h *MarketHandler) CancelListing(w http.ResponseWriter, r *http.Request, listingIDStr string) {
+	listingID, userID, ok := parseListingAction(w, r, listingIDStr)
+	if !ok {
+		return
+	}
+
+	if err := h.marketService.CancelListing(r.Context(), userID, listingID); err != nil {
+		respondMarketError(w, err, "Failed to cancel listing")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]string{
+		"id":     listingID.String(),
+		"status": string(domain.ListingStatusCancelled),
+	})
+}
+
+// parseListingAction reads the listing ID from the path and the acting user from the body
+func parseListingAction(w http.ResponseWriter, r *http.Request, listingIDStr string) (uuid.UUID, uuid.UUID, bool) {
+	listingID, err := uuid.Parse(listingIDStr)
+	if err != nil {
+		RespondBadRequest(w, "Invalid listing ID format")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	var req ListingActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return listingID, userID, true
+}
+
+// respondMarketError maps market errors to HTTP responses
+func respondMarketError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, validators.ErrInvalidListingPrice),
+		errors.Is(err, validators.ErrInvalidBidIncrement),
+		errors.Is(err, validators.ErrInvalidAuctionDuration),
+		errors.Is(err, service.ErrBidTooLow):
+		RespondBadRequest(w, err.Error())
+	case errors.Is(err, service.ErrNotPokemonOwner), errors.Is(err, service.ErrNotListingSeller):
+		RespondError(w, http.StatusForbidden, ErrCodeForbidden, err.Error())
+	case errors.Is(err, service.ErrInsufficientFunds):
+		RespondError(w, http.StatusPaymentRequired, ErrCodeInsufficientCoins, err.Error())
+	case errors.Is(err, service.ErrCannotBuyOwnListing), errors.Is(err, service.ErrCannotBidOwnAuction):
+		RespondBadRequest(w, err.Error())
+	case errors.Is(err, service.ErrListingUnavailable), errors.Is(err, repository.ErrAlreadyListed),
+		errors.Is(err, service.ErrListingIsAuction), errors.Is(err, service.ErrAuctionEnded),
+		errors.Is(err, service.ErrAuctionHasBids), errors.Is(err, repository.ErrBidConflict),
+		errors.Is(err, service.ErrPokemonLocked):
+		RespondConflict(w, err.Error())
+	case strings.Contains(err.Error(), "not found"):
+		RespondNotFound(w, err.Error())
+	default:
+		RespondInternalError(w, fallback)
+	}
+}
+
+// parseIntParam parses an optional non-negative integer query parameter
+func parseIntParam(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, errors.New("invalid integer")
+	}
+	return n, nil
+}
+
+// parseFloatParam parses an optional non-negative number query parameter
+func parseFloatParam(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f < 0 {
+		return 0, errors.New("invalid number")
+	}
+	return f, nil
+}
+
+// listingToResponse converts a listing to response format
+func listingToResponse(l *domain.MarketListing) ListingResponse {
+	response := ListingResponse{
+		ID:       l.ID.String(),
+		SellerID: l.SellerID.String(),
+		Price:    l.Price,
+		Fee:      l.Fee(),
+		Type:     string(l.Type),
+		Status:   string(l.Status),
+		ListedAt: l.ListedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if l.Pokemon != nil && l.Pokemon.Species != nil {
+		response.Pokemon = pokemonToResponse(l.Pokemon)
+	}
+
+	return response
+}
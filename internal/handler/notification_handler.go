@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/google/uuid"
+)
+
+// DefaultNotificationBatch is how many notifications the bot gets per poll by default
+const DefaultNotificationBatch = 50
+
+type NotificationHandler struct {
+	notificationRepo repository.NotificationRepository
+}
+
+func NewNotificationHandler(notificationRepo repository.NotificationRepository) *NotificationHandler {
+	return &NotificationHandler{
+		notificationRepo: notificationRepo,
+	}
+}
+
+type NotificationResponse struct {
+	ID          string  `json:"id"`
+	UserID      string  `json:"user_id"`
+	DiscordID   string  `json:"discord_id"`
+	Type        string  `json:"type"`
+	Title       string  `json:"title"`
+	Message     string  `json:"message"`
+	ReferenceID *string `json:"reference_id"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+// GET /api/notifications/pending?limit=
+func (h *NotificationHandler) GetPending(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	limit, err := parseIntParam(r.URL.Query().Get("limit"))
+	if err != nil || limit > 100 {
+		RespondBadRequest(w, "limit must be between 0 and 100")
+		return
+	}
+	if limit == 0 {
+		limit = DefaultNotificationBatch
+	}
+
+	notifications, err := h.notificationRepo.ListUndelivered(r.Context(), limit)
+	if err != nil {
+		RespondInternalError(w, "Failed to retrieve notifications")
+		return
+	}
+
+	response := make([]NotificationResponse, len(notifications))
+	for i, n := range notifications {
+		response[i] = NotificationResponse{
+			ID:        n.ID.String(),
+			UserID:    n.UserID.String(),
+			DiscordID: n.DiscordID,
+			Type:      string(n.Type),
+			Title:     n.Title,
+			Message:   n.Message,
+			CreatedAt: n.CreatedAt.UTC().Format(time.RFC3339),
+		}
+		if n.ReferenceID != nil {
+			ref := n.ReferenceID.String()
+			response[i].ReferenceID = &ref
+		}
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"notifications": response,
+		"count":         len(response),
+	})
+}
+
+// POST /api/notifications/{id}/delivered
+func (h *NotificationHandler) MarkDelivered(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[3] != "delivered" {
+		RespondNotFound(w, "Route not found")
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	id, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		RespondBadRequest(w, "Invalid notification ID format")
+		return
+	}
+
+	if err := h.notificationRepo.MarkDelivered(r.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrNotificationNotFound) {
+			RespondNotFound(w, err.Error())
+			return
+		}
+		RespondInternalError(w, "Failed to update notification")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]string{
+		"id": id.String(),
+	})
+}
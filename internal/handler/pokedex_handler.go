@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/google/uuid"
+)
+
+type PokedexHandler struct {
+	pokedexService *service.PokedexService
+}
+
+func NewPokedexHandler(pokedexService *service.PokedexService) *PokedexHandler {
+	return &PokedexHandler{
+		pokedexService: pokedexService,
+	}
+}
+
+// GetPokedex handles GET /api/users/{id}/pokedex
+func (h *PokedexHandler) GetPokedex(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[3] != "pokedex" {
+		RespondNotFound(w, "Route not found")
+		return
+	}
+
+	userID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	pokedex, err := h.pokedexService.GetPokedex(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			RespondNotFound(w, "User not found")
+			return
+		}
+		RespondInternalError(w, "Failed to retrieve pokedex")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, pokedex)
+}
@@ -1,24 +1,31 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
+	"github.com/danielyang21/GoBattleServer/internal/domain"
 	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
 	"github.com/google/uuid"
 )
 
 type PokemonHandler struct {
-	gachaService *service.GachaService
+	gachaService     *service.GachaService
+	valuationService *service.ValuationService
 }
 
-func NewPokemonHandler(gachaService *service.GachaService) *PokemonHandler {
+func NewPokemonHandler(gachaService *service.GachaService, valuationService *service.ValuationService) *PokemonHandler {
 	return &PokemonHandler{
-		gachaService: gachaService,
+		gachaService:     gachaService,
+		valuationService: valuationService,
 	}
 }
 
-// GET /api/users/{user_id}/pokemon
+// GET /api/users/{user_id}/pokemon?rarity=&species=&type=&nature=&min_iv=&favorite=&shiny=&nickname=&sort=&order=&limit=&cursor=
 func (h *PokemonHandler) GetUserPokemon(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
@@ -38,24 +45,108 @@ func (h *PokemonHandler) GetUserPokemon(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Get user's Pokemon collection
-	pokemons, err := h.gachaService.GetUserPokemon(r.Context(), userID)
+	filter, ok := parseBoxFilter(w, r.URL.Query())
+	if !ok {
+		return
+	}
+
+	// Get a page of the user's Pokemon collection
+	page, err := h.gachaService.GetUserBox(r.Context(), userID, filter)
 	if err != nil {
-		RespondInternalError(w, "Failed to retrieve Pokemon collection")
+		switch {
+		case errors.Is(err, validators.ErrInvalidRarity),
+			errors.Is(err, validators.ErrInvalidSpecies),
+			errors.Is(err, validators.ErrInvalidType),
+			errors.Is(err, validators.ErrInvalidNature),
+			errors.Is(err, validators.ErrInvalidBoxIV),
+			errors.Is(err, validators.ErrInvalidBoxSort),
+			errors.Is(err, validators.ErrInvalidBoxLimit),
+			errors.Is(err, domain.ErrInvalidBoxCursor):
+			RespondBadRequest(w, err.Error())
+		default:
+			RespondInternalError(w, "Failed to retrieve Pokemon collection")
+		}
 		return
 	}
 
-	response := make([]PokemonRollResponse, len(pokemons))
-	for i, p := range pokemons {
-		response[i] = pokemonToResponse(p)
+	response := pokemonsToResponse(r.Context(), h.valuationService, page.Pokemon)
+
+	var nextCursor interface{}
+	if page.NextCursor != nil {
+		nextCursor = page.NextCursor.String()
 	}
 
 	RespondJSON(w, http.StatusOK, map[string]interface{}{
-		"pokemons": response,
-		"count":    len(response),
+		"pokemons":    response,
+		"count":       len(response),
+		"total":       page.Total,
+		"next_cursor": nextCursor,
 	})
 }
 
+// parseBoxFilter reads a box filter from query parameters, responding with
+// an error for parameters that can't be parsed. The species can be given
+// as a Pokedex number or a name.
+func parseBoxFilter(w http.ResponseWriter, query url.Values) (domain.BoxFilter, bool) {
+	filter := domain.BoxFilter{
+		Rarity:   domain.Rarity(strings.ToLower(query.Get("rarity"))),
+		Type:     domain.PokemonType(strings.ToLower(query.Get("type"))),
+		Nature:   domain.Nature(strings.ToLower(query.Get("nature"))),
+		Nickname: query.Get("nickname"),
+		Sort:     domain.BoxSort(strings.ToLower(query.Get("sort"))),
+	}
+
+	if species := query.Get("species"); species != "" {
+		if id, err := strconv.Atoi(species); err == nil {
+			filter.SpeciesID = id
+		} else {
+			filter.SpeciesName = species
+		}
+	}
+
+	var err error
+	if filter.MinIVPercent, err = parseFloatParam(query.Get("min_iv")); err != nil {
+		RespondBadRequest(w, "min_iv must be a non-negative number")
+		return filter, false
+	}
+	if filter.Limit, err = parseIntParam(query.Get("limit")); err != nil {
+		RespondBadRequest(w, "limit must be a non-negative integer")
+		return filter, false
+	}
+
+	for param, dest := range map[string]**bool{
+		"favorite": &filter.Favorite,
+		"shiny":    &filter.Shiny,
+	} {
+		if value := query.Get(param); value != "" {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				RespondBadRequest(w, param+" must be true or false")
+				return filter, false
+			}
+			*dest = &b
+		}
+	}
+
+	switch strings.ToLower(query.Get("order")) {
+	case "", "desc":
+	case "asc":
+		filter.Ascending = true
+	default:
+		RespondBadRequest(w, "order must be asc or desc")
+		return filter, false
+	}
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		if filter.Cursor, err = domain.ParseBoxCursor(cursor); err != nil {
+			RespondBadRequest(w, err.Error())
+			return filter, false
+		}
+	}
+
+	return filter, true
+}
+
 // GET /api/pokemon/{pokemon_id}
 func (h *PokemonHandler) GetPokemonByID(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -87,6 +178,6 @@ func (h *PokemonHandler) GetPokemonByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	response := pokemonToResponse(pokemon)
-	RespondJSON(w, http.StatusOK, response)
+	response := pokemonsToResponse(r.Context(), h.valuationService, []*domain.UserPokemon{pokemon})
+	RespondJSON(w, http.StatusOK, response[0])
 }
\ No newline at end of file
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/google/uuid"
+)
+
+type QuestHandler struct {
+	questService *service.QuestService
+}
+
+func NewQuestHandler(questService *service.QuestService) *QuestHandler {
+	return &QuestHandler{
+		questService: questService,
+	}
+}
+
+// QuestActions routes /api/users/{id}/quests and
+// /api/users/{id}/quests/{quest_id}/claim
+func (h *QuestHandler) QuestActions(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 || pathParts[3] != "quests" {
+		RespondNotFound(w, "Route not found")
+		return
+	}
+
+	userID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	switch {
+	case len(pathParts) == 4:
+		h.listQuests(w, r, userID)
+	case len(pathParts) == 6 && pathParts[5] == "claim":
+		h.claimQuest(w, r, userID, pathParts[4])
+	default:
+		RespondNotFound(w, "Route not found")
+	}
+}
+
+// GET /api/users/{user_id}/quests
+func (h *QuestHandler) listQuests(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	if r.Method != http.MethodGet {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	quests, err := h.questService.ListQuests(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			RespondNotFound(w, "User not found")
+			return
+		}
+		RespondInternalError(w, "Failed to retrieve quests")
+		return
+	}
+	if quests == nil {
+		quests = []*domain.QuestStatus{}
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"quests": quests,
+		"count":  len(quests),
+	})
+}
+
+// POST /api/users/{user_id}/quests/{quest_id}/claim
+func (h *QuestHandler) claimQuest(w http.ResponseWriter, r *http.Request, userID uuid.UUID, questID string) {
+	if r.Method != http.MethodPost {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	claim, err := h.questService.ClaimQuest(r.Context(), userID, questID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUserNotFound), errors.Is(err, service.ErrQuestNotFound):
+			RespondNotFound(w, err.Error())
+		case errors.Is(err, service.ErrQuestNotCompleted):
+			RespondError(w, http.StatusConflict, ErrCodeRequirementsNotMet, err.Error())
+		default:
+			RespondInternalError(w, "Failed to claim quest")
+		}
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, claim)
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/google/uuid"
+)
+
+type ReleaseHandler struct {
+	releaseService *service.ReleaseService
+}
+
+func NewReleaseHandler(releaseService *service.ReleaseService) *ReleaseHandler {
+	return &ReleaseHandler{
+		releaseService: releaseService,
+	}
+}
+
+type ReleaseRequest struct {
+	UserID     string   `json:"user_id"`
+	PokemonIDs []string `json:"pokemon_ids"`
+	DryRun     bool     `json:"dry_run"`
+}
+
+type BulkSellRequest struct {
+	UserID         string  `json:"user_id"`
+	Rarity         string  `json:"rarity"`
+	SpeciesID      int     `json:"species_id"`
+	MaxIVPercent   float64 `json:"max_iv_percent"`
+	KeepPerSpecies int     `json:"keep_per_species"`
+	DryRun         bool    `json:"dry_run"`
+}
+
+type ReleasedPokemonResponse struct {
+	Pokemon PokemonRollResponse `json:"pokemon"`
+	Payout  int                 `json:"payout"`
+	Candy   int                 `json:"candy"`
+}
+
+type ProtectedPokemonResponse struct {
+	Pokemon PokemonRollResponse `json:"pokemon"`
+	Reason  string              `json:"reason"`
+}
+
+type ReleaseResponse struct {
+	Released    []ReleasedPokemonResponse  `json:"released"`
+	Protected   []ProtectedPokemonResponse `json:"protected"`
+	Count       int                        `json:"count"`
+	TotalPayout int                        `json:"total_payout"`
+	DryRun      bool                       `json:"dry_run"`
+}
+
+// POST /api/pokemon/release
+func (h *ReleaseHandler) Release(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	var req ReleaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	if len(req.PokemonIDs) == 0 {
+		RespondBadRequest(w, "pokemon_ids is required")
+		return
+	}
+
+	pokemonIDs, err := parseUUIDs(req.PokemonIDs)
+	if err != nil {
+		RespondBadRequest(w, "Invalid Pokemon ID format")
+		return
+	}
+
+	filter := &domain.ReleaseFilter{PokemonIDs: pokemonIDs}
+	h.release(w, r, userID, filter, req.DryRun)
+}
+
+// POST /api/pokemon/bulk-sell
+func (h *ReleaseHandler) BulkSell(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	var req BulkSellRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	filter := &domain.ReleaseFilter{
+		Rarity:         domain.Rarity(req.Rarity),
+		SpeciesID:      req.SpeciesID,
+		MaxIVPercent:   req.MaxIVPercent,
+		KeepPerSpecies: req.KeepPerSpecies,
+	}
+	h.release(w, r, userID, filter, req.DryRun)
+}
+
+// release runs a release or its preview and writes the result
+func (h *ReleaseHandler) release(w http.ResponseWriter, r *http.Request, userID uuid.UUID, filter *domain.ReleaseFilter, dryRun bool) {
+	result, err := h.releaseService.Release(r.Context(), userID, filter, dryRun)
+	if err != nil {
+		respondReleaseError(w, err)
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, releaseToResponse(result))
+}
+
+// respondReleaseError maps release errors to HTTP responses
+func respondReleaseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, validators.ErrEmptyReleaseFilter),
+		errors.Is(err, validators.ErrInvalidRarity),
+		errors.Is(err, validators.ErrInvalidSpecies),
+		errors.Is(err, validators.ErrInvalidReleaseIV),
+		errors.Is(err, validators.ErrInvalidKeepCount),
+		errors.Is(err, validators.ErrDuplicateReleasePokemon):
+		RespondBadRequest(w, err.Error())
+	case errors.Is(err, service.ErrNotPokemonOwner):
+		RespondError(w, http.StatusForbidden, ErrCodeForbidden, err.Error())
+	case strings.Contains(err.Error(), "not found"):
+		RespondNotFound(w, err.Error())
+	default:
+		RespondInternalError(w, "Failed to release pokemon")
+	}
+}
+
+func releaseToResponse(result *domain.ReleaseResult) ReleaseResponse {
+	response := ReleaseResponse{
+		Released:    make([]ReleasedPokemonResponse, len(result.Released)),
+		Protected:   make([]ProtectedPokemonResponse, len(result.Protected)),
+		Count:       len(result.Released),
+		TotalPayout: result.TotalPayout,
+		DryRun:      result.DryRun,
+	}
+
+	for i, released := range result.Released {
+		response.Released[i] = ReleasedPokemonResponse{
+			Pokemon: pokemonToResponse(released.Pokemon),
+			Payout:  released.Payout,
+			Candy:   released.Candy,
+		}
+	}
+
+	for i, protected := range result.Protected {
+		response.Protected[i] = ProtectedPokemonResponse{
+			Pokemon: pokemonToResponse(protected.Pokemon),
+			Reason:  string(protected.Reason),
+		}
+	}
+
+	return response
+}
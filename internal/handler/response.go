@@ -19,11 +19,15 @@ type APIError struct {
 const (
 	ErrCodeBadRequest          = "bad_request"
 	ErrCodeUnauthorized        = "unauthorized"
+	ErrCodeForbidden           = "forbidden"
 	ErrCodeNotFound            = "not_found"
 	ErrCodeConflict            = "conflict"
 	ErrCodeInternalServerError = "internal_server_error"
 	ErrCodeCooldownActive      = "cooldown_active"
 	ErrCodeInsufficientCoins   = "insufficient_coins"
+	ErrCodeInsufficientCandy   = "insufficient_candy"
+	ErrCodeRequirementsNotMet  = "requirements_not_met"
+	ErrCodeInsufficientItems   = "insufficient_items"
 )
 
 func RespondJSON(w http.ResponseWriter, status int, data interface{}) {
@@ -2,25 +2,72 @@ package handler
 
 import (
 	"net/http"
+	"path"
+	"strings"
 
 	"github.com/danielyang21/GoBattleServer/internal/service"
 	"github.com/danielyang21/GoBattleServer/internal/repository"
 )
 
 type Router struct {
-	userHandler    *UserHandler
-	gachaHandler   *GachaHandler
-	pokemonHandler *PokemonHandler
+	userHandler         *UserHandler
+	gachaHandler        *GachaHandler
+	pokemonHandler      *PokemonHandler
+	marketHandler       *MarketHandler
+	auctionHandler      *AuctionHandler
+	notificationHandler *NotificationHandler
+	tradeHandler        *TradeHandler
+	valuationHandler    *ValuationHandler
+	releaseHandler      *ReleaseHandler
+	candyHandler        *CandyHandler
+	evolutionHandler    *EvolutionHandler
+	battleHandler       *BattleHandler
+	trainingHandler     *TrainingHandler
+	abilityHandler      *AbilityHandler
+	streakHandler       *StreakHandler
+	questHandler        *QuestHandler
+	pokedexHandler      *PokedexHandler
+	speciesHandler      *SpeciesHandler
 }
 
 func NewRouter(
 	userRepo repository.UserRepository,
 	gachaService *service.GachaService,
+	marketService *service.MarketService,
+	auctionService *service.AuctionService,
+	notificationRepo repository.NotificationRepository,
+	tradeService *service.TradeService,
+	valuationService *service.ValuationService,
+	releaseService *service.ReleaseService,
+	candyService *service.CandyService,
+	evolutionService *service.EvolutionService,
+	wildBattleService *service.WildBattleService,
+	trainingService *service.TrainingService,
+	abilityService *service.AbilityService,
+	streakService *service.StreakService,
+	questService *service.QuestService,
+	pokedexService *service.PokedexService,
+	speciesService *service.SpeciesService,
 ) *Router {
 	return &Router{
-		userHandler:    NewUserHandler(userRepo),
-		gachaHandler:   NewGachaHandler(gachaService),
-		pokemonHandler: NewPokemonHandler(gachaService),
+		userHandler:         NewUserHandler(userRepo),
+		gachaHandler:        NewGachaHandler(gachaService, valuationService),
+		pokemonHandler:      NewPokemonHandler(gachaService, valuationService),
+		marketHandler:       NewMarketHandler(marketService),
+		auctionHandler:      NewAuctionHandler(auctionService),
+		notificationHandler: NewNotificationHandler(notificationRepo),
+		tradeHandler:        NewTradeHandler(tradeService),
+		valuationHandler:    NewValuationHandler(valuationService),
+		releaseHandler:      NewReleaseHandler(releaseService),
+		candyHandler:        NewCandyHandler(candyService, valuationService),
+		evolutionHandler:    NewEvolutionHandler(evolutionService, valuationService),
+		battleHandler:       NewBattleHandler(wildBattleService, valuationService),
+		trainingHandler:     NewTrainingHandler(trainingService, valuationService),
+		abilityHandler:      NewAbilityHandler(abilityService, valuationService),
+		streakHandler:       NewStreakHandler(streakService, valuationService),
+		questHandler:        NewQuestHandler(questService),
+		pokedexHandler:      NewPokedexHandler(pokedexService),
+		speciesHandler:      NewSpeciesHandler(speciesService),
 	}
 }
 
@@ -46,6 +93,22 @@ func (router *Router) SetupRoutes() http.Handler {
 				// Check if path ends with /pokemon
 				if len(r.URL.Path) >= 8 && r.URL.Path[len(r.URL.Path)-8:] == "/pokemon" {
 					router.pokemonHandler.GetUserPokemon(w, r)
+				} else if strings.HasSuffix(r.URL.Path, "/candy") {
+					router.candyHandler.GetCandy(w, r)
+				} else if strings.HasSuffix(r.URL.Path, "/items") {
+					router.evolutionHandler.GetItems(w, r)
+				} else if strings.HasSuffix(r.URL.Path, "/pity") {
+					router.gachaHandler.GetPity(w, r)
+				} else if strings.HasSuffix(r.URL.Path, "/pulls") {
+					router.gachaHandler.GetPullHistory(w, r)
+				} else if strings.HasSuffix(r.URL.Path, "/fair-seed") || strings.HasSuffix(r.URL.Path, "/fair-seed/rotate") {
+					router.gachaHandler.FairSeedActions(w, r)
+				} else if strings.HasSuffix(r.URL.Path, "/streak") || strings.HasSuffix(r.URL.Path, "/check-in") {
+					router.streakHandler.StreakActions(w, r)
+				} else if strings.HasSuffix(r.URL.Path, "/quests") || strings.Contains(r.URL.Path, "/quests/") {
+					router.questHandler.QuestActions(w, r)
+				} else if strings.HasSuffix(r.URL.Path, "/pokedex") {
+					router.pokedexHandler.GetPokedex(w, r)
 				} else {
 					router.userHandler.GetUser(w, r)
 				}
@@ -58,9 +121,54 @@ func (router *Router) SetupRoutes() http.Handler {
 	// Gacha routes
 	mux.HandleFunc("/api/gacha/daily-roll", router.gachaHandler.DailyRoll)
 	mux.HandleFunc("/api/gacha/premium-roll", router.gachaHandler.PremiumRoll)
+	mux.HandleFunc("/api/gacha/verify", router.gachaHandler.VerifyPull)
+	mux.HandleFunc("/api/gacha/stats", router.gachaHandler.GetDropRateReport)
+	mux.HandleFunc("/api/banners", router.gachaHandler.GetBanners)
+
+	// Species catalog routes
+	mux.HandleFunc("/api/species", router.speciesHandler.Species)
+	mux.HandleFunc("/api/species/", router.speciesHandler.Species)
 
 	// Pokemon routes
-	mux.HandleFunc("/api/pokemon/", router.pokemonHandler.GetPokemonByID)
+	mux.HandleFunc("/api/pokemon/", func(w http.ResponseWriter, r *http.Request) {
+		// /api/pokemon/{id}/{action} evolves, battles or trains a Pokemon or changes it with candy or items
+		if strings.Count(strings.Trim(r.URL.Path, "/"), "/") == 3 {
+			switch path.Base(r.URL.Path) {
+			case "evolutions", "evolve", "moves":
+				router.evolutionHandler.PokemonActions(w, r)
+			case "wild-battle":
+				router.battleHandler.WildBattle(w, r)
+			case "evs":
+				router.trainingHandler.TrainEVs(w, r)
+			case "ability":
+				router.abilityHandler.ChangeAbility(w, r)
+			default:
+				router.candyHandler.PokemonActions(w, r)
+			}
+			return
+		}
+		router.pokemonHandler.GetPokemonByID(w, r)
+	})
+	mux.HandleFunc("/api/pokemon/release", router.releaseHandler.Release)
+	mux.HandleFunc("/api/pokemon/bulk-sell", router.releaseHandler.BulkSell)
+
+	// Market routes
+	mux.HandleFunc("/api/market/listings", router.marketHandler.Listings)
+	mux.HandleFunc("/api/market/listings/", router.marketHandler.Listings)
+	mux.HandleFunc("/api/market/auctions", router.auctionHandler.Auctions)
+	mux.HandleFunc("/api/market/auctions/", router.auctionHandler.Auctions)
+	mux.HandleFunc("/api/market/prices/", router.valuationHandler.GetPrices)
+
+	// Battle routes
+	mux.HandleFunc("/api/battles/formats", router.battleHandler.GetFormats)
+
+	// Trade routes
+	mux.HandleFunc("/api/trades", router.tradeHandler.Trades)
+	mux.HandleFunc("/api/trades/", router.tradeHandler.Trades)
+
+	// Notification routes (polled by the Discord bot)
+	mux.HandleFunc("/api/notifications/pending", router.notificationHandler.GetPending)
+	mux.HandleFunc("/api/notifications/", router.notificationHandler.MarkDelivered)
 
 	// Apply middleware
 	handler := Chain(
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+)
+
+type SpeciesHandler struct {
+	speciesService *service.SpeciesService
+}
+
+func NewSpeciesHandler(speciesService *service.SpeciesService) *SpeciesHandler {
+	return &SpeciesHandler{
+		speciesService: speciesService,
+	}
+}
+
+type CatalogSpeciesResponse struct {
+	ID             int           `json:"id"`
+	Name           string        `json:"name"`
+	Type1          string        `json:"type1"`
+	Type2          string        `json:"type2,omitempty"`
+	Rarity         string        `json:"rarity"`
+	BaseStats      StatsResponse `json:"base_stats"`
+	BaseStatTotal  int           `json:"base_stat_total"`
+	SpriteURL      string        `json:"sprite_url"`
+	ShinySpriteURL string        `json:"shiny_sprite_url"`
+}
+
+type SpeciesDetailResponse struct {
+	CatalogSpeciesResponse
+	Matchups  *domain.TypeMatchup     `json:"matchups"`
+	Learnset  []MoveResponse          `json:"learnset"`
+	Abilities []domain.SpeciesAbility `json:"abilities"`
+}
+
+// Species routes /api/species and /api/species/{id}
+func (h *SpeciesHandler) Species(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case len(pathParts) > 3:
+		RespondNotFound(w, "Route not found")
+	case r.Method != http.MethodGet:
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+	case len(pathParts) == 2:
+		h.SearchSpecies(w, r)
+	default:
+		h.GetSpecies(w, r, pathParts[2])
+	}
+}
+
+// GET /api/species?type1=&type2=&rarity=&min_<stat>=&max_<stat>=&sort=&order=&limit=&offset=
+func (h *SpeciesHandler) SearchSpecies(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.SpeciesSearchFilter{
+		Type1:    domain.PokemonType(strings.ToLower(query.Get("type1"))),
+		Type2:    domain.PokemonType(strings.ToLower(query.Get("type2"))),
+		Rarity:   domain.Rarity(strings.ToLower(query.Get("rarity"))),
+		MinStats: make(map[string]int),
+		MaxStats: make(map[string]int),
+		Sort:     strings.ToLower(query.Get("sort")),
+	}
+
+	for _, t := range []domain.PokemonType{filter.Type1, filter.Type2} {
+		if t != "" && !domain.IsValidType(string(t)) {
+			RespondBadRequest(w, "Invalid type: "+string(t))
+			return
+		}
+	}
+	if filter.Rarity != "" && !validators.ValidateRarity(filter.Rarity) {
+		RespondBadRequest(w, "Invalid rarity")
+		return
+	}
+	if filter.Sort != "" && !domain.IsValidSpeciesSort(filter.Sort) {
+		RespondBadRequest(w, "sort must be id, name, total or a base stat")
+		return
+	}
+
+	switch strings.ToLower(query.Get("order")) {
+	case "", "asc":
+	case "desc":
+		filter.Descending = true
+	default:
+		RespondBadRequest(w, "order must be asc or desc")
+		return
+	}
+
+	// Stat bounds are given as min_<stat> and max_<stat>, e.g. min_speed=100
+	for _, stat := range domain.SpeciesStats {
+		for _, param := range []string{"min_" + stat, "max_" + stat} {
+			value := query.Get(param)
+			if value == "" {
+				continue
+			}
+			n, err := parseIntParam(value)
+			if err != nil {
+				RespondBadRequest(w, param+" must be a non-negative integer")
+				return
+			}
+			if strings.HasPrefix(param, "min_") {
+				filter.MinStats[stat] = n
+			} else {
+				filter.MaxStats[stat] = n
+			}
+		}
+	}
+
+	var err error
+	for param, dest := range map[string]*int{
+		"limit":  &filter.Limit,
+		"offset": &filter.Offset,
+	} {
+		if *dest, err = parseIntParam(query.Get(param)); err != nil {
+			RespondBadRequest(w, param+" must be a non-negative integer")
+			return
+		}
+	}
+
+	if filter.Limit > 100 {
+		RespondBadRequest(w, "limit must be at most 100")
+		return
+	}
+
+	species, err := h.speciesService.SearchSpecies(r.Context(), filter)
+	if err != nil {
+		RespondInternalError(w, "Failed to search species")
+		return
+	}
+
+	response := make([]CatalogSpeciesResponse, len(species))
+	for i, s := range species {
+		response[i] = catalogSpeciesToResponse(s)
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"species": response,
+		"count":   len(response),
+	})
+}
+
+// GET /api/species/{id}, where the species can be given as a Pokedex number or a name
+func (h *SpeciesHandler) GetSpecies(w http.ResponseWriter, r *http.Request, idOrName string) {
+	var details *domain.SpeciesDetails
+	var err error
+	if id, convErr := strconv.Atoi(idOrName); convErr == nil {
+		details, err = h.speciesService.GetSpecies(r.Context(), id)
+	} else {
+		details, err = h.speciesService.GetSpeciesByName(r.Context(), idOrName)
+	}
+	if err != nil {
+		if errors.Is(err, service.ErrSpeciesNotFound) {
+			RespondNotFound(w, "Species not found")
+			return
+		}
+		RespondInternalError(w, "Failed to retrieve species")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, SpeciesDetailResponse{
+		CatalogSpeciesResponse: catalogSpeciesToResponse(details.Species),
+		Matchups:               details.Matchups,
+		Learnset:               movesToResponse(details.Learnset),
+		Abilities:              details.Abilities,
+	})
+}
+
+// catalogSpeciesToResponse converts a species to its catalog format
+func catalogSpeciesToResponse(s *domain.PokemonSpecies) CatalogSpeciesResponse {
+	response := CatalogSpeciesResponse{
+		ID:     s.ID,
+		Name:   s.Name,
+		Type1:  string(s.Type1),
+		Rarity: string(s.Rarity),
+		BaseStats: StatsResponse{
+			HP:        s.BaseHP,
+			Attack:    s.BaseAttack,
+			Defense:   s.BaseDefense,
+			SpAttack:  s.BaseSpAttack,
+			SpDefense: s.BaseSpDefense,
+			Speed:     s.BaseSpeed,
+		},
+		BaseStatTotal:  s.BaseStatTotal(),
+		SpriteURL:      s.SpriteURL,
+		ShinySpriteURL: s.ShinySpriteURL,
+	}
+	if s.Type2 != nil {
+		response.Type2 = string(*s.Type2)
+	}
+	return response
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/google/uuid"
+)
+
+type StreakHandler struct {
+	streakService    *service.StreakService
+	valuationService *service.ValuationService
+}
+
+func NewStreakHandler(streakService *service.StreakService, valuationService *service.ValuationService) *StreakHandler {
+	return &StreakHandler{
+		streakService:    streakService,
+		valuationService: valuationService,
+	}
+}
+
+type CheckInResponse struct {
+	Streak  domain.StreakStatus  `json:"streak"`
+	Reward  domain.StreakReward  `json:"reward"`
+	Pokemon *PokemonRollResponse `json:"pokemon,omitempty"`
+	Coins   int                  `json:"coins"`
+}
+
+// StreakActions routes /api/users/{id}/streak and /api/users/{id}/check-in
+func (h *StreakHandler) StreakActions(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 {
+		RespondNotFound(w, "Route not found")
+		return
+	}
+
+	userID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	switch pathParts[3] {
+	case "streak":
+		h.getStreak(w, r, userID)
+	case "check-in":
+		h.checkIn(w, r, userID)
+	default:
+		RespondNotFound(w, "Route not found")
+	}
+}
+
+// GET /api/users/{user_id}/streak
+func (h *StreakHandler) getStreak(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	if r.Method != http.MethodGet {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	status, err := h.streakService.GetStreak(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			RespondNotFound(w, "User not found")
+			return
+		}
+		RespondInternalError(w, "Failed to retrieve streak")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, status)
+}
+
+// POST /api/users/{user_id}/check-in
+func (h *StreakHandler) checkIn(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	if r.Method != http.MethodPost {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	result, err := h.streakService.CheckIn(r.Context(), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			RespondNotFound(w, "User not found")
+		case errors.Is(err, service.ErrAlreadyCheckedIn):
+			RespondError(w, http.StatusTooManyRequests, ErrCodeCooldownActive, err.Error())
+		default:
+			RespondInternalError(w, "Failed to check in")
+		}
+		return
+	}
+
+	response := CheckInResponse{
+		Streak: result.Streak,
+		Reward: result.Reward,
+		Coins:  result.Coins,
+	}
+	if result.Pokemon != nil {
+		pokemon := pokemonsToResponse(r.Context(), h.valuationService, []*domain.UserPokemon{result.Pokemon})[0]
+		response.Pokemon = &pokemon
+	}
+
+	RespondJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/google/uuid"
+)
+
+type TradeHandler struct {
+	tradeService *service.TradeService
+}
+
+func NewTradeHandler(tradeService *service.TradeService) *TradeHandler {
+	return &TradeHandler{
+		tradeService: tradeService,
+	}
+}
+
+type ProposeTradeRequest struct {
+	UserID              string   `json:"user_id"`
+	RecipientID         string   `json:"recipient_id"`
+	ProposerPokemonIDs  []string `json:"proposer_pokemon_ids"`
+	RecipientPokemonIDs []string `json:"recipient_pokemon_ids"`
+	ProposerCoins       int      `json:"proposer_coins"`
+	RecipientCoins      int      `json:"recipient_coins"`
+}
+
+type TradeActionRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type TradeResponse struct {
+	ID                  string   `json:"id"`
+	ProposerID          string   `json:"proposer_id"`
+	RecipientID         string   `json:"recipient_id"`
+	ProposerPokemonIDs  []string `json:"proposer_pokemon_ids"`
+	RecipientPokemonIDs []string `json:"recipient_pokemon_ids"`
+	ProposerCoins       int      `json:"proposer_coins"`
+	RecipientCoins      int      `json:"recipient_coins"`
+	ProposerConfirmed   bool     `json:"proposer_confirmed"`
+	RecipientConfirmed  bool     `json:"recipient_confirmed"`
+	Status              string   `json:"status"`
+	CreatedAt           string   `json:"created_at"`
+	ExpiresAt           string   `json:"expires_at"`
+}
+
+// Trades routes /api/trades and /api/trades/{id}[/confirm|/cancel]
+func (h *TradeHandler) Trades(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case len(pathParts) == 2 && r.Method == http.MethodGet:
+		h.ListTrades(w, r)
+	case len(pathParts) == 2 && r.Method == http.MethodPost:
+		h.ProposeTrade(w, r)
+	case len(pathParts) == 3 && r.Method == http.MethodGet:
+		h.GetTrade(w, r, pathParts[2])
+	case len(pathParts) == 4 && pathParts[3] == "confirm" && r.Method == http.MethodPost:
+		h.ConfirmTrade(w, r, pathParts[2])
+	case len(pathParts) == 4 && pathParts[3] == "cancel" && r.Method == http.MethodPost:
+		h.CancelTrade(w, r, pathParts[2])
+	case len(pathParts) <= 4:
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+	default:
+		RespondNotFound(w, "Route not found")
+	}
+}
+
+// GET /api/trades?user_id=
+func (h *TradeHandler) ListTrades(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	trades, err := h.tradeService.ListPendingTrades(r.Context(), userID)
+	if err != nil {
+		RespondInternalError(w, "Failed to list trades")
+		return
+	}
+
+	response := make([]TradeResponse, len(trades))
+	for i, t := range trades {
+		response[i] = tradeToResponse(t)
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"trades": response,
+		"count":  len(response),
+	})
+}
+
+// POST /api/trades
+func (h *TradeHandler) ProposeTrade(w http.ResponseWriter, r *http.Request) {
+	var req ProposeTradeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	recipientID, err := uuid.Parse(req.RecipientID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid recipient ID format")
+		return
+	}
+
+	proposerPokemonIDs, err := parseUUIDs(req.ProposerPokemonIDs)
+	if err != nil {
+		RespondBadRequest(w, "Invalid Pokemon ID format")
+		return
+	}
+
+	recipientPokemonIDs, err := parseUUIDs(req.RecipientPokemonIDs)
+	if err != nil {
+		RespondBadRequest(w, "Invalid Pokemon ID format")
+		return
+	}
+
+	trade, err := h.tradeService.ProposeTrade(r.Context(), userID, recipientID,
+		proposerPokemonIDs, recipientPokemonIDs, req.ProposerCoins, req.RecipientCoins)
+	if err != nil {
+		respondTradeError(w, err, "Failed to propose trade")
+		return
+	}
+
+	RespondJSON(w, http.StatusCreated, tradeToResponse(trade))
+}
+
+// GET /api/trades/{id}
+func (h *TradeHandler) GetTrade(w http.ResponseWriter, r *http.Request, tradeIDStr string) {
+	tradeID, err := uuid.Parse(tradeIDStr)
+	if err != nil {
+		RespondBadRequest(w, "Invalid trade ID format")
+		return
+	}
+
+	trade, err := h.tradeService.GetTrade(r.Context(), tradeID)
+	if err != nil {
+		respondTradeError(w, err, "Failed to retrieve trade")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, tradeToResponse(trade))
+}
+
+// POST /api/trades/{id}/confirm
+func (h *TradeHandler) ConfirmTrade(w http.ResponseWriter, r *http.Request, tradeIDStr string) {
+	tradeID, userID, ok := parseTradeAction(w, r, tradeIDStr)
+	if !ok {
+		return
+	}
+
+	trade, err := h.tradeService.ConfirmTrade(r.Context(), userID, tradeID)
+	if err != nil {
+		respondTradeError(w, err, "Failed to confirm trade")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, tradeToResponse(trade))
+}
+
+// POST /api/trades/{id}/cancel
+func (h *TradeHandler) CancelTrade(w http.ResponseWriter, r *http.Request, tradeIDStr string) {
+	tradeID, userID, ok := parseTradeAction(w, r, tradeIDStr)
+	if !ok {
+		return
+	}
+
+	if err := h.tradeService.CancelTrade(r.Context(), userID, tradeID); err != nil {
+		respondTradeError(w, err, "Failed to cancel trade")
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, map[string]string{
+		"id":     tradeID.String(),
+		"status": string(domain.TradeStatusCancelled),
+	})
+}
+
+// parseTradeAction parses the trade ID from the path and the acting user from the body
+func parseTradeAction(w http.ResponseWriter, r *http.Request, tradeIDStr string) (uuid.UUID, uuid.UUID, bool) {
+	tradeID, err := uuid.Parse(tradeIDStr)
+	if err != nil {
+		RespondBadRequest(w, "Invalid trade ID format")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	var req TradeActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return tradeID, userID, true
+}
+
+// respondTradeError maps trade errors to HTTP responses
+func respondTradeError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, validators.ErrCannotTradeWithSelf),
+		errors.Is(err, validators.ErrEmptyTrade),
+		errors.Is(err, validators.ErrTooManyTradePokemon),
+		errors.Is(err, validators.ErrInvalidTradeCoins),
+		errors.Is(err, validators.ErrDuplicateTradePokemon):
+		RespondBadRequest(w, err.Error())
+	case errors.Is(err, service.ErrNotTradeParticipant):
+		RespondError(w, http.StatusForbidden, ErrCodeForbidden, err.Error())
+	case errors.Is(err, service.ErrTradeInsufficientCoins):
+		RespondError(w, http.StatusPaymentRequired, ErrCodeInsufficientCoins, err.Error())
+	case errors.Is(err, service.ErrTradeUnavailable), errors.Is(err, service.ErrTradeExpired),
+		errors.Is(err, service.ErrTradePokemonUnavailable), errors.Is(err, service.ErrPokemonListed),
+		errors.Is(err, service.ErrPokemonOnCooldown), errors.Is(err, service.ErrPokemonInPendingTrade),
+		errors.Is(err, service.ErrPokemonLocked):
+		RespondConflict(w, err.Error())
+	case strings.Contains(err.Error(), "not found"):
+		RespondNotFound(w, err.Error())
+	default:
+		RespondInternalError(w, fallback)
+	}
+}
+
+// parseUUIDs parses a list of IDs from a request body
+func parseUUIDs(values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, len(values))
+	for i, v := range values {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// uuidStrings formats a list of IDs for a response
+func uuidStrings(ids []uuid.UUID) []string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	return values
+}
+
+// tradeToResponse converts a trade to response format
+func tradeToResponse(t *domain.Trade) TradeResponse {
+	return TradeResponse{
+		ID:                  t.ID.String(),
+		ProposerID:          t.ProposerID.String(),
+		RecipientID:         t.RecipientID.String(),
+		ProposerPokemonIDs:  uuidStrings(t.ProposerPokemonIDs),
+		RecipientPokemonIDs: uuidStrings(t.RecipientPokemonIDs),
+		ProposerCoins:       t.ProposerCoins,
+		RecipientCoins:      t.RecipientCoins,
+		ProposerConfirmed:   t.ProposerConfirmed,
+		RecipientConfirmed:  t.RecipientConfirmed,
+		Status:              string(t.Status),
+		CreatedAt:           t.CreatedAt.UTC().Format(time.RFC3339),
+		ExpiresAt:           t.ExpiresAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/google/uuid"
+)
+
+type TrainingHandler struct {
+	trainingService  *service.TrainingService
+	valuationService *service.ValuationService
+}
+
+func NewTrainingHandler(trainingService *service.TrainingService, valuationService *service.ValuationService) *TrainingHandler {
+	return &TrainingHandler{
+		trainingService:  trainingService,
+		valuationService: valuationService,
+	}
+}
+
+type TrainEVsRequest struct {
+	UserID  string      `json:"user_id"`
+	EVs     *domain.EVs `json:"evs"`     // EVs to add per stat
+	Spread  string      `json:"spread"`  // Or a Showdown spread, e.g. "252 Atk / 4 SpD"
+	Payment string      `json:"payment"` // "coins" or "vitamins"
+}
+
+type TrainEVsResponse struct {
+	Pokemon PokemonRollResponse   `json:"pokemon"`
+	Cost    domain.EVTrainingCost `json:"cost"`
+}
+
+// POST /api/pokemon/{id}/evs
+func (h *TrainingHandler) TrainEVs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 {
+		RespondNotFound(w, "Route not found")
+		return
+	}
+
+	pokemonID, err := uuid.Parse(pathParts[2])
+	if err != nil {
+		RespondBadRequest(w, "Invalid Pokemon ID format")
+		return
+	}
+
+	var req TrainEVsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "Invalid request body")
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		RespondBadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	var evs domain.EVs
+	switch {
+	case req.EVs != nil && req.Spread != "":
+		RespondBadRequest(w, "Send either evs or spread, not both")
+		return
+	case req.EVs != nil:
+		evs = *req.EVs
+	default:
+		if evs, err = domain.ParseEVs(req.Spread); err != nil {
+			RespondBadRequest(w, err.Error())
+			return
+		}
+	}
+
+	payment := domain.EVPayment(req.Payment)
+	if payment == "" {
+		payment = domain.PayWithCoins
+	}
+
+	result, err := h.trainingService.TrainEVs(r.Context(), userID, pokemonID, evs, payment)
+	if err != nil {
+		switch {
+		case errors.Is(err, validators.ErrNoEVsToTrain),
+			errors.Is(err, validators.ErrNegativeEVs),
+			errors.Is(err, validators.ErrInvalidEVPayment),
+			errors.Is(err, validators.ErrInvalidEV),
+			errors.Is(err, validators.ErrTooManyEVs):
+			RespondBadRequest(w, err.Error())
+		case errors.Is(err, service.ErrNotPokemonOwner):
+			RespondError(w, http.StatusForbidden, ErrCodeForbidden, err.Error())
+		case errors.Is(err, service.ErrTrainingCoins):
+			RespondError(w, http.StatusPaymentRequired, ErrCodeInsufficientCoins, err.Error())
+		case errors.Is(err, service.ErrTrainingVitamins):
+			RespondError(w, http.StatusPaymentRequired, ErrCodeInsufficientItems, err.Error())
+		case errors.Is(err, service.ErrPokemonLocked):
+			RespondConflict(w, err.Error())
+		case strings.Contains(err.Error(), "not found"):
+			RespondNotFound(w, err.Error())
+		default:
+			RespondInternalError(w, "Failed to train EVs")
+		}
+		return
+	}
+
+	RespondJSON(w, http.StatusOK, TrainEVsResponse{
+		Pokemon: pokemonsToResponse(r.Context(), h.valuationService, []*domain.UserPokemon{result.Pokemon})[0],
+		Cost:    result.Cost,
+	})
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+)
+
+// DefaultPriceHistoryDays is how much price history is returned by default
+const DefaultPriceHistoryDays = 30
+
+type ValuationHandler struct {
+	valuationService *service.ValuationService
+}
+
+func NewValuationHandler(valuationService *service.ValuationService) *ValuationHandler {
+	return &ValuationHandler{
+		valuationService: valuationService,
+	}
+}
+
+// GET /api/market/prices/{species_id}?days=
+func (h *ValuationHandler) GetPrices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		RespondError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) != 4 {
+		RespondNotFound(w, "Route not found")
+		return
+	}
+
+	speciesID, err := strconv.Atoi(pathParts[3])
+	if err != nil || speciesID <= 0 {
+		RespondBadRequest(w, "Invalid species ID format")
+		return
+	}
+
+	days, err := parseIntParam(r.URL.Query().Get("days"))
+	if err != nil {
+		RespondBadRequest(w, "days must be a non-negative integer")
+		return
+	}
+	if days == 0 {
+		days = DefaultPriceHistoryDays
+	}
+
+	report, err := h.valuationService.GetPriceReport(r.Context(), speciesID, days)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidHistoryDays) {
+			RespondBadRequest(w, err.Error())
+			return
+		}
+		RespondInternalError(w, "Failed to retrieve prices")
+		return
+	}
+
+	if report.History == nil {
+		report.History = []domain.PricePoint{}
+	}
+
+	RespondJSON(w, http.StatusOK, report)
+}
+
+// pokemonsToResponse converts Pokemon to response format, valued from market
+// sales where there are enough. If valuation fails the error is logged and
+// the formula value is used.
+func pokemonsToResponse(ctx context.Context, valuationService *service.ValuationService, pokemons []*domain.UserPokemon) []PokemonRollResponse {
+	if err := valuationService.ApplyMarketValues(ctx, pokemons); err != nil {
+		log.Printf("failed to apply market values, using formula values: %v", err)
+	}
+
+	response := make([]PokemonRollResponse, len(pokemons))
+	for i, p := range pokemons {
+		response[i] = pokemonToResponse(p)
+	}
+	return response
+}
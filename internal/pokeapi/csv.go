@@ -0,0 +1,282 @@
+package pokeapi
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+)
+
+// englishLanguageID is the id of English in languages.csv
+const englishLanguageID = 9
+
+// csvRow is a CSV row read by column name
+type csvRow map[string]string
+
+// Int returns a column as a number, or 0 when it's empty
+func (r csvRow) Int(column string) int {
+	n, _ := strconv.Atoi(r[column])
+	return n
+}
+
+// Bool returns a 0/1 column as a bool
+func (r csvRow) Bool(column string) bool {
+	return r[column] == "1"
+}
+
+// LoadCSV reads a dump of the PokeAPI CSV tables. pokemon.csv,
+// pokemon_species.csv, pokemon_types.csv, types.csv, pokemon_stats.csv and
+// stats.csv are required. Names, abilities, growth rates, learnsets
+// (pokemon_moves.csv) and moves (moves.csv with move_damage_classes.csv)
+// are read when their tables are there, along with the move meta tables.
+func LoadCSV(dir string) (*domain.SpeciesImport, error) {
+	t := &csvTables{dir: dir}
+
+	types := t.identifiers("types.csv", true)
+	stats := t.identifiers("stats.csv", true)
+	growthRates := t.identifiers("growth_rates.csv", false)
+	abilities := t.identifiers("abilities.csv", false)
+
+	species := make(map[int]csvRow)
+	for _, row := range t.read("pokemon_species.csv", true) {
+		species[row.Int("id")] = row
+	}
+	speciesNames := t.englishNames("pokemon_species_names.csv", "pokemon_species_id")
+
+	records := make(map[int]*pokemonRecord)
+	var pokemon []*pokemonRecord
+	for _, row := range t.read("pokemon.csv", true) {
+		// Alternate forms share their species' Pokedex number
+		if !row.Bool("is_default") {
+			continue
+		}
+		speciesID := row.Int("species_id")
+		record := &pokemonRecord{
+			ID:             speciesID,
+			Identifier:     row["identifier"],
+			Name:           speciesNames[speciesID],
+			Stats:          make(map[string]int),
+			BaseExperience: row.Int("base_experience"),
+		}
+		if s, ok := species[speciesID]; ok {
+			record.Identifier = s["identifier"]
+			record.GenderRate = s.Int("gender_rate")
+			record.GrowthRate = growthRates[s.Int("growth_rate_id")]
+			record.Legendary = s.Bool("is_legendary")
+			record.Mythical = s.Bool("is_mythical")
+		}
+		records[row.Int("id")] = record
+		pokemon = append(pokemon, record)
+	}
+
+	// Rows are keyed by Pokemon id, so alternate forms' rows find no record
+	slots := make(map[*pokemonRecord]map[int]string)
+	for _, row := range t.read("pokemon_types.csv", true) {
+		if record, ok := records[row.Int("pokemon_id")]; ok {
+			if slots[record] == nil {
+				slots[record] = make(map[int]string)
+			}
+			slots[record][row.Int("slot")] = types[row.Int("type_id")]
+		}
+	}
+	for record, bySlot := range slots {
+		for slot := 1; slot <= len(bySlot); slot++ {
+			record.Types = append(record.Types, bySlot[slot])
+		}
+	}
+
+	for _, row := range t.read("pokemon_stats.csv", true) {
+		if record, ok := records[row.Int("pokemon_id")]; ok {
+			record.Stats[stats[row.Int("stat_id")]] = row.Int("base_stat")
+		}
+	}
+
+	abilitySlots := make(map[*pokemonRecord]map[int]string)
+	for _, row := range t.read("pokemon_abilities.csv", false) {
+		record, ok := records[row.Int("pokemon_id")]
+		if !ok {
+			continue
+		}
+		if row.Bool("is_hidden") {
+			record.HiddenAbility = abilities[row.Int("ability_id")]
+			continue
+		}
+		if abilitySlots[record] == nil {
+			abilitySlots[record] = make(map[int]string)
+		}
+		abilitySlots[record][row.Int("slot")] = abilities[row.Int("ability_id")]
+	}
+	for record, bySlot := range abilitySlots {
+		for slot := 1; slot <= 3; slot++ {
+			if ability, ok := bySlot[slot]; ok {
+				record.Abilities = append(record.Abilities, ability)
+			}
+		}
+	}
+
+	moves := t.moves(types)
+	moveIdentifiers := make(map[int]string, len(moves))
+	for _, move := range moves {
+		moveIdentifiers[move.ID] = move.Identifier
+	}
+	if len(moves) == 0 {
+		// Learnsets can still name moves seeded before the import
+		moveIdentifiers = t.identifiers("moves.csv", false)
+	}
+	for _, row := range t.read("pokemon_moves.csv", false) {
+		if record, ok := records[row.Int("pokemon_id")]; ok {
+			if identifier, ok := moveIdentifiers[row.Int("move_id")]; ok {
+				record.Moves = append(record.Moves, identifier)
+			}
+		}
+	}
+
+	if t.err != nil {
+		return nil, t.err
+	}
+	return build(pokemon, moves), nil
+}
+
+// moves reads moves.csv and its meta tables, or nothing when the move
+// tables aren't in the dump
+func (t *csvTables) moves(types map[int]string) []*moveRecord {
+	damageClasses := t.identifiers("move_damage_classes.csv", false)
+	if len(damageClasses) == 0 {
+		return nil
+	}
+	targets := t.identifiers("move_targets.csv", false)
+	ailments := t.identifiers("move_meta_ailments.csv", false)
+	categories := t.identifiers("move_meta_categories.csv", false)
+	stats := t.identifiers("stats.csv", true)
+	names := t.englishNames("move_names.csv", "move_id")
+
+	effects := make(map[int]string)
+	for _, row := range t.read("move_effect_prose.csv", false) {
+		if row.Int("local_language_id") == englishLanguageID {
+			effects[row.Int("move_effect_id")] = row["short_effect"]
+		}
+	}
+
+	byID := make(map[int]*moveRecord)
+	var moves []*moveRecord
+	for _, row := range t.read("moves.csv", false) {
+		move := &moveRecord{
+			ID:           row.Int("id"),
+			Identifier:   row["identifier"],
+			Name:         names[row.Int("id")],
+			Type:         types[row.Int("type_id")],
+			DamageClass:  damageClasses[row.Int("damage_class_id")],
+			Target:       targets[row.Int("target_id")],
+			Power:        row.Int("power"),
+			Accuracy:     row.Int("accuracy"),
+			PP:           row.Int("pp"),
+			Priority:     row.Int("priority"),
+			EffectChance: row.Int("effect_chance"),
+			ShortEffect:  effects[row.Int("effect_id")],
+		}
+		byID[move.ID] = move
+		moves = append(moves, move)
+	}
+
+	for _, row := range t.read("move_meta.csv", false) {
+		if move, ok := byID[row.Int("move_id")]; ok {
+			move.Meta = &moveMeta{
+				Category:      categories[row.Int("meta_category_id")],
+				Ailment:       ailments[row.Int("meta_ailment_id")],
+				MinHits:       row.Int("min_hits"),
+				MaxHits:       row.Int("max_hits"),
+				Drain:         row.Int("drain"),
+				Healing:       row.Int("healing"),
+				CritRate:      row.Int("crit_rate"),
+				AilmentChance: row.Int("ailment_chance"),
+				FlinchChance:  row.Int("flinch_chance"),
+				StatChance:    row.Int("stat_chance"),
+			}
+		}
+	}
+
+	for _, row := range t.read("move_meta_stat_changes.csv", false) {
+		if move, ok := byID[row.Int("move_id")]; ok {
+			move.StatChanges = append(move.StatChanges, statChange{Stat: stats[row.Int("stat_id")], Change: row.Int("change")})
+		}
+	}
+
+	return moves
+}
+
+// csvTables reads the tables of a CSV dump, keeping the first error so
+// LoadCSV can read every table before checking
+type csvTables struct {
+	dir string
+	err error
+}
+
+// read returns the rows of a table. A missing optional table has no rows.
+func (t *csvTables) read(name string, required bool) []csvRow {
+	if t.err != nil {
+		return nil
+	}
+
+	file, err := os.Open(filepath.Join(t.dir, name))
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return nil
+	}
+	if err != nil {
+		t.err = fmt.Errorf("failed to open %s: %w", name, err)
+		return nil
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	header, err := reader.Read()
+	if err != nil {
+		t.err = fmt.Errorf("failed to read %s: %w", name, err)
+		return nil
+	}
+
+	var rows []csvRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.err = fmt.Errorf("failed to read %s: %w", name, err)
+			return nil
+		}
+		row := make(csvRow, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				row[column] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// identifiers maps the ids of a lookup table to their identifiers
+func (t *csvTables) identifiers(name string, required bool) map[int]string {
+	identifiers := make(map[int]string)
+	for _, row := range t.read(name, required) {
+		identifiers[row.Int("id")] = row["identifier"]
+	}
+	return identifiers
+}
+
+// englishNames maps the ids of a names table to their English names
+func (t *csvTables) englishNames(name, idColumn string) map[int]string {
+	names := make(map[int]string)
+	for _, row := range t.read(name, false) {
+		if row.Int("local_language_id") == englishLanguageID {
+			names[row.Int(idColumn)] = row["name"]
+		}
+	}
+	return names
+}
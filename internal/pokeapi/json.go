@@ -0,0 +1,283 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+)
+
+// namedResource is a PokeAPI link to another resource
+type namedResource struct {
+	Name string `json:"name"`
+}
+
+// localizedName is a name in one language
+type localizedName struct {
+	Name     string        `json:"name"`
+	Language namedResource `json:"language"`
+}
+
+type pokemonJSON struct {
+	ID             int           `json:"id"`
+	Name           string        `json:"name"`
+	IsDefault      bool          `json:"is_default"`
+	BaseExperience *int          `json:"base_experience"`
+	Species        namedResource `json:"species"`
+	Types          []struct {
+		Slot int           `json:"slot"`
+		Type namedResource `json:"type"`
+	} `json:"types"`
+	Stats []struct {
+		BaseStat int           `json:"base_stat"`
+		Stat     namedResource `json:"stat"`
+	} `json:"stats"`
+	Abilities []struct {
+		Ability  namedResource `json:"ability"`
+		IsHidden bool          `json:"is_hidden"`
+		Slot     int           `json:"slot"`
+	} `json:"abilities"`
+	Sprites struct {
+		FrontDefault string `json:"front_default"`
+		FrontShiny   string `json:"front_shiny"`
+	} `json:"sprites"`
+	Moves []struct {
+		Move namedResource `json:"move"`
+	} `json:"moves"`
+}
+
+type speciesJSON struct {
+	Name        string          `json:"name"`
+	GenderRate  int             `json:"gender_rate"`
+	GrowthRate  namedResource   `json:"growth_rate"`
+	IsLegendary bool            `json:"is_legendary"`
+	IsMythical  bool            `json:"is_mythical"`
+	Names       []localizedName `json:"names"`
+}
+
+type moveJSON struct {
+	ID            int             `json:"id"`
+	Name          string          `json:"name"`
+	Accuracy      *int            `json:"accuracy"`
+	Power         *int            `json:"power"`
+	PP            *int            `json:"pp"`
+	Priority      int             `json:"priority"`
+	EffectChance  *int            `json:"effect_chance"`
+	Type          namedResource   `json:"type"`
+	DamageClass   namedResource   `json:"damage_class"`
+	Target        namedResource   `json:"target"`
+	Names         []localizedName `json:"names"`
+	EffectEntries []struct {
+		ShortEffect string        `json:"short_effect"`
+		Language    namedResource `json:"language"`
+	} `json:"effect_entries"`
+	Meta *struct {
+		Ailment       namedResource `json:"ailment"`
+		Category      namedResource `json:"category"`
+		MinHits       *int          `json:"min_hits"`
+		MaxHits       *int          `json:"max_hits"`
+		Drain         int           `json:"drain"`
+		Healing       int           `json:"healing"`
+		CritRate      int           `json:"crit_rate"`
+		AilmentChance int           `json:"ailment_chance"`
+		FlinchChance  int           `json:"flinch_chance"`
+		StatChance    int           `json:"stat_chance"`
+	} `json:"meta"`
+	StatChanges []struct {
+		Change int           `json:"change"`
+		Stat   namedResource `json:"stat"`
+	} `json:"stat_changes"`
+}
+
+// LoadJSON reads a dump of PokeAPI responses: one JSON file per resource
+// under pokemon/, pokemon-species/ and move/, at any depth, so both
+// pokemon/1.json and the api-data layout pokemon/1/index.json work. Only
+// pokemon/ is required; without pokemon-species/ names, legendary status
+// and growth rates are missing, and without move/ no moves are imported.
+func LoadJSON(dir string) (*domain.SpeciesImport, error) {
+	var species []speciesJSON
+	if err := readJSONDir(filepath.Join(dir, "pokemon-species"), false, &species); err != nil {
+		return nil, err
+	}
+	speciesByName := make(map[string]*speciesJSON, len(species))
+	for i := range species {
+		speciesByName[species[i].Name] = &species[i]
+	}
+
+	var pokemon []pokemonJSON
+	if err := readJSONDir(filepath.Join(dir, "pokemon"), true, &pokemon); err != nil {
+		return nil, err
+	}
+
+	var records []*pokemonRecord
+	for _, p := range pokemon {
+		// Alternate forms share their species' Pokedex number
+		if !p.IsDefault {
+			continue
+		}
+		records = append(records, pokemonFromJSON(&p, speciesByName[p.Species.Name]))
+	}
+
+	var moves []moveJSON
+	if err := readJSONDir(filepath.Join(dir, "move"), false, &moves); err != nil {
+		return nil, err
+	}
+	moveRecords := make([]*moveRecord, len(moves))
+	for i := range moves {
+		moveRecords[i] = moveFromJSON(&moves[i])
+	}
+
+	return build(records, moveRecords), nil
+}
+
+// readJSONDir decodes every .json file under dir into a slice
+func readJSONDir[T any](dir string, required bool, dest *[]T) error {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.EqualFold(filepath.Ext(path), ".json") {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		var value T
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		*dest = append(*dest, value)
+	}
+	return nil
+}
+
+// pokemonFromJSON joins a Pokemon with its species, which may be nil
+func pokemonFromJSON(p *pokemonJSON, species *speciesJSON) *pokemonRecord {
+	record := &pokemonRecord{
+		ID:             p.ID,
+		Identifier:     p.Species.Name,
+		Stats:          make(map[string]int, len(p.Stats)),
+		SpriteURL:      p.Sprites.FrontDefault,
+		ShinySpriteURL: p.Sprites.FrontShiny,
+	}
+	if record.Identifier == "" {
+		record.Identifier = p.Name
+	}
+	if p.BaseExperience != nil {
+		record.BaseExperience = *p.BaseExperience
+	}
+
+	types := p.Types
+	sort.Slice(types, func(i, j int) bool { return types[i].Slot < types[j].Slot })
+	for _, t := range types {
+		record.Types = append(record.Types, t.Type.Name)
+	}
+
+	for _, s := range p.Stats {
+		record.Stats[s.Stat.Name] = s.BaseStat
+	}
+
+	abilities := p.Abilities
+	sort.Slice(abilities, func(i, j int) bool { return abilities[i].Slot < abilities[j].Slot })
+	for _, a := range abilities {
+		if a.IsHidden {
+			record.HiddenAbility = a.Ability.Name
+		} else {
+			record.Abilities = append(record.Abilities, a.Ability.Name)
+		}
+	}
+
+	for _, m := range p.Moves {
+		record.Moves = append(record.Moves, m.Move.Name)
+	}
+
+	if species != nil {
+		record.Name = englishName(species.Names)
+		record.GenderRate = species.GenderRate
+		record.GrowthRate = species.GrowthRate.Name
+		record.Legendary = species.IsLegendary
+		record.Mythical = species.IsMythical
+	}
+	return record
+}
+
+// moveFromJSON converts a move response
+func moveFromJSON(m *moveJSON) *moveRecord {
+	record := &moveRecord{
+		ID:           m.ID,
+		Identifier:   m.Name,
+		Name:         englishName(m.Names),
+		Type:         m.Type.Name,
+		DamageClass:  m.DamageClass.Name,
+		Target:       m.Target.Name,
+		Power:        intOrZero(m.Power),
+		Accuracy:     intOrZero(m.Accuracy),
+		PP:           intOrZero(m.PP),
+		Priority:     m.Priority,
+		EffectChance: intOrZero(m.EffectChance),
+	}
+
+	for _, entry := range m.EffectEntries {
+		if entry.Language.Name == "en" {
+			record.ShortEffect = entry.ShortEffect
+			break
+		}
+	}
+
+	if m.Meta != nil {
+		record.Meta = &moveMeta{
+			Category:      m.Meta.Category.Name,
+			Ailment:       m.Meta.Ailment.Name,
+			MinHits:       intOrZero(m.Meta.MinHits),
+			MaxHits:       intOrZero(m.Meta.MaxHits),
+			Drain:         m.Meta.Drain,
+			Healing:       m.Meta.Healing,
+			CritRate:      m.Meta.CritRate,
+			AilmentChance: m.Meta.AilmentChance,
+			FlinchChance:  m.Meta.FlinchChance,
+			StatChance:    m.Meta.StatChance,
+		}
+	}
+
+	for _, change := range m.StatChanges {
+		record.StatChanges = append(record.StatChanges, statChange{Stat: change.Stat.Name, Change: change.Change})
+	}
+	return record
+}
+
+// englishName returns the English name from a list of localized names
+func englishName(names []localizedName) string {
+	for _, name := range names {
+		if name.Language.Name == "en" {
+			return name.Name
+		}
+	}
+	return ""
+}
+
+// intOrZero reads a nullable number
+func intOrZero(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
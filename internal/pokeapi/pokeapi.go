@@ -0,0 +1,370 @@
+// Package pokeapi reads species and move data from local PokeAPI dumps,
+// either the JSON API responses or the CSV tables of the PokeAPI repo.
+package pokeapi
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// spriteURL and shinySpriteURL are used when a dump has no sprites
+const (
+	spriteURL      = "https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/%d.png"
+	shinySpriteURL = "https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/%d.png"
+)
+
+var ErrUnknownFormat = errors.New("unknown dump format")
+
+// pokemonRecord is a default Pokemon joined with its species, in either format
+type pokemonRecord struct {
+	ID             int
+	Identifier     string
+	Name           string // English name, if the dump has one
+	Types          []string
+	Stats          map[string]int
+	Abilities      []string
+	HiddenAbility  string
+	BaseExperience int
+	GenderRate     int
+	GrowthRate     string
+	Legendary      bool
+	Mythical       bool
+	SpriteURL      string
+	ShinySpriteURL string
+	Moves          []string // Move identifiers
+}
+
+// moveRecord is a move with its meta data, in either format
+type moveRecord struct {
+	ID           int
+	Identifier   string
+	Name         string // English name, if the dump has one
+	Type         string
+	DamageClass  string
+	Target       string
+	Power        int
+	Accuracy     int
+	PP           int
+	Priority     int
+	EffectChance int
+	ShortEffect  string
+	Meta         *moveMeta
+	StatChanges  []statChange
+}
+
+type moveMeta struct {
+	Category      string
+	Ailment       string
+	MinHits       int
+	MaxHits       int
+	Drain         int
+	Healing       int
+	CritRate      int
+	AilmentChance int
+	FlinchChance  int
+	StatChance    int
+}
+
+type statChange struct {
+	Stat   string
+	Change int
+}
+
+// Load reads the dump in dir. format is FormatJSON, FormatCSV or empty to
+// detect it: CSV dumps have a pokemon.csv, JSON dumps a pokemon directory.
+func Load(dir, format string) (*domain.SpeciesImport, error) {
+	if format == "" {
+		if _, err := os.Stat(filepath.Join(dir, "pokemon.csv")); err == nil {
+			format = FormatCSV
+		} else {
+			format = FormatJSON
+		}
+	}
+
+	switch format {
+	case FormatJSON:
+		return LoadJSON(dir)
+	case FormatCSV:
+		return LoadCSV(dir)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// build converts the records of a dump to species and moves
+func build(pokemon []*pokemonRecord, moves []*moveRecord) *domain.SpeciesImport {
+	sort.Slice(pokemon, func(i, j int) bool { return pokemon[i].ID < pokemon[j].ID })
+	sort.Slice(moves, func(i, j int) bool { return moves[i].ID < moves[j].ID })
+
+	data := &domain.SpeciesImport{
+		Species: make([]*domain.ImportedSpecies, 0, len(pokemon)),
+		Moves:   make([]*domain.Move, 0, len(moves)),
+	}
+
+	moveNames := make(map[string]string, len(moves))
+	for _, record := range moves {
+		move := convertMove(record)
+		moveNames[record.Identifier] = move.Name
+		data.Moves = append(data.Moves, move)
+	}
+
+	for _, record := range pokemon {
+		imported := &domain.ImportedSpecies{
+			Species:   convertSpecies(record),
+			Legendary: record.Legendary,
+			Mythical:  record.Mythical,
+		}
+		seen := make(map[string]bool, len(record.Moves))
+		for _, identifier := range record.Moves {
+			if seen[identifier] {
+				continue
+			}
+			seen[identifier] = true
+
+			name, ok := moveNames[identifier]
+			if !ok {
+				name = displayName(identifier)
+			}
+			imported.Moves = append(imported.Moves, name)
+		}
+		data.Species = append(data.Species, imported)
+	}
+
+	return data
+}
+
+// convertSpecies converts a Pokemon record. Rarity and drop weight are
+// left for the importer to assign.
+func convertSpecies(record *pokemonRecord) *domain.PokemonSpecies {
+	species := &domain.PokemonSpecies{
+		ID:             record.ID,
+		Name:           record.Name,
+		BaseHP:         record.Stats["hp"],
+		BaseAttack:     record.Stats["attack"],
+		BaseDefense:    record.Stats["defense"],
+		BaseSpAttack:   record.Stats["special-attack"],
+		BaseSpDefense:  record.Stats["special-defense"],
+		BaseSpeed:      record.Stats["speed"],
+		SpriteURL:      record.SpriteURL,
+		ShinySpriteURL: record.ShinySpriteURL,
+		GrowthRate:     growthRate(record.GrowthRate),
+		BaseExperience: record.BaseExperience,
+		GenderRate:     record.GenderRate,
+		HiddenAbility:  abilityName(record.HiddenAbility),
+	}
+
+	if species.Name == "" {
+		species.Name = displayName(record.Identifier)
+	}
+	if species.SpriteURL == "" {
+		species.SpriteURL = fmt.Sprintf(spriteURL, record.ID)
+	}
+	if species.ShinySpriteURL == "" {
+		species.ShinySpriteURL = fmt.Sprintf(shinySpriteURL, record.ID)
+	}
+	if species.BaseExperience == 0 {
+		species.BaseExperience = domain.DefaultBaseExperience
+	}
+
+	if len(record.Types) > 0 {
+		species.Type1 = domain.PokemonType(record.Types[0])
+	}
+	if len(record.Types) > 1 {
+		type2 := domain.PokemonType(record.Types[1])
+		species.Type2 = &type2
+	}
+
+	for _, ability := range record.Abilities {
+		species.Abilities = append(species.Abilities, abilityName(ability))
+	}
+
+	return species
+}
+
+// convertMove converts a move record, mapping its meta data onto the
+// effects the battle engine understands
+func convertMove(record *moveRecord) *domain.Move {
+	move := &domain.Move{
+		ID:          record.ID,
+		Name:        record.Name,
+		Type:        domain.PokemonType(record.Type),
+		Category:    domain.MoveCategory(record.DamageClass),
+		Power:       record.Power,
+		Accuracy:    record.Accuracy,
+		PP:          record.PP,
+		Priority:    record.Priority,
+		Target:      moveTarget(record.Target),
+		Description: description(record.ShortEffect, record.EffectChance),
+	}
+	if move.Name == "" {
+		move.Name = displayName(record.Identifier)
+	}
+
+	meta := record.Meta
+	if meta == nil {
+		return move
+	}
+
+	move.CritRatio = meta.CritRate
+	if meta.Drain > 0 {
+		move.DrainPercent = meta.Drain
+	} else if meta.Drain < 0 {
+		move.RecoilPercent = -meta.Drain
+	}
+	if meta.Healing > 0 {
+		move.HealPercent = meta.Healing
+	}
+	if meta.MinHits > 0 && meta.MaxHits > 0 {
+		move.MultiHit = &domain.MultiHit{MinHits: meta.MinHits, MaxHits: meta.MaxHits}
+	}
+
+	if status, ok := ailment(record.Identifier, meta.Ailment); ok {
+		// Status moves and moves without a chance always inflict it
+		if meta.AilmentChance > 0 && meta.AilmentChance < 100 && move.Category != domain.Status {
+			move.SecondaryEffect = &domain.SecondaryEffect{
+				Chance:        meta.AilmentChance,
+				StatusInflict: &domain.StatusInflict{Status: status, Chance: meta.AilmentChance},
+			}
+		} else {
+			move.StatusInflict = &domain.StatusInflict{Status: status, Chance: 100}
+		}
+	}
+
+	if meta.FlinchChance > 0 {
+		if move.SecondaryEffect == nil {
+			move.SecondaryEffect = &domain.SecondaryEffect{Chance: meta.FlinchChance}
+		}
+		move.SecondaryEffect.FlinchChance = meta.FlinchChance
+	}
+
+	changes := statChanges(record, meta)
+	if len(changes) > 0 {
+		if meta.StatChance > 0 && meta.StatChance < 100 {
+			if move.SecondaryEffect == nil {
+				move.SecondaryEffect = &domain.SecondaryEffect{Chance: meta.StatChance}
+			}
+			move.SecondaryEffect.StatChanges = changes
+		} else {
+			move.StatChanges = changes
+		}
+	}
+
+	return move
+}
+
+// statChanges converts a move's stat changes. They fall on the user for
+// moves that target it or raise its stats after dealing damage.
+func statChanges(record *moveRecord, meta *moveMeta) []domain.StatChange {
+	target := "opponent"
+	if meta.Category == "damage+raise" || moveTarget(record.Target) == domain.TargetSelf || moveTarget(record.Target) == domain.TargetUserAndAllies {
+		target = "self"
+	}
+
+	var changes []domain.StatChange
+	for _, change := range record.StatChanges {
+		changes = append(changes, domain.StatChange{
+			Stat:   domain.StatType(strings.ReplaceAll(change.Stat, "-", "_")),
+			Stages: change.Change,
+			Target: target,
+		})
+	}
+	return changes
+}
+
+// ailment maps a PokeAPI ailment to a status condition. Ailments the
+// battle engine doesn't model, like confusion, are dropped.
+func ailment(moveIdentifier, name string) (domain.StatusCondition, bool) {
+	switch name {
+	case "burn":
+		return domain.StatusBurn, true
+	case "freeze":
+		return domain.StatusFreeze, true
+	case "paralysis":
+		return domain.StatusParalysis, true
+	case "sleep":
+		return domain.StatusSleep, true
+	case "poison":
+		if moveIdentifier == "toxic" {
+			return domain.StatusBadlyPoison, true
+		}
+		return domain.StatusPoison, true
+	}
+	return "", false
+}
+
+// moveTarget maps a PokeAPI move target to a battle target
+func moveTarget(target string) domain.MoveTarget {
+	switch target {
+	case "user", "user-or-ally":
+		return domain.TargetSelf
+	case "ally", "users-field", "user-and-allies":
+		return domain.TargetUserAndAllies
+	case "all-opponents", "opponents-field":
+		return domain.TargetAllOpponents
+	case "all-other-pokemon", "all-pokemon", "entire-field":
+		return domain.TargetAllPokemon
+	case "random-opponent":
+		return domain.TargetRandomOpponent
+	}
+	return domain.TargetOpponent
+}
+
+// growthRate maps a PokeAPI growth rate to the nearest curve the game has
+func growthRate(name string) domain.GrowthRate {
+	switch name {
+	case "fast", "slow-then-very-fast":
+		return domain.GrowthFast
+	case "medium", "medium-fast":
+		return domain.GrowthMediumFast
+	case "medium-slow":
+		return domain.GrowthMediumSlow
+	case "slow", "fast-then-very-slow":
+		return domain.GrowthSlow
+	}
+	return ""
+}
+
+// abilityName converts a PokeAPI ability identifier to the game's format
+func abilityName(identifier string) string {
+	return strings.ReplaceAll(identifier, "-", "_")
+}
+
+// displayName turns an identifier like "quick-attack" into "Quick Attack"
+func displayName(identifier string) string {
+	words := strings.Split(identifier, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// markupPattern matches PokeAPI prose links like [paralyzes]{mechanic:paralysis}
+var markupPattern = regexp.MustCompile(`\[([^\]]*)\]\{[^:}]*:([^}]*)\}`)
+
+// description cleans a move's short effect for display
+func description(shortEffect string, effectChance int) string {
+	text := strings.ReplaceAll(shortEffect, "$effect_chance", strconv.Itoa(effectChance))
+	text = markupPattern.ReplaceAllStringFunc(text, func(link string) string {
+		parts := markupPattern.FindStringSubmatch(link)
+		if parts[1] != "" {
+			return parts[1]
+		}
+		return displayName(parts[2])
+	})
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -2,11 +2,19 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/danielyang21/GoBattleServer/internal/domain"
 	"github.com/google/uuid"
 )
 
+// TxManager runs a unit of work in a single transaction.
+// Repository calls made with the ctx passed to fn join the transaction,
+// and any error returned by fn rolls all of them back.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type UserRepository interface {
 	// Create inserts a new user
 	Create(ctx context.Context, user *domain.User) error
@@ -18,8 +26,17 @@ type UserRepository interface {
 	Update(ctx context.Context, user *domain.User) error
 
 	UpdateCoins(ctx context.Context, userID uuid.UUID, coins int) error
+
+	// AdjustCoins atomically adds delta to the balance (negative to deduct).
+	// It returns ErrInsufficientCoins instead of going below zero.
+	AdjustCoins(ctx context.Context, userID uuid.UUID, delta int) error
+
 	UpdateLastDailyRoll(ctx context.Context, userID uuid.UUID) error
 
+	// ClaimDailyRoll atomically marks the daily roll as used if the cooldown
+	// has elapsed. It returns false when the roll was already claimed.
+	ClaimDailyRoll(ctx context.Context, userID uuid.UUID, cooldown time.Duration) (bool, error)
+
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
@@ -29,11 +46,22 @@ type PokemonSpeciesRepository interface {
 	Create(ctx context.Context, species *domain.PokemonSpecies) error
 
 	GetByID(ctx context.Context, id int) (*domain.PokemonSpecies, error)
+
+	// GetByName retrieves a species by name, ignoring case
+	GetByName(ctx context.Context, name string) (*domain.PokemonSpecies, error)
+
 	GetByRarity(ctx context.Context, rarity domain.Rarity) ([]*domain.PokemonSpecies, error)
 	GetRandomByRarity(ctx context.Context, rarity domain.Rarity) (*domain.PokemonSpecies, error)
 
 	// List retrieves all Pokemon species
 	List(ctx context.Context) ([]*domain.PokemonSpecies, error)
+
+	// Search retrieves a page of species matching a filter
+	Search(ctx context.Context, filter domain.SpeciesSearchFilter) ([]*domain.PokemonSpecies, error)
+
+	// Upsert inserts a species or updates the one with its Pokedex number
+	Upsert(ctx context.Context, species *domain.PokemonSpecies) error
+
 	BulkCreate(ctx context.Context, species []*domain.PokemonSpecies) error
 }
 
@@ -48,14 +76,23 @@ type UserPokemonRepository interface {
 	// GetByUserID retrieves all Pokemon owned by a user
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.UserPokemon, error)
 
-	// Update updates Pokemon information (e.g., favorite, nickname)
+	// SearchByUser retrieves a page of a user's box matching the filter
+	SearchByUser(ctx context.Context, userID uuid.UUID, filter domain.BoxFilter) (*domain.BoxPage, error)
+
+	// GetForUpdate retrieves a Pokemon and locks it until the transaction ends
+	GetForUpdate(ctx context.Context, id uuid.UUID) (*domain.UserPokemon, error)
+
+	// Update saves a Pokemon's species, form, ability slot, level, experience, favorite flag, nickname, IVs, EVs and nature
 	Update(ctx context.Context, pokemon *domain.UserPokemon) error
 
-	// Delete removes a Pokemon (e.g., if released)
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Delete removes a user's Pokemon (e.g., if released). Returns
+	// ErrPokemonNotFound if the Pokemon doesn't belong to the user.
+	Delete(ctx context.Context, id, userID uuid.UUID) error
 
-	// TransferOwnership changes Pokemon owner (for market transactions)
-	TransferOwnership(ctx context.Context, pokemonID, newOwnerID uuid.UUID) error
+	// TransferOwnership changes Pokemon owner (market sales and trades).
+	// The new owner's acquisition time starts now. Returns ErrPokemonNotFound
+	// if the Pokemon no longer belongs to fromOwnerID.
+	TransferOwnership(ctx context.Context, pokemonID, fromOwnerID, toOwnerID uuid.UUID) error
 
 	// CountByUser returns the number of Pokemon a user owns
 	CountByUser(ctx context.Context, userID uuid.UUID) (int, error)
@@ -78,8 +115,310 @@ type BattleRepository interface {
 	// ListByPlayer retrieves all battles for a player
 	ListByPlayer(ctx context.Context, playerID uuid.UUID) ([]*domain.Battle, error)
 
+	// CountWins returns how many battles a Pokemon has won for its side
+	CountWins(ctx context.Context, pokemonID uuid.UUID) (int, error)
+
 	// Delete removes a battle
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
-// TODO: Add MarketListingRepository when market domain model is ready
\ No newline at end of file
+// EscrowRepository defines methods for battle wager escrow.
+// Settlement only applies to held escrows, so repeating a call with the same
+// outcome is a no-op and coins can never be moved twice.
+type EscrowRepository interface {
+	// Lock deducts the stake from both players and records a held escrow
+	Lock(ctx context.Context, escrow *domain.BattleEscrow) error
+
+	// GetByBattleID retrieves the escrow for a battle
+	GetByBattleID(ctx context.Context, battleID uuid.UUID) (*domain.BattleEscrow, error)
+
+	// PayOut credits the whole pot to the winner
+	PayOut(ctx context.Context, battleID, winnerID uuid.UUID) error
+
+	// Refund returns each player's stake
+	Refund(ctx context.Context, battleID uuid.UUID) error
+
+	// ListHeld retrieves all escrows that have not been settled yet
+	ListHeld(ctx context.Context) ([]*domain.BattleEscrow, error)
+}
+
+// RollReceiptRepository defines methods for idempotent roll receipts
+type RollReceiptRepository interface {
+	// Create stores the result of a roll under its idempotency key
+	Create(ctx context.Context, receipt *domain.RollReceipt) error
+
+	// GetByKey retrieves the receipt a user stored under a key
+	GetByKey(ctx context.Context, userID uuid.UUID, key string) (*domain.RollReceipt, error)
+}
+
+// BannerRepository defines methods for gacha banner data access
+type BannerRepository interface {
+	// Create inserts a banner with its species pool and featured species
+	Create(ctx context.Context, banner *domain.Banner) error
+
+	// GetByID retrieves a banner
+	GetByID(ctx context.Context, id string) (*domain.Banner, error)
+
+	// ListActive retrieves the banners running at a time, ending soonest first
+	ListActive(ctx context.Context, at time.Time) ([]*domain.Banner, error)
+}
+
+// PityRepository defines methods for per-banner pity counters
+type PityRepository interface {
+	// Get retrieves a user's pity on a banner, with no pulls counted if they
+	// have never pulled on it
+	Get(ctx context.Context, userID uuid.UUID, bannerID string) (*domain.PityState, error)
+
+	// GetForUpdate retrieves a user's pity on a banner and locks it until the
+	// transaction ends
+	GetForUpdate(ctx context.Context, userID uuid.UUID, bannerID string) (*domain.PityState, error)
+
+	// Save stores a user's pity counters on a banner
+	Save(ctx context.Context, state *domain.PityState) error
+}
+
+// FairSeedRepository defines methods for the seeds behind provably fair pulls
+type FairSeedRepository interface {
+	// Create stores a seed pair as a user's active one. It does nothing if
+	// they already have an active seed pair.
+	Create(ctx context.Context, seed *domain.FairSeed) error
+
+	// GetActive retrieves a user's active, unrevealed seed pair
+	GetActive(ctx context.Context, userID uuid.UUID) (*domain.FairSeed, error)
+
+	// GetActiveForUpdate retrieves a user's active seed pair and locks it
+	// until the transaction ends
+	GetActiveForUpdate(ctx context.Context, userID uuid.UUID) (*domain.FairSeed, error)
+
+	// Update stores a seed pair's nonce and when it was revealed
+	Update(ctx context.Context, seed *domain.FairSeed) error
+
+	// ListRevealed retrieves a user's revealed seed pairs, newest first
+	ListRevealed(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.FairSeed, error)
+}
+
+// GachaPullRepository defines methods for gacha pull history
+type GachaPullRepository interface {
+	// Create records a pull
+	Create(ctx context.Context, pull *domain.GachaPull) error
+
+	// ListByUser retrieves a user's pulls matching the filter, newest first
+	ListByUser(ctx context.Context, userID uuid.UUID, filter domain.PullHistoryFilter) ([]*domain.GachaPull, error)
+
+	// Tally counts the pulls matching the filter and, for each rarity, the
+	// pulls that gave it against the odds they were made at
+	Tally(ctx context.Context, filter domain.PullStatsFilter) (int, []domain.RarityTally, error)
+}
+
+// LoginStreakRepository defines methods for daily check-in streaks
+type LoginStreakRepository interface {
+	// Get retrieves a user's streak, with no check-ins if they never
+	// checked in
+	Get(ctx context.Context, userID uuid.UUID) (*domain.LoginStreak, error)
+
+	// GetForUpdate retrieves a user's streak and locks it until the
+	// transaction ends
+	GetForUpdate(ctx context.Context, userID uuid.UUID) (*domain.LoginStreak, error)
+
+	// Save stores a user's streak
+	Save(ctx context.Context, streak *domain.LoginStreak) error
+}
+
+// QuestProgressRepository defines methods for players' quest progress
+type QuestProgressRepository interface {
+	// Add atomically adds delta to a player's progress on a quest in a
+	// period, capped at the goal, and marks it completed on reaching it
+	Add(ctx context.Context, userID uuid.UUID, questID, periodKey string, delta, goal int) error
+
+	// ListByUser retrieves a player's progress in any of the periods
+	ListByUser(ctx context.Context, userID uuid.UUID, periodKeys []string) ([]*domain.QuestProgress, error)
+
+	// GetForUpdate retrieves a player's progress on a quest in a period and
+	// locks it until the transaction ends, with nothing counted if they
+	// have made none
+	GetForUpdate(ctx context.Context, userID uuid.UUID, questID, periodKey string) (*domain.QuestProgress, error)
+
+	// Save stores a player's progress on a quest
+	Save(ctx context.Context, progress *domain.QuestProgress) error
+}
+
+// PokedexRepository defines methods for the species players have seen and
+// owned
+type PokedexRepository interface {
+	// MarkSeen records species as seen by a player
+	MarkSeen(ctx context.Context, userID uuid.UUID, speciesIDs []int) error
+
+	// MarkOwned records species as seen and owned by a player, keeping the
+	// time each was first owned
+	MarkOwned(ctx context.Context, userID uuid.UUID, speciesIDs []int) error
+
+	// ListByUser retrieves every species a player has seen or owned
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.PokedexEntry, error)
+
+	// CountOwned counts the species a player has ever owned
+	CountOwned(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// GrantMilestone records a milestone reward as paid to a player,
+	// returning false if it already was
+	GrantMilestone(ctx context.Context, userID uuid.UUID, owned int) (bool, error)
+
+	// ListMilestones retrieves the milestones paid to a player
+	ListMilestones(ctx context.Context, userID uuid.UUID) ([]int, error)
+}
+
+// MarketListingRepository defines methods for market listing data access
+type MarketListingRepository interface {
+	// Create inserts a new listing (ErrAlreadyListed if the Pokemon has an active one)
+	Create(ctx context.Context, listing *domain.MarketListing) error
+
+	// GetByID retrieves a listing with its Pokemon
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.MarketListing, error)
+
+	// GetActiveByPokemonID retrieves the active listing for a Pokemon
+	GetActiveByPokemonID(ctx context.Context, pokemonID uuid.UUID) (*domain.MarketListing, error)
+
+	// UpdateStatus moves an active listing to sold, cancelled or expired.
+	// It returns ErrListingNotActive if the listing was already closed.
+	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.ListingStatus) error
+
+	// Search retrieves active fixed-price listings matching the filter, cheapest first
+	Search(ctx context.Context, filter domain.MarketSearchFilter) ([]*domain.MarketListing, error)
+}
+
+// MarketTransactionRepository defines methods for completed market sales
+type MarketTransactionRepository interface {
+	// Create records a completed sale
+	Create(ctx context.Context, transaction *domain.MarketTransaction) error
+
+	// ListByUser retrieves a user's purchases and sales, newest first
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.MarketTransaction, error)
+
+	// ListSales retrieves sales of the given species since a time, newest first
+	ListSales(ctx context.Context, speciesIDs []int, since time.Time) ([]*domain.MarketSale, error)
+}
+
+// AuctionRepository defines methods for auction data access.
+// The auction's listing is stored through MarketListingRepository.
+type AuctionRepository interface {
+	// Create inserts the bidding details for an auction listing
+	Create(ctx context.Context, auction *domain.Auction) error
+
+	// GetByListingID retrieves an auction with its listing and Pokemon
+	GetByListingID(ctx context.Context, listingID uuid.UUID) (*domain.Auction, error)
+
+	// GetForUpdate retrieves an auction and locks it until the transaction ends.
+	// Bids, cancellation and settlement call it inside WithinTx so they run one at a time.
+	GetForUpdate(ctx context.Context, listingID uuid.UUID) (*domain.Auction, error)
+
+	// PlaceBid saves the auction's new highest bid and records it.
+	// It returns ErrBidConflict if the current bid is no longer previousBid.
+	PlaceBid(ctx context.Context, auction *domain.Auction, bid *domain.AuctionBid, previousBid int) error
+
+	// ListBids retrieves an auction's bids, newest first
+	ListBids(ctx context.Context, listingID uuid.UUID) ([]*domain.AuctionBid, error)
+
+	// ListActive retrieves active auctions, ending soonest first
+	ListActive(ctx context.Context, limit, offset int) ([]*domain.Auction, error)
+
+	// ListEnded retrieves active auctions whose end time has passed
+	ListEnded(ctx context.Context, now time.Time) ([]*domain.Auction, error)
+}
+
+// NotificationRepository defines methods for user notifications
+type NotificationRepository interface {
+	// Create stores a notification for delivery
+	Create(ctx context.Context, notification *domain.Notification) error
+
+	// ListUndelivered retrieves the oldest undelivered notifications with the user's Discord ID
+	ListUndelivered(ctx context.Context, limit int) ([]*domain.Notification, error)
+
+	// MarkDelivered records that a notification was sent
+	MarkDelivered(ctx context.Context, id uuid.UUID) error
+}
+
+// TradeRepository defines methods for direct trade data access
+type TradeRepository interface {
+	// Create inserts a new trade offer
+	Create(ctx context.Context, trade *domain.Trade) error
+
+	// GetByID retrieves a trade
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Trade, error)
+
+	// GetForUpdate retrieves a trade and locks it until the transaction ends.
+	// Confirmation and cancellation call it inside WithinTx so they run one at a time.
+	GetForUpdate(ctx context.Context, id uuid.UUID) (*domain.Trade, error)
+
+	// Update saves a trade's confirmations and status
+	Update(ctx context.Context, trade *domain.Trade) error
+
+	// ListPendingByUser retrieves a user's unexpired pending trades, newest first
+	ListPendingByUser(ctx context.Context, userID uuid.UUID, now time.Time) ([]*domain.Trade, error)
+
+	// ExpirePending marks overdue pending trades as expired and returns them
+	ExpirePending(ctx context.Context, now time.Time) ([]*domain.Trade, error)
+}
+
+// CandyRepository defines methods for per-species candy balances
+type CandyRepository interface {
+	// Get returns a user's candy for a species (0 if they have none)
+	Get(ctx context.Context, userID uuid.UUID, speciesID int) (int, error)
+
+	// Adjust atomically adds delta to a balance (negative to spend).
+	// It returns ErrInsufficientCandy instead of going below zero.
+	Adjust(ctx context.Context, userID uuid.UUID, speciesID int, delta int) error
+
+	// ListByUser retrieves a user's non-empty balances by species
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.CandyBalance, error)
+}
+
+// PokemonAuditRepository defines methods for the Pokemon audit log
+type PokemonAuditRepository interface {
+	// Create appends an entry to the log
+	Create(ctx context.Context, entry *domain.PokemonAuditEntry) error
+
+	// ListByPokemon retrieves a Pokemon's history, newest first
+	ListByPokemon(ctx context.Context, pokemonID uuid.UUID, limit int) ([]*domain.PokemonAuditEntry, error)
+}
+
+// EvolutionRepository defines methods for species evolution chains
+type EvolutionRepository interface {
+	// ListFrom retrieves the evolutions of a species with their target species
+	ListFrom(ctx context.Context, speciesID int) ([]*domain.Evolution, error)
+}
+
+// LearnsetRepository defines methods for species learnsets and the moves Pokemon unlock
+type LearnsetRepository interface {
+	// ListBySpecies retrieves the moves a species can learn
+	ListBySpecies(ctx context.Context, speciesID int) ([]*domain.Move, error)
+
+	// Unlock unlocks a species' learnset for a Pokemon and returns the newly unlocked moves
+	Unlock(ctx context.Context, pokemonID uuid.UUID, speciesID int) ([]*domain.Move, error)
+
+	// ListUnlocked retrieves the moves a Pokemon has unlocked
+	ListUnlocked(ctx context.Context, pokemonID uuid.UUID) ([]*domain.Move, error)
+
+	// Add adds moves to a species' learnset, keeping the moves it already has
+	Add(ctx context.Context, speciesID int, moves []*domain.Move) error
+}
+
+// MoveRepository defines methods for move data access
+type MoveRepository interface {
+	// Upsert inserts a move or updates the one with its name, setting its ID
+	Upsert(ctx context.Context, move *domain.Move) error
+
+	// List retrieves every move
+	List(ctx context.Context) ([]*domain.Move, error)
+}
+
+// ItemRepository defines methods for users' item inventories
+type ItemRepository interface {
+	// Get returns how many of an item a user holds (0 if none)
+	Get(ctx context.Context, userID uuid.UUID, item string) (int, error)
+
+	// Adjust atomically adds delta to an item count (negative to use).
+	// It returns ErrInsufficientItems instead of going below zero.
+	Adjust(ctx context.Context, userID uuid.UUID, item string, delta int) error
+
+	// ListByUser retrieves the items a user holds
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.InventoryItem, error)
+}
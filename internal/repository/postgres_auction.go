@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrAuctionNotFound = errors.New("auction not found")
+	ErrBidConflict     = errors.New("auction was bid on at the same time")
+)
+
+// auctionSelect selects an auction with its listing, Pokemon and species, in scanAuction order
+const auctionSelect = `
+	SELECT a.listing_id, a.starting_price, a.min_increment, a.current_bid,
+		a.highest_bidder_id, a.bid_count, a.ends_at,` + listingColumns + `
+	FROM auctions a
+	JOIN market_listings ml ON a.listing_id = ml.id
+	JOIN user_pokemon up ON ml.user_pokemon_id = up.id
+	JOIN pokemon_species ps ON up.species_id = ps.id
+`
+
+// PostgresAuctionRepository implements AuctionRepository
+type PostgresAuctionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresAuctionRepository creates a new repository
+func NewPostgresAuctionRepository(pool *pgxpool.Pool) *PostgresAuctionRepository {
+	return &PostgresAuctionRepository{pool: pool}
+}
+
+// Create inserts the bidding details for an auction listing
+func (r *PostgresAuctionRepository) Create(ctx context.Context, auction *domain.Auction) error {
+	query := `
+		INSERT INTO auctions (listing_id, starting_price, min_increment, current_bid, highest_bidder_id, bid_count, ends_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		auction.ListingID,
+		auction.StartingPrice,
+		auction.MinIncrement,
+		auction.CurrentBid,
+		auction.HighestBidderID,
+		auction.BidCount,
+		auction.EndsAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create auction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByListingID retrieves an auction with its listing and Pokemon
+func (r *PostgresAuctionRepository) GetByListingID(ctx context.Context, listingID uuid.UUID) (*domain.Auction, error) {
+	query := auctionSelect + `WHERE a.listing_id = $1`
+
+	auction, err := scanAuction(conn(ctx, r.pool).QueryRow(ctx, query, listingID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAuctionNotFound
+		}
+		return nil, fmt.Errorf("failed to get auction: %w", err)
+	}
+
+	return auction, nil
+}
+
+// GetForUpdate retrieves an auction and locks its listing and auction rows
+// until the surrounding transaction ends
+func (r *PostgresAuctionRepository) GetForUpdate(ctx context.Context, listingID uuid.UUID) (*domain.Auction, error) {
+	query := auctionSelect + `WHERE a.listing_id = $1 FOR UPDATE OF a, ml`
+
+	auction, err := scanAuction(conn(ctx, r.pool).QueryRow(ctx, query, listingID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAuctionNotFound
+		}
+		return nil, fmt.Errorf("failed to get auction: %w", err)
+	}
+
+	return auction, nil
+}
+
+// PlaceBid saves the auction's new highest bid and records it.
+// The update only applies while the auction is active and still at
+// previousBid, so of two concurrent bids only one can win.
+func (r *PostgresAuctionRepository) PlaceBid(ctx context.Context, auction *domain.Auction, bid *domain.AuctionBid, previousBid int) error {
+	tx, err := begin(ctx, r.pool)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	update := `
+		UPDATE auctions a
+		SET current_bid = $2, highest_bidder_id = $3, bid_count = $4, ends_at = $5
+		FROM market_listings ml
+		WHERE a.listing_id = $1 AND ml.id = a.listing_id
+			AND ml.status = $6 AND a.current_bid = $7
+	`
+
+	result, err := tx.Exec(ctx, update,
+		auction.ListingID,
+		auction.CurrentBid,
+		auction.HighestBidderID,
+		auction.BidCount,
+		auction.EndsAt,
+		domain.ListingStatusActive,
+		previousBid,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update auction: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrBidConflict
+	}
+
+	insert := `
+		INSERT INTO auction_bids (id, listing_id, bidder_id, amount, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	if _, err := tx.Exec(ctx, insert, bid.ID, bid.ListingID, bid.BidderID, bid.Amount, bid.CreatedAt); err != nil {
+		return fmt.Errorf("failed to record bid: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListBids retrieves an auction's bids, newest first
+func (r *PostgresAuctionRepository) ListBids(ctx context.Context, listingID uuid.UUID) ([]*domain.AuctionBid, error) {
+	query := `
+		SELECT id, listing_id, bidder_id, amount, created_at
+		FROM auction_bids
+		WHERE listing_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, listingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bids: %w", err)
+	}
+	defer rows.Close()
+
+	var bids []*domain.AuctionBid
+	for rows.Next() {
+		bid := &domain.AuctionBid{}
+		if err := rows.Scan(&bid.ID, &bid.ListingID, &bid.BidderID, &bid.Amount, &bid.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan bid: %w", err)
+		}
+		bids = append(bids, bid)
+	}
+
+	return bids, nil
+}
+
+// ListActive retrieves active auctions, ending soonest first
+func (r *PostgresAuctionRepository) ListActive(ctx context.Context, limit, offset int) ([]*domain.Auction, error) {
+	if limit <= 0 {
+		limit = DefaultMarketSearchLimit
+	}
+
+	query := auctionSelect + `
+		WHERE ml.status = $1
+		ORDER BY a.ends_at ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	return r.queryAuctions(ctx, query, domain.ListingStatusActive, limit, offset)
+}
+
+// ListEnded retrieves active auctions whose end time has passed
+func (r *PostgresAuctionRepository) ListEnded(ctx context.Context, now time.Time) ([]*domain.Auction, error) {
+	query := auctionSelect + `
+		WHERE ml.status = $1 AND a.ends_at <= $2
+		ORDER BY a.ends_at ASC
+	`
+
+	return r.queryAuctions(ctx, query, domain.ListingStatusActive, now)
+}
+
+// queryAuctions runs a query selected with auctionSelect
+func (r *PostgresAuctionRepository) queryAuctions(ctx context.Context, query string, args ...any) ([]*domain.Auction, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list auctions: %w", err)
+	}
+	defer rows.Close()
+
+	var auctions []*domain.Auction
+	for rows.Next() {
+		auction, err := scanAuction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan auction: %w", err)
+		}
+		auctions = append(auctions, auction)
+	}
+
+	return auctions, nil
+}
+
+// scanAuction scans a row selected with auctionSelect
+func scanAuction(row pgx.Row) (*domain.Auction, error) {
+	auction := &domain.Auction{Listing: newScannedListing()}
+
+	dest := []any{
+		&auction.ListingID,
+		&auction.StartingPrice,
+		&auction.MinIncrement,
+		&auction.CurrentBid,
+		&auction.HighestBidderID,
+		&auction.BidCount,
+		&auction.EndsAt,
+	}
+
+	if err := row.Scan(append(dest, listingDest(auction.Listing)...)...); err != nil {
+		return nil, err
+	}
+
+	return auction, nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrBannerNotFound = errors.New("banner not found")
+)
+
+// bannerColumns selects a banner aliased as b, in bannerDest order. Its
+// pool comes back as an array and its featured species as JSON.
+const bannerColumns = `
+	b.id, b.name, b.description, b.starts_at, b.ends_at, b.rates, b.created_at,
+	COALESCE((SELECT array_agg(bp.species_id ORDER BY bp.species_id)
+		FROM banner_pool bp WHERE bp.banner_id = b.id), '{}'),
+	(SELECT COALESCE(jsonb_agg(jsonb_build_object(
+			'species_id', bf.species_id, 'name', ps.name, 'weight', bf.weight
+		) ORDER BY bf.weight DESC, bf.species_id), '[]'::jsonb)
+		FROM banner_featured bf JOIN pokemon_species ps ON ps.id = bf.species_id
+		WHERE bf.banner_id = b.id)
+`
+
+// PostgresBannerRepository implements BannerRepository
+type PostgresBannerRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresBannerRepository creates a new repository
+func NewPostgresBannerRepository(pool *pgxpool.Pool) *PostgresBannerRepository {
+	return &PostgresBannerRepository{pool: pool}
+}
+
+// Create inserts a banner with its species pool and featured species
+func (r *PostgresBannerRepository) Create(ctx context.Context, banner *domain.Banner) error {
+	db := conn(ctx, r.pool)
+
+	var rates any
+	if len(banner.Rates) > 0 {
+		rates = banner.Rates
+	}
+
+	_, err := db.Exec(ctx, `
+		INSERT INTO banners (id, name, description, starts_at, ends_at, rates, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, banner.ID, banner.Name, banner.Description, banner.StartsAt, banner.EndsAt, rates, banner.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create banner: %w", err)
+	}
+
+	for _, speciesID := range banner.SpeciesPool {
+		_, err := db.Exec(ctx, `
+			INSERT INTO banner_pool (banner_id, species_id) VALUES ($1, $2)
+		`, banner.ID, speciesID)
+		if err != nil {
+			return fmt.Errorf("failed to add species %d to banner pool: %w", speciesID, err)
+		}
+	}
+
+	for _, featured := range banner.Featured {
+		_, err := db.Exec(ctx, `
+			INSERT INTO banner_featured (banner_id, species_id, weight) VALUES ($1, $2, $3)
+		`, banner.ID, featured.SpeciesID, featured.Weight)
+		if err != nil {
+			return fmt.Errorf("failed to feature species %d on banner: %w", featured.SpeciesID, err)
+		}
+	}
+
+	return nil
+}
+
+// GetByID retrieves a banner
+func (r *PostgresBannerRepository) GetByID(ctx context.Context, id string) (*domain.Banner, error) {
+	query := `SELECT ` + bannerColumns + ` FROM banners b WHERE b.id = $1`
+
+	banner := &domain.Banner{}
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(bannerDest(banner)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBannerNotFound
+		}
+		return nil, fmt.Errorf("failed to get banner: %w", err)
+	}
+
+	return banner, nil
+}
+
+// ListActive retrieves the banners running at a time, ending soonest first
+func (r *PostgresBannerRepository) ListActive(ctx context.Context, at time.Time) ([]*domain.Banner, error) {
+	query := `
+		SELECT ` + bannerColumns + `
+		FROM banners b
+		WHERE b.starts_at <= $1 AND (b.ends_at IS NULL OR b.ends_at > $1)
+		ORDER BY b.ends_at ASC NULLS LAST, b.id
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list banners: %w", err)
+	}
+	defer rows.Close()
+
+	var banners []*domain.Banner
+	for rows.Next() {
+		banner := &domain.Banner{}
+		if err := rows.Scan(bannerDest(banner)...); err != nil {
+			return nil, fmt.Errorf("failed to scan banner: %w", err)
+		}
+		banners = append(banners, banner)
+	}
+
+	return banners, nil
+}
+
+// bannerDest returns the scan destinations for bannerColumns
+func bannerDest(banner *domain.Banner) []any {
+	return []any{
+		&banner.ID,
+		&banner.Name,
+		&banner.Description,
+		&banner.StartsAt,
+		&banner.EndsAt,
+		&banner.Rates,
+		&banner.CreatedAt,
+		&banner.SpeciesPool,
+		&banner.Featured,
+	}
+}
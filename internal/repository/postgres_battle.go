@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrBattleNotFound = errors.New("battle not found")
+
+// battleSelect selects a battle in scanBattle order
+const battleSelect = `
+	SELECT id, player1_id, player2_id, player1_pokemon_id, player2_pokemon_id,
+		wager_amount, format, status, winner_id, current_turn, created_at, started_at, completed_at
+	FROM battles
+`
+
+// PostgresBattleRepository implements BattleRepository.
+// Only the battle record is stored; live battle state stays in the service.
+type PostgresBattleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresBattleRepository creates a new repository
+func NewPostgresBattleRepository(pool *pgxpool.Pool) *PostgresBattleRepository {
+	return &PostgresBattleRepository{pool: pool}
+}
+
+// Create inserts a new battle
+func (r *PostgresBattleRepository) Create(ctx context.Context, battle *domain.Battle) error {
+	query := `
+		INSERT INTO battles (id, player1_id, player2_id, player1_pokemon_id, player2_pokemon_id,
+			wager_amount, format, status, winner_id, current_turn, created_at, started_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		battle.ID,
+		battle.Player1ID,
+		nullUUID(battle.Player2ID),
+		nullUUID(battle.Player1Pokemon),
+		nullUUID(battle.Player2Pokemon),
+		battle.WagerAmount,
+		battle.Format,
+		battle.Status,
+		battle.WinnerID,
+		battle.CurrentTurn,
+		battle.CreatedAt,
+		battle.StartedAt,
+		battle.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create battle: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a battle by ID
+func (r *PostgresBattleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Battle, error) {
+	battle, err := scanBattle(conn(ctx, r.pool).QueryRow(ctx, battleSelect+`WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBattleNotFound
+		}
+		return nil, fmt.Errorf("failed to get battle: %w", err)
+	}
+
+	return battle, nil
+}
+
+// Update saves a battle's players, selections, status and result
+func (r *PostgresBattleRepository) Update(ctx context.Context, battle *domain.Battle) error {
+	query := `
+		UPDATE battles
+		SET player2_id = $2, player1_pokemon_id = $3, player2_pokemon_id = $4, status = $5,
+			winner_id = $6, current_turn = $7, started_at = $8, completed_at = $9
+		WHERE id = $1
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
+		battle.ID,
+		nullUUID(battle.Player2ID),
+		nullUUID(battle.Player1Pokemon),
+		nullUUID(battle.Player2Pokemon),
+		battle.Status,
+		battle.WinnerID,
+		battle.CurrentTurn,
+		battle.StartedAt,
+		battle.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update battle: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrBattleNotFound
+	}
+
+	return nil
+}
+
+// ListActive retrieves all battles in progress
+func (r *PostgresBattleRepository) ListActive(ctx context.Context) ([]*domain.Battle, error) {
+	return r.list(ctx, battleSelect+`WHERE status = $1 ORDER BY created_at DESC`, domain.BattleStatusInProgress)
+}
+
+// ListByPlayer retrieves all battles for a player, newest first
+func (r *PostgresBattleRepository) ListByPlayer(ctx context.Context, playerID uuid.UUID) ([]*domain.Battle, error) {
+	return r.list(ctx, battleSelect+`WHERE player1_id = $1 OR player2_id = $1 ORDER BY created_at DESC`, playerID)
+}
+
+// CountWins returns how many battles a Pokemon has won for its side
+func (r *PostgresBattleRepository) CountWins(ctx context.Context, pokemonID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM battles
+		WHERE (player1_pokemon_id = $1 AND winner_id = player1_id)
+		   OR (player2_pokemon_id = $1 AND winner_id = player2_id)
+	`
+
+	var wins int
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, pokemonID).Scan(&wins); err != nil {
+		return 0, fmt.Errorf("failed to count battle wins: %w", err)
+	}
+
+	return wins, nil
+}
+
+// list runs a multi-row query selected with battleSelect
+func (r *PostgresBattleRepository) list(ctx context.Context, query string, args ...interface{}) ([]*domain.Battle, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list battles: %w", err)
+	}
+	defer rows.Close()
+
+	var battles []*domain.Battle
+	for rows.Next() {
+		battle, err := scanBattle(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan battle: %w", err)
+		}
+		battles = append(battles, battle)
+	}
+
+	return battles, nil
+}
+
+// Delete removes a battle
+func (r *PostgresBattleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM battles WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete battle: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrBattleNotFound
+	}
+
+	return nil
+}
+
+// scanBattle scans a row selected with battleSelect.
+// Players and Pokemon that are not set yet scan as uuid.Nil.
+func scanBattle(row pgx.Row) (*domain.Battle, error) {
+	battle := &domain.Battle{}
+	var player2ID, player1Pokemon, player2Pokemon *uuid.UUID
+
+	err := row.Scan(
+		&battle.ID,
+		&battle.Player1ID,
+		&player2ID,
+		&player1Pokemon,
+		&player2Pokemon,
+		&battle.WagerAmount,
+		&battle.Format,
+		&battle.Status,
+		&battle.WinnerID,
+		&battle.CurrentTurn,
+		&battle.CreatedAt,
+		&battle.StartedAt,
+		&battle.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if player2ID != nil {
+		battle.Player2ID = *player2ID
+	}
+	if player1Pokemon != nil {
+		battle.Player1Pokemon = *player1Pokemon
+	}
+	if player2Pokemon != nil {
+		battle.Player2Pokemon = *player2Pokemon
+	}
+
+	return battle, nil
+}
+
+// nullUUID stores uuid.Nil as NULL
+func nullUUID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrInsufficientCandy = errors.New("insufficient candy")
+
+// PostgresCandyRepository implements CandyRepository
+type PostgresCandyRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresCandyRepository creates a new repository
+func NewPostgresCandyRepository(pool *pgxpool.Pool) *PostgresCandyRepository {
+	return &PostgresCandyRepository{pool: pool}
+}
+
+// Get returns a user's candy for a species (0 if they have none)
+func (r *PostgresCandyRepository) Get(ctx context.Context, userID uuid.UUID, speciesID int) (int, error) {
+	query := `SELECT amount FROM user_candy WHERE user_id = $1 AND species_id = $2`
+
+	var amount int
+	err := conn(ctx, r.pool).QueryRow(ctx, query, userID, speciesID).Scan(&amount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get candy: %w", err)
+	}
+
+	return amount, nil
+}
+
+// Adjust atomically adds delta to a user's candy for a species (negative to spend).
+// It returns ErrInsufficientCandy instead of going below zero.
+func (r *PostgresCandyRepository) Adjust(ctx context.Context, userID uuid.UUID, speciesID int, delta int) error {
+	if delta >= 0 {
+		query := `
+			INSERT INTO user_candy (user_id, species_id, amount)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (user_id, species_id)
+			DO UPDATE SET amount = user_candy.amount + EXCLUDED.amount, updated_at = NOW()
+		`
+		if _, err := conn(ctx, r.pool).Exec(ctx, query, userID, speciesID, delta); err != nil {
+			return fmt.Errorf("failed to add candy: %w", err)
+		}
+		return nil
+	}
+
+	query := `
+		UPDATE user_candy
+		SET amount = amount + $3, updated_at = NOW()
+		WHERE user_id = $1 AND species_id = $2 AND amount + $3 >= 0
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query, userID, speciesID, delta)
+	if err != nil {
+		return fmt.Errorf("failed to spend candy: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrInsufficientCandy
+	}
+
+	return nil
+}
+
+// ListByUser retrieves a user's non-empty candy balances by species
+func (r *PostgresCandyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.CandyBalance, error) {
+	query := `
+		SELECT user_id, species_id, amount
+		FROM user_candy
+		WHERE user_id = $1 AND amount > 0
+		ORDER BY species_id
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list candy: %w", err)
+	}
+	defer rows.Close()
+
+	var balances []*domain.CandyBalance
+	for rows.Next() {
+		balance := &domain.CandyBalance{}
+		if err := rows.Scan(&balance.UserID, &balance.SpeciesID, &balance.Amount); err != nil {
+			return nil, fmt.Errorf("failed to scan candy: %w", err)
+		}
+		balances = append(balances, balance)
+	}
+
+	return balances, nil
+}
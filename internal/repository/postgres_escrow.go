@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrEscrowNotFound       = errors.New("escrow not found")
+	ErrEscrowAlreadySettled = errors.New("escrow already settled with a different outcome")
+	ErrInvalidEscrowWinner  = errors.New("winner is not a player in this escrow")
+)
+
+// PostgresEscrowRepository implements EscrowRepository
+type PostgresEscrowRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresEscrowRepository creates a new repository
+func NewPostgresEscrowRepository(pool *pgxpool.Pool) *PostgresEscrowRepository {
+	return &PostgresEscrowRepository{pool: pool}
+}
+
+// Lock deducts the stake from both players and records a held escrow.
+// Locking a battle that already has an escrow is a no-op.
+func (r *PostgresEscrowRepository) Lock(ctx context.Context, escrow *domain.BattleEscrow) error {
+	tx, err := begin(ctx, r.pool)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insert := `
+		INSERT INTO battle_escrows (battle_id, player1_id, player2_id, amount, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (battle_id) DO NOTHING
+	`
+
+	result, err := tx.Exec(ctx, insert,
+		escrow.BattleID,
+		escrow.Player1ID,
+		escrow.Player2ID,
+		escrow.Amount,
+		domain.EscrowStatusHeld,
+		escrow.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create escrow: %w", err)
+	}
+
+	// Escrow already exists, so the stakes were already taken
+	if result.RowsAffected() == 0 {
+		return nil
+	}
+
+	debit := `
+		UPDATE users
+		SET coins = coins - $2
+		WHERE id = $1 AND coins >= $2
+	`
+
+	for _, playerID := range []uuid.UUID{escrow.Player1ID, escrow.Player2ID} {
+		result, err := tx.Exec(ctx, debit, playerID, escrow.Amount)
+		if err != nil {
+			return fmt.Errorf("failed to deduct wager: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return ErrInsufficientCoins
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	escrow.Status = domain.EscrowStatusHeld
+	return nil
+}
+
+// GetByBattleID retrieves the escrow for a battle
+func (r *PostgresEscrowRepository) GetByBattleID(ctx context.Context, battleID uuid.UUID) (*domain.BattleEscrow, error) {
+	query := `
+		SELECT battle_id, player1_id, player2_id, amount, status, winner_id, created_at, settled_at
+		FROM battle_escrows
+		WHERE battle_id = $1
+	`
+
+	escrow, err := scanEscrow(conn(ctx, r.pool).QueryRow(ctx, query, battleID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEscrowNotFound
+		}
+		return nil, fmt.Errorf("failed to get escrow: %w", err)
+	}
+
+	return escrow, nil
+}
+
+// PayOut credits the whole pot to the winner
+func (r *PostgresEscrowRepository) PayOut(ctx context.Context, battleID, winnerID uuid.UUID) error {
+	return r.settle(ctx, battleID, domain.EscrowStatusPaidOut, &winnerID)
+}
+
+// Refund returns each player's stake
+func (r *PostgresEscrowRepository) Refund(ctx context.Context, battleID uuid.UUID) error {
+	return r.settle(ctx, battleID, domain.EscrowStatusRefunded, nil)
+}
+
+// ListHeld retrieves all escrows that have not been settled yet
+func (r *PostgresEscrowRepository) ListHeld(ctx context.Context) ([]*domain.BattleEscrow, error) {
+	query := `
+		SELECT battle_id, player1_id, player2_id, amount, status, winner_id, created_at, settled_at
+		FROM battle_escrows
+		WHERE status = $1
+		ORDER BY created_at
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, domain.EscrowStatusHeld)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list held escrows: %w", err)
+	}
+	defer rows.Close()
+
+	var escrows []*domain.BattleEscrow
+	for rows.Next() {
+		escrow, err := scanEscrow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan escrow: %w", err)
+		}
+		escrows = append(escrows, escrow)
+	}
+
+	return escrows, nil
+}
+
+// settle moves a held escrow to its final status and credits the coins in one transaction.
+// The escrow row is locked first so concurrent settlements are serialized.
+func (r *PostgresEscrowRepository) settle(ctx context.Context, battleID uuid.UUID, status domain.EscrowStatus, winnerID *uuid.UUID) error {
+	tx, err := begin(ctx, r.pool)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT battle_id, player1_id, player2_id, amount, status, winner_id, created_at, settled_at
+		FROM battle_escrows
+		WHERE battle_id = $1
+		FOR UPDATE
+	`
+
+	escrow, err := scanEscrow(tx.QueryRow(ctx, query, battleID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrEscrowNotFound
+		}
+		return fmt.Errorf("failed to get escrow: %w", err)
+	}
+
+	if escrow.IsSettled() {
+		if escrow.SettledAs(status, winnerID) {
+			return nil
+		}
+		return ErrEscrowAlreadySettled
+	}
+
+	credit := `
+		UPDATE users
+		SET coins = coins + $2
+		WHERE id = $1
+	`
+
+	switch status {
+	case domain.EscrowStatusPaidOut:
+		if !escrow.HasPlayer(*winnerID) {
+			return ErrInvalidEscrowWinner
+		}
+		if _, err := tx.Exec(ctx, credit, *winnerID, escrow.Pot()); err != nil {
+			return fmt.Errorf("failed to pay out escrow: %w", err)
+		}
+	case domain.EscrowStatusRefunded:
+		for _, playerID := range []uuid.UUID{escrow.Player1ID, escrow.Player2ID} {
+			if _, err := tx.Exec(ctx, credit, playerID, escrow.Amount); err != nil {
+				return fmt.Errorf("failed to refund escrow: %w", err)
+			}
+		}
+	}
+
+	update := `
+		UPDATE battle_escrows
+		SET status = $2, winner_id = $3, settled_at = CURRENT_TIMESTAMP
+		WHERE battle_id = $1
+	`
+
+	if _, err := tx.Exec(ctx, update, battleID, status, winnerID); err != nil {
+		return fmt.Errorf("failed to update escrow: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// scanEscrow scans a single escrow row
+func scanEscrow(row pgx.Row) (*domain.BattleEscrow, error) {
+	escrow := &domain.BattleEscrow{}
+	err := row.Scan(
+		&escrow.BattleID,
+		&escrow.Player1ID,
+		&escrow.Player2ID,
+		&escrow.Amount,
+		&escrow.Status,
+		&escrow.WinnerID,
+		&escrow.CreatedAt,
+		&escrow.SettledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return escrow, nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresEvolutionRepository implements EvolutionRepository
+type PostgresEvolutionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresEvolutionRepository creates a new repository
+func NewPostgresEvolutionRepository(pool *pgxpool.Pool) *PostgresEvolutionRepository {
+	return &PostgresEvolutionRepository{pool: pool}
+}
+
+// ListFrom retrieves the evolutions of a species with their target species
+func (r *PostgresEvolutionRepository) ListFrom(ctx context.Context, speciesID int) ([]*domain.Evolution, error) {
+	query := `
+		SELECT
+			e.from_species_id, e.to_species_id, e.candy_cost, e.coin_cost,
+			COALESCE(e.item, ''), e.battle_wins,` + speciesColumns + `
+		FROM species_evolutions e
+		JOIN pokemon_species ps ON e.to_species_id = ps.id
+		WHERE e.from_species_id = $1
+		ORDER BY e.to_species_id
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, speciesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list evolutions: %w", err)
+	}
+	defer rows.Close()
+
+	var evolutions []*domain.Evolution
+	for rows.Next() {
+		evolution := &domain.Evolution{ToSpecies: &domain.PokemonSpecies{}}
+		dest := append([]any{
+			&evolution.FromSpeciesID,
+			&evolution.ToSpeciesID,
+			&evolution.CandyCost,
+			&evolution.CoinCost,
+			&evolution.Item,
+			&evolution.BattleWins,
+		}, speciesDest(evolution.ToSpecies)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan evolution: %w", err)
+		}
+		evolutions = append(evolutions, evolution)
+	}
+
+	return evolutions, nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrFairSeedNotFound = errors.New("fair seed not found")
+)
+
+// fairSeedColumns selects a seed pair in fairSeedDest order
+const fairSeedColumns = `
+	id, user_id, server_seed, server_seed_hash, client_seed, nonce, created_at, revealed_at
+`
+
+// PostgresFairSeedRepository implements FairSeedRepository
+type PostgresFairSeedRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresFairSeedRepository creates a new repository
+func NewPostgresFairSeedRepository(pool *pgxpool.Pool) *PostgresFairSeedRepository {
+	return &PostgresFairSeedRepository{pool: pool}
+}
+
+// Create stores a seed pair as a user's active one. It does nothing if they
+// already have an active seed pair, so two first pulls racing each other
+// end up on the same one.
+func (r *PostgresFairSeedRepository) Create(ctx context.Context, seed *domain.FairSeed) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO fair_seeds (id, user_id, server_seed, server_seed_hash, client_seed, nonce, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id) WHERE revealed_at IS NULL DO NOTHING
+	`, seed.ID, seed.UserID, seed.ServerSeed, seed.ServerSeedHash, seed.ClientSeed, seed.Nonce, seed.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create fair seed: %w", err)
+	}
+
+	return nil
+}
+
+// GetActive retrieves a user's active, unrevealed seed pair
+func (r *PostgresFairSeedRepository) GetActive(ctx context.Context, userID uuid.UUID) (*domain.FairSeed, error) {
+	return r.getActive(ctx, userID, "")
+}
+
+// GetActiveForUpdate retrieves a user's active seed pair and locks it until
+// the transaction ends
+func (r *PostgresFairSeedRepository) GetActiveForUpdate(ctx context.Context, userID uuid.UUID) (*domain.FairSeed, error) {
+	return r.getActive(ctx, userID, "FOR UPDATE")
+}
+
+func (r *PostgresFairSeedRepository) getActive(ctx context.Context, userID uuid.UUID, lock string) (*domain.FairSeed, error) {
+	query := `
+		SELECT ` + fairSeedColumns + `
+		FROM fair_seeds
+		WHERE user_id = $1 AND revealed_at IS NULL
+		` + lock
+
+	seed := &domain.FairSeed{}
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, userID).Scan(fairSeedDest(seed)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrFairSeedNotFound
+		}
+		return nil, fmt.Errorf("failed to get fair seed: %w", err)
+	}
+
+	return seed, nil
+}
+
+// Update stores a seed pair's nonce and when it was revealed
+func (r *PostgresFairSeedRepository) Update(ctx context.Context, seed *domain.FairSeed) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `
+		UPDATE fair_seeds SET nonce = $2, revealed_at = $3 WHERE id = $1
+	`, seed.ID, seed.Nonce, seed.RevealedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update fair seed: %w", err)
+	}
+
+	return nil
+}
+
+// ListRevealed retrieves a user's revealed seed pairs, newest first
+func (r *PostgresFairSeedRepository) ListRevealed(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.FairSeed, error) {
+	query := `
+		SELECT ` + fairSeedColumns + `
+		FROM fair_seeds
+		WHERE user_id = $1 AND revealed_at IS NOT NULL
+		ORDER BY revealed_at DESC
+		LIMIT $2
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fair seeds: %w", err)
+	}
+	defer rows.Close()
+
+	var seeds []*domain.FairSeed
+	for rows.Next() {
+		seed := &domain.FairSeed{}
+		if err := rows.Scan(fairSeedDest(seed)...); err != nil {
+			return nil, fmt.Errorf("failed to scan fair seed: %w", err)
+		}
+		seeds = append(seeds, seed)
+	}
+
+	return seeds, nil
+}
+
+// fairSeedDest returns the scan destinations for fairSeedColumns
+func fairSeedDest(seed *domain.FairSeed) []any {
+	return []any{
+		&seed.ID,
+		&seed.UserID,
+		&seed.ServerSeed,
+		&seed.ServerSeedHash,
+		&seed.ClientSeed,
+		&seed.Nonce,
+		&seed.CreatedAt,
+		&seed.RevealedAt,
+	}
+}
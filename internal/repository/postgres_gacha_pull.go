@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DefaultPullHistoryLimit is the page size used when a history request
+// doesn't set one
+const DefaultPullHistoryLimit = 25
+
+// gachaPullColumns selects a pull aliased as p, joined to its species as
+// ps, in gachaPullDest order
+const gachaPullColumns = `
+	p.id, p.roll_id, p.user_id, p.pokemon_id, p.banner_id, p.roll_type, p.rarity,
+	p.species_id, ps.name, p.pity, p.odds, p.nonce, p.created_at
+`
+
+// PostgresGachaPullRepository implements GachaPullRepository
+type PostgresGachaPullRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresGachaPullRepository creates a new repository
+func NewPostgresGachaPullRepository(pool *pgxpool.Pool) *PostgresGachaPullRepository {
+	return &PostgresGachaPullRepository{pool: pool}
+}
+
+// Create records a pull
+func (r *PostgresGachaPullRepository) Create(ctx context.Context, pull *domain.GachaPull) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO gacha_pulls (id, roll_id, user_id, pokemon_id, banner_id, roll_type, rarity, species_id, pity, odds, nonce, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`, pull.ID, pull.RollID, pull.UserID, pull.PokemonID, pull.BannerID, pull.RollType, pull.Rarity,
+		pull.SpeciesID, pull.Pity, pull.Odds, pull.Nonce, pull.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record pull: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser retrieves a user's pulls matching the filter, newest first
+func (r *PostgresGachaPullRepository) ListByUser(ctx context.Context, userID uuid.UUID, filter domain.PullHistoryFilter) ([]*domain.GachaPull, error) {
+	conditions := []string{"p.user_id = $1"}
+	args := []any{userID}
+
+	add := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.BannerID != nil {
+		add("p.banner_id = $%d", *filter.BannerID)
+	}
+	if filter.RollType != "" {
+		add("p.roll_type = $%d", filter.RollType)
+	}
+	if filter.Rarity != "" {
+		add("p.rarity = $%d", filter.Rarity)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultPullHistoryLimit
+	}
+	args = append(args, limit, filter.Offset)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM gacha_pulls p
+		JOIN pokemon_species ps ON ps.id = p.species_id
+		WHERE %s
+		ORDER BY p.created_at DESC, p.id
+		LIMIT $%d OFFSET $%d
+	`, gachaPullColumns, strings.Join(conditions, " AND "), len(args)-1, len(args))
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pulls: %w", err)
+	}
+	defer rows.Close()
+
+	var pulls []*domain.GachaPull
+	for rows.Next() {
+		pull := &domain.GachaPull{}
+		if err := rows.Scan(gachaPullDest(pull)...); err != nil {
+			return nil, fmt.Errorf("failed to scan pull: %w", err)
+		}
+		pulls = append(pulls, pull)
+	}
+
+	return pulls, nil
+}
+
+// Tally counts the pulls matching the filter and, for each rarity, the
+// pulls that gave it against the odds they were made at. Every rarity is
+// tallied, including ones with no pulls.
+func (r *PostgresGachaPullRepository) Tally(ctx context.Context, filter domain.PullStatsFilter) (int, []domain.RarityTally, error) {
+	rarities := make([]string, len(domain.Rarities))
+	for i, rarity := range domain.Rarities {
+		rarities[i] = string(rarity)
+	}
+
+	conditions := []string{"TRUE"}
+	args := []any{rarities}
+
+	add := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.BannerID != nil {
+		add("p.banner_id = $%d", *filter.BannerID)
+	}
+	if filter.RollType != "" {
+		add("p.roll_type = $%d", filter.RollType)
+	}
+	if !filter.Since.IsZero() {
+		add("p.created_at >= $%d", filter.Since)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT r.rarity,
+			COUNT(p.id) FILTER (WHERE p.rarity = r.rarity),
+			COALESCE(SUM(COALESCE((p.odds->>r.rarity)::float8, 0)), 0),
+			COALESCE(SUM(COALESCE((p.odds->>r.rarity)::float8, 0) * (1 - COALESCE((p.odds->>r.rarity)::float8, 0))), 0),
+			COUNT(p.id)
+		FROM unnest($1::text[]) WITH ORDINALITY AS r(rarity, position)
+		LEFT JOIN gacha_pulls p ON %s
+		GROUP BY r.rarity, r.position
+		ORDER BY r.position
+	`, strings.Join(conditions, " AND "))
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to tally pulls: %w", err)
+	}
+	defer rows.Close()
+
+	total := 0
+	var tallies []domain.RarityTally
+	for rows.Next() {
+		var tally domain.RarityTally
+		if err := rows.Scan(&tally.Rarity, &tally.Pulls, &tally.Expected, &tally.Variance, &total); err != nil {
+			return 0, nil, fmt.Errorf("failed to scan pull tally: %w", err)
+		}
+		tallies = append(tallies, tally)
+	}
+
+	return total, tallies, nil
+}
+
+// gachaPullDest returns the scan destinations for gachaPullColumns
+func gachaPullDest(pull *domain.GachaPull) []any {
+	return []any{
+		&pull.ID,
+		&pull.RollID,
+		&pull.UserID,
+		&pull.PokemonID,
+		&pull.BannerID,
+		&pull.RollType,
+		&pull.Rarity,
+		&pull.SpeciesID,
+		&pull.Species,
+		&pull.Pity,
+		&pull.Odds,
+		&pull.Nonce,
+		&pull.CreatedAt,
+	}
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrInsufficientItems = errors.New("insufficient items")
+
+// PostgresItemRepository implements ItemRepository
+type PostgresItemRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresItemRepository creates a new repository
+func NewPostgresItemRepository(pool *pgxpool.Pool) *PostgresItemRepository {
+	return &PostgresItemRepository{pool: pool}
+}
+
+// Get returns how many of an item a user holds (0 if none)
+func (r *PostgresItemRepository) Get(ctx context.Context, userID uuid.UUID, item string) (int, error) {
+	query := `SELECT quantity FROM user_items WHERE user_id = $1 AND item = $2`
+
+	var quantity int
+	err := conn(ctx, r.pool).QueryRow(ctx, query, userID, item).Scan(&quantity)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get item: %w", err)
+	}
+
+	return quantity, nil
+}
+
+// Adjust atomically adds delta to a user's item count (negative to use).
+// It returns ErrInsufficientItems instead of going below zero.
+func (r *PostgresItemRepository) Adjust(ctx context.Context, userID uuid.UUID, item string, delta int) error {
+	if delta >= 0 {
+		query := `
+			INSERT INTO user_items (user_id, item, quantity)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (user_id, item)
+			DO UPDATE SET quantity = user_items.quantity + EXCLUDED.quantity, updated_at = NOW()
+		`
+		if _, err := conn(ctx, r.pool).Exec(ctx, query, userID, item, delta); err != nil {
+			return fmt.Errorf("failed to add item: %w", err)
+		}
+		return nil
+	}
+
+	query := `
+		UPDATE user_items
+		SET quantity = quantity + $3, updated_at = NOW()
+		WHERE user_id = $1 AND item = $2 AND quantity + $3 >= 0
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query, userID, item, delta)
+	if err != nil {
+		return fmt.Errorf("failed to use item: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrInsufficientItems
+	}
+
+	return nil
+}
+
+// ListByUser retrieves the items a user holds
+func (r *PostgresItemRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.InventoryItem, error) {
+	query := `
+		SELECT user_id, item, quantity
+		FROM user_items
+		WHERE user_id = $1 AND quantity > 0
+		ORDER BY item
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*domain.InventoryItem
+	for rows.Next() {
+		item := &domain.InventoryItem{}
+		if err := rows.Scan(&item.UserID, &item.Item, &item.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresLearnsetRepository implements LearnsetRepository
+type PostgresLearnsetRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresLearnsetRepository creates a new repository
+func NewPostgresLearnsetRepository(pool *pgxpool.Pool) *PostgresLearnsetRepository {
+	return &PostgresLearnsetRepository{pool: pool}
+}
+
+// ListBySpecies retrieves the moves a species can learn
+func (r *PostgresLearnsetRepository) ListBySpecies(ctx context.Context, speciesID int) ([]*domain.Move, error) {
+	query := `
+		SELECT m.id, m.name, m.type, m.category, COALESCE(m.power, 0), COALESCE(m.accuracy, 0),
+			m.pp, COALESCE(m.priority, 0), COALESCE(m.description, '')
+		FROM species_learnsets l
+		JOIN moves m ON l.move_id = m.id
+		WHERE l.species_id = $1
+		ORDER BY m.name
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, speciesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list learnset: %w", err)
+	}
+
+	return scanMoves(rows)
+}
+
+// Unlock unlocks a species' learnset for a Pokemon and returns the moves
+// that were not unlocked before
+func (r *PostgresLearnsetRepository) Unlock(ctx context.Context, pokemonID uuid.UUID, speciesID int) ([]*domain.Move, error) {
+	query := `
+		WITH unlocked AS (
+			INSERT INTO user_pokemon_unlocked_moves (user_pokemon_id, move_id)
+			SELECT $1, move_id FROM species_learnsets WHERE species_id = $2
+			ON CONFLICT DO NOTHING
+			RETURNING move_id
+		)
+		SELECT m.id, m.name, m.type, m.category, COALESCE(m.power, 0), COALESCE(m.accuracy, 0),
+			m.pp, COALESCE(m.priority, 0), COALESCE(m.description, '')
+		FROM unlocked u
+		JOIN moves m ON u.move_id = m.id
+		ORDER BY m.name
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, pokemonID, speciesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlock moves: %w", err)
+	}
+
+	return scanMoves(rows)
+}
+
+// ListUnlocked retrieves the moves a Pokemon has unlocked
+func (r *PostgresLearnsetRepository) ListUnlocked(ctx context.Context, pokemonID uuid.UUID) ([]*domain.Move, error) {
+	query := `
+		SELECT m.id, m.name, m.type, m.category, COALESCE(m.power, 0), COALESCE(m.accuracy, 0),
+			m.pp, COALESCE(m.priority, 0), COALESCE(m.description, '')
+		FROM user_pokemon_unlocked_moves u
+		JOIN moves m ON u.move_id = m.id
+		WHERE u.user_pokemon_id = $1
+		ORDER BY m.name
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, pokemonID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unlocked moves: %w", err)
+	}
+
+	return scanMoves(rows)
+}
+
+// Add adds moves to a species' learnset, keeping the moves it already has
+func (r *PostgresLearnsetRepository) Add(ctx context.Context, speciesID int, moves []*domain.Move) error {
+	moveIDs := make([]int, len(moves))
+	for i, move := range moves {
+		moveIDs[i] = move.ID
+	}
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO species_learnsets (species_id, move_id)
+		SELECT DISTINCT $1::int, unnest($2::int[])
+		ON CONFLICT DO NOTHING
+	`, speciesID, moveIDs)
+	if err != nil {
+		return fmt.Errorf("failed to add learnset moves: %w", err)
+	}
+
+	return nil
+}
+
+// scanMoves scans the basic properties of each move and closes rows
+func scanMoves(rows pgx.Rows) ([]*domain.Move, error) {
+	defer rows.Close()
+
+	var moves []*domain.Move
+	for rows.Next() {
+		move := &domain.Move{}
+		err := rows.Scan(
+			&move.ID,
+			&move.Name,
+			&move.Type,
+			&move.Category,
+			&move.Power,
+			&move.Accuracy,
+			&move.PP,
+			&move.Priority,
+			&move.Description,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan move: %w", err)
+		}
+		moves = append(moves, move)
+	}
+
+	return moves, nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresLoginStreakRepository implements LoginStreakRepository
+type PostgresLoginStreakRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresLoginStreakRepository creates a new repository
+func NewPostgresLoginStreakRepository(pool *pgxpool.Pool) *PostgresLoginStreakRepository {
+	return &PostgresLoginStreakRepository{pool: pool}
+}
+
+// Get retrieves a user's streak, with no check-ins if they never checked in
+func (r *PostgresLoginStreakRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.LoginStreak, error) {
+	return r.get(ctx, userID, "")
+}
+
+// GetForUpdate retrieves a user's streak and locks it until the transaction
+// ends. The row is created first so that a player's first check-in locks it
+// too.
+func (r *PostgresLoginStreakRepository) GetForUpdate(ctx context.Context, userID uuid.UUID) (*domain.LoginStreak, error) {
+	_, err := conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO login_streaks (user_id)
+		VALUES ($1)
+		ON CONFLICT (user_id) DO NOTHING
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create login streak: %w", err)
+	}
+
+	return r.get(ctx, userID, "FOR UPDATE")
+}
+
+func (r *PostgresLoginStreakRepository) get(ctx context.Context, userID uuid.UUID, lock string) (*domain.LoginStreak, error) {
+	query := `
+		SELECT user_id, current_streak, longest_streak, total_check_ins, last_check_in, updated_at
+		FROM login_streaks
+		WHERE user_id = $1
+		` + lock
+
+	streak := &domain.LoginStreak{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, userID).Scan(
+		&streak.UserID,
+		&streak.Current,
+		&streak.Longest,
+		&streak.TotalCheckIns,
+		&streak.LastCheckIn,
+		&streak.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.NewLoginStreak(userID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login streak: %w", err)
+	}
+
+	return streak, nil
+}
+
+// Save stores a user's streak
+func (r *PostgresLoginStreakRepository) Save(ctx context.Context, streak *domain.LoginStreak) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO login_streaks (user_id, current_streak, longest_streak, total_check_ins, last_check_in, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE SET
+			current_streak = EXCLUDED.current_streak,
+			longest_streak = EXCLUDED.longest_streak,
+			total_check_ins = EXCLUDED.total_check_ins,
+			last_check_in = EXCLUDED.last_check_in,
+			updated_at = EXCLUDED.updated_at
+	`, streak.UserID, streak.Current, streak.Longest, streak.TotalCheckIns, streak.LastCheckIn, streak.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save login streak: %w", err)
+	}
+
+	return nil
+}
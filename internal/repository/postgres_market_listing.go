@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrListingNotFound  = errors.New("listing not found")
+	ErrListingNotActive = errors.New("listing is no longer active")
+	ErrAlreadyListed    = errors.New("pokemon is already listed")
+)
+
+// DefaultMarketSearchLimit is the page size used when a search doesn't set one
+const DefaultMarketSearchLimit = 25
+
+// listingColumns selects a listing with its Pokemon and species, in scanListing order
+const listingColumns = `
+	ml.id, ml.seller_id, ml.user_pokemon_id, ml.price, ml.listing_type, ml.status, ml.listed_at,` + pokemonColumns
+
+// PostgresMarketListingRepository implements MarketListingRepository
+type PostgresMarketListingRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresMarketListingRepository creates a new repository
+func NewPostgresMarketListingRepository(pool *pgxpool.Pool) *PostgresMarketListingRepository {
+	return &PostgresMarketListingRepository{pool: pool}
+}
+
+// Create inserts a new listing. A Pokemon can only have one active listing.
+func (r *PostgresMarketListingRepository) Create(ctx context.Context, listing *domain.MarketListing) error {
+	query := `
+		INSERT INTO market_listings (id, seller_id, user_pokemon_id, price, listing_type, status, listed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		listing.ID,
+		listing.SellerID,
+		listing.UserPokemonID,
+		listing.Price,
+		listing.Type,
+		listing.Status,
+		listing.ListedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "idx_market_listings_active_pokemon" {
+			return ErrAlreadyListed
+		}
+		return fmt.Errorf("failed to create listing: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a listing with its Pokemon
+func (r *PostgresMarketListingRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.MarketListing, error) {
+	query := `
+		SELECT ` + listingColumns + `
+		FROM market_listings ml
+		JOIN user_pokemon up ON ml.user_pokemon_id = up.id
+		JOIN pokemon_species ps ON up.species_id = ps.id
+		WHERE ml.id = $1
+	`
+
+	listing, err := scanListing(conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrListingNotFound
+		}
+		return nil, fmt.Errorf("failed to get listing: %w", err)
+	}
+
+	return listing, nil
+}
+
+// GetActiveByPokemonID retrieves the active listing for a Pokemon, if any
+func (r *PostgresMarketListingRepository) GetActiveByPokemonID(ctx context.Context, pokemonID uuid.UUID) (*domain.MarketListing, error) {
+	query := `
+		SELECT ` + listingColumns + `
+		FROM market_listings ml
+		JOIN user_pokemon up ON ml.user_pokemon_id = up.id
+		JOIN pokemon_species ps ON up.species_id = ps.id
+		WHERE ml.user_pokemon_id = $1 AND ml.status = $2
+	`
+
+	listing, err := scanListing(conn(ctx, r.pool).QueryRow(ctx, query, pokemonID, domain.ListingStatusActive))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrListingNotFound
+		}
+		return nil, fmt.Errorf("failed to get listing: %w", err)
+	}
+
+	return listing, nil
+}
+
+// UpdateStatus moves an active listing to a final status.
+// The status check is part of the update, so only one buyer or cancel can win.
+func (r *PostgresMarketListingRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.ListingStatus) error {
+	query := `
+		UPDATE market_listings
+		SET status = $2
+		WHERE id = $1 AND status = $3
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query, id, status, domain.ListingStatusActive)
+	if err != nil {
+		return fmt.Errorf("failed to update listing: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrListingNotActive
+	}
+
+	return nil
+}
+
+// Search retrieves active fixed-price listings matching the filter, cheapest first
+func (r *PostgresMarketListingRepository) Search(ctx context.Context, filter domain.MarketSearchFilter) ([]*domain.MarketListing, error) {
+	conditions := []string{"ml.status = $1", "ml.listing_type = $2"}
+	args := []any{domain.ListingStatusActive, domain.ListingTypeFixed}
+
+	add := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.SellerID != uuid.Nil {
+		add("ml.seller_id = $%d", filter.SellerID)
+	}
+	if filter.SpeciesID != 0 {
+		add("up.species_id = $%d", filter.SpeciesID)
+	}
+	if filter.SpeciesName != "" {
+		add("LOWER(ps.name) = LOWER($%d)", filter.SpeciesName)
+	}
+	if filter.Rarity != "" {
+		add("ps.rarity = $%d", filter.Rarity)
+	}
+	if filter.MinIVPercent > 0 {
+		add("(up.iv_hp + up.iv_attack + up.iv_defense + up.iv_sp_attack + up.iv_sp_defense + up.iv_speed) * 100.0 / 186.0 >= $%d", filter.MinIVPercent)
+	}
+	if filter.MinPrice > 0 {
+		add("ml.price >= $%d", filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 {
+		add("ml.price <= $%d", filter.MaxPrice)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultMarketSearchLimit
+	}
+	args = append(args, limit, filter.Offset)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM market_listings ml
+		JOIN user_pokemon up ON ml.user_pokemon_id = up.id
+		JOIN pokemon_species ps ON up.species_id = ps.id
+		WHERE %s
+		ORDER BY ml.price ASC, ml.listed_at ASC
+		LIMIT $%d OFFSET $%d
+	`, listingColumns, strings.Join(conditions, " AND "), len(args)-1, len(args))
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search listings: %w", err)
+	}
+	defer rows.Close()
+
+	var listings []*domain.MarketListing
+	for rows.Next() {
+		listing, err := scanListing(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan listing: %w", err)
+		}
+		listings = append(listings, listing)
+	}
+
+	return listings, nil
+}
+
+// scanListing scans a row selected with listingColumns
+func scanListing(row pgx.Row) (*domain.MarketListing, error) {
+	listing := newScannedListing()
+	if err := row.Scan(listingDest(listing)...); err != nil {
+		return nil, err
+	}
+	return listing, nil
+}
+
+// newScannedListing allocates a listing with room for its Pokemon and species
+func newScannedListing() *domain.MarketListing {
+	return &domain.MarketListing{
+		Pokemon: &domain.UserPokemon{
+			Species: &domain.PokemonSpecies{},
+		},
+	}
+}
+
+// listingDest returns the scan destinations for listingColumns
+func listingDest(listing *domain.MarketListing) []any {
+	return append([]any{
+		&listing.ID,
+		&listing.SellerID,
+		&listing.UserPokemonID,
+		&listing.Price,
+		&listing.Type,
+		&listing.Status,
+		&listing.ListedAt,
+	}, pokemonDest(listing.Pokemon)...)
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresMarketTransactionRepository implements MarketTransactionRepository
+type PostgresMarketTransactionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresMarketTransactionRepository creates a new repository
+func NewPostgresMarketTransactionRepository(pool *pgxpool.Pool) *PostgresMarketTransactionRepository {
+	return &PostgresMarketTransactionRepository{pool: pool}
+}
+
+// Create records a completed sale
+func (r *PostgresMarketTransactionRepository) Create(ctx context.Context, transaction *domain.MarketTransaction) error {
+	query := `
+		INSERT INTO market_transactions (id, listing_id, buyer_id, seller_id, price, fee, species_id, iv_percentage, variant_factor, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, 0), $8, GREATEST($9, 1), $10)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		transaction.ID,
+		transaction.ListingID,
+		transaction.BuyerID,
+		transaction.SellerID,
+		transaction.Price,
+		transaction.Fee,
+		transaction.SpeciesID,
+		transaction.IVPercentage,
+		transaction.VariantFactor,
+		transaction.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create market transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser retrieves a user's purchases and sales, newest first.
+// Sales of Pokemon that were since released have a zero ListingID.
+func (r *PostgresMarketTransactionRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.MarketTransaction, error) {
+	query := `
+		SELECT id, COALESCE(listing_id, '00000000-0000-0000-0000-000000000000'), buyer_id, seller_id, price, fee,
+			COALESCE(species_id, 0), iv_percentage::float8, variant_factor::float8, completed_at
+		FROM market_transactions
+		WHERE buyer_id = $1 OR seller_id = $1
+		ORDER BY completed_at DESC
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list market transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var transactions []*domain.MarketTransaction
+	for rows.Next() {
+		transaction := &domain.MarketTransaction{}
+		err := rows.Scan(
+			&transaction.ID,
+			&transaction.ListingID,
+			&transaction.BuyerID,
+			&transaction.SellerID,
+			&transaction.Price,
+			&transaction.Fee,
+			&transaction.SpeciesID,
+			&transaction.IVPercentage,
+			&transaction.VariantFactor,
+			&transaction.CompletedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan market transaction: %w", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, nil
+}
+
+// ListSales retrieves sales of the given species since a time, newest first
+func (r *PostgresMarketTransactionRepository) ListSales(ctx context.Context, speciesIDs []int, since time.Time) ([]*domain.MarketSale, error) {
+	query := `
+		SELECT id, species_id, price, iv_percentage::float8, variant_factor::float8, completed_at
+		FROM market_transactions
+		WHERE species_id = ANY($1) AND completed_at >= $2
+		ORDER BY completed_at DESC
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, speciesIDs, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sales: %w", err)
+	}
+	defer rows.Close()
+
+	var sales []*domain.MarketSale
+	for rows.Next() {
+		sale := &domain.MarketSale{}
+		if err := rows.Scan(&sale.TransactionID, &sale.SpeciesID, &sale.Price, &sale.IVPercentage, &sale.VariantFactor, &sale.SoldAt); err != nil {
+			return nil, fmt.Errorf("failed to scan sale: %w", err)
+		}
+		sales = append(sales, sale)
+	}
+
+	return sales, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresMoveRepository implements MoveRepository
+type PostgresMoveRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresMoveRepository creates a new repository
+func NewPostgresMoveRepository(pool *pgxpool.Pool) *PostgresMoveRepository {
+	return &PostgresMoveRepository{pool: pool}
+}
+
+// Upsert inserts a move or updates the one with its name, setting its ID.
+// Status moves are stored without power, like the seeded moves.
+func (r *PostgresMoveRepository) Upsert(ctx context.Context, move *domain.Move) error {
+	query := `
+		INSERT INTO moves (
+			name, type, category, power, accuracy, pp, priority, crit_ratio, target, description,
+			secondary_effect, multi_hit, recoil_percent, drain_percent, heal_percent,
+			stat_changes, status_inflict
+		) VALUES ($1, $2, $3, NULLIF($4, 0), $5, $6, $7, $8, $9, NULLIF($10, ''),
+			$11, $12, $13, $14, $15, $16, $17)
+		ON CONFLICT (name) DO UPDATE SET
+			type = EXCLUDED.type,
+			category = EXCLUDED.category,
+			power = EXCLUDED.power,
+			accuracy = EXCLUDED.accuracy,
+			pp = EXCLUDED.pp,
+			priority = EXCLUDED.priority,
+			crit_ratio = EXCLUDED.crit_ratio,
+			target = EXCLUDED.target,
+			description = COALESCE(EXCLUDED.description, moves.description),
+			secondary_effect = EXCLUDED.secondary_effect,
+			multi_hit = EXCLUDED.multi_hit,
+			recoil_percent = EXCLUDED.recoil_percent,
+			drain_percent = EXCLUDED.drain_percent,
+			heal_percent = EXCLUDED.heal_percent,
+			stat_changes = EXCLUDED.stat_changes,
+			status_inflict = EXCLUDED.status_inflict
+		RETURNING id
+	`
+
+	target := move.Target
+	if target == "" {
+		target = domain.TargetOpponent
+	}
+	statChanges := move.StatChanges
+	if statChanges == nil {
+		statChanges = []domain.StatChange{}
+	}
+
+	err := conn(ctx, r.pool).QueryRow(ctx, query,
+		move.Name,
+		move.Type,
+		move.Category,
+		move.Power,
+		move.Accuracy,
+		move.PP,
+		move.Priority,
+		move.CritRatio,
+		target,
+		move.Description,
+		move.SecondaryEffect,
+		move.MultiHit,
+		move.RecoilPercent,
+		move.DrainPercent,
+		move.HealPercent,
+		statChanges,
+		move.StatusInflict,
+	).Scan(&move.ID)
+	if err != nil {
+		return fmt.Errorf("failed to upsert move %s: %w", move.Name, err)
+	}
+
+	return nil
+}
+
+// List retrieves every move
+func (r *PostgresMoveRepository) List(ctx context.Context) ([]*domain.Move, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `
+		SELECT m.id, m.name, m.type, m.category, COALESCE(m.power, 0), COALESCE(m.accuracy, 0),
+			m.pp, COALESCE(m.priority, 0), COALESCE(m.description, '')
+		FROM moves m
+		ORDER BY m.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moves: %w", err)
+	}
+
+	return scanMoves(rows)
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// PostgresNotificationRepository implements NotificationRepository
+type PostgresNotificationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresNotificationRepository creates a new repository
+func NewPostgresNotificationRepository(pool *pgxpool.Pool) *PostgresNotificationRepository {
+	return &PostgresNotificationRepository{pool: pool}
+}
+
+// Create stores a notification for delivery
+func (r *PostgresNotificationRepository) Create(ctx context.Context, notification *domain.Notification) error {
+	query := `
+		INSERT INTO notifications (id, user_id, type, title, message, reference_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		notification.ID,
+		notification.UserID,
+		notification.Type,
+		notification.Title,
+		notification.Message,
+		notification.ReferenceID,
+		notification.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+// ListUndelivered retrieves the oldest undelivered notifications with the user's Discord ID
+func (r *PostgresNotificationRepository) ListUndelivered(ctx context.Context, limit int) ([]*domain.Notification, error) {
+	query := `
+		SELECT n.id, n.user_id, u.discord_id, n.type, n.title, n.message, n.reference_id, n.created_at, n.delivered_at
+		FROM notifications n
+		JOIN users u ON n.user_id = u.id
+		WHERE n.delivered_at IS NULL
+		ORDER BY n.created_at ASC
+		LIMIT $1
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*domain.Notification
+	for rows.Next() {
+		n := &domain.Notification{}
+		err := rows.Scan(&n.ID, &n.UserID, &n.DiscordID, &n.Type, &n.Title, &n.Message, &n.ReferenceID, &n.CreatedAt, &n.DeliveredAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+
+	return notifications, nil
+}
+
+// MarkDelivered records that a notification was sent.
+// Marking an already delivered notification again is a no-op.
+func (r *PostgresNotificationRepository) MarkDelivered(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE notifications
+		SET delivered_at = COALESCE(delivered_at, CURRENT_TIMESTAMP)
+		WHERE id = $1
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification delivered: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotificationNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresPityRepository implements PityRepository
+type PostgresPityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresPityRepository creates a new repository
+func NewPostgresPityRepository(pool *pgxpool.Pool) *PostgresPityRepository {
+	return &PostgresPityRepository{pool: pool}
+}
+
+// Get retrieves a user's pity on a banner, with no pulls counted if they
+// have never pulled on it
+func (r *PostgresPityRepository) Get(ctx context.Context, userID uuid.UUID, bannerID string) (*domain.PityState, error) {
+	return r.get(ctx, userID, bannerID, "")
+}
+
+// GetForUpdate retrieves a user's pity on a banner and locks it until the
+// transaction ends. The counters are created first so that a player's first
+// pulls on a banner lock them too.
+func (r *PostgresPityRepository) GetForUpdate(ctx context.Context, userID uuid.UUID, bannerID string) (*domain.PityState, error) {
+	for _, rule := range domain.PityRules {
+		_, err := conn(ctx, r.pool).Exec(ctx, `
+			INSERT INTO pity_counters (user_id, banner_id, rarity, pulls)
+			VALUES ($1, $2, $3, 0)
+			ON CONFLICT (user_id, banner_id, rarity) DO NOTHING
+		`, userID, bannerID, rule.Rarity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pity counter: %w", err)
+		}
+	}
+
+	return r.get(ctx, userID, bannerID, "FOR UPDATE")
+}
+
+func (r *PostgresPityRepository) get(ctx context.Context, userID uuid.UUID, bannerID string, lock string) (*domain.PityState, error) {
+	query := `
+		SELECT rarity, pulls, updated_at
+		FROM pity_counters
+		WHERE user_id = $1 AND banner_id = $2
+		` + lock
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID, bannerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pity: %w", err)
+	}
+	defer rows.Close()
+
+	state := domain.NewPityState(userID, bannerID)
+	for rows.Next() {
+		var rarity domain.Rarity
+		var pulls int
+		if err := rows.Scan(&rarity, &pulls, &state.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan pity counter: %w", err)
+		}
+		state.Pulls[rarity] = pulls
+	}
+
+	return state, nil
+}
+
+// Save stores a user's pity counters on a banner
+func (r *PostgresPityRepository) Save(ctx context.Context, state *domain.PityState) error {
+	for _, rule := range domain.PityRules {
+		_, err := conn(ctx, r.pool).Exec(ctx, `
+			INSERT INTO pity_counters (user_id, banner_id, rarity, pulls, updated_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (user_id, banner_id, rarity)
+			DO UPDATE SET pulls = EXCLUDED.pulls, updated_at = EXCLUDED.updated_at
+		`, state.UserID, state.BannerID, rule.Rarity, state.Pulls[rule.Rarity], state.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save pity: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresPokedexRepository implements PokedexRepository
+type PostgresPokedexRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresPokedexRepository creates a new repository
+func NewPostgresPokedexRepository(pool *pgxpool.Pool) *PostgresPokedexRepository {
+	return &PostgresPokedexRepository{pool: pool}
+}
+
+// MarkSeen records species as seen by a player
+func (r *PostgresPokedexRepository) MarkSeen(ctx context.Context, userID uuid.UUID, speciesIDs []int) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO pokedex_entries (user_id, species_id)
+		SELECT DISTINCT $1::uuid, unnest($2::int[])
+		ON CONFLICT (user_id, species_id) DO NOTHING
+	`, userID, speciesIDs)
+	if err != nil {
+		return fmt.Errorf("failed to mark species seen: %w", err)
+	}
+
+	return nil
+}
+
+// MarkOwned records species as seen and owned by a player, keeping the
+// time each was first owned
+func (r *PostgresPokedexRepository) MarkOwned(ctx context.Context, userID uuid.UUID, speciesIDs []int) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO pokedex_entries (user_id, species_id, owned_at)
+		SELECT DISTINCT $1::uuid, unnest($2::int[]), NOW()
+		ON CONFLICT (user_id, species_id) DO UPDATE SET
+			owned_at = COALESCE(pokedex_entries.owned_at, EXCLUDED.owned_at)
+	`, userID, speciesIDs)
+	if err != nil {
+		return fmt.Errorf("failed to mark species owned: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser retrieves every species a player has seen or owned
+func (r *PostgresPokedexRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.PokedexEntry, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `
+		SELECT user_id, species_id, seen_at, owned_at
+		FROM pokedex_entries
+		WHERE user_id = $1
+		ORDER BY species_id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pokedex entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.PokedexEntry
+	for rows.Next() {
+		entry := &domain.PokedexEntry{}
+		if err := rows.Scan(&entry.UserID, &entry.SpeciesID, &entry.SeenAt, &entry.OwnedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan pokedex entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// CountOwned counts the species a player has ever owned
+func (r *PostgresPokedexRepository) CountOwned(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := conn(ctx, r.pool).QueryRow(ctx, `
+		SELECT COUNT(*) FROM pokedex_entries WHERE user_id = $1 AND owned_at IS NOT NULL
+	`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count owned species: %w", err)
+	}
+
+	return count, nil
+}
+
+// GrantMilestone records a milestone reward as paid to a player, returning
+// false if it already was
+func (r *PostgresPokedexRepository) GrantMilestone(ctx context.Context, userID uuid.UUID, owned int) (bool, error) {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO pokedex_milestones (user_id, owned)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, owned) DO NOTHING
+	`, userID, owned)
+	if err != nil {
+		return false, fmt.Errorf("failed to grant pokedex milestone: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// ListMilestones retrieves the milestones paid to a player
+func (r *PostgresPokedexRepository) ListMilestones(ctx context.Context, userID uuid.UUID) ([]int, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `
+		SELECT owned FROM pokedex_milestones WHERE user_id = $1 ORDER BY owned
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pokedex milestones: %w", err)
+	}
+	defer rows.Close()
+
+	var milestones []int
+	for rows.Next() {
+		var owned int
+		if err := rows.Scan(&owned); err != nil {
+			return nil, fmt.Errorf("failed to scan pokedex milestone: %w", err)
+		}
+		milestones = append(milestones, owned)
+	}
+
+	return milestones, nil
+}
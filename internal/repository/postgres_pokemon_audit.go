@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresPokemonAuditRepository implements PokemonAuditRepository
+type PostgresPokemonAuditRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresPokemonAuditRepository creates a new repository
+func NewPostgresPokemonAuditRepository(pool *pgxpool.Pool) *PostgresPokemonAuditRepository {
+	return &PostgresPokemonAuditRepository{pool: pool}
+}
+
+// Create appends an entry to the audit log
+func (r *PostgresPokemonAuditRepository) Create(ctx context.Context, entry *domain.PokemonAuditEntry) error {
+	query := `
+		INSERT INTO pokemon_audit_log (id, user_id, user_pokemon_id, species_id, action,
+			candy_delta, before_traits, after_traits, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		entry.ID,
+		entry.UserID,
+		entry.PokemonID,
+		entry.SpeciesID,
+		entry.Action,
+		entry.CandyDelta,
+		entry.Before,
+		entry.After,
+		entry.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// ListByPokemon retrieves a Pokemon's audit history, newest first
+func (r *PostgresPokemonAuditRepository) ListByPokemon(ctx context.Context, pokemonID uuid.UUID, limit int) ([]*domain.PokemonAuditEntry, error) {
+	query := `
+		SELECT id, user_id, user_pokemon_id, species_id, action,
+			candy_delta, before_traits, after_traits, created_at
+		FROM pokemon_audit_log
+		WHERE user_pokemon_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, pokemonID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.PokemonAuditEntry
+	for rows.Next() {
+		entry := &domain.PokemonAuditEntry{}
+		err := rows.Scan(
+			&entry.ID,
+			&entry.UserID,
+			&entry.PokemonID,
+			&entry.SpeciesID,
+			&entry.Action,
+			&entry.CandyDelta,
+			&entry.Before,
+			&entry.After,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
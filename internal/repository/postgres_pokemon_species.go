@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/danielyang21/GoBattleServer/internal/domain"
 	"github.com/jackc/pgx/v5"
@@ -15,6 +16,28 @@ var (
 	ErrNoSpeciesFound  = errors.New("no pokemon species found for rarity")
 )
 
+// speciesColumns selects a species aliased as ps, in speciesDest order.
+// Its forms come back as one JSON array.
+const speciesColumns = `
+	ps.id, ps.name, ps.rarity, ps.base_hp, ps.base_attack, ps.base_defense,
+	ps.base_sp_attack, ps.base_sp_defense, ps.base_speed, ps.sprite_url, ps.drop_weight,
+	ps.growth_rate, ps.base_experience, ps.gender_rate, ps.shiny_sprite_url,
+	ps.abilities, COALESCE(ps.hidden_ability, ''), ps.type1, ps.type2,
+	(SELECT COALESCE(jsonb_agg(to_jsonb(psf) ORDER BY psf.id), '[]'::jsonb)
+		FROM pokemon_forms psf WHERE psf.species_id = ps.id)
+`
+
+// speciesStatColumns are the columns of each stat in domain.SpeciesStats
+var speciesStatColumns = map[string]string{
+	"hp":                    "ps.base_hp",
+	"attack":                "ps.base_attack",
+	"defense":               "ps.base_defense",
+	"sp_attack":             "ps.base_sp_attack",
+	"sp_defense":            "ps.base_sp_defense",
+	"speed":                 "ps.base_speed",
+	domain.SpeciesStatTotal: "(ps.base_hp + ps.base_attack + ps.base_defense + ps.base_sp_attack + ps.base_sp_defense + ps.base_speed)",
+}
+
 // PostgresPokemonSpeciesRepository implements PokemonSpeciesRepository
 type PostgresPokemonSpeciesRepository struct {
 	pool *pgxpool.Pool
@@ -25,31 +48,56 @@ func NewPostgresPokemonSpeciesRepository(pool *pgxpool.Pool) *PostgresPokemonSpe
 	return &PostgresPokemonSpeciesRepository{pool: pool}
 }
 
+// insertSpecies inserts a species from speciesArgs
+const insertSpecies = `
+	INSERT INTO pokemon_species (
+		id, name, rarity, base_hp, base_attack, base_defense,
+		base_sp_attack, base_sp_defense, base_speed, sprite_url, drop_weight,
+		growth_rate, base_experience, gender_rate, shiny_sprite_url,
+		abilities, hidden_ability, type1, type2
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
+		COALESCE($16::text[], '{}'), NULLIF($17, ''), COALESCE(NULLIF($18, ''), 'normal'), $19)
+`
+
 // Create inserts a new Pokemon species
 func (r *PostgresPokemonSpeciesRepository) Create(ctx context.Context, species *domain.PokemonSpecies) error {
-	query := `
-		INSERT INTO pokemon_species (
-			id, name, rarity, base_hp, base_attack, base_defense,
-			base_sp_attack, base_sp_defense, base_speed, sprite_url, drop_weight
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-	`
+	_, err := conn(ctx, r.pool).Exec(ctx, insertSpecies, speciesArgs(species)...)
+	if err != nil {
+		return fmt.Errorf("failed to create pokemon species: %w", err)
+	}
 
-	_, err := r.pool.Exec(ctx, query,
-		species.ID,
-		species.Name,
-		species.Rarity,
-		species.BaseHP,
-		species.BaseAttack,
-		species.BaseDefense,
-		species.BaseSpAttack,
-		species.BaseSpDefense,
-		species.BaseSpeed,
-		species.SpriteURL,
-		species.DropWeight,
-	)
+	return nil
+}
+
+// Upsert inserts a species or updates the one with its Pokedex number. An
+// existing species keeps its drop weight unless its rarity changes.
+func (r *PostgresPokemonSpeciesRepository) Upsert(ctx context.Context, species *domain.PokemonSpecies) error {
+	query := insertSpecies + `
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			rarity = EXCLUDED.rarity,
+			base_hp = EXCLUDED.base_hp,
+			base_attack = EXCLUDED.base_attack,
+			base_defense = EXCLUDED.base_defense,
+			base_sp_attack = EXCLUDED.base_sp_attack,
+			base_sp_defense = EXCLUDED.base_sp_defense,
+			base_speed = EXCLUDED.base_speed,
+			sprite_url = EXCLUDED.sprite_url,
+			drop_weight = CASE WHEN pokemon_species.rarity = EXCLUDED.rarity
+				THEN pokemon_species.drop_weight ELSE EXCLUDED.drop_weight END,
+			growth_rate = EXCLUDED.growth_rate,
+			base_experience = EXCLUDED.base_experience,
+			gender_rate = EXCLUDED.gender_rate,
+			shiny_sprite_url = EXCLUDED.shiny_sprite_url,
+			abilities = EXCLUDED.abilities,
+			hidden_ability = EXCLUDED.hidden_ability,
+			type1 = EXCLUDED.type1,
+			type2 = EXCLUDED.type2
+	`
 
+	_, err := conn(ctx, r.pool).Exec(ctx, query, speciesArgs(species)...)
 	if err != nil {
-		return fmt.Errorf("failed to create pokemon species: %w", err)
+		return fmt.Errorf("failed to upsert species %s: %w", species.Name, err)
 	}
 
 	return nil
@@ -58,26 +106,13 @@ func (r *PostgresPokemonSpeciesRepository) Create(ctx context.Context, species *
 // GetByID retrieves a species by national dex number
 func (r *PostgresPokemonSpeciesRepository) GetByID(ctx context.Context, id int) (*domain.PokemonSpecies, error) {
 	query := `
-		SELECT id, name, rarity, base_hp, base_attack, base_defense,
-		       base_sp_attack, base_sp_defense, base_speed, sprite_url, drop_weight
-		FROM pokemon_species
-		WHERE id = $1
+		SELECT ` + speciesColumns + `
+		FROM pokemon_species ps
+		WHERE ps.id = $1
 	`
 
 	species := &domain.PokemonSpecies{}
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&species.ID,
-		&species.Name,
-		&species.Rarity,
-		&species.BaseHP,
-		&species.BaseAttack,
-		&species.BaseDefense,
-		&species.BaseSpAttack,
-		&species.BaseSpDefense,
-		&species.BaseSpeed,
-		&species.SpriteURL,
-		&species.DropWeight,
-	)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(speciesDest(species)...)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -92,14 +127,13 @@ func (r *PostgresPokemonSpeciesRepository) GetByID(ctx context.Context, id int)
 // GetByRarity retrieves all species of a given rarity
 func (r *PostgresPokemonSpeciesRepository) GetByRarity(ctx context.Context, rarity domain.Rarity) ([]*domain.PokemonSpecies, error) {
 	query := `
-		SELECT id, name, rarity, base_hp, base_attack, base_defense,
-		       base_sp_attack, base_sp_defense, base_speed, sprite_url, drop_weight
-		FROM pokemon_species
-		WHERE rarity = $1
-		ORDER BY id
+		SELECT ` + speciesColumns + `
+		FROM pokemon_species ps
+		WHERE ps.rarity = $1
+		ORDER BY ps.id
 	`
 
-	rows, err := r.pool.Query(ctx, query, rarity)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, rarity)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get species by rarity: %w", err)
 	}
@@ -108,19 +142,7 @@ func (r *PostgresPokemonSpeciesRepository) GetByRarity(ctx context.Context, rari
 	var species []*domain.PokemonSpecies
 	for rows.Next() {
 		s := &domain.PokemonSpecies{}
-		err := rows.Scan(
-			&s.ID,
-			&s.Name,
-			&s.Rarity,
-			&s.BaseHP,
-			&s.BaseAttack,
-			&s.BaseDefense,
-			&s.BaseSpAttack,
-			&s.BaseSpDefense,
-			&s.BaseSpeed,
-			&s.SpriteURL,
-			&s.DropWeight,
-		)
+		err := rows.Scan(speciesDest(s)...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan species: %w", err)
 		}
@@ -133,28 +155,15 @@ func (r *PostgresPokemonSpeciesRepository) GetByRarity(ctx context.Context, rari
 // GetRandomByRarity retrieves a random species from a rarity tier
 func (r *PostgresPokemonSpeciesRepository) GetRandomByRarity(ctx context.Context, rarity domain.Rarity) (*domain.PokemonSpecies, error) {
 	query := `
-		SELECT id, name, rarity, base_hp, base_attack, base_defense,
-		       base_sp_attack, base_sp_defense, base_speed, sprite_url, drop_weight
-		FROM pokemon_species
-		WHERE rarity = $1
+		SELECT ` + speciesColumns + `
+		FROM pokemon_species ps
+		WHERE ps.rarity = $1
 		ORDER BY RANDOM()
 		LIMIT 1
 	`
 
 	species := &domain.PokemonSpecies{}
-	err := r.pool.QueryRow(ctx, query, rarity).Scan(
-		&species.ID,
-		&species.Name,
-		&species.Rarity,
-		&species.BaseHP,
-		&species.BaseAttack,
-		&species.BaseDefense,
-		&species.BaseSpAttack,
-		&species.BaseSpDefense,
-		&species.BaseSpeed,
-		&species.SpriteURL,
-		&species.DropWeight,
-	)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, rarity).Scan(speciesDest(species)...)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -169,13 +178,12 @@ func (r *PostgresPokemonSpeciesRepository) GetRandomByRarity(ctx context.Context
 // List retrieves all Pokemon species
 func (r *PostgresPokemonSpeciesRepository) List(ctx context.Context) ([]*domain.PokemonSpecies, error) {
 	query := `
-		SELECT id, name, rarity, base_hp, base_attack, base_defense,
-		       base_sp_attack, base_sp_defense, base_speed, sprite_url, drop_weight
-		FROM pokemon_species
-		ORDER BY id
+		SELECT ` + speciesColumns + `
+		FROM pokemon_species ps
+		ORDER BY ps.id
 	`
 
-	rows, err := r.pool.Query(ctx, query)
+	rows, err := conn(ctx, r.pool).Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list species: %w", err)
 	}
@@ -184,19 +192,7 @@ func (r *PostgresPokemonSpeciesRepository) List(ctx context.Context) ([]*domain.
 	var species []*domain.PokemonSpecies
 	for rows.Next() {
 		s := &domain.PokemonSpecies{}
-		err := rows.Scan(
-			&s.ID,
-			&s.Name,
-			&s.Rarity,
-			&s.BaseHP,
-			&s.BaseAttack,
-			&s.BaseDefense,
-			&s.BaseSpAttack,
-			&s.BaseSpDefense,
-			&s.BaseSpeed,
-			&s.SpriteURL,
-			&s.DropWeight,
-		)
+		err := rows.Scan(speciesDest(s)...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan species: %w", err)
 		}
@@ -206,37 +202,109 @@ func (r *PostgresPokemonSpeciesRepository) List(ctx context.Context) ([]*domain.
 	return species, nil
 }
 
+// GetByName retrieves a species by name, ignoring case
+func (r *PostgresPokemonSpeciesRepository) GetByName(ctx context.Context, name string) (*domain.PokemonSpecies, error) {
+	query := `
+		SELECT ` + speciesColumns + `
+		FROM pokemon_species ps
+		WHERE LOWER(ps.name) = LOWER($1)
+	`
+
+	species := &domain.PokemonSpecies{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, name).Scan(speciesDest(species)...)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSpeciesNotFound
+		}
+		return nil, fmt.Errorf("failed to get species by name: %w", err)
+	}
+
+	return species, nil
+}
+
+// Search retrieves a page of species matching a filter, by Pokedex number
+// unless the filter sorts them otherwise
+func (r *PostgresPokemonSpeciesRepository) Search(ctx context.Context, filter domain.SpeciesSearchFilter) ([]*domain.PokemonSpecies, error) {
+	conditions := []string{"TRUE"}
+	var args []any
+
+	add := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Type1 != "" {
+		add("ps.type1 = $%d", filter.Type1)
+	}
+	if filter.Type2 != "" {
+		add("ps.type2 = $%d", filter.Type2)
+	}
+	if filter.Rarity != "" {
+		add("ps.rarity = $%d", filter.Rarity)
+	}
+	for _, stat := range domain.SpeciesStats {
+		if value, ok := filter.MinStats[stat]; ok {
+			add(speciesStatColumns[stat]+" >= $%d", value)
+		}
+		if value, ok := filter.MaxStats[stat]; ok {
+			add(speciesStatColumns[stat]+" <= $%d", value)
+		}
+	}
+
+	order := "ps.id"
+	switch {
+	case filter.Sort == domain.SpeciesSortName:
+		order = "ps.name"
+	case speciesStatColumns[filter.Sort] != "":
+		order = speciesStatColumns[filter.Sort]
+	}
+	if filter.Descending {
+		order += " DESC"
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultMarketSearchLimit
+	}
+	args = append(args, limit, filter.Offset)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM pokemon_species ps
+		WHERE %s
+		ORDER BY %s, ps.id
+		LIMIT $%d OFFSET $%d
+	`, speciesColumns, strings.Join(conditions, " AND "), order, len(args)-1, len(args))
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search species: %w", err)
+	}
+	defer rows.Close()
+
+	var species []*domain.PokemonSpecies
+	for rows.Next() {
+		s := &domain.PokemonSpecies{}
+		if err := rows.Scan(speciesDest(s)...); err != nil {
+			return nil, fmt.Errorf("failed to scan species: %w", err)
+		}
+		species = append(species, s)
+	}
+
+	return species, nil
+}
+
 // BulkCreate inserts multiple species (for seeding)
 func (r *PostgresPokemonSpeciesRepository) BulkCreate(ctx context.Context, species []*domain.PokemonSpecies) error {
-	tx, err := r.pool.Begin(ctx)
+	tx, err := begin(ctx, r.pool)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	query := `
-		INSERT INTO pokemon_species (
-			id, name, rarity, base_hp, base_attack, base_defense,
-			base_sp_attack, base_sp_defense, base_speed, sprite_url, drop_weight
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		ON CONFLICT (id) DO NOTHING
-	`
-
 	for _, s := range species {
-		_, err := tx.Exec(ctx, query,
-			s.ID,
-			s.Name,
-			s.Rarity,
-			s.BaseHP,
-			s.BaseAttack,
-			s.BaseDefense,
-			s.BaseSpAttack,
-			s.BaseSpDefense,
-			s.BaseSpeed,
-			s.SpriteURL,
-			s.DropWeight,
-		)
-		if err != nil {
+		if _, err := tx.Exec(ctx, insertSpecies+" ON CONFLICT (id) DO NOTHING", speciesArgs(s)...); err != nil {
 			return fmt.Errorf("failed to insert species %s: %w", s.Name, err)
 		}
 	}
@@ -247,3 +315,54 @@ func (r *PostgresPokemonSpeciesRepository) BulkCreate(ctx context.Context, speci
 
 	return nil
 }
+
+// speciesArgs returns the insertSpecies arguments for a species
+func speciesArgs(species *domain.PokemonSpecies) []any {
+	return []any{
+		species.ID,
+		species.Name,
+		species.Rarity,
+		species.BaseHP,
+		species.BaseAttack,
+		species.BaseDefense,
+		species.BaseSpAttack,
+		species.BaseSpDefense,
+		species.BaseSpeed,
+		species.SpriteURL,
+		species.DropWeight,
+		species.GrowthRate,
+		species.BaseExperience,
+		species.GenderRate,
+		species.ShinySpriteURL,
+		species.Abilities,
+		species.HiddenAbility,
+		species.Type1,
+		species.Type2,
+	}
+}
+
+// speciesDest returns the scan destinations for speciesColumns
+func speciesDest(species *domain.PokemonSpecies) []any {
+	return []any{
+		&species.ID,
+		&species.Name,
+		&species.Rarity,
+		&species.BaseHP,
+		&species.BaseAttack,
+		&species.BaseDefense,
+		&species.BaseSpAttack,
+		&species.BaseSpDefense,
+		&species.BaseSpeed,
+		&species.SpriteURL,
+		&species.DropWeight,
+		&species.GrowthRate,
+		&species.BaseExperience,
+		&species.GenderRate,
+		&species.ShinySpriteURL,
+		&species.Abilities,
+		&species.HiddenAbility,
+		&species.Type1,
+		&species.Type2,
+		&species.Forms,
+	}
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// questProgressColumns selects quest progress in questProgressDest order
+const questProgressColumns = `
+	user_id, quest_id, period_key, progress, completed_at, claimed_at, updated_at
+`
+
+// PostgresQuestProgressRepository implements QuestProgressRepository
+type PostgresQuestProgressRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresQuestProgressRepository creates a new repository
+func NewPostgresQuestProgressRepository(pool *pgxpool.Pool) *PostgresQuestProgressRepository {
+	return &PostgresQuestProgressRepository{pool: pool}
+}
+
+// Add atomically adds delta to a player's progress on a quest in a period,
+// capped at the goal, and marks it completed on reaching it
+func (r *PostgresQuestProgressRepository) Add(ctx context.Context, userID uuid.UUID, questID, periodKey string, delta, goal int) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO quest_progress (user_id, quest_id, period_key, progress, completed_at)
+		VALUES ($1, $2, $3, LEAST($4::int, $5::int), CASE WHEN $4::int >= $5::int THEN NOW() END)
+		ON CONFLICT (user_id, quest_id, period_key) DO UPDATE SET
+			progress = LEAST(quest_progress.progress + $4::int, $5::int),
+			completed_at = COALESCE(quest_progress.completed_at,
+				CASE WHEN quest_progress.progress + $4::int >= $5::int THEN NOW() END),
+			updated_at = NOW()
+	`, userID, questID, periodKey, delta, goal)
+	if err != nil {
+		return fmt.Errorf("failed to add quest progress: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser retrieves a player's progress in any of the periods
+func (r *PostgresQuestProgressRepository) ListByUser(ctx context.Context, userID uuid.UUID, periodKeys []string) ([]*domain.QuestProgress, error) {
+	query := `
+		SELECT ` + questProgressColumns + `
+		FROM quest_progress
+		WHERE user_id = $1 AND period_key = ANY($2)
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID, periodKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quest progress: %w", err)
+	}
+	defer rows.Close()
+
+	var progress []*domain.QuestProgress
+	for rows.Next() {
+		p := &domain.QuestProgress{}
+		if err := rows.Scan(questProgressDest(p)...); err != nil {
+			return nil, fmt.Errorf("failed to scan quest progress: %w", err)
+		}
+		progress = append(progress, p)
+	}
+
+	return progress, nil
+}
+
+// GetForUpdate retrieves a player's progress on a quest in a period and
+// locks it until the transaction ends, with nothing counted if they have
+// made none
+func (r *PostgresQuestProgressRepository) GetForUpdate(ctx context.Context, userID uuid.UUID, questID, periodKey string) (*domain.QuestProgress, error) {
+	query := `
+		SELECT ` + questProgressColumns + `
+		FROM quest_progress
+		WHERE user_id = $1 AND quest_id = $2 AND period_key = $3
+		FOR UPDATE
+	`
+
+	progress := &domain.QuestProgress{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, userID, questID, periodKey).Scan(questProgressDest(progress)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.NewQuestProgress(userID, questID, periodKey), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quest progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// Save stores a player's progress on a quest
+func (r *PostgresQuestProgressRepository) Save(ctx context.Context, progress *domain.QuestProgress) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO quest_progress (user_id, quest_id, period_key, progress, completed_at, claimed_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, quest_id, period_key) DO UPDATE SET
+			progress = EXCLUDED.progress,
+			completed_at = EXCLUDED.completed_at,
+			claimed_at = EXCLUDED.claimed_at,
+			updated_at = EXCLUDED.updated_at
+	`, progress.UserID, progress.QuestID, progress.PeriodKey, progress.Progress,
+		progress.CompletedAt, progress.ClaimedAt, progress.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save quest progress: %w", err)
+	}
+
+	return nil
+}
+
+// questProgressDest returns the scan destinations for questProgressColumns
+func questProgressDest(progress *domain.QuestProgress) []any {
+	return []any{
+		&progress.UserID,
+		&progress.QuestID,
+		&progress.PeriodKey,
+		&progress.Progress,
+		&progress.CompletedAt,
+		&progress.ClaimedAt,
+		&progress.UpdatedAt,
+	}
+}
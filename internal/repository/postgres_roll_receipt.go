@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrRollReceiptNotFound = errors.New("roll receipt not found")
+)
+
+// PostgresRollReceiptRepository implements RollReceiptRepository
+type PostgresRollReceiptRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresRollReceiptRepository creates a new repository
+func NewPostgresRollReceiptRepository(pool *pgxpool.Pool) *PostgresRollReceiptRepository {
+	return &PostgresRollReceiptRepository{pool: pool}
+}
+
+// Create stores the result of a roll under its idempotency key
+func (r *PostgresRollReceiptRepository) Create(ctx context.Context, receipt *domain.RollReceipt) error {
+	query := `
+		INSERT INTO roll_receipts (user_id, idempotency_key, pokemon_ids, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		receipt.UserID,
+		receipt.Key,
+		receipt.PokemonIDs,
+		receipt.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create roll receipt: %w", err)
+	}
+
+	return nil
+}
+
+// GetByKey retrieves the receipt a user stored under a key
+func (r *PostgresRollReceiptRepository) GetByKey(ctx context.Context, userID uuid.UUID, key string) (*domain.RollReceipt, error) {
+	query := `
+		SELECT user_id, idempotency_key, pokemon_ids, created_at
+		FROM roll_receipts
+		WHERE user_id = $1 AND idempotency_key = $2
+	`
+
+	receipt := &domain.RollReceipt{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, userID, key).Scan(
+		&receipt.UserID,
+		&receipt.Key,
+		&receipt.PokemonIDs,
+		&receipt.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRollReceiptNotFound
+		}
+		return nil, fmt.Errorf("failed to get roll receipt: %w", err)
+	}
+
+	return receipt, nil
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrTradeNotFound = errors.New("trade not found")
+
+// tradeSelect selects a trade in scanTrade order
+const tradeSelect = `
+	SELECT id, proposer_id, recipient_id, proposer_pokemon_ids, recipient_pokemon_ids,
+		proposer_coins, recipient_coins, proposer_confirmed, recipient_confirmed,
+		status, created_at, expires_at, completed_at
+	FROM trades
+`
+
+// PostgresTradeRepository implements TradeRepository
+type PostgresTradeRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresTradeRepository creates a new repository
+func NewPostgresTradeRepository(pool *pgxpool.Pool) *PostgresTradeRepository {
+	return &PostgresTradeRepository{pool: pool}
+}
+
+// Create inserts a new trade offer
+func (r *PostgresTradeRepository) Create(ctx context.Context, trade *domain.Trade) error {
+	query := `
+		INSERT INTO trades (id, proposer_id, recipient_id, proposer_pokemon_ids, recipient_pokemon_ids,
+			proposer_coins, recipient_coins, proposer_confirmed, recipient_confirmed,
+			status, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		trade.ID,
+		trade.ProposerID,
+		trade.RecipientID,
+		trade.ProposerPokemonIDs,
+		trade.RecipientPokemonIDs,
+		trade.ProposerCoins,
+		trade.RecipientCoins,
+		trade.ProposerConfirmed,
+		trade.RecipientConfirmed,
+		trade.Status,
+		trade.CreatedAt,
+		trade.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create trade: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a trade
+func (r *PostgresTradeRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Trade, error) {
+	return r.get(ctx, tradeSelect+`WHERE id = $1`, id)
+}
+
+// GetForUpdate retrieves a trade and locks it until the surrounding transaction ends
+func (r *PostgresTradeRepository) GetForUpdate(ctx context.Context, id uuid.UUID) (*domain.Trade, error) {
+	return r.get(ctx, tradeSelect+`WHERE id = $1 FOR UPDATE`, id)
+}
+
+// get runs a single-row query selected with tradeSelect
+func (r *PostgresTradeRepository) get(ctx context.Context, query string, id uuid.UUID) (*domain.Trade, error) {
+	trade, err := scanTrade(conn(ctx, r.pool).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTradeNotFound
+		}
+		return nil, fmt.Errorf("failed to get trade: %w", err)
+	}
+
+	return trade, nil
+}
+
+// Update saves a trade's confirmations and status
+func (r *PostgresTradeRepository) Update(ctx context.Context, trade *domain.Trade) error {
+	query := `
+		UPDATE trades
+		SET proposer_confirmed = $2, recipient_confirmed = $3, status = $4, completed_at = $5
+		WHERE id = $1
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
+		trade.ID,
+		trade.ProposerConfirmed,
+		trade.RecipientConfirmed,
+		trade.Status,
+		trade.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update trade: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrTradeNotFound
+	}
+
+	return nil
+}
+
+// ListPendingByUser retrieves a user's unexpired pending trades on either side, newest first
+func (r *PostgresTradeRepository) ListPendingByUser(ctx context.Context, userID uuid.UUID, now time.Time) ([]*domain.Trade, error) {
+	query := tradeSelect + `
+		WHERE (proposer_id = $1 OR recipient_id = $1)
+			AND status = $2 AND expires_at > $3
+		ORDER BY created_at DESC
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID, domain.TradeStatusPending, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trades: %w", err)
+	}
+	defer rows.Close()
+
+	var trades []*domain.Trade
+	for rows.Next() {
+		trade, err := scanTrade(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan trade: %w", err)
+		}
+		trades = append(trades, trade)
+	}
+
+	return trades, nil
+}
+
+// ExpirePending marks pending trades past their expiry as expired and
+// returns the trades it expired
+func (r *PostgresTradeRepository) ExpirePending(ctx context.Context, now time.Time) ([]*domain.Trade, error) {
+	query := `
+		UPDATE trades
+		SET status = $1
+		WHERE status = $2 AND expires_at <= $3
+		RETURNING id, proposer_id, recipient_id, proposer_pokemon_ids, recipient_pokemon_ids,
+			proposer_coins, recipient_coins, proposer_confirmed, recipient_confirmed,
+			status, created_at, expires_at, completed_at
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, domain.TradeStatusExpired, domain.TradeStatusPending, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire trades: %w", err)
+	}
+	defer rows.Close()
+
+	var trades []*domain.Trade
+	for rows.Next() {
+		trade, err := scanTrade(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan trade: %w", err)
+		}
+		trades = append(trades, trade)
+	}
+
+	return trades, nil
+}
+
+// scanTrade scans a row selected with tradeSelect
+func scanTrade(row pgx.Row) (*domain.Trade, error) {
+	trade := &domain.Trade{}
+
+	err := row.Scan(
+		&trade.ID,
+		&trade.ProposerID,
+		&trade.RecipientID,
+		&trade.ProposerPokemonIDs,
+		&trade.RecipientPokemonIDs,
+		&trade.ProposerCoins,
+		&trade.RecipientCoins,
+		&trade.ProposerConfirmed,
+		&trade.RecipientConfirmed,
+		&trade.Status,
+		&trade.CreatedAt,
+		&trade.ExpiresAt,
+		&trade.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return trade, nil
+}
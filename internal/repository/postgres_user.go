@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/danielyang21/GoBattleServer/internal/domain"
 	"github.com/google/uuid"
@@ -14,6 +15,7 @@ import (
 var (
 	ErrUserNotFound      = errors.New("user not found")
 	ErrUserAlreadyExists = errors.New("user with this discord ID already exists")
+	ErrInsufficientCoins = errors.New("insufficient coins")
 )
 
 // PostgresUserRepository implements UserRepository using PostgreSQL
@@ -33,7 +35,7 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *domain.User)
 		VALUES ($1, $2, $3, $4, $5)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		user.ID,
 		user.DiscordID,
 		user.Coins,
@@ -61,7 +63,7 @@ func (r *PostgresUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*do
 	`
 
 	user := &domain.User{}
-	err := r.pool.QueryRow(ctx, query, id).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
 		&user.ID,
 		&user.DiscordID,
 		&user.Coins,
@@ -88,7 +90,7 @@ func (r *PostgresUserRepository) GetByDiscordID(ctx context.Context, discordID s
 	`
 
 	user := &domain.User{}
-	err := r.pool.QueryRow(ctx, query, discordID).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, discordID).Scan(
 		&user.ID,
 		&user.DiscordID,
 		&user.Coins,
@@ -114,7 +116,7 @@ func (r *PostgresUserRepository) Update(ctx context.Context, user *domain.User)
 		WHERE id = $1
 	`
 
-	result, err := r.pool.Exec(ctx, query,
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
 		user.ID,
 		user.DiscordID,
 		user.Coins,
@@ -140,7 +142,7 @@ func (r *PostgresUserRepository) UpdateCoins(ctx context.Context, userID uuid.UU
 		WHERE id = $1
 	`
 
-	result, err := r.pool.Exec(ctx, query, userID, coins)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, userID, coins)
 	if err != nil {
 		return fmt.Errorf("failed to update coins: %w", err)
 	}
@@ -152,6 +154,31 @@ func (r *PostgresUserRepository) UpdateCoins(ctx context.Context, userID uuid.UU
 	return nil
 }
 
+// AdjustCoins adds delta to the user's balance (negative to deduct).
+// The balance is checked in the same statement, so it can never go below zero.
+func (r *PostgresUserRepository) AdjustCoins(ctx context.Context, userID uuid.UUID, delta int) error {
+	query := `
+		UPDATE users
+		SET coins = coins + $2
+		WHERE id = $1 AND coins + $2 >= 0
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query, userID, delta)
+	if err != nil {
+		return fmt.Errorf("failed to adjust coins: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		// Distinguish a missing user from an overdraft
+		if _, err := r.GetByID(ctx, userID); err != nil {
+			return err
+		}
+		return ErrInsufficientCoins
+	}
+
+	return nil
+}
+
 // UpdateLastDailyRoll updates the last daily roll timestamp
 func (r *PostgresUserRepository) UpdateLastDailyRoll(ctx context.Context, userID uuid.UUID) error {
 	query := `
@@ -160,7 +187,7 @@ func (r *PostgresUserRepository) UpdateLastDailyRoll(ctx context.Context, userID
 		WHERE id = $1
 	`
 
-	result, err := r.pool.Exec(ctx, query, userID)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, userID)
 	if err != nil {
 		return fmt.Errorf("failed to update last daily roll: %w", err)
 	}
@@ -172,11 +199,29 @@ func (r *PostgresUserRepository) UpdateLastDailyRoll(ctx context.Context, userID
 	return nil
 }
 
+// ClaimDailyRoll sets the last daily roll timestamp only if the cooldown has elapsed.
+// The check and the write are one statement, so concurrent claims can't both succeed.
+func (r *PostgresUserRepository) ClaimDailyRoll(ctx context.Context, userID uuid.UUID, cooldown time.Duration) (bool, error) {
+	query := `
+		UPDATE users
+		SET last_daily_roll = CURRENT_TIMESTAMP
+		WHERE id = $1
+		  AND (last_daily_roll IS NULL OR last_daily_roll <= CURRENT_TIMESTAMP - make_interval(secs => $2))
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query, userID, cooldown.Seconds())
+	if err != nil {
+		return false, fmt.Errorf("failed to claim daily roll: %w", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
 // Delete removes a user
 func (r *PostgresUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM users WHERE id = $1`
 
-	result, err := r.pool.Exec(ctx, query, id)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
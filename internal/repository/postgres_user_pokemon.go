@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/danielyang21/GoBattleServer/internal/domain"
 	"github.com/google/uuid"
@@ -15,6 +16,19 @@ var (
 	ErrPokemonNotFound = errors.New("pokemon not found")
 )
 
+// pokemonColumns selects a Pokemon aliased as up with its species, in pokemonDest order.
+// Its form comes back as JSON, or NULL for the base form.
+const pokemonColumns = `
+	up.id, up.user_id, up.species_id,
+	up.iv_hp, up.iv_attack, up.iv_defense,
+	up.iv_sp_attack, up.iv_sp_defense, up.iv_speed,
+	up.ev_hp, up.ev_attack, up.ev_defense,
+	up.ev_sp_attack, up.ev_sp_defense, up.ev_speed,
+	up.nature, up.is_shiny, up.gender, up.form_id,
+	(SELECT to_jsonb(upf) FROM pokemon_forms upf WHERE upf.id = up.form_id),
+	up.ability_slot,
+	up.level, up.experience, up.acquired_at, up.is_favorite, up.nickname,` + speciesColumns
+
 // PostgresUserPokemonRepository implements UserPokemonRepository
 type PostgresUserPokemonRepository struct {
 	pool *pgxpool.Pool
@@ -31,11 +45,14 @@ func (r *PostgresUserPokemonRepository) Create(ctx context.Context, pokemon *dom
 		INSERT INTO user_pokemon (
 			id, user_id, species_id, iv_hp, iv_attack, iv_defense,
 			iv_sp_attack, iv_sp_defense, iv_speed, nature, level,
-			acquired_at, is_favorite, nickname
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			acquired_at, is_favorite, nickname, experience,
+			ev_hp, ev_attack, ev_defense, ev_sp_attack, ev_sp_defense, ev_speed,
+			is_shiny, gender, form_id, ability_slot
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
+			$16, $17, $18, $19, $20, $21, $22, $23, $24, $25)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		pokemon.ID,
 		pokemon.UserID,
 		pokemon.SpeciesID,
@@ -50,6 +67,17 @@ func (r *PostgresUserPokemonRepository) Create(ctx context.Context, pokemon *dom
 		pokemon.AcquiredAt,
 		pokemon.IsFavorite,
 		pokemon.Nickname,
+		pokemon.Experience,
+		pokemon.EVs.HP,
+		pokemon.EVs.Attack,
+		pokemon.EVs.Defense,
+		pokemon.EVs.SpAttack,
+		pokemon.EVs.SpDefense,
+		pokemon.EVs.Speed,
+		pokemon.IsShiny,
+		pokemon.Gender,
+		pokemon.FormID,
+		pokemon.AbilitySlot,
 	)
 
 	if err != nil {
@@ -61,50 +89,28 @@ func (r *PostgresUserPokemonRepository) Create(ctx context.Context, pokemon *dom
 
 // GetByID retrieves a specific Pokemon instance
 func (r *PostgresUserPokemonRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.UserPokemon, error) {
+	return r.get(ctx, id, "")
+}
+
+// GetForUpdate retrieves a Pokemon and locks its row until the surrounding transaction ends
+func (r *PostgresUserPokemonRepository) GetForUpdate(ctx context.Context, id uuid.UUID) (*domain.UserPokemon, error) {
+	return r.get(ctx, id, "FOR UPDATE OF up")
+}
+
+// get retrieves a Pokemon with its species, optionally with a locking clause
+func (r *PostgresUserPokemonRepository) get(ctx context.Context, id uuid.UUID, lock string) (*domain.UserPokemon, error) {
 	query := `
-		SELECT
-			up.id, up.user_id, up.species_id,
-			up.iv_hp, up.iv_attack, up.iv_defense,
-			up.iv_sp_attack, up.iv_sp_defense, up.iv_speed,
-			up.nature, up.level, up.acquired_at, up.is_favorite, up.nickname,
-			ps.id, ps.name, ps.rarity, ps.base_hp, ps.base_attack, ps.base_defense,
-			ps.base_sp_attack, ps.base_sp_defense, ps.base_speed, ps.sprite_url, ps.drop_weight
+		SELECT ` + pokemonColumns + `
 		FROM user_pokemon up
 		JOIN pokemon_species ps ON up.species_id = ps.id
 		WHERE up.id = $1
-	`
+	` + lock
 
 	pokemon := &domain.UserPokemon{
 		Species: &domain.PokemonSpecies{},
 	}
 
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&pokemon.ID,
-		&pokemon.UserID,
-		&pokemon.SpeciesID,
-		&pokemon.IVs.HP,
-		&pokemon.IVs.Attack,
-		&pokemon.IVs.Defense,
-		&pokemon.IVs.SpAttack,
-		&pokemon.IVs.SpDefense,
-		&pokemon.IVs.Speed,
-		&pokemon.Nature,
-		&pokemon.Level,
-		&pokemon.AcquiredAt,
-		&pokemon.IsFavorite,
-		&pokemon.Nickname,
-		&pokemon.Species.ID,
-		&pokemon.Species.Name,
-		&pokemon.Species.Rarity,
-		&pokemon.Species.BaseHP,
-		&pokemon.Species.BaseAttack,
-		&pokemon.Species.BaseDefense,
-		&pokemon.Species.BaseSpAttack,
-		&pokemon.Species.BaseSpDefense,
-		&pokemon.Species.BaseSpeed,
-		&pokemon.Species.SpriteURL,
-		&pokemon.Species.DropWeight,
-	)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(pokemonDest(pokemon)...)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -119,20 +125,14 @@ func (r *PostgresUserPokemonRepository) GetByID(ctx context.Context, id uuid.UUI
 // GetByUserID retrieves all Pokemon owned by a user
 func (r *PostgresUserPokemonRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.UserPokemon, error) {
 	query := `
-		SELECT
-			up.id, up.user_id, up.species_id,
-			up.iv_hp, up.iv_attack, up.iv_defense,
-			up.iv_sp_attack, up.iv_sp_defense, up.iv_speed,
-			up.nature, up.level, up.acquired_at, up.is_favorite, up.nickname,
-			ps.id, ps.name, ps.rarity, ps.base_hp, ps.base_attack, ps.base_defense,
-			ps.base_sp_attack, ps.base_sp_defense, ps.base_speed, ps.sprite_url, ps.drop_weight
+		SELECT ` + pokemonColumns + `
 		FROM user_pokemon up
 		JOIN pokemon_species ps ON up.species_id = ps.id
 		WHERE up.user_id = $1
 		ORDER BY up.acquired_at DESC
 	`
 
-	rows, err := r.pool.Query(ctx, query, userID)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pokemon by user ID: %w", err)
 	}
@@ -144,33 +144,7 @@ func (r *PostgresUserPokemonRepository) GetByUserID(ctx context.Context, userID
 			Species: &domain.PokemonSpecies{},
 		}
 
-		err := rows.Scan(
-			&pokemon.ID,
-			&pokemon.UserID,
-			&pokemon.SpeciesID,
-			&pokemon.IVs.HP,
-			&pokemon.IVs.Attack,
-			&pokemon.IVs.Defense,
-			&pokemon.IVs.SpAttack,
-			&pokemon.IVs.SpDefense,
-			&pokemon.IVs.Speed,
-			&pokemon.Nature,
-			&pokemon.Level,
-			&pokemon.AcquiredAt,
-			&pokemon.IsFavorite,
-			&pokemon.Nickname,
-			&pokemon.Species.ID,
-			&pokemon.Species.Name,
-			&pokemon.Species.Rarity,
-			&pokemon.Species.BaseHP,
-			&pokemon.Species.BaseAttack,
-			&pokemon.Species.BaseDefense,
-			&pokemon.Species.BaseSpAttack,
-			&pokemon.Species.BaseSpDefense,
-			&pokemon.Species.BaseSpeed,
-			&pokemon.Species.SpriteURL,
-			&pokemon.Species.DropWeight,
-		)
+		err := rows.Scan(pokemonDest(pokemon)...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan pokemon: %w", err)
 		}
@@ -181,18 +155,42 @@ func (r *PostgresUserPokemonRepository) GetByUserID(ctx context.Context, userID
 	return pokemons, nil
 }
 
-// Update updates Pokemon information
+// Update saves a Pokemon's species, form, ability slot, level, experience, favorite flag, nickname, IVs, EVs and nature
 func (r *PostgresUserPokemonRepository) Update(ctx context.Context, pokemon *domain.UserPokemon) error {
 	query := `
 		UPDATE user_pokemon
-		SET is_favorite = $2, nickname = $3
+		SET is_favorite = $2, nickname = $3,
+			iv_hp = $4, iv_attack = $5, iv_defense = $6,
+			iv_sp_attack = $7, iv_sp_defense = $8, iv_speed = $9, nature = $10,
+			species_id = $11, level = $12, experience = $13,
+			ev_hp = $14, ev_attack = $15, ev_defense = $16,
+			ev_sp_attack = $17, ev_sp_defense = $18, ev_speed = $19,
+			form_id = $20, ability_slot = $21
 		WHERE id = $1
 	`
 
-	result, err := r.pool.Exec(ctx, query,
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
 		pokemon.ID,
 		pokemon.IsFavorite,
 		pokemon.Nickname,
+		pokemon.IVs.HP,
+		pokemon.IVs.Attack,
+		pokemon.IVs.Defense,
+		pokemon.IVs.SpAttack,
+		pokemon.IVs.SpDefense,
+		pokemon.IVs.Speed,
+		pokemon.Nature,
+		pokemon.SpeciesID,
+		pokemon.Level,
+		pokemon.Experience,
+		pokemon.EVs.HP,
+		pokemon.EVs.Attack,
+		pokemon.EVs.Defense,
+		pokemon.EVs.SpAttack,
+		pokemon.EVs.SpDefense,
+		pokemon.EVs.Speed,
+		pokemon.FormID,
+		pokemon.AbilitySlot,
 	)
 
 	if err != nil {
@@ -207,10 +205,10 @@ func (r *PostgresUserPokemonRepository) Update(ctx context.Context, pokemon *dom
 }
 
 // Delete removes a Pokemon
-func (r *PostgresUserPokemonRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM user_pokemon WHERE id = $1`
+func (r *PostgresUserPokemonRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	query := `DELETE FROM user_pokemon WHERE id = $1 AND user_id = $2`
 
-	result, err := r.pool.Exec(ctx, query, id)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, id, userID)
 	if err != nil {
 		return fmt.Errorf("failed to delete pokemon: %w", err)
 	}
@@ -222,15 +220,17 @@ func (r *PostgresUserPokemonRepository) Delete(ctx context.Context, id uuid.UUID
 	return nil
 }
 
-// TransferOwnership changes Pokemon owner
-func (r *PostgresUserPokemonRepository) TransferOwnership(ctx context.Context, pokemonID, newOwnerID uuid.UUID) error {
+// TransferOwnership changes Pokemon owner and resets the acquisition time.
+// Only the expected owner's Pokemon changes hands, so a Pokemon that already
+// moved in a concurrent trade or sale can't move again.
+func (r *PostgresUserPokemonRepository) TransferOwnership(ctx context.Context, pokemonID, fromOwnerID, toOwnerID uuid.UUID) error {
 	query := `
 		UPDATE user_pokemon
-		SET user_id = $2
-		WHERE id = $1
+		SET user_id = $2, acquired_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $3
 	`
 
-	result, err := r.pool.Exec(ctx, query, pokemonID, newOwnerID)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, pokemonID, toOwnerID, fromOwnerID)
 	if err != nil {
 		return fmt.Errorf("failed to transfer ownership: %w", err)
 	}
@@ -247,10 +247,217 @@ func (r *PostgresUserPokemonRepository) CountByUser(ctx context.Context, userID
 	query := `SELECT COUNT(*) FROM user_pokemon WHERE user_id = $1`
 
 	var count int
-	err := r.pool.QueryRow(ctx, query, userID).Scan(&count)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, userID).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count pokemon: %w", err)
 	}
 
 	return count, nil
 }
+
+// SearchByUser retrieves a page of a user's box matching the filter, in
+// the filter's order, with the total number of matching Pokemon
+func (r *PostgresUserPokemonRepository) SearchByUser(ctx context.Context, userID uuid.UUID, filter domain.BoxFilter) (*domain.BoxPage, error) {
+	conditions := []string{"up.user_id = $1"}
+	args := []any{userID}
+
+	add := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Rarity != "" {
+		add("ps.rarity = $%d", filter.Rarity)
+	}
+	if filter.SpeciesID != 0 {
+		add("up.species_id = $%d", filter.SpeciesID)
+	}
+	if filter.SpeciesName != "" {
+		add("LOWER(ps.name) = LOWER($%d)", filter.SpeciesName)
+	}
+	if filter.Type != "" {
+		// Forms replace both of the species' types
+		add("$%d IN (COALESCE(pf.type1, ps.type1), CASE WHEN pf.id IS NULL THEN ps.type2 ELSE pf.type2 END)", string(filter.Type))
+	}
+	if filter.Nature != "" {
+		add("up.nature = $%d", filter.Nature)
+	}
+	if filter.MinIVPercent > 0 {
+		add(ivTotalSQL+" * 100.0 / 186.0 >= $%d", filter.MinIVPercent)
+	}
+	if filter.Favorite != nil {
+		add("up.is_favorite = $%d", *filter.Favorite)
+	}
+	if filter.Shiny != nil {
+		add("up.is_shiny = $%d", *filter.Shiny)
+	}
+	if filter.Nickname != "" {
+		add("up.nickname ILIKE '%%' || $%d || '%%'", likeEscaper.Replace(filter.Nickname))
+	}
+
+	from := `
+		FROM user_pokemon up
+		JOIN pokemon_species ps ON up.species_id = ps.id
+		LEFT JOIN pokemon_forms pf ON pf.id = up.form_id
+		WHERE ` + strings.Join(conditions, " AND ")
+
+	page := &domain.BoxPage{}
+	if err := conn(ctx, r.pool).QueryRow(ctx, "SELECT COUNT(*)"+from, args...).Scan(&page.Total); err != nil {
+		return nil, fmt.Errorf("failed to count box: %w", err)
+	}
+
+	sort := filter.Sort
+	if sort == "" {
+		sort = domain.BoxSortAcquired
+	}
+	key := boxSortKeys[sort]
+
+	direction, after := "DESC", "<"
+	if filter.Ascending {
+		direction, after = "ASC", ">"
+	}
+	if filter.Cursor != nil {
+		args = append(args, filter.Cursor.Key, filter.Cursor.ID)
+		from += fmt.Sprintf(" AND (%s, up.id) %s ($%d, $%d)", key, after, len(args)-1, len(args))
+	}
+
+	// One extra row tells whether there is another page
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = domain.DefaultBoxPageSize
+	}
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(`
+		SELECT %s, %s AS sort_key
+		%s
+		ORDER BY sort_key %s, up.id %s
+		LIMIT $%d
+	`, pokemonColumns, key, from, direction, direction, len(args))
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search box: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []int64
+	for rows.Next() {
+		pokemon := &domain.UserPokemon{
+			Species: &domain.PokemonSpecies{},
+		}
+
+		var sortKey int64
+		if err := rows.Scan(append(pokemonDest(pokemon), &sortKey)...); err != nil {
+			return nil, fmt.Errorf("failed to scan pokemon: %w", err)
+		}
+
+		page.Pokemon = append(page.Pokemon, pokemon)
+		keys = append(keys, sortKey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search box: %w", err)
+	}
+
+	if len(page.Pokemon) > limit {
+		page.Pokemon = page.Pokemon[:limit]
+		last := page.Pokemon[limit-1]
+		page.NextCursor = &domain.BoxCursor{Sort: sort, Key: keys[limit-1], ID: last.ID}
+	}
+
+	return page, nil
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ivTotalSQL is the sum of a Pokemon's IVs, like domain.IVs.TotalIVs
+const ivTotalSQL = "(up.iv_hp + up.iv_attack + up.iv_defense + up.iv_sp_attack + up.iv_sp_defense + up.iv_speed)"
+
+// boxSortKeys computes domain.UserPokemon.BoxSortKey in SQL, over the
+// Pokemon up, its species ps and its form pf
+var boxSortKeys = map[domain.BoxSort]string{
+	domain.BoxSortAcquired: "(EXTRACT(EPOCH FROM up.acquired_at) * 1000000)::bigint",
+	domain.BoxSortIV:       ivTotalSQL,
+	domain.BoxSortValue:    formulaValueSQL(),
+	domain.BoxSortStats:    totalStatsSQL(),
+}
+
+// formulaValueSQL mirrors domain.UserPokemon.FormulaValue, in float8 so it
+// rounds the same way
+func formulaValueSQL() string {
+	cases := make([]string, len(domain.Rarities))
+	for i, rarity := range domain.Rarities {
+		cases[i] = fmt.Sprintf("WHEN '%s' THEN %d", rarity, rarity.BaseValue())
+	}
+	base := "(CASE ps.rarity " + strings.Join(cases, " ") + " ELSE 0 END)"
+
+	var neutral []string
+	for _, nature := range domain.AllNatures() {
+		if increased, _ := nature.GetModifiers(); increased == "" {
+			neutral = append(neutral, "'"+string(nature)+"'")
+		}
+	}
+
+	ivBonus := fmt.Sprintf("TRUNC(%s::float8 * (%s::float8 / 186 * 100 / 100) * 0.5)", base, ivTotalSQL)
+	natureBonus := fmt.Sprintf("(CASE WHEN up.nature IN (%s) THEN 0 ELSE TRUNC(%s * 0.1::float8) END)", strings.Join(neutral, ", "), base)
+	return fmt.Sprintf("TRUNC((%s + %s + %s) * (CASE WHEN up.is_shiny THEN %g ELSE 1 END) * (CASE WHEN up.form_id IS NULL THEN 1 ELSE %g END))::bigint",
+		base, ivBonus, natureBonus, domain.ShinyValueFactor, domain.FormValueFactor)
+}
+
+// totalStatsSQL mirrors domain.UserPokemon.TotalStats. Nature multipliers
+// are applied in tenths, which floors the same as the float multiply.
+func totalStatsSQL() string {
+	stat := func(name string) string {
+		return fmt.Sprintf("(2 * COALESCE(pf.base_%s, ps.base_%s) + up.iv_%s + up.ev_%s / 4) * up.level / 100", name, name, name, name)
+	}
+
+	terms := []string{stat("hp") + " + up.level + 10"}
+	for _, name := range []string{"attack", "defense", "sp_attack", "sp_defense", "speed"} {
+		var raised, lowered []string
+		for _, nature := range domain.AllNatures() {
+			increased, decreased := nature.GetModifiers()
+			if increased == name {
+				raised = append(raised, "'"+string(nature)+"'")
+			}
+			if decreased == name {
+				lowered = append(lowered, "'"+string(nature)+"'")
+			}
+		}
+		terms = append(terms, fmt.Sprintf("(%s + 5) * (CASE WHEN up.nature IN (%s) THEN 11 WHEN up.nature IN (%s) THEN 9 ELSE 10 END) / 10",
+			stat(name), strings.Join(raised, ", "), strings.Join(lowered, ", ")))
+	}
+	return "(" + strings.Join(terms, " + ") + ")::bigint"
+}
+
+// pokemonDest returns the scan destinations for pokemonColumns
+func pokemonDest(pokemon *domain.UserPokemon) []any {
+	return append([]any{
+		&pokemon.ID,
+		&pokemon.UserID,
+		&pokemon.SpeciesID,
+		&pokemon.IVs.HP,
+		&pokemon.IVs.Attack,
+		&pokemon.IVs.Defense,
+		&pokemon.IVs.SpAttack,
+		&pokemon.IVs.SpDefense,
+		&pokemon.IVs.Speed,
+		&pokemon.EVs.HP,
+		&pokemon.EVs.Attack,
+		&pokemon.EVs.Defense,
+		&pokemon.EVs.SpAttack,
+		&pokemon.EVs.SpDefense,
+		&pokemon.EVs.Speed,
+		&pokemon.Nature,
+		&pokemon.IsShiny,
+		&pokemon.Gender,
+		&pokemon.FormID,
+		&pokemon.Form,
+		&pokemon.AbilitySlot,
+		&pokemon.Level,
+		&pokemon.Experience,
+		&pokemon.AcquiredAt,
+		&pokemon.IsFavorite,
+		&pokemon.Nickname,
+	}, speciesDest(pokemon.Species)...)
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/google/uuid"
+)
+
+var ErrNoAbilityCapsule = errors.New("an Ability Capsule is needed to change abilities")
+
+// AbilityService switches a Pokemon between its species' regular abilities
+// using an Ability Capsule from the user's inventory
+type AbilityService struct {
+	pokemonRepo repository.UserPokemonRepository
+	itemRepo    repository.ItemRepository
+	auditRepo   repository.PokemonAuditRepository
+	locks       *pokemonLocks
+	txManager   repository.TxManager
+}
+
+// NewAbilityService creates a new ability service
+func NewAbilityService(
+	pokemonRepo repository.UserPokemonRepository,
+	itemRepo repository.ItemRepository,
+	auditRepo repository.PokemonAuditRepository,
+	listingRepo repository.MarketListingRepository,
+	tradeRepo repository.TradeRepository,
+	battleRepo repository.BattleRepository,
+	txManager repository.TxManager,
+) *AbilityService {
+	return &AbilityService{
+		pokemonRepo: pokemonRepo,
+		itemRepo:    itemRepo,
+		auditRepo:   auditRepo,
+		locks:       &pokemonLocks{listingRepo: listingRepo, tradeRepo: tradeRepo, battleRepo: battleRepo},
+		txManager:   txManager,
+	}
+}
+
+// ChangeAbility switches a user's Pokemon to another regular ability slot,
+// consuming one Ability Capsule
+func (s *AbilityService) ChangeAbility(ctx context.Context, userID, pokemonID uuid.UUID, slot domain.AbilitySlot) (*domain.AbilityChangeResult, error) {
+	if err := validators.ValidateAbilitySlot(slot); err != nil {
+		return nil, err
+	}
+
+	var result *domain.AbilityChangeResult
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		pokemon, err := s.pokemonRepo.GetForUpdate(ctx, pokemonID)
+		if err != nil {
+			return err
+		}
+
+		if pokemon.UserID != userID {
+			return ErrNotPokemonOwner
+		}
+
+		if err := s.locks.check(ctx, pokemon); err != nil {
+			return err
+		}
+
+		if err := validators.ValidateAbilityChange(pokemon, slot); err != nil {
+			return err
+		}
+
+		before := pokemon.Traits()
+		previous := pokemon.Ability()
+		pokemon.AbilitySlot = slot
+		if err := validators.ValidateUserPokemon(pokemon); err != nil {
+			return err
+		}
+
+		if err := s.itemRepo.Adjust(ctx, userID, domain.AbilityCapsule, -1); err != nil {
+			if errors.Is(err, repository.ErrInsufficientItems) {
+				return ErrNoAbilityCapsule
+			}
+			return err
+		}
+
+		if err := s.pokemonRepo.Update(ctx, pokemon); err != nil {
+			return err
+		}
+
+		result = &domain.AbilityChangeResult{Pokemon: pokemon, PreviousAbility: previous}
+		return s.auditRepo.Create(ctx, domain.NewPokemonAuditEntry(pokemon, domain.AuditAbilityCapsule, 0, before))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAuctionEnded        = errors.New("auction has ended")
+	ErrBidTooLow           = errors.New("bid is below the minimum")
+	ErrCannotBidOwnAuction = errors.New("cannot bid on your own auction")
+	ErrAuctionHasBids      = errors.New("cannot cancel an auction that has bids")
+)
+
+// AuctionService handles timed auctions: bidding with escrowed coins,
+// anti-sniping extensions and settlement when an auction ends
+type AuctionService struct {
+	userRepo         repository.UserRepository
+	pokemonRepo      repository.UserPokemonRepository
+	listingRepo      repository.MarketListingRepository
+	auctionRepo      repository.AuctionRepository
+	transactionRepo  repository.MarketTransactionRepository
+	notificationRepo repository.NotificationRepository
+	txManager        repository.TxManager
+	locks            *pokemonLocks
+	events           EventRecorder
+}
+
+// NewAuctionService creates a new auction service
+func NewAuctionService(
+	userRepo repository.UserRepository,
+	pokemonRepo repository.UserPokemonRepository,
+	listingRepo repository.MarketListingRepository,
+	auctionRepo repository.AuctionRepository,
+	transactionRepo repository.MarketTransactionRepository,
+	notificationRepo repository.NotificationRepository,
+	tradeRepo repository.TradeRepository,
+	battleRepo repository.BattleRepository,
+	txManager repository.TxManager,
+) *AuctionService {
+	return &AuctionService{
+		userRepo:         userRepo,
+		pokemonRepo:      pokemonRepo,
+		listingRepo:      listingRepo,
+		auctionRepo:      auctionRepo,
+		transactionRepo:  transactionRepo,
+		notificationRepo: notificationRepo,
+		txManager:        txManager,
+		// Listing twice is left to the listing repository, which reports ErrAlreadyListed
+		locks: &pokemonLocks{tradeRepo: tradeRepo, battleRepo: battleRepo},
+	}
+}
+
+// SetEventRecorder reports every auction sale, and the Pokemon the winner
+// obtained, to a recorder such as the quest service
+func (s *AuctionService) SetEventRecorder(recorder EventRecorder) {
+	s.events = recorder
+}
+
+// CreateAuction puts one of the seller's Pokemon up for auction
+func (s *AuctionService) CreateAuction(ctx context.Context, sellerID, pokemonID uuid.UUID, startingPrice, minIncrement int, duration time.Duration) (*domain.Auction, error) {
+	if err := validators.ValidateAuction(startingPrice, minIncrement, duration); err != nil {
+		return nil, err
+	}
+
+	pokemon, err := s.pokemonRepo.GetByID(ctx, pokemonID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pokemon.UserID != sellerID {
+		return nil, ErrNotPokemonOwner
+	}
+
+	if err := s.locks.check(ctx, pokemon); err != nil {
+		return nil, err
+	}
+
+	auction := domain.NewAuction(pokemon, startingPrice, minIncrement, duration)
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.listingRepo.Create(ctx, auction.Listing); err != nil {
+			return err
+		}
+		return s.auctionRepo.Create(ctx, auction)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return auction, nil
+}
+
+// PlaceBid bids on an auction. The bid's coins are taken from the bidder
+// and the previous highest bidder is refunded in the same transaction.
+func (s *AuctionService) PlaceBid(ctx context.Context, bidderID, listingID uuid.UUID, amount int) (*domain.Auction, error) {
+	if err := validators.ValidateListingPrice(amount); err != nil {
+		return nil, err
+	}
+
+	var auction *domain.Auction
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		auction, err = s.auctionRepo.GetForUpdate(ctx, listingID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		switch {
+		case !auction.IsActive():
+			return ErrListingUnavailable
+		case auction.HasEnded(now):
+			return ErrAuctionEnded
+		case auction.SellerID() == bidderID:
+			return ErrCannotBidOwnAuction
+		case amount < auction.MinimumBid():
+			return fmt.Errorf("%w of %d coins", ErrBidTooLow, auction.MinimumBid())
+		}
+
+		previousBid, previousBidder := auction.CurrentBid, auction.HighestBidderID
+
+		// Refund first, so a bidder raising their own bid only needs the difference
+		if previousBidder != nil {
+			if err := s.userRepo.AdjustCoins(ctx, *previousBidder, previousBid); err != nil {
+				return fmt.Errorf("failed to refund previous bid: %w", err)
+			}
+		}
+
+		if err := s.userRepo.AdjustCoins(ctx, bidderID, -amount); err != nil {
+			if errors.Is(err, repository.ErrInsufficientCoins) {
+				return ErrInsufficientFunds
+			}
+			return fmt.Errorf("failed to hold bid: %w", err)
+		}
+
+		extended := auction.ApplyBid(bidderID, amount, now)
+		bid := domain.NewAuctionBid(auction, bidderID, amount)
+		if err := s.auctionRepo.PlaceBid(ctx, auction, bid, previousBid); err != nil {
+			return err
+		}
+
+		if previousBidder != nil && *previousBidder != bidderID {
+			message := fmt.Sprintf("Someone bid %d coins on **%s**. Your %d coins have been refunded.",
+				amount, auction.Listing.Pokemon.DisplayName(), previousBid)
+			if extended {
+				message += " The auction was extended by a minute."
+			}
+			return s.notify(ctx, *previousBidder, domain.NotificationAuctionOutbid, "You've been outbid", message)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return auction, nil
+}
+
+// CancelAuction takes an auction off the market. Only auctions without bids can be cancelled.
+func (s *AuctionService) CancelAuction(ctx context.Context, sellerID, listingID uuid.UUID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		auction, err := s.auctionRepo.GetForUpdate(ctx, listingID)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case auction.SellerID() != sellerID:
+			return ErrNotListingSeller
+		case !auction.IsActive():
+			return ErrListingUnavailable
+		case auction.HasBids():
+			return ErrAuctionHasBids
+		}
+
+		if err := s.listingRepo.UpdateStatus(ctx, listingID, domain.ListingStatusCancelled); err != nil {
+			if errors.Is(err, repository.ErrListingNotActive) {
+				return ErrListingUnavailable
+			}
+			return err
+		}
+
+		auction.Listing.Status = domain.ListingStatusCancelled
+		return nil
+	})
+}
+
+// GetAuction retrieves an auction by its listing ID
+func (s *AuctionService) GetAuction(ctx context.Context, listingID uuid.UUID) (*domain.Auction, error) {
+	return s.auctionRepo.GetByListingID(ctx, listingID)
+}
+
+// ListAuctions retrieves active auctions, ending soonest first
+func (s *AuctionService) ListAuctions(ctx context.Context, limit, offset int) ([]*domain.Auction, error) {
+	return s.auctionRepo.ListActive(ctx, limit, offset)
+}
+
+// ListBids retrieves an auction's bids, newest first
+func (s *AuctionService) ListBids(ctx context.Context, listingID uuid.UUID) ([]*domain.AuctionBid, error) {
+	return s.auctionRepo.ListBids(ctx, listingID)
+}
+
+// SettleEndedAuctions settles every active auction past its end time.
+// Returns the number of auctions settled.
+func (s *AuctionService) SettleEndedAuctions(ctx context.Context) (int, error) {
+	auctions, err := s.auctionRepo.ListEnded(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to list ended auctions: %w", err)
+	}
+
+	settled := 0
+	var errs []error
+
+	for _, auction := range auctions {
+		done, err := s.settleAuction(ctx, auction.ListingID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("auction %s: %w", auction.ListingID, err))
+			continue
+		}
+		if done {
+			settled++
+		}
+	}
+
+	return settled, errors.Join(errs...)
+}
+
+// RunSettlementScheduler settles ended auctions until ctx is cancelled.
+// It runs once immediately to catch auctions that ended while the server was down.
+func (s *AuctionService) RunSettlementScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := s.SettleEndedAuctions(ctx); err != nil {
+			log.Printf("auction scheduler: settled %d auctions with errors: %v", n, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// settleAuction closes an ended auction. With a winner, the Pokemon and the
+// held bid (minus the fee) change hands; without bids the listing expires.
+// Returns false if the auction was already settled or has been extended.
+func (s *AuctionService) settleAuction(ctx context.Context, listingID uuid.UUID) (bool, error) {
+	settled := false
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		auction, err := s.auctionRepo.GetForUpdate(ctx, listingID)
+		if err != nil {
+			return err
+		}
+
+		// Settled by another run, or extended by a late bid
+		if !auction.IsActive() || !auction.HasEnded(time.Now()) {
+			return nil
+		}
+
+		sellerID := auction.SellerID()
+		name := auction.Listing.Pokemon.DisplayName()
+
+		if !auction.HasBids() {
+			if err := s.listingRepo.UpdateStatus(ctx, listingID, domain.ListingStatusExpired); err != nil {
+				return err
+			}
+			settled = true
+			return s.notify(ctx, sellerID, domain.NotificationAuctionExpired, "Auction ended",
+				fmt.Sprintf("Your auction for **%s** ended without any bids.", name))
+		}
+
+		winnerID := *auction.HighestBidderID
+
+		// The seller must still own the Pokemon, otherwise the winner gets their bid back
+		pokemon, err := s.pokemonRepo.GetByID(ctx, auction.Listing.UserPokemonID)
+		if err != nil || pokemon.UserID != sellerID {
+			if err := s.listingRepo.UpdateStatus(ctx, listingID, domain.ListingStatusCancelled); err != nil {
+				return err
+			}
+			if err := s.userRepo.AdjustCoins(ctx, winnerID, auction.CurrentBid); err != nil {
+				return fmt.Errorf("failed to refund winning bid: %w", err)
+			}
+			settled = true
+			return s.notify(ctx, winnerID, domain.NotificationAuctionExpired, "Auction cancelled",
+				fmt.Sprintf("The auction for **%s** was cancelled. Your %d coins have been refunded.", name, auction.CurrentBid))
+		}
+
+		if err := s.listingRepo.UpdateStatus(ctx, listingID, domain.ListingStatusSold); err != nil {
+			return err
+		}
+
+		if err := s.pokemonRepo.TransferOwnership(ctx, auction.Listing.UserPokemonID, sellerID, winnerID); err != nil {
+			return fmt.Errorf("failed to transfer pokemon: %w", err)
+		}
+
+		// The winner's coins were taken when they bid, so only the seller is paid here
+		if err := s.userRepo.AdjustCoins(ctx, sellerID, auction.SellerProceeds()); err != nil {
+			return fmt.Errorf("failed to pay seller: %w", err)
+		}
+
+		if err := s.transactionRepo.Create(ctx, domain.NewAuctionTransaction(auction)); err != nil {
+			return err
+		}
+		if err := recordEvents(ctx, s.events,
+			domain.NewGameEvent(domain.EventMarketSale, sellerID, 1),
+			domain.NewObtainedEvent(winnerID, pokemon.SpeciesID)); err != nil {
+			return err
+		}
+
+		if err := s.notify(ctx, winnerID, domain.NotificationAuctionWon, "You won an auction!",
+			fmt.Sprintf("You won **%s** for %d coins. It's now in your `/box`.", name, auction.CurrentBid)); err != nil {
+			return err
+		}
+
+		settled = true
+		return s.notify(ctx, sellerID, domain.NotificationAuctionSold, "Auction sold!",
+			fmt.Sprintf("**%s** sold for %d coins. You received %d coins after the %d coin fee.",
+				name, auction.CurrentBid, auction.SellerProceeds(), auction.Fee()))
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return settled, nil
+}
+
+// notify queues a Discord notification as part of the current transaction
+func (s *AuctionService) notify(ctx context.Context, userID uuid.UUID, notificationType domain.NotificationType, title, message string) error {
+	notification := domain.NewNotification(userID, notificationType, title, message)
+	if err := s.notificationRepo.Create(ctx, notification); err != nil {
+		return fmt.Errorf("failed to queue notification: %w", err)
+	}
+	return nil
+}
@@ -1,15 +1,17 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log"
 	"math/rand"
 	"sync"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/danielyang21/GoBattleServer/internal/domain"
 	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/google/uuid"
 )
 
 var (
@@ -18,21 +20,26 @@ var (
 	ErrNotYourTurn         = errors.New("not your turn")
 	ErrInvalidAction       = errors.New("invalid action")
 	ErrBattleNotActive     = errors.New("battle is not active")
-	ErrInsufficientCoins   = errors.New("insufficient coins for wager")
+	ErrInsufficientWager   = errors.New("insufficient coins for wager")
 	ErrInvalidPokemon      = errors.New("invalid Pokemon selection")
 	ErrPlayerNotInBattle   = errors.New("player not in this battle")
+	ErrUnknownFormat       = errors.New("unknown battle format")
 )
 
 // BattleService handles battle logic and state management
 type BattleService struct {
-	userRepo           repository.UserRepository
-	pokemonRepo        repository.UserPokemonRepository
-	battleRepo         repository.BattleRepository
-	turnResolver       *domain.TurnResolver
-	activeBattles      map[uuid.UUID]*domain.BattleState // battleID -> state
-	playerBattles      map[uuid.UUID]uuid.UUID           // userID -> battleID
-	mu                 sync.RWMutex
-	rand               *rand.Rand
+	userRepo      repository.UserRepository
+	pokemonRepo   repository.UserPokemonRepository
+	battleRepo    repository.BattleRepository
+	escrowRepo    repository.EscrowRepository
+	txManager     repository.TxManager
+	turnResolver  *domain.TurnResolver
+	activeBattles map[uuid.UUID]*domain.BattleState // battleID -> state
+	playerBattles map[uuid.UUID]uuid.UUID           // userID -> battleID
+	lastActivity  map[uuid.UUID]time.Time           // battleID -> last action or selection time
+	mu            sync.RWMutex
+	rand          *rand.Rand
+	events        EventRecorder
 }
 
 // NewBattleService creates a new battle service
@@ -40,22 +47,39 @@ func NewBattleService(
 	userRepo repository.UserRepository,
 	pokemonRepo repository.UserPokemonRepository,
 	battleRepo repository.BattleRepository,
+	escrowRepo repository.EscrowRepository,
+	txManager repository.TxManager,
 ) *BattleService {
 	source := rand.NewSource(time.Now().UnixNano())
 	return &BattleService{
 		userRepo:      userRepo,
 		pokemonRepo:   pokemonRepo,
 		battleRepo:    battleRepo,
+		escrowRepo:    escrowRepo,
+		txManager:     txManager,
 		turnResolver:  domain.NewTurnResolver(source),
 		activeBattles: make(map[uuid.UUID]*domain.BattleState),
 		playerBattles: make(map[uuid.UUID]uuid.UUID),
+		lastActivity:  make(map[uuid.UUID]time.Time),
 		mu:            sync.RWMutex{},
 		rand:          rand.New(source),
 	}
 }
 
-// CreateBattle creates a new battle challenge
-func (s *BattleService) CreateBattle(challengerID, opponentID uuid.UUID, wagerAmount int) (*domain.Battle, error) {
+// SetEventRecorder reports wins, super-effective hits and species seen in
+// finished battles to a recorder, such as the quest service
+func (s *BattleService) SetEventRecorder(recorder EventRecorder) {
+	s.events = recorder
+}
+
+// CreateBattle creates a new battle challenge played under the named
+// format. An empty format is the standard format.
+func (s *BattleService) CreateBattle(ctx context.Context, challengerID, opponentID uuid.UUID, wagerAmount int, formatName string) (*domain.Battle, error) {
+	format, exists := domain.GetBattleFormat(formatName)
+	if !exists {
+		return nil, ErrUnknownFormat
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -68,47 +92,49 @@ func (s *BattleService) CreateBattle(challengerID, opponentID uuid.UUID, wagerAm
 	}
 
 	// Verify both users exist and have sufficient coins
-	challenger, err := s.userRepo.GetByID(challengerID)
+	challenger, err := s.userRepo.GetByID(ctx, challengerID)
 	if err != nil {
 		return nil, fmt.Errorf("challenger not found: %w", err)
 	}
 
-	opponent, err := s.userRepo.GetByID(opponentID)
+	opponent, err := s.userRepo.GetByID(ctx, opponentID)
 	if err != nil {
 		return nil, fmt.Errorf("opponent not found: %w", err)
 	}
 
 	if !challenger.HasCoins(wagerAmount) {
-		return nil, fmt.Errorf("challenger: %w", ErrInsufficientCoins)
+		return nil, fmt.Errorf("challenger: %w", ErrInsufficientWager)
 	}
 
 	if !opponent.HasCoins(wagerAmount) {
-		return nil, fmt.Errorf("opponent: %w", ErrInsufficientCoins)
+		return nil, fmt.Errorf("opponent: %w", ErrInsufficientWager)
 	}
 
 	// Create battle
 	battle := domain.NewBattle(challengerID, opponentID, wagerAmount)
 	battle.Status = domain.BattleStatusWaitingForPlayers
+	battle.Format = format.Name
 
 	// Save to database
-	if err := s.battleRepo.Create(battle); err != nil {
+	if err := s.battleRepo.Create(ctx, battle); err != nil {
 		return nil, fmt.Errorf("failed to create battle: %w", err)
 	}
 
-	// Track player battles
+	// Track player battles, so the sweeper can abandon challenges that never start
 	s.playerBattles[challengerID] = battle.ID
 	s.playerBattles[opponentID] = battle.ID
+	s.lastActivity[battle.ID] = time.Now()
 
 	return battle, nil
 }
 
 // SelectPokemon allows a player to select their Pokemon for battle
-func (s *BattleService) SelectPokemon(battleID, playerID, pokemonID uuid.UUID) error {
+func (s *BattleService) SelectPokemon(ctx context.Context, battleID, playerID, pokemonID uuid.UUID) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	// Get battle
-	battle, err := s.battleRepo.GetByID(battleID)
+	battle, err := s.battleRepo.GetByID(ctx, battleID)
 	if err != nil {
 		return ErrBattleNotFound
 	}
@@ -119,7 +145,7 @@ func (s *BattleService) SelectPokemon(battleID, playerID, pokemonID uuid.UUID) e
 	}
 
 	// Verify Pokemon belongs to player
-	pokemon, err := s.pokemonRepo.GetByID(pokemonID)
+	pokemon, err := s.pokemonRepo.GetByID(ctx, pokemonID)
 	if err != nil || pokemon.UserID != playerID {
 		return ErrInvalidPokemon
 	}
@@ -132,61 +158,71 @@ func (s *BattleService) SelectPokemon(battleID, playerID, pokemonID uuid.UUID) e
 	}
 
 	// Update battle
-	if err := s.battleRepo.Update(battle); err != nil {
+	if err := s.battleRepo.Update(ctx, battle); err != nil {
 		return fmt.Errorf("failed to update battle: %w", err)
 	}
 
 	// If both players have selected, start the battle
 	if battle.Player1Pokemon != uuid.Nil && battle.Player2Pokemon != uuid.Nil {
-		return s.startBattle(battle)
+		return s.startBattle(ctx, battle)
 	}
+	s.lastActivity[battle.ID] = time.Now()
 
 	return nil
 }
 
-// startBattle initializes the battle state
-func (s *BattleService) startBattle(battle *domain.Battle) error {
+// startBattle locks both wagers in escrow and initializes the battle state
+func (s *BattleService) startBattle(ctx context.Context, battle *domain.Battle) error {
 	// Load both Pokemon with full details
-	p1Pokemon, err := s.pokemonRepo.GetByID(battle.Player1Pokemon)
+	p1Pokemon, err := s.pokemonRepo.GetByID(ctx, battle.Player1Pokemon)
 	if err != nil {
 		return fmt.Errorf("failed to load player 1 pokemon: %w", err)
 	}
 
-	p2Pokemon, err := s.pokemonRepo.GetByID(battle.Player2Pokemon)
+	p2Pokemon, err := s.pokemonRepo.GetByID(ctx, battle.Player2Pokemon)
 	if err != nil {
 		return fmt.Errorf("failed to load player 2 pokemon: %w", err)
 	}
 
-	// Create battle Pokemon
-	p1BattlePokemon := s.createBattlePokemon(p1Pokemon)
-	p2BattlePokemon := s.createBattlePokemon(p2Pokemon)
-
-	// Initialize battle state
-	battle.InitializeBattleState(p1BattlePokemon, p2BattlePokemon)
-	battle.Status = domain.BattleStatusInProgress
+	// Create battle Pokemon at the format's levels
+	format, exists := domain.GetBattleFormat(battle.Format)
+	if !exists {
+		return ErrUnknownFormat
+	}
+	p1BattlePokemon := createBattlePokemon(p1Pokemon, format)
+	p2BattlePokemon := createBattlePokemon(p2Pokemon, format)
 	now := time.Now()
-	battle.StartedAt = &now
 
-	// Deduct wager from both players
-	if err := s.userRepo.UpdateCoins(battle.Player1ID, -battle.WagerAmount); err != nil {
-		return fmt.Errorf("failed to deduct wager from player 1: %w", err)
-	}
-	if err := s.userRepo.UpdateCoins(battle.Player2ID, -battle.WagerAmount); err != nil {
-		// Refund player 1 if player 2 fails
-		s.userRepo.UpdateCoins(battle.Player1ID, battle.WagerAmount)
-		return fmt.Errorf("failed to deduct wager from player 2: %w", err)
-	}
+	// Lock both wagers and mark the battle live together. Either both stakes
+	// are taken and the battle is running, or nothing changes.
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.escrowRepo.Lock(ctx, domain.NewBattleEscrow(battle)); err != nil {
+			return err
+		}
 
-	// Update battle in database
-	if err := s.battleRepo.Update(battle); err != nil {
-		// Refund both players if update fails
-		s.userRepo.UpdateCoins(battle.Player1ID, battle.WagerAmount)
-		s.userRepo.UpdateCoins(battle.Player2ID, battle.WagerAmount)
-		return fmt.Errorf("failed to update battle: %w", err)
+		// Initialize battle state
+		battle.InitializeBattleState(p1BattlePokemon, p2BattlePokemon)
+		battle.Status = domain.BattleStatusInProgress
+		battle.StartedAt = &now
+
+		return s.battleRepo.Update(ctx, battle)
+	})
+	if err != nil {
+		battle.Status = domain.BattleStatusAbandoned
+		s.untrackBattle(battle.ID)
+		if updateErr := s.battleRepo.Update(ctx, battle); updateErr != nil {
+			return fmt.Errorf("failed to abandon battle after %v: %w", err, updateErr)
+		}
+
+		if errors.Is(err, repository.ErrInsufficientCoins) {
+			return ErrInsufficientWager
+		}
+		return fmt.Errorf("failed to start battle: %w", err)
 	}
 
 	// Store active battle state
 	s.activeBattles[battle.ID] = battle.State
+	s.lastActivity[battle.ID] = now
 
 	// Log battle start
 	battle.State.AddLogEntry("battle_start", "Battle has started!", map[string]interface{}{
@@ -194,12 +230,15 @@ func (s *BattleService) startBattle(battle *domain.Battle) error {
 		"player2": battle.Player2ID,
 		"wager":   battle.WagerAmount,
 	})
+	battle.State.ApplyEntryAbilities()
 
 	return nil
 }
 
-// createBattlePokemon creates a BattlePokemon from a UserPokemon
-func (s *BattleService) createBattlePokemon(pokemon *domain.UserPokemon) *domain.BattlePokemon {
+// createBattlePokemon creates a BattlePokemon from a UserPokemon at the
+// level the format lets it battle at
+func createBattlePokemon(pokemon *domain.UserPokemon, format *domain.BattleFormat) *domain.BattlePokemon {
+	pokemon = pokemon.AtLevel(format.EffectiveLevel(pokemon.Level))
 	stats := pokemon.GetStats()
 
 	// TODO: Load moves from database
@@ -210,29 +249,29 @@ func (s *BattleService) createBattlePokemon(pokemon *domain.UserPokemon) *domain
 	}
 
 	return &domain.BattlePokemon{
-		UserPokemonID: pokemon.ID,
-		Species:       pokemon.Species,
-		Level:         pokemon.Level,
-		CurrentHP:     stats.HP,
-		MaxHP:         stats.HP,
-		Stats:         stats,
-		IVs:           pokemon.IVs,
-		Nature:        pokemon.Nature,
-		Ability:       "", // TODO: Load from species
-		HeldItem:      "", // TODO: Load from user pokemon
-		Moves:         moves,
-		Status:        domain.StatusNone,
-		StatusTurns:   0,
-		StatStages:    domain.StatStages{},
+		UserPokemonID:  pokemon.ID,
+		Species:        pokemon.EffectiveSpecies(),
+		Level:          pokemon.Level,
+		CurrentHP:      stats.HP,
+		MaxHP:          stats.HP,
+		Stats:          stats,
+		IVs:            pokemon.IVs,
+		Nature:         pokemon.Nature,
+		Ability:        pokemon.Ability(),
+		HeldItem:       "", // TODO: Load from user pokemon
+		Moves:          moves,
+		Status:         domain.StatusNone,
+		StatusTurns:    0,
+		StatStages:     domain.StatStages{},
 		VolatileStatus: []string{},
-		MovePP:        []int{35, 30, 0, 0}, // TODO: Match moves
-		ItemConsumed:  false,
-		Fainted:       false,
+		MovePP:         []int{35, 30, 0, 0}, // TODO: Match moves
+		ItemConsumed:   false,
+		Fainted:        false,
 	}
 }
 
 // SubmitAction submits a player's action for the current turn
-func (s *BattleService) SubmitAction(battleID, playerID uuid.UUID, actionType domain.BattleActionType, moveIndex int) (*domain.BattleState, error) {
+func (s *BattleService) SubmitAction(ctx context.Context, battleID, playerID uuid.UUID, actionType domain.BattleActionType, moveIndex int) (*domain.BattleState, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -284,25 +323,29 @@ func (s *BattleService) SubmitAction(battleID, playerID uuid.UUID, actionType do
 
 	// Set player action
 	state.SetPlayerAction(playerID, action)
+	s.lastActivity[battleID] = time.Now()
 
 	// If both players ready, resolve turn
 	if state.BothPlayersReady() {
-		return state, s.resolveTurn(battleID, state)
+		return state, s.resolveTurn(ctx, battleID, state)
 	}
 
 	return state, nil
 }
 
 // resolveTurn resolves the current turn
-func (s *BattleService) resolveTurn(battleID uuid.UUID, state *domain.BattleState) error {
+func (s *BattleService) resolveTurn(ctx context.Context, battleID uuid.UUID, state *domain.BattleState) error {
 	state.Phase = domain.BattleStatusResolvingTurn
 
 	// Resolve turn using turn resolver
 	resolution := s.turnResolver.ResolveTurn(state)
 
 	// Check if battle ended
-	if resolution.BattleEnded && resolution.Winner != nil {
-		return s.endBattle(battleID, *resolution.Winner)
+	if resolution.BattleEnded {
+		if state.IsDraw() || resolution.Winner == nil {
+			return s.drawBattle(ctx, battleID)
+		}
+		return s.endBattle(ctx, battleID, *resolution.Winner)
 	}
 
 	// Set phase back to waiting for actions
@@ -311,47 +354,216 @@ func (s *BattleService) resolveTurn(battleID uuid.UUID, state *domain.BattleStat
 	return nil
 }
 
-// endBattle ends the battle and awards winner
-func (s *BattleService) endBattle(battleID, winnerID uuid.UUID) error {
+// endBattle ends the battle and pays the escrowed pot to the winner
+func (s *BattleService) endBattle(ctx context.Context, battleID, winnerID uuid.UUID) error {
 	// Get battle from database
-	battle, err := s.battleRepo.GetByID(battleID)
+	battle, err := s.battleRepo.GetByID(ctx, battleID)
 	if err != nil {
 		return err
 	}
 
-	// Set winner
-	battle.WinnerID = &winnerID
-	battle.Status = domain.BattleStatusCompleted
-	now := time.Now()
-	battle.CompletedAt = &now
+	// Pay out the pot, record the winner and award experience together
+	var gains []*domain.ExperienceGain
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.escrowRepo.PayOut(ctx, battleID, winnerID); err != nil {
+			return fmt.Errorf("failed to pay out wager: %w", err)
+		}
+
+		// Set winner
+		battle.WinnerID = &winnerID
+		battle.Status = domain.BattleStatusCompleted
+
+		awarded, err := s.awardExperience(ctx, battle.ID, &winnerID)
+		if err != nil {
+			return err
+		}
+		gains = awarded
 
-	// Award winner (2x wager)
-	totalPrize := battle.WagerAmount * 2
-	if err := s.userRepo.UpdateCoins(winnerID, totalPrize); err != nil {
-		return fmt.Errorf("failed to award winner: %w", err)
+		if err := s.recordBattle(ctx, battle.ID, &winnerID); err != nil {
+			return err
+		}
+		return s.saveResult(ctx, battle)
+	})
+	if err != nil {
+		return err
 	}
 
-	// Update battle in database
-	if err := s.battleRepo.Update(battle); err != nil {
-		// Try to refund if database update fails
-		s.userRepo.UpdateCoins(winnerID, -totalPrize)
-		return fmt.Errorf("failed to update battle: %w", err)
+	s.finishBattle(battle, "battle_end", fmt.Sprintf("Battle ended! Winner: %s", winnerID), map[string]interface{}{
+		"winner":     winnerID,
+		"prize":      battle.WagerAmount * 2,
+		"experience": gains,
+	})
+
+	return nil
+}
+
+// drawBattle ends the battle without a winner and refunds both wagers
+func (s *BattleService) drawBattle(ctx context.Context, battleID uuid.UUID) error {
+	battle, err := s.battleRepo.GetByID(ctx, battleID)
+	if err != nil {
+		return err
 	}
 
-	// Remove from active battles
-	delete(s.activeBattles, battleID)
-	delete(s.playerBattles, battle.Player1ID)
-	delete(s.playerBattles, battle.Player2ID)
+	var gains []*domain.ExperienceGain
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.escrowRepo.Refund(ctx, battleID); err != nil {
+			return fmt.Errorf("failed to refund wager: %w", err)
+		}
 
-	// Log battle end
-	battle.State.AddLogEntry("battle_end", fmt.Sprintf("Battle ended! Winner: %s", winnerID), map[string]interface{}{
-		"winner": winnerID,
-		"prize":  totalPrize,
+		battle.Status = domain.BattleStatusCompleted
+
+		awarded, err := s.awardExperience(ctx, battle.ID, nil)
+		if err != nil {
+			return err
+		}
+		gains = awarded
+
+		if err := s.recordBattle(ctx, battle.ID, nil); err != nil {
+			return err
+		}
+		return s.saveResult(ctx, battle)
 	})
+	if err != nil {
+		return err
+	}
+
+	s.finishBattle(battle, "battle_draw", "Battle ended in a draw! Wagers refunded.", map[string]interface{}{
+		"refund":     battle.WagerAmount,
+		"experience": gains,
+	})
+
+	return nil
+}
+
+// abandonBattle refunds both wagers and marks the battle as abandoned.
+// The coins are refunded even if the battle record is missing.
+func (s *BattleService) abandonBattle(ctx context.Context, battleID uuid.UUID) error {
+	battle, err := s.battleRepo.GetByID(ctx, battleID)
+	if err != nil {
+		if refundErr := s.refundIfHeld(ctx, battleID); refundErr != nil {
+			return refundErr
+		}
+		s.untrackBattle(battleID)
+		return fmt.Errorf("wager refunded but battle not found: %w", err)
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.refundIfHeld(ctx, battleID); err != nil {
+			return err
+		}
+
+		battle.Status = domain.BattleStatusAbandoned
+
+		return s.saveResult(ctx, battle)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.finishBattle(battle, "battle_abandoned", "Battle was abandoned. Wagers refunded.", map[string]interface{}{
+		"refund": battle.WagerAmount,
+	})
+
+	return nil
+}
+
+// awardExperience gives both Pokemon of a finished battle experience for
+// the opponent they faced. Without a winner both sides earn the loser's share.
+func (s *BattleService) awardExperience(ctx context.Context, battleID uuid.UUID, winnerID *uuid.UUID) ([]*domain.ExperienceGain, error) {
+	state, exists := s.activeBattles[battleID]
+	if !exists {
+		return nil, nil
+	}
+
+	var gains []*domain.ExperienceGain
+	for _, player := range []*domain.BattlePlayer{state.Player1, state.Player2} {
+		opponent := state.GetOpponent(player.UserID).Pokemon
+		won := winnerID != nil && *winnerID == player.UserID
+
+		gain, err := grantExperience(ctx, s.pokemonRepo, player.Pokemon.UserPokemonID,
+			domain.BattleExperience(opponent.Species, opponent.Level, won))
+		if err != nil {
+			return nil, err
+		}
+		gains = append(gains, gain)
+	}
+
+	return gains, nil
+}
+
+// recordBattle reports both players' super-effective hits in a finished
+// battle, the win if there is a winner, and each other's species as seen
+func (s *BattleService) recordBattle(ctx context.Context, battleID uuid.UUID, winnerID *uuid.UUID) error {
+	state, exists := s.activeBattles[battleID]
+	if !exists {
+		return nil
+	}
+
+	events := battleEvents(state.Player1, winnerID)
+	events = append(events, battleEvents(state.Player2, winnerID)...)
+	events = append(events,
+		domain.NewSeenEvent(state.Player1.UserID, state.Player2.Pokemon.Species.ID),
+		domain.NewSeenEvent(state.Player2.UserID, state.Player1.Pokemon.Species.ID))
+	return recordEvents(ctx, s.events, events...)
+}
+
+// battleEvents returns the events a player's side of a finished battle
+// counts for
+func battleEvents(player *domain.BattlePlayer, winnerID *uuid.UUID) []domain.GameEvent {
+	var events []domain.GameEvent
+	if winnerID != nil && *winnerID == player.UserID {
+		events = append(events, domain.NewGameEvent(domain.EventBattleWon, player.UserID, 1))
+	}
+	if player.SuperEffectiveHits > 0 {
+		events = append(events, domain.NewGameEvent(domain.EventSuperEffectiveHit, player.UserID, player.SuperEffectiveHits))
+	}
+	return events
+}
+
+// refundIfHeld refunds a battle's escrow, ignoring battles that never locked a wager
+func (s *BattleService) refundIfHeld(ctx context.Context, battleID uuid.UUID) error {
+	if err := s.escrowRepo.Refund(ctx, battleID); err != nil && !errors.Is(err, repository.ErrEscrowNotFound) {
+		return fmt.Errorf("failed to refund wager: %w", err)
+	}
+	return nil
+}
+
+// saveResult records the final battle state. It runs in the same
+// transaction as the escrow settlement, so a failure here undoes the payout.
+func (s *BattleService) saveResult(ctx context.Context, battle *domain.Battle) error {
+	now := time.Now()
+	battle.CompletedAt = &now
 
+	if err := s.battleRepo.Update(ctx, battle); err != nil {
+		return fmt.Errorf("failed to update battle: %w", err)
+	}
 	return nil
 }
 
+// finishBattle logs the battle result and stops tracking it
+func (s *BattleService) finishBattle(battle *domain.Battle, logType, message string, data map[string]interface{}) {
+	// Log battle end
+	if state, exists := s.activeBattles[battle.ID]; exists {
+		state.Phase = battle.Status
+		state.AddLogEntry(logType, message, data)
+	}
+
+	// Remove from active battles
+	s.untrackBattle(battle.ID)
+}
+
+// untrackBattle removes a battle from in-memory tracking. Players are
+// found through the tracking itself, so this works without the battle record.
+func (s *BattleService) untrackBattle(battleID uuid.UUID) {
+	delete(s.activeBattles, battleID)
+	delete(s.lastActivity, battleID)
+	for playerID, id := range s.playerBattles {
+		if id == battleID {
+			delete(s.playerBattles, playerID)
+		}
+	}
+}
+
 // GetBattleState returns the current battle state
 func (s *BattleService) GetBattleState(battleID uuid.UUID) (*domain.BattleState, error) {
 	s.mu.RLock()
@@ -379,7 +591,7 @@ func (s *BattleService) GetPlayerBattle(playerID uuid.UUID) (uuid.UUID, error) {
 }
 
 // ForfeitBattle allows a player to forfeit the battle
-func (s *BattleService) ForfeitBattle(battleID, playerID uuid.UUID) error {
+func (s *BattleService) ForfeitBattle(ctx context.Context, battleID, playerID uuid.UUID) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -388,25 +600,95 @@ func (s *BattleService) ForfeitBattle(battleID, playerID uuid.UUID) error {
 		return ErrBattleNotFound
 	}
 
-	// Determine winner (the opponent)
-	var winnerID uuid.UUID
-	if state.Player1.UserID == playerID {
-		winnerID = state.Player2.UserID
-	} else {
-		winnerID = state.Player1.UserID
+	if state.GetPlayer(playerID) == nil {
+		return ErrPlayerNotInBattle
+	}
+
+	// The opponent wins
+	winnerID := state.GetOpponent(playerID).UserID
+
+	return s.endBattle(ctx, battleID, winnerID)
+}
+
+// AbandonInactiveBattles refunds and abandons every active battle with no
+// actions, and every challenge still waiting for players, for longer than
+// the timeout. Returns the number of battles abandoned.
+func (s *BattleService) AbandonInactiveBattles(ctx context.Context, timeout time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := time.Now().Add(-timeout)
+	abandoned := 0
+	var errs []error
+
+	for battleID, last := range s.lastActivity {
+		if last.After(cutoff) {
+			continue
+		}
+		if err := s.abandonBattle(ctx, battleID); err != nil {
+			errs = append(errs, fmt.Errorf("battle %s: %w", battleID, err))
+			continue
+		}
+		abandoned++
 	}
 
-	return s.endBattle(battleID, winnerID)
+	return abandoned, errors.Join(errs...)
+}
+
+// RecoverEscrows refunds every held escrow whose battle is not running in
+// this process, e.g. after a server crash lost the in-memory battle state.
+// Call on startup before accepting battle requests.
+func (s *BattleService) RecoverEscrows(ctx context.Context) (int, error) {
+	escrows, err := s.escrowRepo.ListHeld(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list held escrows: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	recovered := 0
+	var errs []error
+
+	for _, escrow := range escrows {
+		if _, active := s.activeBattles[escrow.BattleID]; active {
+			continue
+		}
+		if err := s.abandonBattle(ctx, escrow.BattleID); err != nil {
+			errs = append(errs, fmt.Errorf("battle %s: %w", escrow.BattleID, err))
+			continue
+		}
+		recovered++
+	}
+
+	return recovered, errors.Join(errs...)
+}
+
+// RunAbandonmentSweeper periodically abandons inactive battles until ctx is cancelled
+func (s *BattleService) RunAbandonmentSweeper(ctx context.Context, interval, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.AbandonInactiveBattles(ctx, timeout); err != nil {
+				log.Printf("battle sweeper: abandoned %d battles with errors: %v", n, err)
+			}
+		}
+	}
 }
 
 // ListActiveBattles returns all active battles
-func (s *BattleService) ListActiveBattles() ([]*domain.Battle, error) {
+func (s *BattleService) ListActiveBattles(ctx context.Context) ([]*domain.Battle, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	battles := make([]*domain.Battle, 0)
 	for battleID := range s.activeBattles {
-		battle, err := s.battleRepo.GetByID(battleID)
+		battle, err := s.battleRepo.GetByID(ctx, battleID)
 		if err == nil {
 			battles = append(battles, battle)
 		}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/google/uuid"
+)
+
+// MaxAuditHistory is the most audit entries returned for a Pokemon
+const MaxAuditHistory = 100
+
+var (
+	ErrInsufficientCandy = errors.New("not enough candy for this species")
+	ErrSameNature        = errors.New("pokemon already has this nature")
+)
+
+// CandyService spends species candy on a Pokemon's IVs and nature.
+// Every change is validated as a whole Pokemon before it is saved and
+// recorded in the audit log with the candy it cost.
+type CandyService struct {
+	pokemonRepo repository.UserPokemonRepository
+	candyRepo   repository.CandyRepository
+	auditRepo   repository.PokemonAuditRepository
+	locks       *pokemonLocks
+	txManager   repository.TxManager
+	rand        *rand.Rand
+}
+
+// NewCandyService creates a new candy service
+func NewCandyService(
+	pokemonRepo repository.UserPokemonRepository,
+	candyRepo repository.CandyRepository,
+	auditRepo repository.PokemonAuditRepository,
+	listingRepo repository.MarketListingRepository,
+	tradeRepo repository.TradeRepository,
+	battleRepo repository.BattleRepository,
+	txManager repository.TxManager,
+) *CandyService {
+	return &CandyService{
+		pokemonRepo: pokemonRepo,
+		candyRepo:   candyRepo,
+		auditRepo:   auditRepo,
+		locks:       &pokemonLocks{listingRepo: listingRepo, tradeRepo: tradeRepo, battleRepo: battleRepo},
+		txManager:   txManager,
+		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// ListCandy retrieves a user's candy balances by species
+func (s *CandyService) ListCandy(ctx context.Context, userID uuid.UUID) ([]*domain.CandyBalance, error) {
+	return s.candyRepo.ListByUser(ctx, userID)
+}
+
+// RerollIVs rolls new random values for the chosen IVs
+func (s *CandyService) RerollIVs(ctx context.Context, userID, pokemonID uuid.UUID, stats []string) (*domain.UserPokemon, error) {
+	if err := validators.ValidateIVStats(stats); err != nil {
+		return nil, err
+	}
+
+	cost := len(stats) * domain.CandyPerRerolledIV
+	return s.modify(ctx, userID, pokemonID, domain.AuditIVReroll, cost, func(p *domain.UserPokemon) error {
+		for _, stat := range stats {
+			*p.IVs.IV(stat) = s.rand.Intn(domain.MaxIV + 1)
+		}
+		return nil
+	})
+}
+
+// RaiseIVs raises each chosen IV by points. Raising past the maximum
+// fails validation and nothing is spent.
+func (s *CandyService) RaiseIVs(ctx context.Context, userID, pokemonID uuid.UUID, stats []string, points int) (*domain.UserPokemon, error) {
+	if err := validators.ValidateIVStats(stats); err != nil {
+		return nil, err
+	}
+	if points < 1 {
+		return nil, validators.ErrInvalidIVPoints
+	}
+
+	cost := len(stats) * points * domain.CandyPerIVPoint
+	return s.modify(ctx, userID, pokemonID, domain.AuditIVRaise, cost, func(p *domain.UserPokemon) error {
+		for _, stat := range stats {
+			*p.IVs.IV(stat) += points
+		}
+		return nil
+	})
+}
+
+// MintNature changes a Pokemon's nature
+func (s *CandyService) MintNature(ctx context.Context, userID, pokemonID uuid.UUID, nature domain.Nature) (*domain.UserPokemon, error) {
+	if !validators.ValidateNature(nature) {
+		return nil, validators.ErrInvalidNature
+	}
+
+	return s.modify(ctx, userID, pokemonID, domain.AuditNatureMint, domain.CandyPerMint, func(p *domain.UserPokemon) error {
+		if p.Nature == nature {
+			return ErrSameNature
+		}
+		p.Nature = nature
+		return nil
+	})
+}
+
+// GetHistory retrieves the newest audit entries for a Pokemon
+func (s *CandyService) GetHistory(ctx context.Context, pokemonID uuid.UUID, limit int) ([]*domain.PokemonAuditEntry, error) {
+	if limit <= 0 || limit > MaxAuditHistory {
+		limit = MaxAuditHistory
+	}
+	return s.auditRepo.ListByPokemon(ctx, pokemonID, limit)
+}
+
+// modify applies a candy-paid change to a Pokemon in one transaction:
+// the Pokemon is locked, changed, validated and saved, the candy of its
+// species is spent and the change is logged
+func (s *CandyService) modify(ctx context.Context, userID, pokemonID uuid.UUID, action domain.PokemonAuditAction, cost int, change func(p *domain.UserPokemon) error) (*domain.UserPokemon, error) {
+	var pokemon *domain.UserPokemon
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pokemon, err = s.pokemonRepo.GetForUpdate(ctx, pokemonID)
+		if err != nil {
+			return err
+		}
+
+		if pokemon.UserID != userID {
+			return ErrNotPokemonOwner
+		}
+
+		if err := s.locks.check(ctx, pokemon); err != nil {
+			return err
+		}
+
+		before := pokemon.Traits()
+		if err := change(pokemon); err != nil {
+			return err
+		}
+		if err := validators.ValidateUserPokemon(pokemon); err != nil {
+			return err
+		}
+
+		if err := s.candyRepo.Adjust(ctx, userID, pokemon.SpeciesID, -cost); err != nil {
+			if errors.Is(err, repository.ErrInsufficientCandy) {
+				return ErrInsufficientCandy
+			}
+			return err
+		}
+
+		if err := s.pokemonRepo.Update(ctx, pokemon); err != nil {
+			return err
+		}
+
+		return s.auditRepo.Create(ctx, domain.NewPokemonAuditEntry(pokemon, action, -cost, before))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pokemon, nil
+}
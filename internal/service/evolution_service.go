@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNoEvolution           = errors.New("this pokemon cannot evolve into that species")
+	ErrEvolutionChoice       = errors.New("this pokemon can evolve in several ways, choose a species")
+	ErrEvolutionRequirements = errors.New("evolution requirements not met")
+)
+
+// EvolutionService evolves Pokemon along their species' chains. Evolving
+// pays the evolution's candy, coins and item, keeps the Pokemon's IVs,
+// nature and nickname, and unlocks the new species' learnset.
+type EvolutionService struct {
+	userRepo      repository.UserRepository
+	pokemonRepo   repository.UserPokemonRepository
+	evolutionRepo repository.EvolutionRepository
+	learnsetRepo  repository.LearnsetRepository
+	candyRepo     repository.CandyRepository
+	itemRepo      repository.ItemRepository
+	battleRepo    repository.BattleRepository
+	auditRepo     repository.PokemonAuditRepository
+	locks         *pokemonLocks
+	txManager     repository.TxManager
+	events        EventRecorder
+}
+
+// NewEvolutionService creates a new evolution service
+func NewEvolutionService(
+	userRepo repository.UserRepository,
+	pokemonRepo repository.UserPokemonRepository,
+	evolutionRepo repository.EvolutionRepository,
+	learnsetRepo repository.LearnsetRepository,
+	candyRepo repository.CandyRepository,
+	itemRepo repository.ItemRepository,
+	auditRepo repository.PokemonAuditRepository,
+	listingRepo repository.MarketListingRepository,
+	tradeRepo repository.TradeRepository,
+	battleRepo repository.BattleRepository,
+	txManager repository.TxManager,
+) *EvolutionService {
+	return &EvolutionService{
+		userRepo:      userRepo,
+		pokemonRepo:   pokemonRepo,
+		evolutionRepo: evolutionRepo,
+		learnsetRepo:  learnsetRepo,
+		candyRepo:     candyRepo,
+		itemRepo:      itemRepo,
+		battleRepo:    battleRepo,
+		auditRepo:     auditRepo,
+		locks:         &pokemonLocks{listingRepo: listingRepo, tradeRepo: tradeRepo, battleRepo: battleRepo},
+		txManager:     txManager,
+	}
+}
+
+// SetEventRecorder reports the species each evolution obtains to a
+// recorder, such as the Pokedex service
+func (s *EvolutionService) SetEventRecorder(recorder EventRecorder) {
+	s.events = recorder
+}
+
+// ListEvolutions retrieves the evolutions of a user's Pokemon with what is still missing for each
+func (s *EvolutionService) ListEvolutions(ctx context.Context, userID, pokemonID uuid.UUID) ([]*domain.EvolutionOption, error) {
+	pokemon, err := s.pokemonRepo.GetByID(ctx, pokemonID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pokemon.UserID != userID {
+		return nil, ErrNotPokemonOwner
+	}
+
+	evolutions, err := s.evolutionRepo.ListFrom(ctx, pokemon.SpeciesID)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]*domain.EvolutionOption, len(evolutions))
+	for i, evolution := range evolutions {
+		progress, err := s.progress(ctx, pokemon, evolution)
+		if err != nil {
+			return nil, err
+		}
+		options[i] = &domain.EvolutionOption{
+			Evolution: evolution,
+			Progress:  progress,
+			Unmet:     evolution.Unmet(progress),
+		}
+	}
+
+	return options, nil
+}
+
+// Evolve evolves a user's Pokemon into toSpeciesID. When toSpeciesID is 0
+// the species' only evolution is used.
+func (s *EvolutionService) Evolve(ctx context.Context, userID, pokemonID uuid.UUID, toSpeciesID int) (*domain.EvolutionResult, error) {
+	var result *domain.EvolutionResult
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		pokemon, err := s.pokemonRepo.GetForUpdate(ctx, pokemonID)
+		if err != nil {
+			return err
+		}
+
+		if pokemon.UserID != userID {
+			return ErrNotPokemonOwner
+		}
+
+		if err := s.locks.check(ctx, pokemon); err != nil {
+			return err
+		}
+
+		evolutions, err := s.evolutionRepo.ListFrom(ctx, pokemon.SpeciesID)
+		if err != nil {
+			return err
+		}
+		evolution, err := chooseEvolution(evolutions, toSpeciesID)
+		if err != nil {
+			return err
+		}
+
+		progress, err := s.progress(ctx, pokemon, evolution)
+		if err != nil {
+			return err
+		}
+		if unmet := evolution.Unmet(progress); len(unmet) > 0 {
+			return unmetError(unmet)
+		}
+
+		if err := s.pay(ctx, pokemon, evolution); err != nil {
+			return err
+		}
+
+		before := pokemon.Traits()
+		from := pokemon.Species
+		pokemon.Evolve(evolution.ToSpecies)
+		if err := validators.ValidateUserPokemon(pokemon); err != nil {
+			return err
+		}
+		if err := s.pokemonRepo.Update(ctx, pokemon); err != nil {
+			return err
+		}
+
+		// The moves of the old species stay available alongside the new ones
+		if _, err := s.learnsetRepo.Unlock(ctx, pokemon.ID, before.SpeciesID); err != nil {
+			return err
+		}
+		unlocked, err := s.learnsetRepo.Unlock(ctx, pokemon.ID, pokemon.SpeciesID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.auditRepo.Create(ctx, domain.NewPokemonAuditEntry(pokemon, domain.AuditEvolve, -evolution.CandyCost, before)); err != nil {
+			return err
+		}
+		if err := recordEvents(ctx, s.events, domain.NewObtainedEvent(userID, pokemon.SpeciesID)); err != nil {
+			return err
+		}
+
+		result = &domain.EvolutionResult{Pokemon: pokemon, FromSpecies: from, UnlockedMoves: unlocked}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListMoves retrieves the moves a Pokemon can use: its species' learnset
+// and every move it unlocked by evolving
+func (s *EvolutionService) ListMoves(ctx context.Context, pokemonID uuid.UUID) ([]*domain.Move, error) {
+	pokemon, err := s.pokemonRepo.GetByID(ctx, pokemonID)
+	if err != nil {
+		return nil, err
+	}
+
+	learnset, err := s.learnsetRepo.ListBySpecies(ctx, pokemon.SpeciesID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlocked, err := s.learnsetRepo.ListUnlocked(ctx, pokemon.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]bool)
+	var moves []*domain.Move
+	for _, move := range append(learnset, unlocked...) {
+		if !seen[move.ID] {
+			seen[move.ID] = true
+			moves = append(moves, move)
+		}
+	}
+	sort.Slice(moves, func(i, j int) bool { return moves[i].Name < moves[j].Name })
+
+	return moves, nil
+}
+
+// ListItems retrieves the items a user holds
+func (s *EvolutionService) ListItems(ctx context.Context, userID uuid.UUID) ([]*domain.InventoryItem, error) {
+	return s.itemRepo.ListByUser(ctx, userID)
+}
+
+// progress gathers what the owner and Pokemon have toward an evolution
+func (s *EvolutionService) progress(ctx context.Context, pokemon *domain.UserPokemon, evolution *domain.Evolution) (domain.EvolutionProgress, error) {
+	var progress domain.EvolutionProgress
+
+	user, err := s.userRepo.GetByID(ctx, pokemon.UserID)
+	if err != nil {
+		return progress, err
+	}
+	progress.Coins = user.Coins
+
+	if progress.Candy, err = s.candyRepo.Get(ctx, pokemon.UserID, pokemon.SpeciesID); err != nil {
+		return progress, err
+	}
+
+	if evolution.Item != "" {
+		if progress.Items, err = s.itemRepo.Get(ctx, pokemon.UserID, evolution.Item); err != nil {
+			return progress, err
+		}
+	}
+
+	if evolution.BattleWins > 0 {
+		if progress.BattleWins, err = s.battleRepo.CountWins(ctx, pokemon.ID); err != nil {
+			return progress, err
+		}
+	}
+
+	return progress, nil
+}
+
+// pay spends the evolution's candy, coins and item. The repositories check
+// the balances again, so a concurrent spend fails instead of overdrawing.
+func (s *EvolutionService) pay(ctx context.Context, pokemon *domain.UserPokemon, evolution *domain.Evolution) error {
+	if evolution.CandyCost > 0 {
+		if err := s.candyRepo.Adjust(ctx, pokemon.UserID, pokemon.SpeciesID, -evolution.CandyCost); err != nil {
+			if errors.Is(err, repository.ErrInsufficientCandy) {
+				return unmetError([]domain.EvolutionRequirement{domain.RequireCandy})
+			}
+			return err
+		}
+	}
+
+	if evolution.CoinCost > 0 {
+		if err := s.userRepo.AdjustCoins(ctx, pokemon.UserID, -evolution.CoinCost); err != nil {
+			if errors.Is(err, repository.ErrInsufficientCoins) {
+				return unmetError([]domain.EvolutionRequirement{domain.RequireCoins})
+			}
+			return err
+		}
+	}
+
+	if evolution.Item != "" {
+		if err := s.itemRepo.Adjust(ctx, pokemon.UserID, evolution.Item, -1); err != nil {
+			if errors.Is(err, repository.ErrInsufficientItems) {
+				return unmetError([]domain.EvolutionRequirement{domain.RequireItem})
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// chooseEvolution picks the evolution into toSpeciesID, or the only one when it is 0
+func chooseEvolution(evolutions []*domain.Evolution, toSpeciesID int) (*domain.Evolution, error) {
+	if toSpeciesID == 0 {
+		switch len(evolutions) {
+		case 0:
+			return nil, ErrNoEvolution
+		case 1:
+			return evolutions[0], nil
+		default:
+			return nil, ErrEvolutionChoice
+		}
+	}
+
+	for _, evolution := range evolutions {
+		if evolution.ToSpeciesID == toSpeciesID {
+			return evolution, nil
+		}
+	}
+	return nil, ErrNoEvolution
+}
+
+// unmetError wraps ErrEvolutionRequirements with the missing requirements
+func unmetError(unmet []domain.EvolutionRequirement) error {
+	names := make([]string, len(unmet))
+	for i, requirement := range unmet {
+		names[i] = string(requirement)
+	}
+	return fmt.Errorf("%w: missing %s", ErrEvolutionRequirements, strings.Join(names, ", "))
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/google/uuid"
+)
+
+// grantExperience adds experience to a Pokemon and saves any level-ups.
+// Run it inside the caller's transaction so the row stays locked.
+func grantExperience(ctx context.Context, pokemonRepo repository.UserPokemonRepository, pokemonID uuid.UUID, amount int) (*domain.ExperienceGain, error) {
+	pokemon, err := pokemonRepo.GetForUpdate(ctx, pokemonID)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := pokemon.GainExperience(amount)
+	if err := pokemonRepo.Update(ctx, pokemon); err != nil {
+		return nil, err
+	}
+
+	return &domain.ExperienceGain{
+		PokemonID:    pokemon.ID,
+		Experience:   amount,
+		Level:        pokemon.Level,
+		LevelsGained: levels,
+	}, nil
+}
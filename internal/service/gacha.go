@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/danielyang21/GoBattleServer/internal/domain"
 	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
 	"github.com/google/uuid"
 )
 
@@ -15,6 +17,9 @@ var (
 	ErrAlreadyRolledToday  = errors.New("daily roll already claimed today")
 	ErrInsufficientCoins   = errors.New("insufficient coins for premium roll")
 	ErrUserNotFound        = errors.New("user not found")
+	ErrBannerNotActive     = errors.New("banner is not running")
+	ErrEmptyBanner         = errors.New("banner has no species to pull")
+	ErrSeedMismatch        = errors.New("server seed does not match its hash")
 )
 
 // GachaService handles gacha rolling logic
@@ -22,7 +27,15 @@ type GachaService struct {
 	userRepo    repository.UserRepository
 	speciesRepo repository.PokemonSpeciesRepository
 	pokemonRepo repository.UserPokemonRepository
+	receiptRepo repository.RollReceiptRepository
+	bannerRepo  repository.BannerRepository
+	pityRepo    repository.PityRepository
+	seedRepo    repository.FairSeedRepository
+	pullRepo    repository.GachaPullRepository
+	txManager   repository.TxManager
+	events      EventRecorder
 	rand        *rand.Rand
+	species     speciesCache
 }
 
 // NewGachaService creates a new gacha service
@@ -30,138 +43,645 @@ func NewGachaService(
 	userRepo repository.UserRepository,
 	speciesRepo repository.PokemonSpeciesRepository,
 	pokemonRepo repository.UserPokemonRepository,
+	receiptRepo repository.RollReceiptRepository,
+	bannerRepo repository.BannerRepository,
+	pityRepo repository.PityRepository,
+	seedRepo repository.FairSeedRepository,
+	pullRepo repository.GachaPullRepository,
+	txManager repository.TxManager,
 ) *GachaService {
 	return &GachaService{
 		userRepo:    userRepo,
 		speciesRepo: speciesRepo,
 		pokemonRepo: pokemonRepo,
-		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
+		receiptRepo: receiptRepo,
+		bannerRepo:  bannerRepo,
+		pityRepo:    pityRepo,
+		seedRepo:    seedRepo,
+		pullRepo:    pullRepo,
+		txManager:   txManager,
+		rand:        rand.New(&lockedSource{src: rand.NewSource(time.Now().UnixNano())}),
 	}
 }
 
+// SetRandSource replaces the source pulls are rolled from, so tests can
+// make them repeatable
+func (g *GachaService) SetRandSource(source rand.Source) {
+	g.rand = rand.New(&lockedSource{src: source})
+}
+
+// SetEventRecorder reports every pull and Pokemon obtained to a recorder,
+// such as the quest service
+func (g *GachaService) SetEventRecorder(recorder EventRecorder) {
+	g.events = recorder
+}
+
+// RefreshSpecies drops the cached species so the next pull reloads them.
+// Call it after adding or changing species.
+func (g *GachaService) RefreshSpecies() {
+	g.species.mu.Lock()
+	defer g.species.mu.Unlock()
+	g.species.sampler = nil
+}
+
 // DailyRoll performs a free daily roll (5 Pokemon with pity system)
 func (g *GachaService) DailyRoll(ctx context.Context, userID uuid.UUID) ([]*domain.UserPokemon, error) {
+	return g.DailyRollWithKey(ctx, userID, "")
+}
+
+// DailyRollWithKey performs a daily roll under a client idempotency key.
+// Retrying with the same key returns the original pull instead of an error.
+// An empty key disables idempotency.
+func (g *GachaService) DailyRollWithKey(ctx context.Context, userID uuid.UUID, key string) ([]*domain.UserPokemon, error) {
+	if key != "" {
+		pokemons, err := g.replayReceipt(ctx, userID, key)
+		if err == nil {
+			return pokemons, nil
+		}
+		if !errors.Is(err, repository.ErrRollReceiptNotFound) {
+			return nil, err
+		}
+	}
+
 	// Get user
-	user, err := g.userRepo.GetByID(ctx, userID)
-	if err != nil {
+	if _, err := g.userRepo.GetByID(ctx, userID); err != nil {
 		return nil, ErrUserNotFound
 	}
 
-	// Check cooldown
-	if !user.CanDailyRoll() {
-		return nil, ErrAlreadyRolledToday
+	var pokemons []*domain.UserPokemon
+	err := g.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Claim the roll before generating anything. The claim is a single
+		// conditional update, so only one concurrent request can win it.
+		claimed, err := g.userRepo.ClaimDailyRoll(ctx, userID, domain.DailyCooldown)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return ErrAlreadyRolledToday
+		}
+
+		pity, err := g.pityRepo.GetForUpdate(ctx, userID, domain.StandardBannerID)
+		if err != nil {
+			return err
+		}
+
+		var pulls []*domain.GachaPull
+		pokemons, pulls, err = g.rollDaily(ctx, userID, pity)
+		if err != nil {
+			return err
+		}
+
+		if err := g.savePokemons(ctx, pokemons); err != nil {
+			return err
+		}
+		if err := g.savePulls(ctx, pulls); err != nil {
+			return err
+		}
+		if err := g.pityRepo.Save(ctx, pity); err != nil {
+			return err
+		}
+
+		if key != "" {
+			return g.receiptRepo.Create(ctx, domain.NewRollReceipt(userID, key, pokemons))
+		}
+		return nil
+	})
+	if err != nil {
+		// A concurrent request with the same key may have won the claim
+		if key != "" && errors.Is(err, ErrAlreadyRolledToday) {
+			if pokemons, replayErr := g.replayReceipt(ctx, userID, key); replayErr == nil {
+				return pokemons, nil
+			}
+		}
+		return nil, err
+	}
+
+	return pokemons, nil
+}
+
+// rollDaily generates the Pokemon for a daily roll. Daily rolls always use
+// the standard pool and count toward its pity.
+func (g *GachaService) rollDaily(ctx context.Context, userID uuid.UUID, pity *domain.PityState) ([]*domain.UserPokemon, []*domain.GachaPull, error) {
+	pool, err := g.standardPool(ctx)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Give 5 free rolls per day
+	rollID := uuid.New()
 	pokemons := make([]*domain.UserPokemon, 5)
+	pulls := make([]*domain.GachaPull, len(pokemons))
+
+	for i := range pokemons {
+		// First 4 cards are normal rolls; the 5th is guaranteed rare or better
+		minRarity := domain.Common
+		if i == 4 {
+			minRarity = domain.Rare
+		}
 
-	// First 4 cards are normal rolls
-	for i := 0; i < 4; i++ {
-		species, err := g.rollSpecies()
+		counters := pity.Counters()
+		species, odds, err := g.pull(g.rand, pool, pity, minRarity)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		pokemons[i] = domain.NewUserPokemon(userID, species)
+		pokemons[i] = domain.NewUserPokemonWithRand(userID, species, g.rand)
+		pulls[i] = domain.NewGachaPull(rollID, pokemons[i], domain.StandardBannerID, domain.RollTypeDaily, counters, odds)
 	}
 
-	// 5th card is guaranteed rare or better (pity system)
-	species, err := g.rollSpeciesWithMinRarity(domain.Rare)
+	return pokemons, pulls, nil
+}
+
+// replayReceipt loads the pull stored under an idempotency key
+func (g *GachaService) replayReceipt(ctx context.Context, userID uuid.UUID, key string) ([]*domain.UserPokemon, error) {
+	receipt, err := g.receiptRepo.GetByKey(ctx, userID, key)
 	if err != nil {
 		return nil, err
 	}
-	pokemons[4] = domain.NewUserPokemon(userID, species)
 
-	// Save all Pokemon to database
-	for _, pokemon := range pokemons {
-		if err := g.pokemonRepo.Create(ctx, pokemon); err != nil {
+	pokemons := make([]*domain.UserPokemon, len(receipt.PokemonIDs))
+	for i, id := range receipt.PokemonIDs {
+		pokemon, err := g.pokemonRepo.GetByID(ctx, id)
+		if err != nil {
 			return nil, err
 		}
-	}
-
-	// Update last daily roll timestamp
-	if err := g.userRepo.UpdateLastDailyRoll(ctx, userID); err != nil {
-		return nil, err
+		pokemons[i] = pokemon
 	}
 
 	return pokemons, nil
 }
 
-// PremiumRoll performs paid rolls with coins
+// PremiumRoll performs paid rolls with coins on the standard pool
 func (g *GachaService) PremiumRoll(ctx context.Context, userID uuid.UUID, count int) ([]*domain.UserPokemon, error) {
+	return g.PremiumRollOnBanner(ctx, userID, "", count)
+}
+
+// PremiumRollOnBanner performs paid rolls with coins on a running banner.
+// An empty banner ID rolls on the standard pool.
+func (g *GachaService) PremiumRollOnBanner(ctx context.Context, userID uuid.UUID, bannerID string, count int) ([]*domain.UserPokemon, error) {
+	pokemons, _, err := g.premiumRoll(ctx, userID, bannerID, count, false)
+	return pokemons, err
+}
+
+// FairPremiumRoll performs provably fair paid rolls on a running banner or
+// the standard pool. Each pull is rolled from the user's active seed pair
+// and the next nonce, and comes with the proof needed to verify it once the
+// server seed is revealed.
+func (g *GachaService) FairPremiumRoll(ctx context.Context, userID uuid.UUID, bannerID string, count int) ([]*domain.UserPokemon, []*domain.FairProof, error) {
+	return g.premiumRoll(ctx, userID, bannerID, count, true)
+}
+
+func (g *GachaService) premiumRoll(ctx context.Context, userID uuid.UUID, bannerID string, count int, fair bool) ([]*domain.UserPokemon, []*domain.FairProof, error) {
 	cost := count * domain.PremiumRollCost
 
 	// Get user
 	user, err := g.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, ErrUserNotFound
+		return nil, nil, ErrUserNotFound
 	}
 
 	// Check if user has enough coins
 	if !user.HasCoins(cost) {
-		return nil, ErrInsufficientCoins
+		return nil, nil, ErrInsufficientCoins
+	}
+
+	var pool *pullPool
+	if bannerID == "" {
+		pool, err = g.standardPool(ctx)
+	} else {
+		pool, err = g.bannerPool(ctx, bannerID)
+	}
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Roll Pokemon
+	// Roll, deduct coins and save the pull together, so the user is never
+	// charged for a partial pull and pity moves with the pulls it counts
+	rollID := uuid.New()
 	pokemons := make([]*domain.UserPokemon, count)
-	for i := 0; i < count; i++ {
-		species, err := g.rollSpecies()
+	pulls := make([]*domain.GachaPull, count)
+	var proofs []*domain.FairProof
+	err = g.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		pity, err := g.pityRepo.GetForUpdate(ctx, userID, bannerID)
 		if err != nil {
-			return nil, err
+			return err
+		}
+
+		var seed *domain.FairSeed
+		if fair {
+			if seed, err = g.lockFairSeed(ctx, userID); err != nil {
+				return err
+			}
+			proofs = make([]*domain.FairProof, count)
+		}
+
+		for i := range pokemons {
+			// Multi-roll bonus: 10 rolls = 1 guaranteed epic or better
+			minRarity := domain.Common
+			if i == 9 {
+				minRarity = domain.Epic
+			}
+
+			r := g.rand
+			if seed != nil {
+				proofs[i], r = seed.NextPull(bannerID, pity.Rates(pool.rates), minRarity)
+			}
+
+			counters := pity.Counters()
+			species, odds, err := g.pull(r, pool, pity, minRarity)
+			if err != nil {
+				return err
+			}
+			pokemons[i] = domain.NewUserPokemonWithRand(userID, species, r)
+			pulls[i] = domain.NewGachaPull(rollID, pokemons[i], bannerID, domain.RollTypePremium, counters, odds)
+			if seed != nil {
+				pulls[i].Nonce = &proofs[i].Nonce
+			}
 		}
-		pokemons[i] = domain.NewUserPokemon(userID, species)
+
+		// The balance may have changed since it was checked, so the cost is
+		// taken with a conditional update rather than written back
+		if err := g.userRepo.AdjustCoins(ctx, userID, -cost); err != nil {
+			if errors.Is(err, repository.ErrInsufficientCoins) {
+				return ErrInsufficientCoins
+			}
+			return err
+		}
+		if err := g.savePokemons(ctx, pokemons); err != nil {
+			return err
+		}
+		if err := g.savePulls(ctx, pulls); err != nil {
+			return err
+		}
+		if seed != nil {
+			if err := g.seedRepo.Update(ctx, seed); err != nil {
+				return err
+			}
+		}
+		return g.pityRepo.Save(ctx, pity)
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Multi-roll bonus: 10 rolls = 1 guaranteed epic or better
-	if count >= 10 {
-		species, err := g.rollSpeciesWithMinRarity(domain.Epic)
+	return pokemons, proofs, nil
+}
+
+// GrantPokemon gives a user a Pokemon from the standard pool with at least
+// minRarity, outside of any roll. It costs nothing, doesn't count toward
+// pity and isn't recorded as a pull, but is reported as obtained.
+func (g *GachaService) GrantPokemon(ctx context.Context, userID uuid.UUID, minRarity domain.Rarity) (*domain.UserPokemon, error) {
+	pool, err := g.standardPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	species, err := g.rollSpeciesWithMinRarity(g.rand, pool, minRarity)
+	if err != nil {
+		return nil, err
+	}
+
+	pokemon := domain.NewUserPokemonWithRand(userID, species, g.rand)
+	if err := g.savePokemons(ctx, []*domain.UserPokemon{pokemon}); err != nil {
+		return nil, err
+	}
+	return pokemon, nil
+}
+
+// savePokemons persists a pull to the database and reports the Pokemon as
+// obtained
+func (g *GachaService) savePokemons(ctx context.Context, pokemons []*domain.UserPokemon) error {
+	events := make([]domain.GameEvent, len(pokemons))
+	for i, pokemon := range pokemons {
+		if err := g.pokemonRepo.Create(ctx, pokemon); err != nil {
+			return err
+		}
+		events[i] = domain.NewObtainedEvent(pokemon.UserID, pokemon.SpeciesID)
+	}
+	return recordEvents(ctx, g.events, events...)
+}
+
+// savePulls records a pull's cards in the pull history and reports them as
+// events
+func (g *GachaService) savePulls(ctx context.Context, pulls []*domain.GachaPull) error {
+	events := make([]domain.GameEvent, len(pulls))
+	for i, pull := range pulls {
+		if err := g.pullRepo.Create(ctx, pull); err != nil {
+			return err
+		}
+		events[i] = domain.NewPullEvent(pull)
+	}
+	return recordEvents(ctx, g.events, events...)
+}
+
+// GetPullHistory retrieves a user's pulls matching the filter, newest first
+func (g *GachaService) GetPullHistory(ctx context.Context, userID uuid.UUID, filter domain.PullHistoryFilter) ([]*domain.GachaPull, error) {
+	return g.pullRepo.ListByUser(ctx, userID, filter)
+}
+
+// GetDropRateReport compares how often each rarity was pulled with the odds
+// the pulls were made at, across every player
+func (g *GachaService) GetDropRateReport(ctx context.Context, filter domain.PullStatsFilter) (*domain.DropRateReport, error) {
+	total, tallies, err := g.pullRepo.Tally(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewDropRateReport(total, tallies), nil
+}
+
+// GetPity retrieves a user's pity on a banner, the standard pool when the
+// banner ID is empty
+func (g *GachaService) GetPity(ctx context.Context, userID uuid.UUID, bannerID string) (*domain.PityState, error) {
+	return g.pityRepo.Get(ctx, userID, bannerID)
+}
+
+// GetFairSeed retrieves a user's active seed pair with the server seed
+// hidden, committing to a new one if they have none
+func (g *GachaService) GetFairSeed(ctx context.Context, userID uuid.UUID) (*domain.FairSeed, error) {
+	if err := g.createFairSeed(ctx, userID, ""); err != nil {
+		return nil, err
+	}
+
+	seed, err := g.seedRepo.GetActive(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return seed.Public(), nil
+}
+
+// ListRevealedSeeds retrieves a user's last revealed seed pairs, newest first
+func (g *GachaService) ListRevealedSeeds(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.FairSeed, error) {
+	return g.seedRepo.ListRevealed(ctx, userID, limit)
+}
+
+// RotateFairSeed reveals a user's active seed pair and commits to a new one
+// with clientSeed, or a random client seed if it is empty. It returns the
+// revealed pair and the new one with its server seed hidden.
+func (g *GachaService) RotateFairSeed(ctx context.Context, userID uuid.UUID, clientSeed string) (*domain.FairSeed, *domain.FairSeed, error) {
+	if clientSeed != "" {
+		if err := validators.ValidateClientSeed(clientSeed); err != nil {
+			return nil, nil, err
+		}
+	}
+	if _, err := g.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, nil, ErrUserNotFound
+	}
+
+	var revealed, active *domain.FairSeed
+	err := g.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		seed, err := g.lockFairSeed(ctx, userID)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		pokemons[9] = domain.NewUserPokemon(userID, species)
+
+		seed.Reveal()
+		if err := g.seedRepo.Update(ctx, seed); err != nil {
+			return err
+		}
+		if err := g.createFairSeed(ctx, userID, clientSeed); err != nil {
+			return err
+		}
+
+		revealed = seed
+		active, err = g.seedRepo.GetActive(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Deduct coins
-	user.DeductCoins(cost)
-	if err := g.userRepo.UpdateCoins(ctx, userID, user.Coins); err != nil {
+	return revealed, active.Public(), nil
+}
+
+// VerifyPull reproduces a provably fair pull from its proof and the revealed
+// server seed. The pull is rolled from the same species pool with the
+// proof's rates, so it comes out the same as long as the pool's species and
+// their weights haven't changed since. The Pokemon returned is not saved
+// and has no ID.
+func (g *GachaService) VerifyPull(ctx context.Context, proof *domain.FairProof, serverSeed string) (*domain.UserPokemon, error) {
+	if err := validators.ValidateFairProof(proof, serverSeed); err != nil {
 		return nil, err
 	}
+	if proof.ServerSeedHash != "" && proof.ServerSeedHash != domain.HashServerSeed(serverSeed) {
+		return nil, ErrSeedMismatch
+	}
 
-	// Save all Pokemon to database
-	for _, pokemon := range pokemons {
-		if err := g.pokemonRepo.Create(ctx, pokemon); err != nil {
-			return nil, err
+	// Banners that have ended can still be verified
+	var pool *pullPool
+	var err error
+	if proof.BannerID == "" {
+		pool, err = g.standardPool(ctx)
+	} else {
+		var banner *domain.Banner
+		if banner, err = g.bannerRepo.GetByID(ctx, proof.BannerID); err == nil {
+			pool, err = g.poolForBanner(ctx, banner)
 		}
 	}
+	if err != nil {
+		return nil, err
+	}
+	if len(proof.Rates) > 0 {
+		pool.rates = proof.Rates
+	}
 
-	return pokemons, nil
+	minRarity := proof.MinRarity
+	if minRarity == "" {
+		minRarity = domain.Common
+	}
+
+	r := domain.NewFairRand(serverSeed, proof.ClientSeed, proof.Nonce)
+	species, err := g.rollSpeciesWithMinRarity(r, pool, minRarity)
+	if err != nil {
+		return nil, err
+	}
+	pokemon := domain.NewUserPokemonWithRand(uuid.Nil, species, r)
+	pokemon.ID = uuid.Nil
+	return pokemon, nil
+}
+
+// createFairSeed commits to a new seed pair for a user unless they already
+// have an active one
+func (g *GachaService) createFairSeed(ctx context.Context, userID uuid.UUID, clientSeed string) error {
+	seed, err := domain.NewFairSeed(userID, clientSeed)
+	if err != nil {
+		return err
+	}
+	return g.seedRepo.Create(ctx, seed)
+}
+
+// lockFairSeed locks a user's active seed pair, committing to one first if
+// they have none so their first fair pull locks it too
+func (g *GachaService) lockFairSeed(ctx context.Context, userID uuid.UUID) (*domain.FairSeed, error) {
+	if err := g.createFairSeed(ctx, userID, ""); err != nil {
+		return nil, err
+	}
+	return g.seedRepo.GetActiveForUpdate(ctx, userID)
+}
+
+// ListActiveBanners retrieves the banners players can roll on right now
+func (g *GachaService) ListActiveBanners(ctx context.Context) ([]*domain.Banner, error) {
+	return g.bannerRepo.ListActive(ctx, time.Now())
+}
+
+// SpeciesCacheTTL is how long pulls use the species they loaded before
+// loading them again, so species added by another process show up
+const SpeciesCacheTTL = 5 * time.Minute
+
+// speciesCache holds every species and a sampler over them by DropWeight,
+// so pulls don't query the species table
+type speciesCache struct {
+	mu       sync.Mutex
+	species  []*domain.PokemonSpecies
+	sampler  *domain.SpeciesSampler
+	loadedAt time.Time
 }
 
-// rollSpecies rolls a random Pokemon species based on rarity rates
-func (g *GachaService) rollSpecies() (*domain.PokemonSpecies, error) {
-	roll := g.rand.Float64()
+// loadSpecies returns every species and the standard sampler over them,
+// loading them if the cache is empty or older than SpeciesCacheTTL
+func (g *GachaService) loadSpecies(ctx context.Context) ([]*domain.PokemonSpecies, *domain.SpeciesSampler, error) {
+	g.species.mu.Lock()
+	defer g.species.mu.Unlock()
 
-	var targetRarity domain.Rarity
-	switch {
-	case roll < 0.005: // 0.5%
-		targetRarity = domain.Mythic
-	case roll < 0.03: // 2.5%
-		targetRarity = domain.Legendary
-	case roll < 0.10: // 7%
-		targetRarity = domain.Epic
-	case roll < 0.25: // 15%
-		targetRarity = domain.Rare
-	case roll < 0.50: // 25%
-		targetRarity = domain.Uncommon
-	default: // 50%
-		targetRarity = domain.Common
+	if g.species.sampler == nil || time.Since(g.species.loadedAt) > SpeciesCacheTTL {
+		species, err := g.speciesRepo.List(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		g.species.species = species
+		g.species.sampler = domain.NewSpeciesSampler(species, domain.SpeciesDropWeight)
+		g.species.loadedAt = time.Now()
 	}
 
-	return g.speciesRepo.GetRandomByRarity(context.Background(), targetRarity)
+	return g.species.species, g.species.sampler, nil
+}
+
+// lockedSource makes a rand.Source safe for concurrent pulls
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+// pullPool is what a pull draws from: every species at the standard rates,
+// or a banner's species at its rates. Rarities the pool has no species of
+// are left out and the other rates scaled up to match.
+type pullPool struct {
+	banner  *domain.Banner
+	rates   domain.RateTable
+	sampler *domain.SpeciesSampler
+}
+
+// standardPool returns the pool pulls use without a banner
+func (g *GachaService) standardPool(ctx context.Context) (*pullPool, error) {
+	_, sampler, err := g.loadSpecies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := domain.DefaultRates().Only(sampler.Rarities())
+	if len(rates) == 0 {
+		return nil, repository.ErrNoSpeciesFound
+	}
+	return &pullPool{rates: rates, sampler: sampler}, nil
+}
+
+// bannerPool loads a running banner's pool, weighing its featured species
+// up
+func (g *GachaService) bannerPool(ctx context.Context, bannerID string) (*pullPool, error) {
+	banner, err := g.bannerRepo.GetByID(ctx, bannerID)
+	if err != nil {
+		return nil, err
+	}
+	if !banner.IsActive(time.Now()) {
+		return nil, ErrBannerNotActive
+	}
+	return g.poolForBanner(ctx, banner)
+}
+
+// poolForBanner builds a banner's pool whether or not it is running
+func (g *GachaService) poolForBanner(ctx context.Context, banner *domain.Banner) (*pullPool, error) {
+	if err := validators.ValidateBanner(banner); err != nil {
+		return nil, err
+	}
+
+	species, _, err := g.loadSpecies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sampler := domain.NewSpeciesSampler(species, banner.SpeciesWeight)
+	rates := banner.RateTable().Only(sampler.Rarities())
+	if len(rates) == 0 {
+		return nil, ErrEmptyBanner
+	}
+	return &pullPool{banner: banner, rates: rates, sampler: sampler}, nil
+}
+
+// guaranteedRarity returns the rarity a guarantee of at least min pulls:
+// the most common rarity at or above min that the pool has species of. It
+// returns false if there is none.
+func (p *pullPool) guaranteedRarity(min domain.Rarity) (domain.Rarity, bool) {
+	for _, rarity := range domain.Rarities {
+		if rarity.Value() >= min.Value() && p.rates[rarity] > 0 {
+			return rarity, true
+		}
+	}
+	return "", false
+}
+
+// rollSpecies rolls a random Pokemon species based on the pool's rates
+func (g *GachaService) rollSpecies(r *rand.Rand, pool *pullPool) (*domain.PokemonSpecies, error) {
+	return g.pickSpecies(r, pool, pool.rates.Roll(r.Float64()))
+}
+
+// pickSpecies picks a species of a rarity from the pool by weight
+func (g *GachaService) pickSpecies(r *rand.Rand, pool *pullPool, rarity domain.Rarity) (*domain.PokemonSpecies, error) {
+	species := pool.sampler.Pick(r, rarity)
+	if species == nil {
+		return nil, repository.ErrNoSpeciesFound
+	}
+	return species, nil
+}
+
+// pull rolls one species with the player's pity applied to the pool's rates,
+// with at least minRarity, and counts it toward their pity. It also returns
+// the odds of each rarity the pull was rolled at.
+func (g *GachaService) pull(r *rand.Rand, pool *pullPool, pity *domain.PityState, minRarity domain.Rarity) (*domain.PokemonSpecies, domain.RateTable, error) {
+	pitied := *pool
+	pitied.rates = pity.Rates(pool.rates)
+
+	species, err := g.rollSpeciesWithMinRarity(r, &pitied, minRarity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pity.Record(species.Rarity)
+	return species, pitied.odds(minRarity), nil
+}
+
+// odds returns the chance of each rarity on a pull from the pool with at
+// least minRarity
+func (p *pullPool) odds(minRarity domain.Rarity) domain.RateTable {
+	guaranteed, ok := p.guaranteedRarity(minRarity)
+	if !ok {
+		return p.rates.WithMinimum(domain.Common, domain.Common)
+	}
+	return p.rates.WithMinimum(minRarity, guaranteed)
 }
 
 // rollSpeciesWithMinRarity rolls with a minimum rarity guarantee (pity system)
-func (g *GachaService) rollSpeciesWithMinRarity(minRarity domain.Rarity) (*domain.PokemonSpecies, error) {
-	species, err := g.rollSpecies()
+func (g *GachaService) rollSpeciesWithMinRarity(r *rand.Rand, pool *pullPool, minRarity domain.Rarity) (*domain.PokemonSpecies, error) {
+	species, err := g.rollSpecies(r, pool)
 	if err != nil {
 		return nil, err
 	}
@@ -171,8 +691,13 @@ func (g *GachaService) rollSpeciesWithMinRarity(minRarity domain.Rarity) (*domai
 		return species, nil
 	}
 
-	// Otherwise, guarantee minimum rarity
-	return g.speciesRepo.GetRandomByRarity(context.Background(), minRarity)
+	// Otherwise, guarantee minimum rarity. A pool with nothing that rare
+	// keeps the roll.
+	rarity, ok := pool.guaranteedRarity(minRarity)
+	if !ok {
+		return species, nil
+	}
+	return g.pickSpecies(r, pool, rarity)
 }
 
 // GetUserPokemon retrieves all Pokemon for a user
@@ -180,6 +705,18 @@ func (g *GachaService) GetUserPokemon(ctx context.Context, userID uuid.UUID) ([]
 	return g.pokemonRepo.GetByUserID(ctx, userID)
 }
 
+// GetUserBox retrieves a page of a user's box matching the filter.
+// Without a sort the newest Pokemon come first.
+func (g *GachaService) GetUserBox(ctx context.Context, userID uuid.UUID, filter domain.BoxFilter) (*domain.BoxPage, error) {
+	if filter.Sort == "" {
+		filter.Sort = domain.BoxSortAcquired
+	}
+	if err := validators.ValidateBoxFilter(&filter); err != nil {
+		return nil, err
+	}
+	return g.pokemonRepo.SearchByUser(ctx, userID, filter)
+}
+
 // GetPokemonByID retrieves a specific Pokemon by ID
 func (g *GachaService) GetPokemonByID(ctx context.Context, pokemonID uuid.UUID) (*domain.UserPokemon, error) {
 	return g.pokemonRepo.GetByID(ctx, pokemonID)
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+)
+
+// ImportService loads species and move data from a data dump into the
+// game. Imports are idempotent: species are matched by Pokedex number and
+// moves by name, and learnsets only ever gain moves, so running the same
+// dump twice changes nothing and moves curated by hand are kept.
+type ImportService struct {
+	speciesRepo  repository.PokemonSpeciesRepository
+	moveRepo     repository.MoveRepository
+	learnsetRepo repository.LearnsetRepository
+	txManager    repository.TxManager
+}
+
+// NewImportService creates a new import service
+func NewImportService(
+	speciesRepo repository.PokemonSpeciesRepository,
+	moveRepo repository.MoveRepository,
+	learnsetRepo repository.LearnsetRepository,
+	txManager repository.TxManager,
+) *ImportService {
+	return &ImportService{
+		speciesRepo:  speciesRepo,
+		moveRepo:     moveRepo,
+		learnsetRepo: learnsetRepo,
+		txManager:    txManager,
+	}
+}
+
+// Import writes the species and moves of a dump in one transaction. Each
+// species gets the rarity the rule assigns it, and new species get the
+// average drop weight of their rarity so they don't skew the gacha. Rows
+// that fail validation are skipped and listed in the result.
+func (s *ImportService) Import(ctx context.Context, data *domain.SpeciesImport, rule domain.RarityRule) (*domain.ImportResult, error) {
+	result := &domain.ImportResult{ByRarity: make(map[domain.Rarity]int)}
+
+	moves := make([]*domain.Move, 0, len(data.Moves))
+	for _, move := range data.Moves {
+		if err := validators.ValidateMove(move); err != nil {
+			result.Skipped = append(result.Skipped, domain.ImportSkip{Kind: "move", ID: move.ID, Name: move.Name, Reason: err.Error()})
+			continue
+		}
+		moves = append(moves, move)
+	}
+
+	weights := make(map[domain.Rarity]float64)
+	species := make([]*domain.ImportedSpecies, 0, len(data.Species))
+	for _, imported := range data.Species {
+		imported.Species.Rarity = rule.Assign(imported)
+
+		weight, ok := weights[imported.Species.Rarity]
+		if !ok {
+			var err error
+			if weight, err = s.averageDropWeight(ctx, imported.Species.Rarity); err != nil {
+				return nil, err
+			}
+			weights[imported.Species.Rarity] = weight
+		}
+		imported.Species.DropWeight = weight
+
+		if err := validators.ValidatePokemonSpecies(imported.Species); err != nil {
+			result.Skipped = append(result.Skipped, domain.ImportSkip{Kind: "species", ID: imported.Species.ID, Name: imported.Species.Name, Reason: err.Error()})
+			continue
+		}
+		species = append(species, imported)
+	}
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		for _, move := range moves {
+			if err := s.moveRepo.Upsert(ctx, move); err != nil {
+				return err
+			}
+		}
+
+		for _, imported := range species {
+			if err := s.speciesRepo.Upsert(ctx, imported.Species); err != nil {
+				return err
+			}
+		}
+
+		// Learnsets may name moves seeded before the import, so they're
+		// matched against every stored move rather than just the dump's
+		stored, err := s.moveRepo.List(ctx)
+		if err != nil {
+			return err
+		}
+		byName := make(map[string]*domain.Move, len(stored))
+		for _, move := range stored {
+			byName[strings.ToLower(move.Name)] = move
+		}
+
+		for _, imported := range species {
+			learnset := make([]*domain.Move, 0, len(imported.Moves))
+			for _, name := range imported.Moves {
+				move, ok := byName[strings.ToLower(name)]
+				if !ok {
+					result.UnknownMoves++
+					continue
+				}
+				learnset = append(learnset, move)
+			}
+			if len(learnset) == 0 {
+				continue
+			}
+			if err := s.learnsetRepo.Add(ctx, imported.Species.ID, learnset); err != nil {
+				return err
+			}
+			result.LearnsetMoves += len(learnset)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Moves = len(moves)
+	result.Species = len(species)
+	for _, imported := range species {
+		result.ByRarity[imported.Species.Rarity]++
+	}
+	return result, nil
+}
+
+// averageDropWeight returns the average drop weight of the species of a
+// rarity, or 1 if there are none yet
+func (s *ImportService) averageDropWeight(ctx context.Context, rarity domain.Rarity) (float64, error) {
+	existing, err := s.speciesRepo.GetByRarity(ctx, rarity)
+	if err != nil {
+		return 0, err
+	}
+	if len(existing) == 0 {
+		return 1, nil
+	}
+
+	total := 0.0
+	for _, species := range existing {
+		total += species.DropWeight
+	}
+	return total / float64(len(existing)), nil
+}
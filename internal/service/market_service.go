@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotPokemonOwner     = errors.New("pokemon does not belong to this user")
+	ErrNotListingSeller    = errors.New("only the seller can cancel this listing")
+	ErrCannotBuyOwnListing = errors.New("cannot buy your own listing")
+	ErrListingUnavailable  = errors.New("listing is no longer available")
+	ErrInsufficientFunds   = errors.New("insufficient coins to buy this listing")
+	ErrListingIsAuction    = errors.New("listing is an auction, place a bid instead")
+)
+
+// MarketService handles listing, cancelling and buying Pokemon on the market
+type MarketService struct {
+	userRepo        repository.UserRepository
+	pokemonRepo     repository.UserPokemonRepository
+	listingRepo     repository.MarketListingRepository
+	transactionRepo repository.MarketTransactionRepository
+	txManager       repository.TxManager
+	locks           *pokemonLocks
+	events          EventRecorder
+}
+
+// NewMarketService creates a new market service
+func NewMarketService(
+	userRepo repository.UserRepository,
+	pokemonRepo repository.UserPokemonRepository,
+	listingRepo repository.MarketListingRepository,
+	transactionRepo repository.MarketTransactionRepository,
+	tradeRepo repository.TradeRepository,
+	battleRepo repository.BattleRepository,
+	txManager repository.TxManager,
+) *MarketService {
+	return &MarketService{
+		userRepo:        userRepo,
+		pokemonRepo:     pokemonRepo,
+		listingRepo:     listingRepo,
+		transactionRepo: transactionRepo,
+		txManager:       txManager,
+		// Listing twice is left to the listing repository, which reports ErrAlreadyListed
+		locks: &pokemonLocks{tradeRepo: tradeRepo, battleRepo: battleRepo},
+	}
+}
+
+// SetEventRecorder reports every sale, and the Pokemon the buyer obtained,
+// to a recorder such as the quest service
+func (s *MarketService) SetEventRecorder(recorder EventRecorder) {
+	s.events = recorder
+}
+
+// CreateListing puts one of the seller's Pokemon up for sale
+func (s *MarketService) CreateListing(ctx context.Context, sellerID, pokemonID uuid.UUID, price int) (*domain.MarketListing, error) {
+	if err := validators.ValidateListingPrice(price); err != nil {
+		return nil, err
+	}
+
+	pokemon, err := s.pokemonRepo.GetByID(ctx, pokemonID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pokemon.UserID != sellerID {
+		return nil, ErrNotPokemonOwner
+	}
+
+	if err := s.locks.check(ctx, pokemon); err != nil {
+		return nil, err
+	}
+
+	listing := domain.NewMarketListing(pokemon, price)
+	if err := s.listingRepo.Create(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	return listing, nil
+}
+
+// CancelListing takes an active listing off the market
+func (s *MarketService) CancelListing(ctx context.Context, sellerID, listingID uuid.UUID) error {
+	listing, err := s.listingRepo.GetByID(ctx, listingID)
+	if err != nil {
+		return err
+	}
+
+	if listing.SellerID != sellerID {
+		return ErrNotListingSeller
+	}
+
+	if listing.IsAuction() {
+		return ErrListingIsAuction
+	}
+
+	if err := s.listingRepo.UpdateStatus(ctx, listingID, domain.ListingStatusCancelled); err != nil {
+		if errors.Is(err, repository.ErrListingNotActive) {
+			return ErrListingUnavailable
+		}
+		return err
+	}
+
+	listing.Status = domain.ListingStatusCancelled
+	return nil
+}
+
+// BuyListing purchases a listing. Closing the listing, moving the coins and
+// transferring the Pokemon happen in one transaction, so a failed purchase
+// leaves everything as it was.
+func (s *MarketService) BuyListing(ctx context.Context, buyerID, listingID uuid.UUID) (*domain.MarketTransaction, error) {
+	var transaction *domain.MarketTransaction
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		listing, err := s.listingRepo.GetByID(ctx, listingID)
+		if err != nil {
+			return err
+		}
+
+		if listing.IsAuction() {
+			return ErrListingIsAuction
+		}
+
+		if listing.SellerID == buyerID {
+			return ErrCannotBuyOwnListing
+		}
+
+		// Closing the listing first means only one buyer can get past this point
+		if err := s.listingRepo.UpdateStatus(ctx, listingID, domain.ListingStatusSold); err != nil {
+			if errors.Is(err, repository.ErrListingNotActive) {
+				return ErrListingUnavailable
+			}
+			return err
+		}
+
+		// The seller must still own the Pokemon they listed
+		pokemon, err := s.pokemonRepo.GetByID(ctx, listing.UserPokemonID)
+		if err != nil || pokemon.UserID != listing.SellerID {
+			return ErrListingUnavailable
+		}
+
+		if err := s.userRepo.AdjustCoins(ctx, buyerID, -listing.Price); err != nil {
+			if errors.Is(err, repository.ErrInsufficientCoins) {
+				return ErrInsufficientFunds
+			}
+			return fmt.Errorf("failed to charge buyer: %w", err)
+		}
+
+		// The fee is never credited to anyone, which removes it from the economy
+		if err := s.userRepo.AdjustCoins(ctx, listing.SellerID, listing.SellerProceeds()); err != nil {
+			return fmt.Errorf("failed to pay seller: %w", err)
+		}
+
+		if err := s.pokemonRepo.TransferOwnership(ctx, listing.UserPokemonID, listing.SellerID, buyerID); err != nil {
+			return fmt.Errorf("failed to transfer pokemon: %w", err)
+		}
+
+		transaction = domain.NewMarketTransaction(listing, buyerID)
+		if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+			return err
+		}
+		return recordEvents(ctx, s.events,
+			domain.NewGameEvent(domain.EventMarketSale, listing.SellerID, 1),
+			domain.NewObtainedEvent(buyerID, pokemon.SpeciesID))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// GetListing retrieves a listing by ID
+func (s *MarketService) GetListing(ctx context.Context, listingID uuid.UUID) (*domain.MarketListing, error) {
+	return s.listingRepo.GetByID(ctx, listingID)
+}
+
+// SearchListings retrieves active listings matching the filter
+func (s *MarketService) SearchListings(ctx context.Context, filter domain.MarketSearchFilter) ([]*domain.MarketListing, error) {
+	return s.listingRepo.Search(ctx, filter)
+}
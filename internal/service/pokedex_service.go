@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/google/uuid"
+)
+
+// PokedexService records the species players see and obtain, and pays
+// milestone rewards for the number of species they have owned
+type PokedexService struct {
+	userRepo    repository.UserRepository
+	speciesRepo repository.PokemonSpeciesRepository
+	pokedexRepo repository.PokedexRepository
+	itemRepo    repository.ItemRepository
+}
+
+// NewPokedexService creates a new Pokedex service
+func NewPokedexService(
+	userRepo repository.UserRepository,
+	speciesRepo repository.PokemonSpeciesRepository,
+	pokedexRepo repository.PokedexRepository,
+	itemRepo repository.ItemRepository,
+) *PokedexService {
+	return &PokedexService{
+		userRepo:    userRepo,
+		speciesRepo: speciesRepo,
+		pokedexRepo: pokedexRepo,
+		itemRepo:    itemRepo,
+	}
+}
+
+// pokedexUpdate is the species one player saw and obtained in a batch of
+// events
+type pokedexUpdate struct {
+	seen  []int
+	owned []int
+}
+
+// Record adds the species in pokemon_obtained and pokemon_seen events to
+// players' Pokedexes, and pays any milestone a player's owned species
+// reached. It runs in the transaction of what the events describe.
+func (s *PokedexService) Record(ctx context.Context, events ...domain.GameEvent) error {
+	var users []uuid.UUID
+	updates := make(map[uuid.UUID]*pokedexUpdate)
+	for _, event := range events {
+		if event.Type != domain.EventPokemonObtained && event.Type != domain.EventPokemonSeen {
+			continue
+		}
+
+		update, exists := updates[event.UserID]
+		if !exists {
+			update = &pokedexUpdate{}
+			updates[event.UserID] = update
+			users = append(users, event.UserID)
+		}
+		if event.Type == domain.EventPokemonObtained {
+			update.owned = append(update.owned, event.SpeciesID)
+		} else {
+			update.seen = append(update.seen, event.SpeciesID)
+		}
+	}
+
+	for _, userID := range users {
+		update := updates[userID]
+		if len(update.seen) > 0 {
+			if err := s.pokedexRepo.MarkSeen(ctx, userID, update.seen); err != nil {
+				return err
+			}
+		}
+		if len(update.owned) > 0 {
+			if err := s.pokedexRepo.MarkOwned(ctx, userID, update.owned); err != nil {
+				return err
+			}
+			if err := s.grantMilestones(ctx, userID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// grantMilestones pays every milestone a player has reached and not been
+// paid for yet
+func (s *PokedexService) grantMilestones(ctx context.Context, userID uuid.UUID) error {
+	owned, err := s.pokedexRepo.CountOwned(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, milestone := range domain.PokedexMilestones {
+		if owned < milestone.Owned {
+			break
+		}
+
+		granted, err := s.pokedexRepo.GrantMilestone(ctx, userID, milestone.Owned)
+		if err != nil {
+			return err
+		}
+		if !granted {
+			continue
+		}
+
+		if err := s.userRepo.AdjustCoins(ctx, userID, milestone.Coins); err != nil {
+			return err
+		}
+		for item, count := range milestone.Items {
+			if err := s.itemRepo.Adjust(ctx, userID, item, count); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetPokedex returns a user's completion of every species
+func (s *PokedexService) GetPokedex(ctx context.Context, userID uuid.UUID) (*domain.Pokedex, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	species, err := s.speciesRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.pokedexRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	granted, err := s.pokedexRepo.ListMilestones(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewPokedex(species, entries, granted), nil
+}
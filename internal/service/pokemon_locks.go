@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrPokemonLocked is returned when a Pokemon is tied up elsewhere
+var ErrPokemonLocked = errors.New("pokemon is listed, in a battle or in a pending trade")
+
+// pokemonLocks finds Pokemon that are tied up in a market listing, an
+// unfinished battle or a pending trade, and so must not change or leave.
+// Without a listing or trade repository, listings or trades are not checked.
+type pokemonLocks struct {
+	listingRepo repository.MarketListingRepository
+	tradeRepo   repository.TradeRepository
+	battleRepo  repository.BattleRepository
+}
+
+// find returns the reason each of a user's Pokemon is locked, if it is
+func (l *pokemonLocks) find(ctx context.Context, userID uuid.UUID, pokemons []*domain.UserPokemon) (map[uuid.UUID]domain.ReleaseProtection, error) {
+	var trades []*domain.Trade
+	if l.tradeRepo != nil {
+		var err error
+		trades, err = l.tradeRepo.ListPendingByUser(ctx, userID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	battles, err := l.battleRepo.ListByPlayer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	locks := make(map[uuid.UUID]domain.ReleaseProtection)
+	for _, p := range pokemons {
+		if reason, ok, err := l.reason(ctx, p.ID, trades, battles); err != nil {
+			return nil, err
+		} else if ok {
+			locks[p.ID] = reason
+		}
+	}
+
+	return locks, nil
+}
+
+// check returns ErrPokemonLocked if the Pokemon is locked
+func (l *pokemonLocks) check(ctx context.Context, pokemon *domain.UserPokemon) error {
+	locks, err := l.find(ctx, pokemon.UserID, []*domain.UserPokemon{pokemon})
+	if err != nil {
+		return err
+	}
+	if _, ok := locks[pokemon.ID]; ok {
+		return ErrPokemonLocked
+	}
+	return nil
+}
+
+func (l *pokemonLocks) reason(ctx context.Context, pokemonID uuid.UUID, trades []*domain.Trade, battles []*domain.Battle) (domain.ReleaseProtection, bool, error) {
+	if l.listingRepo != nil {
+		if _, err := l.listingRepo.GetActiveByPokemonID(ctx, pokemonID); err == nil {
+			return domain.ProtectedListed, true, nil
+		} else if !errors.Is(err, repository.ErrListingNotFound) {
+			return "", false, err
+		}
+	}
+
+	for _, battle := range battles {
+		if !battle.IsCompleted() && battle.UsesPokemon(pokemonID) {
+			return domain.ProtectedInBattle, true, nil
+		}
+	}
+
+	for _, trade := range trades {
+		if trade.Includes(pokemonID) {
+			return domain.ProtectedInTrade, true, nil
+		}
+	}
+
+	return "", false, nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrQuestNotFound     = errors.New("quest is not active")
+	ErrQuestNotCompleted = errors.New("quest is not completed yet")
+)
+
+// EventRecorder consumes the game events other services report, such as
+// pulls, battles won and sales. Services record events in the same
+// transaction as what they describe, so a rolled back action counts for
+// nothing.
+type EventRecorder interface {
+	Record(ctx context.Context, events ...domain.GameEvent) error
+}
+
+// EventRecorders reports every event to each of several recorders in turn
+type EventRecorders []EventRecorder
+
+// Record reports events to each recorder, stopping at the first error
+func (r EventRecorders) Record(ctx context.Context, events ...domain.GameEvent) error {
+	for _, recorder := range r {
+		if err := recorder.Record(ctx, events...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordEvents reports events to a recorder, if the service has one
+func recordEvents(ctx context.Context, recorder EventRecorder, events ...domain.GameEvent) error {
+	if recorder == nil || len(events) == 0 {
+		return nil
+	}
+	return recorder.Record(ctx, events...)
+}
+
+// QuestService counts game events toward daily and weekly quests and
+// achievements, and pays out their rewards
+type QuestService struct {
+	userRepo  repository.UserRepository
+	questRepo repository.QuestProgressRepository
+	itemRepo  repository.ItemRepository
+	txManager repository.TxManager
+}
+
+// NewQuestService creates a new quest service
+func NewQuestService(
+	userRepo repository.UserRepository,
+	questRepo repository.QuestProgressRepository,
+	itemRepo repository.ItemRepository,
+	txManager repository.TxManager,
+) *QuestService {
+	return &QuestService{
+		userRepo:  userRepo,
+		questRepo: questRepo,
+		itemRepo:  itemRepo,
+		txManager: txManager,
+	}
+}
+
+// questKey identifies a player's progress on a quest in a period
+type questKey struct {
+	userID    uuid.UUID
+	quest     *domain.ActiveQuest
+	periodKey string
+}
+
+// Record counts events toward every active quest they match. Events of
+// the same player and quest are added up and written once.
+func (s *QuestService) Record(ctx context.Context, events ...domain.GameEvent) error {
+	quests := domain.ActiveQuests(time.Now())
+
+	var keys []questKey
+	deltas := make(map[questKey]int)
+	for _, event := range events {
+		for _, quest := range quests {
+			count := quest.Counts(event)
+			if count == 0 {
+				continue
+			}
+			key := questKey{userID: event.UserID, quest: quest, periodKey: quest.PeriodKey}
+			if _, seen := deltas[key]; !seen {
+				keys = append(keys, key)
+			}
+			deltas[key] += count
+		}
+	}
+
+	for _, key := range keys {
+		if err := s.questRepo.Add(ctx, key.userID, key.quest.ID, key.periodKey, deltas[key], key.quest.Goal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListQuests returns the quests running now with a user's progress on each
+func (s *QuestService) ListQuests(ctx context.Context, userID uuid.UUID) ([]*domain.QuestStatus, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	quests := domain.ActiveQuests(time.Now())
+	periods := make(map[string]bool)
+	var periodKeys []string
+	for _, quest := range quests {
+		if !periods[quest.PeriodKey] {
+			periods[quest.PeriodKey] = true
+			periodKeys = append(periodKeys, quest.PeriodKey)
+		}
+	}
+
+	progress, err := s.questRepo.ListByUser(ctx, userID, periodKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	byQuest := make(map[string]*domain.QuestProgress, len(progress))
+	for _, p := range progress {
+		byQuest[p.QuestID+"/"+p.PeriodKey] = p
+	}
+
+	statuses := make([]*domain.QuestStatus, len(quests))
+	for i, quest := range quests {
+		statuses[i] = domain.NewQuestStatus(quest, byQuest[quest.ID+"/"+quest.PeriodKey])
+	}
+	return statuses, nil
+}
+
+// ClaimQuest pays out a completed quest running now. Claiming a quest
+// again returns the reward it gave with AlreadyClaimed set, without paying
+// it twice.
+func (s *QuestService) ClaimQuest(ctx context.Context, userID uuid.UUID, questID string) (*domain.QuestClaim, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	quest, ok := domain.FindActiveQuest(questID, time.Now())
+	if !ok {
+		return nil, ErrQuestNotFound
+	}
+
+	claim := &domain.QuestClaim{Reward: quest.Reward}
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		progress, err := s.questRepo.GetForUpdate(ctx, userID, quest.ID, quest.PeriodKey)
+		if err != nil {
+			return err
+		}
+		if !progress.IsCompleted() {
+			return ErrQuestNotCompleted
+		}
+
+		if progress.IsClaimed() {
+			claim.AlreadyClaimed = true
+		} else {
+			if err := s.userRepo.AdjustCoins(ctx, userID, quest.Reward.Coins); err != nil {
+				return err
+			}
+			for item, count := range quest.Reward.Items {
+				if err := s.itemRepo.Adjust(ctx, userID, item, count); err != nil {
+					return err
+				}
+			}
+
+			now := time.Now()
+			progress.ClaimedAt = &now
+			progress.UpdatedAt = now
+			if err := s.questRepo.Save(ctx, progress); err != nil {
+				return err
+			}
+		}
+
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		claim.Coins = user.Coins
+		claim.Quest = domain.NewQuestStatus(quest, progress)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/google/uuid"
+)
+
+// ReleaseService releases Pokemon back into the wild for a share of their
+// value and candy of their species. Favorites and Pokemon tied up in a
+// listing, battle or trade are never released, whatever the filter says.
+type ReleaseService struct {
+	userRepo         repository.UserRepository
+	pokemonRepo      repository.UserPokemonRepository
+	candyRepo        repository.CandyRepository
+	auditRepo        repository.PokemonAuditRepository
+	locks            *pokemonLocks
+	valuationService *ValuationService
+	txManager        repository.TxManager
+}
+
+// NewReleaseService creates a new release service
+func NewReleaseService(
+	userRepo repository.UserRepository,
+	pokemonRepo repository.UserPokemonRepository,
+	listingRepo repository.MarketListingRepository,
+	tradeRepo repository.TradeRepository,
+	battleRepo repository.BattleRepository,
+	candyRepo repository.CandyRepository,
+	auditRepo repository.PokemonAuditRepository,
+	valuationService *ValuationService,
+	txManager repository.TxManager,
+) *ReleaseService {
+	return &ReleaseService{
+		userRepo:         userRepo,
+		pokemonRepo:      pokemonRepo,
+		candyRepo:        candyRepo,
+		auditRepo:        auditRepo,
+		locks:            &pokemonLocks{listingRepo: listingRepo, tradeRepo: tradeRepo, battleRepo: battleRepo},
+		valuationService: valuationService,
+		txManager:        txManager,
+	}
+}
+
+// Release releases every unprotected Pokemon in the user's box that matches
+// the filter, pays out ReleasePayoutPercent of their release value and credits
+// candy of each released species. With dryRun nothing changes and the
+// result previews what would be released.
+func (s *ReleaseService) Release(ctx context.Context, userID uuid.UUID, filter *domain.ReleaseFilter, dryRun bool) (*domain.ReleaseResult, error) {
+	if err := validators.ValidateReleaseFilter(filter); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		result, err := s.plan(ctx, userID, filter)
+		if err != nil {
+			return nil, err
+		}
+		result.DryRun = true
+		return result, nil
+	}
+
+	var result *domain.ReleaseResult
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.plan(ctx, userID, filter)
+		if err != nil {
+			return err
+		}
+
+		for _, released := range result.Released {
+			if err := s.pokemonRepo.Delete(ctx, released.Pokemon.ID, userID); err != nil {
+				return err
+			}
+			if err := s.candyRepo.Adjust(ctx, userID, released.Pokemon.SpeciesID, released.Candy); err != nil {
+				return err
+			}
+			entry := domain.NewPokemonAuditEntry(released.Pokemon, domain.AuditRelease, released.Candy, released.Pokemon.Traits())
+			if err := s.auditRepo.Create(ctx, entry); err != nil {
+				return err
+			}
+		}
+
+		if result.TotalPayout == 0 {
+			return nil
+		}
+		return s.userRepo.AdjustCoins(ctx, userID, result.TotalPayout)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// plan works out which matching Pokemon would be released and what they pay
+func (s *ReleaseService) plan(ctx context.Context, userID uuid.UUID, filter *domain.ReleaseFilter) (*domain.ReleaseResult, error) {
+	box, err := s.pokemonRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make(map[uuid.UUID]bool, len(box))
+	for _, p := range box {
+		owned[p.ID] = true
+	}
+	for _, id := range filter.PokemonIDs {
+		if !owned[id] {
+			return nil, ErrNotPokemonOwner
+		}
+	}
+
+	selected := filter.Select(box)
+	result := &domain.ReleaseResult{
+		Released:  []*domain.ReleasedPokemon{},
+		Protected: []*domain.ProtectedPokemon{},
+	}
+	if len(selected) == 0 {
+		return result, nil
+	}
+
+	protections, err := s.protections(ctx, userID, selected)
+	if err != nil {
+		return nil, err
+	}
+
+	var releasing []*domain.UserPokemon
+	for _, p := range selected {
+		if reason, ok := protections[p.ID]; ok {
+			result.Protected = append(result.Protected, &domain.ProtectedPokemon{Pokemon: p, Reason: reason})
+			continue
+		}
+		releasing = append(releasing, p)
+	}
+
+	if err := s.valuationService.ApplyMarketValues(ctx, releasing); err != nil {
+		return nil, err
+	}
+
+	for _, p := range releasing {
+		released := &domain.ReleasedPokemon{
+			Pokemon: p,
+			Payout:  domain.ReleasePayout(p.ReleaseValue()),
+			Candy:   domain.CandyForRelease(speciesRarity(p)),
+		}
+		result.Released = append(result.Released, released)
+		result.TotalPayout += released.Payout
+	}
+
+	return result, nil
+}
+
+// protections finds why each of the given Pokemon must be kept, if it must
+func (s *ReleaseService) protections(ctx context.Context, userID uuid.UUID, pokemons []*domain.UserPokemon) (map[uuid.UUID]domain.ReleaseProtection, error) {
+	protections, err := s.locks.find(ctx, userID, pokemons)
+	if err != nil {
+		return nil, err
+	}
+
+	// A favorite is reported as such even if it is also locked elsewhere
+	for _, p := range pokemons {
+		if p.IsFavorite {
+			protections[p.ID] = domain.ProtectedFavorite
+		}
+	}
+
+	return protections, nil
+}
+
+// speciesRarity returns a Pokemon's rarity, or "" if its species is not loaded
+func speciesRarity(p *domain.UserPokemon) domain.Rarity {
+	if p.Species == nil {
+		return ""
+	}
+	return p.Species.Rarity
+}
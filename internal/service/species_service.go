@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+)
+
+var ErrSpeciesNotFound = errors.New("species not found")
+
+// SpeciesService serves the public species catalog
+type SpeciesService struct {
+	speciesRepo  repository.PokemonSpeciesRepository
+	learnsetRepo repository.LearnsetRepository
+}
+
+// NewSpeciesService creates a new species service
+func NewSpeciesService(
+	speciesRepo repository.PokemonSpeciesRepository,
+	learnsetRepo repository.LearnsetRepository,
+) *SpeciesService {
+	return &SpeciesService{
+		speciesRepo:  speciesRepo,
+		learnsetRepo: learnsetRepo,
+	}
+}
+
+// SearchSpecies returns a page of the species matching a filter
+func (s *SpeciesService) SearchSpecies(ctx context.Context, filter domain.SpeciesSearchFilter) ([]*domain.PokemonSpecies, error) {
+	return s.speciesRepo.Search(ctx, filter)
+}
+
+// GetSpecies returns a species by Pokedex number with its type matchups,
+// learnset and abilities
+func (s *SpeciesService) GetSpecies(ctx context.Context, id int) (*domain.SpeciesDetails, error) {
+	species, err := s.speciesRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrSpeciesNotFound
+	}
+	return s.details(ctx, species)
+}
+
+// GetSpeciesByName returns a species by name, like GetSpecies
+func (s *SpeciesService) GetSpeciesByName(ctx context.Context, name string) (*domain.SpeciesDetails, error) {
+	species, err := s.speciesRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, ErrSpeciesNotFound
+	}
+	return s.details(ctx, species)
+}
+
+// details builds a species' catalog entry
+func (s *SpeciesService) details(ctx context.Context, species *domain.PokemonSpecies) (*domain.SpeciesDetails, error) {
+	learnset, err := s.learnsetRepo.ListBySpecies(ctx, species.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SpeciesDetails{
+		Species:   species,
+		Matchups:  domain.NewTypeMatchup(species.Type1, species.Type2),
+		Learnset:  learnset,
+		Abilities: species.AbilityList(),
+	}, nil
+}
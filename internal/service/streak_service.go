@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/google/uuid"
+)
+
+var ErrAlreadyCheckedIn = errors.New("already checked in today")
+
+// StreakService handles daily check-ins and the escalating rewards of a
+// login streak
+type StreakService struct {
+	userRepo     repository.UserRepository
+	streakRepo   repository.LoginStreakRepository
+	itemRepo     repository.ItemRepository
+	gachaService *GachaService
+	txManager    repository.TxManager
+}
+
+// NewStreakService creates a new streak service
+func NewStreakService(
+	userRepo repository.UserRepository,
+	streakRepo repository.LoginStreakRepository,
+	itemRepo repository.ItemRepository,
+	gachaService *GachaService,
+	txManager repository.TxManager,
+) *StreakService {
+	return &StreakService{
+		userRepo:     userRepo,
+		streakRepo:   streakRepo,
+		itemRepo:     itemRepo,
+		gachaService: gachaService,
+		txManager:    txManager,
+	}
+}
+
+// GetStreak reports a user's streak as of now
+func (s *StreakService) GetStreak(ctx context.Context, userID uuid.UUID) (*domain.StreakStatus, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	streak, err := s.streakRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := streak.Status(time.Now())
+	return &status, nil
+}
+
+// CheckIn counts a user's check-in for today and grants the reward for
+// the day of their streak it reaches. Each check-in day starts at midnight
+// in the check-in timezone.
+func (s *StreakService) CheckIn(ctx context.Context, userID uuid.UUID) (*domain.CheckInResult, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	result := &domain.CheckInResult{}
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		streak, err := s.streakRepo.GetForUpdate(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		day, ok := streak.CheckIn(now)
+		if !ok {
+			return ErrAlreadyCheckedIn
+		}
+
+		result.Reward = domain.StreakRewardFor(day)
+		if err := s.userRepo.AdjustCoins(ctx, userID, result.Reward.Coins); err != nil {
+			return err
+		}
+		for item, count := range result.Reward.Items {
+			if err := s.itemRepo.Adjust(ctx, userID, item, count); err != nil {
+				return err
+			}
+		}
+		if result.Reward.Pokemon != "" {
+			if result.Pokemon, err = s.gachaService.GrantPokemon(ctx, userID, result.Reward.Pokemon); err != nil {
+				return err
+			}
+		}
+
+		if err := s.streakRepo.Save(ctx, streak); err != nil {
+			return err
+		}
+
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		result.Coins = user.Coins
+		result.Streak = streak.Status(now)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,450 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotTradeParticipant     = errors.New("only the two traders can act on this trade")
+	ErrTradeUnavailable        = errors.New("trade is no longer pending")
+	ErrTradeExpired            = errors.New("trade offer has expired")
+	ErrTradePokemonUnavailable = errors.New("a pokemon in the trade is no longer available")
+	ErrTradeInsufficientCoins  = errors.New("a trader does not have enough coins for this trade")
+	ErrPokemonListed           = errors.New("pokemon is listed on the market")
+	ErrPokemonOnCooldown       = errors.New("pokemon was acquired too recently to trade")
+	ErrPokemonInPendingTrade   = errors.New("pokemon is already in another pending trade")
+)
+
+// TradeService handles direct trades between two players. An offer only
+// executes once both players confirm, and everything changes hands in one
+// transaction after ownership is checked again.
+type TradeService struct {
+	userRepo         repository.UserRepository
+	pokemonRepo      repository.UserPokemonRepository
+	listingRepo      repository.MarketListingRepository
+	tradeRepo        repository.TradeRepository
+	notificationRepo repository.NotificationRepository
+	txManager        repository.TxManager
+	locks            *pokemonLocks
+	cooldown         time.Duration
+	events           EventRecorder
+}
+
+// NewTradeService creates a new trade service
+func NewTradeService(
+	userRepo repository.UserRepository,
+	pokemonRepo repository.UserPokemonRepository,
+	listingRepo repository.MarketListingRepository,
+	tradeRepo repository.TradeRepository,
+	battleRepo repository.BattleRepository,
+	notificationRepo repository.NotificationRepository,
+	txManager repository.TxManager,
+) *TradeService {
+	return &TradeService{
+		userRepo:         userRepo,
+		pokemonRepo:      pokemonRepo,
+		listingRepo:      listingRepo,
+		tradeRepo:        tradeRepo,
+		notificationRepo: notificationRepo,
+		txManager:        txManager,
+		// Listings and pending trades are checked by the trade itself
+		locks: &pokemonLocks{battleRepo: battleRepo},
+	}
+}
+
+// SetEventRecorder reports every completed trade, and the Pokemon each
+// side obtained, to a recorder such as the quest service
+func (s *TradeService) SetEventRecorder(recorder EventRecorder) {
+	s.events = recorder
+}
+
+// SetAcquisitionCooldown stops Pokemon from being traded until they have
+// been owned for at least d. Zero (the default) disables the cool-down.
+func (s *TradeService) SetAcquisitionCooldown(d time.Duration) {
+	s.cooldown = d
+}
+
+// ProposeTrade offers the proposer's Pokemon and coins for the recipient's
+func (s *TradeService) ProposeTrade(ctx context.Context, proposerID, recipientID uuid.UUID, proposerPokemonIDs, recipientPokemonIDs []uuid.UUID, proposerCoins, recipientCoins int) (*domain.Trade, error) {
+	trade := domain.NewTrade(proposerID, recipientID, proposerPokemonIDs, recipientPokemonIDs, proposerCoins, recipientCoins)
+	if err := validators.ValidateTrade(trade); err != nil {
+		return nil, err
+	}
+
+	proposer, err := s.userRepo.GetByID(ctx, proposerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, recipientID); err != nil {
+		return nil, err
+	}
+
+	if proposer.Coins < proposerCoins {
+		return nil, ErrTradeInsufficientCoins
+	}
+
+	if _, err := s.checkTradeable(ctx, proposerID, proposerPokemonIDs, time.Now()); err != nil {
+		return nil, err
+	}
+	if _, err := s.checkTradeable(ctx, recipientID, recipientPokemonIDs, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotPending(ctx, trade); err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.tradeRepo.Create(ctx, trade); err != nil {
+			return err
+		}
+		return s.notify(ctx, recipientID, trade.ID, domain.NotificationTradeOffer, "New trade offer",
+			fmt.Sprintf("<@%s> wants to trade with you.\n%s", proposer.DiscordID, s.describe(ctx, trade)))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return trade, nil
+}
+
+// ConfirmTrade records a participant's confirmation. The second confirmation
+// executes the trade: ownership, listings, cool-downs and balances are checked
+// again and everything moves at once, or nothing does.
+func (s *TradeService) ConfirmTrade(ctx context.Context, userID, tradeID uuid.UUID) (*domain.Trade, error) {
+	var trade *domain.Trade
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		trade, err = s.lockPending(ctx, userID, tradeID)
+		if err != nil {
+			return err
+		}
+
+		trade.Confirm(userID)
+		otherID := trade.OtherParticipant(userID)
+
+		if !trade.IsConfirmed() {
+			if err := s.tradeRepo.Update(ctx, trade); err != nil {
+				return err
+			}
+			return s.notify(ctx, otherID, trade.ID, domain.NotificationTradeConfirmed, "Trade confirmed",
+				"The other trader confirmed your trade. Confirm it too to complete the swap.")
+		}
+
+		if err := s.execute(ctx, trade); err != nil {
+			return err
+		}
+
+		for _, id := range []uuid.UUID{trade.ProposerID, trade.RecipientID} {
+			if err := s.notify(ctx, id, trade.ID, domain.NotificationTradeCompleted, "Trade complete!",
+				"Your trade went through. Check your `/box` for your new Pokemon."); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return trade, nil
+}
+
+// CancelTrade lets either participant withdraw from a pending trade
+func (s *TradeService) CancelTrade(ctx context.Context, userID, tradeID uuid.UUID) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		trade, err := s.lockPending(ctx, userID, tradeID)
+		if err != nil {
+			return err
+		}
+
+		trade.Status = domain.TradeStatusCancelled
+		if err := s.tradeRepo.Update(ctx, trade); err != nil {
+			return err
+		}
+
+		return s.notify(ctx, trade.OtherParticipant(userID), trade.ID, domain.NotificationTradeCancelled, "Trade cancelled",
+			"The other trader cancelled your trade.")
+	})
+}
+
+// GetTrade retrieves a trade by ID
+func (s *TradeService) GetTrade(ctx context.Context, tradeID uuid.UUID) (*domain.Trade, error) {
+	return s.tradeRepo.GetByID(ctx, tradeID)
+}
+
+// ListPendingTrades retrieves a user's open trade offers, sent and received
+func (s *TradeService) ListPendingTrades(ctx context.Context, userID uuid.UUID) ([]*domain.Trade, error) {
+	return s.tradeRepo.ListPendingByUser(ctx, userID, time.Now())
+}
+
+// ExpireTrades expires every pending trade past its expiry and tells both traders.
+// Returns the number of trades expired.
+func (s *TradeService) ExpireTrades(ctx context.Context) (int, error) {
+	expired := 0
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		trades, err := s.tradeRepo.ExpirePending(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+
+		for _, trade := range trades {
+			for _, id := range []uuid.UUID{trade.ProposerID, trade.RecipientID} {
+				if err := s.notify(ctx, id, trade.ID, domain.NotificationTradeCancelled, "Trade expired",
+					"A trade offer expired before both traders confirmed it."); err != nil {
+					return err
+				}
+			}
+		}
+
+		expired = len(trades)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return expired, nil
+}
+
+// RunExpiryScheduler expires stale trade offers until ctx is cancelled
+func (s *TradeService) RunExpiryScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.ExpireTrades(ctx); err != nil {
+			log.Printf("trade scheduler: failed to expire trades: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// lockPending locks a trade that userID can still act on
+func (s *TradeService) lockPending(ctx context.Context, userID, tradeID uuid.UUID) (*domain.Trade, error) {
+	trade, err := s.tradeRepo.GetForUpdate(ctx, tradeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !trade.HasParticipant(userID) {
+		return nil, ErrNotTradeParticipant
+	}
+
+	if !trade.IsPending() {
+		return nil, ErrTradeUnavailable
+	}
+
+	if trade.IsExpired(time.Now()) {
+		return nil, ErrTradeExpired
+	}
+
+	return trade, nil
+}
+
+// execute swaps the Pokemon and coins of a fully confirmed trade and
+// reports the trade to the event recorder
+func (s *TradeService) execute(ctx context.Context, trade *domain.Trade) error {
+	now := time.Now()
+
+	// Another trade, sale or auction may want the same Pokemon, so they're
+	// locked before ownership is checked and stay locked until they move
+	if err := s.lockPokemon(ctx, trade); err != nil {
+		return err
+	}
+
+	proposerPokemon, err := s.checkTradeable(ctx, trade.ProposerID, trade.ProposerPokemonIDs, now)
+	if err != nil {
+		return err
+	}
+	recipientPokemon, err := s.checkTradeable(ctx, trade.RecipientID, trade.RecipientPokemonIDs, now)
+	if err != nil {
+		return err
+	}
+
+	if err := s.moveCoins(ctx, trade.ProposerID, trade.RecipientID, trade.ProposerCoins); err != nil {
+		return err
+	}
+	if err := s.moveCoins(ctx, trade.RecipientID, trade.ProposerID, trade.RecipientCoins); err != nil {
+		return err
+	}
+
+	for _, id := range trade.ProposerPokemonIDs {
+		if err := s.pokemonRepo.TransferOwnership(ctx, id, trade.ProposerID, trade.RecipientID); err != nil {
+			return fmt.Errorf("failed to transfer pokemon: %w", err)
+		}
+	}
+	for _, id := range trade.RecipientPokemonIDs {
+		if err := s.pokemonRepo.TransferOwnership(ctx, id, trade.RecipientID, trade.ProposerID); err != nil {
+			return fmt.Errorf("failed to transfer pokemon: %w", err)
+		}
+	}
+
+	trade.Status = domain.TradeStatusCompleted
+	trade.CompletedAt = &now
+	if err := s.tradeRepo.Update(ctx, trade); err != nil {
+		return err
+	}
+
+	events := []domain.GameEvent{
+		domain.NewGameEvent(domain.EventTradeCompleted, trade.ProposerID, 1),
+		domain.NewGameEvent(domain.EventTradeCompleted, trade.RecipientID, 1),
+	}
+	for _, pokemon := range proposerPokemon {
+		events = append(events, domain.NewObtainedEvent(trade.RecipientID, pokemon.SpeciesID))
+	}
+	for _, pokemon := range recipientPokemon {
+		events = append(events, domain.NewObtainedEvent(trade.ProposerID, pokemon.SpeciesID))
+	}
+	return recordEvents(ctx, s.events, events...)
+}
+
+// lockPokemon locks every Pokemon in a trade. They're locked in ID order
+// so two trades sharing Pokemon wait for each other instead of deadlocking.
+func (s *TradeService) lockPokemon(ctx context.Context, trade *domain.Trade) error {
+	ids := make([]uuid.UUID, 0, len(trade.ProposerPokemonIDs)+len(trade.RecipientPokemonIDs))
+	ids = append(ids, trade.ProposerPokemonIDs...)
+	ids = append(ids, trade.RecipientPokemonIDs...)
+	sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i][:], ids[j][:]) < 0 })
+
+	for _, id := range ids {
+		if _, err := s.pokemonRepo.GetForUpdate(ctx, id); err != nil {
+			if errors.Is(err, repository.ErrPokemonNotFound) {
+				return ErrTradePokemonUnavailable
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// checkNotPending verifies that none of a new trade's Pokemon are already
+// offered in another pending trade. Either trader would be a participant in
+// such a trade, since they own the Pokemon.
+func (s *TradeService) checkNotPending(ctx context.Context, trade *domain.Trade) error {
+	for _, userID := range []uuid.UUID{trade.ProposerID, trade.RecipientID} {
+		pending, err := s.tradeRepo.ListPendingByUser(ctx, userID, time.Now())
+		if err != nil {
+			return err
+		}
+		for _, other := range pending {
+			for _, ids := range [][]uuid.UUID{trade.ProposerPokemonIDs, trade.RecipientPokemonIDs} {
+				for _, id := range ids {
+					if other.Includes(id) {
+						return ErrPokemonInPendingTrade
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// moveCoins pays amount from one trader to the other
+func (s *TradeService) moveCoins(ctx context.Context, fromID, toID uuid.UUID, amount int) error {
+	if amount == 0 {
+		return nil
+	}
+
+	if err := s.userRepo.AdjustCoins(ctx, fromID, -amount); err != nil {
+		if errors.Is(err, repository.ErrInsufficientCoins) {
+			return ErrTradeInsufficientCoins
+		}
+		return fmt.Errorf("failed to charge trader: %w", err)
+	}
+
+	if err := s.userRepo.AdjustCoins(ctx, toID, amount); err != nil {
+		return fmt.Errorf("failed to pay trader: %w", err)
+	}
+
+	return nil
+}
+
+// checkTradeable verifies that ownerID owns every Pokemon, none are on the
+// market or in an unfinished battle and all are past the acquisition
+// cool-down, and returns them
+func (s *TradeService) checkTradeable(ctx context.Context, ownerID uuid.UUID, pokemonIDs []uuid.UUID, now time.Time) ([]*domain.UserPokemon, error) {
+	pokemons := make([]*domain.UserPokemon, 0, len(pokemonIDs))
+	for _, id := range pokemonIDs {
+		pokemon, err := s.pokemonRepo.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, repository.ErrPokemonNotFound) {
+				return nil, ErrTradePokemonUnavailable
+			}
+			return nil, err
+		}
+
+		if pokemon.UserID != ownerID {
+			return nil, ErrTradePokemonUnavailable
+		}
+
+		if _, err := s.listingRepo.GetActiveByPokemonID(ctx, id); err == nil {
+			return nil, ErrPokemonListed
+		} else if !errors.Is(err, repository.ErrListingNotFound) {
+			return nil, err
+		}
+
+		if err := s.locks.check(ctx, pokemon); err != nil {
+			return nil, err
+		}
+
+		if s.cooldown > 0 && now.Sub(pokemon.AcquiredAt) < s.cooldown {
+			return nil, ErrPokemonOnCooldown
+		}
+		pokemons = append(pokemons, pokemon)
+	}
+
+	return pokemons, nil
+}
+
+// describe summarises what each side gives for a notification
+func (s *TradeService) describe(ctx context.Context, trade *domain.Trade) string {
+	return fmt.Sprintf("They give: %s\nYou give: %s",
+		s.describeSide(ctx, trade.ProposerPokemonIDs, trade.ProposerCoins),
+		s.describeSide(ctx, trade.RecipientPokemonIDs, trade.RecipientCoins))
+}
+
+func (s *TradeService) describeSide(ctx context.Context, pokemonIDs []uuid.UUID, coins int) string {
+	var parts []string
+	for _, id := range pokemonIDs {
+		if pokemon, err := s.pokemonRepo.GetByID(ctx, id); err == nil {
+			parts = append(parts, fmt.Sprintf("**%s**", pokemon.DisplayName()))
+		}
+	}
+	if coins > 0 {
+		parts = append(parts, fmt.Sprintf("%d coins", coins))
+	}
+	if len(parts) == 0 {
+		return "nothing"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// notify queues a Discord notification about a trade as part of the current transaction
+func (s *TradeService) notify(ctx context.Context, userID, tradeID uuid.UUID, notificationType domain.NotificationType, title, message string) error {
+	notification := domain.NewNotification(userID, notificationType, title, message)
+	notification.ReferenceID = &tradeID
+	if err := s.notificationRepo.Create(ctx, notification); err != nil {
+		return fmt.Errorf("failed to queue notification: %w", err)
+	}
+	return nil
+}
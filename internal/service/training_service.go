@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTrainingCoins    = errors.New("insufficient coins for EV training")
+	ErrTrainingVitamins = errors.New("not enough vitamins for EV training")
+)
+
+// TrainingService trains a Pokemon's EVs. Training is paid with coins or
+// with each stat's vitamin, is validated against the EV limits before
+// anything is spent, and is recorded in the audit log.
+type TrainingService struct {
+	userRepo    repository.UserRepository
+	pokemonRepo repository.UserPokemonRepository
+	itemRepo    repository.ItemRepository
+	auditRepo   repository.PokemonAuditRepository
+	locks       *pokemonLocks
+	txManager   repository.TxManager
+}
+
+// NewTrainingService creates a new training service
+func NewTrainingService(
+	userRepo repository.UserRepository,
+	pokemonRepo repository.UserPokemonRepository,
+	itemRepo repository.ItemRepository,
+	auditRepo repository.PokemonAuditRepository,
+	listingRepo repository.MarketListingRepository,
+	tradeRepo repository.TradeRepository,
+	battleRepo repository.BattleRepository,
+	txManager repository.TxManager,
+) *TrainingService {
+	return &TrainingService{
+		userRepo:    userRepo,
+		pokemonRepo: pokemonRepo,
+		itemRepo:    itemRepo,
+		auditRepo:   auditRepo,
+		locks:       &pokemonLocks{listingRepo: listingRepo, tradeRepo: tradeRepo, battleRepo: battleRepo},
+		txManager:   txManager,
+	}
+}
+
+// TrainEVs adds evs to a user's Pokemon, paying with coins or vitamins
+func (s *TrainingService) TrainEVs(ctx context.Context, userID, pokemonID uuid.UUID, evs domain.EVs, payment domain.EVPayment) (*domain.EVTrainingResult, error) {
+	if err := validators.ValidateEVTraining(evs, payment); err != nil {
+		return nil, err
+	}
+
+	cost := evs.TrainingCost(payment)
+
+	var pokemon *domain.UserPokemon
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pokemon, err = s.pokemonRepo.GetForUpdate(ctx, pokemonID)
+		if err != nil {
+			return err
+		}
+
+		if pokemon.UserID != userID {
+			return ErrNotPokemonOwner
+		}
+
+		if err := s.locks.check(ctx, pokemon); err != nil {
+			return err
+		}
+
+		before := pokemon.Traits()
+		pokemon.EVs = pokemon.EVs.Add(evs)
+		if err := validators.ValidateUserPokemon(pokemon); err != nil {
+			return err
+		}
+
+		if err := s.pay(ctx, userID, cost); err != nil {
+			return err
+		}
+
+		if err := s.pokemonRepo.Update(ctx, pokemon); err != nil {
+			return err
+		}
+
+		return s.auditRepo.Create(ctx, domain.NewPokemonAuditEntry(pokemon, domain.AuditEVTrain, 0, before))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.EVTrainingResult{Pokemon: pokemon, Cost: cost}, nil
+}
+
+// pay takes the coins or vitamins a training costs
+func (s *TrainingService) pay(ctx context.Context, userID uuid.UUID, cost domain.EVTrainingCost) error {
+	if cost.Coins > 0 {
+		if err := s.userRepo.AdjustCoins(ctx, userID, -cost.Coins); err != nil {
+			if errors.Is(err, repository.ErrInsufficientCoins) {
+				return ErrTrainingCoins
+			}
+			return err
+		}
+	}
+
+	// Spend vitamins in a fixed order so concurrent trainings lock rows alike
+	vitamins := make([]string, 0, len(cost.Vitamins))
+	for vitamin := range cost.Vitamins {
+		vitamins = append(vitamins, vitamin)
+	}
+	sort.Strings(vitamins)
+
+	for _, vitamin := range vitamins {
+		if err := s.itemRepo.Adjust(ctx, userID, vitamin, -cost.Vitamins[vitamin]); err != nil {
+			if errors.Is(err, repository.ErrInsufficientItems) {
+				return ErrTrainingVitamins
+			}
+			return err
+		}
+	}
+
+	return nil
+}
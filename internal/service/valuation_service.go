@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+)
+
+// MaxPriceHistoryDays is the longest price history that can be requested
+const MaxPriceHistoryDays = 365
+
+var ErrInvalidHistoryDays = errors.New("price history must cover 1 to 365 days")
+
+// ValuationService values Pokemon from completed market sales
+type ValuationService struct {
+	transactionRepo repository.MarketTransactionRepository
+}
+
+// NewValuationService creates a new valuation service
+func NewValuationService(transactionRepo repository.MarketTransactionRepository) *ValuationService {
+	return &ValuationService{
+		transactionRepo: transactionRepo,
+	}
+}
+
+// GetValuations computes the current valuation of each species that has
+// enough recent sales. Species without one are left out of the map.
+func (s *ValuationService) GetValuations(ctx context.Context, speciesIDs []int) (map[int]*domain.SpeciesValuation, error) {
+	valuations := make(map[int]*domain.SpeciesValuation)
+	if len(speciesIDs) == 0 {
+		return valuations, nil
+	}
+
+	sales, err := s.transactionRepo.ListSales(ctx, speciesIDs, time.Now().Add(-domain.ValuationWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sales: %w", err)
+	}
+
+	// Sales arrive newest first, which grouping keeps
+	bySpecies := make(map[int][]*domain.MarketSale)
+	for _, sale := range sales {
+		bySpecies[sale.SpeciesID] = append(bySpecies[sale.SpeciesID], sale)
+	}
+
+	for speciesID, speciesSales := range bySpecies {
+		if valuation := domain.NewSpeciesValuation(speciesID, speciesSales); valuation != nil {
+			valuations[speciesID] = valuation
+		}
+	}
+
+	return valuations, nil
+}
+
+// ApplyMarketValues sets the market value of each Pokemon whose species has
+// enough recent sales. The others keep their formula value.
+func (s *ValuationService) ApplyMarketValues(ctx context.Context, pokemons []*domain.UserPokemon) error {
+	seen := make(map[int]bool)
+	var speciesIDs []int
+	for _, p := range pokemons {
+		if !seen[p.SpeciesID] {
+			seen[p.SpeciesID] = true
+			speciesIDs = append(speciesIDs, p.SpeciesID)
+		}
+	}
+
+	valuations, err := s.GetValuations(ctx, speciesIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range pokemons {
+		if valuation, ok := valuations[p.SpeciesID]; ok {
+			value := valuation.ValueOf(p)
+			p.MarketValue = &value
+		}
+	}
+
+	return nil
+}
+
+// GetPriceReport retrieves a species' valuation and daily price history
+// for the last days days
+func (s *ValuationService) GetPriceReport(ctx context.Context, speciesID, days int) (*domain.PriceReport, error) {
+	if days <= 0 || days > MaxPriceHistoryDays {
+		return nil, ErrInvalidHistoryDays
+	}
+
+	now := time.Now()
+	historyStart := now.Add(-time.Duration(days) * domain.PriceHistoryBucket).Truncate(domain.PriceHistoryBucket)
+
+	since := historyStart
+	if windowStart := now.Add(-domain.ValuationWindow); windowStart.Before(since) {
+		since = windowStart
+	}
+
+	sales, err := s.transactionRepo.ListSales(ctx, []int{speciesID}, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sales: %w", err)
+	}
+
+	var recent []*domain.MarketSale
+	for _, sale := range sales {
+		if !sale.SoldAt.Before(now.Add(-domain.ValuationWindow)) {
+			recent = append(recent, sale)
+		}
+	}
+
+	return &domain.PriceReport{
+		SpeciesID: speciesID,
+		Valuation: domain.NewSpeciesValuation(speciesID, recent),
+		History:   domain.BuildPriceHistory(sales, historyStart, domain.PriceHistoryBucket),
+	}, nil
+}
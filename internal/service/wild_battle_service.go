@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxWildBattleTurns = 50 // A wild battle still running after this many turns is lost
+	WildLevelSpread    = 3  // Wild Pokemon are up to this many levels above or below yours
+)
+
+// WildBattleService runs battles against wild Pokemon. The battle is
+// simulated turn by turn with random moves, and the player's Pokemon earns
+// experience for the wild Pokemon it faced. Each battle is stored as a
+// finished battle without a second player, so wins count toward evolutions.
+type WildBattleService struct {
+	speciesRepo repository.PokemonSpeciesRepository
+	pokemonRepo repository.UserPokemonRepository
+	battleRepo  repository.BattleRepository
+	locks       *pokemonLocks
+	txManager   repository.TxManager
+	events      EventRecorder
+}
+
+// NewWildBattleService creates a new wild battle service
+func NewWildBattleService(
+	speciesRepo repository.PokemonSpeciesRepository,
+	pokemonRepo repository.UserPokemonRepository,
+	listingRepo repository.MarketListingRepository,
+	tradeRepo repository.TradeRepository,
+	battleRepo repository.BattleRepository,
+	txManager repository.TxManager,
+) *WildBattleService {
+	return &WildBattleService{
+		speciesRepo: speciesRepo,
+		pokemonRepo: pokemonRepo,
+		battleRepo:  battleRepo,
+		locks:       &pokemonLocks{listingRepo: listingRepo, tradeRepo: tradeRepo, battleRepo: battleRepo},
+		txManager:   txManager,
+	}
+}
+
+// SetEventRecorder reports wins, super-effective hits and the wild species
+// seen to a recorder, such as the quest service
+func (s *WildBattleService) SetEventRecorder(recorder EventRecorder) {
+	s.events = recorder
+}
+
+// Battle sends a user's Pokemon against a random wild Pokemon under the
+// named format and awards the experience it earned
+func (s *WildBattleService) Battle(ctx context.Context, userID, pokemonID uuid.UUID, formatName string) (*domain.WildBattleResult, error) {
+	format, exists := domain.GetBattleFormat(formatName)
+	if !exists {
+		return nil, ErrUnknownFormat
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	var result *domain.WildBattleResult
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		pokemon, err := s.pokemonRepo.GetForUpdate(ctx, pokemonID)
+		if err != nil {
+			return err
+		}
+
+		if pokemon.UserID != userID {
+			return ErrNotPokemonOwner
+		}
+
+		if err := s.locks.check(ctx, pokemon); err != nil {
+			return err
+		}
+
+		species, err := s.wildSpecies(ctx, rng)
+		if err != nil {
+			return err
+		}
+		wild := domain.NewUserPokemon(uuid.Nil, species).AtLevel(wildLevel(pokemon.Level, rng))
+
+		own := createBattlePokemon(pokemon, format)
+		opponent := createBattlePokemon(wild, format)
+		won, turns, state := simulateWildBattle(userID, own, opponent, rng)
+
+		amount := domain.BattleExperience(species, opponent.Level, won)
+		levels := pokemon.GainExperience(amount)
+		if err := s.pokemonRepo.Update(ctx, pokemon); err != nil {
+			return err
+		}
+
+		var winnerID *uuid.UUID
+		if won {
+			winnerID = &userID
+		}
+		if err := s.battleRepo.Create(ctx, wildBattleRecord(userID, pokemon.ID, format.Name, turns, winnerID)); err != nil {
+			return err
+		}
+		events := append(battleEvents(state.Player1, winnerID), domain.NewSeenEvent(userID, species.ID))
+		if err := recordEvents(ctx, s.events, events...); err != nil {
+			return err
+		}
+
+		result = &domain.WildBattleResult{
+			Pokemon:   pokemon,
+			Wild:      opponent.Species,
+			WildLevel: opponent.Level,
+			Format:    format.Name,
+			Won:       won,
+			Turns:     turns,
+			Gain: &domain.ExperienceGain{
+				PokemonID:    pokemon.ID,
+				Experience:   amount,
+				Level:        pokemon.Level,
+				LevelsGained: levels,
+			},
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// wildSpecies picks the species of a wild Pokemon. Wild Pokemon are
+// common 60% of the time, uncommon 30% and rare 10%.
+func (s *WildBattleService) wildSpecies(ctx context.Context, rng *rand.Rand) (*domain.PokemonSpecies, error) {
+	roll := rng.Float64()
+
+	var rarity domain.Rarity
+	switch {
+	case roll < 0.6:
+		rarity = domain.Common
+	case roll < 0.9:
+		rarity = domain.Uncommon
+	default:
+		rarity = domain.Rare
+	}
+
+	return s.speciesRepo.GetRandomByRarity(ctx, rarity)
+}
+
+// wildBattleRecord returns a finished battle record for a wild battle. The
+// wild side has no player or stored Pokemon.
+func wildBattleRecord(userID, pokemonID uuid.UUID, formatName string, turns int, winnerID *uuid.UUID) *domain.Battle {
+	battle := domain.NewBattle(userID, uuid.Nil, 0)
+	battle.Format = formatName
+	battle.Player1Pokemon = pokemonID
+	battle.Status = domain.BattleStatusCompleted
+	battle.WinnerID = winnerID
+	battle.CurrentTurn = turns
+	now := battle.CreatedAt
+	battle.StartedAt = &now
+	battle.CompletedAt = &now
+	return battle
+}
+
+// wildLevel returns a level within WildLevelSpread of level
+func wildLevel(level int, rng *rand.Rand) int {
+	level += rng.Intn(2*WildLevelSpread+1) - WildLevelSpread
+	if level < domain.MinLevel {
+		return domain.MinLevel
+	}
+	if level > domain.MaxLevel {
+		return domain.MaxLevel
+	}
+	return level
+}
+
+// simulateWildBattle plays both sides with random usable moves until one
+// faints or MaxWildBattleTurns pass. The player wins only by knocking out
+// the wild Pokemon while their own stays standing. It also returns the
+// battle's final state.
+func simulateWildBattle(userID uuid.UUID, own, wild *domain.BattlePokemon, rng *rand.Rand) (bool, int, *domain.BattleState) {
+	battle := domain.NewBattle(userID, uuid.New(), 0)
+	battle.InitializeBattleState(own, wild)
+	state := battle.State
+	state.ApplyEntryAbilities()
+	resolver := domain.NewTurnResolver(rand.NewSource(rng.Int63()))
+
+	for turn := 1; turn <= MaxWildBattleTurns; turn++ {
+		state.SetPlayerAction(state.Player1.UserID, randomMoveAction(state.Player1, rng))
+		state.SetPlayerAction(state.Player2.UserID, randomMoveAction(state.Player2, rng))
+
+		if resolver.ResolveTurn(state).BattleEnded {
+			return wild.Fainted && !own.Fainted, turn, state
+		}
+	}
+
+	return false, MaxWildBattleTurns, state
+}
+
+// randomMoveAction picks a random move the player's Pokemon can still use
+func randomMoveAction(player *domain.BattlePlayer, rng *rand.Rand) *domain.BattleAction {
+	var usable []int
+	for i := range player.Pokemon.Moves {
+		if ok, _ := player.Pokemon.CanUseMove(i); ok {
+			usable = append(usable, i)
+		}
+	}
+
+	index := 0
+	if len(usable) > 0 {
+		index = usable[rng.Intn(len(usable))]
+	}
+
+	return &domain.BattleAction{
+		PlayerID:  player.UserID,
+		Type:      domain.ActionMove,
+		MoveIndex: index,
+		Move:      player.Pokemon.Moves[index],
+	}
+}
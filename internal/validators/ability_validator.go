@@ -0,0 +1,54 @@
+package validators
+
+import (
+	"errors"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+)
+
+var (
+	ErrInvalidAbilitySlot  = errors.New("ability slot must be 1, 2 or 3")
+	ErrTooManyAbilities    = errors.New("a species has at most two regular abilities")
+	ErrEmptyAbilitySlot    = errors.New("the species has no ability in that slot")
+	ErrSameAbility         = errors.New("the Pokemon already has that ability")
+	ErrHiddenAbilityLocked = errors.New("an Ability Capsule cannot switch to or from a hidden ability")
+)
+
+// ValidateAbilitySlot checks a slot is one of the three ability slots
+func ValidateAbilitySlot(slot domain.AbilitySlot) error {
+	if slot < domain.AbilitySlot1 || slot > domain.HiddenAbilitySlot {
+		return ErrInvalidAbilitySlot
+	}
+	return nil
+}
+
+// ValidateAbilities checks a species' regular abilities
+func ValidateAbilities(s *domain.PokemonSpecies) error {
+	if len(s.Abilities) > domain.MaxRegularAbilities {
+		return ErrTooManyAbilities
+	}
+	for _, ability := range s.Abilities {
+		if ability == "" {
+			return ErrEmptyName
+		}
+	}
+	return nil
+}
+
+// ValidateAbilityChange checks an Ability Capsule can switch a Pokemon to a
+// slot. Capsules only switch between regular abilities.
+func ValidateAbilityChange(p *domain.UserPokemon, slot domain.AbilitySlot) error {
+	if err := ValidateAbilitySlot(slot); err != nil {
+		return err
+	}
+	if slot.IsHidden() || p.HasHiddenAbility() {
+		return ErrHiddenAbilityLocked
+	}
+	if p.Species.Ability(slot) == "" {
+		return ErrEmptyAbilitySlot
+	}
+	if p.Species.Ability(slot) == p.Ability() {
+		return ErrSameAbility
+	}
+	return nil
+}
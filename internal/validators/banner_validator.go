@@ -0,0 +1,85 @@
+package validators
+
+import (
+	"errors"
+	"math"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+)
+
+var (
+	ErrInvalidBannerID       = errors.New("banner ID must be a lowercase slug")
+	ErrInvalidBannerSchedule = errors.New("banner must end after it starts")
+	ErrInvalidBannerRates    = errors.New("banner rates must be non-negative and total 1")
+	ErrInvalidFeaturedWeight = errors.New("featured species weight must be at least 1")
+	ErrFeaturedNotInPool     = errors.New("featured species must be in the banner's pool")
+)
+
+// rateTolerance allows for rounding in rates written as decimals
+const rateTolerance = 1e-6
+
+// ValidateBanner checks if a banner's schedule, rates and species are valid
+func ValidateBanner(b *domain.Banner) error {
+	if !isSlug(b.ID) {
+		return ErrInvalidBannerID
+	}
+
+	if b.Name == "" {
+		return ErrEmptyName
+	}
+
+	if b.EndsAt != nil && !b.EndsAt.After(b.StartsAt) {
+		return ErrInvalidBannerSchedule
+	}
+
+	if err := ValidateRateTable(b.Rates); err != nil {
+		return err
+	}
+
+	for _, featured := range b.Featured {
+		if featured.Weight < 1 {
+			return ErrInvalidFeaturedWeight
+		}
+		if !b.Includes(featured.SpeciesID) {
+			return ErrFeaturedNotInPool
+		}
+	}
+
+	return nil
+}
+
+// ValidateRateTable checks a custom rate table. An empty table is valid and
+// means the standard rates.
+func ValidateRateTable(rates domain.RateTable) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	for rarity, rate := range rates {
+		if !ValidateRarity(rarity) {
+			return ErrInvalidRarity
+		}
+		if rate < 0 {
+			return ErrInvalidBannerRates
+		}
+	}
+
+	if math.Abs(rates.Total()-1) > rateTolerance {
+		return ErrInvalidBannerRates
+	}
+	return nil
+}
+
+// isSlug checks for a non-empty string of lowercase letters, digits and
+// inner dashes
+func isSlug(s string) bool {
+	if s == "" || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package validators
+
+import (
+	"errors"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+)
+
+var (
+	ErrInvalidBoxIV    = errors.New("minimum IV percent must be between 0 and 100")
+	ErrInvalidBoxSort  = errors.New("box sort must be acquired, iv, value or stats")
+	ErrInvalidBoxLimit = errors.New("box page size must be between 1 and 100")
+)
+
+// ValidateBoxFilter checks a box filter's criteria, page size and cursor
+func ValidateBoxFilter(filter *domain.BoxFilter) error {
+	if filter.Rarity != "" && !ValidateRarity(filter.Rarity) {
+		return ErrInvalidRarity
+	}
+
+	if filter.SpeciesID < 0 {
+		return ErrInvalidSpecies
+	}
+
+	if filter.Type != "" && !domain.IsValidType(string(filter.Type)) {
+		return ErrInvalidType
+	}
+
+	if filter.Nature != "" && !ValidateNature(filter.Nature) {
+		return ErrInvalidNature
+	}
+
+	if filter.MinIVPercent < 0 || filter.MinIVPercent > 100 {
+		return ErrInvalidBoxIV
+	}
+
+	if filter.Sort != "" && !filter.Sort.IsValid() {
+		return ErrInvalidBoxSort
+	}
+
+	if filter.Limit < 0 || filter.Limit > domain.MaxBoxPageSize {
+		return ErrInvalidBoxLimit
+	}
+
+	// A cursor only makes sense in the order it was made for
+	if filter.Cursor != nil && filter.Cursor.Sort != filter.Sort {
+		return domain.ErrInvalidBoxCursor
+	}
+
+	return nil
+}
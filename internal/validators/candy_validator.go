@@ -0,0 +1,35 @@
+package validators
+
+import (
+	"errors"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+)
+
+var (
+	ErrNoIVStats       = errors.New("choose at least one IV")
+	ErrUnknownIVStat   = errors.New("unknown IV stat")
+	ErrDuplicateIVStat = errors.New("an IV is chosen more than once")
+	ErrInvalidIVPoints = errors.New("IV points must be at least 1")
+)
+
+// ValidateIVStats checks a selection of IVs to change
+func ValidateIVStats(stats []string) error {
+	if len(stats) == 0 {
+		return ErrNoIVStats
+	}
+
+	seen := make(map[string]bool)
+	for _, stat := range stats {
+		var ivs domain.IVs
+		if ivs.IV(stat) == nil {
+			return ErrUnknownIVStat
+		}
+		if seen[stat] {
+			return ErrDuplicateIVStat
+		}
+		seen[stat] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package validators
+
+import (
+	"errors"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+)
+
+var (
+	ErrNoEVsToTrain     = errors.New("choose at least one EV to train")
+	ErrNegativeEVs      = errors.New("EVs to train cannot be negative")
+	ErrInvalidEVPayment = errors.New("EV training is paid with coins or vitamins")
+)
+
+// ValidateEVTraining checks the EVs to add and how they are paid for.
+// Whether they fit on the Pokemon is checked with ValidateEVs afterwards.
+func ValidateEVTraining(evs domain.EVs, payment domain.EVPayment) error {
+	for _, stat := range domain.IVStats {
+		if *evs.EV(stat) < 0 {
+			return ErrNegativeEVs
+		}
+	}
+	if evs.Total() == 0 {
+		return ErrNoEVsToTrain
+	}
+
+	if payment != domain.PayWithCoins && payment != domain.PayWithVitamins {
+		return ErrInvalidEVPayment
+	}
+
+	return nil
+}
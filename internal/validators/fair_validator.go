@@ -0,0 +1,47 @@
+package validators
+
+import (
+	"errors"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+)
+
+var (
+	ErrInvalidClientSeed = errors.New("client seed must be 1-64 letters, digits, dashes or underscores")
+	ErrInvalidServerSeed = errors.New("server seed is required")
+	ErrInvalidNonce      = errors.New("nonce must not be negative")
+)
+
+// MaxClientSeedLength is the longest client seed a player can pick
+const MaxClientSeedLength = 64
+
+// ValidateClientSeed checks if a client seed a player picked is valid
+func ValidateClientSeed(seed string) error {
+	if len(seed) == 0 || len(seed) > MaxClientSeedLength {
+		return ErrInvalidClientSeed
+	}
+	for _, c := range seed {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return ErrInvalidClientSeed
+		}
+	}
+	return nil
+}
+
+// ValidateFairProof checks if a pull can be reproduced from a proof and a
+// server seed
+func ValidateFairProof(proof *domain.FairProof, serverSeed string) error {
+	if serverSeed == "" {
+		return ErrInvalidServerSeed
+	}
+	if err := ValidateClientSeed(proof.ClientSeed); err != nil {
+		return err
+	}
+	if proof.Nonce < 0 {
+		return ErrInvalidNonce
+	}
+	if proof.MinRarity != "" && !ValidateRarity(proof.MinRarity) {
+		return ErrInvalidRarity
+	}
+	return ValidateRateTable(proof.Rates)
+}
@@ -0,0 +1,36 @@
+package validators
+
+import (
+	"errors"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+)
+
+var (
+	ErrInvalidListingPrice    = errors.New("listing price is out of range")
+	ErrInvalidBidIncrement    = errors.New("minimum bid increment is out of range")
+	ErrInvalidAuctionDuration = errors.New("auction duration is out of range")
+)
+
+// ValidateListingPrice checks if a price is within the market limits
+func ValidateListingPrice(price int) error {
+	if price < domain.MinListingPrice || price > domain.MaxListingPrice {
+		return ErrInvalidListingPrice
+	}
+	return nil
+}
+
+// ValidateAuction checks an auction's starting price, bid increment and duration
+func ValidateAuction(startingPrice, minIncrement int, duration time.Duration) error {
+	if err := ValidateListingPrice(startingPrice); err != nil {
+		return err
+	}
+	if minIncrement < 1 || minIncrement > domain.MaxListingPrice {
+		return ErrInvalidBidIncrement
+	}
+	if duration < domain.MinAuctionDuration || duration > domain.MaxAuctionDuration {
+		return ErrInvalidAuctionDuration
+	}
+	return nil
+}
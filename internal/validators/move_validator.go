@@ -0,0 +1,56 @@
+package validators
+
+import (
+	"errors"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+)
+
+var (
+	ErrInvalidMoveCategory = errors.New("move category must be physical, special or status")
+	ErrInvalidMovePower    = errors.New("move power cannot be negative")
+	ErrInvalidAccuracy     = errors.New("move accuracy must be between 0 and 100")
+	ErrInvalidPP           = errors.New("move PP must be positive")
+	ErrInvalidChance       = errors.New("effect chances must be between 0 and 100")
+)
+
+// ValidateMove checks if move data is valid
+func ValidateMove(m *domain.Move) error {
+	if m.Name == "" {
+		return ErrEmptyName
+	}
+
+	if !domain.IsValidType(string(m.Type)) {
+		return ErrInvalidType
+	}
+
+	if !domain.IsValidMoveCategory(string(m.Category)) {
+		return ErrInvalidMoveCategory
+	}
+
+	if m.Power < 0 {
+		return ErrInvalidMovePower
+	}
+
+	// 0 means the move always hits
+	if m.Accuracy < 0 || m.Accuracy > 100 {
+		return ErrInvalidAccuracy
+	}
+
+	if m.PP <= 0 {
+		return ErrInvalidPP
+	}
+
+	if m.StatusInflict != nil && !validChance(m.StatusInflict.Chance) {
+		return ErrInvalidChance
+	}
+	if m.SecondaryEffect != nil && !validChance(m.SecondaryEffect.Chance) {
+		return ErrInvalidChance
+	}
+	return nil
+}
+
+// validChance checks a percentage chance
+func validChance(chance int) bool {
+	return chance >= 0 && chance <= 100
+}
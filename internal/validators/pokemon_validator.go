@@ -13,8 +13,19 @@ var (
 	ErrInvalidRarity  = errors.New("invalid rarity")
 	ErrInvalidNature  = errors.New("invalid nature")
 	ErrInvalidStats   = errors.New("invalid base stats")
+	ErrInvalidType    = errors.New("invalid type")
 	ErrInvalidWeight  = errors.New("invalid drop weight")
 	ErrEmptyName      = errors.New("name cannot be empty")
+
+	ErrInvalidExperience = errors.New("experience cannot be negative")
+	ErrInvalidEV         = errors.New("EV must be between 0 and 252")
+	ErrTooManyEVs        = errors.New("EVs cannot total more than 510")
+	ErrInvalidGrowthRate = errors.New("invalid growth rate")
+
+	ErrInvalidGender     = errors.New("gender does not match the species' gender ratio")
+	ErrInvalidGenderRate = errors.New("gender rate must be between -1 and 8")
+	ErrInvalidForm       = errors.New("form does not belong to the species")
+	ErrInvalidRollChance = errors.New("form roll chances must be positive and total at most 1")
 )
 
 // ValidateIVs checks if all IVs are in valid range (0-31)
@@ -30,6 +41,19 @@ func ValidateIVs(ivs domain.IVs) error {
 	return nil
 }
 
+// ValidateEVs checks each EV is 0-252 and the total is at most 510
+func ValidateEVs(evs domain.EVs) error {
+	for _, stat := range domain.IVStats {
+		if ev := *evs.EV(stat); ev < 0 || ev > domain.MaxEVPerStat {
+			return ErrInvalidEV
+		}
+	}
+	if evs.Total() > domain.MaxTotalEVs {
+		return ErrTooManyEVs
+	}
+	return nil
+}
+
 // ValidateLevel checks if level is valid (1-100)
 func ValidateLevel(level int) error {
 	if level < 1 || level > 100 {
@@ -44,6 +68,10 @@ func ValidateUserPokemon(p *domain.UserPokemon) error {
 		return err
 	}
 
+	if err := ValidateEVs(p.EVs); err != nil {
+		return err
+	}
+
 	if !ValidateNature(p.Nature) {
 		return ErrInvalidNature
 	}
@@ -52,6 +80,38 @@ func ValidateUserPokemon(p *domain.UserPokemon) error {
 		return err
 	}
 
+	if p.Experience < 0 {
+		return ErrInvalidExperience
+	}
+
+	if err := ValidateGender(p); err != nil {
+		return err
+	}
+
+	if p.Form != nil && p.Form.SpeciesID != p.SpeciesID {
+		return ErrInvalidForm
+	}
+
+	return ValidateAbilitySlot(p.AbilitySlot)
+}
+
+// ValidateGender checks a Pokemon's gender is possible for its species
+func ValidateGender(p *domain.UserPokemon) error {
+	if !p.Gender.IsValid() {
+		return ErrInvalidGender
+	}
+	if p.Species == nil {
+		return nil
+	}
+
+	genderless := p.Species.GenderRate == domain.GenderlessRate
+	if genderless != (p.Gender == domain.Genderless) {
+		return ErrInvalidGender
+	}
+	if (p.Gender == domain.Female && p.Species.GenderRate == 0) ||
+		(p.Gender == domain.Male && p.Species.GenderRate == 8) {
+		return ErrInvalidGender
+	}
 	return nil
 }
 
@@ -69,6 +129,14 @@ func ValidatePokemonSpecies(s *domain.PokemonSpecies) error {
 		return ErrInvalidRarity
 	}
 
+	// An empty primary type is stored as normal
+	if s.Type1 != "" && !domain.IsValidType(string(s.Type1)) {
+		return ErrInvalidType
+	}
+	if s.Type2 != nil && (!domain.IsValidType(string(*s.Type2)) || *s.Type2 == s.Type1) {
+		return ErrInvalidType
+	}
+
 	if s.BaseHP <= 0 || s.BaseAttack < 0 || s.BaseDefense < 0 ||
 		s.BaseSpAttack < 0 || s.BaseSpDefense < 0 || s.BaseSpeed < 0 {
 		return ErrInvalidStats
@@ -78,5 +146,48 @@ func ValidatePokemonSpecies(s *domain.PokemonSpecies) error {
 		return ErrInvalidWeight
 	}
 
+	// An empty growth rate levels on the medium fast curve
+	if s.GrowthRate != "" && !s.GrowthRate.IsValid() {
+		return ErrInvalidGrowthRate
+	}
+
+	if s.BaseExperience < 0 {
+		return ErrInvalidExperience
+	}
+
+	if s.GenderRate < domain.GenderlessRate || s.GenderRate > 8 {
+		return ErrInvalidGenderRate
+	}
+
+	if err := ValidateAbilities(s); err != nil {
+		return err
+	}
+
+	return ValidateForms(s)
+}
+
+// ValidateForms checks each form belongs to the species and their roll
+// chances leave room for the base form
+func ValidateForms(s *domain.PokemonSpecies) error {
+	total := 0.0
+	for _, form := range s.Forms {
+		if form.SpeciesID != s.ID {
+			return ErrInvalidForm
+		}
+		if form.Name == "" {
+			return ErrEmptyName
+		}
+		if form.BaseHP <= 0 || form.BaseAttack < 0 || form.BaseDefense < 0 ||
+			form.BaseSpAttack < 0 || form.BaseSpDefense < 0 || form.BaseSpeed < 0 {
+			return ErrInvalidStats
+		}
+		if form.RollChance <= 0 {
+			return ErrInvalidRollChance
+		}
+		total += form.RollChance
+	}
+	if total > 1 {
+		return ErrInvalidRollChance
+	}
 	return nil
 }
@@ -0,0 +1,48 @@
+package validators
+
+import (
+	"errors"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEmptyReleaseFilter      = errors.New("release needs pokemon IDs or at least one filter")
+	ErrInvalidReleaseIV        = errors.New("IV threshold must be between 0 and 100")
+	ErrInvalidKeepCount        = errors.New("duplicates to keep cannot be negative")
+	ErrDuplicateReleasePokemon = errors.New("a pokemon is included in the release more than once")
+)
+
+// ValidateReleaseFilter checks that a release filter selects something specific
+func ValidateReleaseFilter(filter *domain.ReleaseFilter) error {
+	if filter.IsEmpty() {
+		return ErrEmptyReleaseFilter
+	}
+
+	if filter.Rarity != "" && !ValidateRarity(filter.Rarity) {
+		return ErrInvalidRarity
+	}
+
+	if filter.SpeciesID < 0 {
+		return ErrInvalidSpecies
+	}
+
+	if filter.MaxIVPercent < 0 || filter.MaxIVPercent > 100 {
+		return ErrInvalidReleaseIV
+	}
+
+	if filter.KeepPerSpecies < 0 {
+		return ErrInvalidKeepCount
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for _, id := range filter.PokemonIDs {
+		if seen[id] {
+			return ErrDuplicateReleasePokemon
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package validators
+
+import (
+	"errors"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCannotTradeWithSelf   = errors.New("cannot trade with yourself")
+	ErrEmptyTrade            = errors.New("trade must include at least one pokemon")
+	ErrTooManyTradePokemon   = errors.New("too many pokemon on one side of the trade")
+	ErrInvalidTradeCoins     = errors.New("trade coins cannot be negative")
+	ErrDuplicateTradePokemon = errors.New("a pokemon is included in the trade more than once")
+)
+
+// ValidateTrade checks a trade offer's participants, Pokemon and coins
+func ValidateTrade(trade *domain.Trade) error {
+	if trade.ProposerID == trade.RecipientID {
+		return ErrCannotTradeWithSelf
+	}
+
+	if trade.ProposerCoins < 0 || trade.RecipientCoins < 0 {
+		return ErrInvalidTradeCoins
+	}
+
+	if len(trade.ProposerPokemonIDs) > domain.MaxTradePokemon || len(trade.RecipientPokemonIDs) > domain.MaxTradePokemon {
+		return ErrTooManyTradePokemon
+	}
+
+	if len(trade.ProposerPokemonIDs)+len(trade.RecipientPokemonIDs) == 0 {
+		return ErrEmptyTrade
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for _, ids := range [][]uuid.UUID{trade.ProposerPokemonIDs, trade.RecipientPokemonIDs} {
+		for _, id := range ids {
+			if seen[id] {
+				return ErrDuplicateTradePokemon
+			}
+			seen[id] = true
+		}
+	}
+
+	return nil
+}
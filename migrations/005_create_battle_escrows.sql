@@ -0,0 +1,42 @@
+-- Migration: Create battle escrow table
+-- Wagers are locked here when a battle starts and leave exactly once:
+-- paid out to the winner, or refunded on draw, crash or abandonment.
+-- Battles themselves are now stored too, so crashed and abandoned battles
+-- can be found again.
+
+CREATE TABLE IF NOT EXISTS battle_escrows (
+  battle_id UUID PRIMARY KEY,
+  player1_id UUID NOT NULL REFERENCES users(id),
+  player2_id UUID NOT NULL REFERENCES users(id),
+  amount INTEGER NOT NULL CHECK (amount >= 0),  -- Stake per player
+  status VARCHAR(50) NOT NULL DEFAULT 'held',
+  winner_id UUID REFERENCES users(id),
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  settled_at TIMESTAMP,
+
+  CHECK (status IN ('held', 'paid_out', 'refunded')),
+  CHECK (status <> 'paid_out' OR winner_id IS NOT NULL),
+  CHECK (player1_id != player2_id)
+);
+
+-- Unsettled escrows are scanned on startup to refund battles lost in a crash
+CREATE INDEX IF NOT EXISTS idx_battle_escrows_status ON battle_escrows(status);
+
+-- The initial schema created battles before migration 003 could, so bring
+-- it up to the columns the battle repository maps
+ALTER TABLE battles
+  ADD COLUMN IF NOT EXISTS player1_pokemon_id UUID,
+  ADD COLUMN IF NOT EXISTS player2_pokemon_id UUID,
+  ADD COLUMN IF NOT EXISTS wager_amount INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS status VARCHAR(50) NOT NULL DEFAULT 'completed',
+  ADD COLUMN IF NOT EXISTS current_turn INTEGER DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS created_at TIMESTAMP DEFAULT NOW(),
+  ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP;
+
+-- Player 2 is unknown while a battle waits for an opponent
+ALTER TABLE battles ALTER COLUMN player2_id DROP NOT NULL;
+ALTER TABLE battles ALTER COLUMN started_at DROP NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_battles_status ON battles(status);
+
+COMMENT ON TABLE battle_escrows IS 'Battle wagers held until the battle is won, drawn or abandoned';
@@ -0,0 +1,14 @@
+-- Migration: Create roll receipts table
+-- Stores the result of a roll under the client's idempotency key, so a
+-- retried request returns the original pull instead of an error.
+
+CREATE TABLE IF NOT EXISTS roll_receipts (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  idempotency_key VARCHAR(255) NOT NULL,
+  pokemon_ids UUID[] NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (user_id, idempotency_key)
+);
+
+COMMENT ON TABLE roll_receipts IS 'Pulls keyed by client idempotency key for safe request retries';
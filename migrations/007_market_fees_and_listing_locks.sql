@@ -0,0 +1,14 @@
+-- Migration: Market fees and listing locks
+-- Records the fee burned on each sale and stops a Pokemon from having
+-- more than one active listing at a time.
+
+ALTER TABLE market_transactions
+  ADD COLUMN IF NOT EXISTS fee INTEGER NOT NULL DEFAULT 0 CHECK (fee >= 0);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_market_listings_active_pokemon
+  ON market_listings(user_pokemon_id)
+  WHERE status = 'active';
+
+CREATE INDEX IF NOT EXISTS idx_market_transactions_listing ON market_transactions(listing_id);
+
+COMMENT ON COLUMN market_transactions.fee IS 'Coins removed from the economy, the seller receives price - fee';
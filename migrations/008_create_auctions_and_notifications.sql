@@ -0,0 +1,56 @@
+-- Migration: Create auctions and notifications
+-- Auctions are market listings with bidding details, so the one active
+-- listing per Pokemon rule covers them too. Only the highest bid is held:
+-- its coins are taken when placed and refunded as soon as it is outbid.
+
+ALTER TABLE market_listings
+  ADD COLUMN IF NOT EXISTS listing_type VARCHAR(20) NOT NULL DEFAULT 'fixed'
+  CHECK (listing_type IN ('fixed', 'auction'));
+
+ALTER TABLE market_listings DROP CONSTRAINT IF EXISTS market_listings_status_check;
+ALTER TABLE market_listings
+  ADD CONSTRAINT market_listings_status_check
+  CHECK (status IN ('active', 'sold', 'cancelled', 'expired'));
+
+CREATE TABLE IF NOT EXISTS auctions (
+  listing_id UUID PRIMARY KEY REFERENCES market_listings(id) ON DELETE CASCADE,
+  starting_price INTEGER NOT NULL CHECK (starting_price > 0),
+  min_increment INTEGER NOT NULL CHECK (min_increment > 0),
+  current_bid INTEGER NOT NULL DEFAULT 0 CHECK (current_bid >= 0),
+  highest_bidder_id UUID REFERENCES users(id),
+  bid_count INTEGER NOT NULL DEFAULT 0 CHECK (bid_count >= 0),
+  ends_at TIMESTAMP NOT NULL,
+
+  CHECK ((highest_bidder_id IS NULL) = (bid_count = 0))
+);
+
+-- The settlement scheduler looks up auctions by end time
+CREATE INDEX IF NOT EXISTS idx_auctions_ends_at ON auctions(ends_at);
+
+CREATE TABLE IF NOT EXISTS auction_bids (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  listing_id UUID NOT NULL REFERENCES auctions(listing_id) ON DELETE CASCADE,
+  bidder_id UUID NOT NULL REFERENCES users(id),
+  amount INTEGER NOT NULL CHECK (amount > 0),
+  created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_auction_bids_listing ON auction_bids(listing_id, created_at);
+
+-- Messages for users, written with the event and delivered by the Discord bot
+CREATE TABLE IF NOT EXISTS notifications (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  type VARCHAR(50) NOT NULL,
+  title VARCHAR(255) NOT NULL,
+  message TEXT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  delivered_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_undelivered
+  ON notifications(created_at)
+  WHERE delivered_at IS NULL;
+
+COMMENT ON TABLE auctions IS 'Bidding details for market listings of type auction';
+COMMENT ON TABLE notifications IS 'User notifications waiting for (or already sent by) the Discord bot';
@@ -0,0 +1,31 @@
+-- Migration: Create trades table
+-- Direct player-to-player trades. Each side's Pokemon and coins only move
+-- once both players confirm, all in one transaction.
+
+CREATE TABLE IF NOT EXISTS trades (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  proposer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  recipient_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  proposer_pokemon_ids UUID[] NOT NULL DEFAULT '{}',
+  recipient_pokemon_ids UUID[] NOT NULL DEFAULT '{}',
+  proposer_coins INTEGER NOT NULL DEFAULT 0 CHECK (proposer_coins >= 0),
+  recipient_coins INTEGER NOT NULL DEFAULT 0 CHECK (recipient_coins >= 0),
+  proposer_confirmed BOOLEAN NOT NULL DEFAULT FALSE,
+  recipient_confirmed BOOLEAN NOT NULL DEFAULT FALSE,
+  status VARCHAR(50) NOT NULL DEFAULT 'pending',
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP NOT NULL,
+  completed_at TIMESTAMP,
+
+  CHECK (status IN ('pending', 'completed', 'cancelled', 'expired')),
+  CHECK (proposer_id != recipient_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_trades_pending_proposer ON trades(proposer_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_trades_pending_recipient ON trades(recipient_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_trades_pending_expiry ON trades(expires_at) WHERE status = 'pending';
+
+-- Lets the bot attach actions (e.g. confirm/cancel buttons) to a notification
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS reference_id UUID;
+
+COMMENT ON TABLE trades IS 'Direct trade offers between two players, executed once both confirm';
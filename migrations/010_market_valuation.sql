@@ -0,0 +1,22 @@
+-- Migration: Market valuation
+-- Each sale records the species and IV% of the Pokemon sold, so prices can
+-- be valued per species without depending on what happens to the Pokemon later.
+
+ALTER TABLE market_transactions
+  ADD COLUMN IF NOT EXISTS species_id INTEGER REFERENCES pokemon_species(id),
+  ADD COLUMN IF NOT EXISTS iv_percentage NUMERIC(5, 2) NOT NULL DEFAULT 0
+    CHECK (iv_percentage BETWEEN 0 AND 100);
+
+-- Backfill earlier sales from the Pokemon as they are now
+UPDATE market_transactions mt
+SET species_id = up.species_id,
+    iv_percentage = ROUND((up.iv_hp + up.iv_attack + up.iv_defense
+      + up.iv_sp_attack + up.iv_sp_defense + up.iv_speed) / 186.0 * 100, 2)
+FROM market_listings ml
+JOIN user_pokemon up ON ml.user_pokemon_id = up.id
+WHERE mt.listing_id = ml.id AND mt.species_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_market_transactions_species_time
+  ON market_transactions(species_id, completed_at DESC);
+
+COMMENT ON COLUMN market_transactions.iv_percentage IS 'IV% of the Pokemon when it sold, used to adjust valuations';
@@ -0,0 +1,25 @@
+-- Migration: Allow Pokemon to be released
+-- Released Pokemon are deleted, so nothing that outlives them may block the
+-- delete: sales keep their species and IV snapshot, battle history keeps
+-- the battle, and past team entries go with the Pokemon.
+
+-- Sold listings cascade with their Pokemon; the sale record stays
+ALTER TABLE market_transactions ALTER COLUMN listing_id DROP NOT NULL;
+ALTER TABLE market_transactions DROP CONSTRAINT IF EXISTS market_transactions_listing_id_fkey;
+ALTER TABLE market_transactions
+  ADD CONSTRAINT market_transactions_listing_id_fkey
+  FOREIGN KEY (listing_id) REFERENCES market_listings(id) ON DELETE SET NULL;
+
+ALTER TABLE battle_teams DROP CONSTRAINT IF EXISTS battle_teams_user_pokemon_id_fkey;
+ALTER TABLE battle_teams
+  ADD CONSTRAINT battle_teams_user_pokemon_id_fkey
+  FOREIGN KEY (user_pokemon_id) REFERENCES user_pokemon(id) ON DELETE CASCADE;
+
+ALTER TABLE battles DROP CONSTRAINT IF EXISTS battles_player1_pokemon_id_fkey;
+ALTER TABLE battles DROP CONSTRAINT IF EXISTS battles_player2_pokemon_id_fkey;
+ALTER TABLE battles
+  ADD CONSTRAINT battles_player1_pokemon_id_fkey
+  FOREIGN KEY (player1_pokemon_id) REFERENCES user_pokemon(id) ON DELETE SET NULL;
+ALTER TABLE battles
+  ADD CONSTRAINT battles_player2_pokemon_id_fkey
+  FOREIGN KEY (player2_pokemon_id) REFERENCES user_pokemon(id) ON DELETE SET NULL;
@@ -0,0 +1,34 @@
+-- Migration: Create candy balances and the Pokemon audit log
+-- Candy is earned per species by releasing Pokemon and spent on IV
+-- re-rolls, IV raises and nature mints. Every one of those changes is
+-- recorded in the audit log with the traits before and after.
+
+CREATE TABLE IF NOT EXISTS user_candy (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  species_id INTEGER NOT NULL REFERENCES pokemon_species(id),
+  amount INTEGER NOT NULL DEFAULT 0 CHECK (amount >= 0),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (user_id, species_id)
+);
+
+-- No foreign key on the Pokemon: a release is logged as it is deleted
+CREATE TABLE IF NOT EXISTS pokemon_audit_log (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  user_pokemon_id UUID NOT NULL,
+  species_id INTEGER NOT NULL REFERENCES pokemon_species(id),
+  action VARCHAR(50) NOT NULL,
+  candy_delta INTEGER NOT NULL DEFAULT 0,
+  before_traits JSONB,
+  after_traits JSONB,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+  CHECK (action IN ('release', 'iv_reroll', 'iv_raise', 'nature_mint'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_pokemon_audit_log_pokemon ON pokemon_audit_log(user_pokemon_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pokemon_audit_log_user ON pokemon_audit_log(user_id, created_at DESC);
+
+COMMENT ON TABLE user_candy IS 'Per-species candy earned by releasing duplicates';
+COMMENT ON TABLE pokemon_audit_log IS 'Every candy-driven change to a Pokemon, including releases';
@@ -0,0 +1,114 @@
+-- Migration: Create evolution chains, learnsets and item inventories
+-- Each species lists the species it can evolve into and what that costs:
+-- candy of the species, coins, an item from the user's inventory and a
+-- number of battles the Pokemon itself has won. Evolving unlocks the new
+-- species' learnset moves for that Pokemon.
+
+CREATE TABLE IF NOT EXISTS species_evolutions (
+  from_species_id INTEGER NOT NULL REFERENCES pokemon_species(id),
+  to_species_id INTEGER NOT NULL REFERENCES pokemon_species(id),
+  candy_cost INTEGER NOT NULL DEFAULT 0 CHECK (candy_cost >= 0),
+  coin_cost INTEGER NOT NULL DEFAULT 0 CHECK (coin_cost >= 0),
+  item VARCHAR(100),                                              -- Consumed on evolution
+  battle_wins INTEGER NOT NULL DEFAULT 0 CHECK (battle_wins >= 0), -- Won by the Pokemon itself
+
+  PRIMARY KEY (from_species_id, to_species_id),
+  CHECK (from_species_id != to_species_id)
+);
+
+CREATE TABLE IF NOT EXISTS species_learnsets (
+  species_id INTEGER NOT NULL REFERENCES pokemon_species(id),
+  move_id INTEGER NOT NULL REFERENCES moves(id),
+
+  PRIMARY KEY (species_id, move_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_pokemon_unlocked_moves (
+  user_pokemon_id UUID NOT NULL REFERENCES user_pokemon(id) ON DELETE CASCADE,
+  move_id INTEGER NOT NULL REFERENCES moves(id),
+  unlocked_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (user_pokemon_id, move_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_items (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  item VARCHAR(100) NOT NULL,
+  quantity INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (user_id, item)
+);
+
+-- Battle wins are counted per Pokemon
+CREATE INDEX IF NOT EXISTS idx_battles_player1_pokemon ON battles(player1_pokemon_id) WHERE winner_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_battles_player2_pokemon ON battles(player2_pokemon_id) WHERE winner_id IS NOT NULL;
+
+-- Evolutions are audited alongside candy changes
+ALTER TABLE pokemon_audit_log DROP CONSTRAINT IF EXISTS pokemon_audit_log_action_check;
+ALTER TABLE pokemon_audit_log ADD CONSTRAINT pokemon_audit_log_action_check
+  CHECK (action IN ('release', 'iv_reroll', 'iv_raise', 'nature_mint', 'evolve'));
+
+-- =====================================================
+-- Seed chains for the seeded species. Steps to unseeded species (e.g.
+-- Kadabra) are skipped, and trade evolutions cost coins instead.
+-- =====================================================
+INSERT INTO species_evolutions (from_species_id, to_species_id, candy_cost, coin_cost, item, battle_wins)
+SELECT v.from_id, v.to_id, v.candy, v.coins, v.item, v.wins
+FROM (VALUES
+  (1, 2, 25, 0, NULL, 0),          -- Bulbasaur -> Ivysaur
+  (2, 3, 100, 0, NULL, 3),         -- Ivysaur -> Venusaur
+  (4, 5, 25, 0, NULL, 0),          -- Charmander -> Charmeleon
+  (5, 6, 100, 0, NULL, 3),         -- Charmeleon -> Charizard
+  (7, 8, 25, 0, NULL, 0),          -- Squirtle -> Wartortle
+  (8, 9, 100, 0, NULL, 3),         -- Wartortle -> Blastoise
+  (172, 25, 25, 0, NULL, 5),       -- Pichu -> Pikachu
+  (25, 26, 50, 0, 'thunder-stone', 0),
+  (32, 34, 100, 0, 'moon-stone', 0), -- Nidoran-m -> Nidoking
+  (58, 59, 50, 0, 'fire-stone', 0),
+  (63, 65, 100, 500, NULL, 0),     -- Abra -> Alakazam
+  (66, 68, 100, 500, NULL, 0),     -- Machop -> Machamp
+  (74, 76, 100, 500, NULL, 0),     -- Geodude -> Golem
+  (92, 94, 100, 500, NULL, 0),     -- Gastly -> Gengar
+  (111, 112, 50, 0, NULL, 0),      -- Rhyhorn -> Rhydon
+  (129, 130, 400, 0, NULL, 0),     -- Magikarp -> Gyarados
+  (147, 148, 25, 0, NULL, 0),      -- Dratini -> Dragonair
+  (148, 149, 100, 0, NULL, 10)     -- Dragonair -> Dragonite
+) AS v(from_id, to_id, candy, coins, item, wins)
+JOIN pokemon_species f ON f.id = v.from_id
+JOIN pokemon_species t ON t.id = v.to_id
+ON CONFLICT DO NOTHING;
+
+INSERT INTO species_learnsets (species_id, move_id)
+SELECT v.species_id, m.id
+FROM (VALUES
+  (1, 'Tackle'), (1, 'Vine Whip'),
+  (2, 'Razor Leaf'), (2, 'Poison Sting'),
+  (3, 'Solar Beam'), (3, 'Giga Drain'), (3, 'Sludge Bomb'), (3, 'Earthquake'),
+  (4, 'Scratch'), (4, 'Ember'),
+  (5, 'Flamethrower'), (5, 'Metal Claw'),
+  (6, 'Fire Blast'), (6, 'Wing Attack'), (6, 'Dragon Claw'), (6, 'Will-O-Wisp'),
+  (7, 'Tackle'), (7, 'Water Gun'),
+  (8, 'Bubble Beam'), (8, 'Bite'),
+  (9, 'Hydro Pump'), (9, 'Surf'), (9, 'Ice Beam'), (9, 'Flash Cannon'),
+  (172, 'Thunder Shock'), (172, 'Quick Attack'),
+  (25, 'Thunderbolt'), (25, 'Thunder Wave'),
+  (26, 'Thunder'), (26, 'Body Slam'),
+  (32, 'Poison Sting'), (34, 'Earthquake'), (34, 'Sludge Bomb'), (34, 'Earth Power'),
+  (58, 'Bite'), (58, 'Ember'), (59, 'Flamethrower'), (59, 'Crunch'),
+  (63, 'Confusion'), (65, 'Psychic'), (65, 'Future Sight'), (65, 'Shadow Ball'),
+  (66, 'Karate Chop'), (66, 'Low Kick'), (68, 'Close Combat'), (68, 'Mach Punch'), (68, 'Stone Edge'),
+  (74, 'Rock Throw'), (74, 'Tackle'), (76, 'Rock Slide'), (76, 'Earthquake'), (76, 'Stealth Rock'),
+  (92, 'Lick'), (94, 'Shadow Ball'), (94, 'Sludge Bomb'), (94, 'Thunderbolt'),
+  (111, 'Rock Throw'), (112, 'Earthquake'), (112, 'Stone Edge'),
+  (129, 'Tackle'), (130, 'Bite'), (130, 'Crunch'), (130, 'Aqua Jet'),
+  (147, 'Dragon Rage'), (148, 'Dragon Claw'), (148, 'Aqua Jet'), (149, 'Outrage'), (149, 'Wing Attack')
+) AS v(species_id, move)
+JOIN pokemon_species s ON s.id = v.species_id
+JOIN moves m ON m.name = v.move
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE species_evolutions IS 'Evolution chains with the candy, coins, item and battle wins each step needs';
+COMMENT ON TABLE species_learnsets IS 'Moves each species can learn';
+COMMENT ON TABLE user_pokemon_unlocked_moves IS 'Learnset moves a Pokemon has unlocked by evolving';
+COMMENT ON TABLE user_items IS 'Items (e.g. evolution stones) each user holds';
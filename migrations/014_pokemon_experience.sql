@@ -0,0 +1,53 @@
+-- Migration: Experience, growth rates and battle formats
+-- Battles award experience to the Pokemon that fought. Each species levels
+-- along one of four growth-rate curves, and each battle is played under a
+-- format that can cap or flatten levels.
+
+ALTER TABLE pokemon_species
+  ADD COLUMN IF NOT EXISTS growth_rate VARCHAR(20) NOT NULL DEFAULT 'medium_fast'
+    CHECK (growth_rate IN ('fast', 'medium_fast', 'medium_slow', 'slow')),
+  ADD COLUMN IF NOT EXISTS base_experience INTEGER NOT NULL DEFAULT 64 CHECK (base_experience > 0);
+
+-- Experience yield grows with rarity
+UPDATE pokemon_species SET base_experience = CASE rarity
+  WHEN 'common' THEN 60
+  WHEN 'uncommon' THEN 100
+  WHEN 'rare' THEN 160
+  WHEN 'epic' THEN 240
+  WHEN 'legendary' THEN 270
+  WHEN 'mythic' THEN 300
+  ELSE 64
+END;
+
+-- Legendaries and pseudo-legendary lines level slowly
+UPDATE pokemon_species SET growth_rate = 'slow'
+WHERE rarity IN ('legendary', 'mythic')
+   OR id IN (111, 112, 129, 130, 131, 142, 143, 147, 148, 149, 248, 376);
+
+-- Starter lines, Pidgey and the Nidoran lines level medium slow
+UPDATE pokemon_species SET growth_rate = 'medium_slow'
+WHERE id IN (1, 2, 3, 4, 5, 6, 7, 8, 9, 16, 29, 32, 34, 60, 63, 65, 66, 68, 69, 74, 76, 92, 94, 152, 155, 158, 282);
+
+-- Fairy-like species level fast
+UPDATE pokemon_species SET growth_rate = 'fast' WHERE id IN (39);
+
+ALTER TABLE user_pokemon
+  ADD COLUMN IF NOT EXISTS experience INTEGER NOT NULL DEFAULT 0 CHECK (experience >= 0);
+
+-- Existing Pokemon start with the experience their level needs
+UPDATE user_pokemon up SET experience = CASE ps.growth_rate
+  WHEN 'fast' THEN 4 * up.level * up.level * up.level / 5
+  WHEN 'medium_slow' THEN 6 * up.level * up.level * up.level / 5 - 15 * up.level * up.level + 100 * up.level - 140
+  WHEN 'slow' THEN 5 * up.level * up.level * up.level / 4
+  ELSE up.level * up.level * up.level
+END
+FROM pokemon_species ps
+WHERE up.species_id = ps.id AND up.level > 1;
+
+ALTER TABLE battles
+  ADD COLUMN IF NOT EXISTS format VARCHAR(50) NOT NULL DEFAULT 'standard';
+
+COMMENT ON COLUMN pokemon_species.growth_rate IS 'Experience curve: fast, medium_fast, medium_slow or slow';
+COMMENT ON COLUMN pokemon_species.base_experience IS 'Experience yield for defeating this species';
+COMMENT ON COLUMN user_pokemon.experience IS 'Total experience; the level follows the species growth rate';
+COMMENT ON COLUMN battles.format IS 'Format the battle is played under, e.g. ranked flattens levels to 50';
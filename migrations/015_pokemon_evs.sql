@@ -0,0 +1,21 @@
+-- Migration: Effort values
+-- Each Pokemon holds up to 252 EVs per stat and 510 in total. EVs start at
+-- zero and are trained with coins or vitamins.
+
+ALTER TABLE user_pokemon
+  ADD COLUMN IF NOT EXISTS ev_hp INTEGER NOT NULL DEFAULT 0 CHECK (ev_hp BETWEEN 0 AND 252),
+  ADD COLUMN IF NOT EXISTS ev_attack INTEGER NOT NULL DEFAULT 0 CHECK (ev_attack BETWEEN 0 AND 252),
+  ADD COLUMN IF NOT EXISTS ev_defense INTEGER NOT NULL DEFAULT 0 CHECK (ev_defense BETWEEN 0 AND 252),
+  ADD COLUMN IF NOT EXISTS ev_sp_attack INTEGER NOT NULL DEFAULT 0 CHECK (ev_sp_attack BETWEEN 0 AND 252),
+  ADD COLUMN IF NOT EXISTS ev_sp_defense INTEGER NOT NULL DEFAULT 0 CHECK (ev_sp_defense BETWEEN 0 AND 252),
+  ADD COLUMN IF NOT EXISTS ev_speed INTEGER NOT NULL DEFAULT 0 CHECK (ev_speed BETWEEN 0 AND 252);
+
+ALTER TABLE user_pokemon DROP CONSTRAINT IF EXISTS user_pokemon_ev_total_check;
+ALTER TABLE user_pokemon ADD CONSTRAINT user_pokemon_ev_total_check
+  CHECK (ev_hp + ev_attack + ev_defense + ev_sp_attack + ev_sp_defense + ev_speed <= 510);
+
+ALTER TABLE pokemon_audit_log DROP CONSTRAINT IF EXISTS pokemon_audit_log_action_check;
+ALTER TABLE pokemon_audit_log ADD CONSTRAINT pokemon_audit_log_action_check
+  CHECK (action IN ('release', 'iv_reroll', 'iv_raise', 'nature_mint', 'evolve', 'ev_train'));
+
+COMMENT ON COLUMN user_pokemon.ev_hp IS 'Effort values, 0-252 per stat and 510 in total';
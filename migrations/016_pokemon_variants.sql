@@ -0,0 +1,116 @@
+-- Migration: Shiny Pokemon, genders and forms
+-- Each pull rolls shininess, a gender following the species' ratio, and
+-- sometimes an alternate or regional form with its own types, base stats
+-- and sprites.
+
+-- =====================================================
+-- 1. Species gender ratios and shiny sprites
+-- =====================================================
+ALTER TABLE pokemon_species
+  ADD COLUMN IF NOT EXISTS gender_rate SMALLINT NOT NULL DEFAULT 4
+    CHECK (gender_rate BETWEEN -1 AND 8),
+  ADD COLUMN IF NOT EXISTS shiny_sprite_url TEXT;
+
+COMMENT ON COLUMN pokemon_species.gender_rate IS 'Chance of being female in eighths, -1 for genderless';
+
+UPDATE pokemon_species
+SET shiny_sprite_url = REPLACE(sprite_url, '/sprites/pokemon/', '/sprites/pokemon/shiny/')
+WHERE shiny_sprite_url IS NULL AND sprite_url IS NOT NULL;
+
+-- Genderless
+UPDATE pokemon_species SET gender_rate = -1
+WHERE id IN (81, 100, 120, 144, 145, 146, 150, 151, 243, 244, 245, 249, 250, 376, 377, 378, 379, 384);
+
+-- Always male
+UPDATE pokemon_species SET gender_rate = 0 WHERE id IN (32, 34);
+
+-- Mostly male (one in eight female)
+UPDATE pokemon_species SET gender_rate = 1
+WHERE id IN (1, 2, 3, 4, 5, 6, 7, 8, 9, 133, 142, 143, 152, 155, 158);
+
+-- Three in four male
+UPDATE pokemon_species SET gender_rate = 2 WHERE id IN (58, 59, 63, 65, 66, 68);
+
+-- Mostly female
+UPDATE pokemon_species SET gender_rate = 6 WHERE id IN (39);
+
+-- Always female
+UPDATE pokemon_species SET gender_rate = 8 WHERE id IN (29, 380);
+
+-- =====================================================
+-- 2. Forms
+-- =====================================================
+CREATE TABLE IF NOT EXISTS pokemon_forms (
+  id SERIAL PRIMARY KEY,
+  species_id INTEGER NOT NULL REFERENCES pokemon_species(id) ON DELETE CASCADE,
+  name VARCHAR(50) NOT NULL,
+  type1 VARCHAR(50) NOT NULL,
+  type2 VARCHAR(50),
+  base_hp INTEGER NOT NULL,
+  base_attack INTEGER NOT NULL,
+  base_defense INTEGER NOT NULL,
+  base_sp_attack INTEGER NOT NULL,
+  base_sp_defense INTEGER NOT NULL,
+  base_speed INTEGER NOT NULL,
+  sprite_url TEXT,
+  shiny_sprite_url TEXT,
+  roll_chance FLOAT NOT NULL CHECK (roll_chance > 0 AND roll_chance <= 1),
+
+  UNIQUE (species_id, name),
+  UNIQUE (id, species_id)
+);
+
+COMMENT ON COLUMN pokemon_forms.roll_chance IS 'Chance a pull of the species comes in this form';
+
+INSERT INTO pokemon_forms (species_id, name, type1, type2, base_hp, base_attack, base_defense, base_sp_attack, base_sp_defense, base_speed, sprite_url, shiny_sprite_url, roll_chance) VALUES
+(19, 'alola', 'dark', 'normal', 30, 56, 35, 25, 35, 72, 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/10091.png', 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/10091.png', 0.2),
+(26, 'alola', 'electric', 'psychic', 60, 85, 50, 95, 85, 110, 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/10100.png', 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/10100.png', 0.2),
+(27, 'alola', 'ice', 'steel', 50, 75, 90, 10, 35, 40, 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/10101.png', 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/10101.png', 0.2),
+(50, 'alola', 'ground', 'steel', 10, 55, 30, 35, 45, 90, 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/10105.png', 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/10105.png', 0.2),
+(52, 'alola', 'dark', NULL, 40, 35, 35, 50, 40, 90, 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/10107.png', 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/10107.png', 0.15),
+(52, 'galar', 'steel', NULL, 50, 65, 55, 40, 40, 40, 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/10161.png', 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/10161.png', 0.15),
+(58, 'hisui', 'fire', 'rock', 60, 75, 45, 65, 50, 55, 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/10229.png', 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/10229.png', 0.2),
+(59, 'hisui', 'fire', 'rock', 95, 115, 80, 95, 80, 90, 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/10230.png', 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/10230.png', 0.2),
+(74, 'alola', 'rock', 'electric', 40, 80, 100, 30, 30, 20, 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/10109.png', 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/10109.png', 0.2),
+(76, 'alola', 'rock', 'electric', 80, 120, 130, 55, 65, 45, 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/10111.png', 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/10111.png', 0.2),
+(77, 'galar', 'psychic', NULL, 50, 85, 55, 65, 65, 90, 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/10162.png', 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/10162.png', 0.2),
+(103, 'alola', 'grass', 'dragon', 95, 105, 85, 125, 75, 45, 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/10114.png', 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/10114.png', 0.2),
+(144, 'galar', 'psychic', 'flying', 90, 85, 85, 125, 100, 95, 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/10169.png', 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/10169.png', 0.1),
+(145, 'galar', 'fighting', 'flying', 90, 125, 90, 85, 90, 100, 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/10170.png', 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/10170.png', 0.1),
+(146, 'galar', 'dark', 'flying', 90, 85, 90, 100, 125, 90, 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/10171.png', 'https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/shiny/10171.png', 0.1)
+ON CONFLICT (species_id, name) DO NOTHING;
+
+-- =====================================================
+-- 3. Pokemon shininess, gender and form
+-- =====================================================
+ALTER TABLE user_pokemon
+  ADD COLUMN IF NOT EXISTS is_shiny BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN IF NOT EXISTS gender VARCHAR(20) NOT NULL DEFAULT 'genderless'
+    CHECK (gender IN ('male', 'female', 'genderless')),
+  ADD COLUMN IF NOT EXISTS form_id INTEGER;
+
+-- A form must belong to the Pokemon's species
+ALTER TABLE user_pokemon DROP CONSTRAINT IF EXISTS user_pokemon_form_species_fkey;
+ALTER TABLE user_pokemon ADD CONSTRAINT user_pokemon_form_species_fkey
+  FOREIGN KEY (form_id, species_id) REFERENCES pokemon_forms (id, species_id);
+
+-- Existing Pokemon get a gender following their species' ratio
+UPDATE user_pokemon up
+SET gender = CASE
+    WHEN ps.gender_rate = -1 THEN 'genderless'
+    WHEN random() * 8 < ps.gender_rate THEN 'female'
+    ELSE 'male'
+  END
+FROM pokemon_species ps
+WHERE up.species_id = ps.id;
+
+CREATE INDEX IF NOT EXISTS idx_user_pokemon_shiny ON user_pokemon(user_id) WHERE is_shiny;
+
+-- =====================================================
+-- 4. Sales record shininess and form for valuation
+-- =====================================================
+ALTER TABLE market_transactions
+  ADD COLUMN IF NOT EXISTS variant_factor NUMERIC(6, 2) NOT NULL DEFAULT 1
+    CHECK (variant_factor >= 1);
+
+COMMENT ON COLUMN market_transactions.variant_factor IS 'Shiny and form value factor of the Pokemon when it sold, used to adjust valuations';
@@ -0,0 +1,134 @@
+-- Migration: Ability slots and hidden abilities
+-- Each species has up to two regular abilities and one hidden ability. A
+-- pull gets one of the regular abilities at random, or rarely the hidden
+-- one. An Ability Capsule switches a Pokemon between its regular abilities.
+
+-- =====================================================
+-- 1. Species abilities
+-- =====================================================
+ALTER TABLE pokemon_species
+  ADD COLUMN IF NOT EXISTS abilities TEXT[] NOT NULL DEFAULT '{}'
+    CHECK (cardinality(abilities) <= 2),
+  ADD COLUMN IF NOT EXISTS hidden_ability VARCHAR(100);
+
+COMMENT ON COLUMN pokemon_species.abilities IS 'Regular abilities, up to two';
+COMMENT ON COLUMN pokemon_species.hidden_ability IS 'Rare ability a pull can roll, NULL if the species has none';
+
+UPDATE pokemon_species ps
+SET abilities = v.abilities, hidden_ability = v.hidden_ability
+FROM (VALUES
+  -- Legendary and mythic
+  (150, ARRAY['pressure'], 'unnerve'),
+  (151, ARRAY['synchronize'], NULL),
+  (249, ARRAY['pressure'], 'multiscale'),
+  (250, ARRAY['pressure'], 'regenerator'),
+  (384, ARRAY['air_lock'], NULL),
+  (144, ARRAY['pressure'], 'snow_cloak'),
+  (145, ARRAY['pressure'], 'static'),
+  (146, ARRAY['pressure'], 'flame_body'),
+  (243, ARRAY['pressure'], 'inner_focus'),
+  (244, ARRAY['pressure'], 'inner_focus'),
+  (245, ARRAY['pressure'], 'inner_focus'),
+  (377, ARRAY['clear_body'], 'sturdy'),
+  (378, ARRAY['clear_body'], 'ice_body'),
+  (379, ARRAY['clear_body'], 'light_metal'),
+  (380, ARRAY['levitate'], NULL),
+  -- Epic
+  (3, ARRAY['overgrow'], 'chlorophyll'),
+  (6, ARRAY['blaze'], 'solar_power'),
+  (9, ARRAY['torrent'], 'rain_dish'),
+  (94, ARRAY['cursed_body'], NULL),
+  (131, ARRAY['water_absorb', 'shell_armor'], 'hydration'),
+  (143, ARRAY['immunity', 'thick_fat'], 'gluttony'),
+  (149, ARRAY['inner_focus'], 'multiscale'),
+  (248, ARRAY['sand_stream'], 'unnerve'),
+  (282, ARRAY['synchronize', 'trace'], 'telepathy'),
+  (376, ARRAY['clear_body'], 'light_metal'),
+  -- Rare
+  (2, ARRAY['overgrow'], 'chlorophyll'),
+  (5, ARRAY['blaze'], 'solar_power'),
+  (8, ARRAY['torrent'], 'rain_dish'),
+  (26, ARRAY['static'], 'lightning_rod'),
+  (34, ARRAY['poison_point', 'rivalry'], 'sheer_force'),
+  (59, ARRAY['intimidate', 'flash_fire'], 'justified'),
+  (65, ARRAY['synchronize', 'inner_focus'], 'magic_guard'),
+  (68, ARRAY['guts', 'no_guard'], 'steadfast'),
+  (76, ARRAY['rock_head', 'sturdy'], 'sand_veil'),
+  (91, ARRAY['shell_armor', 'skill_link'], 'overcoat'),
+  (103, ARRAY['chlorophyll'], 'harvest'),
+  (112, ARRAY['lightning_rod', 'rock_head'], 'reckless'),
+  (130, ARRAY['intimidate'], 'moxie'),
+  (142, ARRAY['rock_head', 'pressure'], 'unnerve'),
+  (148, ARRAY['shed_skin'], 'marvel_scale'),
+  -- Uncommon
+  (1, ARRAY['overgrow'], 'chlorophyll'),
+  (4, ARRAY['blaze'], 'solar_power'),
+  (7, ARRAY['torrent'], 'rain_dish'),
+  (25, ARRAY['static'], 'lightning_rod'),
+  (39, ARRAY['cute_charm', 'competitive'], 'friend_guard'),
+  (54, ARRAY['damp', 'cloud_nine'], 'swift_swim'),
+  (58, ARRAY['intimidate', 'flash_fire'], 'justified'),
+  (63, ARRAY['synchronize', 'inner_focus'], 'magic_guard'),
+  (66, ARRAY['guts', 'no_guard'], 'steadfast'),
+  (74, ARRAY['rock_head', 'sturdy'], 'sand_veil'),
+  (92, ARRAY['levitate'], NULL),
+  (95, ARRAY['rock_head', 'sturdy'], 'weak_armor'),
+  (104, ARRAY['rock_head', 'lightning_rod'], 'battle_armor'),
+  (111, ARRAY['lightning_rod', 'rock_head'], 'reckless'),
+  (133, ARRAY['run_away', 'adaptability'], 'anticipation'),
+  (147, ARRAY['shed_skin'], 'marvel_scale'),
+  (152, ARRAY['overgrow'], 'leaf_guard'),
+  (155, ARRAY['blaze'], 'flash_fire'),
+  (158, ARRAY['torrent'], 'sheer_force'),
+  (172, ARRAY['static'], 'lightning_rod'),
+  -- Common
+  (10, ARRAY['shield_dust'], 'run_away'),
+  (13, ARRAY['shield_dust'], 'run_away'),
+  (16, ARRAY['keen_eye', 'tangled_feet'], 'big_pecks'),
+  (19, ARRAY['run_away', 'guts'], 'hustle'),
+  (21, ARRAY['keen_eye'], 'sniper'),
+  (27, ARRAY['sand_veil'], 'sand_rush'),
+  (29, ARRAY['poison_point', 'rivalry'], 'hustle'),
+  (32, ARRAY['poison_point', 'rivalry'], 'hustle'),
+  (41, ARRAY['inner_focus'], 'infiltrator'),
+  (43, ARRAY['chlorophyll'], 'run_away'),
+  (48, ARRAY['compound_eyes', 'tinted_lens'], 'run_away'),
+  (50, ARRAY['sand_veil', 'arena_trap'], 'sand_force'),
+  (52, ARRAY['pickup', 'technician'], 'unnerve'),
+  (60, ARRAY['water_absorb', 'damp'], 'swift_swim'),
+  (69, ARRAY['chlorophyll'], 'gluttony'),
+  (72, ARRAY['clear_body', 'liquid_ooze'], 'rain_dish'),
+  (77, ARRAY['run_away', 'flash_fire'], 'flame_body'),
+  (81, ARRAY['magnet_pull', 'sturdy'], 'analytic'),
+  (84, ARRAY['run_away', 'early_bird'], 'tangled_feet'),
+  (96, ARRAY['insomnia', 'forewarn'], 'inner_focus'),
+  (98, ARRAY['hyper_cutter', 'shell_armor'], 'sheer_force'),
+  (100, ARRAY['soundproof', 'static'], 'aftermath'),
+  (109, ARRAY['levitate'], NULL),
+  (118, ARRAY['swift_swim', 'water_veil'], 'lightning_rod'),
+  (120, ARRAY['illuminate', 'natural_cure'], 'analytic'),
+  (129, ARRAY['swift_swim'], 'rattled')
+) AS v(species_id, abilities, hidden_ability)
+WHERE ps.id = v.species_id;
+
+-- =====================================================
+-- 2. Pokemon ability slots
+-- =====================================================
+ALTER TABLE user_pokemon
+  ADD COLUMN IF NOT EXISTS ability_slot SMALLINT NOT NULL DEFAULT 1
+    CHECK (ability_slot BETWEEN 1 AND 3);
+
+COMMENT ON COLUMN user_pokemon.ability_slot IS '1 or 2 for a regular ability, 3 for the hidden ability';
+
+-- Existing Pokemon get one of their species' regular abilities
+UPDATE user_pokemon up
+SET ability_slot = 2
+FROM pokemon_species ps
+WHERE up.species_id = ps.id AND cardinality(ps.abilities) = 2 AND random() < 0.5;
+
+-- =====================================================
+-- 3. Ability Capsules are audited like other trait changes
+-- =====================================================
+ALTER TABLE pokemon_audit_log DROP CONSTRAINT IF EXISTS pokemon_audit_log_action_check;
+ALTER TABLE pokemon_audit_log ADD CONSTRAINT pokemon_audit_log_action_check
+  CHECK (action IN ('release', 'iv_reroll', 'iv_raise', 'nature_mint', 'evolve', 'ev_train', 'ability_capsule'));
@@ -0,0 +1,67 @@
+-- Migration: Create gacha banners
+-- A banner runs between two times and can limit the species that drop,
+-- feature some of them at a higher rate and override the rarity rates.
+-- Premium rolls pick a banner; daily rolls and rolls without one use the
+-- standard rates over every species.
+
+CREATE TABLE IF NOT EXISTS banners (
+  id VARCHAR(50) PRIMARY KEY,                  -- Slug players pick the banner by
+  name VARCHAR(100) NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  starts_at TIMESTAMP NOT NULL,
+  ends_at TIMESTAMP,                           -- NULL runs forever
+  rates JSONB,                                 -- Chance per rarity, NULL for the standard rates
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+  CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+-- Species a banner can drop. A banner without rows drops every species.
+CREATE TABLE IF NOT EXISTS banner_pool (
+  banner_id VARCHAR(50) NOT NULL REFERENCES banners(id) ON DELETE CASCADE,
+  species_id INTEGER NOT NULL REFERENCES pokemon_species(id),
+
+  PRIMARY KEY (banner_id, species_id)
+);
+
+-- Rate-up species, weighted against the other species of their rarity
+CREATE TABLE IF NOT EXISTS banner_featured (
+  banner_id VARCHAR(50) NOT NULL REFERENCES banners(id) ON DELETE CASCADE,
+  species_id INTEGER NOT NULL REFERENCES pokemon_species(id),
+  weight NUMERIC(8, 2) NOT NULL CHECK (weight >= 1),
+
+  PRIMARY KEY (banner_id, species_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_banners_active ON banners(starts_at, ends_at);
+
+COMMENT ON TABLE banners IS 'Limited-time gacha pools with rate-ups and optional custom rates';
+COMMENT ON COLUMN banner_featured.weight IS 'How many times more likely than other species of the same rarity';
+
+-- =====================================================
+-- Seed banners
+-- =====================================================
+INSERT INTO banners (id, name, description, starts_at, ends_at, rates) VALUES
+('legendary-birds', 'Legendary Birds', 'Articuno, Zapdos and Moltres at 5x and double legendary rates',
+  NOW(), NOW() + INTERVAL '14 days',
+  '{"common": 0.475, "uncommon": 0.25, "rare": 0.15, "epic": 0.07, "legendary": 0.05, "mythic": 0.005}'),
+('starter-festival', 'Starter Festival', 'Only the Kanto and Johto starters and their evolutions',
+  NOW(), NOW() + INTERVAL '30 days', NULL)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO banner_featured (banner_id, species_id, weight) VALUES
+('legendary-birds', 144, 5),
+('legendary-birds', 145, 5),
+('legendary-birds', 146, 5)
+ON CONFLICT (banner_id, species_id) DO NOTHING;
+
+INSERT INTO banner_pool (banner_id, species_id)
+SELECT 'starter-festival', id FROM pokemon_species
+WHERE id IN (1, 2, 3, 4, 5, 6, 7, 8, 9, 152, 155, 158)
+ON CONFLICT (banner_id, species_id) DO NOTHING;
+
+INSERT INTO banner_featured (banner_id, species_id, weight) VALUES
+('starter-festival', 152, 2),
+('starter-festival', 155, 2),
+('starter-festival', 158, 2)
+ON CONFLICT (banner_id, species_id) DO NOTHING;
@@ -0,0 +1,18 @@
+-- Migration: Persistent pity counters
+-- Each player has a counter per banner and per pity rarity: the pulls since
+-- they last pulled that rarity or rarer. Past soft pity each pull raises the
+-- chance of the rarity, and at hard pity it is guaranteed. The rules live in
+-- domain.PityRules.
+
+CREATE TABLE IF NOT EXISTS pity_counters (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  banner_id VARCHAR(50) NOT NULL DEFAULT '',   -- Empty for the standard pool and daily rolls
+  rarity VARCHAR(20) NOT NULL CHECK (rarity IN ('common', 'uncommon', 'rare', 'epic', 'legendary', 'mythic')),
+  pulls INTEGER NOT NULL DEFAULT 0 CHECK (pulls >= 0),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (user_id, banner_id, rarity)
+);
+
+COMMENT ON TABLE pity_counters IS 'Pulls since a player last pulled a rarity or rarer, per banner';
+COMMENT ON COLUMN pity_counters.banner_id IS 'Banner the pulls were on, not a foreign key so the standard pool can use an empty ID';
@@ -0,0 +1,22 @@
+-- Migration: Provably fair pulls
+-- A fair pull is rolled from HMAC-SHA256(server_seed, "client_seed:nonce:block").
+-- Players see the server seed's hash before they pull and the seed itself
+-- once they rotate to a new pair, so they can check every pull made with it.
+
+CREATE TABLE IF NOT EXISTS fair_seeds (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  server_seed VARCHAR(64) NOT NULL,           -- Hex, secret until revealed_at
+  server_seed_hash VARCHAR(64) NOT NULL,      -- Hex SHA-256 of server_seed
+  client_seed VARCHAR(64) NOT NULL,
+  nonce INTEGER NOT NULL DEFAULT 0 CHECK (nonce >= 0),   -- Nonce of the next pull
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  revealed_at TIMESTAMP
+);
+
+-- Each player has one active seed pair at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_fair_seeds_active ON fair_seeds(user_id) WHERE revealed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_fair_seeds_revealed ON fair_seeds(user_id, revealed_at DESC) WHERE revealed_at IS NOT NULL;
+
+COMMENT ON TABLE fair_seeds IS 'Committed seed pairs behind provably fair pulls';
+COMMENT ON COLUMN fair_seeds.server_seed IS 'Never shown to the player before revealed_at';
@@ -0,0 +1,24 @@
+-- Migration: Gacha pull history
+-- One row per card pulled, with the odds it was pulled at, so players can
+-- page through their pulls and anyone can compare how often each rarity
+-- drops with the odds the pulls were made at.
+
+CREATE TABLE IF NOT EXISTS gacha_pulls (
+  id UUID PRIMARY KEY,
+  roll_id UUID NOT NULL,                       -- Shared by the cards of one roll
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  pokemon_id UUID NOT NULL,                    -- Not a foreign key so history outlives released Pokemon
+  banner_id VARCHAR(50) NOT NULL DEFAULT '',   -- Empty for the standard pool
+  roll_type VARCHAR(20) NOT NULL CHECK (roll_type IN ('daily', 'premium')),
+  rarity VARCHAR(20) NOT NULL CHECK (rarity IN ('common', 'uncommon', 'rare', 'epic', 'legendary', 'mythic')),
+  species_id INTEGER NOT NULL REFERENCES pokemon_species(id),
+  pity JSONB NOT NULL DEFAULT '{}',            -- Pity counters before the pull
+  odds JSONB NOT NULL,                         -- Chance per rarity after pity and guarantees
+  nonce INTEGER,                               -- Nonce of a provably fair pull
+  created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_gacha_pulls_user ON gacha_pulls(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_gacha_pulls_banner ON gacha_pulls(banner_id, roll_type, created_at);
+
+COMMENT ON TABLE gacha_pulls IS 'Every card pulled from the gacha and the odds it was pulled at';
@@ -0,0 +1,17 @@
+-- Migration: Daily check-in streaks
+-- One row per player with their run of daily check-ins. Check-in days start
+-- at midnight in the configured timezone (STREAK_TIMEZONE), and missing a
+-- single day keeps the streak going. The rewards live in
+-- domain.StreakRewardFor.
+
+CREATE TABLE IF NOT EXISTS login_streaks (
+  user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  current_streak INTEGER NOT NULL DEFAULT 0 CHECK (current_streak >= 0),
+  longest_streak INTEGER NOT NULL DEFAULT 0 CHECK (longest_streak >= current_streak),
+  total_check_ins INTEGER NOT NULL DEFAULT 0 CHECK (total_check_ins >= 0),
+  last_check_in TIMESTAMPTZ,                   -- NULL until the first check-in
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE login_streaks IS 'Daily check-in streaks, one row per player';
+COMMENT ON COLUMN login_streaks.current_streak IS 'Streak as of the last check-in; broken streaks are reset on the next check-in';
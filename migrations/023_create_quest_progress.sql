@@ -0,0 +1,23 @@
+-- Migration: Quests and achievements
+-- One row per player, quest and period with the progress counted from game
+-- events such as pulls, battles won and sales. Daily and weekly quests
+-- rotate, so their progress is kept per day or week; achievements never
+-- rotate and use an empty period. The quests themselves live in
+-- domain.DailyQuests, domain.WeeklyQuests and domain.Achievements.
+
+CREATE TABLE IF NOT EXISTS quest_progress (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  quest_id VARCHAR(50) NOT NULL,
+  period_key VARCHAR(20) NOT NULL DEFAULT '',  -- Day (2026-10-18) or week (2026-W42); empty for achievements
+  progress INTEGER NOT NULL DEFAULT 0 CHECK (progress >= 0),
+  completed_at TIMESTAMP,                      -- Set when progress reaches the goal
+  claimed_at TIMESTAMP,                        -- Set once the reward is claimed
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (user_id, quest_id, period_key),
+  CHECK (claimed_at IS NULL OR completed_at IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_quest_progress_period ON quest_progress(user_id, period_key);
+
+COMMENT ON TABLE quest_progress IS 'Players'' progress on quests and achievements, per rotation period';
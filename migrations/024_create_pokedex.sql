@@ -0,0 +1,40 @@
+-- Migration: Pokedex
+-- One row per player and species they have seen or owned. Owning a species
+-- is recorded when it is first obtained, by pull, trade, purchase, auction
+-- or evolution, and stays recorded after the Pokemon is traded away or
+-- released. Milestone rewards for species owned are recorded once each so
+-- they are never paid twice.
+
+CREATE TABLE IF NOT EXISTS pokedex_entries (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  species_id INTEGER NOT NULL REFERENCES pokemon_species(id),
+  seen_at TIMESTAMP NOT NULL DEFAULT NOW(),   -- First seen, in a wild battle or by owning it
+  owned_at TIMESTAMP,                         -- First owned; NULL if only seen
+
+  PRIMARY KEY (user_id, species_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pokedex_entries_owned ON pokedex_entries(user_id) WHERE owned_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS pokedex_milestones (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  owned INTEGER NOT NULL,                     -- Species owned to reach the milestone
+  granted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (user_id, owned)
+);
+
+-- Species players own now or pulled before count as owned. Milestones they
+-- already reached are paid the next time they obtain a Pokemon.
+INSERT INTO pokedex_entries (user_id, species_id, seen_at, owned_at)
+SELECT user_id, species_id, MIN(acquired_at), MIN(acquired_at)
+FROM (
+  SELECT user_id, species_id, acquired_at FROM user_pokemon
+  UNION ALL
+  SELECT user_id, species_id, created_at FROM gacha_pulls
+) owned
+GROUP BY user_id, species_id
+ON CONFLICT (user_id, species_id) DO NOTHING;
+
+COMMENT ON TABLE pokedex_entries IS 'Species each player has seen or ever owned';
+COMMENT ON TABLE pokedex_milestones IS 'Pokedex milestone rewards paid to each player';
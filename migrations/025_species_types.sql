@@ -0,0 +1,48 @@
+-- Migration: Species types
+-- Types were added to pokemon_species after the species were seeded, so
+-- every seeded species was left with the default 'normal' type. The
+-- species catalog filters by type and shows each species' type matchups,
+-- so set the real types (Gen 6+, with Fairy).
+
+UPDATE pokemon_species ps
+SET type1 = t.type1, type2 = t.type2
+FROM (VALUES
+  -- Mythic
+  (150, 'psychic', NULL), (151, 'psychic', NULL), (249, 'psychic', 'flying'),
+  (250, 'fire', 'flying'), (384, 'dragon', 'flying'),
+  -- Legendary
+  (144, 'ice', 'flying'), (145, 'electric', 'flying'), (146, 'fire', 'flying'),
+  (243, 'electric', NULL), (244, 'fire', NULL), (245, 'water', NULL),
+  (377, 'rock', NULL), (378, 'ice', NULL), (379, 'steel', NULL),
+  (380, 'dragon', 'psychic'),
+  -- Epic
+  (3, 'grass', 'poison'), (6, 'fire', 'flying'), (9, 'water', NULL),
+  (94, 'ghost', 'poison'), (131, 'water', 'ice'), (143, 'normal', NULL),
+  (149, 'dragon', 'flying'), (248, 'rock', 'dark'), (282, 'psychic', 'fairy'),
+  (376, 'steel', 'psychic'),
+  -- Rare
+  (2, 'grass', 'poison'), (5, 'fire', NULL), (8, 'water', NULL),
+  (26, 'electric', NULL), (34, 'poison', 'ground'), (59, 'fire', NULL),
+  (65, 'psychic', NULL), (68, 'fighting', NULL), (76, 'rock', 'ground'),
+  (91, 'water', 'ice'), (103, 'grass', 'psychic'), (112, 'ground', 'rock'),
+  (130, 'water', 'flying'), (142, 'rock', 'flying'), (148, 'dragon', NULL),
+  -- Uncommon
+  (1, 'grass', 'poison'), (4, 'fire', NULL), (7, 'water', NULL),
+  (25, 'electric', NULL), (39, 'normal', 'fairy'), (54, 'water', NULL),
+  (58, 'fire', NULL), (63, 'psychic', NULL), (66, 'fighting', NULL),
+  (74, 'rock', 'ground'), (92, 'ghost', 'poison'), (95, 'rock', 'ground'),
+  (104, 'ground', NULL), (111, 'ground', 'rock'), (133, 'normal', NULL),
+  (147, 'dragon', NULL), (152, 'grass', NULL), (155, 'fire', NULL),
+  (158, 'water', NULL), (172, 'electric', NULL),
+  -- Common
+  (10, 'bug', NULL), (13, 'bug', 'poison'), (16, 'normal', 'flying'),
+  (19, 'normal', NULL), (21, 'normal', 'flying'), (27, 'ground', NULL),
+  (29, 'poison', NULL), (32, 'poison', NULL), (41, 'poison', 'flying'),
+  (43, 'grass', 'poison'), (48, 'bug', 'poison'), (50, 'ground', NULL),
+  (52, 'normal', NULL), (60, 'water', NULL), (69, 'grass', 'poison'),
+  (72, 'water', 'poison'), (77, 'fire', NULL), (81, 'electric', 'steel'),
+  (84, 'normal', 'flying'), (96, 'psychic', NULL), (98, 'water', NULL),
+  (100, 'electric', NULL), (109, 'poison', NULL), (118, 'water', NULL),
+  (120, 'water', NULL), (129, 'water', NULL)
+) AS t(id, type1, type2)
+WHERE ps.id = t.id;
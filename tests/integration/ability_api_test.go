@@ -0,0 +1,76 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestAbilityAPI_ChangeAbility(t *testing.T) {
+	ctx := context.Background()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+
+	user := mocks.CreateTestUser("capsule-user")
+	species := mocks.CreateTestSpecies(143, "Snorlax", domain.Epic)
+	species.Abilities = []string{"immunity", domain.AbilityThickFat}
+	species.HiddenAbility = "gluttony"
+	pokemon := domain.NewUserPokemon(user.ID, species)
+	pokemon.AbilitySlot = domain.AbilitySlot1
+	pokemonRepo.Create(ctx, pokemon)
+
+	abilityService := service.NewAbilityService(pokemonRepo, itemRepo, auditRepo, mocks.NewMockMarketListingRepository(),
+		mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(pokemonRepo, itemRepo, auditRepo))
+	abilities := handler.NewAbilityHandler(abilityService, service.NewValuationService(mocks.NewMockMarketTransactionRepository()))
+	path := "/api/pokemon/" + pokemon.ID.String() + "/ability"
+
+	rr, response := doJSONRequest(abilities.ChangeAbility, http.MethodPost, path, map[string]interface{}{
+		"user_id": user.ID.String(),
+		"slot":    2,
+	})
+	if rr.Code != http.StatusPaymentRequired {
+		t.Fatalf("Expected status 402 without a capsule, got %d", rr.Code)
+	}
+	if code := response["error"].(map[string]interface{})["code"]; code != handler.ErrCodeInsufficientItems {
+		t.Errorf("Expected %s, got %v", handler.ErrCodeInsufficientItems, code)
+	}
+
+	itemRepo.Adjust(ctx, user.ID, domain.AbilityCapsule, 1)
+	rr, response = doJSONRequest(abilities.ChangeAbility, http.MethodPost, path, map[string]interface{}{
+		"user_id": user.ID.String(),
+		"slot":    2,
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	data := response["data"].(map[string]interface{})
+	changed := data["pokemon"].(map[string]interface{})
+	if changed["ability"] != domain.AbilityThickFat || changed["ability_slot"].(float64) != 2 || changed["hidden_ability"] != false {
+		t.Errorf("Expected Thick Fat in slot 2, got %v in slot %v", changed["ability"], changed["ability_slot"])
+	}
+	if data["previous_ability"] != "immunity" {
+		t.Errorf("Expected the previous ability, got %v", data["previous_ability"])
+	}
+
+	rr, _ = doJSONRequest(abilities.ChangeAbility, http.MethodPost, path, map[string]interface{}{
+		"user_id": user.ID.String(),
+		"slot":    3,
+	})
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for the hidden slot, got %d", rr.Code)
+	}
+
+	rr, _ = doJSONRequest(abilities.ChangeAbility, http.MethodPost, path, map[string]interface{}{
+		"user_id": mocks.CreateTestUser("other").ID.String(),
+		"slot":    1,
+	})
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for another user's Pokemon, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,136 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+func doJSONRequest(h http.HandlerFunc, method, path string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	h(rr, req)
+
+	var response map[string]interface{}
+	json.Unmarshal(rr.Body.Bytes(), &response)
+	return rr, response
+}
+
+func TestAuctionAPI_BidSettleAndNotify(t *testing.T) {
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	auctionRepo := mocks.NewMockAuctionRepository(listingRepo)
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo)
+
+	auctionService := service.NewAuctionService(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+	auctions := handler.NewAuctionHandler(auctionService)
+	notifications := handler.NewNotificationHandler(notificationRepo)
+
+	seller := mocks.CreateTestUser("seller")
+	bidder := mocks.CreateTestUser("bidder")
+	userRepo.Create(ctx, seller)
+	userRepo.Create(ctx, bidder)
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// Start
+	rr, response := doJSONRequest(auctions.Auctions, http.MethodPost, "/api/market/auctions", map[string]interface{}{
+		"user_id":          seller.ID.String(),
+		"pokemon_id":       pokemon.ID.String(),
+		"starting_price":   100,
+		"min_increment":    10,
+		"duration_minutes": 60,
+	})
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	listingID := response["data"].(map[string]interface{})["listing"].(map[string]interface{})["id"].(string)
+
+	// Bid below the starting price
+	rr, _ = doJSONRequest(auctions.Auctions, http.MethodPost, "/api/market/auctions/"+listingID+"/bids", map[string]interface{}{
+		"user_id": bidder.ID.String(),
+		"amount":  50,
+	})
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a low bid, got %d", rr.Code)
+	}
+
+	// Valid bid
+	rr, response = doJSONRequest(auctions.Auctions, http.MethodPost, "/api/market/auctions/"+listingID+"/bids", map[string]interface{}{
+		"user_id": bidder.ID.String(),
+		"amount":  150,
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	if next := response["data"].(map[string]interface{})["minimum_bid"]; next != float64(160) {
+		t.Errorf("Expected next minimum bid 160, got %v", next)
+	}
+
+	// Detail includes the bid history
+	rr, response = doJSONRequest(auctions.Auctions, http.MethodGet, "/api/market/auctions/"+listingID, nil)
+	if bids := response["data"].(map[string]interface{})["bids"].([]interface{}); len(bids) != 1 {
+		t.Errorf("Expected 1 bid in history, got %d", len(bids))
+	}
+
+	// Seller can't cancel once there are bids
+	rr, _ = doJSONRequest(auctions.Auctions, http.MethodPost, "/api/market/auctions/"+listingID+"/cancel", map[string]string{
+		"user_id": seller.ID.String(),
+	})
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected status 409, got %d", rr.Code)
+	}
+
+	// End and settle
+	auctionRepo.Auctions[uuid.MustParse(listingID)].EndsAt = time.Now().Add(-time.Second)
+	if _, err := auctionService.SettleEndedAuctions(ctx); err != nil {
+		t.Fatalf("Expected no error settling, got %v", err)
+	}
+
+	// The bot picks up one notification for each side
+	rr, response = doJSONRequest(notifications.GetPending, http.MethodGet, "/api/notifications/pending", nil)
+	pending := response["data"].(map[string]interface{})["notifications"].([]interface{})
+	if rr.Code != http.StatusOK || len(pending) != 2 {
+		t.Fatalf("Expected 2 pending notifications, got %d (status %d)", len(pending), rr.Code)
+	}
+
+	discordIDs := map[string]bool{}
+	for _, p := range pending {
+		n := p.(map[string]interface{})
+		discordIDs[n["discord_id"].(string)] = true
+
+		rr, _ = doJSONRequest(notifications.MarkDelivered, http.MethodPost, "/api/notifications/"+n["id"].(string)+"/delivered", nil)
+		if rr.Code != http.StatusOK {
+			t.Errorf("Expected status 200 marking delivered, got %d", rr.Code)
+		}
+	}
+	if !discordIDs["seller"] || !discordIDs["bidder"] {
+		t.Errorf("Expected notifications for the seller and the winner, got %v", discordIDs)
+	}
+
+	_, response = doJSONRequest(notifications.GetPending, http.MethodGet, "/api/notifications/pending", nil)
+	if count := response["data"].(map[string]interface{})["count"]; count != float64(0) {
+		t.Errorf("Expected no pending notifications after delivery, got %v", count)
+	}
+}
@@ -0,0 +1,104 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestBannerAPI_ListAndRoll(t *testing.T) {
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	bannerRepo := mocks.NewMockBannerRepository()
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(),
+		bannerRepo, mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+	gachaHandler := handler.NewGachaHandler(gachaService, service.NewValuationService(mocks.NewMockMarketTransactionRepository()))
+
+	mocks.SeedAllRarities(speciesRepo)
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(144, "Articuno", domain.Legendary))
+
+	user := mocks.CreateTestUser("banner-api")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+
+	endsAt := time.Now().Add(24 * time.Hour)
+	bannerRepo.Create(ctx, &domain.Banner{
+		ID:          "articuno",
+		Name:        "Articuno Rate-Up",
+		StartsAt:    time.Now().Add(-time.Hour),
+		EndsAt:      &endsAt,
+		SpeciesPool: []int{144},
+		Featured:    []*domain.FeaturedSpecies{{SpeciesID: 144, Name: "Articuno", Weight: 5}},
+	})
+	ended := time.Now().Add(-time.Hour)
+	bannerRepo.Create(ctx, &domain.Banner{
+		ID:       "over",
+		Name:     "Over",
+		StartsAt: ended.Add(-time.Hour),
+		EndsAt:   &ended,
+	})
+
+	// Only the running banner is listed, with its effective rates
+	rr, response := doJSONRequest(gachaHandler.GetBanners, http.MethodGet, "/api/banners", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	data := response["data"].(map[string]interface{})
+	banners := data["banners"].([]interface{})
+	if len(banners) != 1 {
+		t.Fatalf("Expected 1 active banner, got %d", len(banners))
+	}
+	banner := banners[0].(map[string]interface{})
+	if banner["id"] != "articuno" {
+		t.Errorf("Expected the articuno banner, got %v", banner["id"])
+	}
+	rates := banner["rates"].(map[string]interface{})
+	if rates["legendary"] != domain.Legendary.DropRate() {
+		t.Errorf("Expected the standard legendary rate, got %v", rates["legendary"])
+	}
+
+	// Rolling on the banner pulls from its pool
+	rr, response = doJSONRequest(gachaHandler.PremiumRoll, http.MethodPost, "/api/gacha/premium-roll", map[string]interface{}{
+		"user_id":   user.ID.String(),
+		"count":     2,
+		"banner_id": "articuno",
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	pokemons := response["data"].(map[string]interface{})["pokemons"].([]interface{})
+	for _, p := range pokemons {
+		species := p.(map[string]interface{})["species"].(map[string]interface{})
+		if species["name"] != "Articuno" {
+			t.Errorf("Expected Articuno, got %v", species["name"])
+		}
+	}
+
+	// Ended and unknown banners are rejected
+	rr, _ = doJSONRequest(gachaHandler.PremiumRoll, http.MethodPost, "/api/gacha/premium-roll", map[string]interface{}{
+		"user_id":   user.ID.String(),
+		"count":     1,
+		"banner_id": "over",
+	})
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an ended banner, got %d", rr.Code)
+	}
+
+	rr, _ = doJSONRequest(gachaHandler.PremiumRoll, http.MethodPost, "/api/gacha/premium-roll", map[string]interface{}{
+		"user_id":   user.ID.String(),
+		"count":     1,
+		"banner_id": "missing",
+	})
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown banner, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,61 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestBattleAPI_WildBattleAwardsExperience(t *testing.T) {
+	ctx := context.Background()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	mocks.SeedAllRarities(speciesRepo)
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+
+	user := mocks.CreateTestUser("trainer")
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(1, "TestMon", domain.Common))
+	pokemonRepo.Create(ctx, pokemon)
+	start := pokemon.Experience
+
+	wildBattleService := service.NewWildBattleService(speciesRepo, pokemonRepo, mocks.NewMockMarketListingRepository(),
+		mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(pokemonRepo))
+	battles := handler.NewBattleHandler(wildBattleService, service.NewValuationService(mocks.NewMockMarketTransactionRepository()))
+	path := "/api/pokemon/" + pokemon.ID.String() + "/wild-battle"
+
+	rr, response := doJSONRequest(battles.WildBattle, http.MethodPost, path, map[string]interface{}{
+		"user_id": user.ID.String(),
+		"format":  domain.FormatRanked,
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	data := response["data"].(map[string]interface{})
+	if data["wild_level"].(float64) != 50 {
+		t.Errorf("Expected a level 50 wild Pokemon in ranked, got %v", data["wild_level"])
+	}
+	gained := int(data["experience"].(map[string]interface{})["experience"].(float64))
+	if got := int(data["pokemon"].(map[string]interface{})["experience"].(float64)); got != start+gained {
+		t.Errorf("Expected the Pokemon to show %d experience, got %d", start+gained, got)
+	}
+
+	rr, _ = doJSONRequest(battles.WildBattle, http.MethodPost, path, map[string]interface{}{
+		"user_id": user.ID.String(),
+		"format":  "no_such_format",
+	})
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown format, got %d", rr.Code)
+	}
+
+	rr, response = doJSONRequest(battles.GetFormats, http.MethodGet, "/api/battles/formats", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rr.Code)
+	}
+	if count := response["data"].(map[string]interface{})["count"].(float64); int(count) != len(domain.BattleFormats) {
+		t.Errorf("Expected %d formats, got %v", len(domain.BattleFormats), count)
+	}
+}
@@ -0,0 +1,128 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestBoxAPI_FiltersSortsAndPages(t *testing.T) {
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+
+	gachaService := service.NewGachaService(userRepo, mocks.NewMockPokemonSpeciesRepository(), pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+	pokemon := handler.NewPokemonHandler(gachaService, service.NewValuationService(mocks.NewMockMarketTransactionRepository()))
+
+	user := mocks.CreateTestUser("collector")
+	userRepo.Create(ctx, user)
+
+	pikachu := mocks.CreateTestSpecies(25, "Pikachu", domain.Rare)
+	eevee := mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon)
+	for i := 0; i < 12; i++ {
+		species := eevee
+		if i%3 == 0 {
+			species = pikachu
+		}
+		p := domain.NewUserPokemon(user.ID, species)
+		p.IVs = domain.IVs{HP: i, Attack: i, Defense: i, SpAttack: i, SpDefense: i, Speed: i}
+		p.IsShiny = i == 6
+		p.Nickname = ""
+		if i == 9 {
+			p.Nickname = "Bolt"
+		}
+		p.AcquiredAt = time.Now().Add(-time.Duration(i) * time.Minute)
+		pokemonRepo.Create(ctx, p)
+	}
+
+	box := func(query url.Values) (int, map[string]interface{}) {
+		rr, response := doJSONRequest(pokemon.GetUserPokemon, http.MethodGet, "/api/users/"+user.ID.String()+"/pokemon?"+query.Encode(), nil)
+		if rr.Code != http.StatusOK {
+			return rr.Code, nil
+		}
+		return rr.Code, response["data"].(map[string]interface{})
+	}
+
+	// Filters are applied across the whole collection
+	_, data := box(url.Values{"species": {"pikachu"}, "shiny": {"false"}})
+	if data["total"].(float64) != 3 || data["count"].(float64) != 3 {
+		t.Errorf("Expected 3 non-shiny Pikachu, got %v of %v", data["count"], data["total"])
+	}
+	_, data = box(url.Values{"nickname": {"bol"}})
+	if data["total"].(float64) != 1 {
+		t.Errorf("Expected one Pokemon nicknamed Bolt, got %v", data["total"])
+	}
+	_, data = box(url.Values{"rarity": {"uncommon"}, "min_iv": {"25"}})
+	if data["total"].(float64) != 3 {
+		t.Errorf("Expected 3 Eevee with at least 25%% IVs, got %v", data["total"])
+	}
+
+	// Paging by IVs, best first, walks the box once
+	query := url.Values{"sort": {"iv"}, "limit": {"5"}}
+	var ivs []float64
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatal("Expected paging to end after 3 pages")
+		}
+		code, data := box(query)
+		if code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", code)
+		}
+		if data["total"].(float64) != 12 {
+			t.Errorf("Expected a total of 12, got %v", data["total"])
+		}
+		for _, p := range data["pokemons"].([]interface{}) {
+			ivs = append(ivs, p.(map[string]interface{})["iv_percentage"].(float64))
+		}
+		next, _ := data["next_cursor"].(string)
+		if next == "" {
+			break
+		}
+		query.Set("cursor", next)
+	}
+	if len(ivs) != 12 {
+		t.Fatalf("Expected 12 Pokemon across pages, got %d", len(ivs))
+	}
+	for i := 1; i < len(ivs); i++ {
+		if ivs[i] > ivs[i-1] {
+			t.Errorf("Expected IVs to descend, got %v after %v", ivs[i], ivs[i-1])
+		}
+	}
+
+	// Ascending newest-first is oldest first
+	_, data = box(url.Values{"order": {"asc"}, "limit": {"1"}})
+	oldest := data["pokemons"].([]interface{})[0].(map[string]interface{})
+	oldestIVs := domain.IVs{HP: 11, Attack: 11, Defense: 11, SpAttack: 11, SpDefense: 11, Speed: 11}
+	if oldest["iv_percentage"].(float64) != oldestIVs.IVPercentage() {
+		t.Errorf("Expected the oldest Pokemon first, got %v", oldest["iv_percentage"])
+	}
+
+	// A cursor from one sort can't be used with another
+	_, data = box(url.Values{"sort": {"value"}, "limit": {"1"}})
+	cursor := data["next_cursor"].(string)
+
+	for _, bad := range []url.Values{
+		{"rarity": {"shiny"}},
+		{"type": {"cosmic"}},
+		{"nature": {"grumpy"}},
+		{"min_iv": {"150"}},
+		{"min_iv": {"lots"}},
+		{"favorite": {"maybe"}},
+		{"sort": {"height"}},
+		{"order": {"sideways"}},
+		{"limit": {"500"}},
+		{"cursor": {"garbage"}},
+		{"sort": {"stats"}, "cursor": {cursor}},
+	} {
+		if code, _ := box(bad); code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %v, got %d", bad.Encode(), code)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestCandyAPI_ReleaseThenRaiseIVs(t *testing.T) {
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, battleRepo, candyRepo, auditRepo)
+	valuationService := service.NewValuationService(mocks.NewMockMarketTransactionRepository())
+
+	releases := handler.NewReleaseHandler(service.NewReleaseService(userRepo, pokemonRepo, listingRepo, tradeRepo,
+		battleRepo, candyRepo, auditRepo, valuationService, txManager))
+	candy := handler.NewCandyHandler(service.NewCandyService(pokemonRepo, candyRepo, auditRepo, listingRepo,
+		tradeRepo, battleRepo, txManager), valuationService)
+
+	user := mocks.CreateTestUser("trainer")
+	userRepo.Create(ctx, user)
+	species := mocks.CreateTestSpecies(149, "Dragonite", domain.Legendary)
+	keep := domain.NewUserPokemon(user.ID, species)
+	keep.IVs = domain.IVs{HP: 10, Attack: 10, Defense: 10, SpAttack: 10, SpDefense: 10, Speed: 10}
+	duplicate := domain.NewUserPokemon(user.ID, species)
+	pokemonRepo.Create(ctx, keep)
+	pokemonRepo.Create(ctx, duplicate)
+
+	rr, _ := doJSONRequest(releases.Release, http.MethodPost, "/api/pokemon/release", map[string]interface{}{
+		"user_id":     user.ID.String(),
+		"pokemon_ids": []string{duplicate.ID.String()},
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+
+	rr, response := doJSONRequest(candy.GetCandy, http.MethodGet, "/api/users/"+user.ID.String()+"/candy", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	balances := response["data"].(map[string]interface{})["candy"].([]interface{})
+	if len(balances) != 1 || int(balances[0].(map[string]interface{})["amount"].(float64)) != domain.CandyForRelease(domain.Legendary) {
+		t.Fatalf("Expected %d Dragonite candy, got %v", domain.CandyForRelease(domain.Legendary), balances)
+	}
+
+	path := "/api/pokemon/" + keep.ID.String() + "/raise-ivs"
+	rr, response = doJSONRequest(candy.PokemonActions, http.MethodPost, path, map[string]interface{}{
+		"user_id": user.ID.String(),
+		"stats":   []string{"speed"},
+		"points":  1,
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	ivs := response["data"].(map[string]interface{})["ivs"].(map[string]interface{})
+	if ivs["speed"].(float64) != 11 {
+		t.Errorf("Expected speed IV 11, got %v", ivs["speed"])
+	}
+
+	// Raising past 31 is rejected
+	rr, _ = doJSONRequest(candy.PokemonActions, http.MethodPost, path, map[string]interface{}{
+		"user_id": user.ID.String(),
+		"stats":   []string{"speed"},
+		"points":  21,
+	})
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 raising past 31, got %d", rr.Code)
+	}
+
+	rr, response = doJSONRequest(candy.PokemonActions, http.MethodGet, "/api/pokemon/"+keep.ID.String()+"/history", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	if count := response["data"].(map[string]interface{})["count"].(float64); count != 1 {
+		t.Errorf("Expected one audit entry, got %v", count)
+	}
+}
+
+func TestCandyAPI_InsufficientCandy(t *testing.T) {
+	ctx := context.Background()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	txManager := mocks.NewMockTxManager(pokemonRepo, candyRepo, auditRepo, listingRepo)
+	candy := handler.NewCandyHandler(service.NewCandyService(pokemonRepo, candyRepo, auditRepo, listingRepo,
+		mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager),
+		service.NewValuationService(mocks.NewMockMarketTransactionRepository()))
+
+	user := mocks.CreateTestUser("trainer")
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+	pokemon.Nature = domain.Hardy
+	pokemonRepo.Create(ctx, pokemon)
+
+	rr, _ := doJSONRequest(candy.PokemonActions, http.MethodPost, "/api/pokemon/"+pokemon.ID.String()+"/mint", map[string]interface{}{
+		"user_id": user.ID.String(),
+		"nature":  "timid",
+	})
+	if rr.Code != http.StatusPaymentRequired {
+		t.Errorf("Expected status 402 without candy, got %d", rr.Code)
+	}
+
+	rr, _ = doJSONRequest(candy.PokemonActions, http.MethodPost, "/api/pokemon/"+pokemon.ID.String()+"/mint", map[string]interface{}{
+		"user_id": user.ID.String(),
+		"nature":  "sleepy",
+	})
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown nature, got %d", rr.Code)
+	}
+}
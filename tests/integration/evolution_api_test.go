@@ -0,0 +1,145 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+type evolutionAPI struct {
+	handler   *handler.EvolutionHandler
+	candyRepo *mocks.MockCandyRepository
+	user      *domain.User
+	pokemon   *domain.UserPokemon
+}
+
+// newEvolutionAPI gives a user a Pikachu that evolves into Raichu with a thunder stone
+func newEvolutionAPI(t *testing.T) *evolutionAPI {
+	t.Helper()
+	ctx := context.Background()
+
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	evolutionRepo := mocks.NewMockEvolutionRepository()
+	learnsetRepo := mocks.NewMockLearnsetRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, learnsetRepo, candyRepo, itemRepo, auditRepo)
+
+	raichu := mocks.CreateTestSpecies(26, "Raichu", domain.Rare)
+	evolutionRepo.Evolutions[25] = []*domain.Evolution{
+		{FromSpeciesID: 25, ToSpeciesID: 26, ToSpecies: raichu, CandyCost: 50, Item: "thunder-stone"},
+	}
+	learnsetRepo.Learnsets[26] = []*domain.Move{{ID: 85, Name: "Thunderbolt", Type: domain.Electric, Power: 90}}
+
+	user := mocks.CreateTestUser("trainer")
+	userRepo.Create(ctx, user)
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Uncommon))
+	pokemonRepo.Create(ctx, pokemon)
+	itemRepo.Adjust(ctx, user.ID, "thunder-stone", 1)
+
+	evolutionService := service.NewEvolutionService(userRepo, pokemonRepo, evolutionRepo, learnsetRepo, candyRepo, itemRepo,
+		auditRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	return &evolutionAPI{
+		handler:   handler.NewEvolutionHandler(evolutionService, service.NewValuationService(mocks.NewMockMarketTransactionRepository())),
+		candyRepo: candyRepo,
+		user:      user,
+		pokemon:   pokemon,
+	}
+}
+
+func TestEvolutionAPI_ListThenEvolve(t *testing.T) {
+	api := newEvolutionAPI(t)
+	api.candyRepo.Adjust(context.Background(), api.user.ID, 25, 50)
+	base := "/api/pokemon/" + api.pokemon.ID.String()
+
+	rr, response := doJSONRequest(api.handler.PokemonActions, http.MethodGet, base+"/evolutions?user_id="+api.user.ID.String(), nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	evolutions := response["data"].(map[string]interface{})["evolutions"].([]interface{})
+	if len(evolutions) != 1 {
+		t.Fatalf("Expected one evolution, got %d", len(evolutions))
+	}
+	option := evolutions[0].(map[string]interface{})
+	if option["can_evolve"] != true || option["item"] != "thunder-stone" {
+		t.Errorf("Expected an evolvable thunder stone evolution, got %v", option)
+	}
+
+	rr, response = doJSONRequest(api.handler.PokemonActions, http.MethodPost, base+"/evolve", map[string]interface{}{
+		"user_id": api.user.ID.String(),
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	data := response["data"].(map[string]interface{})
+	species := data["pokemon"].(map[string]interface{})["species"].(map[string]interface{})
+	if species["name"] != "Raichu" {
+		t.Errorf("Expected a Raichu, got %v", species["name"])
+	}
+	if from := data["from_species"].(map[string]interface{}); from["name"] != "Pikachu" {
+		t.Errorf("Expected to evolve from Pikachu, got %v", from["name"])
+	}
+	if moves := data["unlocked_moves"].([]interface{}); len(moves) != 1 {
+		t.Errorf("Expected Thunderbolt unlocked, got %v", moves)
+	}
+
+	rr, response = doJSONRequest(api.handler.PokemonActions, http.MethodGet, base+"/moves", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	if count := response["data"].(map[string]interface{})["count"].(float64); count != 1 {
+		t.Errorf("Expected one move, got %v", count)
+	}
+
+	rr, response = doJSONRequest(api.handler.GetItems, http.MethodGet, "/api/users/"+api.user.ID.String()+"/items", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	if count := response["data"].(map[string]interface{})["count"].(float64); count != 0 {
+		t.Errorf("Expected the thunder stone to be used, got %v items", count)
+	}
+}
+
+func TestEvolutionAPI_Errors(t *testing.T) {
+	api := newEvolutionAPI(t)
+	path := "/api/pokemon/" + api.pokemon.ID.String() + "/evolve"
+
+	rr, response := doJSONRequest(api.handler.PokemonActions, http.MethodPost, path, map[string]interface{}{
+		"user_id": api.user.ID.String(),
+	})
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("Expected status 409 without candy, got %d", rr.Code)
+	}
+	if code := response["error"].(map[string]interface{})["code"]; code != handler.ErrCodeRequirementsNotMet {
+		t.Errorf("Expected %s, got %v", handler.ErrCodeRequirementsNotMet, code)
+	}
+
+	rr, _ = doJSONRequest(api.handler.PokemonActions, http.MethodPost, path, map[string]interface{}{
+		"user_id":    api.user.ID.String(),
+		"species_id": 3,
+	})
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a species it cannot evolve into, got %d", rr.Code)
+	}
+
+	rr, _ = doJSONRequest(api.handler.PokemonActions, http.MethodPost, path, map[string]interface{}{
+		"user_id": uuid.New().String(),
+	})
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for another user's Pokemon, got %d", rr.Code)
+	}
+
+	rr, _ = doJSONRequest(api.handler.PokemonActions, http.MethodGet, "/api/pokemon/"+uuid.New().String()+"/moves", nil)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for an unknown Pokemon, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,105 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestFairnessAPI_RollRotateVerify(t *testing.T) {
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	seedRepo := mocks.NewMockFairSeedRepository()
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(),
+		mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), seedRepo, mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, seedRepo))
+	gachaHandler := handler.NewGachaHandler(gachaService, service.NewValuationService(mocks.NewMockMarketTransactionRepository()))
+
+	mocks.SeedAllRarities(speciesRepo)
+	user := mocks.CreateTestUser("fair-api")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+	seedPath := "/api/users/" + user.ID.String() + "/fair-seed"
+
+	// The commitment is published before any pull
+	rr, response := doJSONRequest(gachaHandler.FairSeedActions, http.MethodGet, seedPath, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	active := response["data"].(map[string]interface{})["active"].(map[string]interface{})
+	if _, leaked := active["server_seed"]; leaked {
+		t.Fatal("Expected the active server seed to stay hidden")
+	}
+	hash := active["server_seed_hash"].(string)
+
+	rr, response = doJSONRequest(gachaHandler.PremiumRoll, http.MethodPost, "/api/gacha/premium-roll", map[string]interface{}{
+		"user_id": user.ID.String(),
+		"count":   2,
+		"fair":    true,
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	data := response["data"].(map[string]interface{})
+	pokemons := data["pokemons"].([]interface{})
+	proofs, ok := data["proofs"].([]interface{})
+	if !ok || len(proofs) != 2 {
+		t.Fatalf("Expected a proof per pull, got %v", data["proofs"])
+	}
+	if proofs[1].(map[string]interface{})["server_seed_hash"] != hash {
+		t.Error("Expected the proofs to carry the committed hash")
+	}
+
+	rr, response = doJSONRequest(gachaHandler.FairSeedActions, http.MethodPost, seedPath+"/rotate", map[string]interface{}{})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	serverSeed := response["data"].(map[string]interface{})["revealed"].(map[string]interface{})["server_seed"].(string)
+
+	// Each pull is reproduced from its proof and the revealed seed
+	for i, proof := range proofs {
+		body := proof.(map[string]interface{})
+		body["server_seed"] = serverSeed
+
+		rr, response = doJSONRequest(gachaHandler.VerifyPull, http.MethodPost, "/api/gacha/verify", body)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+		verified := response["data"].(map[string]interface{})
+		if verified["server_seed_hash"] != hash {
+			t.Errorf("Expected the revealed seed to hash to %s, got %v", hash, verified["server_seed_hash"])
+		}
+
+		want := pokemons[i].(map[string]interface{})
+		got := verified["pokemon"].(map[string]interface{})
+		for _, field := range []string{"species", "ivs", "nature", "is_shiny", "gender"} {
+			if !reflect.DeepEqual(got[field], want[field]) {
+				t.Errorf("Expected pull %d's %s to be %v, got %v", i+1, field, want[field], got[field])
+			}
+		}
+	}
+
+	// The revealed seed is listed from then on
+	rr, response = doJSONRequest(gachaHandler.FairSeedActions, http.MethodGet, seedPath, nil)
+	revealed := response["data"].(map[string]interface{})["revealed"].([]interface{})
+	if rr.Code != http.StatusOK || len(revealed) != 1 {
+		t.Fatalf("Expected 1 revealed seed, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr, _ = doJSONRequest(gachaHandler.VerifyPull, http.MethodPost, "/api/gacha/verify", map[string]interface{}{
+		"server_seed":      "wrong",
+		"server_seed_hash": hash,
+		"client_seed":      "abc",
+		"nonce":            0,
+	})
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a seed that doesn't match its hash, got %d", rr.Code)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/danielyang21/GoBattleServer/internal/domain"
 	"github.com/danielyang21/GoBattleServer/internal/handler"
 	"github.com/danielyang21/GoBattleServer/internal/service"
 	"github.com/danielyang21/GoBattleServer/tests/mocks"
@@ -20,8 +21,8 @@ func setupGachaHandler() (*handler.GachaHandler, *mocks.MockUserRepository, *moc
 	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
 	pokemonRepo := mocks.NewMockUserPokemonRepository()
 
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
-	gachaHandler := handler.NewGachaHandler(gachaService)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+	gachaHandler := handler.NewGachaHandler(gachaService, service.NewValuationService(mocks.NewMockMarketTransactionRepository()))
 
 	return gachaHandler, userRepo, speciesRepo, pokemonRepo
 }
@@ -105,11 +106,8 @@ func TestDailyRollAPI_AlreadyRolledToday(t *testing.T) {
 	handler.DailyRoll(rr, req)
 
 	// Assert
-	// Note: Handler currently returns 500 instead of 429 because error message
-	// says "already claimed" not "already rolled". This is a handler bug.
-	// For now, we test the actual behavior.
-	if rr.Code != http.StatusInternalServerError {
-		t.Errorf("Expected status 500, got %d. Body: %s", rr.Code, rr.Body.String())
+	if rr.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status 429, got %d. Body: %s", rr.Code, rr.Body.String())
 	}
 
 	// Verify it's an error response
@@ -118,6 +116,100 @@ func TestDailyRollAPI_AlreadyRolledToday(t *testing.T) {
 	if success, _ := response["success"].(bool); success {
 		t.Errorf("Expected success=false for already rolled error")
 	}
+	if code := response["error"].(map[string]interface{})["code"]; code != "cooldown_active" {
+		t.Errorf("Expected cooldown_active, got %v", code)
+	}
+}
+
+func TestDailyRollAPI_SecondRollWithoutKeyIsRateLimited(t *testing.T) {
+	// Setup
+	handler, userRepo, speciesRepo, pokemonRepo := setupGachaHandler()
+
+	// Create test user
+	user := mocks.CreateTestUser("discord123")
+	userRepo.Create(context.Background(), user)
+
+	// Seed all rarities
+	mocks.SeedAllRarities(speciesRepo)
+
+	jsonBody, _ := json.Marshal(map[string]string{
+		"user_id": user.ID.String(),
+	})
+
+	// Execute - the second request loses the daily claim to the first
+	codes := make([]int, 2)
+	for attempt := range codes {
+		req := httptest.NewRequest(http.MethodPost, "/api/gacha/daily-roll", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		handler.DailyRoll(rr, req)
+		codes[attempt] = rr.Code
+	}
+
+	// Assert
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests {
+		t.Errorf("Expected statuses 200 then 429, got %d then %d", codes[0], codes[1])
+	}
+	if len(pokemonRepo.Pokemons) != 5 {
+		t.Errorf("Expected only the first roll's 5 Pokemon saved, got %d", len(pokemonRepo.Pokemons))
+	}
+}
+
+func TestDailyRollAPI_IdempotentRetry(t *testing.T) {
+	// Setup
+	handler, userRepo, speciesRepo, pokemonRepo := setupGachaHandler()
+
+	// Create test user
+	user := mocks.CreateTestUser("discord123")
+	userRepo.Create(context.Background(), user)
+
+	// Seed all rarities
+	mocks.SeedAllRarities(speciesRepo)
+
+	jsonBody, _ := json.Marshal(map[string]string{
+		"user_id": user.ID.String(),
+	})
+
+	// Execute - the same request is sent twice with one key
+	var ids [2][]string
+	for attempt := 0; attempt < 2; attempt++ {
+		req := httptest.NewRequest(http.MethodPost, "/api/gacha/daily-roll", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "interaction-123")
+
+		rr := httptest.NewRecorder()
+		handler.DailyRoll(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Attempt %d: expected status 200, got %d. Body: %s", attempt+1, rr.Code, rr.Body.String())
+		}
+
+		var response struct {
+			Data struct {
+				Pokemons []struct {
+					ID string `json:"id"`
+				} `json:"pokemons"`
+			} `json:"data"`
+		}
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		for _, p := range response.Data.Pokemons {
+			ids[attempt] = append(ids[attempt], p.ID)
+		}
+	}
+
+	// Assert - both responses describe the same pull
+	if len(ids[0]) != 5 || len(ids[1]) != 5 {
+		t.Fatalf("Expected 5 Pokemon in both responses, got %d and %d", len(ids[0]), len(ids[1]))
+	}
+	for i := range ids[0] {
+		if ids[0][i] != ids[1][i] {
+			t.Errorf("Pokemon %d: expected %s on retry, got %s", i, ids[0][i], ids[1][i])
+		}
+	}
+	if len(pokemonRepo.Pokemons) != 5 {
+		t.Errorf("Expected 5 Pokemon saved, got %d", len(pokemonRepo.Pokemons))
+	}
 }
 
 func TestDailyRollAPI_InvalidUserID(t *testing.T) {
@@ -388,3 +480,60 @@ func TestPremiumRollAPI_TenRollBonus(t *testing.T) {
 		t.Errorf("Expected 10 Pokemon in response")
 	}
 }
+
+func TestPremiumRollAPI_ShowsShinyGenderAndForm(t *testing.T) {
+	handler, userRepo, speciesRepo, _ := setupGachaHandler()
+
+	previous := domain.ShinyRate()
+	domain.SetShinyRate(1)
+	defer domain.SetShinyRate(previous)
+
+	user := mocks.CreateTestUser("discord123")
+	user.Coins = 1000
+	userRepo.Create(context.Background(), user)
+
+	mocks.SeedAllRarities(speciesRepo)
+	for _, s := range speciesRepo.Species {
+		s.ShinySpriteURL = "https://example.com/shiny.png"
+		s.Forms = []*domain.PokemonForm{{
+			ID: s.ID, SpeciesID: s.ID, Name: "alola", Type1: domain.Ice,
+			BaseHP: 50, BaseAttack: 50, BaseDefense: 50, BaseSpAttack: 50, BaseSpDefense: 50, BaseSpeed: 50,
+			SpriteURL: "https://example.com/alola.png", ShinySpriteURL: "https://example.com/alola-shiny.png",
+			RollChance: 1,
+		}}
+	}
+
+	jsonBody, _ := json.Marshal(map[string]interface{}{"user_id": user.ID.String(), "count": 1})
+	req := httptest.NewRequest(http.MethodPost, "/api/gacha/premium-roll", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+	handler.PremiumRoll(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+
+	var response struct {
+		Data struct {
+			Pokemons []map[string]interface{} `json:"pokemons"`
+		} `json:"data"`
+	}
+	json.Unmarshal(rr.Body.Bytes(), &response)
+	if len(response.Data.Pokemons) != 1 {
+		t.Fatalf("Expected 1 Pokemon, got %d", len(response.Data.Pokemons))
+	}
+
+	p := response.Data.Pokemons[0]
+	if p["is_shiny"] != true {
+		t.Errorf("Expected is_shiny true, got %v", p["is_shiny"])
+	}
+	if p["gender"] != "male" && p["gender"] != "female" {
+		t.Errorf("Expected a gender, got %v", p["gender"])
+	}
+	if p["sprite_url"] != "https://example.com/alola-shiny.png" {
+		t.Errorf("Expected the shiny Alolan sprite, got %v", p["sprite_url"])
+	}
+	form, ok := p["form"].(map[string]interface{})
+	if !ok || form["name"] != "alola" {
+		t.Errorf("Expected the Alolan form, got %v", p["form"])
+	}
+}
@@ -0,0 +1,135 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func setupMarketHandler() (*handler.MarketHandler, *mocks.MockUserRepository, *mocks.MockUserPokemonRepository) {
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo,
+		mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(),
+		mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, marketTxRepo))
+
+	return handler.NewMarketHandler(marketService), userRepo, pokemonRepo
+}
+
+func doMarketRequest(h *handler.MarketHandler, method, path string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+	return doJSONRequest(h.Listings, method, path, body)
+}
+
+func TestMarketAPI_ListSearchAndBuy(t *testing.T) {
+	h, userRepo, pokemonRepo := setupMarketHandler()
+	ctx := context.Background()
+
+	seller := mocks.CreateTestUser("seller")
+	buyer := mocks.CreateTestUser("buyer")
+	userRepo.Create(ctx, seller)
+	userRepo.Create(ctx, buyer)
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// List
+	rr, response := doMarketRequest(h, http.MethodPost, "/api/market/listings", map[string]interface{}{
+		"user_id":    seller.ID.String(),
+		"pokemon_id": pokemon.ID.String(),
+		"price":      200,
+	})
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	listingID := response["data"].(map[string]interface{})["id"].(string)
+
+	// Search
+	rr, response = doMarketRequest(h, http.MethodGet, "/api/market/listings?rarity=rare&species=pikachu", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	if count := response["data"].(map[string]interface{})["count"]; count != float64(1) {
+		t.Errorf("Expected 1 listing, got %v", count)
+	}
+
+	// Buy
+	rr, response = doMarketRequest(h, http.MethodPost, "/api/market/listings/"+listingID+"/buy", map[string]string{
+		"user_id": buyer.ID.String(),
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	if fee := response["data"].(map[string]interface{})["fee"]; fee != float64(10) {
+		t.Errorf("Expected fee 10, got %v", fee)
+	}
+
+	// Second buy conflicts
+	rr, _ = doMarketRequest(h, http.MethodPost, "/api/market/listings/"+listingID+"/buy", map[string]string{
+		"user_id": buyer.ID.String(),
+	})
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected status 409, got %d", rr.Code)
+	}
+}
+
+func TestMarketAPI_BuyInsufficientCoins(t *testing.T) {
+	h, userRepo, pokemonRepo := setupMarketHandler()
+	ctx := context.Background()
+
+	seller := mocks.CreateTestUser("seller")
+	buyer := mocks.CreateTestUser("buyer")
+	buyer.Coins = 5
+	userRepo.Create(ctx, seller)
+	userRepo.Create(ctx, buyer)
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	_, response := doMarketRequest(h, http.MethodPost, "/api/market/listings", map[string]interface{}{
+		"user_id":    seller.ID.String(),
+		"pokemon_id": pokemon.ID.String(),
+		"price":      200,
+	})
+	listingID := response["data"].(map[string]interface{})["id"].(string)
+
+	rr, _ := doMarketRequest(h, http.MethodPost, "/api/market/listings/"+listingID+"/buy", map[string]string{
+		"user_id": buyer.ID.String(),
+	})
+	if rr.Code != http.StatusPaymentRequired {
+		t.Errorf("Expected status 402, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestMarketAPI_InvalidRequests(t *testing.T) {
+	h, _, _ := setupMarketHandler()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   int
+	}{
+		{"invalid rarity", http.MethodGet, "/api/market/listings?rarity=shiny", http.StatusBadRequest},
+		{"invalid min price", http.MethodGet, "/api/market/listings?min_price=abc", http.StatusBadRequest},
+		{"invalid listing ID", http.MethodGet, "/api/market/listings/not-a-uuid", http.StatusBadRequest},
+		{"unknown listing", http.MethodGet, "/api/market/listings/00000000-0000-0000-0000-000000000000", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr, _ := doMarketRequest(h, tt.method, tt.path, nil)
+			if rr.Code != tt.want {
+				t.Errorf("Expected status %d, got %d. Body: %s", tt.want, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
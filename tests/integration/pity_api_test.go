@@ -0,0 +1,73 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestPityAPI_RollReportsProgress(t *testing.T) {
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	pityRepo := mocks.NewMockPityRepository()
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(),
+		mocks.NewMockBannerRepository(), pityRepo, mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, pityRepo))
+	gachaHandler := handler.NewGachaHandler(gachaService, service.NewValuationService(mocks.NewMockMarketTransactionRepository()))
+
+	mocks.SeedAllRarities(speciesRepo)
+	user := mocks.CreateTestUser("pity-api")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+
+	// The legendary counter only resets on legendary or mythic pulls
+	pityRepo.SetPulls(user.ID, domain.StandardBannerID, domain.Legendary, 10)
+
+	rr, response := doJSONRequest(gachaHandler.PremiumRoll, http.MethodPost, "/api/gacha/premium-roll", map[string]interface{}{
+		"user_id": user.ID.String(),
+		"count":   1,
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	data := response["data"].(map[string]interface{})
+	pity, ok := data["pity"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected pity in the roll response, got %v", data)
+	}
+	progress := pity["progress"].([]interface{})
+	if len(progress) != len(domain.PityRules) {
+		t.Fatalf("Expected progress for %d rules, got %d", len(domain.PityRules), len(progress))
+	}
+
+	// The same progress is available without rolling
+	rr, response = doJSONRequest(gachaHandler.GetPity, http.MethodGet, "/api/users/"+user.ID.String()+"/pity", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	stored, _ := gachaService.GetPity(ctx, user.ID, domain.StandardBannerID)
+	for _, entry := range response["data"].(map[string]interface{})["progress"].([]interface{}) {
+		entry := entry.(map[string]interface{})
+		rarity := domain.Rarity(entry["rarity"].(string))
+		if int(entry["pulls"].(float64)) != stored.Pulls[rarity] {
+			t.Errorf("Expected %d pulls toward %s, got %v", stored.Pulls[rarity], rarity, entry["pulls"])
+		}
+		if rarity == domain.Legendary && stored.Pulls[rarity] != 0 && stored.Pulls[rarity] != 11 {
+			t.Errorf("Expected the legendary counter to reach 11 or reset, got %d", stored.Pulls[rarity])
+		}
+	}
+
+	rr, _ = doJSONRequest(gachaHandler.GetPity, http.MethodGet, "/api/users/not-a-uuid/pity", nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a bad user ID, got %d", rr.Code)
+	}
+}
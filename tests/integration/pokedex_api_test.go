@@ -0,0 +1,59 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+func TestPokedexAPI_GetPokedex(t *testing.T) {
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokedexService := service.NewPokedexService(userRepo, speciesRepo, mocks.NewMockPokedexRepository(), mocks.NewMockItemRepository())
+	pokedexHandler := handler.NewPokedexHandler(pokedexService)
+
+	mocks.SeedAllRarities(speciesRepo)
+	user := mocks.CreateTestUser("dex-api")
+	userRepo.Create(ctx, user)
+	userPath := "/api/users/" + user.ID.String()
+
+	pokedexService.Record(ctx, domain.NewObtainedEvent(user.ID, 1), domain.NewSeenEvent(user.ID, 3))
+
+	rr, response := doJSONRequest(pokedexHandler.GetPokedex, http.MethodGet, userPath+"/pokedex", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	data := response["data"].(map[string]interface{})
+	overall := data["overall"].(map[string]interface{})
+	if overall["total"].(float64) != 6 || overall["owned"].(float64) != 1 || overall["seen"].(float64) != 2 {
+		t.Errorf("Expected 1 of 6 owned and 2 seen, got %v", overall)
+	}
+	common := data["by_rarity"].(map[string]interface{})["common"].(map[string]interface{})
+	if common["percent"].(float64) != 100 {
+		t.Errorf("Expected every common owned, got %v", common)
+	}
+	if len(data["species"].([]interface{})) != 6 || len(data["milestones"].([]interface{})) != len(domain.PokedexMilestones) {
+		t.Errorf("Expected every species and milestone listed, got %v", data)
+	}
+
+	for _, bad := range []struct {
+		method string
+		path   string
+		code   int
+	}{
+		{http.MethodGet, "/api/users/" + uuid.New().String() + "/pokedex", http.StatusNotFound},
+		{http.MethodGet, "/api/users/not-a-uuid/pokedex", http.StatusBadRequest},
+		{http.MethodPost, userPath + "/pokedex", http.StatusMethodNotAllowed},
+	} {
+		if rr, _ := doJSONRequest(pokedexHandler.GetPokedex, bad.method, bad.path, nil); rr.Code != bad.code {
+			t.Errorf("Expected %d for %s %s, got %d", bad.code, bad.method, bad.path, rr.Code)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestPullHistoryAPI_HistoryAndStats(t *testing.T) {
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	pullRepo := mocks.NewMockGachaPullRepository()
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(),
+		mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), pullRepo,
+		mocks.NewMockTxManager(userRepo, pokemonRepo, pullRepo))
+	gachaHandler := handler.NewGachaHandler(gachaService, service.NewValuationService(mocks.NewMockMarketTransactionRepository()))
+
+	mocks.SeedAllRarities(speciesRepo)
+	user := mocks.CreateTestUser("history-api")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+
+	rr, _ := doJSONRequest(gachaHandler.PremiumRoll, http.MethodPost, "/api/gacha/premium-roll", map[string]interface{}{
+		"user_id": user.ID.String(),
+		"count":   3,
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	historyPath := "/api/users/" + user.ID.String() + "/pulls"
+	rr, response := doJSONRequest(gachaHandler.GetPullHistory, http.MethodGet, historyPath+"?banner_id=&roll_type=premium&limit=2", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	pulls := response["data"].(map[string]interface{})["pulls"].([]interface{})
+	if len(pulls) != 2 {
+		t.Fatalf("Expected a page of 2 pulls, got %d", len(pulls))
+	}
+	pull := pulls[0].(map[string]interface{})
+	for _, field := range []string{"roll_id", "pokemon_id", "species", "rarity", "pity", "odds"} {
+		if pull[field] == nil {
+			t.Errorf("Expected %s in each pull, got %v", field, pull)
+		}
+	}
+
+	rr, response = doJSONRequest(gachaHandler.GetPullHistory, http.MethodGet, historyPath+"?roll_type=daily", nil)
+	if rr.Code != http.StatusOK || len(response["data"].(map[string]interface{})["pulls"].([]interface{})) != 0 {
+		t.Errorf("Expected no daily pulls, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr, response = doJSONRequest(gachaHandler.GetDropRateReport, http.MethodGet, "/api/gacha/stats?since=2000-01-01T00:00:00Z", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	report := response["data"].(map[string]interface{})
+	if report["total_pulls"].(float64) != 3 || len(report["rarities"].([]interface{})) != 6 {
+		t.Errorf("Expected 3 pulls over 6 rarities, got %v", report)
+	}
+
+	for _, bad := range []struct {
+		handler http.HandlerFunc
+		path    string
+	}{
+		{gachaHandler.GetPullHistory, historyPath + "?roll_type=weekly"},
+		{gachaHandler.GetPullHistory, historyPath + "?limit=500"},
+		{gachaHandler.GetDropRateReport, "/api/gacha/stats?since=yesterday"},
+	} {
+		if rr, _ := doJSONRequest(bad.handler, http.MethodGet, bad.path, nil); rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", bad.path, rr.Code)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+func TestQuestAPI_ListAndClaim(t *testing.T) {
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	questRepo := mocks.NewMockQuestProgressRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	questService := service.NewQuestService(userRepo, questRepo, itemRepo, mocks.NewMockTxManager(userRepo, questRepo, itemRepo))
+	questHandler := handler.NewQuestHandler(questService)
+
+	user := mocks.CreateTestUser("quest-api")
+	userRepo.Create(ctx, user)
+	userPath := "/api/users/" + user.ID.String()
+	startingCoins := user.Coins
+
+	questService.Record(ctx, domain.NewPullEvent(&domain.GachaPull{UserID: user.ID, Rarity: domain.Common}))
+
+	rr, response := doJSONRequest(questHandler.QuestActions, http.MethodGet, userPath+"/quests", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	data := response["data"].(map[string]interface{})
+	expected := domain.DailyQuestCount + domain.WeeklyQuestCount + len(domain.Achievements)
+	if data["count"].(float64) != float64(expected) {
+		t.Fatalf("Expected %d quests, got %v", expected, data["count"])
+	}
+	for _, q := range data["quests"].([]interface{}) {
+		quest := q.(map[string]interface{})
+		if quest["id"] == "first-pull" && (quest["progress"].(float64) != 1 || quest["completed"] != true || quest["claimed"] != false) {
+			t.Errorf("Expected the first pull completed and unclaimed, got %v", quest)
+		}
+	}
+
+	rr, response = doJSONRequest(questHandler.QuestActions, http.MethodPost, userPath+"/quests/first-pull/claim", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	claim := response["data"].(map[string]interface{})
+	if claim["already_claimed"] != false || claim["coins"].(float64) != float64(startingCoins+100) {
+		t.Errorf("Expected 100 coins paid, got %v", claim)
+	}
+
+	rr, response = doJSONRequest(questHandler.QuestActions, http.MethodPost, userPath+"/quests/first-pull/claim", nil)
+	claim = response["data"].(map[string]interface{})
+	if rr.Code != http.StatusOK || claim["already_claimed"] != true || claim["coins"].(float64) != float64(startingCoins+100) {
+		t.Errorf("Expected a repeat claim to pay nothing, got %d: %v", rr.Code, claim)
+	}
+
+	for _, bad := range []struct {
+		method string
+		path   string
+		code   int
+	}{
+		{http.MethodPost, userPath + "/quests/pulls-1000/claim", http.StatusConflict},
+		{http.MethodPost, userPath + "/quests/no-such-quest/claim", http.StatusNotFound},
+		{http.MethodGet, "/api/users/" + uuid.New().String() + "/quests", http.StatusNotFound},
+		{http.MethodGet, "/api/users/not-a-uuid/quests", http.StatusBadRequest},
+		{http.MethodGet, userPath + "/quests/first-pull/claim", http.StatusMethodNotAllowed},
+		{http.MethodPost, userPath + "/quests", http.StatusMethodNotAllowed},
+	} {
+		if rr, _ := doJSONRequest(questHandler.QuestActions, bad.method, bad.path, nil); rr.Code != bad.code {
+			t.Errorf("Expected %d for %s %s, got %d", bad.code, bad.method, bad.path, rr.Code)
+		}
+	}
+}
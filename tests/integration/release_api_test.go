@@ -0,0 +1,110 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestReleaseAPI_PreviewThenBulkSell(t *testing.T) {
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, battleRepo, candyRepo, auditRepo)
+	valuationService := service.NewValuationService(mocks.NewMockMarketTransactionRepository())
+
+	releaseService := service.NewReleaseService(userRepo, pokemonRepo, listingRepo, tradeRepo, battleRepo, candyRepo, auditRepo, valuationService, txManager)
+	releases := handler.NewReleaseHandler(releaseService)
+
+	user := mocks.CreateTestUser("releaser")
+	userRepo.Create(ctx, user)
+	species := mocks.CreateTestSpecies(16, "Pidgey", domain.Common)
+	keep := domain.NewUserPokemon(user.ID, species)
+	keep.IsFavorite = true
+	sell := domain.NewUserPokemon(user.ID, species)
+	pokemonRepo.Create(ctx, keep)
+	pokemonRepo.Create(ctx, sell)
+
+	body := map[string]interface{}{
+		"user_id": user.ID.String(),
+		"rarity":  "common",
+		"dry_run": true,
+	}
+
+	// The preview lists both outcomes without changing anything
+	rr, response := doJSONRequest(releases.BulkSell, http.MethodPost, "/api/pokemon/bulk-sell", body)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	data := response["data"].(map[string]interface{})
+	if data["dry_run"] != true || data["count"].(float64) != 1 || len(data["protected"].([]interface{})) != 1 {
+		t.Fatalf("Expected a preview releasing one and protecting one, got %v", data)
+	}
+	if count, _ := pokemonRepo.CountByUser(ctx, user.ID); count != 2 {
+		t.Fatalf("Expected the preview to keep both Pokemon, got %d", count)
+	}
+
+	body["dry_run"] = false
+	rr, response = doJSONRequest(releases.BulkSell, http.MethodPost, "/api/pokemon/bulk-sell", body)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	payout := int(response["data"].(map[string]interface{})["total_payout"].(float64))
+
+	stored, _ := userRepo.GetByID(ctx, user.ID)
+	if stored.Coins != domain.StartingCoins+payout {
+		t.Errorf("Expected %d coins after selling, got %d", domain.StartingCoins+payout, stored.Coins)
+	}
+	if _, err := pokemonRepo.GetByID(ctx, keep.ID); err != nil {
+		t.Error("Expected the favorite to be kept")
+	}
+}
+
+func TestReleaseAPI_Validation(t *testing.T) {
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo)
+	releaseService := service.NewReleaseService(userRepo, pokemonRepo, mocks.NewMockMarketListingRepository(),
+		mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(),
+		mocks.NewMockCandyRepository(), mocks.NewMockPokemonAuditRepository(),
+		service.NewValuationService(mocks.NewMockMarketTransactionRepository()), txManager)
+	releases := handler.NewReleaseHandler(releaseService)
+
+	user := mocks.CreateTestUser("releaser")
+	userRepo.Create(ctx, user)
+
+	rr, _ := doJSONRequest(releases.Release, http.MethodPost, "/api/pokemon/release", map[string]interface{}{
+		"user_id": user.ID.String(),
+	})
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 without pokemon_ids, got %d", rr.Code)
+	}
+
+	rr, _ = doJSONRequest(releases.BulkSell, http.MethodPost, "/api/pokemon/bulk-sell", map[string]interface{}{
+		"user_id": user.ID.String(),
+	})
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an empty filter, got %d", rr.Code)
+	}
+
+	other := domain.NewUserPokemon(mocks.CreateTestUser("other").ID, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+	pokemonRepo.Create(ctx, other)
+	rr, _ = doJSONRequest(releases.Release, http.MethodPost, "/api/pokemon/release", map[string]interface{}{
+		"user_id":     user.ID.String(),
+		"pokemon_ids": []string{other.ID.String()},
+	})
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 releasing another user's Pokemon, got %d", rr.Code)
+	}
+}
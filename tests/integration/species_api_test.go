@@ -0,0 +1,84 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestSpeciesAPI_Catalog(t *testing.T) {
+	ctx := context.Background()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	learnsetRepo := mocks.NewMockLearnsetRepository()
+	speciesHandler := handler.NewSpeciesHandler(service.NewSpeciesService(speciesRepo, learnsetRepo))
+
+	flying := domain.Flying
+	charizard := mocks.CreateTestSpecies(6, "Charizard", domain.Epic)
+	charizard.Type1, charizard.Type2 = domain.Fire, &flying
+	charizard.BaseSpeed = 100
+	charizard.Abilities, charizard.HiddenAbility = []string{"blaze"}, "solar_power"
+	squirtle := mocks.CreateTestSpecies(7, "Squirtle", domain.Uncommon)
+	squirtle.Type1 = domain.Water
+	squirtle.BaseSpeed = 43
+	speciesRepo.Create(ctx, charizard)
+	speciesRepo.Create(ctx, squirtle)
+	learnsetRepo.Learnsets[6] = []*domain.Move{{ID: 1, Name: "Flamethrower", Type: domain.Fire}}
+
+	rr, response := doJSONRequest(speciesHandler.Species, http.MethodGet, "/api/species?type1=fire&min_speed=90&sort=total&order=desc", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	data := response["data"].(map[string]interface{})
+	species := data["species"].([]interface{})
+	if data["count"].(float64) != 1 || len(species) != 1 {
+		t.Fatalf("Expected only Charizard, got %v", data)
+	}
+	first := species[0].(map[string]interface{})
+	if first["name"] != "Charizard" || first["type2"] != "flying" || first["base_stat_total"].(float64) != 600 {
+		t.Errorf("Expected Charizard's types and stat total, got %v", first)
+	}
+
+	for _, path := range []string{"/api/species/6", "/api/species/charizard"} {
+		rr, response = doJSONRequest(speciesHandler.Species, http.MethodGet, path, nil)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected 200 for %s, got %d: %s", path, rr.Code, rr.Body.String())
+		}
+		details := response["data"].(map[string]interface{})
+		matchups := details["matchups"].(map[string]interface{})
+		if matchups["multipliers"].(map[string]interface{})["rock"].(float64) != 4 {
+			t.Errorf("Expected Rock to deal 4x to Charizard, got %v", matchups["multipliers"])
+		}
+		if immunities := matchups["immunities"].([]interface{}); len(immunities) != 1 || immunities[0] != "ground" {
+			t.Errorf("Expected a Ground immunity, got %v", immunities)
+		}
+		if len(details["learnset"].([]interface{})) != 1 || len(details["abilities"].([]interface{})) != 2 {
+			t.Errorf("Expected the learnset and both abilities, got %v", details)
+		}
+	}
+
+	for _, bad := range []struct {
+		method string
+		path   string
+		code   int
+	}{
+		{http.MethodGet, "/api/species?type1=plasma", http.StatusBadRequest},
+		{http.MethodGet, "/api/species?rarity=shiny", http.StatusBadRequest},
+		{http.MethodGet, "/api/species?sort=weight", http.StatusBadRequest},
+		{http.MethodGet, "/api/species?order=sideways", http.StatusBadRequest},
+		{http.MethodGet, "/api/species?min_speed=fast", http.StatusBadRequest},
+		{http.MethodGet, "/api/species?limit=101", http.StatusBadRequest},
+		{http.MethodGet, "/api/species/999", http.StatusNotFound},
+		{http.MethodGet, "/api/species/missingno", http.StatusNotFound},
+		{http.MethodGet, "/api/species/6/moves", http.StatusNotFound},
+		{http.MethodPost, "/api/species", http.StatusMethodNotAllowed},
+	} {
+		if rr, _ := doJSONRequest(speciesHandler.Species, bad.method, bad.path, nil); rr.Code != bad.code {
+			t.Errorf("Expected %d for %s %s, got %d", bad.code, bad.method, bad.path, rr.Code)
+		}
+	}
+}
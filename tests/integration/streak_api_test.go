@@ -0,0 +1,80 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+func TestStreakAPI_CheckIn(t *testing.T) {
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	streakRepo := mocks.NewMockLoginStreakRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, streakRepo, itemRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(),
+		mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(),
+		mocks.NewMockGachaPullRepository(), txManager)
+	streakHandler := handler.NewStreakHandler(service.NewStreakService(userRepo, streakRepo, itemRepo, gachaService, txManager),
+		service.NewValuationService(mocks.NewMockMarketTransactionRepository()))
+
+	mocks.SeedAllRarities(speciesRepo)
+	user := mocks.CreateTestUser("streak-api")
+	userRepo.Create(ctx, user)
+	userPath := "/api/users/" + user.ID.String()
+
+	rr, response := doJSONRequest(streakHandler.StreakActions, http.MethodGet, userPath+"/streak", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	streak := response["data"].(map[string]interface{})
+	if streak["current"].(float64) != 0 || streak["checked_in"] != false || streak["timezone"] != "UTC" {
+		t.Errorf("Expected no streak yet, got %v", streak)
+	}
+
+	rr, response = doJSONRequest(streakHandler.StreakActions, http.MethodPost, userPath+"/check-in", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	data := response["data"].(map[string]interface{})
+	reward := data["reward"].(map[string]interface{})
+	if reward["day"].(float64) != 1 || reward["coins"].(float64) != 100 || data["coins"].(float64) != float64(user.Coins) {
+		t.Errorf("Expected 100 coins on day 1 and the new balance, got %v", data)
+	}
+	if _, hasPokemon := data["pokemon"]; hasPokemon {
+		t.Error("Expected no Pokemon before a milestone")
+	}
+
+	rr, _ = doJSONRequest(streakHandler.StreakActions, http.MethodPost, userPath+"/check-in", nil)
+	if rr.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected 429 for a second check-in, got %d", rr.Code)
+	}
+
+	rr, response = doJSONRequest(streakHandler.StreakActions, http.MethodGet, userPath+"/streak", nil)
+	streak = response["data"].(map[string]interface{})
+	if rr.Code != http.StatusOK || streak["current"].(float64) != 1 || streak["checked_in"] != true {
+		t.Errorf("Expected a checked-in streak of 1, got %d: %v", rr.Code, streak)
+	}
+
+	for _, bad := range []struct {
+		method string
+		path   string
+		code   int
+	}{
+		{http.MethodGet, "/api/users/" + uuid.New().String() + "/streak", http.StatusNotFound},
+		{http.MethodPost, "/api/users/not-a-uuid/check-in", http.StatusBadRequest},
+		{http.MethodGet, userPath + "/check-in", http.StatusMethodNotAllowed},
+	} {
+		if rr, _ := doJSONRequest(streakHandler.StreakActions, bad.method, bad.path, nil); rr.Code != bad.code {
+			t.Errorf("Expected %d for %s %s, got %d", bad.code, bad.method, bad.path, rr.Code)
+		}
+	}
+}
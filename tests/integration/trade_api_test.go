@@ -0,0 +1,137 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestTradeAPI_ProposeConfirmAndNotify(t *testing.T) {
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, notificationRepo)
+
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, mocks.NewMockBattleRepository(), notificationRepo, txManager)
+	trades := handler.NewTradeHandler(tradeService)
+	notifications := handler.NewNotificationHandler(notificationRepo)
+
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	userRepo.Create(ctx, alice)
+	userRepo.Create(ctx, bob)
+
+	pikachu := domain.NewUserPokemon(alice.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	eevee := domain.NewUserPokemon(bob.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, pikachu)
+	pokemonRepo.Create(ctx, eevee)
+
+	// Propose
+	rr, response := doJSONRequest(trades.Trades, http.MethodPost, "/api/trades", map[string]interface{}{
+		"user_id":               alice.ID.String(),
+		"recipient_id":          bob.ID.String(),
+		"proposer_pokemon_ids":  []string{pikachu.ID.String()},
+		"recipient_pokemon_ids": []string{eevee.ID.String()},
+		"proposer_coins":        50,
+	})
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	tradeID := response["data"].(map[string]interface{})["id"].(string)
+
+	// The offer notification carries the trade ID for the bot's buttons
+	_, response = doJSONRequest(notifications.GetPending, http.MethodGet, "/api/notifications/pending", nil)
+	pending := response["data"].(map[string]interface{})["notifications"].([]interface{})
+	if len(pending) != 1 || pending[0].(map[string]interface{})["reference_id"] != tradeID {
+		t.Fatalf("Expected one trade offer notification referencing the trade, got %v", pending)
+	}
+
+	// Both sides see it
+	rr, response = doJSONRequest(trades.Trades, http.MethodGet, "/api/trades?user_id="+bob.ID.String(), nil)
+	if rr.Code != http.StatusOK || response["data"].(map[string]interface{})["count"].(float64) != 1 {
+		t.Fatalf("Expected one pending trade for bob, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+
+	// An outsider cannot confirm
+	carol := mocks.CreateTestUser("carol")
+	userRepo.Create(ctx, carol)
+	rr, _ = doJSONRequest(trades.Trades, http.MethodPost, "/api/trades/"+tradeID+"/confirm", map[string]string{
+		"user_id": carol.ID.String(),
+	})
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for an outsider, got %d", rr.Code)
+	}
+
+	// Confirm from both sides
+	for _, user := range []*domain.User{alice, bob} {
+		rr, response = doJSONRequest(trades.Trades, http.MethodPost, "/api/trades/"+tradeID+"/confirm", map[string]string{
+			"user_id": user.ID.String(),
+		})
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 confirming, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+	}
+	if status := response["data"].(map[string]interface{})["status"]; status != string(domain.TradeStatusCompleted) {
+		t.Errorf("Expected completed trade, got %v", status)
+	}
+
+	if pikachu.UserID != bob.ID || eevee.UserID != alice.ID {
+		t.Error("Expected the Pokemon to swap owners")
+	}
+
+	// A completed trade cannot be cancelled
+	rr, _ = doJSONRequest(trades.Trades, http.MethodPost, "/api/trades/"+tradeID+"/cancel", map[string]string{
+		"user_id": alice.ID.String(),
+	})
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 cancelling a completed trade, got %d", rr.Code)
+	}
+}
+
+func TestTradeAPI_InvalidRequests(t *testing.T) {
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, notificationRepo)
+	trades := handler.NewTradeHandler(service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, mocks.NewMockBattleRepository(), notificationRepo, txManager))
+
+	alice := mocks.CreateTestUser("alice")
+	userRepo.Create(context.Background(), alice)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     interface{}
+		expected int
+	}{
+		{"bad pokemon id", http.MethodPost, "/api/trades", map[string]interface{}{
+			"user_id": alice.ID.String(), "recipient_id": alice.ID.String(), "proposer_pokemon_ids": []string{"nope"},
+		}, http.StatusBadRequest},
+		{"self trade", http.MethodPost, "/api/trades", map[string]interface{}{
+			"user_id": alice.ID.String(), "recipient_id": alice.ID.String(), "proposer_coins": 10,
+		}, http.StatusBadRequest},
+		{"missing user", http.MethodGet, "/api/trades", nil, http.StatusBadRequest},
+		{"unknown trade", http.MethodGet, "/api/trades/00000000-0000-0000-0000-000000000000", nil, http.StatusNotFound},
+		{"wrong method", http.MethodDelete, "/api/trades", nil, http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr, _ := doJSONRequest(trades.Trades, tt.method, tt.path, tt.body)
+			if rr.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d. Body: %s", tt.expected, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestTrainingAPI_TrainEVsFromSpread(t *testing.T) {
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+
+	user := mocks.CreateTestUser("trainer")
+	user.Coins = 2000
+	userRepo.Create(ctx, user)
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(1, "TestMon", domain.Common))
+	pokemonRepo.Create(ctx, pokemon)
+
+	trainingService := service.NewTrainingService(userRepo, pokemonRepo, itemRepo, auditRepo, mocks.NewMockMarketListingRepository(),
+		mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, itemRepo, auditRepo))
+	training := handler.NewTrainingHandler(trainingService, service.NewValuationService(mocks.NewMockMarketTransactionRepository()))
+	path := "/api/pokemon/" + pokemon.ID.String() + "/evs"
+
+	rr, response := doJSONRequest(training.TrainEVs, http.MethodPost, path, map[string]interface{}{
+		"user_id": user.ID.String(),
+		"spread":  "252 Atk / 4 SpD / 252 Spe",
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	data := response["data"].(map[string]interface{})
+	trained := data["pokemon"].(map[string]interface{})
+	if trained["ev_spread"] != "252 Atk / 4 SpD / 252 Spe" {
+		t.Errorf("Expected the spread back, got %v", trained["ev_spread"])
+	}
+	if evs := trained["evs"].(map[string]interface{}); evs["speed"].(float64) != 252 {
+		t.Errorf("Expected 252 Speed EVs, got %v", evs["speed"])
+	}
+	if coins := data["cost"].(map[string]interface{})["coins"].(float64); int(coins) != 508*domain.CoinsPerEV {
+		t.Errorf("Expected %d coins, got %v", 508*domain.CoinsPerEV, coins)
+	}
+
+	rr, _ = doJSONRequest(training.TrainEVs, http.MethodPost, path, map[string]interface{}{
+		"user_id": user.ID.String(),
+		"evs":     map[string]int{"hp": 4},
+	})
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 past 510 EVs, got %d", rr.Code)
+	}
+
+	rr, response = doJSONRequest(training.TrainEVs, http.MethodPost, path, map[string]interface{}{
+		"user_id": user.ID.String(),
+		"evs":     map[string]int{"hp": 2},
+		"payment": "vitamins",
+	})
+	if rr.Code != http.StatusPaymentRequired {
+		t.Fatalf("Expected status 402 without vitamins, got %d", rr.Code)
+	}
+	if code := response["error"].(map[string]interface{})["code"]; code != handler.ErrCodeInsufficientItems {
+		t.Errorf("Expected %s, got %v", handler.ErrCodeInsufficientItems, code)
+	}
+
+	rr, _ = doJSONRequest(training.TrainEVs, http.MethodPost, path, map[string]interface{}{
+		"user_id": user.ID.String(),
+		"spread":  "252 Luck",
+	})
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a bad spread, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,88 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/handler"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+func TestValuationAPI_PricesAndBoxValues(t *testing.T) {
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+	valuationService := service.NewValuationService(marketTxRepo)
+	prices := handler.NewValuationHandler(valuationService)
+	pokemon := handler.NewPokemonHandler(gachaService, valuationService)
+
+	user := mocks.CreateTestUser("collector")
+	userRepo.Create(ctx, user)
+
+	pikachu := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	eevee := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, pikachu)
+	pokemonRepo.Create(ctx, eevee)
+
+	for i := 0; i < domain.MinSalesForValuation; i++ {
+		marketTxRepo.Transactions = append(marketTxRepo.Transactions, &domain.MarketTransaction{
+			ID:           uuid.New(),
+			SpeciesID:    25,
+			Price:        1000,
+			IVPercentage: domain.ReferenceIVPercent,
+			CompletedAt:  time.Now().Add(-time.Duration(i) * time.Hour),
+		})
+	}
+
+	// Price report
+	rr, response := doJSONRequest(prices.GetPrices, http.MethodGet, "/api/market/prices/25?days=7", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	data := response["data"].(map[string]interface{})
+	valuation, ok := data["valuation"].(map[string]interface{})
+	if !ok || valuation["median_price"].(float64) != 1000 {
+		t.Errorf("Expected a median of 1000, got %v", data["valuation"])
+	}
+	if len(data["history"].([]interface{})) == 0 {
+		t.Error("Expected price history points")
+	}
+
+	// Species without sales still get an (empty) report
+	_, response = doJSONRequest(prices.GetPrices, http.MethodGet, "/api/market/prices/133", nil)
+	data = response["data"].(map[string]interface{})
+	if data["valuation"] != nil || len(data["history"].([]interface{})) != 0 {
+		t.Errorf("Expected no valuation or history for Eevee, got %v", data)
+	}
+
+	rr, _ = doJSONRequest(prices.GetPrices, http.MethodGet, "/api/market/prices/25?days=1000", nil)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for too many days, got %d", rr.Code)
+	}
+
+	// The box values Pikachu from sales and Eevee by formula
+	rr, response = doJSONRequest(pokemon.GetUserPokemon, http.MethodGet, "/api/users/"+user.ID.String()+"/pokemon", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	for _, p := range response["data"].(map[string]interface{})["pokemons"].([]interface{}) {
+		entry := p.(map[string]interface{})
+		species := entry["species"].(map[string]interface{})["name"]
+		want := "formula"
+		if species == "Pikachu" {
+			want = "market"
+		}
+		if entry["value_source"] != want {
+			t.Errorf("Expected %s to be valued by %s, got %v", species, want, entry["value_source"])
+		}
+	}
+}
@@ -9,17 +9,20 @@ import (
 // CreateTestSpecies creates a test Pokemon species
 func CreateTestSpecies(id int, name string, rarity domain.Rarity) *domain.PokemonSpecies {
 	return &domain.PokemonSpecies{
-		ID:            id,
-		Name:          name,
-		Rarity:        rarity,
-		BaseHP:        100,
-		BaseAttack:    100,
-		BaseDefense:   100,
-		BaseSpAttack:  100,
-		BaseSpDefense: 100,
-		BaseSpeed:     100,
-		SpriteURL:     "https://example.com/sprite.png",
-		DropWeight:    1.0,
+		ID:             id,
+		Name:           name,
+		Rarity:         rarity,
+		BaseHP:         100,
+		BaseAttack:     100,
+		BaseDefense:    100,
+		BaseSpAttack:   100,
+		BaseSpDefense:  100,
+		BaseSpeed:      100,
+		SpriteURL:      "https://example.com/sprite.png",
+		DropWeight:     1.0,
+		GrowthRate:     domain.GrowthMediumFast,
+		BaseExperience: domain.DefaultBaseExperience,
+		GenderRate:     4,
 	}
 }
 
@@ -3,9 +3,13 @@ package mocks
 import (
 	"context"
 	"errors"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
 	"github.com/google/uuid"
 )
 
@@ -15,6 +19,7 @@ type MockUserRepository struct {
 	Users            map[uuid.UUID]*domain.User
 	UpdateCoinsCalls int
 	UpdateRollCalls  int
+	ClaimRollCalls   int
 	CreateError      error
 	GetByIDError     error
 	UpdateError      error
@@ -75,6 +80,19 @@ func (m *MockUserRepository) UpdateCoins(ctx context.Context, userID uuid.UUID,
 	return nil
 }
 
+func (m *MockUserRepository) AdjustCoins(ctx context.Context, userID uuid.UUID, delta int) error {
+	user, exists := m.Users[userID]
+	if !exists {
+		return errors.New("user not found")
+	}
+	if user.Coins+delta < 0 {
+		return repository.ErrInsufficientCoins
+	}
+	user.Coins += delta
+	m.UpdateCoinsCalls++
+	return nil
+}
+
 func (m *MockUserRepository) UpdateLastDailyRoll(ctx context.Context, userID uuid.UUID) error {
 	user, exists := m.Users[userID]
 	if !exists {
@@ -86,6 +104,20 @@ func (m *MockUserRepository) UpdateLastDailyRoll(ctx context.Context, userID uui
 	return nil
 }
 
+func (m *MockUserRepository) ClaimDailyRoll(ctx context.Context, userID uuid.UUID, cooldown time.Duration) (bool, error) {
+	user, exists := m.Users[userID]
+	if !exists {
+		return false, nil
+	}
+	m.ClaimRollCalls++
+	if user.LastDailyRoll != nil && time.Since(*user.LastDailyRoll) < cooldown {
+		return false, nil
+	}
+	now := time.Now()
+	user.LastDailyRoll = &now
+	return true, nil
+}
+
 func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	if _, exists := m.Users[id]; !exists {
 		return errors.New("user not found")
@@ -94,12 +126,17 @@ func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (m *MockUserRepository) Snapshot() func() {
+	return snapshotMap(m.Users)
+}
+
 // MockPokemonSpeciesRepository
 
 type MockPokemonSpeciesRepository struct {
 	Species     map[int]*domain.PokemonSpecies
 	RarityMap   map[domain.Rarity][]*domain.PokemonSpecies
 	RandomIndex int
+	ListCalls   int
 	GetByIDError error
 }
 
@@ -127,6 +164,15 @@ func (m *MockPokemonSpeciesRepository) GetByID(ctx context.Context, id int) (*do
 	return species, nil
 }
 
+func (m *MockPokemonSpeciesRepository) GetByName(ctx context.Context, name string) (*domain.PokemonSpecies, error) {
+	for _, s := range m.Species {
+		if strings.EqualFold(s.Name, name) {
+			return s, nil
+		}
+	}
+	return nil, repository.ErrSpeciesNotFound
+}
+
 func (m *MockPokemonSpeciesRepository) GetByRarity(ctx context.Context, rarity domain.Rarity) ([]*domain.PokemonSpecies, error) {
 	return m.RarityMap[rarity], nil
 }
@@ -143,10 +189,71 @@ func (m *MockPokemonSpeciesRepository) GetRandomByRarity(ctx context.Context, ra
 }
 
 func (m *MockPokemonSpeciesRepository) List(ctx context.Context) ([]*domain.PokemonSpecies, error) {
+	m.ListCalls++
 	var result []*domain.PokemonSpecies
 	for _, s := range m.Species {
 		result = append(result, s)
 	}
+
+	// By ID, like Postgres, so seeded pulls repeat
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+func (m *MockPokemonSpeciesRepository) Search(ctx context.Context, filter domain.SpeciesSearchFilter) ([]*domain.PokemonSpecies, error) {
+	var result []*domain.PokemonSpecies
+	for _, s := range m.Species {
+		if filter.Type1 != "" && s.Type1 != filter.Type1 ||
+			filter.Type2 != "" && (s.Type2 == nil || *s.Type2 != filter.Type2) ||
+			filter.Rarity != "" && s.Rarity != filter.Rarity {
+			continue
+		}
+		matches := true
+		for stat, min := range filter.MinStats {
+			if value, _ := s.BaseStat(stat); value < min {
+				matches = false
+			}
+		}
+		for stat, max := range filter.MaxStats {
+			if value, _ := s.BaseStat(stat); value > max {
+				matches = false
+			}
+		}
+		if matches {
+			result = append(result, s)
+		}
+	}
+
+	// Like Postgres, ties fall back to Pokedex number
+	key := func(s *domain.PokemonSpecies) int {
+		if value, ok := s.BaseStat(filter.Sort); ok {
+			return value
+		}
+		return s.ID
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if filter.Descending {
+			a, b = b, a
+		}
+		switch {
+		case filter.Sort == domain.SpeciesSortName && a.Name != b.Name:
+			return a.Name < b.Name
+		case key(a) != key(b):
+			return key(a) < key(b)
+		}
+		return result[i].ID < result[j].ID
+	})
+
+	if filter.Offset >= len(result) {
+		return nil, nil
+	}
+	result = result[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(result) {
+		result = result[:filter.Limit]
+	}
 	return result, nil
 }
 
@@ -157,13 +264,54 @@ func (m *MockPokemonSpeciesRepository) BulkCreate(ctx context.Context, species [
 	return nil
 }
 
+func (m *MockPokemonSpeciesRepository) Upsert(ctx context.Context, species *domain.PokemonSpecies) error {
+	existing, exists := m.Species[species.ID]
+	if !exists {
+		return m.Create(ctx, species)
+	}
+
+	updated := *species
+	if existing.Rarity == species.Rarity {
+		updated.DropWeight = existing.DropWeight
+	}
+	*existing = updated
+	m.rebuildRarityMap()
+	return nil
+}
+
+// rebuildRarityMap regroups the species by rarity after they change
+func (m *MockPokemonSpeciesRepository) rebuildRarityMap() {
+	m.RarityMap = make(map[domain.Rarity][]*domain.PokemonSpecies)
+	ids := make([]int, 0, len(m.Species))
+	for id := range m.Species {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		species := m.Species[id]
+		m.RarityMap[species.Rarity] = append(m.RarityMap[species.Rarity], species)
+	}
+}
+
+func (m *MockPokemonSpeciesRepository) Snapshot() func() {
+	restore := snapshotMap(m.Species)
+	return func() {
+		restore()
+		m.rebuildRarityMap()
+	}
+}
+
 // MockUserPokemonRepository
 
 type MockUserPokemonRepository struct {
-	Pokemons    map[uuid.UUID]*domain.UserPokemon
-	CreateCalls int
-	CreateError error
+	Pokemons     map[uuid.UUID]*domain.UserPokemon
+	CreateCalls  int
+	CreateError  error
+	FailCreateAt int // Fail the Nth Create call (1-based), 0 disables
 	GetByIDError error
+	UpdateError  error
+	DeleteCalls  int
+	FailDeleteAt int // Fail the Nth Delete call (1-based), 0 disables
 }
 
 func NewMockUserPokemonRepository() *MockUserPokemonRepository {
@@ -176,6 +324,10 @@ func (m *MockUserPokemonRepository) Create(ctx context.Context, pokemon *domain.
 	if m.CreateError != nil {
 		return m.CreateError
 	}
+	if m.FailCreateAt > 0 && m.CreateCalls+1 == m.FailCreateAt {
+		m.CreateCalls++
+		return errors.New("failed to create pokemon")
+	}
 	m.Pokemons[pokemon.ID] = pokemon
 	m.CreateCalls++
 	return nil
@@ -187,11 +339,15 @@ func (m *MockUserPokemonRepository) GetByID(ctx context.Context, id uuid.UUID) (
 	}
 	pokemon, exists := m.Pokemons[id]
 	if !exists {
-		return nil, errors.New("pokemon not found")
+		return nil, repository.ErrPokemonNotFound
 	}
 	return pokemon, nil
 }
 
+func (m *MockUserPokemonRepository) GetForUpdate(ctx context.Context, id uuid.UUID) (*domain.UserPokemon, error) {
+	return m.GetByID(ctx, id)
+}
+
 func (m *MockUserPokemonRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.UserPokemon, error) {
 	var result []*domain.UserPokemon
 	for _, p := range m.Pokemons {
@@ -202,28 +358,70 @@ func (m *MockUserPokemonRepository) GetByUserID(ctx context.Context, userID uuid
 	return result, nil
 }
 
+func (m *MockUserPokemonRepository) SearchByUser(ctx context.Context, userID uuid.UUID, filter domain.BoxFilter) (*domain.BoxPage, error) {
+	sortBy := filter.Sort
+	if sortBy == "" {
+		sortBy = domain.BoxSortAcquired
+	}
+
+	var matches []*domain.UserPokemon
+	for _, p := range m.Pokemons {
+		if p.UserID == userID && filter.Matches(p) {
+			matches = append(matches, p)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return domain.NewBoxCursor(sortBy, matches[i]).Precedes(matches[j].BoxSortKey(sortBy), matches[j].ID, filter.Ascending)
+	})
+
+	page := &domain.BoxPage{Total: len(matches)}
+	for _, p := range matches {
+		if filter.Cursor == nil || filter.Cursor.Precedes(p.BoxSortKey(sortBy), p.ID, filter.Ascending) {
+			page.Pokemon = append(page.Pokemon, p)
+		}
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = domain.DefaultBoxPageSize
+	}
+	if len(page.Pokemon) > limit {
+		page.Pokemon = page.Pokemon[:limit]
+		page.NextCursor = domain.NewBoxCursor(sortBy, page.Pokemon[limit-1])
+	}
+	return page, nil
+}
+
 func (m *MockUserPokemonRepository) Update(ctx context.Context, pokemon *domain.UserPokemon) error {
+	if m.UpdateError != nil {
+		return m.UpdateError
+	}
 	if _, exists := m.Pokemons[pokemon.ID]; !exists {
-		return errors.New("pokemon not found")
+		return repository.ErrPokemonNotFound
 	}
 	m.Pokemons[pokemon.ID] = pokemon
 	return nil
 }
 
-func (m *MockUserPokemonRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	if _, exists := m.Pokemons[id]; !exists {
-		return errors.New("pokemon not found")
+func (m *MockUserPokemonRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	m.DeleteCalls++
+	if m.FailDeleteAt > 0 && m.DeleteCalls == m.FailDeleteAt {
+		return errors.New("failed to delete pokemon")
+	}
+	if pokemon, exists := m.Pokemons[id]; !exists || pokemon.UserID != userID {
+		return repository.ErrPokemonNotFound
 	}
 	delete(m.Pokemons, id)
 	return nil
 }
 
-func (m *MockUserPokemonRepository) TransferOwnership(ctx context.Context, pokemonID, newOwnerID uuid.UUID) error {
+func (m *MockUserPokemonRepository) TransferOwnership(ctx context.Context, pokemonID, fromOwnerID, toOwnerID uuid.UUID) error {
 	pokemon, exists := m.Pokemons[pokemonID]
-	if !exists {
-		return errors.New("pokemon not found")
+	if !exists || pokemon.UserID != fromOwnerID {
+		return repository.ErrPokemonNotFound
 	}
-	pokemon.UserID = newOwnerID
+	pokemon.UserID = toOwnerID
+	pokemon.AcquiredAt = time.Now()
 	return nil
 }
 
@@ -236,3 +434,1423 @@ func (m *MockUserPokemonRepository) CountByUser(ctx context.Context, userID uuid
 	}
 	return count, nil
 }
+
+func (m *MockUserPokemonRepository) Snapshot() func() {
+	return snapshotMap(m.Pokemons)
+}
+
+// MockBattleRepository
+
+type MockBattleRepository struct {
+	Battles     map[uuid.UUID]*domain.Battle
+	UpdateError error
+}
+
+func NewMockBattleRepository() *MockBattleRepository {
+	return &MockBattleRepository{
+		Battles: make(map[uuid.UUID]*domain.Battle),
+	}
+}
+
+func (m *MockBattleRepository) Create(ctx context.Context, battle *domain.Battle) error {
+	m.Battles[battle.ID] = battle
+	return nil
+}
+
+func (m *MockBattleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Battle, error) {
+	battle, exists := m.Battles[id]
+	if !exists {
+		return nil, errors.New("battle not found")
+	}
+	return battle, nil
+}
+
+func (m *MockBattleRepository) Update(ctx context.Context, battle *domain.Battle) error {
+	if m.UpdateError != nil {
+		return m.UpdateError
+	}
+	if _, exists := m.Battles[battle.ID]; !exists {
+		return errors.New("battle not found")
+	}
+	m.Battles[battle.ID] = battle
+	return nil
+}
+
+func (m *MockBattleRepository) ListActive(ctx context.Context) ([]*domain.Battle, error) {
+	var result []*domain.Battle
+	for _, b := range m.Battles {
+		if b.Status == domain.BattleStatusInProgress {
+			result = append(result, b)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockBattleRepository) ListByPlayer(ctx context.Context, playerID uuid.UUID) ([]*domain.Battle, error) {
+	var result []*domain.Battle
+	for _, b := range m.Battles {
+		if b.Player1ID == playerID || b.Player2ID == playerID {
+			result = append(result, b)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockBattleRepository) CountWins(ctx context.Context, pokemonID uuid.UUID) (int, error) {
+	wins := 0
+	for _, b := range m.Battles {
+		if b.WinnerID == nil {
+			continue
+		}
+		if (b.Player1Pokemon == pokemonID && *b.WinnerID == b.Player1ID) ||
+			(b.Player2Pokemon == pokemonID && *b.WinnerID == b.Player2ID) {
+			wins++
+		}
+	}
+	return wins, nil
+}
+
+func (m *MockBattleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, exists := m.Battles[id]; !exists {
+		return errors.New("battle not found")
+	}
+	delete(m.Battles, id)
+	return nil
+}
+
+func (m *MockBattleRepository) Snapshot() func() {
+	return snapshotMap(m.Battles)
+}
+
+// MockEscrowRepository moves coins on the shared MockUserRepository so tests
+// can assert on balances the same way they would against Postgres.
+
+type MockEscrowRepository struct {
+	Escrows     map[uuid.UUID]*domain.BattleEscrow
+	Users       *MockUserRepository
+	PayOutCalls int
+	RefundCalls int
+}
+
+func NewMockEscrowRepository(users *MockUserRepository) *MockEscrowRepository {
+	return &MockEscrowRepository{
+		Escrows: make(map[uuid.UUID]*domain.BattleEscrow),
+		Users:   users,
+	}
+}
+
+func (m *MockEscrowRepository) Lock(ctx context.Context, escrow *domain.BattleEscrow) error {
+	if _, exists := m.Escrows[escrow.BattleID]; exists {
+		return nil
+	}
+
+	p1, ok1 := m.Users.Users[escrow.Player1ID]
+	p2, ok2 := m.Users.Users[escrow.Player2ID]
+	if !ok1 || !ok2 {
+		return errors.New("user not found")
+	}
+	if !p1.HasCoins(escrow.Amount) || !p2.HasCoins(escrow.Amount) {
+		return repository.ErrInsufficientCoins
+	}
+
+	p1.DeductCoins(escrow.Amount)
+	p2.DeductCoins(escrow.Amount)
+	escrow.Status = domain.EscrowStatusHeld
+	m.Escrows[escrow.BattleID] = escrow
+	return nil
+}
+
+func (m *MockEscrowRepository) GetByBattleID(ctx context.Context, battleID uuid.UUID) (*domain.BattleEscrow, error) {
+	escrow, exists := m.Escrows[battleID]
+	if !exists {
+		return nil, repository.ErrEscrowNotFound
+	}
+	return escrow, nil
+}
+
+func (m *MockEscrowRepository) PayOut(ctx context.Context, battleID, winnerID uuid.UUID) error {
+	m.PayOutCalls++
+	escrow, exists := m.Escrows[battleID]
+	if !exists {
+		return repository.ErrEscrowNotFound
+	}
+	if escrow.IsSettled() {
+		if escrow.SettledAs(domain.EscrowStatusPaidOut, &winnerID) {
+			return nil
+		}
+		return repository.ErrEscrowAlreadySettled
+	}
+	if !escrow.HasPlayer(winnerID) {
+		return repository.ErrInvalidEscrowWinner
+	}
+
+	m.Users.Users[winnerID].AddCoins(escrow.Pot())
+	now := time.Now()
+	escrow.Status = domain.EscrowStatusPaidOut
+	escrow.WinnerID = &winnerID
+	escrow.SettledAt = &now
+	return nil
+}
+
+func (m *MockEscrowRepository) Refund(ctx context.Context, battleID uuid.UUID) error {
+	m.RefundCalls++
+	escrow, exists := m.Escrows[battleID]
+	if !exists {
+		return repository.ErrEscrowNotFound
+	}
+	if escrow.IsSettled() {
+		if escrow.SettledAs(domain.EscrowStatusRefunded, nil) {
+			return nil
+		}
+		return repository.ErrEscrowAlreadySettled
+	}
+
+	m.Users.Users[escrow.Player1ID].AddCoins(escrow.Amount)
+	m.Users.Users[escrow.Player2ID].AddCoins(escrow.Amount)
+	now := time.Now()
+	escrow.Status = domain.EscrowStatusRefunded
+	escrow.SettledAt = &now
+	return nil
+}
+
+func (m *MockEscrowRepository) ListHeld(ctx context.Context) ([]*domain.BattleEscrow, error) {
+	var result []*domain.BattleEscrow
+	for _, e := range m.Escrows {
+		if e.Status == domain.EscrowStatusHeld {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockEscrowRepository) Snapshot() func() {
+	return snapshotMap(m.Escrows)
+}
+
+// MockRollReceiptRepository
+
+type receiptKey struct {
+	userID uuid.UUID
+	key    string
+}
+
+type MockRollReceiptRepository struct {
+	Receipts map[receiptKey]*domain.RollReceipt
+}
+
+func NewMockRollReceiptRepository() *MockRollReceiptRepository {
+	return &MockRollReceiptRepository{
+		Receipts: make(map[receiptKey]*domain.RollReceipt),
+	}
+}
+
+func (m *MockRollReceiptRepository) Create(ctx context.Context, receipt *domain.RollReceipt) error {
+	k := receiptKey{receipt.UserID, receipt.Key}
+	if _, exists := m.Receipts[k]; exists {
+		return errors.New("roll receipt already exists")
+	}
+	m.Receipts[k] = receipt
+	return nil
+}
+
+func (m *MockRollReceiptRepository) GetByKey(ctx context.Context, userID uuid.UUID, key string) (*domain.RollReceipt, error) {
+	receipt, exists := m.Receipts[receiptKey{userID, key}]
+	if !exists {
+		return nil, repository.ErrRollReceiptNotFound
+	}
+	return receipt, nil
+}
+
+func (m *MockRollReceiptRepository) Snapshot() func() {
+	return snapshotMap(m.Receipts)
+}
+
+// MockBannerRepository
+
+type MockBannerRepository struct {
+	Banners map[string]*domain.Banner
+}
+
+func NewMockBannerRepository() *MockBannerRepository {
+	return &MockBannerRepository{
+		Banners: make(map[string]*domain.Banner),
+	}
+}
+
+func (m *MockBannerRepository) Create(ctx context.Context, banner *domain.Banner) error {
+	if _, exists := m.Banners[banner.ID]; exists {
+		return errors.New("banner already exists")
+	}
+	m.Banners[banner.ID] = banner
+	return nil
+}
+
+func (m *MockBannerRepository) GetByID(ctx context.Context, id string) (*domain.Banner, error) {
+	banner, exists := m.Banners[id]
+	if !exists {
+		return nil, repository.ErrBannerNotFound
+	}
+	return banner, nil
+}
+
+func (m *MockBannerRepository) ListActive(ctx context.Context, at time.Time) ([]*domain.Banner, error) {
+	var banners []*domain.Banner
+	for _, banner := range m.Banners {
+		if banner.IsActive(at) {
+			banners = append(banners, banner)
+		}
+	}
+	sort.Slice(banners, func(i, j int) bool {
+		a, b := banners[i], banners[j]
+		if (a.EndsAt == nil) != (b.EndsAt == nil) {
+			return b.EndsAt == nil
+		}
+		if a.EndsAt != nil && !a.EndsAt.Equal(*b.EndsAt) {
+			return a.EndsAt.Before(*b.EndsAt)
+		}
+		return a.ID < b.ID
+	})
+	return banners, nil
+}
+
+func (m *MockBannerRepository) Snapshot() func() {
+	return snapshotMap(m.Banners)
+}
+
+// MockPityRepository
+
+type pityKey struct {
+	userID   uuid.UUID
+	bannerID string
+}
+
+type MockPityRepository struct {
+	States map[pityKey]*domain.PityState
+}
+
+func NewMockPityRepository() *MockPityRepository {
+	return &MockPityRepository{
+		States: make(map[pityKey]*domain.PityState),
+	}
+}
+
+func (m *MockPityRepository) Get(ctx context.Context, userID uuid.UUID, bannerID string) (*domain.PityState, error) {
+	stored, exists := m.States[pityKey{userID, bannerID}]
+	if !exists {
+		return domain.NewPityState(userID, bannerID), nil
+	}
+
+	// Hand out a copy so unsaved changes are not kept
+	state := *stored
+	state.Pulls = make(map[domain.Rarity]int, len(stored.Pulls))
+	for rarity, pulls := range stored.Pulls {
+		state.Pulls[rarity] = pulls
+	}
+	return &state, nil
+}
+
+func (m *MockPityRepository) GetForUpdate(ctx context.Context, userID uuid.UUID, bannerID string) (*domain.PityState, error) {
+	return m.Get(ctx, userID, bannerID)
+}
+
+func (m *MockPityRepository) Save(ctx context.Context, state *domain.PityState) error {
+	m.States[pityKey{state.UserID, state.BannerID}] = state
+	return nil
+}
+
+// SetPulls sets a user's pulls toward a pity rarity on a banner
+func (m *MockPityRepository) SetPulls(userID uuid.UUID, bannerID string, rarity domain.Rarity, pulls int) {
+	state, _ := m.Get(context.Background(), userID, bannerID)
+	state.Pulls[rarity] = pulls
+	m.States[pityKey{userID, bannerID}] = state
+}
+
+func (m *MockPityRepository) Snapshot() func() {
+	return snapshotMap(m.States)
+}
+
+// MockFairSeedRepository
+
+type MockFairSeedRepository struct {
+	Seeds map[uuid.UUID]*domain.FairSeed
+}
+
+func NewMockFairSeedRepository() *MockFairSeedRepository {
+	return &MockFairSeedRepository{
+		Seeds: make(map[uuid.UUID]*domain.FairSeed),
+	}
+}
+
+func (m *MockFairSeedRepository) Create(ctx context.Context, seed *domain.FairSeed) error {
+	if _, err := m.GetActive(ctx, seed.UserID); err == nil {
+		return nil
+	}
+	stored := *seed
+	m.Seeds[seed.ID] = &stored
+	return nil
+}
+
+func (m *MockFairSeedRepository) GetActive(ctx context.Context, userID uuid.UUID) (*domain.FairSeed, error) {
+	for _, s := range m.Seeds {
+		if s.UserID == userID && !s.IsRevealed() {
+			// Hand out a copy so unsaved changes are not kept
+			seed := *s
+			return &seed, nil
+		}
+	}
+	return nil, repository.ErrFairSeedNotFound
+}
+
+func (m *MockFairSeedRepository) GetActiveForUpdate(ctx context.Context, userID uuid.UUID) (*domain.FairSeed, error) {
+	return m.GetActive(ctx, userID)
+}
+
+func (m *MockFairSeedRepository) Update(ctx context.Context, seed *domain.FairSeed) error {
+	stored, exists := m.Seeds[seed.ID]
+	if !exists {
+		return repository.ErrFairSeedNotFound
+	}
+	stored.Nonce = seed.Nonce
+	stored.RevealedAt = seed.RevealedAt
+	return nil
+}
+
+func (m *MockFairSeedRepository) ListRevealed(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.FairSeed, error) {
+	var result []*domain.FairSeed
+	for _, s := range m.Seeds {
+		if s.UserID == userID && s.IsRevealed() {
+			seed := *s
+			result = append(result, &seed)
+		}
+	}
+
+	// Newest first, like Postgres
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RevealedAt.After(*result[j].RevealedAt)
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *MockFairSeedRepository) Snapshot() func() {
+	return snapshotMap(m.Seeds)
+}
+
+// MockGachaPullRepository
+
+type MockGachaPullRepository struct {
+	Pulls map[uuid.UUID]*domain.GachaPull
+}
+
+func NewMockGachaPullRepository() *MockGachaPullRepository {
+	return &MockGachaPullRepository{
+		Pulls: make(map[uuid.UUID]*domain.GachaPull),
+	}
+}
+
+func (m *MockGachaPullRepository) Create(ctx context.Context, pull *domain.GachaPull) error {
+	m.Pulls[pull.ID] = pull
+	return nil
+}
+
+func (m *MockGachaPullRepository) ListByUser(ctx context.Context, userID uuid.UUID, filter domain.PullHistoryFilter) ([]*domain.GachaPull, error) {
+	var result []*domain.GachaPull
+	for _, p := range m.Pulls {
+		switch {
+		case p.UserID != userID,
+			filter.BannerID != nil && p.BannerID != *filter.BannerID,
+			filter.RollType != "" && p.RollType != filter.RollType,
+			filter.Rarity != "" && p.Rarity != filter.Rarity:
+			continue
+		}
+		result = append(result, p)
+	}
+
+	// Newest first, like Postgres
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+		return result[i].ID.String() < result[j].ID.String()
+	})
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = repository.DefaultPullHistoryLimit
+	}
+	if filter.Offset >= len(result) {
+		return nil, nil
+	}
+	result = result[filter.Offset:]
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *MockGachaPullRepository) Tally(ctx context.Context, filter domain.PullStatsFilter) (int, []domain.RarityTally, error) {
+	tallies := make([]domain.RarityTally, len(domain.Rarities))
+	for i, rarity := range domain.Rarities {
+		tallies[i].Rarity = rarity
+	}
+
+	total := 0
+	for _, p := range m.Pulls {
+		switch {
+		case filter.BannerID != nil && p.BannerID != *filter.BannerID,
+			filter.RollType != "" && p.RollType != filter.RollType,
+			p.CreatedAt.Before(filter.Since):
+			continue
+		}
+		total++
+		for i := range tallies {
+			odds := p.Odds[tallies[i].Rarity]
+			if p.Rarity == tallies[i].Rarity {
+				tallies[i].Pulls++
+			}
+			tallies[i].Expected += odds
+			tallies[i].Variance += odds * (1 - odds)
+		}
+	}
+	return total, tallies, nil
+}
+
+func (m *MockGachaPullRepository) Snapshot() func() {
+	return snapshotMap(m.Pulls)
+}
+
+// MockLoginStreakRepository
+
+type MockLoginStreakRepository struct {
+	Streaks   map[uuid.UUID]*domain.LoginStreak
+	SaveError error
+}
+
+func NewMockLoginStreakRepository() *MockLoginStreakRepository {
+	return &MockLoginStreakRepository{
+		Streaks: make(map[uuid.UUID]*domain.LoginStreak),
+	}
+}
+
+func (m *MockLoginStreakRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.LoginStreak, error) {
+	stored, exists := m.Streaks[userID]
+	if !exists {
+		return domain.NewLoginStreak(userID), nil
+	}
+
+	// Hand out a copy so unsaved changes are not kept
+	streak := *stored
+	return &streak, nil
+}
+
+func (m *MockLoginStreakRepository) GetForUpdate(ctx context.Context, userID uuid.UUID) (*domain.LoginStreak, error) {
+	return m.Get(ctx, userID)
+}
+
+func (m *MockLoginStreakRepository) Save(ctx context.Context, streak *domain.LoginStreak) error {
+	if m.SaveError != nil {
+		return m.SaveError
+	}
+	m.Streaks[streak.UserID] = streak
+	return nil
+}
+
+func (m *MockLoginStreakRepository) Snapshot() func() {
+	return snapshotMap(m.Streaks)
+}
+
+// MockQuestProgressRepository
+
+type questProgressKey struct {
+	UserID    uuid.UUID
+	QuestID   string
+	PeriodKey string
+}
+
+type MockQuestProgressRepository struct {
+	Progress  map[questProgressKey]*domain.QuestProgress
+	AddError  error
+	SaveError error
+}
+
+func NewMockQuestProgressRepository() *MockQuestProgressRepository {
+	return &MockQuestProgressRepository{
+		Progress: make(map[questProgressKey]*domain.QuestProgress),
+	}
+}
+
+func (m *MockQuestProgressRepository) Add(ctx context.Context, userID uuid.UUID, questID, periodKey string, delta, goal int) error {
+	if m.AddError != nil {
+		return m.AddError
+	}
+
+	key := questProgressKey{userID, questID, periodKey}
+	progress, exists := m.Progress[key]
+	if !exists {
+		progress = domain.NewQuestProgress(userID, questID, periodKey)
+		m.Progress[key] = progress
+	}
+	progress.Progress = min(progress.Progress+delta, goal)
+	if progress.CompletedAt == nil && progress.Progress >= goal {
+		now := time.Now()
+		progress.CompletedAt = &now
+	}
+	progress.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *MockQuestProgressRepository) ListByUser(ctx context.Context, userID uuid.UUID, periodKeys []string) ([]*domain.QuestProgress, error) {
+	var result []*domain.QuestProgress
+	for key, progress := range m.Progress {
+		if key.UserID == userID && slices.Contains(periodKeys, key.PeriodKey) {
+			result = append(result, progress)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockQuestProgressRepository) GetForUpdate(ctx context.Context, userID uuid.UUID, questID, periodKey string) (*domain.QuestProgress, error) {
+	stored, exists := m.Progress[questProgressKey{userID, questID, periodKey}]
+	if !exists {
+		return domain.NewQuestProgress(userID, questID, periodKey), nil
+	}
+
+	// Hand out a copy so unsaved changes are not kept
+	progress := *stored
+	return &progress, nil
+}
+
+func (m *MockQuestProgressRepository) Save(ctx context.Context, progress *domain.QuestProgress) error {
+	if m.SaveError != nil {
+		return m.SaveError
+	}
+	m.Progress[questProgressKey{progress.UserID, progress.QuestID, progress.PeriodKey}] = progress
+	return nil
+}
+
+// Get returns a player's progress on a quest in a period, or nil
+func (m *MockQuestProgressRepository) Get(userID uuid.UUID, questID, periodKey string) *domain.QuestProgress {
+	return m.Progress[questProgressKey{userID, questID, periodKey}]
+}
+
+func (m *MockQuestProgressRepository) Snapshot() func() {
+	return snapshotMap(m.Progress)
+}
+
+// MockPokedexRepository
+
+type pokedexKey struct {
+	UserID    uuid.UUID
+	SpeciesID int
+}
+
+type pokedexMilestoneKey struct {
+	UserID uuid.UUID
+	Owned  int
+}
+
+type MockPokedexRepository struct {
+	Entries    map[pokedexKey]*domain.PokedexEntry
+	Milestones map[pokedexMilestoneKey]*time.Time
+}
+
+func NewMockPokedexRepository() *MockPokedexRepository {
+	return &MockPokedexRepository{
+		Entries:    make(map[pokedexKey]*domain.PokedexEntry),
+		Milestones: make(map[pokedexMilestoneKey]*time.Time),
+	}
+}
+
+func (m *MockPokedexRepository) MarkSeen(ctx context.Context, userID uuid.UUID, speciesIDs []int) error {
+	for _, id := range speciesIDs {
+		key := pokedexKey{userID, id}
+		if _, exists := m.Entries[key]; !exists {
+			m.Entries[key] = &domain.PokedexEntry{UserID: userID, SpeciesID: id, SeenAt: time.Now()}
+		}
+	}
+	return nil
+}
+
+func (m *MockPokedexRepository) MarkOwned(ctx context.Context, userID uuid.UUID, speciesIDs []int) error {
+	m.MarkSeen(ctx, userID, speciesIDs)
+	for _, id := range speciesIDs {
+		entry := m.Entries[pokedexKey{userID, id}]
+		if entry.OwnedAt == nil {
+			now := time.Now()
+			entry.OwnedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *MockPokedexRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.PokedexEntry, error) {
+	var result []*domain.PokedexEntry
+	for key, entry := range m.Entries {
+		if key.UserID == userID {
+			result = append(result, entry)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].SpeciesID < result[j].SpeciesID })
+	return result, nil
+}
+
+func (m *MockPokedexRepository) CountOwned(ctx context.Context, userID uuid.UUID) (int, error) {
+	count := 0
+	for key, entry := range m.Entries {
+		if key.UserID == userID && entry.IsOwned() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockPokedexRepository) GrantMilestone(ctx context.Context, userID uuid.UUID, owned int) (bool, error) {
+	key := pokedexMilestoneKey{userID, owned}
+	if _, exists := m.Milestones[key]; exists {
+		return false, nil
+	}
+	now := time.Now()
+	m.Milestones[key] = &now
+	return true, nil
+}
+
+func (m *MockPokedexRepository) ListMilestones(ctx context.Context, userID uuid.UUID) ([]int, error) {
+	var result []int
+	for key := range m.Milestones {
+		if key.UserID == userID {
+			result = append(result, key.Owned)
+		}
+	}
+	sort.Ints(result)
+	return result, nil
+}
+
+// Get returns a player's entry for a species, or nil
+func (m *MockPokedexRepository) Get(userID uuid.UUID, speciesID int) *domain.PokedexEntry {
+	return m.Entries[pokedexKey{userID, speciesID}]
+}
+
+func (m *MockPokedexRepository) Snapshot() func() {
+	restoreEntries := snapshotMap(m.Entries)
+	restoreMilestones := snapshotMap(m.Milestones)
+	return func() {
+		restoreEntries()
+		restoreMilestones()
+	}
+}
+
+// MockMarketListingRepository
+
+type MockMarketListingRepository struct {
+	Listings          map[uuid.UUID]*domain.MarketListing
+	UpdateStatusCalls int
+}
+
+func NewMockMarketListingRepository() *MockMarketListingRepository {
+	return &MockMarketListingRepository{
+		Listings: make(map[uuid.UUID]*domain.MarketListing),
+	}
+}
+
+func (m *MockMarketListingRepository) Create(ctx context.Context, listing *domain.MarketListing) error {
+	if _, err := m.GetActiveByPokemonID(ctx, listing.UserPokemonID); err == nil {
+		return repository.ErrAlreadyListed
+	}
+	m.Listings[listing.ID] = listing
+	return nil
+}
+
+func (m *MockMarketListingRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.MarketListing, error) {
+	listing, exists := m.Listings[id]
+	if !exists {
+		return nil, repository.ErrListingNotFound
+	}
+	return listing, nil
+}
+
+func (m *MockMarketListingRepository) GetActiveByPokemonID(ctx context.Context, pokemonID uuid.UUID) (*domain.MarketListing, error) {
+	for _, l := range m.Listings {
+		if l.UserPokemonID == pokemonID && l.IsActive() {
+			return l, nil
+		}
+	}
+	return nil, repository.ErrListingNotFound
+}
+
+func (m *MockMarketListingRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.ListingStatus) error {
+	m.UpdateStatusCalls++
+	listing, exists := m.Listings[id]
+	if !exists || !listing.IsActive() {
+		return repository.ErrListingNotActive
+	}
+	listing.Status = status
+	return nil
+}
+
+func (m *MockMarketListingRepository) Search(ctx context.Context, filter domain.MarketSearchFilter) ([]*domain.MarketListing, error) {
+	var result []*domain.MarketListing
+	for _, l := range m.Listings {
+		p := l.Pokemon
+		switch {
+		case !l.IsActive(),
+			l.IsAuction(),
+			filter.SellerID != uuid.Nil && l.SellerID != filter.SellerID,
+			filter.SpeciesID != 0 && p.SpeciesID != filter.SpeciesID,
+			filter.SpeciesName != "" && !strings.EqualFold(p.Species.Name, filter.SpeciesName),
+			filter.Rarity != "" && p.Species.Rarity != filter.Rarity,
+			p.IVs.IVPercentage() < filter.MinIVPercent,
+			filter.MinPrice > 0 && l.Price < filter.MinPrice,
+			filter.MaxPrice > 0 && l.Price > filter.MaxPrice:
+			continue
+		}
+		result = append(result, l)
+	}
+
+	// Cheapest first, like Postgres
+	sort.Slice(result, func(i, j int) bool { return result[i].Price < result[j].Price })
+
+	if filter.Offset >= len(result) {
+		return nil, nil
+	}
+	result = result[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(result) {
+		result = result[:filter.Limit]
+	}
+	return result, nil
+}
+
+func (m *MockMarketListingRepository) Snapshot() func() {
+	return snapshotMap(m.Listings)
+}
+
+// MockMarketTransactionRepository
+
+type MockMarketTransactionRepository struct {
+	Transactions []*domain.MarketTransaction
+	CreateError  error
+}
+
+func NewMockMarketTransactionRepository() *MockMarketTransactionRepository {
+	return &MockMarketTransactionRepository{}
+}
+
+func (m *MockMarketTransactionRepository) Create(ctx context.Context, transaction *domain.MarketTransaction) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.Transactions = append(m.Transactions, transaction)
+	return nil
+}
+
+func (m *MockMarketTransactionRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.MarketTransaction, error) {
+	var result []*domain.MarketTransaction
+	for i := len(m.Transactions) - 1; i >= 0; i-- {
+		t := m.Transactions[i]
+		if t.BuyerID == userID || t.SellerID == userID {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockMarketTransactionRepository) ListSales(ctx context.Context, speciesIDs []int, since time.Time) ([]*domain.MarketSale, error) {
+	wanted := make(map[int]bool, len(speciesIDs))
+	for _, id := range speciesIDs {
+		wanted[id] = true
+	}
+
+	var result []*domain.MarketSale
+	for _, t := range m.Transactions {
+		if wanted[t.SpeciesID] && !t.CompletedAt.Before(since) {
+			result = append(result, t.Sale())
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].SoldAt.After(result[j].SoldAt) })
+	return result, nil
+}
+
+func (m *MockMarketTransactionRepository) Snapshot() func() {
+	saved := len(m.Transactions)
+	return func() {
+		m.Transactions = m.Transactions[:saved]
+	}
+}
+
+// MockAuctionRepository keeps auctions alongside the listing mock, which
+// holds their listings. Auctions are returned as copies so a bid only
+// takes effect once PlaceBid saves it, like a database row.
+
+type MockAuctionRepository struct {
+	Auctions    map[uuid.UUID]*domain.Auction
+	Bids        []*domain.AuctionBid
+	PlaceBidErr error
+	listingRepo *MockMarketListingRepository
+}
+
+func NewMockAuctionRepository(listingRepo *MockMarketListingRepository) *MockAuctionRepository {
+	return &MockAuctionRepository{
+		Auctions:    make(map[uuid.UUID]*domain.Auction),
+		listingRepo: listingRepo,
+	}
+}
+
+func (m *MockAuctionRepository) Create(ctx context.Context, auction *domain.Auction) error {
+	stored := *auction
+	stored.Listing = nil
+	m.Auctions[auction.ListingID] = &stored
+	return nil
+}
+
+func (m *MockAuctionRepository) GetByListingID(ctx context.Context, listingID uuid.UUID) (*domain.Auction, error) {
+	stored, exists := m.Auctions[listingID]
+	if !exists {
+		return nil, repository.ErrAuctionNotFound
+	}
+	auction := *stored
+	auction.Listing = m.listingRepo.Listings[listingID]
+	return &auction, nil
+}
+
+func (m *MockAuctionRepository) GetForUpdate(ctx context.Context, listingID uuid.UUID) (*domain.Auction, error) {
+	return m.GetByListingID(ctx, listingID)
+}
+
+func (m *MockAuctionRepository) PlaceBid(ctx context.Context, auction *domain.Auction, bid *domain.AuctionBid, previousBid int) error {
+	if m.PlaceBidErr != nil {
+		return m.PlaceBidErr
+	}
+	stored, exists := m.Auctions[auction.ListingID]
+	listing := m.listingRepo.Listings[auction.ListingID]
+	if !exists || !listing.IsActive() || stored.CurrentBid != previousBid {
+		return repository.ErrBidConflict
+	}
+	*stored = *auction
+	stored.Listing = nil
+	m.Bids = append(m.Bids, bid)
+	return nil
+}
+
+func (m *MockAuctionRepository) ListBids(ctx context.Context, listingID uuid.UUID) ([]*domain.AuctionBid, error) {
+	var result []*domain.AuctionBid
+	for i := len(m.Bids) - 1; i >= 0; i-- {
+		if m.Bids[i].ListingID == listingID {
+			result = append(result, m.Bids[i])
+		}
+	}
+	return result, nil
+}
+
+func (m *MockAuctionRepository) ListActive(ctx context.Context, limit, offset int) ([]*domain.Auction, error) {
+	result := m.list(func(a *domain.Auction) bool { return a.IsActive() })
+
+	if offset >= len(result) {
+		return nil, nil
+	}
+	result = result[offset:]
+	if limit > 0 && limit < len(result) {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *MockAuctionRepository) ListEnded(ctx context.Context, now time.Time) ([]*domain.Auction, error) {
+	return m.list(func(a *domain.Auction) bool { return a.IsActive() && a.HasEnded(now) }), nil
+}
+
+// list returns copies of the auctions matching keep, ending soonest first
+func (m *MockAuctionRepository) list(keep func(*domain.Auction) bool) []*domain.Auction {
+	var result []*domain.Auction
+	for id := range m.Auctions {
+		auction, _ := m.GetByListingID(context.Background(), id)
+		if keep(auction) {
+			result = append(result, auction)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].EndsAt.Before(result[j].EndsAt) })
+	return result
+}
+
+func (m *MockAuctionRepository) Snapshot() func() {
+	restore := snapshotMap(m.Auctions)
+	saved := len(m.Bids)
+	return func() {
+		restore()
+		m.Bids = m.Bids[:saved]
+	}
+}
+
+// MockNotificationRepository
+
+type MockNotificationRepository struct {
+	Notifications []*domain.Notification
+	userRepo      *MockUserRepository
+}
+
+func NewMockNotificationRepository(userRepo *MockUserRepository) *MockNotificationRepository {
+	return &MockNotificationRepository{userRepo: userRepo}
+}
+
+func (m *MockNotificationRepository) Create(ctx context.Context, notification *domain.Notification) error {
+	m.Notifications = append(m.Notifications, notification)
+	return nil
+}
+
+func (m *MockNotificationRepository) ListUndelivered(ctx context.Context, limit int) ([]*domain.Notification, error) {
+	var result []*domain.Notification
+	for _, n := range m.Notifications {
+		if n.DeliveredAt != nil {
+			continue
+		}
+		if user, err := m.userRepo.GetByID(ctx, n.UserID); err == nil {
+			n.DiscordID = user.DiscordID
+		}
+		result = append(result, n)
+		if limit > 0 && len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (m *MockNotificationRepository) MarkDelivered(ctx context.Context, id uuid.UUID) error {
+	for _, n := range m.Notifications {
+		if n.ID == id {
+			if n.DeliveredAt == nil {
+				now := time.Now()
+				n.DeliveredAt = &now
+			}
+			return nil
+		}
+	}
+	return repository.ErrNotificationNotFound
+}
+
+// ForUser returns the notifications sent to a user, oldest first
+func (m *MockNotificationRepository) ForUser(userID uuid.UUID) []*domain.Notification {
+	var result []*domain.Notification
+	for _, n := range m.Notifications {
+		if n.UserID == userID {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func (m *MockNotificationRepository) Snapshot() func() {
+	saved := len(m.Notifications)
+	return func() {
+		m.Notifications = m.Notifications[:saved]
+	}
+}
+
+// MockTradeRepository returns trades as copies so changes only take
+// effect once Update saves them, like a database row.
+
+type MockTradeRepository struct {
+	Trades map[uuid.UUID]*domain.Trade
+}
+
+func NewMockTradeRepository() *MockTradeRepository {
+	return &MockTradeRepository{
+		Trades: make(map[uuid.UUID]*domain.Trade),
+	}
+}
+
+func (m *MockTradeRepository) Create(ctx context.Context, trade *domain.Trade) error {
+	stored := *trade
+	m.Trades[trade.ID] = &stored
+	return nil
+}
+
+func (m *MockTradeRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Trade, error) {
+	stored, exists := m.Trades[id]
+	if !exists {
+		return nil, repository.ErrTradeNotFound
+	}
+	trade := *stored
+	return &trade, nil
+}
+
+func (m *MockTradeRepository) GetForUpdate(ctx context.Context, id uuid.UUID) (*domain.Trade, error) {
+	return m.GetByID(ctx, id)
+}
+
+func (m *MockTradeRepository) Update(ctx context.Context, trade *domain.Trade) error {
+	stored, exists := m.Trades[trade.ID]
+	if !exists {
+		return repository.ErrTradeNotFound
+	}
+	*stored = *trade
+	return nil
+}
+
+func (m *MockTradeRepository) ListPendingByUser(ctx context.Context, userID uuid.UUID, now time.Time) ([]*domain.Trade, error) {
+	var result []*domain.Trade
+	for id, t := range m.Trades {
+		if t.HasParticipant(userID) && t.IsPending() && !t.IsExpired(now) {
+			trade, _ := m.GetByID(ctx, id)
+			result = append(result, trade)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+	return result, nil
+}
+
+func (m *MockTradeRepository) ExpirePending(ctx context.Context, now time.Time) ([]*domain.Trade, error) {
+	var result []*domain.Trade
+	for id, t := range m.Trades {
+		if t.IsPending() && t.IsExpired(now) {
+			t.Status = domain.TradeStatusExpired
+			trade, _ := m.GetByID(ctx, id)
+			result = append(result, trade)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockTradeRepository) Snapshot() func() {
+	return snapshotMap(m.Trades)
+}
+
+// MockCandyRepository
+
+type CandyKey struct {
+	UserID    uuid.UUID
+	SpeciesID int
+}
+
+type MockCandyRepository struct {
+	Balances map[CandyKey]*domain.CandyBalance
+}
+
+func NewMockCandyRepository() *MockCandyRepository {
+	return &MockCandyRepository{
+		Balances: make(map[CandyKey]*domain.CandyBalance),
+	}
+}
+
+func (m *MockCandyRepository) Get(ctx context.Context, userID uuid.UUID, speciesID int) (int, error) {
+	if balance, exists := m.Balances[CandyKey{userID, speciesID}]; exists {
+		return balance.Amount, nil
+	}
+	return 0, nil
+}
+
+func (m *MockCandyRepository) Adjust(ctx context.Context, userID uuid.UUID, speciesID int, delta int) error {
+	key := CandyKey{userID, speciesID}
+	balance, exists := m.Balances[key]
+	if !exists {
+		balance = &domain.CandyBalance{UserID: userID, SpeciesID: speciesID}
+	}
+	if balance.Amount+delta < 0 {
+		return repository.ErrInsufficientCandy
+	}
+	balance.Amount += delta
+	m.Balances[key] = balance
+	return nil
+}
+
+func (m *MockCandyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.CandyBalance, error) {
+	var result []*domain.CandyBalance
+	for _, balance := range m.Balances {
+		if balance.UserID == userID && balance.Amount > 0 {
+			result = append(result, balance)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].SpeciesID < result[j].SpeciesID })
+	return result, nil
+}
+
+func (m *MockCandyRepository) Snapshot() func() {
+	return snapshotMap(m.Balances)
+}
+
+// MockPokemonAuditRepository
+
+type MockPokemonAuditRepository struct {
+	Entries []*domain.PokemonAuditEntry
+}
+
+func NewMockPokemonAuditRepository() *MockPokemonAuditRepository {
+	return &MockPokemonAuditRepository{}
+}
+
+func (m *MockPokemonAuditRepository) Create(ctx context.Context, entry *domain.PokemonAuditEntry) error {
+	m.Entries = append(m.Entries, entry)
+	return nil
+}
+
+func (m *MockPokemonAuditRepository) ListByPokemon(ctx context.Context, pokemonID uuid.UUID, limit int) ([]*domain.PokemonAuditEntry, error) {
+	var result []*domain.PokemonAuditEntry
+	for i := len(m.Entries) - 1; i >= 0 && len(result) < limit; i-- {
+		if m.Entries[i].PokemonID == pokemonID {
+			result = append(result, m.Entries[i])
+		}
+	}
+	return result, nil
+}
+
+func (m *MockPokemonAuditRepository) Snapshot() func() {
+	saved := len(m.Entries)
+	return func() {
+		m.Entries = m.Entries[:saved]
+	}
+}
+
+// MockEvolutionRepository
+
+type MockEvolutionRepository struct {
+	Evolutions map[int][]*domain.Evolution
+}
+
+func NewMockEvolutionRepository() *MockEvolutionRepository {
+	return &MockEvolutionRepository{
+		Evolutions: make(map[int][]*domain.Evolution),
+	}
+}
+
+func (m *MockEvolutionRepository) ListFrom(ctx context.Context, speciesID int) ([]*domain.Evolution, error) {
+	return m.Evolutions[speciesID], nil
+}
+
+// MockLearnsetRepository
+
+type MockLearnsetRepository struct {
+	Learnsets map[int][]*domain.Move
+	Unlocked  map[uuid.UUID]map[int]*domain.Move
+}
+
+func NewMockLearnsetRepository() *MockLearnsetRepository {
+	return &MockLearnsetRepository{
+		Learnsets: make(map[int][]*domain.Move),
+		Unlocked:  make(map[uuid.UUID]map[int]*domain.Move),
+	}
+}
+
+func (m *MockLearnsetRepository) ListBySpecies(ctx context.Context, speciesID int) ([]*domain.Move, error) {
+	return m.Learnsets[speciesID], nil
+}
+
+func (m *MockLearnsetRepository) Unlock(ctx context.Context, pokemonID uuid.UUID, speciesID int) ([]*domain.Move, error) {
+	unlocked, exists := m.Unlocked[pokemonID]
+	if !exists {
+		unlocked = make(map[int]*domain.Move)
+		m.Unlocked[pokemonID] = unlocked
+	}
+
+	var result []*domain.Move
+	for _, move := range m.Learnsets[speciesID] {
+		if _, seen := unlocked[move.ID]; !seen {
+			unlocked[move.ID] = move
+			result = append(result, move)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func (m *MockLearnsetRepository) ListUnlocked(ctx context.Context, pokemonID uuid.UUID) ([]*domain.Move, error) {
+	var result []*domain.Move
+	for _, move := range m.Unlocked[pokemonID] {
+		result = append(result, move)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func (m *MockLearnsetRepository) Add(ctx context.Context, speciesID int, moves []*domain.Move) error {
+	for _, move := range moves {
+		known := false
+		for _, existing := range m.Learnsets[speciesID] {
+			if existing.ID == move.ID {
+				known = true
+				break
+			}
+		}
+		if !known {
+			m.Learnsets[speciesID] = append(m.Learnsets[speciesID], move)
+		}
+	}
+	return nil
+}
+
+func (m *MockLearnsetRepository) Snapshot() func() {
+	learnsets := make(map[int][]*domain.Move, len(m.Learnsets))
+	for speciesID, moves := range m.Learnsets {
+		learnsets[speciesID] = moves[:len(moves):len(moves)]
+	}
+	saved := make(map[uuid.UUID]map[int]*domain.Move, len(m.Unlocked))
+	for pokemonID, moves := range m.Unlocked {
+		copied := make(map[int]*domain.Move, len(moves))
+		for id, move := range moves {
+			copied[id] = move
+		}
+		saved[pokemonID] = copied
+	}
+	return func() {
+		m.Learnsets = learnsets
+		m.Unlocked = saved
+	}
+}
+
+// MockMoveRepository
+
+type MockMoveRepository struct {
+	Moves  map[string]*domain.Move // Keyed by name
+	nextID int
+}
+
+func NewMockMoveRepository() *MockMoveRepository {
+	return &MockMoveRepository{
+		Moves: make(map[string]*domain.Move),
+	}
+}
+
+func (m *MockMoveRepository) Upsert(ctx context.Context, move *domain.Move) error {
+	if existing, exists := m.Moves[move.Name]; exists {
+		move.ID = existing.ID
+		if move.Description == "" {
+			move.Description = existing.Description
+		}
+		*existing = *move
+		return nil
+	}
+
+	for _, existing := range m.Moves {
+		if existing.ID > m.nextID {
+			m.nextID = existing.ID
+		}
+	}
+	m.nextID++
+	move.ID = m.nextID
+	stored := *move
+	m.Moves[move.Name] = &stored
+	return nil
+}
+
+func (m *MockMoveRepository) List(ctx context.Context) ([]*domain.Move, error) {
+	result := make([]*domain.Move, 0, len(m.Moves))
+	for _, move := range m.Moves {
+		result = append(result, move)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (m *MockMoveRepository) Snapshot() func() {
+	return snapshotMap(m.Moves)
+}
+
+// MockItemRepository
+
+type ItemKey struct {
+	UserID uuid.UUID
+	Item   string
+}
+
+type MockItemRepository struct {
+	Items map[ItemKey]*domain.InventoryItem
+}
+
+func NewMockItemRepository() *MockItemRepository {
+	return &MockItemRepository{
+		Items: make(map[ItemKey]*domain.InventoryItem),
+	}
+}
+
+func (m *MockItemRepository) Get(ctx context.Context, userID uuid.UUID, item string) (int, error) {
+	if inventory, exists := m.Items[ItemKey{userID, item}]; exists {
+		return inventory.Quantity, nil
+	}
+	return 0, nil
+}
+
+func (m *MockItemRepository) Adjust(ctx context.Context, userID uuid.UUID, item string, delta int) error {
+	key := ItemKey{userID, item}
+	inventory, exists := m.Items[key]
+	if !exists {
+		inventory = &domain.InventoryItem{UserID: userID, Item: item}
+	}
+	if inventory.Quantity+delta < 0 {
+		return repository.ErrInsufficientItems
+	}
+	inventory.Quantity += delta
+	m.Items[key] = inventory
+	return nil
+}
+
+func (m *MockItemRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.InventoryItem, error) {
+	var result []*domain.InventoryItem
+	for _, inventory := range m.Items {
+		if inventory.UserID == userID && inventory.Quantity > 0 {
+			result = append(result, inventory)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Item < result[j].Item })
+	return result, nil
+}
+
+func (m *MockItemRepository) Snapshot() func() {
+	return snapshotMap(m.Items)
+}
+
+// MockTxManager emulates a transaction by snapshotting the participating
+// mock repositories and restoring them when the unit of work fails.
+
+type TxParticipant interface {
+	Snapshot() func()
+}
+
+type MockTxManager struct {
+	Participants []TxParticipant
+	Calls        int
+	Rollbacks    int
+}
+
+func NewMockTxManager(participants ...TxParticipant) *MockTxManager {
+	return &MockTxManager{Participants: participants}
+}
+
+func (m *MockTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Calls++
+
+	restores := make([]func(), 0, len(m.Participants))
+	for _, p := range m.Participants {
+		restores = append(restores, p.Snapshot())
+	}
+
+	if err := fn(ctx); err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+		m.Rollbacks++
+		return err
+	}
+	return nil
+}
+
+// snapshotMap records the entries of a mock's store and returns a func that
+// restores them. Values are restored in place so pointers held by tests
+// see the rolled back state.
+func snapshotMap[K comparable, V any](store map[K]*V) func() {
+	saved := make(map[K]V, len(store))
+	ptrs := make(map[K]*V, len(store))
+	for k, v := range store {
+		saved[k] = *v
+		ptrs[k] = v
+	}
+
+	return func() {
+		for k := range store {
+			if _, existed := saved[k]; !existed {
+				delete(store, k)
+			}
+		}
+		for k, v := range saved {
+			*ptrs[k] = v
+			store[k] = ptrs[k]
+		}
+	}
+}
@@ -2,9 +2,11 @@ package repository_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
 	"github.com/danielyang21/GoBattleServer/tests/mocks"
 	"github.com/google/uuid"
 )
@@ -229,7 +231,7 @@ func TestUserPokemonRepository_Delete(t *testing.T) {
 	repo.Create(ctx, pokemon)
 
 	// Execute
-	err := repo.Delete(ctx, pokemon.ID)
+	err := repo.Delete(ctx, pokemon.ID, userID)
 
 	// Assert
 	if err != nil {
@@ -252,7 +254,7 @@ func TestUserPokemonRepository_Delete_NotFound(t *testing.T) {
 	nonExistentID := uuid.New()
 
 	// Execute
-	err := repo.Delete(ctx, nonExistentID)
+	err := repo.Delete(ctx, nonExistentID, uuid.New())
 
 	// Assert
 	if err == nil {
@@ -260,6 +262,29 @@ func TestUserPokemonRepository_Delete_NotFound(t *testing.T) {
 	}
 }
 
+func TestUserPokemonRepository_Delete_WrongOwner(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	repo := mocks.NewMockUserPokemonRepository()
+
+	userID := uuid.New()
+	species := mocks.CreateTestSpecies(25, "Pikachu", domain.Rare)
+	pokemon := domain.NewUserPokemon(userID, species)
+	repo.Create(ctx, pokemon)
+
+	// Execute - someone else can't delete the Pokemon
+	err := repo.Delete(ctx, pokemon.ID, uuid.New())
+
+	// Assert
+	if !errors.Is(err, repository.ErrPokemonNotFound) {
+		t.Fatalf("Expected ErrPokemonNotFound, got %v", err)
+	}
+
+	if _, err := repo.GetByID(ctx, pokemon.ID); err != nil {
+		t.Errorf("Expected the Pokemon to remain, got %v", err)
+	}
+}
+
 func TestUserPokemonRepository_TransferOwnership(t *testing.T) {
 	// Setup
 	ctx := context.Background()
@@ -273,7 +298,7 @@ func TestUserPokemonRepository_TransferOwnership(t *testing.T) {
 	repo.Create(ctx, pokemon)
 
 	// Execute - transfer to user2
-	err := repo.TransferOwnership(ctx, pokemon.ID, user2ID)
+	err := repo.TransferOwnership(ctx, pokemon.ID, user1ID, user2ID)
 
 	// Assert
 	if err != nil {
@@ -306,10 +331,11 @@ func TestUserPokemonRepository_TransferOwnership_NotFound(t *testing.T) {
 
 	// Try to transfer non-existent Pokemon
 	nonExistentID := uuid.New()
+	oldOwnerID := uuid.New()
 	newOwnerID := uuid.New()
 
 	// Execute
-	err := repo.TransferOwnership(ctx, nonExistentID, newOwnerID)
+	err := repo.TransferOwnership(ctx, nonExistentID, oldOwnerID, newOwnerID)
 
 	// Assert
 	if err == nil {
@@ -317,6 +343,31 @@ func TestUserPokemonRepository_TransferOwnership_NotFound(t *testing.T) {
 	}
 }
 
+func TestUserPokemonRepository_TransferOwnership_WrongOwner(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	repo := mocks.NewMockUserPokemonRepository()
+
+	user1ID := uuid.New()
+	user2ID := uuid.New()
+	species := mocks.CreateTestSpecies(25, "Pikachu", domain.Rare)
+	pokemon := domain.NewUserPokemon(user1ID, species)
+	repo.Create(ctx, pokemon)
+
+	// Execute - user2 doesn't own the Pokemon, so it can't leave them
+	err := repo.TransferOwnership(ctx, pokemon.ID, user2ID, uuid.New())
+
+	// Assert
+	if !errors.Is(err, repository.ErrPokemonNotFound) {
+		t.Fatalf("Expected ErrPokemonNotFound, got %v", err)
+	}
+
+	retrieved, _ := repo.GetByID(ctx, pokemon.ID)
+	if retrieved.UserID != user1ID {
+		t.Errorf("Expected owner to stay %s, got %s", user1ID, retrieved.UserID)
+	}
+}
+
 func TestUserPokemonRepository_CountByUser(t *testing.T) {
 	// Setup
 	ctx := context.Background()
@@ -382,7 +433,7 @@ func TestUserPokemonRepository_CountByUser_AfterDelete(t *testing.T) {
 	repo.Create(ctx, pokemon2)
 
 	// Delete one
-	repo.Delete(ctx, pokemon1.ID)
+	repo.Delete(ctx, pokemon1.ID, userID)
 
 	// Execute
 	count, err := repo.CountByUser(ctx, userID)
@@ -242,6 +242,58 @@ func TestUserRepository_UpdateLastDailyRoll_NotFound(t *testing.T) {
 	}
 }
 
+func TestUserRepository_ClaimDailyRoll_OnlyOncePerCooldown(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	repo := mocks.NewMockUserRepository()
+
+	// Create user
+	user := domain.NewUser("discord123")
+	user.LastDailyRoll = nil
+	repo.Create(ctx, user)
+
+	// Execute
+	first, err := repo.ClaimDailyRoll(ctx, user.ID, domain.DailyCooldown)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second, _ := repo.ClaimDailyRoll(ctx, user.ID, domain.DailyCooldown)
+
+	// Assert
+	if !first {
+		t.Errorf("Expected first claim to succeed")
+	}
+	if second {
+		t.Errorf("Expected second claim within the cooldown to fail")
+	}
+	if user.LastDailyRoll == nil {
+		t.Errorf("Expected LastDailyRoll to be set")
+	}
+}
+
+func TestUserRepository_ClaimDailyRoll_AfterCooldown(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	repo := mocks.NewMockUserRepository()
+
+	// Create user who rolled yesterday
+	user := domain.NewUser("discord123")
+	yesterday := time.Now().Add(-25 * time.Hour)
+	user.LastDailyRoll = &yesterday
+	repo.Create(ctx, user)
+
+	// Execute
+	claimed, err := repo.ClaimDailyRoll(ctx, user.ID, domain.DailyCooldown)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !claimed {
+		t.Errorf("Expected claim to succeed after the cooldown")
+	}
+}
+
 func TestUserRepository_Delete(t *testing.T) {
 	// Setup
 	ctx := context.Background()
@@ -0,0 +1,289 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+// abilitySpecies returns a species with two regular abilities and a hidden one
+func abilitySpecies(id int, name string) *domain.PokemonSpecies {
+	species := mocks.CreateTestSpecies(id, name, domain.Rare)
+	species.Abilities = []string{domain.AbilityIntimidation, "flash_fire"}
+	species.HiddenAbility = "justified"
+	return species
+}
+
+func TestAbility_RollsFromSpeciesSlots(t *testing.T) {
+	species := abilitySpecies(58, "Growlithe")
+	r := rand.New(rand.NewSource(1))
+
+	counts := make(map[domain.AbilitySlot]int)
+	for i := 0; i < 10000; i++ {
+		counts[species.RollAbilitySlot(r)]++
+	}
+	if counts[domain.AbilitySlot1] < 4000 || counts[domain.AbilitySlot2] < 4000 {
+		t.Errorf("Expected regular abilities to split evenly, got %v", counts)
+	}
+	if hidden := counts[domain.HiddenAbilitySlot]; hidden < 300 || hidden > 700 {
+		t.Errorf("Expected about %.0f%% hidden abilities, got %d of 10000", domain.HiddenAbilityChance*100, hidden)
+	}
+
+	// A single regular ability and no hidden one always rolls the first slot
+	single := mocks.CreateTestSpecies(92, "Gastly", domain.Uncommon)
+	single.Abilities = []string{domain.AbilityLevitate}
+	for i := 0; i < 100; i++ {
+		p := domain.NewUserPokemon(uuid.New(), single)
+		if p.AbilitySlot != domain.AbilitySlot1 || p.Ability() != domain.AbilityLevitate {
+			t.Fatalf("Expected Levitate in slot 1, got %q in slot %d", p.Ability(), p.AbilitySlot)
+		}
+		if err := validators.ValidateUserPokemon(p); err != nil {
+			t.Fatalf("Expected a rolled Pokemon to be valid, got %v", err)
+		}
+	}
+}
+
+func TestAbility_HiddenAndEvolution(t *testing.T) {
+	p := domain.NewUserPokemon(uuid.New(), abilitySpecies(58, "Growlithe"))
+	p.AbilitySlot = domain.HiddenAbilitySlot
+	if p.Ability() != "justified" || !p.HasHiddenAbility() {
+		t.Errorf("Expected the hidden ability, got %q", p.Ability())
+	}
+
+	// Evolving into a species without the slot falls back to the first ability
+	p.AbilitySlot = domain.AbilitySlot2
+	single := mocks.CreateTestSpecies(59, "Arcanine", domain.Rare)
+	single.Abilities = []string{domain.AbilityIntimidation}
+	p.Evolve(single)
+	if p.Ability() != domain.AbilityIntimidation || p.AbilitySlot != domain.AbilitySlot2 {
+		t.Errorf("Expected Intimidate with the slot kept, got %q in slot %d", p.Ability(), p.AbilitySlot)
+	}
+
+	species := abilitySpecies(58, "Growlithe")
+	species.Abilities = append(species.Abilities, "extra")
+	if err := validators.ValidatePokemonSpecies(species); !errors.Is(err, validators.ErrTooManyAbilities) {
+		t.Errorf("Expected ErrTooManyAbilities, got %v", err)
+	}
+
+	p.AbilitySlot = 4
+	if err := validators.ValidateUserPokemon(p); !errors.Is(err, validators.ErrInvalidAbilitySlot) {
+		t.Errorf("Expected ErrInvalidAbilitySlot, got %v", err)
+	}
+}
+
+func TestChangeAbility_UsesCapsule(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(pokemonRepo, itemRepo, auditRepo)
+	abilityService := service.NewAbilityService(pokemonRepo, itemRepo, auditRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	user := mocks.CreateTestUser("capsule-user")
+	pokemon := domain.NewUserPokemon(user.ID, abilitySpecies(58, "Growlithe"))
+	pokemon.AbilitySlot = domain.AbilitySlot1
+	pokemonRepo.Create(ctx, pokemon)
+	itemRepo.Adjust(ctx, user.ID, domain.AbilityCapsule, 1)
+
+	// Execute
+	result, err := abilityService.ChangeAbility(ctx, user.ID, pokemon.ID, domain.AbilitySlot2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Pokemon.Ability() != "flash_fire" || result.PreviousAbility != domain.AbilityIntimidation {
+		t.Errorf("Expected intimidate -> flash_fire, got %q -> %q", result.PreviousAbility, result.Pokemon.Ability())
+	}
+	if left, _ := itemRepo.Get(ctx, user.ID, domain.AbilityCapsule); left != 0 {
+		t.Errorf("Expected the capsule to be used, %d left", left)
+	}
+	if len(auditRepo.Entries) != 1 || auditRepo.Entries[0].Action != domain.AuditAbilityCapsule {
+		t.Fatalf("Expected one ability_capsule audit entry, got %v", auditRepo.Entries)
+	}
+	entry := auditRepo.Entries[0]
+	if entry.Before.AbilitySlot != domain.AbilitySlot1 || entry.After.AbilitySlot != domain.AbilitySlot2 {
+		t.Errorf("Expected the audit to record slot 1 -> 2, got %d -> %d", entry.Before.AbilitySlot, entry.After.AbilitySlot)
+	}
+}
+
+func TestChangeAbility_WithoutCapsuleRollsBack(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(pokemonRepo, itemRepo, auditRepo)
+	abilityService := service.NewAbilityService(pokemonRepo, itemRepo, auditRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	user := mocks.CreateTestUser("capsule-user")
+	pokemon := domain.NewUserPokemon(user.ID, abilitySpecies(58, "Growlithe"))
+	pokemon.AbilitySlot = domain.AbilitySlot1
+	pokemonRepo.Create(ctx, pokemon)
+
+	// Execute
+	_, err := abilityService.ChangeAbility(ctx, user.ID, pokemon.ID, domain.AbilitySlot2)
+
+	// Assert
+	if !errors.Is(err, service.ErrNoAbilityCapsule) {
+		t.Fatalf("Expected ErrNoAbilityCapsule, got %v", err)
+	}
+	if pokemon.AbilitySlot != domain.AbilitySlot1 || len(auditRepo.Entries) != 0 {
+		t.Errorf("Expected nothing to change, got slot %d", pokemon.AbilitySlot)
+	}
+	if txManager.Rollbacks != 1 {
+		t.Errorf("Expected 1 rollback, got %d", txManager.Rollbacks)
+	}
+}
+
+func TestChangeAbility_Rejections(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	txManager := mocks.NewMockTxManager(pokemonRepo, itemRepo, auditRepo)
+	abilityService := service.NewAbilityService(pokemonRepo, itemRepo, auditRepo, listingRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	user := mocks.CreateTestUser("capsule-user")
+	pokemon := domain.NewUserPokemon(user.ID, abilitySpecies(58, "Growlithe"))
+	pokemon.AbilitySlot = domain.AbilitySlot1
+	pokemonRepo.Create(ctx, pokemon)
+	itemRepo.Adjust(ctx, user.ID, domain.AbilityCapsule, 5)
+
+	// Execute and assert
+	tests := []struct {
+		name string
+		slot domain.AbilitySlot
+		err  error
+	}{
+		{"unknown slot", 0, validators.ErrInvalidAbilitySlot},
+		{"same ability", domain.AbilitySlot1, validators.ErrSameAbility},
+		{"to hidden", domain.HiddenAbilitySlot, validators.ErrHiddenAbilityLocked},
+	}
+	for _, tt := range tests {
+		if _, err := abilityService.ChangeAbility(ctx, user.ID, pokemon.ID, tt.slot); !errors.Is(err, tt.err) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.err, err)
+		}
+	}
+
+	pokemon.AbilitySlot = domain.HiddenAbilitySlot
+	if _, err := abilityService.ChangeAbility(ctx, user.ID, pokemon.ID, domain.AbilitySlot1); !errors.Is(err, validators.ErrHiddenAbilityLocked) {
+		t.Errorf("Expected ErrHiddenAbilityLocked from a hidden ability, got %v", err)
+	}
+	pokemon.AbilitySlot = domain.AbilitySlot1
+
+	pokemon.Species.Abilities = pokemon.Species.Abilities[:1]
+	if _, err := abilityService.ChangeAbility(ctx, user.ID, pokemon.ID, domain.AbilitySlot2); !errors.Is(err, validators.ErrEmptyAbilitySlot) {
+		t.Errorf("Expected ErrEmptyAbilitySlot, got %v", err)
+	}
+	pokemon.Species.Abilities = abilitySpecies(58, "Growlithe").Abilities
+
+	if _, err := abilityService.ChangeAbility(ctx, uuid.New(), pokemon.ID, domain.AbilitySlot2); !errors.Is(err, service.ErrNotPokemonOwner) {
+		t.Errorf("Expected ErrNotPokemonOwner, got %v", err)
+	}
+
+	listingRepo.Create(ctx, domain.NewMarketListing(pokemon, 100))
+	if _, err := abilityService.ChangeAbility(ctx, user.ID, pokemon.ID, domain.AbilitySlot2); !errors.Is(err, service.ErrPokemonLocked) {
+		t.Errorf("Expected ErrPokemonLocked, got %v", err)
+	}
+
+	if left, _ := itemRepo.Get(ctx, user.ID, domain.AbilityCapsule); left != 5 {
+		t.Errorf("Expected no capsules used, %d left", left)
+	}
+}
+
+// abilityBattlePokemon builds a battle Pokemon of a species with an ability
+func abilityBattlePokemon(species *domain.PokemonSpecies, ability string) *domain.BattlePokemon {
+	p := domain.NewUserPokemon(uuid.New(), species)
+	p.IVs = domain.IVs{}
+	p.Nature = domain.Hardy
+	stats := p.GetStats()
+	return &domain.BattlePokemon{
+		UserPokemonID: p.ID,
+		Species:       species,
+		Level:         p.Level,
+		CurrentHP:     stats.HP,
+		MaxHP:         stats.HP,
+		Stats:         stats,
+		Ability:       ability,
+		Status:        domain.StatusNone,
+	}
+}
+
+// abilityDamage deals one hit with a fixed random source
+func abilityDamage(attacker, defender *domain.BattlePokemon, move *domain.Move) *domain.DamageResult {
+	calc := domain.NewDamageCalculator(rand.NewSource(7))
+	return calc.CalculateDamage(&domain.DamageContext{
+		Attacker:        attacker,
+		Defender:        defender,
+		AttackerAbility: domain.GetAbilityByName(attacker.Ability),
+		DefenderAbility: domain.GetAbilityByName(defender.Ability),
+		Move:            move,
+	})
+}
+
+func TestAbility_BattleEffects(t *testing.T) {
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	attacker := abilityBattlePokemon(species, "")
+	earthquake := &domain.Move{Name: "Earthquake", Type: domain.Ground, Category: domain.Physical, Power: 100}
+	flamethrower := &domain.Move{Name: "Flamethrower", Type: domain.Fire, Category: domain.Special, Power: 90}
+	tackle := &domain.Move{Name: "Tackle", Type: domain.Normal, Category: domain.Physical, Power: 40}
+
+	if result := abilityDamage(attacker, abilityBattlePokemon(species, domain.AbilityLevitate), earthquake); result.Damage != 0 || result.Effectiveness != 0 {
+		t.Errorf("Expected Levitate to block Ground moves, got %d damage", result.Damage)
+	}
+
+	plain := abilityDamage(attacker, abilityBattlePokemon(species, ""), flamethrower).Damage
+	fat := abilityDamage(attacker, abilityBattlePokemon(species, domain.AbilityThickFat), flamethrower).Damage
+	if fat > plain/2+1 || fat < plain/2-1 {
+		t.Errorf("Expected Thick Fat to halve %d damage, got %d", plain, fat)
+	}
+	if fatAttacker := abilityDamage(abilityBattlePokemon(species, domain.AbilityThickFat), abilityBattlePokemon(species, ""), flamethrower).Damage; fatAttacker != plain {
+		t.Errorf("Expected Thick Fat not to weaken its own attacks, got %d vs %d", fatAttacker, plain)
+	}
+
+	plain = abilityDamage(attacker, abilityBattlePokemon(species, ""), tackle).Damage
+	huge := abilityDamage(abilityBattlePokemon(species, domain.AbilityHugePower), abilityBattlePokemon(species, ""), tackle).Damage
+	if huge < plain*3/2 {
+		t.Errorf("Expected Huge Power to raise %d damage, got %d", plain, huge)
+	}
+
+	// Sturdy survives a knockout from full HP
+	sturdy := abilityBattlePokemon(species, domain.AbilitySturdy)
+	sturdy.CurrentHP, sturdy.MaxHP = 5, 5
+	if result := abilityDamage(attacker, sturdy, tackle); result.Fainted || result.RemainingHP != 1 {
+		t.Errorf("Expected Sturdy to hang on at 1 HP, got %d", result.RemainingHP)
+	}
+}
+
+func TestAbility_EntryEffects(t *testing.T) {
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	slow := abilityBattlePokemon(species, domain.AbilityDrought)
+	slow.Stats.Speed = 10
+	fast := abilityBattlePokemon(species, domain.AbilityIntimidation)
+
+	battle := domain.NewBattle(uuid.New(), uuid.New(), 0)
+	battle.InitializeBattleState(fast, slow)
+	battle.State.ApplyEntryAbilities()
+
+	if slow.StatStages.Attack != -1 || fast.StatStages.Attack != 0 {
+		t.Errorf("Expected Intimidate to lower only the opponent's Attack, got %d and %d", slow.StatStages.Attack, fast.StatStages.Attack)
+	}
+	if battle.State.Weather != domain.WeatherSun || battle.State.WeatherTurns != domain.EntryWeatherTurns {
+		t.Errorf("Expected Drought to summon sun for %d turns, got %s for %d", domain.EntryWeatherTurns, battle.State.Weather, battle.State.WeatherTurns)
+	}
+	if len(battle.State.Log) != 2 {
+		t.Errorf("Expected both abilities to be logged, got %d entries", len(battle.State.Log))
+	}
+}
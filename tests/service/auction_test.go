@@ -0,0 +1,658 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+func TestCreateAuction_LocksPokemonFromOtherListings(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	auctionRepo := mocks.NewMockAuctionRepository(listingRepo)
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo)
+	auctionService := service.NewAuctionService(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	seller := mocks.CreateTestUser("seller")
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	for _, u := range []*domain.User{seller, alice, bob} {
+		userRepo.Create(ctx, u)
+	}
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// An hour-long auction starting at 100 coins, +10 per bid
+	started, err := auctionService.CreateAuction(ctx, seller.ID, pokemon.ID, 100, 10, time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error starting auction, got %v", err)
+	}
+	listingID := started.ListingID
+
+	// Execute and assert
+	if _, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 500); !errors.Is(err, repository.ErrAlreadyListed) {
+		t.Errorf("Expected ErrAlreadyListed for a fixed listing, got %v", err)
+	}
+
+	if _, err := marketService.BuyListing(ctx, alice.ID, listingID); !errors.Is(err, service.ErrListingIsAuction) {
+		t.Errorf("Expected ErrListingIsAuction when buying outright, got %v", err)
+	}
+
+	listings, _ := marketService.SearchListings(ctx, domain.MarketSearchFilter{})
+	if len(listings) != 0 {
+		t.Errorf("Expected auctions to be left out of fixed-price search, got %d listings", len(listings))
+	}
+}
+
+func TestCreateAuction_LockedPokemonRejected(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	auctionRepo := mocks.NewMockAuctionRepository(listingRepo)
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	tradeRepo := mocks.NewMockTradeRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo)
+	auctionService := service.NewAuctionService(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo, tradeRepo, battleRepo, txManager)
+
+	seller := mocks.CreateTestUser("seller")
+	userRepo.Create(ctx, seller)
+
+	species := mocks.CreateTestSpecies(25, "Pikachu", domain.Rare)
+	battling := domain.NewUserPokemon(seller.ID, species)
+	trading := domain.NewUserPokemon(seller.ID, species)
+	pokemonRepo.Create(ctx, battling)
+	pokemonRepo.Create(ctx, trading)
+
+	battle := domain.NewBattle(seller.ID, uuid.New(), 0)
+	battle.Player1Pokemon = battling.ID
+	battle.Status = domain.BattleStatusInProgress
+	battleRepo.Create(ctx, battle)
+	tradeRepo.Create(ctx, domain.NewTrade(seller.ID, uuid.New(), []uuid.UUID{trading.ID}, nil, 0, 100))
+
+	// Execute and assert
+	for _, pokemon := range []*domain.UserPokemon{battling, trading} {
+		if _, err := auctionService.CreateAuction(ctx, seller.ID, pokemon.ID, 100, 10, time.Hour); !errors.Is(err, service.ErrPokemonLocked) {
+			t.Errorf("Expected ErrPokemonLocked, got %v", err)
+		}
+	}
+	if len(auctionRepo.Auctions) != 0 {
+		t.Errorf("Expected no auction created, got %d", len(auctionRepo.Auctions))
+	}
+}
+
+func TestCreateAuction_Validation(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	auctionRepo := mocks.NewMockAuctionRepository(listingRepo)
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo)
+	auctionService := service.NewAuctionService(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	seller := mocks.CreateTestUser("seller")
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	for _, u := range []*domain.User{seller, alice, bob} {
+		userRepo.Create(ctx, u)
+	}
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// Execute and assert
+	tests := []struct {
+		name      string
+		sellerID  uuid.UUID
+		price     int
+		increment int
+		duration  time.Duration
+		wantErr   error
+	}{
+		{"not the owner", alice.ID, 100, 10, time.Hour, service.ErrNotPokemonOwner},
+		{"price too low", seller.ID, 1, 10, time.Hour, validators.ErrInvalidListingPrice},
+		{"no increment", seller.ID, 100, 0, time.Hour, validators.ErrInvalidBidIncrement},
+		{"too short", seller.ID, 100, 10, time.Minute, validators.ErrInvalidAuctionDuration},
+		{"too long", seller.ID, 100, 10, 8 * 24 * time.Hour, validators.ErrInvalidAuctionDuration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := auctionService.CreateAuction(ctx, tt.sellerID, pokemon.ID, tt.price, tt.increment, tt.duration)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPlaceBid_OutbidIsRefundedImmediately(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	auctionRepo := mocks.NewMockAuctionRepository(listingRepo)
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo)
+	auctionService := service.NewAuctionService(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	seller := mocks.CreateTestUser("seller")
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	for _, u := range []*domain.User{seller, alice, bob} {
+		userRepo.Create(ctx, u)
+	}
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// An hour-long auction starting at 100 coins, +10 per bid
+	started, err := auctionService.CreateAuction(ctx, seller.ID, pokemon.ID, 100, 10, time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error starting auction, got %v", err)
+	}
+	listingID := started.ListingID
+
+	if _, err := auctionService.PlaceBid(ctx, alice.ID, listingID, 100); err != nil {
+		t.Fatalf("Expected bid of 100 to succeed, got %v", err)
+	}
+	if alice.Coins != domain.StartingCoins-100 {
+		t.Fatalf("Expected alice's bid to be held, got %d coins", alice.Coins)
+	}
+
+	// Execute
+	auction, err := auctionService.PlaceBid(ctx, bob.ID, listingID, 110)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected bid of 110 to succeed, got %v", err)
+	}
+
+	if alice.Coins != domain.StartingCoins {
+		t.Errorf("Expected alice to be refunded, got %d coins", alice.Coins)
+	}
+	if bob.Coins != domain.StartingCoins-110 {
+		t.Errorf("Expected bob's bid to be held, got %d coins", bob.Coins)
+	}
+	if *auction.HighestBidderID != bob.ID || auction.BidCount != 2 {
+		t.Errorf("Expected bob to lead after 2 bids, got %d bids", auction.BidCount)
+	}
+
+	notes := notificationRepo.ForUser(alice.ID)
+	if len(notes) != 1 || notes[0].Type != domain.NotificationAuctionOutbid {
+		t.Errorf("Expected alice to get one outbid notification, got %d", len(notes))
+	}
+}
+
+func TestPlaceBid_RejectsInvalidBids(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	auctionRepo := mocks.NewMockAuctionRepository(listingRepo)
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo)
+	auctionService := service.NewAuctionService(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	seller := mocks.CreateTestUser("seller")
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	for _, u := range []*domain.User{seller, alice, bob} {
+		userRepo.Create(ctx, u)
+	}
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// An hour-long auction starting at 100 coins, +10 per bid
+	started, err := auctionService.CreateAuction(ctx, seller.ID, pokemon.ID, 100, 10, time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error starting auction, got %v", err)
+	}
+	listingID := started.ListingID
+
+	// Execute and assert
+	if _, err := auctionService.PlaceBid(ctx, alice.ID, listingID, 99); !errors.Is(err, service.ErrBidTooLow) {
+		t.Errorf("Expected ErrBidTooLow below the starting price, got %v", err)
+	}
+
+	if _, err := auctionService.PlaceBid(ctx, seller.ID, listingID, 100); !errors.Is(err, service.ErrCannotBidOwnAuction) {
+		t.Errorf("Expected ErrCannotBidOwnAuction, got %v", err)
+	}
+
+	if _, err := auctionService.PlaceBid(ctx, alice.ID, listingID, 100); err != nil {
+		t.Fatalf("Expected bid of 100 to succeed, got %v", err)
+	}
+	if _, err := auctionService.PlaceBid(ctx, bob.ID, listingID, 105); !errors.Is(err, service.ErrBidTooLow) {
+		t.Errorf("Expected ErrBidTooLow below the minimum increment, got %v", err)
+	}
+
+	auctionRepo.Auctions[listingID].EndsAt = time.Now().Add(-time.Second)
+	if _, err := auctionService.PlaceBid(ctx, bob.ID, listingID, 500); !errors.Is(err, service.ErrAuctionEnded) {
+		t.Errorf("Expected ErrAuctionEnded, got %v", err)
+	}
+}
+
+func TestPlaceBid_InsufficientCoinsKeepsPreviousBid(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	auctionRepo := mocks.NewMockAuctionRepository(listingRepo)
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo)
+	auctionService := service.NewAuctionService(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	seller := mocks.CreateTestUser("seller")
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	for _, u := range []*domain.User{seller, alice, bob} {
+		userRepo.Create(ctx, u)
+	}
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// An hour-long auction starting at 100 coins, +10 per bid
+	started, err := auctionService.CreateAuction(ctx, seller.ID, pokemon.ID, 100, 10, time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error starting auction, got %v", err)
+	}
+	listingID := started.ListingID
+	if _, err := auctionService.PlaceBid(ctx, alice.ID, listingID, 100); err != nil {
+		t.Fatalf("Expected bid of 100 to succeed, got %v", err)
+	}
+
+	bob.Coins = 50
+
+	// Execute
+	_, err = auctionService.PlaceBid(ctx, bob.ID, listingID, 200)
+
+	// Assert
+	if !errors.Is(err, service.ErrInsufficientFunds) {
+		t.Fatalf("Expected ErrInsufficientFunds, got %v", err)
+	}
+
+	// The refund to alice must be rolled back with the failed bid
+	if alice.Coins != domain.StartingCoins-100 {
+		t.Errorf("Expected alice's bid to stay held, got %d coins", alice.Coins)
+	}
+	auction, _ := auctionService.GetAuction(ctx, listingID)
+	if *auction.HighestBidderID != alice.ID || auction.CurrentBid != 100 {
+		t.Errorf("Expected alice to still lead at 100, got %d", auction.CurrentBid)
+	}
+	if len(notificationRepo.Notifications) != 0 {
+		t.Errorf("Expected no notifications, got %d", len(notificationRepo.Notifications))
+	}
+}
+
+func TestPlaceBid_RaisingOwnBidHoldsOnlyTheNewAmount(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	auctionRepo := mocks.NewMockAuctionRepository(listingRepo)
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo)
+	auctionService := service.NewAuctionService(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	seller := mocks.CreateTestUser("seller")
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	for _, u := range []*domain.User{seller, alice, bob} {
+		userRepo.Create(ctx, u)
+	}
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// An hour-long auction starting at 100 coins, +10 per bid
+	started, err := auctionService.CreateAuction(ctx, seller.ID, pokemon.ID, 100, 10, time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error starting auction, got %v", err)
+	}
+	listingID := started.ListingID
+
+	if _, err := auctionService.PlaceBid(ctx, alice.ID, listingID, 100); err != nil {
+		t.Fatalf("Expected bid of 100 to succeed, got %v", err)
+	}
+
+	// Execute
+	if _, err := auctionService.PlaceBid(ctx, alice.ID, listingID, 300); err != nil {
+		t.Fatalf("Expected bid of 300 to succeed, got %v", err)
+	}
+
+	// Assert
+	if alice.Coins != domain.StartingCoins-300 {
+		t.Errorf("Expected 300 coins held in total, got %d", domain.StartingCoins-alice.Coins)
+	}
+	if len(notificationRepo.Notifications) != 0 {
+		t.Errorf("Expected no outbid notification for raising your own bid")
+	}
+}
+
+func TestPlaceBid_AntiSnipingExtendsAuction(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	auctionRepo := mocks.NewMockAuctionRepository(listingRepo)
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo)
+	auctionService := service.NewAuctionService(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	seller := mocks.CreateTestUser("seller")
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	for _, u := range []*domain.User{seller, alice, bob} {
+		userRepo.Create(ctx, u)
+	}
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// An hour-long auction starting at 100 coins, +10 per bid
+	started, err := auctionService.CreateAuction(ctx, seller.ID, pokemon.ID, 100, 10, time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error starting auction, got %v", err)
+	}
+	listingID := started.ListingID
+
+	// Execute and assert
+	// Well before the end, the end time stays put
+	before := auctionRepo.Auctions[listingID].EndsAt
+	auction, err := auctionService.PlaceBid(ctx, alice.ID, listingID, 100)
+	if err != nil {
+		t.Fatalf("Expected bid of 100 to succeed, got %v", err)
+	}
+	if !auction.EndsAt.Equal(before) {
+		t.Errorf("Expected early bid not to extend the auction")
+	}
+
+	// In the final minute, the auction is pushed back to a full minute
+	auctionRepo.Auctions[listingID].EndsAt = time.Now().Add(10 * time.Second)
+	auction, err = auctionService.PlaceBid(ctx, bob.ID, listingID, 110)
+	if err != nil {
+		t.Fatalf("Expected bid of 110 to succeed, got %v", err)
+	}
+
+	remaining := time.Until(auction.EndsAt)
+	if remaining < domain.AntiSnipeWindow-time.Second || remaining > domain.AntiSnipeWindow {
+		t.Errorf("Expected about %v left after a late bid, got %v", domain.AntiSnipeWindow, remaining)
+	}
+}
+
+func TestSettleEndedAuctions_PaysSellerAndTransfersPokemon(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	auctionRepo := mocks.NewMockAuctionRepository(listingRepo)
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo)
+	auctionService := service.NewAuctionService(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	seller := mocks.CreateTestUser("seller")
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	for _, u := range []*domain.User{seller, alice, bob} {
+		userRepo.Create(ctx, u)
+	}
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// An hour-long auction starting at 100 coins, +10 per bid
+	started, err := auctionService.CreateAuction(ctx, seller.ID, pokemon.ID, 100, 10, time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error starting auction, got %v", err)
+	}
+	listingID := started.ListingID
+
+	if _, err := auctionService.PlaceBid(ctx, alice.ID, listingID, 100); err != nil {
+		t.Fatalf("Expected bid of 100 to succeed, got %v", err)
+	}
+	if _, err := auctionService.PlaceBid(ctx, bob.ID, listingID, 200); err != nil {
+		t.Fatalf("Expected bid of 200 to succeed, got %v", err)
+	}
+	auctionRepo.Auctions[listingID].EndsAt = time.Now().Add(-time.Second)
+
+	// Execute
+	settled, err := auctionService.SettleEndedAuctions(ctx)
+
+	// Assert
+	if err != nil || settled != 1 {
+		t.Fatalf("Expected 1 auction settled, got %d (%v)", settled, err)
+	}
+
+	if pokemon.UserID != bob.ID {
+		t.Errorf("Expected the winner to own the Pokemon")
+	}
+	if bob.Coins != domain.StartingCoins-200 || alice.Coins != domain.StartingCoins {
+		t.Errorf("Expected only the winning bid to be spent, got bob %d and alice %d", bob.Coins, alice.Coins)
+	}
+	if seller.Coins != domain.StartingCoins+190 {
+		t.Errorf("Expected seller to receive 190 coins after the fee, got %d", seller.Coins-domain.StartingCoins)
+	}
+	if len(marketTxRepo.Transactions) != 1 || marketTxRepo.Transactions[0].Price != 200 {
+		t.Errorf("Expected the sale to be recorded at 200 coins")
+	}
+
+	winnerNotes := notificationRepo.ForUser(bob.ID)
+	sellerNotes := notificationRepo.ForUser(seller.ID)
+	if len(winnerNotes) != 1 || winnerNotes[0].Type != domain.NotificationAuctionWon {
+		t.Errorf("Expected the winner to be notified")
+	}
+	if len(sellerNotes) != 1 || sellerNotes[0].Type != domain.NotificationAuctionSold {
+		t.Errorf("Expected the seller to be notified")
+	}
+
+	// Running the scheduler again changes nothing
+	settled, err = auctionService.SettleEndedAuctions(ctx)
+	if err != nil || settled != 0 {
+		t.Errorf("Expected nothing left to settle, got %d (%v)", settled, err)
+	}
+	if seller.Coins != domain.StartingCoins+190 {
+		t.Errorf("Expected seller to be paid once")
+	}
+}
+
+func TestSettleEndedAuctions_NoBidsExpires(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	auctionRepo := mocks.NewMockAuctionRepository(listingRepo)
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo)
+	auctionService := service.NewAuctionService(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	seller := mocks.CreateTestUser("seller")
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	for _, u := range []*domain.User{seller, alice, bob} {
+		userRepo.Create(ctx, u)
+	}
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// An hour-long auction starting at 100 coins, +10 per bid
+	started, err := auctionService.CreateAuction(ctx, seller.ID, pokemon.ID, 100, 10, time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error starting auction, got %v", err)
+	}
+	listingID := started.ListingID
+	auctionRepo.Auctions[listingID].EndsAt = time.Now().Add(-time.Second)
+
+	// Execute
+	_, err = auctionService.SettleEndedAuctions(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	auction, _ := auctionService.GetAuction(ctx, listingID)
+	if auction.Listing.Status != domain.ListingStatusExpired {
+		t.Errorf("Expected expired auction, got %s", auction.Listing.Status)
+	}
+	if pokemon.UserID != seller.ID {
+		t.Errorf("Expected seller to keep the Pokemon")
+	}
+	if notes := notificationRepo.ForUser(seller.ID); len(notes) != 1 || notes[0].Type != domain.NotificationAuctionExpired {
+		t.Errorf("Expected the seller to be told the auction expired")
+	}
+
+	// The Pokemon can be listed again
+	if _, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 100); err != nil {
+		t.Errorf("Expected Pokemon to be listable again, got %v", err)
+	}
+}
+
+func TestSettleEndedAuctions_FailureRollsBack(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	auctionRepo := mocks.NewMockAuctionRepository(listingRepo)
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo)
+	auctionService := service.NewAuctionService(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	seller := mocks.CreateTestUser("seller")
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	for _, u := range []*domain.User{seller, alice, bob} {
+		userRepo.Create(ctx, u)
+	}
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// An hour-long auction starting at 100 coins, +10 per bid
+	started, err := auctionService.CreateAuction(ctx, seller.ID, pokemon.ID, 100, 10, time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error starting auction, got %v", err)
+	}
+	listingID := started.ListingID
+	if _, err := auctionService.PlaceBid(ctx, alice.ID, listingID, 100); err != nil {
+		t.Fatalf("Expected bid of 100 to succeed, got %v", err)
+	}
+	auctionRepo.Auctions[listingID].EndsAt = time.Now().Add(-time.Second)
+
+	marketTxRepo.CreateError = errors.New("database unavailable")
+
+	// Execute
+	_, err = auctionService.SettleEndedAuctions(ctx)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error when recording the sale fails")
+	}
+
+	if pokemon.UserID != seller.ID || seller.Coins != domain.StartingCoins {
+		t.Errorf("Expected the settlement to be rolled back")
+	}
+	if len(notificationRepo.Notifications) != 0 {
+		t.Errorf("Expected no notifications for a rolled back settlement")
+	}
+
+	// The next run settles it
+	marketTxRepo.CreateError = nil
+	if settled, err := auctionService.SettleEndedAuctions(ctx); err != nil || settled != 1 {
+		t.Errorf("Expected retry to settle the auction, got %d (%v)", settled, err)
+	}
+}
+
+func TestCancelAuction(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	auctionRepo := mocks.NewMockAuctionRepository(listingRepo)
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo)
+	auctionService := service.NewAuctionService(userRepo, pokemonRepo, listingRepo, auctionRepo, marketTxRepo, notificationRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	seller := mocks.CreateTestUser("seller")
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	for _, u := range []*domain.User{seller, alice, bob} {
+		userRepo.Create(ctx, u)
+	}
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// An hour-long auction starting at 100 coins, +10 per bid
+	started, err := auctionService.CreateAuction(ctx, seller.ID, pokemon.ID, 100, 10, time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error starting auction, got %v", err)
+	}
+	listingID := started.ListingID
+
+	// Execute and assert
+	if err := auctionService.CancelAuction(ctx, alice.ID, listingID); !errors.Is(err, service.ErrNotListingSeller) {
+		t.Errorf("Expected ErrNotListingSeller, got %v", err)
+	}
+
+	if _, err := auctionService.PlaceBid(ctx, alice.ID, listingID, 100); err != nil {
+		t.Fatalf("Expected bid of 100 to succeed, got %v", err)
+	}
+	if err := auctionService.CancelAuction(ctx, seller.ID, listingID); !errors.Is(err, service.ErrAuctionHasBids) {
+		t.Errorf("Expected ErrAuctionHasBids, got %v", err)
+	}
+
+	// Verify an auction without bids can be cancelled
+	other := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(1, "Bulbasaur", domain.Common))
+	pokemonRepo.Create(ctx, other)
+	auction, _ := auctionService.CreateAuction(ctx, seller.ID, other.ID, 100, 10, time.Hour)
+
+	if err := auctionService.CancelAuction(ctx, seller.ID, auction.ListingID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := auctionService.PlaceBid(ctx, alice.ID, auction.ListingID, 100); !errors.Is(err, service.ErrListingUnavailable) {
+		t.Errorf("Expected cancelled auction to reject bids, got %v", err)
+	}
+}
@@ -0,0 +1,389 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+// runningBanner fills in a schedule that started an hour ago and ends in a
+// day where the banner has none
+func runningBanner(banner *domain.Banner) *domain.Banner {
+	if banner.StartsAt.IsZero() {
+		banner.StartsAt = time.Now().Add(-time.Hour)
+	}
+	if banner.EndsAt == nil {
+		endsAt := time.Now().Add(24 * time.Hour)
+		banner.EndsAt = &endsAt
+	}
+	return banner
+}
+
+func TestRateTable_DefaultRatesMatchRarityDropRates(t *testing.T) {
+	rates := domain.DefaultRates()
+
+	if math.Abs(rates.Total()-1) > 1e-9 {
+		t.Errorf("Expected the standard rates to total 1, got %v", rates.Total())
+	}
+	for _, rarity := range domain.Rarities {
+		if rates[rarity] != rarity.DropRate() {
+			t.Errorf("Expected %s rate %v, got %v", rarity, rarity.DropRate(), rates[rarity])
+		}
+	}
+
+	// Rolls keep the old thresholds, rarest first
+	cases := map[float64]domain.Rarity{
+		0.001: domain.Mythic,
+		0.02:  domain.Legendary,
+		0.05:  domain.Epic,
+		0.2:   domain.Rare,
+		0.4:   domain.Uncommon,
+		0.9:   domain.Common,
+	}
+	for roll, want := range cases {
+		if got := rates.Roll(roll); got != want {
+			t.Errorf("Expected roll %v to be %s, got %s", roll, want, got)
+		}
+	}
+}
+
+func TestRateTable_OnlyScalesUpTheRemainingRates(t *testing.T) {
+	rates := domain.DefaultRates().Only([]domain.Rarity{domain.Common, domain.Uncommon})
+
+	if len(rates) != 2 {
+		t.Fatalf("Expected 2 rarities, got %v", rates)
+	}
+	if math.Abs(rates[domain.Common]-2.0/3) > 1e-9 || math.Abs(rates[domain.Uncommon]-1.0/3) > 1e-9 {
+		t.Errorf("Expected 2/3 common and 1/3 uncommon, got %v", rates)
+	}
+}
+
+func TestBanner_SamplerFavorsFeatured(t *testing.T) {
+	articuno := mocks.CreateTestSpecies(144, "Articuno", domain.Legendary)
+	zapdos := mocks.CreateTestSpecies(145, "Zapdos", domain.Legendary)
+	mewtwo := mocks.CreateTestSpecies(150, "Mewtwo", domain.Legendary)
+	banner := &domain.Banner{
+		SpeciesPool: []int{144, 145},
+		Featured:    []*domain.FeaturedSpecies{{SpeciesID: 144, Weight: 9}},
+	}
+	sampler := domain.NewSpeciesSampler([]*domain.PokemonSpecies{articuno, zapdos, mewtwo}, banner.SpeciesWeight)
+
+	r := rand.New(rand.NewSource(1))
+	picks := 0
+	for i := 0; i < 1000; i++ {
+		switch sampler.Pick(r, domain.Legendary).ID {
+		case 144:
+			picks++
+		case 150:
+			t.Fatal("Expected species outside the pool never to be picked")
+		}
+	}
+
+	// Expect about 900 of 1000
+	if picks < 850 || picks > 950 {
+		t.Errorf("Expected the featured species about 90%% of the time, got %d of 1000", picks)
+	}
+
+	if sampler.Pick(r, domain.Mythic) != nil {
+		t.Error("Expected no pick from an empty rarity")
+	}
+}
+
+func TestBanner_IsActive(t *testing.T) {
+	now := time.Now()
+	ended := now.Add(-time.Minute)
+
+	cases := []struct {
+		name   string
+		banner *domain.Banner
+		want   bool
+	}{
+		{"running forever", &domain.Banner{StartsAt: now.Add(-time.Hour)}, true},
+		{"not started", &domain.Banner{StartsAt: now.Add(time.Hour)}, false},
+		{"ended", &domain.Banner{StartsAt: now.Add(-time.Hour), EndsAt: &ended}, false},
+	}
+	for _, tc := range cases {
+		if got := tc.banner.IsActive(now); got != tc.want {
+			t.Errorf("%s: expected active=%v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestPremiumRollOnBanner_OnlyPullsPoolSpecies(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	bannerRepo := mocks.NewMockBannerRepository()
+	mocks.SeedAllRarities(speciesRepo)
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(144, "Articuno", domain.Legendary))
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), bannerRepo, mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("banner-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+	bannerRepo.Create(ctx, runningBanner(&domain.Banner{
+		ID:          "birds",
+		Name:        "Birds",
+		SpeciesPool: []int{16, 144},
+	}))
+
+	// Execute
+	pokemons, err := gachaService.PremiumRollOnBanner(ctx, user.ID, "birds", 30)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, p := range pokemons {
+		if p.SpeciesID != 16 && p.SpeciesID != 144 {
+			t.Errorf("Expected only Pidgey or Articuno, got %s", p.Species.Name)
+		}
+	}
+
+	// The 10-roll guarantee gives the pool's only species above epic
+	if pokemons[9].SpeciesID != 144 {
+		t.Errorf("Expected the 10th pull to be Articuno, got %s", pokemons[9].Species.Name)
+	}
+}
+
+func TestPremiumRollOnBanner_CustomRates(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	bannerRepo := mocks.NewMockBannerRepository()
+	mocks.SeedAllRarities(speciesRepo)
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(144, "Articuno", domain.Legendary))
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), bannerRepo, mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("banner-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+	bannerRepo.Create(ctx, runningBanner(&domain.Banner{
+		ID:    "legendary-only",
+		Name:  "Legendary Only",
+		Rates: domain.RateTable{domain.Legendary: 1},
+	}))
+
+	// Execute
+	pokemons, err := gachaService.PremiumRollOnBanner(ctx, user.ID, "legendary-only", 20)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, p := range pokemons {
+		if p.Species.Rarity != domain.Legendary {
+			t.Errorf("Expected only legendaries, got %s (%s)", p.Species.Name, p.Species.Rarity)
+		}
+	}
+}
+
+func TestPremiumRollOnBanner_FeaturedRateUp(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	bannerRepo := mocks.NewMockBannerRepository()
+	mocks.SeedAllRarities(speciesRepo)
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(144, "Articuno", domain.Legendary))
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), bannerRepo, mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("banner-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+	bannerRepo.Create(ctx, runningBanner(&domain.Banner{
+		ID:          "pidgey-day",
+		Name:        "Pidgey Day",
+		SpeciesPool: []int{1, 16},
+		Featured:    []*domain.FeaturedSpecies{{SpeciesID: 16, Name: "Pidgey", Weight: 1000}},
+	}))
+
+	// Execute
+	pokemons, err := gachaService.PremiumRollOnBanner(ctx, user.ID, "pidgey-day", 50)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	pidgeys := 0
+	for _, p := range pokemons {
+		if p.SpeciesID == 16 {
+			pidgeys++
+		}
+	}
+
+	// Each pull is Pidgey 1000 times in 1001
+	if pidgeys < 45 {
+		t.Errorf("Expected nearly every pull to be the featured Pidgey, got %d of 50", pidgeys)
+	}
+}
+
+func TestPremiumRollOnBanner_InactiveBanner(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	bannerRepo := mocks.NewMockBannerRepository()
+	mocks.SeedAllRarities(speciesRepo)
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(144, "Articuno", domain.Legendary))
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), bannerRepo, mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("banner-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+	ended := time.Now().Add(-time.Hour)
+	bannerRepo.Create(ctx, &domain.Banner{
+		ID:       "last-week",
+		Name:     "Last Week",
+		StartsAt: ended.Add(-7 * 24 * time.Hour),
+		EndsAt:   &ended,
+	})
+
+	// Execute
+	_, err := gachaService.PremiumRollOnBanner(ctx, user.ID, "last-week", 1)
+
+	// Assert
+	if !errors.Is(err, service.ErrBannerNotActive) {
+		t.Fatalf("Expected ErrBannerNotActive, got %v", err)
+	}
+
+	stored, _ := userRepo.GetByID(ctx, user.ID)
+	if stored.Coins != 100000 || pokemonRepo.CreateCalls != 0 {
+		t.Errorf("Expected no charge and no Pokemon, got %d coins and %d Pokemon", stored.Coins, pokemonRepo.CreateCalls)
+	}
+}
+
+func TestPremiumRollOnBanner_UnknownBanner(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	mocks.SeedAllRarities(speciesRepo)
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(144, "Articuno", domain.Legendary))
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("banner-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+
+	// Execute
+	_, err := gachaService.PremiumRollOnBanner(ctx, user.ID, "missing", 1)
+
+	// Assert
+	if !errors.Is(err, repository.ErrBannerNotFound) {
+		t.Fatalf("Expected ErrBannerNotFound, got %v", err)
+	}
+}
+
+func TestListActiveBanners(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	bannerRepo := mocks.NewMockBannerRepository()
+	mocks.SeedAllRarities(speciesRepo)
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(144, "Articuno", domain.Legendary))
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), bannerRepo, mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("banner-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+	soon := time.Now().Add(time.Hour)
+	later := time.Now().Add(48 * time.Hour)
+	ended := time.Now().Add(-time.Hour)
+
+	bannerRepo.Create(ctx, runningBanner(&domain.Banner{ID: "later", Name: "Later", EndsAt: &later}))
+	bannerRepo.Create(ctx, runningBanner(&domain.Banner{ID: "soon", Name: "Soon", EndsAt: &soon}))
+	bannerRepo.Create(ctx, &domain.Banner{ID: "ended", Name: "Ended", StartsAt: ended.Add(-time.Hour), EndsAt: &ended})
+	bannerRepo.Create(ctx, &domain.Banner{ID: "upcoming", Name: "Upcoming", StartsAt: soon, EndsAt: &later})
+
+	// Execute
+	banners, err := gachaService.ListActiveBanners(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(banners) != 2 || banners[0].ID != "soon" || banners[1].ID != "later" {
+		ids := make([]string, len(banners))
+		for i, b := range banners {
+			ids[i] = b.ID
+		}
+		t.Errorf("Expected [soon later], got %v", ids)
+	}
+}
+
+func TestValidateBanner(t *testing.T) {
+	now := time.Now()
+	before := now.Add(-time.Hour)
+
+	valid := func() *domain.Banner {
+		return &domain.Banner{
+			ID:          "legendary-birds",
+			Name:        "Legendary Birds",
+			StartsAt:    now,
+			SpeciesPool: []int{144, 145},
+			Featured:    []*domain.FeaturedSpecies{{SpeciesID: 144, Weight: 5}},
+			Rates:       domain.RateTable{domain.Legendary: 0.5, domain.Common: 0.5},
+		}
+	}
+
+	if err := validators.ValidateBanner(valid()); err != nil {
+		t.Fatalf("Expected a valid banner, got %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		change func(*domain.Banner)
+		want   error
+	}{
+		{"bad slug", func(b *domain.Banner) { b.ID = "Legendary Birds" }, validators.ErrInvalidBannerID},
+		{"no name", func(b *domain.Banner) { b.Name = "" }, validators.ErrEmptyName},
+		{"ends before start", func(b *domain.Banner) { b.EndsAt = &before }, validators.ErrInvalidBannerSchedule},
+		{"rates under 1", func(b *domain.Banner) { b.Rates[domain.Common] = 0.4 }, validators.ErrInvalidBannerRates},
+		{"negative rate", func(b *domain.Banner) { b.Rates[domain.Epic] = -0.1 }, validators.ErrInvalidBannerRates},
+		{"unknown rarity", func(b *domain.Banner) { b.Rates["shiny"] = 0 }, validators.ErrInvalidRarity},
+		{"low weight", func(b *domain.Banner) { b.Featured[0].Weight = 0.5 }, validators.ErrInvalidFeaturedWeight},
+		{"featured outside pool", func(b *domain.Banner) { b.Featured[0].SpeciesID = 146 }, validators.ErrFeaturedNotInPool},
+	}
+	for _, tc := range cases {
+		banner := valid()
+		tc.change(banner)
+		if err := validators.ValidateBanner(banner); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,668 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestStartBattle_LocksWagersInEscrow(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, species)
+	p2Pokemon := domain.NewUserPokemon(player2.ID, species)
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	// Execute
+	// Both players select, which locks the wager
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 200, domain.FormatStandard)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error selecting Pokemon, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player2.ID, p2Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error starting battle, got %v", err)
+	}
+
+	// Assert
+	escrow, err := escrowRepo.GetByBattleID(ctx, battle.ID)
+	if err != nil {
+		t.Fatalf("Expected escrow to exist, got %v", err)
+	}
+	if escrow.Status != domain.EscrowStatusHeld {
+		t.Errorf("Expected escrow status held, got %s", escrow.Status)
+	}
+	if escrow.Pot() != 400 {
+		t.Errorf("Expected pot 400, got %d", escrow.Pot())
+	}
+	if player1.Coins != domain.StartingCoins-200 || player2.Coins != domain.StartingCoins-200 {
+		t.Errorf("Expected both players to have %d coins, got %d and %d",
+			domain.StartingCoins-200, player1.Coins, player2.Coins)
+	}
+}
+
+func TestStartBattle_InsufficientCoinsTakesNothing(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, species)
+	p2Pokemon := domain.NewUserPokemon(player2.ID, species)
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 500, domain.FormatStandard)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+
+	// Player 2 spends coins before the battle starts
+	player2.Coins = 100
+
+	// Execute
+	battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID)
+	err = battleService.SelectPokemon(ctx, battle.ID, player2.ID, p2Pokemon.ID)
+
+	// Assert
+	if !errors.Is(err, service.ErrInsufficientWager) {
+		t.Fatalf("Expected ErrInsufficientWager, got %v", err)
+	}
+	if txManager.Rollbacks != 1 {
+		t.Errorf("Expected 1 rollback, got %d", txManager.Rollbacks)
+	}
+
+	if player1.Coins != domain.StartingCoins {
+		t.Errorf("Expected player 1 to keep %d coins, got %d", domain.StartingCoins, player1.Coins)
+	}
+	if player2.Coins != 100 {
+		t.Errorf("Expected player 2 to keep 100 coins, got %d", player2.Coins)
+	}
+	if len(escrowRepo.Escrows) != 0 {
+		t.Errorf("Expected no escrow, got %d", len(escrowRepo.Escrows))
+	}
+
+	// Players are free to battle again
+	if _, err := battleService.GetPlayerBattle(player1.ID); err == nil {
+		t.Errorf("Expected player 1 to be released from the failed battle")
+	}
+}
+
+func TestStartBattle_FailedUpdateReleasesWagers(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, species)
+	p2Pokemon := domain.NewUserPokemon(player2.ID, species)
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 200, domain.FormatStandard)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error selecting Pokemon, got %v", err)
+	}
+
+	// The wager locks but the battle can't be marked live
+	battleRepo.UpdateError = errors.New("database unavailable")
+
+	// Execute
+	err = battleService.SelectPokemon(ctx, battle.ID, player2.ID, p2Pokemon.ID)
+
+	// Assert
+	if err == nil {
+		t.Fatalf("Expected error when battle update fails")
+	}
+
+	if player1.Coins != domain.StartingCoins || player2.Coins != domain.StartingCoins {
+		t.Errorf("Expected both players to keep %d coins, got %d and %d",
+			domain.StartingCoins, player1.Coins, player2.Coins)
+	}
+	if len(escrowRepo.Escrows) != 0 {
+		t.Errorf("Expected no escrow, got %d", len(escrowRepo.Escrows))
+	}
+}
+
+func TestForfeitBattle_PaysOutEscrowToOpponent(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, species)
+	p2Pokemon := domain.NewUserPokemon(player2.ID, species)
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	// Both players select, which locks the wager
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 200, domain.FormatStandard)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error selecting Pokemon, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player2.ID, p2Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error starting battle, got %v", err)
+	}
+
+	// Execute
+	err = battleService.ForfeitBattle(ctx, battle.ID, player1.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if player1.Coins != domain.StartingCoins-200 {
+		t.Errorf("Expected loser to have %d coins, got %d", domain.StartingCoins-200, player1.Coins)
+	}
+	if player2.Coins != domain.StartingCoins+200 {
+		t.Errorf("Expected winner to have %d coins, got %d", domain.StartingCoins+200, player2.Coins)
+	}
+
+	escrow, _ := escrowRepo.GetByBattleID(ctx, battle.ID)
+	if escrow.Status != domain.EscrowStatusPaidOut {
+		t.Errorf("Expected escrow paid out, got %s", escrow.Status)
+	}
+	if battleRepo.Battles[battle.ID].Status != domain.BattleStatusCompleted {
+		t.Errorf("Expected battle completed, got %s", battleRepo.Battles[battle.ID].Status)
+	}
+}
+
+func TestForfeitBattle_RetryAfterFailedUpdateDoesNotPayTwice(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, species)
+	p2Pokemon := domain.NewUserPokemon(player2.ID, species)
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	// Both players select, which locks the wager
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 200, domain.FormatStandard)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error selecting Pokemon, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player2.ID, p2Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error starting battle, got %v", err)
+	}
+
+	// Escrow pays out but recording the result fails, so the payout is rolled back
+	battleRepo.UpdateError = errors.New("database unavailable")
+
+	// Execute
+	err = battleService.ForfeitBattle(ctx, battle.ID, player1.ID)
+
+	// Assert
+	if err == nil {
+		t.Fatalf("Expected error when battle update fails")
+	}
+	if player2.Coins != domain.StartingCoins-200 {
+		t.Errorf("Expected payout to be rolled back (%d coins), got %d", domain.StartingCoins-200, player2.Coins)
+	}
+	if escrow, _ := escrowRepo.GetByBattleID(ctx, battle.ID); escrow.Status != domain.EscrowStatusHeld {
+		t.Errorf("Expected escrow to still be held, got %s", escrow.Status)
+	}
+
+	// Verify a retry once the database is back pays out once
+	battleRepo.UpdateError = nil
+	if err := battleService.ForfeitBattle(ctx, battle.ID, player1.ID); err != nil {
+		t.Fatalf("Expected retry to succeed, got %v", err)
+	}
+
+	if player2.Coins != domain.StartingCoins+200 {
+		t.Errorf("Expected winner to be paid once (%d coins), got %d", domain.StartingCoins+200, player2.Coins)
+	}
+	if escrowRepo.PayOutCalls != 2 {
+		t.Errorf("Expected 2 PayOut calls, got %d", escrowRepo.PayOutCalls)
+	}
+}
+
+func TestEscrow_ConflictingSettlementRejected(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, species)
+	p2Pokemon := domain.NewUserPokemon(player2.ID, species)
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	// Both players select, which locks the wager
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 200, domain.FormatStandard)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error selecting Pokemon, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player2.ID, p2Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error starting battle, got %v", err)
+	}
+
+	// Execute
+	err = battleService.ForfeitBattle(ctx, battle.ID, player1.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// A late refund must not hand the coins out a second time
+	if err := escrowRepo.Refund(ctx, battle.ID); err == nil {
+		t.Errorf("Expected refund of a paid out escrow to fail")
+	}
+
+	total := player1.Coins + player2.Coins
+	if total != domain.StartingCoins*2 {
+		t.Errorf("Expected total coins to be conserved (%d), got %d", domain.StartingCoins*2, total)
+	}
+}
+
+func TestAbandonInactiveBattles_RefundsBothPlayers(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, species)
+	p2Pokemon := domain.NewUserPokemon(player2.ID, species)
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	// Both players select, which locks the wager
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 300, domain.FormatStandard)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error selecting Pokemon, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player2.ID, p2Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error starting battle, got %v", err)
+	}
+
+	// Execute
+	// A zero timeout treats every running battle as idle
+	abandoned, err := battleService.AbandonInactiveBattles(ctx, 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if abandoned != 1 {
+		t.Fatalf("Expected 1 abandoned battle, got %d", abandoned)
+	}
+
+	if player1.Coins != domain.StartingCoins || player2.Coins != domain.StartingCoins {
+		t.Errorf("Expected both players refunded to %d, got %d and %d",
+			domain.StartingCoins, player1.Coins, player2.Coins)
+	}
+	if battleRepo.Battles[battle.ID].Status != domain.BattleStatusAbandoned {
+		t.Errorf("Expected battle abandoned, got %s", battleRepo.Battles[battle.ID].Status)
+	}
+	if _, err := battleService.GetBattleState(battle.ID); err != service.ErrBattleNotFound {
+		t.Errorf("Expected abandoned battle to be removed, got %v", err)
+	}
+}
+
+func TestAbandonInactiveBattles_KeepsRecentBattles(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, species)
+	p2Pokemon := domain.NewUserPokemon(player2.ID, species)
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	// Both players select, which locks the wager
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 300, domain.FormatStandard)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error selecting Pokemon, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player2.ID, p2Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error starting battle, got %v", err)
+	}
+
+	// Execute
+	abandoned, err := battleService.AbandonInactiveBattles(ctx, time.Hour)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if abandoned != 0 {
+		t.Errorf("Expected no abandoned battles, got %d", abandoned)
+	}
+	if escrowRepo.RefundCalls != 0 {
+		t.Errorf("Expected 0 Refund calls, got %d", escrowRepo.RefundCalls)
+	}
+}
+
+func TestAbandonInactiveBattles_SweepsChallengesNeverStarted(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, species)
+	p2Pokemon := domain.NewUserPokemon(player2.ID, species)
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	// Only the challenger selects, so the battle never starts
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 300, domain.FormatStandard)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error selecting Pokemon, got %v", err)
+	}
+
+	// Execute
+	abandoned, err := battleService.AbandonInactiveBattles(ctx, 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if abandoned != 1 {
+		t.Fatalf("Expected 1 abandoned battle, got %d", abandoned)
+	}
+	if battleRepo.Battles[battle.ID].Status != domain.BattleStatusAbandoned {
+		t.Errorf("Expected battle abandoned, got %s", battleRepo.Battles[battle.ID].Status)
+	}
+	if player1.Coins != domain.StartingCoins || player2.Coins != domain.StartingCoins {
+		t.Errorf("Expected both players to keep %d coins, got %d and %d",
+			domain.StartingCoins, player1.Coins, player2.Coins)
+	}
+
+	// Verify both players can battle again
+	if _, err := battleService.CreateBattle(ctx, player2.ID, player1.ID, 300, domain.FormatStandard); err != nil {
+		t.Errorf("Expected a new battle after the sweep, got %v", err)
+	}
+}
+
+func TestAbandonInactiveBattles_MissingRecordFreesPlayers(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, species)
+	p2Pokemon := domain.NewUserPokemon(player2.ID, species)
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	// Both players select, which locks the wager
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 300, domain.FormatStandard)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error selecting Pokemon, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player2.ID, p2Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error starting battle, got %v", err)
+	}
+	delete(battleRepo.Battles, battle.ID)
+
+	// Execute
+	abandoned, err := battleService.AbandonInactiveBattles(ctx, 0)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected the missing battle record reported")
+	}
+	if abandoned != 0 {
+		t.Errorf("Expected no battles counted as abandoned, got %d", abandoned)
+	}
+	if player1.Coins != domain.StartingCoins || player2.Coins != domain.StartingCoins {
+		t.Errorf("Expected both players refunded to %d, got %d and %d",
+			domain.StartingCoins, player1.Coins, player2.Coins)
+	}
+	for _, player := range []*domain.User{player1, player2} {
+		if _, err := battleService.GetPlayerBattle(player.ID); err != service.ErrBattleNotFound {
+			t.Errorf("Expected %s no longer in a battle, got %v", player.DiscordID, err)
+		}
+	}
+
+	// Verify both players can battle again
+	if _, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 300, domain.FormatStandard); err != nil {
+		t.Errorf("Expected a new battle after the sweep, got %v", err)
+	}
+}
+
+func TestRecoverEscrows_RefundsBattlesLostInCrash(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, species)
+	p2Pokemon := domain.NewUserPokemon(player2.ID, species)
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	// Both players select, which locks the wager
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 250, domain.FormatStandard)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error selecting Pokemon, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player2.ID, p2Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error starting battle, got %v", err)
+	}
+
+	// Simulate a restart: a fresh service has no in-memory battle state
+	restarted := service.NewBattleService(userRepo, mocks.NewMockUserPokemonRepository(), battleRepo, escrowRepo, txManager)
+
+	// Execute
+	recovered, err := restarted.RecoverEscrows(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if recovered != 1 {
+		t.Fatalf("Expected 1 recovered escrow, got %d", recovered)
+	}
+
+	if player1.Coins != domain.StartingCoins || player2.Coins != domain.StartingCoins {
+		t.Errorf("Expected both players refunded to %d, got %d and %d",
+			domain.StartingCoins, player1.Coins, player2.Coins)
+	}
+	if battleRepo.Battles[battle.ID].Status != domain.BattleStatusAbandoned {
+		t.Errorf("Expected battle abandoned, got %s", battleRepo.Battles[battle.ID].Status)
+	}
+
+	// Running recovery again finds nothing left to refund
+	recovered, _ = restarted.RecoverEscrows(ctx)
+	if recovered != 0 {
+		t.Errorf("Expected 0 recovered escrows on second run, got %d", recovered)
+	}
+}
+
+func TestRecoverEscrows_SkipsRunningBattles(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, species)
+	p2Pokemon := domain.NewUserPokemon(player2.ID, species)
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	// Both players select, which locks the wager
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 250, domain.FormatStandard)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error selecting Pokemon, got %v", err)
+	}
+	if err := battleService.SelectPokemon(ctx, battle.ID, player2.ID, p2Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error starting battle, got %v", err)
+	}
+
+	// Execute
+	recovered, err := battleService.RecoverEscrows(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if recovered != 0 {
+		t.Errorf("Expected running battle to be left alone, got %d recovered", recovered)
+	}
+}
@@ -0,0 +1,261 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+// addBoxPokemon gives the user a Pokemon with every IV set to iv, acquired
+// the given number of hours ago
+func addBoxPokemon(repo *mocks.MockUserPokemonRepository, user *domain.User, species *domain.PokemonSpecies, iv, hoursAgo int) *domain.UserPokemon {
+	p := domain.NewUserPokemon(user.ID, species)
+	p.IVs = domain.IVs{HP: iv, Attack: iv, Defense: iv, SpAttack: iv, SpDefense: iv, Speed: iv}
+	p.Nature = domain.Hardy
+	p.IsShiny = false
+	p.IsFavorite = false
+	p.Nickname = ""
+	p.AcquiredAt = time.Now().Add(-time.Duration(hoursAgo) * time.Hour)
+	repo.Create(context.Background(), p)
+	return p
+}
+
+func TestGetUserBox_Filters(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	gachaService := service.NewGachaService(userRepo, mocks.NewMockPokemonSpeciesRepository(), pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("boxer")
+	userRepo.Create(ctx, user)
+
+	pikachu := mocks.CreateTestSpecies(25, "Pikachu", domain.Rare)
+	pikachu.Type1 = domain.Electric
+	charizard := mocks.CreateTestSpecies(6, "Charizard", domain.Epic)
+	charizard.Type1 = domain.Fire
+	flying := domain.Flying
+	charizard.Type2 = &flying
+
+	sparky := addBoxPokemon(pokemonRepo, user, pikachu, 31, 1)
+	sparky.Nickname = "Sparky"
+	sparky.IsFavorite = true
+	plain := addBoxPokemon(pokemonRepo, user, pikachu, 10, 2)
+	plain.Nature = domain.Adamant
+	shiny := addBoxPokemon(pokemonRepo, user, charizard, 20, 3)
+	shiny.IsShiny = true
+
+	// Someone else's Pokemon never show up
+	other := mocks.CreateTestUser("other")
+	addBoxPokemon(pokemonRepo, other, pikachu, 31, 1)
+
+	yes, no := true, false
+	tests := []struct {
+		name   string
+		filter domain.BoxFilter
+		want   []*domain.UserPokemon
+	}{
+		{"everything", domain.BoxFilter{}, []*domain.UserPokemon{sparky, plain, shiny}},
+		{"rarity", domain.BoxFilter{Rarity: domain.Epic}, []*domain.UserPokemon{shiny}},
+		{"species id", domain.BoxFilter{SpeciesID: 25}, []*domain.UserPokemon{sparky, plain}},
+		{"species name", domain.BoxFilter{SpeciesName: "charizard"}, []*domain.UserPokemon{shiny}},
+		{"second type", domain.BoxFilter{Type: domain.Flying}, []*domain.UserPokemon{shiny}},
+		{"nature", domain.BoxFilter{Nature: domain.Adamant}, []*domain.UserPokemon{plain}},
+		{"min iv", domain.BoxFilter{MinIVPercent: 60}, []*domain.UserPokemon{sparky, shiny}},
+		{"favorite", domain.BoxFilter{Favorite: &yes}, []*domain.UserPokemon{sparky}},
+		{"not shiny", domain.BoxFilter{Shiny: &no}, []*domain.UserPokemon{sparky, plain}},
+		{"nickname", domain.BoxFilter{Nickname: "spar"}, []*domain.UserPokemon{sparky}},
+		{"combined", domain.BoxFilter{SpeciesID: 25, MinIVPercent: 90}, []*domain.UserPokemon{sparky}},
+		{"no match", domain.BoxFilter{Rarity: domain.Mythic}, nil},
+	}
+
+	// Execute and assert
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := gachaService.GetUserBox(ctx, user.ID, tt.filter)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if page.Total != len(tt.want) || len(page.Pokemon) != len(tt.want) {
+				t.Fatalf("Expected %d Pokemon, got %d of %d", len(tt.want), len(page.Pokemon), page.Total)
+			}
+			for i, p := range page.Pokemon {
+				if p.ID != tt.want[i].ID {
+					t.Errorf("Expected Pokemon %d to be %s, got %s", i, tt.want[i].ID, p.ID)
+				}
+			}
+			if page.NextCursor != nil {
+				t.Error("Expected no next cursor on a single page")
+			}
+		})
+	}
+}
+
+func TestGetUserBox_Sorts(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	gachaService := service.NewGachaService(userRepo, mocks.NewMockPokemonSpeciesRepository(), pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("boxer")
+	userRepo.Create(ctx, user)
+
+	common := addBoxPokemon(pokemonRepo, user, mocks.CreateTestSpecies(1, "Bulbasaur", domain.Common), 31, 1)
+	legendary := addBoxPokemon(pokemonRepo, user, mocks.CreateTestSpecies(150, "Mewtwo", domain.Legendary), 0, 3)
+	rare := addBoxPokemon(pokemonRepo, user, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare), 15, 2)
+
+	tests := []struct {
+		sort      domain.BoxSort
+		ascending bool
+		want      []*domain.UserPokemon
+	}{
+		{domain.BoxSortAcquired, false, []*domain.UserPokemon{common, rare, legendary}},
+		{domain.BoxSortAcquired, true, []*domain.UserPokemon{legendary, rare, common}},
+		{domain.BoxSortIV, false, []*domain.UserPokemon{common, rare, legendary}},
+		{domain.BoxSortValue, false, []*domain.UserPokemon{legendary, rare, common}},
+		{domain.BoxSortStats, true, []*domain.UserPokemon{legendary, rare, common}},
+	}
+
+	// Execute and assert
+	for _, tt := range tests {
+		page, err := gachaService.GetUserBox(ctx, user.ID, domain.BoxFilter{Sort: tt.sort, Ascending: tt.ascending})
+		if err != nil {
+			t.Fatalf("Expected no error sorting by %s, got %v", tt.sort, err)
+		}
+		for i, p := range page.Pokemon {
+			if p.ID != tt.want[i].ID {
+				t.Errorf("Sorting by %s (ascending %v): expected %s at %d, got %s", tt.sort, tt.ascending, tt.want[i].Species.Name, i, p.Species.Name)
+			}
+		}
+	}
+}
+
+func TestGetUserBox_CursorPaging(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	gachaService := service.NewGachaService(userRepo, mocks.NewMockPokemonSpeciesRepository(), pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("boxer")
+	userRepo.Create(ctx, user)
+
+	// Repeated IVs make ties the cursor has to break by ID
+	species := mocks.CreateTestSpecies(25, "Pikachu", domain.Rare)
+	for i := 0; i < 23; i++ {
+		addBoxPokemon(pokemonRepo, user, species, i%4, i)
+	}
+
+	// Execute and assert
+	for _, ascending := range []bool{false, true} {
+		filter := domain.BoxFilter{Sort: domain.BoxSortIV, Ascending: ascending, Limit: 5}
+		seen := make(map[uuid.UUID]bool)
+		pages := 0
+		var lastKey int64 = -1
+		for {
+			page, err := gachaService.GetUserBox(ctx, user.ID, filter)
+			if err != nil {
+				t.Fatalf("Expected no error on page %d, got %v", pages+1, err)
+			}
+			pages++
+			if page.Total != 23 {
+				t.Errorf("Expected a total of 23 on every page, got %d", page.Total)
+			}
+
+			for _, p := range page.Pokemon {
+				if seen[p.ID] {
+					t.Fatalf("Pokemon %s came up twice", p.ID)
+				}
+				seen[p.ID] = true
+
+				key := p.BoxSortKey(domain.BoxSortIV)
+				if lastKey >= 0 && ((ascending && key < lastKey) || (!ascending && key > lastKey)) {
+					t.Errorf("Pokemon out of order across pages: %d after %d", key, lastKey)
+				}
+				lastKey = key
+			}
+
+			if page.NextCursor == nil {
+				break
+			}
+			// Cursors survive a round trip through clients
+			cursor, err := domain.ParseBoxCursor(page.NextCursor.String())
+			if err != nil {
+				t.Fatalf("Expected the cursor to parse, got %v", err)
+			}
+			filter.Cursor = cursor
+		}
+
+		if pages != 5 || len(seen) != 23 {
+			t.Errorf("Expected 23 Pokemon over 5 pages, got %d over %d", len(seen), pages)
+		}
+	}
+}
+
+func TestGetUserBox_InvalidFilters(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	gachaService := service.NewGachaService(userRepo, mocks.NewMockPokemonSpeciesRepository(), mocks.NewMockUserPokemonRepository(), mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo))
+
+	user := mocks.CreateTestUser("boxer")
+	userRepo.Create(ctx, user)
+
+	ivCursor := &domain.BoxCursor{Sort: domain.BoxSortIV, Key: 100, ID: uuid.New()}
+	tests := []struct {
+		name   string
+		filter domain.BoxFilter
+		want   error
+	}{
+		{"rarity", domain.BoxFilter{Rarity: "shiny"}, validators.ErrInvalidRarity},
+		{"type", domain.BoxFilter{Type: "cosmic"}, validators.ErrInvalidType},
+		{"nature", domain.BoxFilter{Nature: "grumpy"}, validators.ErrInvalidNature},
+		{"iv", domain.BoxFilter{MinIVPercent: 101}, validators.ErrInvalidBoxIV},
+		{"sort", domain.BoxFilter{Sort: "height"}, validators.ErrInvalidBoxSort},
+		{"limit", domain.BoxFilter{Limit: domain.MaxBoxPageSize + 1}, validators.ErrInvalidBoxLimit},
+		{"cursor from another sort", domain.BoxFilter{Sort: domain.BoxSortValue, Cursor: ivCursor}, domain.ErrInvalidBoxCursor},
+		{"cursor against the default sort", domain.BoxFilter{Cursor: ivCursor}, domain.ErrInvalidBoxCursor},
+	}
+
+	// Execute and assert
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := gachaService.GetUserBox(ctx, user.ID, tt.filter); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	// Verify malformed cursors are rejected when parsed
+	for _, encoded := range []string{"not base64!", "aXY6MTAw", "aGVpZ2h0OjE6MDAwMDAwMDAtMDAwMC0wMDAwLTAwMDAtMDAwMDAwMDAwMDAw"} {
+		if _, err := domain.ParseBoxCursor(encoded); !errors.Is(err, domain.ErrInvalidBoxCursor) {
+			t.Errorf("Expected cursor %q to be rejected, got %v", encoded, err)
+		}
+	}
+}
+
+func TestBoxSortKey_MatchesPokemonMethods(t *testing.T) {
+	species := mocks.CreateTestSpecies(150, "Mewtwo", domain.Legendary)
+	for _, nature := range domain.AllNatures() {
+		p := domain.NewUserPokemon(uuid.New(), species)
+		p.Nature = nature
+
+		if got, want := p.BoxSortKey(domain.BoxSortIV), int64(p.IVs.TotalIVs()); got != want {
+			t.Errorf("Expected an IV key of %d, got %d", want, got)
+		}
+		if got, want := p.BoxSortKey(domain.BoxSortValue), int64(p.FormulaValue()); got != want {
+			t.Errorf("Expected a value key of %d, got %d", want, got)
+		}
+		if got, want := p.BoxSortKey(domain.BoxSortStats), int64(p.TotalStats()); got != want {
+			t.Errorf("Expected a stats key of %d, got %d", want, got)
+		}
+	}
+}
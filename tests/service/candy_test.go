@@ -0,0 +1,272 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+func TestCandy_RerollChangesOnlyChosenIVs(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	candyService := service.NewCandyService(pokemonRepo, candyRepo, auditRepo, listingRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(pokemonRepo, listingRepo, candyRepo, auditRepo))
+
+	user := mocks.CreateTestUser("trainer")
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+	pokemon.IVs = domain.IVs{HP: 20, Attack: 20, Defense: 20, SpAttack: 20, SpDefense: 20, Speed: 20}
+	pokemon.Nature = domain.Hardy
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 16, 100)
+
+	// Execute
+	updated, err := candyService.RerollIVs(ctx, user.ID, pokemon.ID, []string{"attack", "speed"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if updated.IVs.HP != 20 || updated.IVs.Defense != 20 || updated.IVs.SpAttack != 20 || updated.IVs.SpDefense != 20 {
+		t.Errorf("Expected untouched IVs to stay at 20, got %+v", updated.IVs)
+	}
+	if err := validators.ValidateIVs(updated.IVs); err != nil {
+		t.Errorf("Expected re-rolled IVs to be valid, got %v", err)
+	}
+
+	if got, _ := candyRepo.Get(ctx, user.ID, 16); got != 100-2*domain.CandyPerRerolledIV {
+		t.Errorf("Expected %d candy left, got %d", 100-2*domain.CandyPerRerolledIV, got)
+	}
+}
+
+func TestCandy_RaiseIVs(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	candyService := service.NewCandyService(pokemonRepo, candyRepo, auditRepo, listingRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(pokemonRepo, listingRepo, candyRepo, auditRepo))
+
+	user := mocks.CreateTestUser("trainer")
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+	pokemon.IVs = domain.IVs{HP: 20, Attack: 20, Defense: 20, SpAttack: 20, SpDefense: 20, Speed: 20}
+	pokemon.Nature = domain.Hardy
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 16, 100)
+
+	// Execute
+	updated, err := candyService.RaiseIVs(ctx, user.ID, pokemon.ID, []string{"hp"}, 11)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if updated.IVs.HP != domain.MaxIV {
+		t.Errorf("Expected HP IV raised to %d, got %d", domain.MaxIV, updated.IVs.HP)
+	}
+	if got, _ := candyRepo.Get(ctx, user.ID, 16); got != 100-11*domain.CandyPerIVPoint {
+		t.Errorf("Expected %d candy left, got %d", 100-11*domain.CandyPerIVPoint, got)
+	}
+}
+
+func TestCandy_RaisePastMaximumIsRejected(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	candyService := service.NewCandyService(pokemonRepo, candyRepo, auditRepo, listingRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(pokemonRepo, listingRepo, candyRepo, auditRepo))
+
+	user := mocks.CreateTestUser("trainer")
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+	pokemon.IVs = domain.IVs{HP: 20, Attack: 20, Defense: 20, SpAttack: 20, SpDefense: 20, Speed: 20}
+	pokemon.Nature = domain.Hardy
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 16, 100)
+
+	// Execute
+	_, err := candyService.RaiseIVs(ctx, user.ID, pokemon.ID, []string{"hp", "attack"}, 12)
+
+	// Assert
+	if !errors.Is(err, validators.ErrInvalidIV) {
+		t.Fatalf("Expected ErrInvalidIV, got %v", err)
+	}
+
+	stored, _ := pokemonRepo.GetByID(ctx, pokemon.ID)
+	if stored.IVs.HP != 20 || stored.IVs.Attack != 20 {
+		t.Errorf("Expected IVs unchanged, got %+v", stored.IVs)
+	}
+	if got, _ := candyRepo.Get(ctx, user.ID, 16); got != 100 {
+		t.Errorf("Expected no candy spent, got %d left", got)
+	}
+	if len(auditRepo.Entries) != 0 {
+		t.Errorf("Expected nothing logged, got %d entries", len(auditRepo.Entries))
+	}
+}
+
+func TestCandy_MintNatureIsAudited(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	candyService := service.NewCandyService(pokemonRepo, candyRepo, auditRepo, listingRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(pokemonRepo, listingRepo, candyRepo, auditRepo))
+
+	user := mocks.CreateTestUser("trainer")
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+	pokemon.IVs = domain.IVs{HP: 20, Attack: 20, Defense: 20, SpAttack: 20, SpDefense: 20, Speed: 20}
+	pokemon.Nature = domain.Hardy
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 16, domain.CandyPerMint)
+
+	// Execute
+	updated, err := candyService.MintNature(ctx, user.ID, pokemon.ID, domain.Adamant)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Nature != domain.Adamant {
+		t.Errorf("Expected adamant nature, got %s", updated.Nature)
+	}
+
+	// Verify the mint was audited
+	history, err := candyService.GetHistory(ctx, pokemon.ID, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("Expected one audit entry, got %d", len(history))
+	}
+
+	entry := history[0]
+	if entry.Action != domain.AuditNatureMint || entry.CandyDelta != -domain.CandyPerMint {
+		t.Errorf("Expected a mint costing %d candy, got %s costing %d", domain.CandyPerMint, entry.Action, -entry.CandyDelta)
+	}
+	if entry.Before.Nature != domain.Hardy || entry.After.Nature != domain.Adamant {
+		t.Errorf("Expected hardy -> adamant, got %s -> %s", entry.Before.Nature, entry.After.Nature)
+	}
+
+	// Verify minting the same nature again is rejected
+	if _, err := candyService.MintNature(ctx, user.ID, pokemon.ID, domain.Adamant); !errors.Is(err, service.ErrSameNature) {
+		t.Errorf("Expected ErrSameNature, got %v", err)
+	}
+}
+
+func TestCandy_InsufficientCandy(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	candyService := service.NewCandyService(pokemonRepo, candyRepo, auditRepo, listingRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(pokemonRepo, listingRepo, candyRepo, auditRepo))
+
+	user := mocks.CreateTestUser("trainer")
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+	pokemon.IVs = domain.IVs{HP: 20, Attack: 20, Defense: 20, SpAttack: 20, SpDefense: 20, Speed: 20}
+	pokemon.Nature = domain.Hardy
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 16, domain.CandyPerRerolledIV-1)
+
+	// Execute
+	_, err := candyService.RerollIVs(ctx, user.ID, pokemon.ID, []string{"hp"})
+
+	// Assert
+	if !errors.Is(err, service.ErrInsufficientCandy) {
+		t.Fatalf("Expected ErrInsufficientCandy, got %v", err)
+	}
+
+	stored, _ := pokemonRepo.GetByID(ctx, pokemon.ID)
+	if stored.IVs.HP != 20 {
+		t.Errorf("Expected HP IV unchanged, got %d", stored.IVs.HP)
+	}
+	if got, _ := candyRepo.Get(ctx, user.ID, 16); got != domain.CandyPerRerolledIV-1 {
+		t.Errorf("Expected candy unchanged, got %d", got)
+	}
+}
+
+func TestCandy_RejectsLockedAndUnownedPokemon(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	candyService := service.NewCandyService(pokemonRepo, candyRepo, auditRepo, listingRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(pokemonRepo, listingRepo, candyRepo, auditRepo))
+
+	user := mocks.CreateTestUser("trainer")
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+	pokemon.IVs = domain.IVs{HP: 20, Attack: 20, Defense: 20, SpAttack: 20, SpDefense: 20, Speed: 20}
+	pokemon.Nature = domain.Hardy
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 16, 100)
+
+	// Execute and assert
+	if _, err := candyService.MintNature(ctx, uuid.New(), pokemon.ID, domain.Timid); !errors.Is(err, service.ErrNotPokemonOwner) {
+		t.Errorf("Expected ErrNotPokemonOwner, got %v", err)
+	}
+
+	listingRepo.Create(ctx, domain.NewMarketListing(pokemon, 100))
+	if _, err := candyService.RaiseIVs(ctx, user.ID, pokemon.ID, []string{"hp"}, 1); !errors.Is(err, service.ErrPokemonLocked) {
+		t.Errorf("Expected ErrPokemonLocked for a listed Pokemon, got %v", err)
+	}
+
+	// Verify nothing was spent
+	if got, _ := candyRepo.Get(ctx, user.ID, 16); got != 100 {
+		t.Errorf("Expected no candy spent, got %d left", got)
+	}
+}
+
+func TestCandy_ValidatesStats(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	candyService := service.NewCandyService(pokemonRepo, candyRepo, auditRepo, listingRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(pokemonRepo, listingRepo, candyRepo, auditRepo))
+
+	user := mocks.CreateTestUser("trainer")
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+	pokemon.IVs = domain.IVs{HP: 20, Attack: 20, Defense: 20, SpAttack: 20, SpDefense: 20, Speed: 20}
+	pokemon.Nature = domain.Hardy
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 16, 100)
+
+	// Execute and assert
+	tests := []struct {
+		name  string
+		stats []string
+		want  error
+	}{
+		{"none", nil, validators.ErrNoIVStats},
+		{"unknown", []string{"luck"}, validators.ErrUnknownIVStat},
+		{"duplicate", []string{"hp", "hp"}, validators.ErrDuplicateIVStat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := candyService.RerollIVs(ctx, user.ID, pokemon.ID, tt.stats); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if _, err := candyService.RaiseIVs(ctx, user.ID, pokemon.ID, []string{"hp"}, 0); !errors.Is(err, validators.ErrInvalidIVPoints) {
+		t.Errorf("Expected ErrInvalidIVPoints, got %v", err)
+	}
+}
@@ -0,0 +1,535 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+func TestEvolution_KeepsTraitsAndRecomputesStats(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	evolutionRepo := mocks.NewMockEvolutionRepository()
+	learnsetRepo := mocks.NewMockLearnsetRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, learnsetRepo, candyRepo, itemRepo, auditRepo)
+	evolutionService := service.NewEvolutionService(userRepo, pokemonRepo, evolutionRepo, learnsetRepo, candyRepo, itemRepo,
+		auditRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	// A nicknamed Charmander that evolves into a stronger Charmeleon for 25 candy and 100 coins
+	charmander := mocks.CreateTestSpecies(4, "Charmander", domain.Common)
+	charmeleon := mocks.CreateTestSpecies(5, "Charmeleon", domain.Uncommon)
+	charmeleon.BaseAttack, charmeleon.BaseSpAttack = 150, 150
+	evolutionRepo.Evolutions[4] = []*domain.Evolution{
+		{FromSpeciesID: 4, ToSpeciesID: 5, ToSpecies: charmeleon, CandyCost: 25, CoinCost: 100},
+	}
+	learnsetRepo.Learnsets[4] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 2, Name: "Scratch"}}
+	learnsetRepo.Learnsets[5] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 3, Name: "Flamethrower"}}
+
+	user := mocks.CreateTestUser("trainer")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+
+	pokemon := domain.NewUserPokemon(user.ID, charmander)
+	pokemon.Nickname = "Blaze"
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 4, 30)
+
+	ivs, nature := pokemon.IVs, pokemon.Nature
+	statsBefore := pokemon.GetStats()
+
+	// Execute
+	result, err := evolutionService.Evolve(ctx, user.ID, pokemon.ID, 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	evolved := result.Pokemon
+	if evolved.SpeciesID != 5 || evolved.Species.Name != "Charmeleon" {
+		t.Errorf("Expected a Charmeleon, got species %d", evolved.SpeciesID)
+	}
+	if result.FromSpecies.Name != "Charmander" {
+		t.Errorf("Expected to evolve from Charmander, got %s", result.FromSpecies.Name)
+	}
+	if evolved.IVs != ivs || evolved.Nature != nature || evolved.Nickname != "Blaze" {
+		t.Errorf("Expected IVs, nature and nickname kept, got %+v %s %q", evolved.IVs, evolved.Nature, evolved.Nickname)
+	}
+	if stats := evolved.GetStats(); stats.Attack <= statsBefore.Attack || stats.SpAttack <= statsBefore.SpAttack {
+		t.Errorf("Expected stats recomputed from the new base stats, got %+v (was %+v)", stats, statsBefore)
+	}
+
+	// Verify the evolution was saved and paid for
+	stored, _ := pokemonRepo.GetByID(ctx, pokemon.ID)
+	if stored.SpeciesID != 5 {
+		t.Errorf("Expected the new species to be saved, got %d", stored.SpeciesID)
+	}
+
+	candy, _ := candyRepo.Get(ctx, user.ID, 4)
+	if candy != 5 {
+		t.Errorf("Expected 5 candy left, got %d", candy)
+	}
+	if user.Coins != 900 {
+		t.Errorf("Expected 900 coins left, got %d", user.Coins)
+	}
+}
+
+func TestEvolution_UnlocksLearnsetMoves(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	evolutionRepo := mocks.NewMockEvolutionRepository()
+	learnsetRepo := mocks.NewMockLearnsetRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, learnsetRepo, candyRepo, itemRepo, auditRepo)
+	evolutionService := service.NewEvolutionService(userRepo, pokemonRepo, evolutionRepo, learnsetRepo, candyRepo, itemRepo,
+		auditRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	// A nicknamed Charmander that evolves into a stronger Charmeleon for 25 candy and 100 coins
+	charmander := mocks.CreateTestSpecies(4, "Charmander", domain.Common)
+	charmeleon := mocks.CreateTestSpecies(5, "Charmeleon", domain.Uncommon)
+	charmeleon.BaseAttack, charmeleon.BaseSpAttack = 150, 150
+	evolutionRepo.Evolutions[4] = []*domain.Evolution{
+		{FromSpeciesID: 4, ToSpeciesID: 5, ToSpecies: charmeleon, CandyCost: 25, CoinCost: 100},
+	}
+	learnsetRepo.Learnsets[4] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 2, Name: "Scratch"}}
+	learnsetRepo.Learnsets[5] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 3, Name: "Flamethrower"}}
+
+	user := mocks.CreateTestUser("trainer")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+
+	pokemon := domain.NewUserPokemon(user.ID, charmander)
+	pokemon.Nickname = "Blaze"
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 4, 30)
+
+	// Execute
+	result, err := evolutionService.Evolve(ctx, user.ID, pokemon.ID, 5)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.UnlockedMoves) != 1 || result.UnlockedMoves[0].Name != "Flamethrower" {
+		t.Errorf("Expected only Flamethrower newly unlocked, got %v", result.UnlockedMoves)
+	}
+
+	// Verify the new moves can be listed
+	moves, err := evolutionService.ListMoves(ctx, pokemon.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	names := make([]string, len(moves))
+	for i, move := range moves {
+		names[i] = move.Name
+	}
+	if len(names) != 3 || names[0] != "Ember" || names[1] != "Flamethrower" || names[2] != "Scratch" {
+		t.Errorf("Expected Ember, Flamethrower and Scratch, got %v", names)
+	}
+}
+
+func TestEvolution_IsAudited(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	evolutionRepo := mocks.NewMockEvolutionRepository()
+	learnsetRepo := mocks.NewMockLearnsetRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, learnsetRepo, candyRepo, itemRepo, auditRepo)
+	evolutionService := service.NewEvolutionService(userRepo, pokemonRepo, evolutionRepo, learnsetRepo, candyRepo, itemRepo,
+		auditRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	// A nicknamed Charmander that evolves into a stronger Charmeleon for 25 candy and 100 coins
+	charmander := mocks.CreateTestSpecies(4, "Charmander", domain.Common)
+	charmeleon := mocks.CreateTestSpecies(5, "Charmeleon", domain.Uncommon)
+	charmeleon.BaseAttack, charmeleon.BaseSpAttack = 150, 150
+	evolutionRepo.Evolutions[4] = []*domain.Evolution{
+		{FromSpeciesID: 4, ToSpeciesID: 5, ToSpecies: charmeleon, CandyCost: 25, CoinCost: 100},
+	}
+	learnsetRepo.Learnsets[4] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 2, Name: "Scratch"}}
+	learnsetRepo.Learnsets[5] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 3, Name: "Flamethrower"}}
+
+	user := mocks.CreateTestUser("trainer")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+
+	pokemon := domain.NewUserPokemon(user.ID, charmander)
+	pokemon.Nickname = "Blaze"
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 4, 30)
+
+	// Execute
+	_, err := evolutionService.Evolve(ctx, user.ID, pokemon.ID, 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(auditRepo.Entries) != 1 {
+		t.Fatalf("Expected one audit entry, got %d", len(auditRepo.Entries))
+	}
+
+	entry := auditRepo.Entries[0]
+	if entry.Action != domain.AuditEvolve || entry.CandyDelta != -25 {
+		t.Errorf("Expected an evolution costing 25 candy, got %s costing %d", entry.Action, -entry.CandyDelta)
+	}
+	if entry.Before.SpeciesID != 4 || entry.After.SpeciesID != 5 {
+		t.Errorf("Expected species 4 -> 5, got %d -> %d", entry.Before.SpeciesID, entry.After.SpeciesID)
+	}
+}
+
+func TestEvolution_UnmetRequirements(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	evolutionRepo := mocks.NewMockEvolutionRepository()
+	learnsetRepo := mocks.NewMockLearnsetRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, learnsetRepo, candyRepo, itemRepo, auditRepo)
+	evolutionService := service.NewEvolutionService(userRepo, pokemonRepo, evolutionRepo, learnsetRepo, candyRepo, itemRepo,
+		auditRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	// A nicknamed Charmander that evolves into a stronger Charmeleon for 25 candy and 100 coins
+	charmander := mocks.CreateTestSpecies(4, "Charmander", domain.Common)
+	charmeleon := mocks.CreateTestSpecies(5, "Charmeleon", domain.Uncommon)
+	charmeleon.BaseAttack, charmeleon.BaseSpAttack = 150, 150
+	evolutionRepo.Evolutions[4] = []*domain.Evolution{
+		{FromSpeciesID: 4, ToSpeciesID: 5, ToSpecies: charmeleon, CandyCost: 25, CoinCost: 100},
+	}
+	learnsetRepo.Learnsets[4] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 2, Name: "Scratch"}}
+	learnsetRepo.Learnsets[5] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 3, Name: "Flamethrower"}}
+
+	user := mocks.CreateTestUser("trainer")
+	user.Coins = 50
+	userRepo.Create(ctx, user)
+
+	pokemon := domain.NewUserPokemon(user.ID, charmander)
+	pokemon.Nickname = "Blaze"
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 4, 30)
+
+	// Execute
+	_, err := evolutionService.Evolve(ctx, user.ID, pokemon.ID, 0)
+
+	// Assert
+	if !errors.Is(err, service.ErrEvolutionRequirements) {
+		t.Fatalf("Expected ErrEvolutionRequirements, got %v", err)
+	}
+
+	stored, _ := pokemonRepo.GetByID(ctx, pokemon.ID)
+	if stored.SpeciesID != 4 {
+		t.Errorf("Expected the Pokemon not to evolve, got species %d", stored.SpeciesID)
+	}
+	if candy, _ := candyRepo.Get(ctx, user.ID, 4); candy != 30 {
+		t.Errorf("Expected no candy spent, got %d left", candy)
+	}
+
+	// Verify the missing requirement is reported
+	options, err := evolutionService.ListEvolutions(ctx, user.ID, pokemon.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(options) != 1 || options[0].CanEvolve() {
+		t.Fatalf("Expected one option that cannot evolve, got %+v", options)
+	}
+	if unmet := options[0].Unmet; len(unmet) != 1 || unmet[0] != domain.RequireCoins {
+		t.Errorf("Expected only coins missing, got %v", unmet)
+	}
+}
+
+func TestEvolution_RequiresItemAndBattleWins(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	evolutionRepo := mocks.NewMockEvolutionRepository()
+	learnsetRepo := mocks.NewMockLearnsetRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, learnsetRepo, candyRepo, itemRepo, auditRepo)
+	evolutionService := service.NewEvolutionService(userRepo, pokemonRepo, evolutionRepo, learnsetRepo, candyRepo, itemRepo,
+		auditRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), battleRepo, txManager)
+
+	// A Charmander that evolves with a fire stone after two battle wins
+	charmander := mocks.CreateTestSpecies(4, "Charmander", domain.Common)
+	charmeleon := mocks.CreateTestSpecies(5, "Charmeleon", domain.Uncommon)
+	charmeleon.BaseAttack, charmeleon.BaseSpAttack = 150, 150
+	evolutionRepo.Evolutions[4] = []*domain.Evolution{
+		{FromSpeciesID: 4, ToSpeciesID: 5, ToSpecies: charmeleon, Item: "fire-stone", BattleWins: 2},
+	}
+	learnsetRepo.Learnsets[4] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 2, Name: "Scratch"}}
+	learnsetRepo.Learnsets[5] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 3, Name: "Flamethrower"}}
+
+	user := mocks.CreateTestUser("trainer")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+
+	pokemon := domain.NewUserPokemon(user.ID, charmander)
+	pokemon.Nickname = "Blaze"
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 4, 30)
+
+	// Execute and assert
+	if _, err := evolutionService.Evolve(ctx, user.ID, pokemon.ID, 0); !errors.Is(err, service.ErrEvolutionRequirements) {
+		t.Fatalf("Expected ErrEvolutionRequirements, got %v", err)
+	}
+
+	// Verify it evolves once both are met
+	itemRepo.Adjust(ctx, user.ID, "fire-stone", 1)
+	for i := 0; i < 2; i++ {
+		opponent := uuid.New()
+		battle := &domain.Battle{
+			ID:             uuid.New(),
+			Player1ID:      opponent,
+			Player2ID:      user.ID,
+			Player2Pokemon: pokemon.ID,
+			Status:         domain.BattleStatusCompleted,
+			WinnerID:       &user.ID,
+		}
+		battleRepo.Create(ctx, battle)
+	}
+
+	if _, err := evolutionService.Evolve(ctx, user.ID, pokemon.ID, 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stones, _ := itemRepo.Get(ctx, user.ID, "fire-stone"); stones != 0 {
+		t.Errorf("Expected the fire stone to be used, got %d left", stones)
+	}
+}
+
+func TestEvolution_WildBattleWinsCount(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	mocks.SeedAllRarities(speciesRepo)
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	evolutionRepo := mocks.NewMockEvolutionRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	txManager := mocks.NewMockTxManager(pokemonRepo, battleRepo)
+
+	evolutionService := service.NewEvolutionService(userRepo, pokemonRepo, evolutionRepo, mocks.NewMockLearnsetRepository(), mocks.NewMockCandyRepository(),
+		mocks.NewMockItemRepository(), mocks.NewMockPokemonAuditRepository(), listingRepo, tradeRepo, battleRepo, txManager)
+	wildBattleService := service.NewWildBattleService(speciesRepo, pokemonRepo, listingRepo, tradeRepo, battleRepo, txManager)
+
+	evolutionRepo.Evolutions[4] = []*domain.Evolution{
+		{FromSpeciesID: 4, ToSpeciesID: 5, ToSpecies: mocks.CreateTestSpecies(5, "Charmeleon", domain.Uncommon), BattleWins: 1},
+	}
+
+	user := mocks.CreateTestUser("trainer")
+	userRepo.Create(ctx, user)
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(4, "Charmander", domain.Common))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// Execute: battle wild Pokemon until one is beaten
+	losses := 0
+	for won := false; !won; {
+		result, err := wildBattleService.Battle(ctx, user.ID, pokemon.ID, "")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if won = result.Won; !won {
+			losses++
+		}
+		if losses > 100 {
+			t.Fatal("Expected a wild battle to be won within 100 tries")
+		}
+	}
+	options, err := evolutionService.ListEvolutions(ctx, user.ID, pokemon.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(options) != 1 || options[0].Progress.BattleWins != 1 || !options[0].CanEvolve() {
+		t.Fatalf("Expected the wild win to count toward evolving, got %+v", options[0])
+	}
+	if len(battleRepo.Battles) != losses+1 {
+		t.Errorf("Expected every wild battle stored, got %d of %d", len(battleRepo.Battles), losses+1)
+	}
+}
+
+func TestEvolution_BranchingRequiresChoice(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	evolutionRepo := mocks.NewMockEvolutionRepository()
+	learnsetRepo := mocks.NewMockLearnsetRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, learnsetRepo, candyRepo, itemRepo, auditRepo)
+	evolutionService := service.NewEvolutionService(userRepo, pokemonRepo, evolutionRepo, learnsetRepo, candyRepo, itemRepo,
+		auditRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	// A Charmander that can evolve into either a Charmeleon or a Charizard
+	charmander := mocks.CreateTestSpecies(4, "Charmander", domain.Common)
+	charmeleon := mocks.CreateTestSpecies(5, "Charmeleon", domain.Uncommon)
+	charmeleon.BaseAttack, charmeleon.BaseSpAttack = 150, 150
+	evolutionRepo.Evolutions[4] = []*domain.Evolution{
+		{FromSpeciesID: 4, ToSpeciesID: 5, ToSpecies: charmeleon, CandyCost: 25, CoinCost: 100},
+		{FromSpeciesID: 4, ToSpeciesID: 6, ToSpecies: mocks.CreateTestSpecies(6, "Charizard", domain.Rare), CandyCost: 25},
+	}
+	learnsetRepo.Learnsets[4] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 2, Name: "Scratch"}}
+	learnsetRepo.Learnsets[5] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 3, Name: "Flamethrower"}}
+
+	user := mocks.CreateTestUser("trainer")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+
+	pokemon := domain.NewUserPokemon(user.ID, charmander)
+	pokemon.Nickname = "Blaze"
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 4, 30)
+
+	// Execute and assert
+	if _, err := evolutionService.Evolve(ctx, user.ID, pokemon.ID, 0); !errors.Is(err, service.ErrEvolutionChoice) {
+		t.Errorf("Expected ErrEvolutionChoice, got %v", err)
+	}
+	if _, err := evolutionService.Evolve(ctx, user.ID, pokemon.ID, 99); !errors.Is(err, service.ErrNoEvolution) {
+		t.Errorf("Expected ErrNoEvolution, got %v", err)
+	}
+
+	// Verify a valid choice evolves
+	result, err := evolutionService.Evolve(ctx, user.ID, pokemon.ID, 6)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Pokemon.SpeciesID != 6 {
+		t.Errorf("Expected the chosen species, got %d", result.Pokemon.SpeciesID)
+	}
+}
+
+func TestEvolution_RejectsLockedAndUnownedPokemon(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	evolutionRepo := mocks.NewMockEvolutionRepository()
+	learnsetRepo := mocks.NewMockLearnsetRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, learnsetRepo, candyRepo, itemRepo, auditRepo)
+	evolutionService := service.NewEvolutionService(userRepo, pokemonRepo, evolutionRepo, learnsetRepo, candyRepo, itemRepo,
+		auditRepo, listingRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	// A nicknamed Charmander that evolves into a stronger Charmeleon for 25 candy and 100 coins
+	charmander := mocks.CreateTestSpecies(4, "Charmander", domain.Common)
+	charmeleon := mocks.CreateTestSpecies(5, "Charmeleon", domain.Uncommon)
+	charmeleon.BaseAttack, charmeleon.BaseSpAttack = 150, 150
+	evolutionRepo.Evolutions[4] = []*domain.Evolution{
+		{FromSpeciesID: 4, ToSpeciesID: 5, ToSpecies: charmeleon, CandyCost: 25, CoinCost: 100},
+	}
+	learnsetRepo.Learnsets[4] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 2, Name: "Scratch"}}
+	learnsetRepo.Learnsets[5] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 3, Name: "Flamethrower"}}
+
+	user := mocks.CreateTestUser("trainer")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+
+	pokemon := domain.NewUserPokemon(user.ID, charmander)
+	pokemon.Nickname = "Blaze"
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 4, 30)
+
+	// Execute and assert
+	if _, err := evolutionService.Evolve(ctx, uuid.New(), pokemon.ID, 0); !errors.Is(err, service.ErrNotPokemonOwner) {
+		t.Errorf("Expected ErrNotPokemonOwner, got %v", err)
+	}
+
+	listingRepo.Create(ctx, domain.NewMarketListing(pokemon, 100))
+	if _, err := evolutionService.Evolve(ctx, user.ID, pokemon.ID, 0); !errors.Is(err, service.ErrPokemonLocked) {
+		t.Errorf("Expected ErrPokemonLocked for a listed Pokemon, got %v", err)
+	}
+
+	// Verify nothing was spent
+	if candy, _ := candyRepo.Get(ctx, user.ID, 4); candy != 30 {
+		t.Errorf("Expected no candy spent, got %d left", candy)
+	}
+}
+
+func TestEvolution_RollsBackOnFailure(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	evolutionRepo := mocks.NewMockEvolutionRepository()
+	learnsetRepo := mocks.NewMockLearnsetRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, learnsetRepo, candyRepo, itemRepo, auditRepo)
+	evolutionService := service.NewEvolutionService(userRepo, pokemonRepo, evolutionRepo, learnsetRepo, candyRepo, itemRepo,
+		auditRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	// A nicknamed Charmander that evolves into a stronger Charmeleon for 25 candy and 100 coins
+	charmander := mocks.CreateTestSpecies(4, "Charmander", domain.Common)
+	charmeleon := mocks.CreateTestSpecies(5, "Charmeleon", domain.Uncommon)
+	charmeleon.BaseAttack, charmeleon.BaseSpAttack = 150, 150
+	evolutionRepo.Evolutions[4] = []*domain.Evolution{
+		{FromSpeciesID: 4, ToSpeciesID: 5, ToSpecies: charmeleon, CandyCost: 25, CoinCost: 100},
+	}
+	learnsetRepo.Learnsets[4] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 2, Name: "Scratch"}}
+	learnsetRepo.Learnsets[5] = []*domain.Move{{ID: 1, Name: "Ember"}, {ID: 3, Name: "Flamethrower"}}
+
+	user := mocks.CreateTestUser("trainer")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+
+	pokemon := domain.NewUserPokemon(user.ID, charmander)
+	pokemon.Nickname = "Blaze"
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 4, 30)
+
+	pokemonRepo.UpdateError = errors.New("database unavailable")
+
+	// Execute
+	_, err := evolutionService.Evolve(ctx, user.ID, pokemon.ID, 0)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if txManager.Rollbacks != 1 {
+		t.Errorf("Expected one rollback, got %d", txManager.Rollbacks)
+	}
+	if candy, _ := candyRepo.Get(ctx, user.ID, 4); candy != 30 {
+		t.Errorf("Expected candy restored, got %d", candy)
+	}
+	if user.Coins != 1000 {
+		t.Errorf("Expected coins restored, got %d", user.Coins)
+	}
+	if pokemon.SpeciesID != 4 {
+		t.Errorf("Expected the Pokemon to stay a Charmander, got species %d", pokemon.SpeciesID)
+	}
+}
@@ -0,0 +1,354 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestGrowthRate_CurvesMatchAtLevel100(t *testing.T) {
+	tests := []struct {
+		rate     domain.GrowthRate
+		expected int
+	}{
+		{domain.GrowthFast, 800000},
+		{domain.GrowthMediumFast, 1000000},
+		{domain.GrowthMediumSlow, 1059860},
+		{domain.GrowthSlow, 1250000},
+	}
+
+	for _, tt := range tests {
+		if got := tt.rate.ExperienceForLevel(domain.MaxLevel); got != tt.expected {
+			t.Errorf("Expected %s to need %d experience for level 100, got %d", tt.rate, tt.expected, got)
+		}
+		if got := tt.rate.LevelForExperience(tt.expected); got != domain.MaxLevel {
+			t.Errorf("Expected %s to reach level 100, got %d", tt.rate, got)
+		}
+	}
+}
+
+func TestGainExperience_LevelsUpAndRecomputesStats(t *testing.T) {
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	pokemon := domain.NewUserPokemon(mocks.CreateTestUser("trainer").ID, species)
+	before := pokemon.GetStats()
+
+	// Medium fast needs 51^3 = 132651 for level 51 and 52^3 = 140608 for 52
+	levels := pokemon.GainExperience(140608 - pokemon.Experience)
+
+	if levels != 2 || pokemon.Level != 52 {
+		t.Fatalf("Expected two level-ups to 52, got %d levels to %d", levels, pokemon.Level)
+	}
+	if after := pokemon.GetStats(); after.Attack <= before.Attack || after.HP <= before.HP {
+		t.Errorf("Expected stats to grow with level, got %+v then %+v", before, after)
+	}
+}
+
+func TestGainExperience_StopsAtMaxLevel(t *testing.T) {
+	pokemon := domain.NewUserPokemon(mocks.CreateTestUser("trainer").ID, mocks.CreateTestSpecies(1, "TestMon", domain.Common))
+
+	pokemon.GainExperience(5000000)
+
+	if pokemon.Level != domain.MaxLevel {
+		t.Errorf("Expected level %d, got %d", domain.MaxLevel, pokemon.Level)
+	}
+	if pokemon.Experience != 1000000 {
+		t.Errorf("Expected experience to stop at 1000000, got %d", pokemon.Experience)
+	}
+	if pokemon.ExperienceToNextLevel() != 0 {
+		t.Errorf("Expected nothing left to earn, got %d", pokemon.ExperienceToNextLevel())
+	}
+}
+
+func TestBattleFormat_EffectiveLevel(t *testing.T) {
+	tests := []struct {
+		format   string
+		level    int
+		expected int
+	}{
+		{domain.FormatStandard, 73, 73},
+		{domain.FormatRanked, 73, 50},
+		{domain.FormatRanked, 12, 50},
+		{domain.FormatLittleCup, 73, 5},
+		{domain.FormatLittleCup, 3, 3},
+		{"", 73, 73},
+	}
+
+	for _, tt := range tests {
+		format, exists := domain.GetBattleFormat(tt.format)
+		if !exists {
+			t.Fatalf("Expected format %q to exist", tt.format)
+		}
+		if got := format.EffectiveLevel(tt.level); got != tt.expected {
+			t.Errorf("Expected level %d in %q to battle at %d, got %d", tt.level, tt.format, tt.expected, got)
+		}
+	}
+}
+
+func TestForfeitBattle_AwardsExperienceToBothPokemon(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, species)
+	p2Pokemon := domain.NewUserPokemon(player2.ID, species)
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 100, domain.FormatStandard)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+	battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID)
+	if err := battleService.SelectPokemon(ctx, battle.ID, player2.ID, p2Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error starting battle, got %v", err)
+	}
+
+	// Execute
+	err = battleService.ForfeitBattle(ctx, battle.ID, player1.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	loser, _ := pokemonRepo.GetByID(ctx, p1Pokemon.ID)
+	winner, _ := pokemonRepo.GetByID(ctx, p2Pokemon.ID)
+	start := domain.GrowthMediumFast.ExperienceForLevel(domain.DefaultLevel)
+
+	// Both fought a level 50 TestMon with a yield of 64
+	if got := winner.Experience - start; got != 64*50/7 {
+		t.Errorf("Expected winner to earn %d experience, got %d", 64*50/7, got)
+	}
+	if got := loser.Experience - start; got != 64*50/7/domain.LoserExperienceDivisor {
+		t.Errorf("Expected loser to earn %d experience, got %d", 64*50/7/domain.LoserExperienceDivisor, got)
+	}
+}
+
+func TestForfeitBattle_FailedResultAwardsNoExperience(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, species)
+	p2Pokemon := domain.NewUserPokemon(player2.ID, species)
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 100, domain.FormatStandard)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+	battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID)
+	if err := battleService.SelectPokemon(ctx, battle.ID, player2.ID, p2Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error starting battle, got %v", err)
+	}
+	start := domain.GrowthMediumFast.ExperienceForLevel(domain.DefaultLevel)
+	battleRepo.UpdateError = errors.New("database unavailable")
+
+	// Execute
+	err = battleService.ForfeitBattle(ctx, battle.ID, player1.ID)
+
+	// Assert
+	if err == nil {
+		t.Fatalf("Expected error when battle update fails")
+	}
+	if winner, _ := pokemonRepo.GetByID(ctx, p2Pokemon.ID); winner.Experience != start {
+		t.Errorf("Expected experience to be rolled back to %d, got %d", start, winner.Experience)
+	}
+}
+
+func TestCreateBattle_UnknownFormat(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	// Execute
+	_, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 100, "no_such_format")
+
+	// Assert
+	if !errors.Is(err, service.ErrUnknownFormat) {
+		t.Fatalf("Expected ErrUnknownFormat, got %v", err)
+	}
+}
+
+func TestStartBattle_RankedFlattensLevels(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+
+	species := mocks.CreateTestSpecies(1, "TestMon", domain.Common)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, species)
+	p1Pokemon.Level = 80
+	p2Pokemon := domain.NewUserPokemon(player2.ID, species)
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 100, domain.FormatRanked)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+	battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID)
+
+	// Execute
+	err = battleService.SelectPokemon(ctx, battle.ID, player2.ID, p2Pokemon.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error starting battle, got %v", err)
+	}
+
+	state, err := battleService.GetBattleState(battle.ID)
+	if err != nil {
+		t.Fatalf("Expected battle state, got %v", err)
+	}
+	if state.Player1.Pokemon.Level != 50 {
+		t.Errorf("Expected level 80 Pokemon to battle at 50, got %d", state.Player1.Pokemon.Level)
+	}
+	if expected := pokemonRepo.Pokemons[p1Pokemon.ID].AtLevel(50).GetStats().HP; state.Player1.Pokemon.MaxHP != expected {
+		t.Errorf("Expected level 50 HP %d, got %d", expected, state.Player1.Pokemon.MaxHP)
+	}
+	if pokemonRepo.Pokemons[p1Pokemon.ID].Level != 80 {
+		t.Errorf("Expected the stored Pokemon to stay level 80")
+	}
+}
+
+func TestWildBattle_AwardsExperienceForWildPokemon(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	mocks.SeedAllRarities(speciesRepo)
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	wildBattleService := service.NewWildBattleService(speciesRepo, pokemonRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(pokemonRepo))
+
+	user := mocks.CreateTestUser("trainer")
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(1, "TestMon", domain.Common))
+	pokemonRepo.Create(ctx, pokemon)
+	start := pokemon.Experience
+
+	// Execute
+	result, err := wildBattleService.Battle(ctx, user.ID, pokemon.ID, "")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.WildLevel < 50-service.WildLevelSpread || result.WildLevel > 50+service.WildLevelSpread {
+		t.Errorf("Expected wild level within %d of 50, got %d", service.WildLevelSpread, result.WildLevel)
+	}
+	if result.Turns < 1 || result.Turns > service.MaxWildBattleTurns {
+		t.Errorf("Expected 1-%d turns, got %d", service.MaxWildBattleTurns, result.Turns)
+	}
+	if expected := domain.BattleExperience(result.Wild, result.WildLevel, result.Won); result.Gain.Experience != expected {
+		t.Errorf("Expected %d experience, got %d", expected, result.Gain.Experience)
+	}
+
+	// Verify the experience was stored
+	stored, _ := pokemonRepo.GetByID(ctx, pokemon.ID)
+	if stored.Experience != start+result.Gain.Experience {
+		t.Errorf("Expected stored experience %d, got %d", start+result.Gain.Experience, stored.Experience)
+	}
+}
+
+func TestWildBattle_RankedBattlesAtLevel50(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	mocks.SeedAllRarities(speciesRepo)
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	wildBattleService := service.NewWildBattleService(speciesRepo, pokemonRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(pokemonRepo))
+
+	user := mocks.CreateTestUser("trainer")
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(1, "TestMon", domain.Common))
+	pokemon.Level = 90
+	pokemonRepo.Create(ctx, pokemon)
+
+	// Execute
+	result, err := wildBattleService.Battle(ctx, user.ID, pokemon.ID, domain.FormatRanked)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.WildLevel != 50 {
+		t.Errorf("Expected the wild Pokemon flattened to 50, got %d", result.WildLevel)
+	}
+	if result.Format != domain.FormatRanked {
+		t.Errorf("Expected ranked format, got %s", result.Format)
+	}
+}
+
+func TestWildBattle_Rejections(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	mocks.SeedAllRarities(speciesRepo)
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	wildBattleService := service.NewWildBattleService(speciesRepo, pokemonRepo, listingRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(pokemonRepo))
+
+	user := mocks.CreateTestUser("trainer")
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(1, "TestMon", domain.Common))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// Execute and assert
+	if _, err := wildBattleService.Battle(ctx, mocks.CreateTestUser("other").ID, pokemon.ID, ""); !errors.Is(err, service.ErrNotPokemonOwner) {
+		t.Errorf("Expected ErrNotPokemonOwner, got %v", err)
+	}
+	if _, err := wildBattleService.Battle(ctx, user.ID, pokemon.ID, "no_such_format"); !errors.Is(err, service.ErrUnknownFormat) {
+		t.Errorf("Expected ErrUnknownFormat, got %v", err)
+	}
+
+	// Verify a listed Pokemon is locked
+	listingRepo.Create(ctx, domain.NewMarketListing(pokemon, 100))
+	if _, err := wildBattleService.Battle(ctx, user.ID, pokemon.ID, ""); !errors.Is(err, service.ErrPokemonLocked) {
+		t.Errorf("Expected ErrPokemonLocked, got %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestHashServerSeed(t *testing.T) {
+	want := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if got := domain.HashServerSeed("abc"); got != want {
+		t.Errorf("Expected the SHA-256 of abc, got %s", got)
+	}
+}
+
+func TestNewFairRand_DependsOnEveryInput(t *testing.T) {
+	first := func(serverSeed, clientSeed string, nonce int) int64 {
+		return domain.NewFairRand(serverSeed, clientSeed, nonce).Int63()
+	}
+
+	base := first("server", "client", 0)
+	if again := first("server", "client", 0); again != base {
+		t.Fatalf("Expected the same inputs to give the same stream, got %d and %d", base, again)
+	}
+	if first("other", "client", 0) == base || first("server", "other", 0) == base || first("server", "client", 1) == base {
+		t.Error("Expected each seed and the nonce to change the stream")
+	}
+
+	// Streams run past a single HMAC block
+	r := domain.NewFairRand("server", "client", 0)
+	seen := make(map[int64]bool)
+	for i := 0; i < 20; i++ {
+		seen[r.Int63()] = true
+	}
+	if len(seen) != 20 {
+		t.Errorf("Expected 20 different values, got %d", len(seen))
+	}
+}
+
+func TestFairPremiumRoll_PullsVerifyAfterRotation(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("fair-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+
+	// Execute
+	committed, err := gachaService.GetFairSeed(ctx, user.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if committed.ServerSeed != "" {
+		t.Fatal("Expected the server seed to stay hidden before it is revealed")
+	}
+
+	pokemons, proofs, err := gachaService.FairPremiumRoll(ctx, user.ID, "", 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(proofs) != len(pokemons) {
+		t.Fatalf("Expected a proof per pull, got %d for %d pulls", len(proofs), len(pokemons))
+	}
+	for i, proof := range proofs {
+		if proof.Nonce != i || proof.ServerSeedHash != committed.ServerSeedHash || proof.ClientSeed != committed.ClientSeed {
+			t.Fatalf("Expected pull %d to use nonce %d of the committed seed, got %+v", i+1, i, proof)
+		}
+	}
+	if proofs[9].MinRarity != domain.Epic {
+		t.Errorf("Expected the 10th pull's guarantee in its proof, got %s", proofs[9].MinRarity)
+	}
+
+	// Verify rotating reveals the seed every pull can be checked against
+	revealed, active, err := gachaService.RotateFairSeed(ctx, user.ID, "my-lucky-seed")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if domain.HashServerSeed(revealed.ServerSeed) != committed.ServerSeedHash {
+		t.Fatal("Expected the revealed server seed to match the commitment")
+	}
+	if revealed.Nonce != 10 {
+		t.Errorf("Expected the revealed seed to have been used for 10 pulls, got %d", revealed.Nonce)
+	}
+	if active.ServerSeed != "" || active.ClientSeed != "my-lucky-seed" || active.Nonce != 0 ||
+		active.ServerSeedHash == committed.ServerSeedHash {
+		t.Errorf("Expected a fresh hidden seed with the new client seed, got %+v", active)
+	}
+
+	for i, proof := range proofs {
+		got, err := gachaService.VerifyPull(ctx, proof, revealed.ServerSeed)
+		if err != nil {
+			t.Fatalf("Expected pull %d to verify, got %v", i+1, err)
+		}
+		want := pokemons[i]
+		if got.SpeciesID != want.SpeciesID || got.IVs != want.IVs || got.Nature != want.Nature ||
+			got.IsShiny != want.IsShiny || got.Gender != want.Gender || got.AbilitySlot != want.AbilitySlot {
+			t.Errorf("Expected pull %d to reproduce %+v, got %+v", i+1, want, got)
+		}
+	}
+}
+
+func TestFairPremiumRoll_NoncesCarryOver(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("fair-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+
+	// Execute
+	for _, count := range []int{3, 2} {
+		if _, _, err := gachaService.FairPremiumRoll(ctx, user.ID, "", count); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	// Unfair pulls don't use up nonces
+	if _, err := gachaService.PremiumRoll(ctx, user.ID, 4); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	_, proofs, err := gachaService.FairPremiumRoll(ctx, user.ID, "", 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	if proofs[0].Nonce != 5 {
+		t.Errorf("Expected nonce 5 after 5 fair pulls, got %d", proofs[0].Nonce)
+	}
+}
+
+func TestFairPremiumRoll_FailedPullKeepsNonce(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	seedRepo := mocks.NewMockFairSeedRepository()
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), seedRepo, mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, seedRepo))
+
+	user := mocks.CreateTestUser("fair-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+
+	// Execute
+	if _, _, err := gachaService.FairPremiumRoll(ctx, user.ID, "", 2); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	pokemonRepo.CreateError = errors.New("database down")
+	if _, _, err := gachaService.FairPremiumRoll(ctx, user.ID, "", 3); err == nil {
+		t.Fatal("Expected the roll to fail")
+	}
+
+	// Assert
+	seed, _ := seedRepo.GetActive(ctx, user.ID)
+	if seed.Nonce != 2 {
+		t.Errorf("Expected the nonce to stay at 2, got %d", seed.Nonce)
+	}
+}
+
+func TestVerifyPull_RejectsBadProofs(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("fair-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+
+	// Execute and assert
+	_, proofs, err := gachaService.FairPremiumRoll(ctx, user.ID, "", 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := gachaService.VerifyPull(ctx, proofs[0], "not-the-server-seed"); !errors.Is(err, service.ErrSeedMismatch) {
+		t.Errorf("Expected ErrSeedMismatch, got %v", err)
+	}
+
+	bad := *proofs[0]
+	bad.Nonce = -1
+	if _, err := gachaService.VerifyPull(ctx, &bad, "seed"); !errors.Is(err, validators.ErrInvalidNonce) {
+		t.Errorf("Expected ErrInvalidNonce, got %v", err)
+	}
+
+	if _, _, err := gachaService.RotateFairSeed(ctx, user.ID, "no spaces: allowed"); !errors.Is(err, validators.ErrInvalidClientSeed) {
+		t.Errorf("Expected ErrInvalidClientSeed, got %v", err)
+	}
+}
@@ -26,7 +26,7 @@ func TestDailyRoll_Success(t *testing.T) {
 	mocks.SeedAllRarities(speciesRepo)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute
 	pokemons, err := gachaService.DailyRoll(ctx, user.ID)
@@ -51,8 +51,8 @@ func TestDailyRoll_Success(t *testing.T) {
 	}
 
 	// Verify last daily roll was updated
-	if userRepo.UpdateRollCalls != 1 {
-		t.Errorf("Expected 1 UpdateLastDailyRoll call, got %d", userRepo.UpdateRollCalls)
+	if userRepo.ClaimRollCalls != 1 {
+		t.Errorf("Expected 1 ClaimDailyRoll call, got %d", userRepo.ClaimRollCalls)
 	}
 
 	// Verify all Pokemon belong to the user
@@ -80,7 +80,7 @@ func TestDailyRoll_AlreadyRolledToday(t *testing.T) {
 	userRepo.Create(ctx, user)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute
 	_, err := gachaService.DailyRoll(ctx, user.ID)
@@ -96,8 +96,8 @@ func TestDailyRoll_AlreadyRolledToday(t *testing.T) {
 	}
 
 	// Verify last daily roll was not updated
-	if userRepo.UpdateRollCalls != 0 {
-		t.Errorf("Expected 0 UpdateLastDailyRoll calls, got %d", userRepo.UpdateRollCalls)
+	if !user.LastDailyRoll.Equal(now) {
+		t.Errorf("Expected last daily roll to stay %v, got %v", now, *user.LastDailyRoll)
 	}
 }
 
@@ -109,7 +109,7 @@ func TestDailyRoll_UserNotFound(t *testing.T) {
 	pokemonRepo := mocks.NewMockUserPokemonRepository()
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute with non-existent user
 	nonExistentID := uuid.New()
@@ -138,7 +138,7 @@ func TestDailyRoll_After24Hours(t *testing.T) {
 	mocks.SeedAllRarities(speciesRepo)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute
 	pokemons, err := gachaService.DailyRoll(ctx, user.ID)
@@ -169,7 +169,7 @@ func TestDailyRoll_FirstTimeUser(t *testing.T) {
 	mocks.SeedAllRarities(speciesRepo)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute
 	pokemons, err := gachaService.DailyRoll(ctx, user.ID)
@@ -184,8 +184,8 @@ func TestDailyRoll_FirstTimeUser(t *testing.T) {
 	}
 
 	// Verify timestamp was updated
-	if userRepo.UpdateRollCalls != 1 {
-		t.Errorf("Expected 1 UpdateLastDailyRoll call, got %d", userRepo.UpdateRollCalls)
+	if userRepo.ClaimRollCalls != 1 {
+		t.Errorf("Expected 1 ClaimDailyRoll call, got %d", userRepo.ClaimRollCalls)
 	}
 }
 
@@ -206,7 +206,7 @@ func TestDailyRoll_ExactlyCooldown(t *testing.T) {
 	mocks.SeedAllRarities(speciesRepo)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute
 	pokemons, err := gachaService.DailyRoll(ctx, user.ID)
@@ -220,3 +220,162 @@ func TestDailyRoll_ExactlyCooldown(t *testing.T) {
 		t.Fatalf("Expected 5 Pokemon, got %d", len(pokemons))
 	}
 }
+
+func TestDailyRoll_CreateFailureKeepsRollAvailable(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+
+	// Create user
+	user := mocks.CreateTestUser("discord123")
+	userRepo.Create(ctx, user)
+
+	// Seed all rarities
+	mocks.SeedAllRarities(speciesRepo)
+
+	// Fail on the last Pokemon of the pull
+	pokemonRepo.FailCreateAt = 5
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	// Execute
+	if _, err := gachaService.DailyRoll(ctx, user.ID); err == nil {
+		t.Fatal("Expected error when saving a Pokemon fails")
+	}
+
+	// Assert - nothing was saved and the daily roll was not used up
+	if len(pokemonRepo.Pokemons) != 0 {
+		t.Errorf("Expected no Pokemon saved, got %d", len(pokemonRepo.Pokemons))
+	}
+
+	if user.LastDailyRoll != nil {
+		t.Error("Expected daily roll to still be available")
+	}
+
+	// Retry succeeds once the database recovers
+	pokemonRepo.FailCreateAt = 0
+	pokemons, err := gachaService.DailyRoll(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Expected retry to succeed, got %v", err)
+	}
+	if len(pokemons) != 5 {
+		t.Errorf("Expected 5 Pokemon, got %d", len(pokemons))
+	}
+}
+
+func TestDailyRoll_ClaimedBeforeGenerating(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+
+	// Create user who can roll
+	user := mocks.CreateTestUser("discord123")
+	userRepo.Create(ctx, user)
+
+	// Seed all rarities
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	// A concurrent request claims the roll first
+	claimed, _ := userRepo.ClaimDailyRoll(ctx, user.ID, domain.DailyCooldown)
+	if !claimed {
+		t.Fatal("Expected first claim to succeed")
+	}
+
+	// Execute
+	_, err := gachaService.DailyRoll(ctx, user.ID)
+
+	// Assert - the losing request fails without rolling anything
+	if err != service.ErrAlreadyRolledToday {
+		t.Fatalf("Expected ErrAlreadyRolledToday, got %v", err)
+	}
+
+	if speciesRepo.RandomIndex != 0 {
+		t.Errorf("Expected no species to be rolled, got %d rolls", speciesRepo.RandomIndex)
+	}
+
+	if pokemonRepo.CreateCalls != 0 {
+		t.Errorf("Expected 0 Create calls, got %d", pokemonRepo.CreateCalls)
+	}
+}
+
+func TestDailyRollWithKey_RetryReturnsOriginalPull(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	receiptRepo := mocks.NewMockRollReceiptRepository()
+
+	// Create user
+	user := mocks.CreateTestUser("discord123")
+	userRepo.Create(ctx, user)
+
+	// Seed all rarities
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, receiptRepo, mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, receiptRepo))
+
+	// Execute - the client retries with the same key
+	first, err := gachaService.DailyRollWithKey(ctx, user.ID, "retry-key")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	second, err := gachaService.DailyRollWithKey(ctx, user.ID, "retry-key")
+	if err != nil {
+		t.Fatalf("Expected retry to succeed, got %v", err)
+	}
+
+	// Assert - the retry returns the same Pokemon and creates nothing new
+	if len(second) != len(first) {
+		t.Fatalf("Expected %d Pokemon, got %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i].ID != second[i].ID {
+			t.Errorf("Pokemon %d: expected %s, got %s", i, first[i].ID, second[i].ID)
+		}
+	}
+
+	if pokemonRepo.CreateCalls != 5 {
+		t.Errorf("Expected 5 Create calls, got %d", pokemonRepo.CreateCalls)
+	}
+}
+
+func TestDailyRollWithKey_NewKeyStillOnCooldown(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	receiptRepo := mocks.NewMockRollReceiptRepository()
+
+	// Create user
+	user := mocks.CreateTestUser("discord123")
+	userRepo.Create(ctx, user)
+
+	// Seed all rarities
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, receiptRepo, mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, receiptRepo))
+
+	if _, err := gachaService.DailyRollWithKey(ctx, user.ID, "first-key"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Execute - a different key is a new request, not a retry
+	_, err := gachaService.DailyRollWithKey(ctx, user.ID, "second-key")
+
+	// Assert
+	if err != service.ErrAlreadyRolledToday {
+		t.Fatalf("Expected ErrAlreadyRolledToday, got %v", err)
+	}
+
+	if len(receiptRepo.Receipts) != 1 {
+		t.Errorf("Expected 1 receipt, got %d", len(receiptRepo.Receipts))
+	}
+}
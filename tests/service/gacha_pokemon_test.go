@@ -27,7 +27,7 @@ func TestGetUserPokemon_Success(t *testing.T) {
 	pokemonRepo.Create(ctx, pokemon2)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute
 	pokemons, err := gachaService.GetUserPokemon(ctx, user.ID)
@@ -60,7 +60,7 @@ func TestGetUserPokemon_Empty(t *testing.T) {
 	user := mocks.CreateTestUser("discord123")
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute
 	pokemons, err := gachaService.GetUserPokemon(ctx, user.ID)
@@ -96,7 +96,7 @@ func TestGetUserPokemon_MultipleUsers(t *testing.T) {
 	pokemonRepo.Create(ctx, pokemon3)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute - get user1's Pokemon
 	pokemons, err := gachaService.GetUserPokemon(ctx, user1.ID)
@@ -132,7 +132,7 @@ func TestGetPokemonByID_Success(t *testing.T) {
 	pokemonRepo.Create(ctx, pokemon)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute
 	result, err := gachaService.GetPokemonByID(ctx, pokemon.ID)
@@ -163,7 +163,7 @@ func TestGetPokemonByID_NotFound(t *testing.T) {
 	pokemonRepo := mocks.NewMockUserPokemonRepository()
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute with non-existent ID
 	nonExistentID := uuid.New()
@@ -189,7 +189,7 @@ func TestGetPokemonStats_Success(t *testing.T) {
 	pokemonRepo.Create(ctx, pokemon)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute
 	stats, err := gachaService.GetPokemonStats(ctx, pokemon.ID)
@@ -237,7 +237,7 @@ func TestGetPokemonStats_NotFound(t *testing.T) {
 	pokemonRepo := mocks.NewMockUserPokemonRepository()
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute with non-existent ID
 	nonExistentID := uuid.New()
@@ -263,7 +263,7 @@ func TestGetPokemonStats_StatsCalculation(t *testing.T) {
 	pokemonRepo.Create(ctx, pokemon)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute
 	stats, err := gachaService.GetPokemonStats(ctx, pokemon.ID)
@@ -26,7 +26,7 @@ func TestPremiumRoll_Success(t *testing.T) {
 	mocks.SeedAllRarities(speciesRepo)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute - roll 3 times
 	count := 3
@@ -73,7 +73,7 @@ func TestPremiumRoll_InsufficientCoins(t *testing.T) {
 	userRepo.Create(ctx, user)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute
 	_, err := gachaService.PremiumRoll(ctx, user.ID, 1)
@@ -111,7 +111,7 @@ func TestPremiumRoll_TenRollBonus(t *testing.T) {
 	mocks.SeedAllRarities(speciesRepo)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute - 10 roll
 	pokemons, err := gachaService.PremiumRoll(ctx, user.ID, 10)
@@ -147,7 +147,7 @@ func TestPremiumRoll_UserNotFound(t *testing.T) {
 	pokemonRepo := mocks.NewMockUserPokemonRepository()
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute with non-existent user
 	nonExistentID := uuid.New()
@@ -175,7 +175,7 @@ func TestPremiumRoll_SingleRoll(t *testing.T) {
 	mocks.SeedAllRarities(speciesRepo)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute - single roll
 	pokemons, err := gachaService.PremiumRoll(ctx, user.ID, 1)
@@ -213,7 +213,7 @@ func TestPremiumRoll_ExactCoins(t *testing.T) {
 	mocks.SeedAllRarities(speciesRepo)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute
 	pokemons, err := gachaService.PremiumRoll(ctx, user.ID, 5)
@@ -250,7 +250,7 @@ func TestPremiumRoll_LessThanTenRolls_NoBonus(t *testing.T) {
 	mocks.SeedAllRarities(speciesRepo)
 
 	// Create service
-	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo)
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
 
 	// Execute - 9 rolls (less than 10)
 	pokemons, err := gachaService.PremiumRoll(ctx, user.ID, 9)
@@ -272,3 +272,127 @@ func TestPremiumRoll_LessThanTenRolls_NoBonus(t *testing.T) {
 		}
 	}
 }
+
+func TestPremiumRoll_CreateFailureRollsBack(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+
+	// Create user
+	user := mocks.CreateTestUser("discord123")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+
+	// Seed all rarities
+	mocks.SeedAllRarities(speciesRepo)
+
+	// Fail partway through saving the pull
+	pokemonRepo.FailCreateAt = 3
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo)
+
+	// Create service
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), txManager)
+
+	// Execute
+	_, err := gachaService.PremiumRoll(ctx, user.ID, 5)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error when saving a Pokemon fails")
+	}
+
+	// Verify the user was not charged
+	updatedUser, _ := userRepo.GetByID(ctx, user.ID)
+	if updatedUser.Coins != 1000 {
+		t.Errorf("Expected coins to be rolled back to 1000, got %d", updatedUser.Coins)
+	}
+
+	// Verify no partial pull was kept
+	if len(pokemonRepo.Pokemons) != 0 {
+		t.Errorf("Expected no Pokemon saved, got %d", len(pokemonRepo.Pokemons))
+	}
+
+	if txManager.Rollbacks != 1 {
+		t.Errorf("Expected 1 rollback, got %d", txManager.Rollbacks)
+	}
+}
+
+// racingUserRepo changes a user's balance right after the service reads
+// it, as a market sale or another roll committing at the same time would
+type racingUserRepo struct {
+	*mocks.MockUserRepository
+	delta int
+}
+
+func (r *racingUserRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	user, err := r.MockUserRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	stale := *user
+	r.MockUserRepository.AdjustCoins(ctx, id, r.delta)
+	return &stale, nil
+}
+
+func TestPremiumRoll_ConcurrentCreditKept(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	mockUsers := mocks.NewMockUserRepository()
+	userRepo := &racingUserRepo{MockUserRepository: mockUsers, delta: 500}
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+
+	user := mocks.CreateTestUser("discord123")
+	user.Coins = 1000
+	mockUsers.Create(ctx, user)
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(mockUsers, pokemonRepo))
+
+	// Execute - 500 coins arrive while the roll is in progress
+	_, err := gachaService.PremiumRoll(ctx, user.ID, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedCoins := 1000 + 500 - domain.PremiumRollCost
+	if user.Coins != expectedCoins {
+		t.Errorf("Expected %d coins, got %d", expectedCoins, user.Coins)
+	}
+}
+
+func TestPremiumRoll_ConcurrentSpendRollsBack(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	mockUsers := mocks.NewMockUserRepository()
+	userRepo := &racingUserRepo{MockUserRepository: mockUsers, delta: -950}
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+
+	user := mocks.CreateTestUser("discord123")
+	user.Coins = 1000
+	mockUsers.Create(ctx, user)
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(mockUsers, pokemonRepo))
+
+	// Execute - another roll spends 950 coins after the balance was checked
+	_, err := gachaService.PremiumRoll(ctx, user.ID, 1)
+
+	// Assert
+	if err != service.ErrInsufficientCoins {
+		t.Fatalf("Expected ErrInsufficientCoins, got %v", err)
+	}
+
+	if user.Coins != 50 {
+		t.Errorf("Expected the other spend to stand with 50 coins left, got %d", user.Coins)
+	}
+
+	if len(pokemonRepo.Pokemons) != 0 {
+		t.Errorf("Expected no Pokemon to be kept, got %d", len(pokemonRepo.Pokemons))
+	}
+}
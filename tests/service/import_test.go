@@ -0,0 +1,241 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/pokeapi"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestParseRarityRule(t *testing.T) {
+	rule, err := domain.ParseRarityRule("legendary=epic, mythical=stats, rare=400, epic=600")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	species := func(total int, legendary, mythical bool) *domain.ImportedSpecies {
+		s := mocks.CreateTestSpecies(1, "Test", domain.Common)
+		s.BaseHP, s.BaseAttack, s.BaseDefense, s.BaseSpAttack, s.BaseSpDefense, s.BaseSpeed = total, 0, 0, 0, 0, 0
+		return &domain.ImportedSpecies{Species: s, Legendary: legendary, Mythical: mythical}
+	}
+
+	for _, tt := range []struct {
+		name    string
+		species *domain.ImportedSpecies
+		want    domain.Rarity
+	}{
+		{"below every threshold", species(300, false, false), domain.Common},
+		{"threshold reached", species(400, false, false), domain.Rare},
+		{"rarest threshold first", species(680, false, false), domain.Epic},
+		{"legendary", species(300, true, false), domain.Epic},
+		{"mythical ranked by stats", species(450, false, true), domain.Rare},
+	} {
+		if got := rule.Assign(tt.species); got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+
+	for _, bad := range []string{"", "rare", "rare=many", "shiny=400", "legendary=shiny", "epic=-1"} {
+		if _, err := domain.ParseRarityRule(bad); !errors.Is(err, domain.ErrInvalidRarityRule) {
+			t.Errorf("Expected ErrInvalidRarityRule for %q, got %v", bad, err)
+		}
+	}
+}
+
+// learnsetNames returns the names of a species' learnset moves, sorted
+func learnsetNames(repo *mocks.MockLearnsetRepository, speciesID int) []string {
+	var names []string
+	for _, move := range repo.Learnsets[speciesID] {
+		names = append(names, move.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestImportService_JSONDump(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	moveRepo := mocks.NewMockMoveRepository()
+	learnsetRepo := mocks.NewMockLearnsetRepository()
+	importService := service.NewImportService(speciesRepo, moveRepo, learnsetRepo, mocks.NewMockTxManager(speciesRepo, moveRepo, learnsetRepo))
+
+	// A seeded move and a hand-tuned species with a curated learnset
+	tackle := &domain.Move{Name: "Tackle", Type: domain.Normal, Category: domain.Physical, Power: 40, Accuracy: 100, PP: 35}
+	growl := &domain.Move{Name: "Growl", Type: domain.Normal, Category: domain.Status, Accuracy: 100, PP: 40}
+	moveRepo.Upsert(ctx, tackle)
+	moveRepo.Upsert(ctx, growl)
+	bulbasaur := mocks.CreateTestSpecies(1, "Bulbasaur", domain.Uncommon)
+	bulbasaur.DropWeight = 50
+	speciesRepo.Create(ctx, bulbasaur)
+	learnsetRepo.Add(ctx, 1, []*domain.Move{growl})
+
+	data, err := pokeapi.Load("testdata/pokeapi/json", "")
+	if err != nil {
+		t.Fatalf("Expected the dump to load, got %v", err)
+	}
+	if len(data.Species) != 2 || len(data.Moves) != 4 {
+		t.Fatalf("Expected 2 species without the alternate form and 4 moves, got %d and %d", len(data.Species), len(data.Moves))
+	}
+
+	// Execute
+	result, err := importService.Import(ctx, data, domain.DefaultRarityRule())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Species != 2 || result.Moves != 4 || result.LearnsetMoves != 5 || result.UnknownMoves != 1 || len(result.Skipped) != 0 {
+		t.Errorf("Expected 2 species, 4 moves, 5 learnset moves and Psystrike unknown, got %+v", result)
+	}
+	if result.ByRarity[domain.Uncommon] != 1 || result.ByRarity[domain.Legendary] != 1 {
+		t.Errorf("Expected an uncommon and a legendary, got %v", result.ByRarity)
+	}
+
+	imported := speciesRepo.Species[1]
+	if imported.Type1 != domain.Grass || imported.Type2 == nil || *imported.Type2 != domain.Poison {
+		t.Errorf("Expected Bulbasaur to be Grass/Poison, got %s/%v", imported.Type1, imported.Type2)
+	}
+	if imported.BaseStatTotal() != 318 || imported.BaseSpAttack != 65 || imported.GrowthRate != domain.GrowthMediumSlow || imported.GenderRate != 1 {
+		t.Errorf("Expected Bulbasaur's stats and species data, got %+v", imported)
+	}
+	if !slices.Equal(imported.Abilities, []string{"overgrow"}) || imported.HiddenAbility != "chlorophyll" {
+		t.Errorf("Expected Overgrow with hidden Chlorophyll, got %v and %q", imported.Abilities, imported.HiddenAbility)
+	}
+	if imported.SpriteURL != "https://example.com/sprites/1.png" || imported.DropWeight != 50 {
+		t.Errorf("Expected the dump's sprite and the tuned drop weight, got %q and %v", imported.SpriteURL, imported.DropWeight)
+	}
+
+	mewtwo := speciesRepo.Species[150]
+	if mewtwo.Name != "Mewtwo" || mewtwo.Rarity != domain.Legendary || mewtwo.DropWeight != 1 || mewtwo.GenderRate != domain.GenderlessRate {
+		t.Errorf("Expected a genderless legendary Mewtwo, got %+v", mewtwo)
+	}
+	if mewtwo.SpriteURL != "https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/150.png" {
+		t.Errorf("Expected the PokeAPI sprite when the dump has none, got %q", mewtwo.SpriteURL)
+	}
+
+	toxic := moveRepo.Moves["Toxic"]
+	if toxic.Category != domain.Status || toxic.Power != 0 || toxic.Accuracy != 90 ||
+		toxic.StatusInflict == nil || *toxic.StatusInflict != (domain.StatusInflict{Status: domain.StatusBadlyPoison, Chance: 100}) {
+		t.Errorf("Expected Toxic to always badly poison, got %+v", toxic)
+	}
+	if toxic.Description != "Badly poisons the target." {
+		t.Errorf("Expected the markup stripped from Toxic's description, got %q", toxic.Description)
+	}
+	closeCombat := moveRepo.Moves["Close Combat"]
+	wantChanges := []domain.StatChange{
+		{Stat: domain.Defense, Stages: -1, Target: "self"},
+		{Stat: domain.SpecialDefense, Stages: -1, Target: "self"},
+	}
+	if !slices.Equal(closeCombat.StatChanges, wantChanges) || closeCombat.SecondaryEffect != nil {
+		t.Errorf("Expected Close Combat to lower the user's defenses, got %+v", closeCombat.StatChanges)
+	}
+	bite := moveRepo.Moves["Bite"]
+	if bite.SecondaryEffect == nil || bite.SecondaryEffect.Chance != 30 || bite.SecondaryEffect.FlinchChance != 30 {
+		t.Errorf("Expected Bite to flinch 30%% of the time, got %+v", bite.SecondaryEffect)
+	}
+	if bite.Description != "Has a 30% chance to make the target flinch." {
+		t.Errorf("Expected the effect chance in Bite's description, got %q", bite.Description)
+	}
+
+	if got := learnsetNames(learnsetRepo, 1); !slices.Equal(got, []string{"Growl", "Tackle", "Toxic", "Vine Whip"}) {
+		t.Errorf("Expected the dump's moves alongside the curated one, got %v", got)
+	}
+	if got := learnsetNames(learnsetRepo, 150); !slices.Equal(got, []string{"Bite", "Close Combat"}) {
+		t.Errorf("Expected Mewtwo's known moves, got %v", got)
+	}
+
+	// Verify importing the same dump again changes nothing
+	moveIDs := make(map[string]int)
+	for name, move := range moveRepo.Moves {
+		moveIDs[name] = move.ID
+	}
+	data, _ = pokeapi.Load("testdata/pokeapi/json", pokeapi.FormatJSON)
+	if _, err := importService.Import(ctx, data, domain.DefaultRarityRule()); err != nil {
+		t.Fatalf("Expected the re-import to succeed, got %v", err)
+	}
+	if len(speciesRepo.Species) != 2 || len(moveRepo.Moves) != 6 || len(learnsetRepo.Learnsets[1]) != 4 {
+		t.Errorf("Expected no new rows on re-import, got %d species, %d moves and %d learnset moves",
+			len(speciesRepo.Species), len(moveRepo.Moves), len(learnsetRepo.Learnsets[1]))
+	}
+	for name, move := range moveRepo.Moves {
+		if move.ID != moveIDs[name] {
+			t.Errorf("Expected %s to keep ID %d, got %d", name, moveIDs[name], move.ID)
+		}
+	}
+	if len(speciesRepo.RarityMap[domain.Legendary]) != 1 {
+		t.Errorf("Expected Mewtwo listed once under legendary, got %d", len(speciesRepo.RarityMap[domain.Legendary]))
+	}
+}
+
+func TestImportService_CSVDump(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	moveRepo := mocks.NewMockMoveRepository()
+	learnsetRepo := mocks.NewMockLearnsetRepository()
+	importService := service.NewImportService(speciesRepo, moveRepo, learnsetRepo, mocks.NewMockTxManager(speciesRepo, moveRepo, learnsetRepo))
+
+	data, err := pokeapi.Load("testdata/pokeapi/csv", "")
+	if err != nil {
+		t.Fatalf("Expected the dump to load, got %v", err)
+	}
+
+	rule, _ := domain.ParseRarityRule("mythical=mythic,legendary=legendary,rare=500,uncommon=300")
+
+	// Execute
+	result, err := importService.Import(ctx, data, rule)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Species != 2 || result.Moves != 2 || len(result.Skipped) != 1 {
+		t.Fatalf("Expected 2 species and 2 moves with one row skipped, got %+v", result)
+	}
+	if skip := result.Skipped[0]; skip.Kind != "species" || skip.ID != 999 || skip.Reason != "invalid type" {
+		t.Errorf("Expected the Shadow-type species skipped, got %+v", skip)
+	}
+
+	bulbasaur, mew := speciesRepo.Species[1], speciesRepo.Species[151]
+	if bulbasaur.Name != "Bulbasaur" || bulbasaur.Rarity != domain.Uncommon || bulbasaur.Type2 == nil || *bulbasaur.Type2 != domain.Poison {
+		t.Errorf("Expected an uncommon Grass/Poison Bulbasaur, got %+v", bulbasaur)
+	}
+	if bulbasaur.BaseStatTotal() != 318 || bulbasaur.HiddenAbility != "chlorophyll" || bulbasaur.GrowthRate != domain.GrowthMediumSlow {
+		t.Errorf("Expected Bulbasaur's stats, hidden ability and growth rate, got %+v", bulbasaur)
+	}
+	if mew.Rarity != domain.Mythic || mew.Type1 != domain.Psychic || !slices.Equal(mew.Abilities, []string{"synchronize"}) {
+		t.Errorf("Expected a mythic Psychic Mew with Synchronize, got %+v", mew)
+	}
+	if _, ok := speciesRepo.Species[3]; ok {
+		t.Error("Expected the alternate form to be left out")
+	}
+
+	gigaDrain := moveRepo.Moves["Giga Drain"]
+	if gigaDrain.Category != domain.Special || gigaDrain.Power != 75 || gigaDrain.DrainPercent != 50 || gigaDrain.Type != domain.Grass {
+		t.Errorf("Expected a draining special Grass move, got %+v", gigaDrain)
+	}
+	if got := learnsetNames(learnsetRepo, 1); !slices.Equal(got, []string{"Giga Drain", "Toxic"}) {
+		t.Errorf("Expected each learnset move once, got %v", got)
+	}
+	if got := learnsetNames(learnsetRepo, 151); !slices.Equal(got, []string{"Toxic"}) {
+		t.Errorf("Expected only Mew's moves in the dump, got %v", got)
+	}
+}
+
+func TestPokeAPILoad_Errors(t *testing.T) {
+	if _, err := pokeapi.Load("testdata/pokeapi/json", "xml"); !errors.Is(err, pokeapi.ErrUnknownFormat) {
+		t.Errorf("Expected ErrUnknownFormat, got %v", err)
+	}
+	if _, err := pokeapi.Load("testdata/pokeapi/missing", pokeapi.FormatJSON); err == nil {
+		t.Error("Expected an error for a dump without Pokemon")
+	}
+	if _, err := pokeapi.Load("testdata/pokeapi/json", pokeapi.FormatCSV); err == nil {
+		t.Error("Expected an error for a CSV dump without its tables")
+	}
+}
@@ -0,0 +1,428 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/repository"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+func TestCreateListing_Success(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, marketTxRepo))
+
+	seller := mocks.CreateTestUser("seller")
+	buyer := mocks.CreateTestUser("buyer")
+	userRepo.Create(ctx, seller)
+	userRepo.Create(ctx, buyer)
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// Execute
+	listing, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 500)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if listing.Status != domain.ListingStatusActive {
+		t.Errorf("Expected active listing, got %s", listing.Status)
+	}
+	if listing.SellerID != seller.ID {
+		t.Errorf("Expected seller %s, got %s", seller.ID, listing.SellerID)
+	}
+	if listing.Fee() != 25 {
+		t.Errorf("Expected 5%% fee of 25 coins, got %d", listing.Fee())
+	}
+}
+
+func TestCreateListing_RejectsInvalidListings(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, marketTxRepo))
+
+	seller := mocks.CreateTestUser("seller")
+	buyer := mocks.CreateTestUser("buyer")
+	userRepo.Create(ctx, seller)
+	userRepo.Create(ctx, buyer)
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// Execute and assert
+	if _, err := marketService.CreateListing(ctx, buyer.ID, pokemon.ID, 500); !errors.Is(err, service.ErrNotPokemonOwner) {
+		t.Errorf("Expected ErrNotPokemonOwner, got %v", err)
+	}
+
+	if _, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 0); !errors.Is(err, validators.ErrInvalidListingPrice) {
+		t.Errorf("Expected ErrInvalidListingPrice, got %v", err)
+	}
+
+	if _, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 500); err != nil {
+		t.Fatalf("Expected no error listing Pokemon, got %v", err)
+	}
+	if _, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 600); !errors.Is(err, repository.ErrAlreadyListed) {
+		t.Errorf("Expected ErrAlreadyListed, got %v", err)
+	}
+}
+
+func TestCreateListing_PokemonInPendingTradeRejected(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, marketTxRepo, tradeRepo, notificationRepo)
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, mocks.NewMockBattleRepository(), notificationRepo, txManager)
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, tradeRepo, mocks.NewMockBattleRepository(), txManager)
+
+	seller := mocks.CreateTestUser("seller")
+	buyer := mocks.CreateTestUser("buyer")
+	userRepo.Create(ctx, seller)
+	userRepo.Create(ctx, buyer)
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// The seller offers the Pikachu to the buyer directly
+	if _, err := tradeService.ProposeTrade(ctx, seller.ID, buyer.ID, []uuid.UUID{pokemon.ID}, nil, 0, 100); err != nil {
+		t.Fatalf("Expected no error proposing trade, got %v", err)
+	}
+
+	// Execute
+	_, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 500)
+
+	// Assert
+	if !errors.Is(err, service.ErrPokemonLocked) {
+		t.Errorf("Expected ErrPokemonLocked, got %v", err)
+	}
+	if len(listingRepo.Listings) != 0 {
+		t.Errorf("Expected no listing created, got %d", len(listingRepo.Listings))
+	}
+}
+
+func TestBuyListing_TransfersCoinsAndPokemon(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, marketTxRepo))
+
+	seller := mocks.CreateTestUser("seller")
+	buyer := mocks.CreateTestUser("buyer")
+	userRepo.Create(ctx, seller)
+	userRepo.Create(ctx, buyer)
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	listing, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 500)
+	if err != nil {
+		t.Fatalf("Expected no error listing Pokemon, got %v", err)
+	}
+
+	// Execute
+	transaction, err := marketService.BuyListing(ctx, buyer.ID, listing.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if buyer.Coins != domain.StartingCoins-500 {
+		t.Errorf("Expected buyer to have %d coins, got %d", domain.StartingCoins-500, buyer.Coins)
+	}
+	if seller.Coins != domain.StartingCoins+475 {
+		t.Errorf("Expected seller to receive 475 coins after the fee, got %d", seller.Coins-domain.StartingCoins)
+	}
+	if pokemon.UserID != buyer.ID {
+		t.Errorf("Expected Pokemon to belong to the buyer")
+	}
+	if listing.Status != domain.ListingStatusSold {
+		t.Errorf("Expected listing sold, got %s", listing.Status)
+	}
+	if transaction.Fee != 25 || len(marketTxRepo.Transactions) != 1 {
+		t.Errorf("Expected one transaction with a 25 coin fee, got %d transactions", len(marketTxRepo.Transactions))
+	}
+}
+
+func TestBuyListing_InsufficientCoinsChangesNothing(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, marketTxRepo))
+
+	seller := mocks.CreateTestUser("seller")
+	buyer := mocks.CreateTestUser("buyer")
+	userRepo.Create(ctx, seller)
+	userRepo.Create(ctx, buyer)
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	listing, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 5000)
+	if err != nil {
+		t.Fatalf("Expected no error listing Pokemon, got %v", err)
+	}
+
+	// Execute
+	_, err = marketService.BuyListing(ctx, buyer.ID, listing.ID)
+
+	// Assert
+	if !errors.Is(err, service.ErrInsufficientFunds) {
+		t.Fatalf("Expected ErrInsufficientFunds, got %v", err)
+	}
+
+	if buyer.Coins != domain.StartingCoins || seller.Coins != domain.StartingCoins {
+		t.Errorf("Expected balances unchanged, got buyer %d and seller %d", buyer.Coins, seller.Coins)
+	}
+	if pokemon.UserID != seller.ID {
+		t.Errorf("Expected Pokemon to stay with the seller")
+	}
+	if !listing.IsActive() {
+		t.Errorf("Expected listing to stay active, got %s", listing.Status)
+	}
+}
+
+func TestBuyListing_FailedRecordRollsBack(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, marketTxRepo))
+
+	seller := mocks.CreateTestUser("seller")
+	buyer := mocks.CreateTestUser("buyer")
+	userRepo.Create(ctx, seller)
+	userRepo.Create(ctx, buyer)
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	listing, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 500)
+	if err != nil {
+		t.Fatalf("Expected no error listing Pokemon, got %v", err)
+	}
+
+	marketTxRepo.CreateError = errors.New("database unavailable")
+
+	// Execute
+	_, err = marketService.BuyListing(ctx, buyer.ID, listing.ID)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error when recording the sale fails")
+	}
+
+	if buyer.Coins != domain.StartingCoins || seller.Coins != domain.StartingCoins {
+		t.Errorf("Expected balances rolled back, got buyer %d and seller %d", buyer.Coins, seller.Coins)
+	}
+	if pokemon.UserID != seller.ID {
+		t.Errorf("Expected Pokemon transfer rolled back")
+	}
+	if !listing.IsActive() {
+		t.Errorf("Expected listing to stay active, got %s", listing.Status)
+	}
+}
+
+func TestBuyListing_OnlyOneBuyerWins(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, marketTxRepo))
+
+	seller := mocks.CreateTestUser("seller")
+	buyer := mocks.CreateTestUser("buyer")
+	userRepo.Create(ctx, seller)
+	userRepo.Create(ctx, buyer)
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	listing, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 500)
+	if err != nil {
+		t.Fatalf("Expected no error listing Pokemon, got %v", err)
+	}
+
+	other := mocks.CreateTestUser("other")
+	userRepo.Create(ctx, other)
+
+	// Execute
+	_, firstErr := marketService.BuyListing(ctx, buyer.ID, listing.ID)
+	_, secondErr := marketService.BuyListing(ctx, other.ID, listing.ID)
+
+	// Assert
+	if firstErr != nil {
+		t.Fatalf("Expected first purchase to succeed, got %v", firstErr)
+	}
+	if !errors.Is(secondErr, service.ErrListingUnavailable) {
+		t.Fatalf("Expected ErrListingUnavailable, got %v", secondErr)
+	}
+	if other.Coins != domain.StartingCoins {
+		t.Errorf("Expected second buyer to keep %d coins, got %d", domain.StartingCoins, other.Coins)
+	}
+}
+
+func TestBuyListing_CannotBuyOwnListing(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, marketTxRepo))
+
+	seller := mocks.CreateTestUser("seller")
+	buyer := mocks.CreateTestUser("buyer")
+	userRepo.Create(ctx, seller)
+	userRepo.Create(ctx, buyer)
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	listing, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 500)
+	if err != nil {
+		t.Fatalf("Expected no error listing Pokemon, got %v", err)
+	}
+
+	// Execute
+	_, err = marketService.BuyListing(ctx, seller.ID, listing.ID)
+
+	// Assert
+	if !errors.Is(err, service.ErrCannotBuyOwnListing) {
+		t.Errorf("Expected ErrCannotBuyOwnListing, got %v", err)
+	}
+}
+
+func TestCancelListing(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, marketTxRepo))
+
+	seller := mocks.CreateTestUser("seller")
+	buyer := mocks.CreateTestUser("buyer")
+	userRepo.Create(ctx, seller)
+	userRepo.Create(ctx, buyer)
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	listing, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 500)
+	if err != nil {
+		t.Fatalf("Expected no error listing Pokemon, got %v", err)
+	}
+
+	// Execute and assert
+	if err := marketService.CancelListing(ctx, buyer.ID, listing.ID); !errors.Is(err, service.ErrNotListingSeller) {
+		t.Errorf("Expected ErrNotListingSeller, got %v", err)
+	}
+
+	if err := marketService.CancelListing(ctx, seller.ID, listing.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := marketService.BuyListing(ctx, buyer.ID, listing.ID); !errors.Is(err, service.ErrListingUnavailable) {
+		t.Errorf("Expected cancelled listing to be unavailable, got %v", err)
+	}
+
+	// The Pokemon can be listed again
+	if _, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 400); err != nil {
+		t.Fatalf("Expected no error listing Pokemon, got %v", err)
+	}
+}
+
+func TestSearchListings_Filters(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, marketTxRepo))
+
+	seller := mocks.CreateTestUser("seller")
+	buyer := mocks.CreateTestUser("buyer")
+	userRepo.Create(ctx, seller)
+	userRepo.Create(ctx, buyer)
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	pokemon.IVs = domain.IVs{HP: 31, Attack: 31, Defense: 31, SpAttack: 31, SpDefense: 31, Speed: 31}
+	if _, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 800); err != nil {
+		t.Fatalf("Expected no error listing Pokemon, got %v", err)
+	}
+
+	weak := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(19, "Rattata", domain.Common))
+	weak.IVs = domain.IVs{}
+	pokemonRepo.Create(ctx, weak)
+	if _, err := marketService.CreateListing(ctx, seller.ID, weak.ID, 50); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Execute and assert
+	tests := []struct {
+		name   string
+		filter domain.MarketSearchFilter
+		want   int
+	}{
+		{"all, cheapest first", domain.MarketSearchFilter{}, 2},
+		{"by species name", domain.MarketSearchFilter{SpeciesName: "pikachu"}, 1},
+		{"by species ID", domain.MarketSearchFilter{SpeciesID: 19}, 1},
+		{"by rarity", domain.MarketSearchFilter{Rarity: domain.Common}, 1},
+		{"by IV percentage", domain.MarketSearchFilter{MinIVPercent: 90}, 1},
+		{"by max price", domain.MarketSearchFilter{MaxPrice: 100}, 1},
+		{"no match", domain.MarketSearchFilter{Rarity: domain.Mythic}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listings, err := marketService.SearchListings(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(listings) != tt.want {
+				t.Errorf("Expected %d listings, got %d", tt.want, len(listings))
+			}
+		})
+	}
+
+	// Verify the cheapest listing comes first
+	listings, _ := marketService.SearchListings(ctx, domain.MarketSearchFilter{})
+	if listings[0].Price != 50 {
+		t.Errorf("Expected cheapest listing first, got price %d", listings[0].Price)
+	}
+}
@@ -0,0 +1,238 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+// pityRule returns the pity rule for a rarity
+func pityRule(t *testing.T, rarity domain.Rarity) domain.PityRule {
+	t.Helper()
+	for _, rule := range domain.PityRules {
+		if rule.Rarity == rarity {
+			return rule
+		}
+	}
+	t.Fatalf("No pity rule for %s", rarity)
+	return domain.PityRule{}
+}
+
+func TestPityState_RatesRiseAfterSoftPity(t *testing.T) {
+	epic := pityRule(t, domain.Epic)
+	base := domain.DefaultRates()
+	state := domain.NewPityState(mocks.CreateTestUser("pity").ID, domain.StandardBannerID)
+
+	// Before soft pity nothing changes
+	state.Pulls[domain.Epic] = epic.SoftPity - 1
+	if got := state.Rates(base).From(domain.Epic); math.Abs(got-base.From(domain.Epic)) > 1e-9 {
+		t.Errorf("Expected the base epic+ rate %v before soft pity, got %v", base.From(domain.Epic), got)
+	}
+
+	// Each pull past soft pity adds a step
+	state.Pulls[domain.Epic] = epic.SoftPity + 2
+	rates := state.Rates(base)
+	want := base.From(domain.Epic) + 3*epic.Step
+	if got := rates.From(domain.Epic); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Expected an epic+ rate of %v three pulls past soft pity, got %v", want, got)
+	}
+	if math.Abs(rates.Total()-1) > 1e-9 {
+		t.Errorf("Expected the rates to still total 1, got %v", rates.Total())
+	}
+
+	// Rarer rarities keep their share of the boost
+	ratio := rates[domain.Legendary] / rates[domain.Epic]
+	if baseRatio := base[domain.Legendary] / base[domain.Epic]; math.Abs(ratio-baseRatio) > 1e-9 {
+		t.Errorf("Expected legendary to epic to stay %v, got %v", baseRatio, ratio)
+	}
+}
+
+func TestPityState_HardPityGuaranteesRarity(t *testing.T) {
+	legendary := pityRule(t, domain.Legendary)
+	state := domain.NewPityState(mocks.CreateTestUser("pity").ID, domain.StandardBannerID)
+	state.Pulls[domain.Legendary] = legendary.HardPity - 1
+
+	rates := state.Rates(domain.DefaultRates())
+	if got := rates.From(domain.Legendary); math.Abs(got-1) > 1e-9 {
+		t.Errorf("Expected legendary or better to be guaranteed, got %v", got)
+	}
+	for _, roll := range []float64{0, 0.5, 0.999} {
+		if rarity := rates.Roll(roll); rarity != domain.Legendary && rarity != domain.Mythic {
+			t.Errorf("Expected roll %v to be legendary or better, got %s", roll, rarity)
+		}
+	}
+}
+
+func TestPityState_RecordResetsOnRarity(t *testing.T) {
+	state := domain.NewPityState(mocks.CreateTestUser("pity").ID, "legendary-birds")
+
+	state.Record(domain.Common)
+	state.Record(domain.Rare)
+	if state.Pulls[domain.Epic] != 2 || state.Pulls[domain.Legendary] != 2 {
+		t.Fatalf("Expected 2 pulls on each counter, got %v", state.Pulls)
+	}
+
+	state.Record(domain.Epic)
+	if state.Pulls[domain.Epic] != 0 || state.Pulls[domain.Legendary] != 3 {
+		t.Errorf("Expected an epic to reset only the epic counter, got %v", state.Pulls)
+	}
+
+	state.Record(domain.Mythic)
+	if state.Pulls[domain.Epic] != 0 || state.Pulls[domain.Legendary] != 0 {
+		t.Errorf("Expected a mythic to reset every counter, got %v", state.Pulls)
+	}
+
+	progress := state.Progress()
+	if len(progress) != len(domain.PityRules) || progress[0].PullsToGuarantee != progress[0].HardPity {
+		t.Errorf("Expected fresh progress toward every rule, got %+v", progress)
+	}
+}
+
+func TestPremiumRoll_HardPityOnSingleRolls(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	pityRepo := mocks.NewMockPityRepository()
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), pityRepo, mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, pityRepo))
+
+	user := mocks.CreateTestUser("pity-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+	epic := pityRule(t, domain.Epic)
+	pityRepo.SetPulls(user.ID, domain.StandardBannerID, domain.Epic, epic.HardPity-1)
+
+	// Execute
+	pokemons, err := gachaService.PremiumRoll(ctx, user.ID, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if pokemons[0].Species.Rarity.Value() < domain.Epic.Value() {
+		t.Errorf("Expected hard pity to give epic or better, got %s", pokemons[0].Species.Rarity)
+	}
+
+	pity, _ := gachaService.GetPity(ctx, user.ID, domain.StandardBannerID)
+	if pity.Pulls[domain.Epic] != 0 {
+		t.Errorf("Expected the epic counter to reset, got %d", pity.Pulls[domain.Epic])
+	}
+}
+
+func TestPremiumRoll_PityCarriesOverPerBanner(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	bannerRepo := mocks.NewMockBannerRepository()
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), bannerRepo, mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("pity-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+	endsAt := time.Now().Add(24 * time.Hour)
+	bannerRepo.Create(ctx, &domain.Banner{
+		ID:       "commons",
+		Name:     "Commons",
+		Rates:    domain.RateTable{domain.Common: 1},
+		StartsAt: time.Now().Add(-time.Hour),
+		EndsAt:   &endsAt,
+	})
+
+	// Execute
+	for _, count := range []int{3, 2} {
+		if _, err := gachaService.PremiumRollOnBanner(ctx, user.ID, "commons", count); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	// Assert
+	pity, _ := gachaService.GetPity(ctx, user.ID, "commons")
+	if pity.Pulls[domain.Epic] != 5 || pity.Pulls[domain.Legendary] != 5 {
+		t.Errorf("Expected 5 pulls counted across both rolls, got %v", pity.Pulls)
+	}
+
+	standard, _ := gachaService.GetPity(ctx, user.ID, domain.StandardBannerID)
+	if standard.Pulls[domain.Epic] != 0 {
+		t.Errorf("Expected the standard pool's pity to be untouched, got %v", standard.Pulls)
+	}
+}
+
+func TestDailyRoll_CountsTowardStandardPity(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("pity-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+
+	// Execute
+	pokemons, err := gachaService.DailyRoll(ctx, user.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := domain.NewPityState(user.ID, domain.StandardBannerID)
+	for _, p := range pokemons {
+		want.Record(p.Species.Rarity)
+	}
+
+	pity, _ := gachaService.GetPity(ctx, user.ID, domain.StandardBannerID)
+	for _, rule := range domain.PityRules {
+		if pity.Pulls[rule.Rarity] != want.Pulls[rule.Rarity] {
+			t.Errorf("Expected %d pulls toward %s, got %d", want.Pulls[rule.Rarity], rule.Rarity, pity.Pulls[rule.Rarity])
+		}
+	}
+}
+
+func TestPremiumRoll_FailedPullKeepsPity(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	pityRepo := mocks.NewMockPityRepository()
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), pityRepo, mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, pityRepo))
+
+	user := mocks.CreateTestUser("pity-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+	pityRepo.SetPulls(user.ID, domain.StandardBannerID, domain.Epic, 7)
+	pokemonRepo.CreateError = errors.New("database down")
+
+	// Execute
+	_, err := gachaService.PremiumRoll(ctx, user.ID, 3)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected the roll to fail")
+	}
+
+	pity, _ := gachaService.GetPity(ctx, user.ID, domain.StandardBannerID)
+	if pity.Pulls[domain.Epic] != 7 {
+		t.Errorf("Expected the epic counter to stay at 7, got %d", pity.Pulls[domain.Epic])
+	}
+}
@@ -0,0 +1,333 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+func TestNewPokedex_Completion(t *testing.T) {
+	// Setup
+	flying := domain.Flying
+	species := []*domain.PokemonSpecies{
+		mocks.CreateTestSpecies(1, "Bulbasaur", domain.Common),
+		mocks.CreateTestSpecies(16, "Pidgey", domain.Common),
+		mocks.CreateTestSpecies(25, "Pikachu", domain.Rare),
+	}
+	species[0].Type1 = domain.Grass
+	species[1].Type1, species[1].Type2 = domain.Normal, &flying
+	species[2].Type1 = domain.Electric
+
+	owned := domain.PokedexEntry{SpeciesID: 16}
+	owned.OwnedAt = &owned.SeenAt
+	entries := []*domain.PokedexEntry{&owned, {SpeciesID: 25}}
+
+	// Execute
+	dex := domain.NewPokedex(species, entries, nil)
+
+	// Assert
+	if dex.Overall != (domain.PokedexCompletion{Total: 3, Seen: 2, Owned: 1, Percent: 33.3}) {
+		t.Errorf("Expected 1 of 3 owned and 2 seen, got %+v", dex.Overall)
+	}
+	if c := dex.ByRarity[domain.Common]; c.Total != 2 || c.Owned != 1 || c.Percent != 50 {
+		t.Errorf("Expected half the commons owned, got %+v", c)
+	}
+	if c := dex.ByRarity[domain.Rare]; c.Total != 1 || c.Seen != 1 || c.Owned != 0 {
+		t.Errorf("Expected the rare seen but not owned, got %+v", c)
+	}
+	if dex.ByType[domain.Flying].Owned != 1 || dex.ByType[domain.Normal].Owned != 1 || dex.ByType[domain.Grass].Seen != 0 {
+		t.Errorf("Expected a dual-type species to count toward both types, got %+v", dex.ByType)
+	}
+	if !dex.Species[1].Owned || !dex.Species[2].Seen || dex.Species[2].Owned || dex.Species[0].Seen {
+		t.Errorf("Expected species flags to follow the entries, got %+v", dex.Species)
+	}
+	if dex.NextMilestone == nil || dex.NextMilestone.Owned != domain.PokedexMilestones[0].Owned || dex.Milestones[0].Reached {
+		t.Errorf("Expected the first milestone next, got %+v", dex.NextMilestone)
+	}
+}
+
+// obtainedEvents returns an obtained event for each species, in order
+func obtainedEvents(userID uuid.UUID, speciesIDs ...int) []domain.GameEvent {
+	events := make([]domain.GameEvent, len(speciesIDs))
+	for i, id := range speciesIDs {
+		events[i] = domain.NewObtainedEvent(userID, id)
+	}
+	return events
+}
+
+func TestPokedex_RecordsSeenAndOwned(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokedexRepo := mocks.NewMockPokedexRepository()
+	mocks.SeedAllRarities(speciesRepo)
+	pokedexService := service.NewPokedexService(userRepo, speciesRepo, pokedexRepo, mocks.NewMockItemRepository())
+
+	user := mocks.CreateTestUser("dex-user")
+	userRepo.Create(ctx, user)
+
+	// Execute: see species 3, then obtain it twice
+	pokedexService.Record(ctx, domain.NewSeenEvent(user.ID, 3), domain.NewGameEvent(domain.EventBattleWon, user.ID, 1))
+	seen := pokedexRepo.Get(user.ID, 3)
+	seenOwned := seen != nil && seen.IsOwned()
+	if err := pokedexService.Record(ctx, obtainedEvents(user.ID, 3, 3)...); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	if seen == nil || seenOwned {
+		t.Fatalf("Expected species 3 seen but not owned, got %+v", seen)
+	}
+	entry := pokedexRepo.Get(user.ID, 3)
+	if entry == nil || !entry.IsOwned() {
+		t.Fatalf("Expected species 3 owned, got %+v", entry)
+	}
+	ownedAt := *entry.OwnedAt
+
+	// Verify seeing or obtaining it again keeps when it was first owned
+	pokedexService.Record(ctx, domain.NewSeenEvent(user.ID, 3))
+	pokedexService.Record(ctx, obtainedEvents(user.ID, 3)...)
+	if !pokedexRepo.Get(user.ID, 3).OwnedAt.Equal(ownedAt) || len(pokedexRepo.Entries) != 1 {
+		t.Errorf("Expected one entry still first owned at %v, got %+v", ownedAt, pokedexRepo.Entries)
+	}
+
+	dex, err := pokedexService.GetPokedex(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if dex.Overall.Owned != 1 || dex.Overall.Total != len(speciesRepo.Species) {
+		t.Errorf("Expected 1 of %d species owned, got %+v", len(speciesRepo.Species), dex.Overall)
+	}
+
+	if _, err := pokedexService.GetPokedex(ctx, uuid.New()); !errors.Is(err, service.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestPokedex_MilestonesPaidOnce(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	mocks.SeedAllRarities(speciesRepo)
+	pokedexService := service.NewPokedexService(userRepo, speciesRepo, mocks.NewMockPokedexRepository(), mocks.NewMockItemRepository())
+
+	user := mocks.CreateTestUser("dex-user")
+	userRepo.Create(ctx, user)
+	startingCoins := user.Coins
+
+	first := domain.PokedexMilestones[0]
+	ids := make([]int, 0, first.Owned)
+	for id := 1; id < first.Owned; id++ {
+		ids = append(ids, id)
+	}
+
+	// Execute: one species short of the first milestone
+	pokedexService.Record(ctx, obtainedEvents(user.ID, ids...)...)
+
+	// Assert
+	if user.Coins != startingCoins {
+		t.Fatalf("Expected nothing paid before the first milestone, got %d coins", user.Coins-startingCoins)
+	}
+
+	// Verify reaching it pays once, even when obtained again
+	pokedexService.Record(ctx, obtainedEvents(user.ID, first.Owned, first.Owned)...)
+	if user.Coins != startingCoins+first.Coins {
+		t.Errorf("Expected %d coins for %d species, got %d", first.Coins, first.Owned, user.Coins-startingCoins)
+	}
+	pokedexService.Record(ctx, obtainedEvents(user.ID, 1, first.Owned)...)
+	if user.Coins != startingCoins+first.Coins {
+		t.Errorf("Expected the milestone paid once, got %d coins", user.Coins-startingCoins)
+	}
+}
+
+func TestPokedex_SeveralMilestonesAtOnce(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	mocks.SeedAllRarities(speciesRepo)
+	pokedexService := service.NewPokedexService(userRepo, speciesRepo, mocks.NewMockPokedexRepository(), itemRepo)
+
+	user := mocks.CreateTestUser("dex-user")
+	userRepo.Create(ctx, user)
+	startingCoins := user.Coins
+
+	first, second := domain.PokedexMilestones[0], domain.PokedexMilestones[1]
+	ids := make([]int, second.Owned)
+	for i := range ids {
+		ids[i] = 100 + i
+	}
+
+	// Execute
+	err := pokedexService.Record(ctx, obtainedEvents(user.ID, ids...)...)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Coins != startingCoins+first.Coins+second.Coins {
+		t.Errorf("Expected both milestones paid, got %d coins", user.Coins-startingCoins)
+	}
+	for item, count := range second.Items {
+		if got, _ := itemRepo.Get(ctx, user.ID, item); got != count {
+			t.Errorf("Expected %d %s, got %d", count, item, got)
+		}
+	}
+
+	dex, _ := pokedexService.GetPokedex(ctx, user.ID)
+	if !dex.Milestones[1].Reached || !dex.Milestones[1].Granted || dex.Milestones[2].Reached {
+		t.Errorf("Expected the first two milestones reached and granted, got %+v", dex.Milestones)
+	}
+}
+
+func TestPokedex_CountsPulls(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	pokedexRepo := mocks.NewMockPokedexRepository()
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+	gachaService.SetEventRecorder(service.NewPokedexService(userRepo, speciesRepo, pokedexRepo, mocks.NewMockItemRepository()))
+
+	user := mocks.CreateTestUser("dex-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+
+	// Execute
+	pokemons, err := gachaService.PremiumRoll(ctx, user.ID, 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, p := range pokemons {
+		if entry := pokedexRepo.Get(user.ID, p.SpeciesID); entry == nil || !entry.IsOwned() {
+			t.Errorf("Expected pulled species %d owned, got %+v", p.SpeciesID, entry)
+		}
+	}
+}
+
+func TestPokedex_TradesKeepSpeciesGivenAway(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	pokedexRepo := mocks.NewMockPokedexRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, notificationRepo)
+
+	pokedexService := service.NewPokedexService(userRepo, mocks.NewMockPokemonSpeciesRepository(), pokedexRepo, mocks.NewMockItemRepository())
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, mocks.NewMockBattleRepository(), notificationRepo, txManager)
+	tradeService.SetEventRecorder(service.EventRecorders{pokedexService})
+
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	userRepo.Create(ctx, alice)
+	userRepo.Create(ctx, bob)
+	pikachu := domain.NewUserPokemon(alice.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	eevee := domain.NewUserPokemon(bob.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, pikachu)
+	pokemonRepo.Create(ctx, eevee)
+	pokedexService.Record(ctx, domain.NewObtainedEvent(bob.ID, eevee.SpeciesID))
+
+	trade, err := tradeService.ProposeTrade(ctx, alice.ID, bob.ID, []uuid.UUID{pikachu.ID}, []uuid.UUID{eevee.ID}, 0, 0)
+	if err != nil {
+		t.Fatalf("Expected no error proposing trade, got %v", err)
+	}
+	tradeService.ConfirmTrade(ctx, alice.ID, trade.ID)
+
+	// Execute
+	_, err = tradeService.ConfirmTrade(ctx, bob.ID, trade.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if entry := pokedexRepo.Get(bob.ID, pikachu.SpeciesID); entry == nil || !entry.IsOwned() {
+		t.Errorf("Expected bob to own the species he received, got %+v", entry)
+	}
+	if entry := pokedexRepo.Get(bob.ID, eevee.SpeciesID); entry == nil || !entry.IsOwned() {
+		t.Errorf("Expected bob to keep the species he traded away, got %+v", entry)
+	}
+	if entry := pokedexRepo.Get(alice.ID, eevee.SpeciesID); entry == nil || !entry.IsOwned() {
+		t.Errorf("Expected alice to own the species she received, got %+v", entry)
+	}
+}
+
+func TestPokedex_CountsEvolutions(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	evolutionRepo := mocks.NewMockEvolutionRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	pokedexRepo := mocks.NewMockPokedexRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, candyRepo, itemRepo)
+
+	evolutionService := service.NewEvolutionService(userRepo, pokemonRepo, evolutionRepo, mocks.NewMockLearnsetRepository(), candyRepo, itemRepo,
+		mocks.NewMockPokemonAuditRepository(), mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+	evolutionService.SetEventRecorder(service.NewPokedexService(userRepo, mocks.NewMockPokemonSpeciesRepository(), pokedexRepo, itemRepo))
+
+	evolutionRepo.Evolutions[4] = []*domain.Evolution{
+		{FromSpeciesID: 4, ToSpeciesID: 5, ToSpecies: mocks.CreateTestSpecies(5, "Charmeleon", domain.Uncommon), CandyCost: 25},
+	}
+
+	user := mocks.CreateTestUser("trainer")
+	userRepo.Create(ctx, user)
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(4, "Charmander", domain.Common))
+	pokemonRepo.Create(ctx, pokemon)
+	candyRepo.Adjust(ctx, user.ID, 4, 25)
+
+	// Execute
+	_, err := evolutionService.Evolve(ctx, user.ID, pokemon.ID, 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if entry := pokedexRepo.Get(user.ID, 5); entry == nil || !entry.IsOwned() {
+		t.Errorf("Expected the evolved species owned, got %+v", entry)
+	}
+}
+
+func TestPokedex_WildBattlesCountAsSeen(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	mocks.SeedAllRarities(speciesRepo)
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	pokedexRepo := mocks.NewMockPokedexRepository()
+
+	wildBattleService := service.NewWildBattleService(speciesRepo, pokemonRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(),
+		mocks.NewMockBattleRepository(), mocks.NewMockTxManager(pokemonRepo))
+	wildBattleService.SetEventRecorder(service.NewPokedexService(mocks.NewMockUserRepository(), mocks.NewMockPokemonSpeciesRepository(), pokedexRepo, mocks.NewMockItemRepository()))
+
+	user := mocks.CreateTestUser("trainer")
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(1, "TestMon", domain.Common))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// Execute
+	result, err := wildBattleService.Battle(ctx, user.ID, pokemon.ID, "")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if entry := pokedexRepo.Get(user.ID, result.Wild.ID); entry == nil || entry.IsOwned() {
+		t.Errorf("Expected the wild species seen but not owned, got %+v", entry)
+	}
+}
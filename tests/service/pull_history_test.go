@@ -0,0 +1,263 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestNewDropRateReport(t *testing.T) {
+	// Execute
+	report := domain.NewDropRateReport(1000, []domain.RarityTally{
+		// 30% expected, 31% observed: fine
+		{Rarity: domain.Common, Pulls: 310, Expected: 300, Variance: 1000 * 0.3 * 0.7},
+		// 5% expected, 15% observed: a rate bug
+		{Rarity: domain.Epic, Pulls: 150, Expected: 50, Variance: 1000 * 0.05 * 0.95},
+		// Impossible pulls
+		{Rarity: domain.Mythic, Pulls: 1},
+	})
+
+	// Assert
+	common := report.Rarities[0]
+	if math.Abs(common.ObservedRate-0.31) > 1e-9 || math.Abs(common.ExpectedRate-0.3) > 1e-9 {
+		t.Errorf("Expected rates of 0.31 observed and 0.30 expected, got %+v", common)
+	}
+	if common.CILow >= 0.31 || common.CIHigh <= 0.31 || !common.WithinCI || common.Alert {
+		t.Errorf("Expected 0.30 inside an interval around 0.31 and no alert, got %+v", common)
+	}
+
+	epic := report.Rarities[1]
+	if epic.WithinCI || !epic.Alert || epic.ZScore < domain.RateAlertZScore {
+		t.Errorf("Expected triple the epic odds to raise an alert, got %+v", epic)
+	}
+
+	if !report.Rarities[2].Alert {
+		t.Error("Expected a pull of a rarity with no odds to raise an alert")
+	}
+
+	if empty := domain.NewDropRateReport(0, nil); len(empty.Rarities) != 0 || empty.Confidence != domain.ReportConfidence {
+		t.Errorf("Expected an empty report without pulls, got %+v", empty)
+	}
+}
+
+func TestPremiumRoll_RecordsPulls(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("puller")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+
+	// Execute
+	pokemons, err := gachaService.PremiumRoll(ctx, user.ID, 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	pulls, err := gachaService.GetPullHistory(ctx, user.ID, domain.PullHistoryFilter{Limit: 100})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pulls) != 10 {
+		t.Fatalf("Expected 10 pulls recorded, got %d", len(pulls))
+	}
+
+	byPokemon := make(map[string]*domain.GachaPull)
+	for _, pull := range pulls {
+		byPokemon[pull.PokemonID.String()] = pull
+		if pull.RollID != pulls[0].RollID || pull.RollType != domain.RollTypePremium || pull.Nonce != nil {
+			t.Errorf("Expected one unfair premium roll, got %+v", pull)
+		}
+		if math.Abs(pull.Odds.Total()-1) > 1e-9 {
+			t.Errorf("Expected odds totalling 1, got %v", pull.Odds)
+		}
+	}
+
+	for i, p := range pokemons {
+		pull := byPokemon[p.ID.String()]
+		if pull == nil || pull.SpeciesID != p.SpeciesID || pull.Rarity != p.Species.Rarity || pull.Species != p.Species.Name {
+			t.Fatalf("Expected pull %d to record %s, got %+v", i+1, p.Species.Name, pull)
+		}
+		if pull.Pity[domain.Epic] > i {
+			t.Errorf("Expected at most %d pulls of epic pity before pull %d, got %d", i, i+1, pull.Pity[domain.Epic])
+		}
+	}
+
+	// Verify the 10th pull can't be commoner than epic
+	tenth := byPokemon[pokemons[9].ID.String()]
+	if tenth.Odds.From(domain.Epic) < 1-1e-9 {
+		t.Errorf("Expected the 10th pull's odds to guarantee epic or better, got %v", tenth.Odds)
+	}
+}
+
+func TestDailyRoll_RecordsPulls(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("puller")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+
+	// Execute
+	if _, err := gachaService.DailyRoll(ctx, user.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := gachaService.PremiumRoll(ctx, user.ID, 2); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	daily, _ := gachaService.GetPullHistory(ctx, user.ID, domain.PullHistoryFilter{RollType: domain.RollTypeDaily})
+	if len(daily) != 5 {
+		t.Fatalf("Expected 5 daily pulls, got %d", len(daily))
+	}
+
+	rareOrBetter := 0
+	for _, pull := range daily {
+		if pull.Odds.From(domain.Rare) > 1-1e-9 {
+			rareOrBetter++
+		}
+	}
+	if rareOrBetter < 1 {
+		t.Error("Expected the 5th daily pull's odds to guarantee rare or better")
+	}
+
+	page, _ := gachaService.GetPullHistory(ctx, user.ID, domain.PullHistoryFilter{Limit: 3, Offset: 5})
+	if len(page) != 2 {
+		t.Errorf("Expected 2 pulls past the first 5 of 7, got %d", len(page))
+	}
+}
+
+func TestFairPremiumRoll_RecordsNonce(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	seedRepo := mocks.NewMockFairSeedRepository()
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), seedRepo, mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, seedRepo))
+
+	user := mocks.CreateTestUser("puller")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+
+	// Execute
+	_, _, err := gachaService.FairPremiumRoll(ctx, user.ID, "", 2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	pulls, _ := gachaService.GetPullHistory(ctx, user.ID, domain.PullHistoryFilter{})
+	nonces := make(map[int]bool)
+	for _, pull := range pulls {
+		if pull.Nonce == nil {
+			t.Fatal("Expected fair pulls to record their nonce")
+		}
+		nonces[*pull.Nonce] = true
+	}
+	if !nonces[0] || !nonces[1] {
+		t.Errorf("Expected nonces 0 and 1, got %v", nonces)
+	}
+}
+
+func TestPremiumRoll_FailedPullRecordsNothing(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	pullRepo := mocks.NewMockGachaPullRepository()
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), pullRepo, mocks.NewMockTxManager(userRepo, pokemonRepo, pullRepo))
+
+	user := mocks.CreateTestUser("puller")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+	pokemonRepo.CreateError = errors.New("database down")
+
+	// Execute
+	_, err := gachaService.PremiumRoll(ctx, user.ID, 3)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected the roll to fail")
+	}
+	if len(pullRepo.Pulls) != 0 {
+		t.Errorf("Expected no pulls recorded, got %d", len(pullRepo.Pulls))
+	}
+}
+
+func TestDropRateReport_MatchesOdds(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	user := mocks.CreateTestUser("puller")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+	gachaService.SetRandSource(rand.NewSource(11))
+
+	// Execute
+	for i := 0; i < 30; i++ {
+		if _, err := gachaService.PremiumRoll(ctx, user.ID, 10); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	standard := domain.StandardBannerID
+	report, err := gachaService.GetDropRateReport(ctx, domain.PullStatsFilter{BannerID: &standard, RollType: domain.RollTypePremium})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.TotalPulls != 300 || len(report.Rarities) != len(domain.Rarities) {
+		t.Fatalf("Expected 300 pulls over every rarity, got %d over %d", report.TotalPulls, len(report.Rarities))
+	}
+
+	pulls := 0
+	for _, stats := range report.Rarities {
+		pulls += stats.Pulls
+		if stats.Alert {
+			t.Errorf("Expected honest pulls not to raise an alert, got %+v", stats)
+		}
+	}
+	if pulls != 300 {
+		t.Errorf("Expected every pull counted once, got %d", pulls)
+	}
+
+	other := "legendary-birds"
+	report, _ = gachaService.GetDropRateReport(ctx, domain.PullStatsFilter{BannerID: &other})
+	if report.TotalPulls != 0 {
+		t.Errorf("Expected no pulls on another banner, got %d", report.TotalPulls)
+	}
+}
@@ -0,0 +1,423 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+func TestActiveQuests_Rotation(t *testing.T) {
+	// Setup: a Wednesday
+	checkInAt(t, "UTC")
+	now := time.Date(2026, 4, 15, 9, 0, 0, 0, time.UTC)
+
+	// Execute
+	active := domain.ActiveQuests(now)
+
+	// Assert
+	counts := make(map[domain.QuestPeriod]int)
+	for _, quest := range active {
+		counts[quest.Period]++
+		switch quest.Period {
+		case domain.QuestDaily:
+			if quest.PeriodKey != "2026-04-15" || !quest.EndsAt.Equal(time.Date(2026, 4, 16, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("Expected %s to run for the day, got %q until %v", quest.ID, quest.PeriodKey, quest.EndsAt)
+			}
+		case domain.QuestWeekly:
+			if quest.PeriodKey != "2026-W16" || !quest.EndsAt.Equal(time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("Expected %s to run until Monday, got %q until %v", quest.ID, quest.PeriodKey, quest.EndsAt)
+			}
+		case domain.QuestAchievement:
+			if quest.PeriodKey != "" || quest.EndsAt != nil {
+				t.Errorf("Expected achievement %s never to rotate, got %q until %v", quest.ID, quest.PeriodKey, quest.EndsAt)
+			}
+		}
+	}
+	if counts[domain.QuestDaily] != domain.DailyQuestCount || counts[domain.QuestWeekly] != domain.WeeklyQuestCount ||
+		counts[domain.QuestAchievement] != len(domain.Achievements) {
+		t.Errorf("Expected %d daily, %d weekly and every achievement, got %v",
+			domain.DailyQuestCount, domain.WeeklyQuestCount, counts)
+	}
+
+	// The same all day, and not the same every day
+	if questIDs(domain.ActiveQuests(now.Add(14*time.Hour))) != questIDs(active) {
+		t.Error("Expected the rotation to stay the same all day")
+	}
+	rotations := make(map[string]bool)
+	for day := 0; day < 14; day++ {
+		rotations[questIDs(domain.ActiveQuests(now.AddDate(0, 0, day)))] = true
+	}
+	if len(rotations) < 2 {
+		t.Error("Expected daily quests to rotate")
+	}
+}
+
+func TestQuest_CountsMinRarity(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	quest := &domain.Quest{Event: domain.EventPull, MinRarity: domain.Legendary, Goal: 1}
+
+	common := domain.NewPullEvent(&domain.GachaPull{UserID: userID, Rarity: domain.Common})
+	mythic := domain.NewPullEvent(&domain.GachaPull{UserID: userID, Rarity: domain.Mythic})
+
+	// Execute and assert
+	if quest.Counts(common) != 0 || quest.Counts(mythic) != 1 {
+		t.Error("Expected only pulls of the minimum rarity or rarer to count")
+	}
+	if quest.Counts(domain.NewGameEvent(domain.EventBattleWon, userID, 1)) != 0 {
+		t.Error("Expected other events not to count")
+	}
+}
+
+func TestRecord_AddsUpEventsCappedAtGoal(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	checkInAt(t, "UTC")
+	userRepo := mocks.NewMockUserRepository()
+	questRepo := mocks.NewMockQuestProgressRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	questService := service.NewQuestService(userRepo, questRepo, itemRepo, mocks.NewMockTxManager(userRepo, questRepo, itemRepo))
+
+	user := mocks.CreateTestUser("quest-user")
+	userRepo.Create(ctx, user)
+
+	events := []domain.GameEvent{
+		domain.NewPullEvent(&domain.GachaPull{UserID: user.ID, Rarity: domain.Common}),
+		domain.NewPullEvent(&domain.GachaPull{UserID: user.ID, Rarity: domain.Legendary}),
+		domain.NewPullEvent(&domain.GachaPull{UserID: user.ID, Rarity: domain.Rare}),
+	}
+
+	// Execute
+	err := questService.Record(ctx, events...)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if p := questRepo.Get(user.ID, "pulls-1000", ""); p == nil || p.Progress != 3 || p.IsCompleted() {
+		t.Errorf("Expected 3 of 1000 pulls, got %+v", p)
+	}
+	if p := questRepo.Get(user.ID, "first-pull", ""); p == nil || p.Progress != 1 || !p.IsCompleted() {
+		t.Errorf("Expected the first pull completed and capped at its goal, got %+v", p)
+	}
+	if p := questRepo.Get(user.ID, "legendary-pull", ""); p == nil || !p.IsCompleted() {
+		t.Errorf("Expected the legendary pull completed, got %+v", p)
+	}
+	if p := questRepo.Get(user.ID, "mythic-pull", ""); p != nil {
+		t.Errorf("Expected no mythic pull progress, got %+v", p)
+	}
+
+	statuses, err := questService.ListQuests(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, status := range statuses {
+		if status.ID == "pulls-1000" && (status.Progress != 3 || status.Completed) {
+			t.Errorf("Expected the listed quest to show 3 pulls, got %+v", status)
+		}
+	}
+
+	if _, err := questService.ListQuests(ctx, uuid.New()); !errors.Is(err, service.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestClaimQuest_PaysOnce(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	checkInAt(t, "UTC")
+	userRepo := mocks.NewMockUserRepository()
+	questRepo := mocks.NewMockQuestProgressRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	questService := service.NewQuestService(userRepo, questRepo, itemRepo, mocks.NewMockTxManager(userRepo, questRepo, itemRepo))
+
+	user := mocks.CreateTestUser("quest-user")
+	userRepo.Create(ctx, user)
+	startingCoins := user.Coins
+
+	questService.Record(ctx, domain.NewGameEvent(domain.EventBattleWon, user.ID, 100))
+
+	// Execute
+	claim, err := questService.ClaimQuest(ctx, user.ID, "battles-100")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if claim.AlreadyClaimed || claim.Coins != startingCoins+3000 || !claim.Quest.Claimed {
+		t.Errorf("Expected 3000 coins paid, got %+v", claim)
+	}
+	if capsules, _ := itemRepo.Get(ctx, user.ID, domain.AbilityCapsule); capsules != 1 {
+		t.Errorf("Expected an Ability Capsule, got %d", capsules)
+	}
+
+	// Verify claiming again reports the reward without paying twice
+	again, err := questService.ClaimQuest(ctx, user.ID, "battles-100")
+	if err != nil {
+		t.Fatalf("Expected claiming again to succeed, got %v", err)
+	}
+	if !again.AlreadyClaimed || again.Reward.Coins != 3000 || again.Coins != startingCoins+3000 {
+		t.Errorf("Expected the same reward reported without paying twice, got %+v", again)
+	}
+	if capsules, _ := itemRepo.Get(ctx, user.ID, domain.AbilityCapsule); capsules != 1 {
+		t.Errorf("Expected still one Ability Capsule, got %d", capsules)
+	}
+}
+
+func TestClaimQuest_Errors(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	checkInAt(t, "UTC")
+	userRepo := mocks.NewMockUserRepository()
+	questRepo := mocks.NewMockQuestProgressRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	questService := service.NewQuestService(userRepo, questRepo, itemRepo, mocks.NewMockTxManager(userRepo, questRepo, itemRepo))
+
+	user := mocks.CreateTestUser("quest-user")
+	userRepo.Create(ctx, user)
+
+	// Execute and assert
+	if _, err := questService.ClaimQuest(ctx, user.ID, "first-pull"); !errors.Is(err, service.ErrQuestNotCompleted) {
+		t.Errorf("Expected ErrQuestNotCompleted, got %v", err)
+	}
+	if _, err := questService.ClaimQuest(ctx, user.ID, "no-such-quest"); !errors.Is(err, service.ErrQuestNotFound) {
+		t.Errorf("Expected ErrQuestNotFound, got %v", err)
+	}
+	if _, err := questService.ClaimQuest(ctx, uuid.New(), "first-pull"); !errors.Is(err, service.ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+
+	// A daily quest from the pool that isn't running today
+	for _, quest := range domain.DailyQuests {
+		if _, ok := domain.FindActiveQuest(quest.ID, time.Now()); !ok {
+			if _, err := questService.ClaimQuest(ctx, user.ID, quest.ID); !errors.Is(err, service.ErrQuestNotFound) {
+				t.Errorf("Expected ErrQuestNotFound for inactive %s, got %v", quest.ID, err)
+			}
+			break
+		}
+	}
+}
+
+func TestClaimQuest_FailedClaimPaysNothing(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	checkInAt(t, "UTC")
+	userRepo := mocks.NewMockUserRepository()
+	questRepo := mocks.NewMockQuestProgressRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	questService := service.NewQuestService(userRepo, questRepo, itemRepo, mocks.NewMockTxManager(userRepo, questRepo, itemRepo))
+
+	user := mocks.CreateTestUser("quest-user")
+	userRepo.Create(ctx, user)
+	startingCoins := user.Coins
+
+	questService.Record(ctx, domain.NewGameEvent(domain.EventMarketSale, user.ID, 50))
+	questRepo.SaveError = errors.New("database down")
+
+	// Execute
+	_, err := questService.ClaimQuest(ctx, user.ID, "market-sales-50")
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected the claim to fail")
+	}
+	if capsules, _ := itemRepo.Get(ctx, user.ID, domain.AbilityCapsule); user.Coins != startingCoins || capsules != 0 {
+		t.Errorf("Expected nothing paid, got %d coins and %d capsules", user.Coins-startingCoins, capsules)
+	}
+
+	// Verify the quest can still be claimed once saving works again
+	questRepo.SaveError = nil
+	if claim, err := questService.ClaimQuest(ctx, user.ID, "market-sales-50"); err != nil || claim.AlreadyClaimed {
+		t.Errorf("Expected the quest still claimable, got %+v (%v)", claim, err)
+	}
+}
+
+func TestQuests_CountPulls(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	checkInAt(t, "UTC")
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	questRepo := mocks.NewMockQuestProgressRepository()
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+	gachaService.SetEventRecorder(service.NewQuestService(userRepo, questRepo, mocks.NewMockItemRepository(), mocks.NewMockTxManager()))
+
+	user := mocks.CreateTestUser("quest-user")
+	user.Coins = 100000
+	userRepo.Create(ctx, user)
+
+	// Execute
+	_, err := gachaService.PremiumRoll(ctx, user.ID, 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if p := questRepo.Get(user.ID, "pulls-1000", ""); p == nil || p.Progress != 10 {
+		t.Errorf("Expected 10 pulls counted, got %+v", p)
+	}
+}
+
+func TestQuests_CountMarketSalesForTheSeller(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	checkInAt(t, "UTC")
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	questRepo := mocks.NewMockQuestProgressRepository()
+
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, marketTxRepo))
+	marketService.SetEventRecorder(service.NewQuestService(userRepo, questRepo, mocks.NewMockItemRepository(), mocks.NewMockTxManager()))
+
+	seller := mocks.CreateTestUser("seller")
+	buyer := mocks.CreateTestUser("buyer")
+	userRepo.Create(ctx, seller)
+	userRepo.Create(ctx, buyer)
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	listing, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 500)
+	if err != nil {
+		t.Fatalf("Expected no error listing Pokemon, got %v", err)
+	}
+
+	// Execute
+	_, err = marketService.BuyListing(ctx, buyer.ID, listing.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if p := questRepo.Get(seller.ID, "market-sales-50", ""); p == nil || p.Progress != 1 {
+		t.Errorf("Expected the seller's sale counted, got %+v", p)
+	}
+	if p := questRepo.Get(buyer.ID, "market-sales-50", ""); p != nil {
+		t.Errorf("Expected nothing counted for the buyer, got %+v", p)
+	}
+}
+
+func TestQuests_CountCompletedTradesForBothSides(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	checkInAt(t, "UTC")
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	questRepo := mocks.NewMockQuestProgressRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, notificationRepo)
+
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, mocks.NewMockBattleRepository(), notificationRepo, txManager)
+	tradeService.SetEventRecorder(service.NewQuestService(userRepo, questRepo, mocks.NewMockItemRepository(), mocks.NewMockTxManager()))
+
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	userRepo.Create(ctx, alice)
+	userRepo.Create(ctx, bob)
+	pikachu := domain.NewUserPokemon(alice.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	eevee := domain.NewUserPokemon(bob.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, pikachu)
+	pokemonRepo.Create(ctx, eevee)
+
+	trade, err := tradeService.ProposeTrade(ctx, alice.ID, bob.ID, []uuid.UUID{pikachu.ID}, []uuid.UUID{eevee.ID}, 0, 0)
+	if err != nil {
+		t.Fatalf("Expected no error proposing trade, got %v", err)
+	}
+	tradeService.ConfirmTrade(ctx, alice.ID, trade.ID)
+	if p := questRepo.Get(alice.ID, "trades-25", ""); p != nil {
+		t.Errorf("Expected nothing counted before the trade completes, got %+v", p)
+	}
+
+	// Execute
+	_, err = tradeService.ConfirmTrade(ctx, bob.ID, trade.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, user := range []*domain.User{alice, bob} {
+		if p := questRepo.Get(user.ID, "trades-25", ""); p == nil || p.Progress != 1 {
+			t.Errorf("Expected the trade counted for %s, got %+v", user.DiscordID, p)
+		}
+	}
+}
+
+func TestQuests_CountPvPWinsAndSeenSpecies(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	checkInAt(t, "UTC")
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	escrowRepo := mocks.NewMockEscrowRepository(userRepo)
+	questRepo := mocks.NewMockQuestProgressRepository()
+	pokedexRepo := mocks.NewMockPokedexRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, battleRepo, escrowRepo)
+
+	battleService := service.NewBattleService(userRepo, pokemonRepo, battleRepo, escrowRepo, txManager)
+	battleService.SetEventRecorder(service.EventRecorders{
+		service.NewQuestService(userRepo, questRepo, mocks.NewMockItemRepository(), mocks.NewMockTxManager()),
+		service.NewPokedexService(userRepo, mocks.NewMockPokemonSpeciesRepository(), pokedexRepo, mocks.NewMockItemRepository()),
+	})
+
+	player1 := mocks.CreateTestUser("discord1")
+	player2 := mocks.CreateTestUser("discord2")
+	userRepo.Create(ctx, player1)
+	userRepo.Create(ctx, player2)
+	p1Pokemon := domain.NewUserPokemon(player1.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	p2Pokemon := domain.NewUserPokemon(player2.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, p1Pokemon)
+	pokemonRepo.Create(ctx, p2Pokemon)
+
+	battle, err := battleService.CreateBattle(ctx, player1.ID, player2.ID, 100, domain.FormatStandard)
+	if err != nil {
+		t.Fatalf("Expected no error creating battle, got %v", err)
+	}
+	battleService.SelectPokemon(ctx, battle.ID, player1.ID, p1Pokemon.ID)
+	if err := battleService.SelectPokemon(ctx, battle.ID, player2.ID, p2Pokemon.ID); err != nil {
+		t.Fatalf("Expected no error starting battle, got %v", err)
+	}
+
+	// Execute
+	err = battleService.ForfeitBattle(ctx, battle.ID, player1.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if p := questRepo.Get(player2.ID, "battles-100", ""); p == nil || p.Progress != 1 {
+		t.Errorf("Expected the winner's battle counted, got %+v", p)
+	}
+	if p := questRepo.Get(player1.ID, "battles-100", ""); p != nil {
+		t.Errorf("Expected nothing counted for the loser, got %+v", p)
+	}
+	if entry := pokedexRepo.Get(player1.ID, 133); entry == nil || entry.IsOwned() {
+		t.Errorf("Expected the opponent's Eevee seen by player 1, got %+v", entry)
+	}
+	if entry := pokedexRepo.Get(player2.ID, 25); entry == nil || entry.IsOwned() {
+		t.Errorf("Expected the opponent's Pikachu seen by player 2, got %+v", entry)
+	}
+}
+
+// questIDs joins the IDs of quests, in order
+func questIDs(quests []*domain.ActiveQuest) string {
+	ids := make([]string, len(quests))
+	for i, quest := range quests {
+		ids[i] = quest.ID
+	}
+	return strings.Join(ids, ",")
+}
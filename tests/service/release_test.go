@@ -0,0 +1,361 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+// releasablePokemon gives the user a Pokemon with every IV set to iv
+func releasablePokemon(repo *mocks.MockUserPokemonRepository, user *domain.User, species *domain.PokemonSpecies, iv int) *domain.UserPokemon {
+	pokemon := domain.NewUserPokemon(user.ID, species)
+	pokemon.IVs = domain.IVs{HP: iv, Attack: iv, Defense: iv, SpAttack: iv, SpDefense: iv, Speed: iv}
+	repo.Create(context.Background(), pokemon)
+	return pokemon
+}
+
+func TestRelease_PaysShareOfValueAndDeletes(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, candyRepo, auditRepo)
+	releaseService := service.NewReleaseService(userRepo, pokemonRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), candyRepo, auditRepo, service.NewValuationService(mocks.NewMockMarketTransactionRepository()), txManager)
+
+	user := mocks.CreateTestUser("releaser")
+	userRepo.Create(ctx, user)
+	pidgey := releasablePokemon(pokemonRepo, user, mocks.CreateTestSpecies(16, "Pidgey", domain.Common), 10)
+	startCoins := user.Coins
+
+	expected := domain.ReleasePayout(pidgey.FormulaValue())
+
+	// Execute
+	result, err := releaseService.Release(ctx, user.ID, &domain.ReleaseFilter{PokemonIDs: []uuid.UUID{pidgey.ID}}, false)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Released) != 1 || result.TotalPayout != expected || result.DryRun {
+		t.Fatalf("Expected one release paying %d, got %d paying %d", expected, len(result.Released), result.TotalPayout)
+	}
+
+	if _, err := pokemonRepo.GetByID(ctx, pidgey.ID); err == nil {
+		t.Error("Expected released Pokemon to be deleted")
+	}
+
+	if user.Coins != startCoins+expected {
+		t.Errorf("Expected %d coins, got %d", startCoins+expected, user.Coins)
+	}
+
+	// Verify candy and the audit log
+	candy, _ := candyRepo.Get(ctx, user.ID, 16)
+	if candy != domain.CandyForRelease(domain.Common) {
+		t.Errorf("Expected %d Pidgey candy, got %d", domain.CandyForRelease(domain.Common), candy)
+	}
+
+	history, _ := auditRepo.ListByPokemon(ctx, pidgey.ID, 10)
+	if len(history) != 1 || history[0].Action != domain.AuditRelease || history[0].CandyDelta != candy {
+		t.Errorf("Expected the release in the audit log, got %v", history)
+	}
+}
+
+func TestRelease_PayoutCappedAtFormulaValue(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	transactionRepo := mocks.NewMockMarketTransactionRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo)
+	releaseService := service.NewReleaseService(userRepo, pokemonRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockCandyRepository(), mocks.NewMockPokemonAuditRepository(), service.NewValuationService(transactionRepo), txManager)
+
+	user := mocks.CreateTestUser("releaser")
+	userRepo.Create(ctx, user)
+	pidgey := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+	pokemonRepo.Create(ctx, pidgey)
+
+	// Pidgey sold back and forth between friends for far more than it's worth
+	recordSales(transactionRepo, 16, domain.ReferenceIVPercent, 50000, 50000, 50000, 50000, 50000)
+
+	// Execute
+	result, err := releaseService.Release(ctx, user.ID, &domain.ReleaseFilter{PokemonIDs: []uuid.UUID{pidgey.ID}}, true)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := domain.ReleasePayout(pidgey.FormulaValue())
+	if len(result.Released) != 1 || result.TotalPayout != expected {
+		t.Errorf("Expected a payout of %d from the formula value, got %d", expected, result.TotalPayout)
+	}
+}
+
+func TestRelease_DryRunChangesNothing(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo)
+	releaseService := service.NewReleaseService(userRepo, pokemonRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockCandyRepository(), mocks.NewMockPokemonAuditRepository(), service.NewValuationService(mocks.NewMockMarketTransactionRepository()), txManager)
+
+	user := mocks.CreateTestUser("releaser")
+	userRepo.Create(ctx, user)
+	species := mocks.CreateTestSpecies(16, "Pidgey", domain.Common)
+	releasablePokemon(pokemonRepo, user, species, 5)
+	releasablePokemon(pokemonRepo, user, species, 6)
+	startCoins := user.Coins
+
+	// Execute
+	result, err := releaseService.Release(ctx, user.ID, &domain.ReleaseFilter{SpeciesID: 16}, true)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !result.DryRun || len(result.Released) != 2 || result.TotalPayout == 0 {
+		t.Fatalf("Expected a preview of two releases, got %+v", result)
+	}
+
+	if count, _ := pokemonRepo.CountByUser(ctx, user.ID); count != 2 {
+		t.Errorf("Expected box untouched by a dry run, got %d Pokemon", count)
+	}
+
+	if user.Coins != startCoins {
+		t.Errorf("Expected coins untouched by a dry run, got %d", user.Coins)
+	}
+}
+
+func TestRelease_ProtectsFavoritesListingsBattlesAndTrades(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, battleRepo)
+	releaseService := service.NewReleaseService(userRepo, pokemonRepo, listingRepo, tradeRepo, battleRepo, mocks.NewMockCandyRepository(), mocks.NewMockPokemonAuditRepository(), service.NewValuationService(mocks.NewMockMarketTransactionRepository()), txManager)
+
+	user := mocks.CreateTestUser("releaser")
+	userRepo.Create(ctx, user)
+	species := mocks.CreateTestSpecies(16, "Pidgey", domain.Common)
+
+	favorite := releasablePokemon(pokemonRepo, user, species, 5)
+	favorite.IsFavorite = true
+
+	listed := releasablePokemon(pokemonRepo, user, species, 5)
+	listingRepo.Create(ctx, domain.NewMarketListing(listed, 100))
+
+	battling := releasablePokemon(pokemonRepo, user, species, 5)
+	battle := domain.NewBattle(user.ID, uuid.New(), 0)
+	battle.Player1Pokemon = battling.ID
+	battle.Status = domain.BattleStatusInProgress
+	battleRepo.Create(ctx, battle)
+
+	trading := releasablePokemon(pokemonRepo, user, species, 5)
+	tradeRepo.Create(ctx, domain.NewTrade(uuid.New(), user.ID, nil, []uuid.UUID{trading.ID}, 100, 0))
+
+	// Pokemon from a finished battle are free to go
+	fought := releasablePokemon(pokemonRepo, user, species, 5)
+	finished := domain.NewBattle(user.ID, uuid.New(), 0)
+	finished.Player1Pokemon = fought.ID
+	finished.Status = domain.BattleStatusCompleted
+	battleRepo.Create(ctx, finished)
+
+	// Execute
+	result, err := releaseService.Release(ctx, user.ID, &domain.ReleaseFilter{SpeciesID: 16}, false)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Released) != 1 || result.Released[0].Pokemon.ID != fought.ID {
+		t.Fatalf("Expected only the Pokemon from the finished battle released, got %d", len(result.Released))
+	}
+
+	// Verify every other Pokemon is kept with its reason
+	reasons := make(map[uuid.UUID]domain.ReleaseProtection)
+	for _, p := range result.Protected {
+		reasons[p.Pokemon.ID] = p.Reason
+	}
+	expected := map[uuid.UUID]domain.ReleaseProtection{
+		favorite.ID: domain.ProtectedFavorite,
+		listed.ID:   domain.ProtectedListed,
+		battling.ID: domain.ProtectedInBattle,
+		trading.ID:  domain.ProtectedInTrade,
+	}
+	for id, reason := range expected {
+		if reasons[id] != reason {
+			t.Errorf("Expected %s to be protected as %q, got %q", id, reason, reasons[id])
+		}
+		if _, err := pokemonRepo.GetByID(ctx, id); err != nil {
+			t.Errorf("Expected protected Pokemon %s to be kept", id)
+		}
+	}
+}
+
+func TestRelease_KeepsBestDuplicates(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo)
+	releaseService := service.NewReleaseService(userRepo, pokemonRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockCandyRepository(), mocks.NewMockPokemonAuditRepository(), service.NewValuationService(mocks.NewMockMarketTransactionRepository()), txManager)
+
+	user := mocks.CreateTestUser("releaser")
+	userRepo.Create(ctx, user)
+	pidgey := mocks.CreateTestSpecies(16, "Pidgey", domain.Common)
+	rattata := mocks.CreateTestSpecies(19, "Rattata", domain.Common)
+
+	best := releasablePokemon(pokemonRepo, user, pidgey, 30)
+	second := releasablePokemon(pokemonRepo, user, pidgey, 20)
+	worst := releasablePokemon(pokemonRepo, user, pidgey, 10)
+	onlyRattata := releasablePokemon(pokemonRepo, user, rattata, 1)
+
+	// Execute
+	result, err := releaseService.Release(ctx, user.ID, &domain.ReleaseFilter{KeepPerSpecies: 2}, false)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Released) != 1 || result.Released[0].Pokemon.ID != worst.ID {
+		t.Fatalf("Expected only the lowest IV duplicate released, got %d", len(result.Released))
+	}
+
+	for _, kept := range []*domain.UserPokemon{best, second, onlyRattata} {
+		if _, err := pokemonRepo.GetByID(ctx, kept.ID); err != nil {
+			t.Errorf("Expected %s to be kept", kept.ID)
+		}
+	}
+}
+
+func TestRelease_FiltersByRarityAndIV(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo)
+	releaseService := service.NewReleaseService(userRepo, pokemonRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockCandyRepository(), mocks.NewMockPokemonAuditRepository(), service.NewValuationService(mocks.NewMockMarketTransactionRepository()), txManager)
+
+	user := mocks.CreateTestUser("releaser")
+	userRepo.Create(ctx, user)
+	common := mocks.CreateTestSpecies(16, "Pidgey", domain.Common)
+	rare := mocks.CreateTestSpecies(25, "Pikachu", domain.Rare)
+
+	weakCommon := releasablePokemon(pokemonRepo, user, common, 5) // 16%
+	releasablePokemon(pokemonRepo, user, common, 25)              // 80%
+	releasablePokemon(pokemonRepo, user, rare, 5)
+
+	// Execute
+	filter := &domain.ReleaseFilter{Rarity: domain.Common, MaxIVPercent: 50}
+	result, err := releaseService.Release(ctx, user.ID, filter, true)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Released) != 1 || result.Released[0].Pokemon.ID != weakCommon.ID {
+		t.Fatalf("Expected only the weak common Pokemon selected, got %d", len(result.Released))
+	}
+}
+
+func TestRelease_RejectsEmptyFilterAndOthersPokemon(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo)
+	releaseService := service.NewReleaseService(userRepo, pokemonRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockCandyRepository(), mocks.NewMockPokemonAuditRepository(), service.NewValuationService(mocks.NewMockMarketTransactionRepository()), txManager)
+
+	user := mocks.CreateTestUser("releaser")
+	userRepo.Create(ctx, user)
+	releasablePokemon(pokemonRepo, user, mocks.CreateTestSpecies(16, "Pidgey", domain.Common), 5)
+
+	// Execute and assert
+	if _, err := releaseService.Release(ctx, user.ID, &domain.ReleaseFilter{}, false); !errors.Is(err, validators.ErrEmptyReleaseFilter) {
+		t.Errorf("Expected ErrEmptyReleaseFilter, got %v", err)
+	}
+
+	if _, err := releaseService.Release(ctx, user.ID, &domain.ReleaseFilter{MaxIVPercent: 150}, false); !errors.Is(err, validators.ErrInvalidReleaseIV) {
+		t.Errorf("Expected ErrInvalidReleaseIV, got %v", err)
+	}
+
+	// Verify another user's Pokemon can't be released
+	other := domain.NewUserPokemon(uuid.New(), mocks.CreateTestSpecies(16, "Pidgey", domain.Common))
+	pokemonRepo.Create(ctx, other)
+	filter := &domain.ReleaseFilter{PokemonIDs: []uuid.UUID{other.ID}}
+	if _, err := releaseService.Release(ctx, user.ID, filter, false); !errors.Is(err, service.ErrNotPokemonOwner) {
+		t.Errorf("Expected ErrNotPokemonOwner, got %v", err)
+	}
+
+	if _, err := pokemonRepo.GetByID(ctx, other.ID); err != nil {
+		t.Error("Expected another user's Pokemon to be untouched")
+	}
+}
+
+func TestRelease_FailureRollsBackEverything(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	candyRepo := mocks.NewMockCandyRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, candyRepo, auditRepo)
+	releaseService := service.NewReleaseService(userRepo, pokemonRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), candyRepo, auditRepo, service.NewValuationService(mocks.NewMockMarketTransactionRepository()), txManager)
+
+	user := mocks.CreateTestUser("releaser")
+	userRepo.Create(ctx, user)
+	species := mocks.CreateTestSpecies(16, "Pidgey", domain.Common)
+	releasablePokemon(pokemonRepo, user, species, 5)
+	releasablePokemon(pokemonRepo, user, species, 6)
+	startCoins := user.Coins
+	pokemonRepo.FailDeleteAt = 2
+
+	// Execute
+	_, err := releaseService.Release(ctx, user.ID, &domain.ReleaseFilter{SpeciesID: 16}, false)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected the failed delete to fail the release")
+	}
+
+	if count, _ := pokemonRepo.CountByUser(ctx, user.ID); count != 2 {
+		t.Errorf("Expected both Pokemon restored, got %d", count)
+	}
+
+	if user.Coins != startCoins {
+		t.Errorf("Expected no payout, got %d coins", user.Coins)
+	}
+
+	if candy, _ := candyRepo.Get(ctx, user.ID, 16); candy != 0 || len(auditRepo.Entries) != 0 {
+		t.Errorf("Expected no candy or audit entries, got %d candy and %d entries", candy, len(auditRepo.Entries))
+	}
+}
+
+func TestDuplicates_TiesKeepTheOldest(t *testing.T) {
+	species := mocks.CreateTestSpecies(16, "Pidgey", domain.Common)
+	older := domain.NewUserPokemon(uuid.New(), species)
+	newer := domain.NewUserPokemon(older.UserID, species)
+	newer.IVs = older.IVs
+	older.AcquiredAt = newer.AcquiredAt.Add(-time.Hour)
+
+	duplicates := domain.Duplicates([]*domain.UserPokemon{newer, older}, 1)
+	if !duplicates[newer.ID] || duplicates[older.ID] {
+		t.Errorf("Expected the newer of two equal Pokemon to be the duplicate, got %v", duplicates)
+	}
+}
@@ -0,0 +1,181 @@
+package service_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestAliasTable_FollowsWeights(t *testing.T) {
+	weights := []float64{1, 2, 0, 7}
+	table := domain.NewAliasTable(weights)
+
+	r := rand.New(rand.NewSource(42))
+	counts := make([]int, len(weights))
+	const samples = 100000
+	for i := 0; i < samples; i++ {
+		counts[table.Sample(r)]++
+	}
+
+	if counts[2] != 0 {
+		t.Errorf("Expected a zero weight never to be picked, got %d", counts[2])
+	}
+	for i, weight := range weights {
+		want := weight / 10
+		if got := float64(counts[i]) / samples; math.Abs(got-want) > 0.01 {
+			t.Errorf("Expected index %d about %.2f of the time, got %.3f", i, want, got)
+		}
+	}
+
+	if domain.NewAliasTable([]float64{0, 0}) != nil {
+		t.Error("Expected no table without a positive weight")
+	}
+}
+
+func TestPremiumRoll_RespectsDropWeight(t *testing.T) {
+	// Setup: two commons, Pidgey weighing 9 and Rattata 1
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+
+	pidgey := mocks.CreateTestSpecies(16, "Pidgey", domain.Common)
+	pidgey.DropWeight = 9
+	speciesRepo.Create(ctx, pidgey)
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(19, "Rattata", domain.Common))
+
+	user := mocks.CreateTestUser("sampler-user")
+	user.Coins = 1000000
+	userRepo.Create(ctx, user)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(),
+		mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+	gachaService.SetRandSource(rand.NewSource(7))
+
+	// Execute
+	pidgeys := 0
+	for i := 0; i < 10; i++ {
+		pokemons, err := gachaService.PremiumRoll(ctx, user.ID, 50)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, p := range pokemons {
+			if p.SpeciesID == 16 {
+				pidgeys++
+			}
+		}
+	}
+
+	// Assert: about 450 of 500
+	if pidgeys < 420 || pidgeys > 480 {
+		t.Errorf("Expected Pidgey about 90%% of the time, got %d of 500", pidgeys)
+	}
+
+	// Species are loaded once and sampled in memory
+	if speciesRepo.ListCalls != 1 || speciesRepo.RandomIndex != 0 {
+		t.Errorf("Expected 1 species load and no per-card queries, got %d loads and %d queries",
+			speciesRepo.ListCalls, speciesRepo.RandomIndex)
+	}
+}
+
+func TestPremiumRoll_SameSeedSamePulls(t *testing.T) {
+	// Setup: two gacha services over the same species, seeded alike
+	ctx := context.Background()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pidgey := mocks.CreateTestSpecies(16, "Pidgey", domain.Common)
+	pidgey.DropWeight = 9
+	speciesRepo.Create(ctx, pidgey)
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(19, "Rattata", domain.Common))
+
+	firstUserRepo := mocks.NewMockUserRepository()
+	firstPokemonRepo := mocks.NewMockUserPokemonRepository()
+	first := service.NewGachaService(firstUserRepo, speciesRepo, firstPokemonRepo, mocks.NewMockRollReceiptRepository(),
+		mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(firstUserRepo, firstPokemonRepo))
+	first.SetRandSource(rand.NewSource(99))
+	firstUser := mocks.CreateTestUser("first-user")
+	firstUser.Coins = 1000000
+	firstUserRepo.Create(ctx, firstUser)
+
+	secondUserRepo := mocks.NewMockUserRepository()
+	secondPokemonRepo := mocks.NewMockUserPokemonRepository()
+	second := service.NewGachaService(secondUserRepo, speciesRepo, secondPokemonRepo, mocks.NewMockRollReceiptRepository(),
+		mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(secondUserRepo, secondPokemonRepo))
+	second.SetRandSource(rand.NewSource(99))
+	secondUser := mocks.CreateTestUser("second-user")
+	secondUser.Coins = 1000000
+	secondUserRepo.Create(ctx, secondUser)
+
+	// Execute
+	a, err := first.PremiumRoll(ctx, firstUser.ID, 30)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	b, err := second.PremiumRoll(ctx, secondUser.ID, 30)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	for i := range a {
+		if a[i].SpeciesID != b[i].SpeciesID {
+			t.Fatalf("Expected the same species at pull %d, got %d and %d", i+1, a[i].SpeciesID, b[i].SpeciesID)
+		}
+	}
+}
+
+func TestGachaService_RefreshSpecies(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+
+	pidgey := mocks.CreateTestSpecies(16, "Pidgey", domain.Common)
+	pidgey.DropWeight = 9
+	speciesRepo.Create(ctx, pidgey)
+	speciesRepo.Create(ctx, mocks.CreateTestSpecies(19, "Rattata", domain.Common))
+
+	user := mocks.CreateTestUser("sampler-user")
+	user.Coins = 1000000
+	userRepo.Create(ctx, user)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(),
+		mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+	gachaService.SetRandSource(rand.NewSource(3))
+
+	if _, err := gachaService.PremiumRoll(ctx, user.ID, 1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// A new species is invisible to pulls until the cache is refreshed
+	spearow := mocks.CreateTestSpecies(21, "Spearow", domain.Common)
+	spearow.DropWeight = 1e9
+	speciesRepo.Create(ctx, spearow)
+
+	pokemons, _ := gachaService.PremiumRoll(ctx, user.ID, 20)
+	for _, p := range pokemons {
+		if p.SpeciesID == 21 {
+			t.Fatal("Expected the cached species to be used before a refresh")
+		}
+	}
+
+	// Execute
+	gachaService.RefreshSpecies()
+
+	// Assert
+	pokemons, _ = gachaService.PremiumRoll(ctx, user.ID, 20)
+	spearows := 0
+	for _, p := range pokemons {
+		if p.SpeciesID == 21 {
+			spearows++
+		}
+	}
+	if spearows < 19 {
+		t.Errorf("Expected nearly every pull to be the heavily weighted Spearow after a refresh, got %d of 20", spearows)
+	}
+}
@@ -0,0 +1,164 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestNewTypeMatchup(t *testing.T) {
+	flying := domain.Flying
+	matchup := domain.NewTypeMatchup(domain.Grass, &flying)
+
+	for attackType, want := range map[domain.PokemonType]float64{
+		domain.Ice:    4,
+		domain.Ground: 0,
+		domain.Grass:  0.25,
+		domain.Water:  0.5,
+		domain.Normal: 1,
+	} {
+		if got := matchup.Multipliers[attackType]; got != want {
+			t.Errorf("Expected %s to deal %vx, got %vx", attackType, want, got)
+		}
+	}
+	if len(matchup.Multipliers) != len(domain.AllTypes()) {
+		t.Errorf("Expected every type in the chart, got %d", len(matchup.Multipliers))
+	}
+	if !slices.Equal(matchup.Immunities, []domain.PokemonType{domain.Ground}) {
+		t.Errorf("Expected only a Ground immunity, got %v", matchup.Immunities)
+	}
+	if !slices.Contains(matchup.Weaknesses, domain.Ice) || slices.Contains(matchup.Weaknesses, domain.Ground) {
+		t.Errorf("Expected an Ice weakness and no Ground weakness, got %v", matchup.Weaknesses)
+	}
+	if !slices.Contains(matchup.Resistances, domain.Grass) {
+		t.Errorf("Expected a Grass resistance, got %v", matchup.Resistances)
+	}
+
+	single := domain.NewTypeMatchup(domain.Normal, nil)
+	if !slices.Equal(single.Weaknesses, []domain.PokemonType{domain.Fighting}) || !slices.Equal(single.Immunities, []domain.PokemonType{domain.Ghost}) {
+		t.Errorf("Expected Normal weak to Fighting and immune to Ghost, got %+v", single)
+	}
+}
+
+// speciesIDs returns the Pokedex numbers of species, in order
+func speciesIDs(species []*domain.PokemonSpecies) []int {
+	ids := make([]int, len(species))
+	for i, s := range species {
+		ids[i] = s.ID
+	}
+	return ids
+}
+
+func TestSpeciesService_SearchSpecies(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	speciesService := service.NewSpeciesService(speciesRepo, mocks.NewMockLearnsetRepository())
+	poison, flying := domain.Poison, domain.Flying
+
+	bulbasaur := mocks.CreateTestSpecies(1, "Bulbasaur", domain.Uncommon)
+	bulbasaur.Type1, bulbasaur.Type2 = domain.Grass, &poison
+	bulbasaur.BaseSpeed = 45
+
+	pidgey := mocks.CreateTestSpecies(16, "Pidgey", domain.Common)
+	pidgey.Type1, pidgey.Type2 = domain.Normal, &flying
+	pidgey.BaseSpeed = 56
+	pidgey.BaseHP = 40
+
+	pikachu := mocks.CreateTestSpecies(25, "Pikachu", domain.Uncommon)
+	pikachu.Type1 = domain.Electric
+	pikachu.BaseSpeed = 90
+
+	rattata := mocks.CreateTestSpecies(19, "Rattata", domain.Common)
+	rattata.Type1 = domain.Normal
+	rattata.BaseSpeed = 72
+
+	for _, s := range []*domain.PokemonSpecies{bulbasaur, pidgey, pikachu, rattata} {
+		speciesRepo.Create(ctx, s)
+	}
+
+	tests := []struct {
+		name   string
+		filter domain.SpeciesSearchFilter
+		want   []int
+	}{
+		{"everything by Pokedex number", domain.SpeciesSearchFilter{}, []int{1, 16, 19, 25}},
+		{"primary type", domain.SpeciesSearchFilter{Type1: domain.Normal}, []int{16, 19}},
+		{"secondary type", domain.SpeciesSearchFilter{Type2: domain.Flying}, []int{16}},
+		{"rarity", domain.SpeciesSearchFilter{Rarity: domain.Uncommon}, []int{1, 25}},
+		{"stat bounds", domain.SpeciesSearchFilter{MinStats: map[string]int{"speed": 50}, MaxStats: map[string]int{"speed": 80}}, []int{16, 19}},
+		{"total", domain.SpeciesSearchFilter{MaxStats: map[string]int{domain.SpeciesStatTotal: 550}}, []int{1, 16}},
+		{"sorted by stat", domain.SpeciesSearchFilter{Sort: "speed", Descending: true}, []int{25, 19, 16, 1}},
+		{"sorted by name", domain.SpeciesSearchFilter{Sort: domain.SpeciesSortName}, []int{1, 16, 25, 19}},
+		{"paged", domain.SpeciesSearchFilter{Sort: "speed", Limit: 2, Offset: 1}, []int{16, 19}},
+		{"past the end", domain.SpeciesSearchFilter{Offset: 10}, []int{}},
+	}
+
+	// Execute and assert
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			species, err := speciesService.SearchSpecies(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got := speciesIDs(species); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected species %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSpeciesService_GetSpecies(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	learnsetRepo := mocks.NewMockLearnsetRepository()
+	speciesService := service.NewSpeciesService(speciesRepo, learnsetRepo)
+
+	poison := domain.Poison
+	bulbasaur := mocks.CreateTestSpecies(1, "Bulbasaur", domain.Uncommon)
+	bulbasaur.Type1, bulbasaur.Type2 = domain.Grass, &poison
+	bulbasaur.Abilities, bulbasaur.HiddenAbility = []string{domain.AbilityOvergrow}, "chlorophyll"
+	speciesRepo.Create(ctx, bulbasaur)
+	learnsetRepo.Learnsets[1] = []*domain.Move{{ID: 1, Name: "Vine Whip"}, {ID: 2, Name: "Tackle"}}
+
+	// Execute
+	details, err := speciesService.GetSpecies(ctx, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if details.Species.Name != "Bulbasaur" || len(details.Learnset) != 2 {
+		t.Errorf("Expected Bulbasaur with its learnset, got %+v", details)
+	}
+	if details.Matchups.Multipliers[domain.Fire] != 2 || details.Matchups.Multipliers[domain.Grass] != 0.25 {
+		t.Errorf("Expected the Grass/Poison matchups, got %v", details.Matchups.Multipliers)
+	}
+
+	want := []domain.SpeciesAbility{
+		{Name: domain.AbilityOvergrow, DisplayName: "Overgrow", Description: domain.GetAbilityByName(domain.AbilityOvergrow).Description},
+		{Name: "chlorophyll", DisplayName: "Chlorophyll", Hidden: true},
+	}
+	if !slices.Equal(details.Abilities, want) {
+		t.Errorf("Expected abilities %+v, got %+v", want, details.Abilities)
+	}
+
+	// Verify lookups by name and of unknown species
+	byName, err := speciesService.GetSpeciesByName(ctx, "bulbasaur")
+	if err != nil || byName.Species.ID != 1 {
+		t.Errorf("Expected Bulbasaur by name ignoring case, got %+v, %v", byName, err)
+	}
+
+	if _, err := speciesService.GetSpecies(ctx, 999); !errors.Is(err, service.ErrSpeciesNotFound) {
+		t.Errorf("Expected ErrSpeciesNotFound, got %v", err)
+	}
+	if _, err := speciesService.GetSpeciesByName(ctx, "missingno"); !errors.Is(err, service.ErrSpeciesNotFound) {
+		t.Errorf("Expected ErrSpeciesNotFound, got %v", err)
+	}
+}
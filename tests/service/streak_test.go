@@ -0,0 +1,245 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+// checkInAt sets the check-in timezone for a test
+func checkInAt(t *testing.T, timezone string) {
+	t.Helper()
+	if err := domain.SetCheckInTimezone(timezone); err != nil {
+		t.Fatalf("Expected %s to load, got %v", timezone, err)
+	}
+	t.Cleanup(func() { domain.SetCheckInTimezone("UTC") })
+}
+
+func TestLoginStreak_CheckInDays(t *testing.T) {
+	checkInAt(t, "America/New_York")
+	streak := domain.NewLoginStreak(mocks.CreateTestUser("streak-days").ID)
+
+	// 23:00 and 00:30 New York time fall on different check-in days
+	first := time.Date(2026, 3, 2, 4, 0, 0, 0, time.UTC)
+	if day, ok := streak.CheckIn(first); !ok || day != 1 {
+		t.Fatalf("Expected day 1, got %d (%v)", day, ok)
+	}
+	if _, ok := streak.CheckIn(first.Add(30 * time.Minute)); ok {
+		t.Error("Expected a second check-in before midnight to be refused")
+	}
+	second := first.Add(90 * time.Minute)
+	if day, ok := streak.CheckIn(second); !ok || day != 2 {
+		t.Fatalf("Expected day 2 after midnight, got %d (%v)", day, ok)
+	}
+
+	// One missed day is forgiven, two are not
+	third := second.Add(48 * time.Hour)
+	if day, _ := streak.CheckIn(third); day != 3 {
+		t.Fatalf("Expected one missed day to keep the streak, got day %d", day)
+	}
+	if streak.CurrentAt(third.Add(72*time.Hour)) != 0 {
+		t.Error("Expected two missed days to break the streak")
+	}
+	if day, _ := streak.CheckIn(third.Add(72 * time.Hour)); day != 1 || streak.Longest != 3 || streak.TotalCheckIns != 4 {
+		t.Errorf("Expected a fresh streak keeping the longest of 3 over 4 check-ins, got %+v", streak)
+	}
+
+	if err := domain.SetCheckInTimezone("Mars/Olympus_Mons"); !errors.Is(err, domain.ErrInvalidTimezone) {
+		t.Errorf("Expected ErrInvalidTimezone, got %v", err)
+	}
+}
+
+func TestLoginStreak_Status(t *testing.T) {
+	checkInAt(t, "UTC")
+	streak := domain.NewLoginStreak(mocks.CreateTestUser("streak-status").ID)
+	now := time.Date(2026, 5, 10, 15, 0, 0, 0, time.UTC)
+
+	status := streak.Status(now)
+	if status.Current != 0 || status.CheckedIn || status.BreaksAt != nil || status.NextReward.Day != 1 {
+		t.Errorf("Expected a new player to start on day 1, got %+v", status)
+	}
+
+	for day := 0; day < 5; day++ {
+		streak.CheckIn(now.AddDate(0, 0, day))
+	}
+	status = streak.Status(now.AddDate(0, 0, 4))
+	if !status.CheckedIn || status.Current != 5 || status.NextReward.Day != 6 {
+		t.Fatalf("Expected day 5 checked in with day 6 next, got %+v", status)
+	}
+	if !status.NextCheckIn.Equal(time.Date(2026, 5, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the next check-in at midnight, got %v", status.NextCheckIn)
+	}
+	if !status.BreaksAt.Equal(time.Date(2026, 5, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the streak to break after a missed day, got %v", status.BreaksAt)
+	}
+	if status.NextMilestone == nil || status.NextMilestone.Day != 7 {
+		t.Errorf("Expected day 7 as the next milestone, got %+v", status.NextMilestone)
+	}
+}
+
+func TestStreakRewardFor(t *testing.T) {
+	tests := []struct {
+		day       int
+		coins     int
+		pokemon   domain.Rarity
+		capsules  int
+		milestone bool
+	}{
+		{day: 1, coins: 100},
+		{day: 2, coins: 120},
+		{day: 6, coins: 200},
+		{day: 7, coins: 720, pokemon: domain.Rare, milestone: true},
+		{day: 8, coins: 220},
+		{day: 14, coins: 720, pokemon: domain.Rare, capsules: 1, milestone: true},
+		{day: 30, coins: 2220, pokemon: domain.Epic, milestone: true},
+		{day: 210, coins: 2720, pokemon: domain.Epic, capsules: 1, milestone: true},
+	}
+
+	for _, tt := range tests {
+		reward := domain.StreakRewardFor(tt.day)
+		if reward.Coins != tt.coins || reward.Pokemon != tt.pokemon || reward.Milestone != tt.milestone ||
+			reward.Items[domain.AbilityCapsule] != tt.capsules {
+			t.Errorf("Day %d: expected %d coins, %q Pokemon and %d capsules, got %+v",
+				tt.day, tt.coins, tt.pokemon, tt.capsules, reward)
+		}
+	}
+}
+
+// streakSinceYesterday returns a streak of current days last checked in the
+// day before today
+func streakSinceYesterday(userID uuid.UUID, current int) *domain.LoginStreak {
+	yesterday := time.Now().AddDate(0, 0, -1)
+	return &domain.LoginStreak{
+		UserID:        userID,
+		Current:       current,
+		Longest:       current,
+		TotalCheckIns: current,
+		LastCheckIn:   &yesterday,
+	}
+}
+
+func TestCheckIn_GrantsReward(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	checkInAt(t, "UTC")
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	streakRepo := mocks.NewMockLoginStreakRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, streakRepo, itemRepo)
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), txManager)
+	streakService := service.NewStreakService(userRepo, streakRepo, itemRepo, gachaService, txManager)
+
+	user := mocks.CreateTestUser("streak-user")
+	userRepo.Create(ctx, user)
+	startingCoins := user.Coins
+
+	// Execute
+	result, err := streakService.CheckIn(ctx, user.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Reward.Day != 1 || result.Coins != startingCoins+domain.DailyLoginBonus || result.Pokemon != nil {
+		t.Errorf("Expected the day 1 bonus and no Pokemon, got %+v", result)
+	}
+	if !result.Streak.CheckedIn || result.Streak.Current != 1 {
+		t.Errorf("Expected a checked-in streak of 1, got %+v", result.Streak)
+	}
+
+	// Verify a second check-in the same day is refused
+	if _, err := streakService.CheckIn(ctx, user.ID); !errors.Is(err, service.ErrAlreadyCheckedIn) {
+		t.Errorf("Expected ErrAlreadyCheckedIn, got %v", err)
+	}
+	if user.Coins != startingCoins+domain.DailyLoginBonus {
+		t.Errorf("Expected a refused check-in to pay nothing, got %d coins", user.Coins)
+	}
+}
+
+func TestCheckIn_Milestones(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	checkInAt(t, "UTC")
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	streakRepo := mocks.NewMockLoginStreakRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, streakRepo, itemRepo)
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), txManager)
+	streakService := service.NewStreakService(userRepo, streakRepo, itemRepo, gachaService, txManager)
+
+	user := mocks.CreateTestUser("streak-user")
+	userRepo.Create(ctx, user)
+	streakRepo.Streaks[user.ID] = streakSinceYesterday(user.ID, 13)
+
+	// Execute
+	result, err := streakService.CheckIn(ctx, user.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Reward.Day != 14 || result.Pokemon == nil || result.Pokemon.Species.Rarity.Value() < domain.Rare.Value() {
+		t.Fatalf("Expected a Rare or better Pokemon on day 14, got %+v", result)
+	}
+	if _, err := pokemonRepo.GetByID(ctx, result.Pokemon.ID); err != nil {
+		t.Errorf("Expected the milestone Pokemon to be saved, got %v", err)
+	}
+	if capsules, _ := itemRepo.Get(ctx, user.ID, domain.AbilityCapsule); capsules != 1 {
+		t.Errorf("Expected an Ability Capsule on day 14, got %d", capsules)
+	}
+
+	status, err := streakService.GetStreak(ctx, user.ID)
+	if err != nil || status.Current != 14 || status.NextMilestone.Day != 21 {
+		t.Errorf("Expected day 14 with day 21 the next milestone, got %+v (%v)", status, err)
+	}
+}
+
+func TestCheckIn_FailedCheckInGrantsNothing(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	checkInAt(t, "UTC")
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	streakRepo := mocks.NewMockLoginStreakRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, streakRepo, itemRepo)
+	mocks.SeedAllRarities(speciesRepo)
+
+	gachaService := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), txManager)
+	streakService := service.NewStreakService(userRepo, streakRepo, itemRepo, gachaService, txManager)
+
+	user := mocks.CreateTestUser("streak-user")
+	userRepo.Create(ctx, user)
+	startingCoins := user.Coins
+	streakRepo.Streaks[user.ID] = streakSinceYesterday(user.ID, 6)
+	streakRepo.SaveError = errors.New("database down")
+
+	// Execute
+	_, err := streakService.CheckIn(ctx, user.ID)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected the check-in to fail")
+	}
+	if user.Coins != startingCoins || len(pokemonRepo.Pokemons) != 0 {
+		t.Errorf("Expected no coins or Pokemon granted, got %d coins and %d Pokemon", user.Coins, len(pokemonRepo.Pokemons))
+	}
+	if streakRepo.Streaks[user.ID].Current != 6 {
+		t.Errorf("Expected the streak unchanged, got %+v", streakRepo.Streaks[user.ID])
+	}
+}
@@ -0,0 +1,535 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+func TestTrade_BothConfirmSwapsPokemonAndCoins(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, notificationRepo)
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, mocks.NewMockBattleRepository(), notificationRepo, txManager)
+
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	userRepo.Create(ctx, alice)
+	userRepo.Create(ctx, bob)
+
+	pikachu := domain.NewUserPokemon(alice.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	eevee := domain.NewUserPokemon(bob.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, pikachu)
+	pokemonRepo.Create(ctx, eevee)
+
+	// Alice offers her Pikachu and 200 coins for bob's Eevee
+	trade, err := tradeService.ProposeTrade(ctx, alice.ID, bob.ID, []uuid.UUID{pikachu.ID}, []uuid.UUID{eevee.ID}, 200, 0)
+	if err != nil {
+		t.Fatalf("Expected no error proposing trade, got %v", err)
+	}
+
+	if len(notificationRepo.ForUser(bob.ID)) != 1 {
+		t.Fatal("Expected the recipient to be notified of the offer")
+	}
+
+	// Execute
+	confirmed, err := tradeService.ConfirmTrade(ctx, alice.ID, trade.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error confirming, got %v", err)
+	}
+	if confirmed.Status != domain.TradeStatusPending {
+		t.Fatalf("Expected trade to wait for the second confirmation, got %s", confirmed.Status)
+	}
+	if pikachu.UserID != alice.ID {
+		t.Fatal("Expected nothing to move after one confirmation")
+	}
+
+	// Verify the second confirmation completes the trade
+	completed, err := tradeService.ConfirmTrade(ctx, bob.ID, trade.ID)
+	if err != nil {
+		t.Fatalf("Expected no error confirming, got %v", err)
+	}
+	if completed.Status != domain.TradeStatusCompleted || completed.CompletedAt == nil {
+		t.Errorf("Expected completed trade, got %s", completed.Status)
+	}
+
+	if pikachu.UserID != bob.ID || eevee.UserID != alice.ID {
+		t.Error("Expected the Pokemon to swap owners")
+	}
+
+	if alice.Coins != domain.StartingCoins-200 || bob.Coins != domain.StartingCoins+200 {
+		t.Errorf("Expected 200 coins to move from alice to bob, got %d and %d", alice.Coins, bob.Coins)
+	}
+
+	stored, _ := tradeRepo.GetByID(ctx, trade.ID)
+	if stored.Status != domain.TradeStatusCompleted {
+		t.Errorf("Expected stored trade to be completed, got %s", stored.Status)
+	}
+}
+
+func TestTrade_ConfirmTwiceByOneSideDoesNotExecute(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, notificationRepo)
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, mocks.NewMockBattleRepository(), notificationRepo, txManager)
+
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	userRepo.Create(ctx, alice)
+	userRepo.Create(ctx, bob)
+
+	pikachu := domain.NewUserPokemon(alice.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	eevee := domain.NewUserPokemon(bob.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, pikachu)
+	pokemonRepo.Create(ctx, eevee)
+
+	// Alice offers her Pikachu for bob's Eevee
+	trade, err := tradeService.ProposeTrade(ctx, alice.ID, bob.ID, []uuid.UUID{pikachu.ID}, []uuid.UUID{eevee.ID}, 0, 0)
+	if err != nil {
+		t.Fatalf("Expected no error proposing trade, got %v", err)
+	}
+
+	// Execute
+	tradeService.ConfirmTrade(ctx, alice.ID, trade.ID)
+	result, err := tradeService.ConfirmTrade(ctx, alice.ID, trade.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected repeat confirmation to be harmless, got %v", err)
+	}
+	if result.Status != domain.TradeStatusPending || pikachu.UserID != alice.ID {
+		t.Error("Expected the trade to still need the recipient's confirmation")
+	}
+}
+
+func TestTrade_OwnershipCheckedAtCommit(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, notificationRepo)
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, mocks.NewMockBattleRepository(), notificationRepo, txManager)
+
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	userRepo.Create(ctx, alice)
+	userRepo.Create(ctx, bob)
+
+	pikachu := domain.NewUserPokemon(alice.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	eevee := domain.NewUserPokemon(bob.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, pikachu)
+	pokemonRepo.Create(ctx, eevee)
+
+	// Alice offers her Pikachu and 100 coins for bob's Eevee
+	trade, err := tradeService.ProposeTrade(ctx, alice.ID, bob.ID, []uuid.UUID{pikachu.ID}, []uuid.UUID{eevee.ID}, 100, 0)
+	if err != nil {
+		t.Fatalf("Expected no error proposing trade, got %v", err)
+	}
+
+	tradeService.ConfirmTrade(ctx, alice.ID, trade.ID)
+
+	// Alice's Pikachu changes hands before bob confirms
+	carol := mocks.CreateTestUser("carol")
+	userRepo.Create(ctx, carol)
+	if err := pokemonRepo.TransferOwnership(ctx, pikachu.ID, alice.ID, carol.ID); err != nil {
+		t.Fatalf("Expected no error transferring, got %v", err)
+	}
+
+	// Execute
+	_, err = tradeService.ConfirmTrade(ctx, bob.ID, trade.ID)
+
+	// Assert
+	if !errors.Is(err, service.ErrTradePokemonUnavailable) {
+		t.Fatalf("Expected ErrTradePokemonUnavailable, got %v", err)
+	}
+
+	if eevee.UserID != bob.ID {
+		t.Error("Expected bob to keep his Eevee")
+	}
+	if bob.Coins != domain.StartingCoins {
+		t.Errorf("Expected bob's coins to be untouched, got %d", bob.Coins)
+	}
+	stored, _ := tradeRepo.GetByID(ctx, trade.ID)
+	if stored.RecipientConfirmed {
+		t.Error("Expected the failed confirmation to be rolled back")
+	}
+}
+
+func TestTrade_UnfinishedBattleCheckedAtCommit(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	battleRepo := mocks.NewMockBattleRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, notificationRepo)
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, battleRepo, notificationRepo, txManager)
+
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	userRepo.Create(ctx, alice)
+	userRepo.Create(ctx, bob)
+
+	pikachu := domain.NewUserPokemon(alice.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	eevee := domain.NewUserPokemon(bob.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, pikachu)
+	pokemonRepo.Create(ctx, eevee)
+
+	// Alice offers her Pikachu for bob's Eevee
+	trade, err := tradeService.ProposeTrade(ctx, alice.ID, bob.ID, []uuid.UUID{pikachu.ID}, []uuid.UUID{eevee.ID}, 0, 0)
+	if err != nil {
+		t.Fatalf("Expected no error proposing trade, got %v", err)
+	}
+
+	tradeService.ConfirmTrade(ctx, alice.ID, trade.ID)
+
+	// Bob takes his Eevee into a battle before confirming
+	battle := domain.NewBattle(bob.ID, uuid.New(), 0)
+	battle.Player1Pokemon = eevee.ID
+	battle.Status = domain.BattleStatusInProgress
+	battleRepo.Create(ctx, battle)
+
+	// Execute
+	_, err = tradeService.ConfirmTrade(ctx, bob.ID, trade.ID)
+
+	// Assert
+	if !errors.Is(err, service.ErrPokemonLocked) {
+		t.Fatalf("Expected ErrPokemonLocked, got %v", err)
+	}
+	if pikachu.UserID != alice.ID || eevee.UserID != bob.ID {
+		t.Error("Expected both Pokemon to stay with their owners")
+	}
+	stored, _ := tradeRepo.GetByID(ctx, trade.ID)
+	if stored.Status != domain.TradeStatusPending {
+		t.Errorf("Expected the trade still pending, got %s", stored.Status)
+	}
+}
+
+func TestTrade_PokemonInPendingTradeCannotBeOfferedAgain(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, notificationRepo)
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, mocks.NewMockBattleRepository(), notificationRepo, txManager)
+
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	userRepo.Create(ctx, alice)
+	userRepo.Create(ctx, bob)
+
+	pikachu := domain.NewUserPokemon(alice.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	eevee := domain.NewUserPokemon(bob.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, pikachu)
+	pokemonRepo.Create(ctx, eevee)
+
+	// Alice offers her Pikachu for bob's Eevee
+	_, err := tradeService.ProposeTrade(ctx, alice.ID, bob.ID, []uuid.UUID{pikachu.ID}, []uuid.UUID{eevee.ID}, 0, 0)
+	if err != nil {
+		t.Fatalf("Expected no error proposing trade, got %v", err)
+	}
+
+	carol := mocks.CreateTestUser("carol")
+	userRepo.Create(ctx, carol)
+
+	// Execute and assert
+	// Alice's Pikachu is already promised to bob
+	_, err = tradeService.ProposeTrade(ctx, alice.ID, carol.ID, []uuid.UUID{pikachu.ID}, nil, 0, 0)
+	if !errors.Is(err, service.ErrPokemonInPendingTrade) {
+		t.Fatalf("Expected ErrPokemonInPendingTrade, got %v", err)
+	}
+
+	// So is bob's Eevee, even when someone else asks for it
+	_, err = tradeService.ProposeTrade(ctx, carol.ID, bob.ID, nil, []uuid.UUID{eevee.ID}, 10, 0)
+	if !errors.Is(err, service.ErrPokemonInPendingTrade) {
+		t.Fatalf("Expected ErrPokemonInPendingTrade, got %v", err)
+	}
+}
+
+func TestTrade_InsufficientCoinsAtCommitRollsBack(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, notificationRepo)
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, mocks.NewMockBattleRepository(), notificationRepo, txManager)
+
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	userRepo.Create(ctx, alice)
+	userRepo.Create(ctx, bob)
+
+	pikachu := domain.NewUserPokemon(alice.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	eevee := domain.NewUserPokemon(bob.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, pikachu)
+	pokemonRepo.Create(ctx, eevee)
+
+	// Alice offers her Pikachu and 500 coins for bob's Eevee
+	trade, err := tradeService.ProposeTrade(ctx, alice.ID, bob.ID, []uuid.UUID{pikachu.ID}, []uuid.UUID{eevee.ID}, 500, 0)
+	if err != nil {
+		t.Fatalf("Expected no error proposing trade, got %v", err)
+	}
+
+	tradeService.ConfirmTrade(ctx, alice.ID, trade.ID)
+	userRepo.UpdateCoins(ctx, alice.ID, 100)
+
+	// Execute
+	_, err = tradeService.ConfirmTrade(ctx, bob.ID, trade.ID)
+
+	// Assert
+	if !errors.Is(err, service.ErrTradeInsufficientCoins) {
+		t.Fatalf("Expected ErrTradeInsufficientCoins, got %v", err)
+	}
+	if pikachu.UserID != alice.ID || eevee.UserID != bob.ID {
+		t.Error("Expected no Pokemon to move")
+	}
+}
+
+func TestTrade_ProposeValidation(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, notificationRepo)
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, mocks.NewMockBattleRepository(), notificationRepo, txManager)
+
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	userRepo.Create(ctx, alice)
+	userRepo.Create(ctx, bob)
+
+	pikachu := domain.NewUserPokemon(alice.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	eevee := domain.NewUserPokemon(bob.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, pikachu)
+	pokemonRepo.Create(ctx, eevee)
+
+	// Execute and assert
+	tests := []struct {
+		name        string
+		recipientID uuid.UUID
+		give        []uuid.UUID
+		want        []uuid.UUID
+		coins       int
+		expected    error
+	}{
+		{"self trade", alice.ID, []uuid.UUID{pikachu.ID}, nil, 0, validators.ErrCannotTradeWithSelf},
+		{"empty", bob.ID, nil, nil, 0, validators.ErrEmptyTrade},
+		{"negative coins", bob.ID, nil, []uuid.UUID{eevee.ID}, -5, validators.ErrInvalidTradeCoins},
+		{"duplicate", bob.ID, []uuid.UUID{pikachu.ID, pikachu.ID}, nil, 0, validators.ErrDuplicateTradePokemon},
+		{"not owned", bob.ID, []uuid.UUID{eevee.ID}, nil, 0, service.ErrTradePokemonUnavailable},
+		{"too many coins", bob.ID, nil, []uuid.UUID{eevee.ID}, domain.StartingCoins + 1, service.ErrTradeInsufficientCoins},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tradeService.ProposeTrade(ctx, alice.ID, tt.recipientID, tt.give, tt.want, tt.coins, 0)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestTrade_ListedPokemonCannotBeTraded(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, notificationRepo)
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, mocks.NewMockBattleRepository(), notificationRepo, txManager)
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, tradeRepo, mocks.NewMockBattleRepository(), txManager)
+
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	userRepo.Create(ctx, alice)
+	userRepo.Create(ctx, bob)
+
+	pikachu := domain.NewUserPokemon(alice.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	eevee := domain.NewUserPokemon(bob.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, pikachu)
+	pokemonRepo.Create(ctx, eevee)
+
+	if _, err := marketService.CreateListing(ctx, alice.ID, pikachu.ID, 50); err != nil {
+		t.Fatalf("Expected no error listing, got %v", err)
+	}
+
+	// Execute
+	_, err := tradeService.ProposeTrade(ctx, alice.ID, bob.ID, []uuid.UUID{pikachu.ID}, nil, 0, 0)
+
+	// Assert
+	if !errors.Is(err, service.ErrPokemonListed) {
+		t.Errorf("Expected ErrPokemonListed, got %v", err)
+	}
+}
+
+func TestTrade_AcquisitionCooldown(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, notificationRepo)
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, mocks.NewMockBattleRepository(), notificationRepo, txManager)
+
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	userRepo.Create(ctx, alice)
+	userRepo.Create(ctx, bob)
+
+	pikachu := domain.NewUserPokemon(alice.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	eevee := domain.NewUserPokemon(bob.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, pikachu)
+	pokemonRepo.Create(ctx, eevee)
+	tradeService.SetAcquisitionCooldown(time.Hour)
+
+	// Execute
+	_, err := tradeService.ProposeTrade(ctx, alice.ID, bob.ID, []uuid.UUID{pikachu.ID}, nil, 0, 0)
+
+	// Assert
+	if !errors.Is(err, service.ErrPokemonOnCooldown) {
+		t.Fatalf("Expected ErrPokemonOnCooldown for a new Pokemon, got %v", err)
+	}
+
+	// Verify the Pokemon can be offered once the cool-down has passed
+	pikachu.AcquiredAt = time.Now().Add(-2 * time.Hour)
+	if _, err := tradeService.ProposeTrade(ctx, alice.ID, bob.ID, []uuid.UUID{pikachu.ID}, nil, 0, 0); err != nil {
+		t.Errorf("Expected no error once the cool-down has passed, got %v", err)
+	}
+}
+
+func TestTrade_CancelAndOutsiders(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, notificationRepo)
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, mocks.NewMockBattleRepository(), notificationRepo, txManager)
+
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	userRepo.Create(ctx, alice)
+	userRepo.Create(ctx, bob)
+
+	pikachu := domain.NewUserPokemon(alice.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	eevee := domain.NewUserPokemon(bob.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, pikachu)
+	pokemonRepo.Create(ctx, eevee)
+
+	// Alice offers her Pikachu for bob's Eevee
+	trade, err := tradeService.ProposeTrade(ctx, alice.ID, bob.ID, []uuid.UUID{pikachu.ID}, []uuid.UUID{eevee.ID}, 0, 0)
+	if err != nil {
+		t.Fatalf("Expected no error proposing trade, got %v", err)
+	}
+
+	carol := mocks.CreateTestUser("carol")
+	userRepo.Create(ctx, carol)
+
+	// Execute and assert
+	if _, err := tradeService.ConfirmTrade(ctx, carol.ID, trade.ID); !errors.Is(err, service.ErrNotTradeParticipant) {
+		t.Errorf("Expected ErrNotTradeParticipant, got %v", err)
+	}
+
+	// Verify either side can cancel
+	if err := tradeService.CancelTrade(ctx, bob.ID, trade.ID); err != nil {
+		t.Fatalf("Expected no error cancelling, got %v", err)
+	}
+	if notes := notificationRepo.ForUser(alice.ID); len(notes) != 1 || notes[0].Type != domain.NotificationTradeCancelled {
+		t.Error("Expected the proposer to be told the trade was cancelled")
+	}
+
+	if _, err := tradeService.ConfirmTrade(ctx, alice.ID, trade.ID); !errors.Is(err, service.ErrTradeUnavailable) {
+		t.Errorf("Expected ErrTradeUnavailable after cancel, got %v", err)
+	}
+}
+
+func TestTrade_ExpiredOffersCannotBeConfirmed(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	tradeRepo := mocks.NewMockTradeRepository()
+	notificationRepo := mocks.NewMockNotificationRepository(userRepo)
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, tradeRepo, notificationRepo)
+	tradeService := service.NewTradeService(userRepo, pokemonRepo, listingRepo, tradeRepo, mocks.NewMockBattleRepository(), notificationRepo, txManager)
+
+	alice := mocks.CreateTestUser("alice")
+	bob := mocks.CreateTestUser("bob")
+	userRepo.Create(ctx, alice)
+	userRepo.Create(ctx, bob)
+
+	pikachu := domain.NewUserPokemon(alice.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	eevee := domain.NewUserPokemon(bob.ID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+	pokemonRepo.Create(ctx, pikachu)
+	pokemonRepo.Create(ctx, eevee)
+
+	// Alice offers her Pikachu for bob's Eevee
+	trade, err := tradeService.ProposeTrade(ctx, alice.ID, bob.ID, []uuid.UUID{pikachu.ID}, []uuid.UUID{eevee.ID}, 0, 0)
+	if err != nil {
+		t.Fatalf("Expected no error proposing trade, got %v", err)
+	}
+
+	tradeRepo.Trades[trade.ID].ExpiresAt = time.Now().Add(-time.Second)
+
+	// Execute and assert
+	if _, err := tradeService.ConfirmTrade(ctx, bob.ID, trade.ID); !errors.Is(err, service.ErrTradeExpired) {
+		t.Errorf("Expected ErrTradeExpired, got %v", err)
+	}
+
+	// Verify expired offers are hidden and swept
+	pending, _ := tradeService.ListPendingTrades(ctx, bob.ID)
+	if len(pending) != 0 {
+		t.Errorf("Expected expired offers to be hidden, got %d", len(pending))
+	}
+
+	expired, err := tradeService.ExpireTrades(ctx)
+	if err != nil || expired != 1 {
+		t.Fatalf("Expected 1 trade expired, got %d (%v)", expired, err)
+	}
+	stored, _ := tradeRepo.GetByID(ctx, trade.ID)
+	if stored.Status != domain.TradeStatusExpired {
+		t.Errorf("Expected expired status, got %s", stored.Status)
+	}
+}
@@ -0,0 +1,260 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+)
+
+func TestCalculateStat_IncludesEVs(t *testing.T) {
+	pokemon := domain.NewUserPokemon(mocks.CreateTestUser("trainer").ID, mocks.CreateTestSpecies(1, "TestMon", domain.Common))
+	pokemon.Nature = domain.Hardy
+	pokemon.IVs = domain.IVs{HP: 31, Attack: 31}
+
+	// Base 100 at level 50: floor((200 + 31 + 63) * 50 / 100) + 5 = 152
+	pokemon.EVs = domain.EVs{HP: 252, Attack: 252}
+	stats := pokemon.GetStats()
+	if stats.Attack != 152 {
+		t.Errorf("Expected 152 Attack with 252 EVs, got %d", stats.Attack)
+	}
+	if stats.HP != 207 {
+		t.Errorf("Expected 207 HP with 252 EVs, got %d", stats.HP)
+	}
+
+	pokemon.EVs = domain.EVs{}
+	if got := pokemon.GetStats().Attack; got != 120 {
+		t.Errorf("Expected 120 Attack without EVs, got %d", got)
+	}
+}
+
+func TestEVSpread_RoundTrips(t *testing.T) {
+	evs, err := domain.ParseEVs("EVs: 252 Atk / 4 SpD / 252 Spe")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if evs != (domain.EVs{Attack: 252, SpDefense: 4, Speed: 252}) {
+		t.Errorf("Expected 252 Atk / 4 SpD / 252 Spe, got %+v", evs)
+	}
+	if evs.String() != "252 Atk / 4 SpD / 252 Spe" {
+		t.Errorf("Expected the spread to format back, got %q", evs.String())
+	}
+
+	if _, err := domain.ParseEVs("252 Luck"); !errors.Is(err, domain.ErrInvalidEVSpread) {
+		t.Errorf("Expected ErrInvalidEVSpread for an unknown stat, got %v", err)
+	}
+	if _, err := domain.ParseEVs("lots Atk"); !errors.Is(err, domain.ErrInvalidEVSpread) {
+		t.Errorf("Expected ErrInvalidEVSpread for a non-number, got %v", err)
+	}
+}
+
+func TestValidateEVs_Limits(t *testing.T) {
+	if err := validators.ValidateEVs(domain.EVs{Attack: 252, Speed: 252, HP: 6}); err != nil {
+		t.Errorf("Expected a full 510 spread to be valid, got %v", err)
+	}
+	if err := validators.ValidateEVs(domain.EVs{Attack: 253}); !errors.Is(err, validators.ErrInvalidEV) {
+		t.Errorf("Expected ErrInvalidEV above 252, got %v", err)
+	}
+	if err := validators.ValidateEVs(domain.EVs{Attack: 252, Speed: 252, HP: 7}); !errors.Is(err, validators.ErrTooManyEVs) {
+		t.Errorf("Expected ErrTooManyEVs above 510, got %v", err)
+	}
+}
+
+func TestTrainEVs_PaysWithCoins(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, itemRepo, auditRepo)
+	trainingService := service.NewTrainingService(userRepo, pokemonRepo, itemRepo, auditRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	user := mocks.CreateTestUser("trainer")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(1, "TestMon", domain.Common))
+	pokemonRepo.Create(ctx, pokemon)
+	before := pokemon.GetStats()
+
+	// Execute
+	result, err := trainingService.TrainEVs(ctx, user.ID, pokemon.ID, domain.EVs{Attack: 100}, domain.PayWithCoins)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Pokemon.EVs.Attack != 100 {
+		t.Errorf("Expected 100 Attack EVs, got %d", result.Pokemon.EVs.Attack)
+	}
+	if result.Pokemon.GetStats().Attack <= before.Attack {
+		t.Errorf("Expected Attack to rise from %d, got %d", before.Attack, result.Pokemon.GetStats().Attack)
+	}
+	if result.Cost.Coins != 100*domain.CoinsPerEV || user.Coins != 1000-100*domain.CoinsPerEV {
+		t.Errorf("Expected to pay %d coins, cost %d and %d left", 100*domain.CoinsPerEV, result.Cost.Coins, user.Coins)
+	}
+
+	if len(auditRepo.Entries) != 1 || auditRepo.Entries[0].Action != domain.AuditEVTrain {
+		t.Fatalf("Expected one ev_train audit entry, got %v", auditRepo.Entries)
+	}
+	if auditRepo.Entries[0].After.EVs.Attack != 100 {
+		t.Errorf("Expected the audit to record the new EVs, got %+v", auditRepo.Entries[0].After.EVs)
+	}
+}
+
+func TestTrainEVs_PaysWithVitamins(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, itemRepo, auditRepo)
+	trainingService := service.NewTrainingService(userRepo, pokemonRepo, itemRepo, auditRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	user := mocks.CreateTestUser("trainer")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(1, "TestMon", domain.Common))
+	pokemonRepo.Create(ctx, pokemon)
+	itemRepo.Adjust(ctx, user.ID, "carbos", 3)
+
+	// Execute
+	// 25 Speed EVs round up to 3 carbos
+	result, err := trainingService.TrainEVs(ctx, user.ID, pokemon.ID, domain.EVs{Speed: 25}, domain.PayWithVitamins)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Cost.Vitamins["carbos"] != 3 {
+		t.Errorf("Expected to use 3 carbos, got %v", result.Cost.Vitamins)
+	}
+	if left, _ := itemRepo.Get(ctx, user.ID, "carbos"); left != 0 {
+		t.Errorf("Expected no carbos left, got %d", left)
+	}
+	if user.Coins != 1000 {
+		t.Errorf("Expected coins untouched, got %d", user.Coins)
+	}
+}
+
+func TestTrainEVs_OverLimitSpendsNothing(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, itemRepo, auditRepo)
+	trainingService := service.NewTrainingService(userRepo, pokemonRepo, itemRepo, auditRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	user := mocks.CreateTestUser("trainer")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(1, "TestMon", domain.Common))
+	pokemonRepo.Create(ctx, pokemon)
+	pokemon.EVs = domain.EVs{Attack: 252, Speed: 252}
+
+	// Execute
+	_, err := trainingService.TrainEVs(ctx, user.ID, pokemon.ID, domain.EVs{HP: 10}, domain.PayWithCoins)
+
+	// Assert
+	if !errors.Is(err, validators.ErrTooManyEVs) {
+		t.Fatalf("Expected ErrTooManyEVs, got %v", err)
+	}
+
+	// Verify the EV cap per stat
+	_, err = trainingService.TrainEVs(ctx, user.ID, pokemon.ID, domain.EVs{Attack: 1}, domain.PayWithCoins)
+	if !errors.Is(err, validators.ErrInvalidEV) {
+		t.Fatalf("Expected ErrInvalidEV, got %v", err)
+	}
+
+	// Verify nothing was spent
+	if user.Coins != 1000 || pokemon.EVs.HP != 0 || len(auditRepo.Entries) != 0 {
+		t.Errorf("Expected nothing spent or changed, got %d coins, %+v", user.Coins, pokemon.EVs)
+	}
+}
+
+func TestTrainEVs_MissingPaymentRollsBack(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, itemRepo, auditRepo)
+	trainingService := service.NewTrainingService(userRepo, pokemonRepo, itemRepo, auditRepo, mocks.NewMockMarketListingRepository(), mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	user := mocks.CreateTestUser("trainer")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(1, "TestMon", domain.Common))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// Execute
+	_, err := trainingService.TrainEVs(ctx, user.ID, pokemon.ID, domain.EVs{Defense: 50}, domain.PayWithVitamins)
+
+	// Assert
+	if !errors.Is(err, service.ErrTrainingVitamins) {
+		t.Fatalf("Expected ErrTrainingVitamins, got %v", err)
+	}
+
+	// Verify paying with too few coins
+	user.Coins = 10
+	_, err = trainingService.TrainEVs(ctx, user.ID, pokemon.ID, domain.EVs{Defense: 50}, domain.PayWithCoins)
+	if !errors.Is(err, service.ErrTrainingCoins) {
+		t.Fatalf("Expected ErrTrainingCoins, got %v", err)
+	}
+
+	// Verify both were rolled back
+	if pokemon.EVs.Defense != 0 {
+		t.Errorf("Expected EVs to be rolled back, got %d", pokemon.EVs.Defense)
+	}
+	if txManager.Rollbacks != 2 {
+		t.Errorf("Expected 2 rollbacks, got %d", txManager.Rollbacks)
+	}
+}
+
+func TestTrainEVs_Rejections(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	itemRepo := mocks.NewMockItemRepository()
+	auditRepo := mocks.NewMockPokemonAuditRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	txManager := mocks.NewMockTxManager(userRepo, pokemonRepo, itemRepo, auditRepo)
+	trainingService := service.NewTrainingService(userRepo, pokemonRepo, itemRepo, auditRepo, listingRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), txManager)
+
+	user := mocks.CreateTestUser("trainer")
+	user.Coins = 1000
+	userRepo.Create(ctx, user)
+	pokemon := domain.NewUserPokemon(user.ID, mocks.CreateTestSpecies(1, "TestMon", domain.Common))
+	pokemonRepo.Create(ctx, pokemon)
+
+	// Execute and assert
+	if _, err := trainingService.TrainEVs(ctx, user.ID, pokemon.ID, domain.EVs{}, domain.PayWithCoins); !errors.Is(err, validators.ErrNoEVsToTrain) {
+		t.Errorf("Expected ErrNoEVsToTrain, got %v", err)
+	}
+	if _, err := trainingService.TrainEVs(ctx, user.ID, pokemon.ID, domain.EVs{Attack: -4}, domain.PayWithCoins); !errors.Is(err, validators.ErrNegativeEVs) {
+		t.Errorf("Expected ErrNegativeEVs, got %v", err)
+	}
+	if _, err := trainingService.TrainEVs(ctx, user.ID, pokemon.ID, domain.EVs{Attack: 4}, "candy"); !errors.Is(err, validators.ErrInvalidEVPayment) {
+		t.Errorf("Expected ErrInvalidEVPayment, got %v", err)
+	}
+	if _, err := trainingService.TrainEVs(ctx, mocks.CreateTestUser("other").ID, pokemon.ID, domain.EVs{Attack: 4}, domain.PayWithCoins); !errors.Is(err, service.ErrNotPokemonOwner) {
+		t.Errorf("Expected ErrNotPokemonOwner, got %v", err)
+	}
+
+	// Verify a listed Pokemon is locked
+	listingRepo.Create(ctx, domain.NewMarketListing(pokemon, 100))
+	if _, err := trainingService.TrainEVs(ctx, user.ID, pokemon.ID, domain.EVs{Attack: 4}, domain.PayWithCoins); !errors.Is(err, service.ErrPokemonLocked) {
+		t.Errorf("Expected ErrPokemonLocked, got %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+// recordSales adds completed sales of a species at the given prices and IV%,
+// one hour apart, most recent last
+func recordSales(repo *mocks.MockMarketTransactionRepository, speciesID int, ivPercentage float64, prices ...int) {
+	start := time.Now().Add(-time.Duration(len(prices)) * time.Hour)
+	for i, price := range prices {
+		repo.Transactions = append(repo.Transactions, &domain.MarketTransaction{
+			ID:           uuid.New(),
+			SpeciesID:    speciesID,
+			Price:        price,
+			IVPercentage: ivPercentage,
+			CompletedAt:  start.Add(time.Duration(i) * time.Hour),
+		})
+	}
+}
+
+func TestValuation_MedianOfRecentSales(t *testing.T) {
+	repo := mocks.NewMockMarketTransactionRepository()
+	svc := service.NewValuationService(repo)
+
+	// At the reference IV% prices need no adjustment; the outlier is left out
+	recordSales(repo, 25, domain.ReferenceIVPercent, 100, 120, 110, 90, 105, 5000)
+
+	valuations, err := svc.GetValuations(context.Background(), []int{25})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	valuation := valuations[25]
+	if valuation == nil {
+		t.Fatal("Expected a valuation with enough sales")
+	}
+	if valuation.MedianPrice != 105 || valuation.SampleSize != 5 {
+		t.Errorf("Expected median 105 over 5 sales, got %d over %d", valuation.MedianPrice, valuation.SampleSize)
+	}
+}
+
+func TestValuation_AdjustsForIVs(t *testing.T) {
+	repo := mocks.NewMockMarketTransactionRepository()
+	svc := service.NewValuationService(repo)
+
+	// Perfect-IV sales are worth 1.5x a 0% Pokemon, so they imply 125 at 50%
+	recordSales(repo, 25, 100, 150, 150, 150, 150, 150)
+
+	valuation := mustValuation(t, svc, 25)
+	if valuation.MedianPrice != 125 {
+		t.Errorf("Expected 125 at the reference IV%%, got %d", valuation.MedianPrice)
+	}
+	if got := valuation.ValueAt(0); got != 100 {
+		t.Errorf("Expected 100 for 0%% IVs, got %d", got)
+	}
+	if got := valuation.ValueAt(100); got != 150 {
+		t.Errorf("Expected 150 for perfect IVs, got %d", got)
+	}
+}
+
+func TestValuation_NotEnoughOrStaleSales(t *testing.T) {
+	repo := mocks.NewMockMarketTransactionRepository()
+	svc := service.NewValuationService(repo)
+
+	// Too few sales of Pikachu
+	recordSales(repo, 25, 50, 100, 100, 100)
+
+	// Old sales are outside the rolling window
+	for i := 0; i < domain.MinSalesForValuation; i++ {
+		repo.Transactions = append(repo.Transactions, &domain.MarketTransaction{
+			ID: uuid.New(), SpeciesID: 133, Price: 100, IVPercentage: 50,
+			CompletedAt: time.Now().Add(-domain.ValuationWindow - time.Hour),
+		})
+	}
+
+	valuations, err := svc.GetValuations(context.Background(), []int{25, 133})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(valuations) != 0 {
+		t.Errorf("Expected no valuations, got %d", len(valuations))
+	}
+}
+
+func TestValuation_TooFewSalesLeftAfterOutliers(t *testing.T) {
+	repo := mocks.NewMockMarketTransactionRepository()
+	svc := service.NewValuationService(repo)
+
+	// Five sales, but two are outliers, leaving too few to value Pikachu
+	recordSales(repo, 25, domain.ReferenceIVPercent, 100, 110, 90, 5000, 6000)
+
+	valuations, err := svc.GetValuations(context.Background(), []int{25})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if valuation := valuations[25]; valuation != nil {
+		t.Errorf("Expected no valuation, got %d over %d sales", valuation.MedianPrice, valuation.SampleSize)
+	}
+}
+
+func TestValuation_ApplyMarketValuesFallsBackToFormula(t *testing.T) {
+	repo := mocks.NewMockMarketTransactionRepository()
+	svc := service.NewValuationService(repo)
+	recordSales(repo, 25, domain.ReferenceIVPercent, 400, 400, 400, 400, 400)
+
+	userID := uuid.New()
+	pikachu := domain.NewUserPokemon(userID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pikachu.IVs = domain.IVs{HP: 31, Attack: 31, Defense: 31, SpAttack: 31, SpDefense: 31, Speed: 31}
+	pikachu.IsShiny = false
+	eevee := domain.NewUserPokemon(userID, mocks.CreateTestSpecies(133, "Eevee", domain.Uncommon))
+
+	if err := svc.ApplyMarketValues(context.Background(), []*domain.UserPokemon{pikachu, eevee}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !pikachu.HasMarketValue() || pikachu.EstimatedValue() != 480 {
+		t.Errorf("Expected a perfect Pikachu to be valued at 480 from sales, got %d", pikachu.EstimatedValue())
+	}
+	if eevee.HasMarketValue() || eevee.EstimatedValue() != eevee.FormulaValue() {
+		t.Error("Expected Eevee without sales to keep its formula value")
+	}
+}
+
+func TestValuation_PriceReport(t *testing.T) {
+	repo := mocks.NewMockMarketTransactionRepository()
+	svc := service.NewValuationService(repo)
+	ctx := context.Background()
+
+	for day, price := range []int{100, 200, 300} {
+		repo.Transactions = append(repo.Transactions, &domain.MarketTransaction{
+			ID: uuid.New(), SpeciesID: 25, Price: price, IVPercentage: 50,
+			CompletedAt: time.Now().Add(-time.Duration(2-day) * 24 * time.Hour),
+		})
+	}
+
+	report, err := svc.GetPriceReport(ctx, 25, 7)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.Valuation != nil {
+		t.Error("Expected no valuation from only 3 sales")
+	}
+	if len(report.History) != 3 {
+		t.Fatalf("Expected one history point per day with sales, got %d", len(report.History))
+	}
+	for i := 1; i < len(report.History); i++ {
+		if !report.History[i].Start.After(report.History[i-1].Start) {
+			t.Error("Expected history oldest first")
+		}
+	}
+	if report.History[0].MedianPrice != 100 || report.History[0].Volume != 1 {
+		t.Errorf("Unexpected first point: %+v", report.History[0])
+	}
+
+	if _, err := svc.GetPriceReport(ctx, 25, 0); !errors.Is(err, service.ErrInvalidHistoryDays) {
+		t.Errorf("Expected ErrInvalidHistoryDays, got %v", err)
+	}
+}
+
+func TestValuation_SalesRecordSpeciesAndIVs(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	listingRepo := mocks.NewMockMarketListingRepository()
+	marketTxRepo := mocks.NewMockMarketTransactionRepository()
+	marketService := service.NewMarketService(userRepo, pokemonRepo, listingRepo, marketTxRepo, mocks.NewMockTradeRepository(), mocks.NewMockBattleRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo, listingRepo, marketTxRepo))
+
+	seller := mocks.CreateTestUser("seller")
+	buyer := mocks.CreateTestUser("buyer")
+	userRepo.Create(ctx, seller)
+	userRepo.Create(ctx, buyer)
+
+	pokemon := domain.NewUserPokemon(seller.ID, mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	pokemonRepo.Create(ctx, pokemon)
+
+	listing, err := marketService.CreateListing(ctx, seller.ID, pokemon.ID, 300)
+	if err != nil {
+		t.Fatalf("Expected no error listing Pokemon, got %v", err)
+	}
+
+	// Execute
+	transaction, err := marketService.BuyListing(ctx, buyer.ID, listing.ID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error buying, got %v", err)
+	}
+	if transaction.SpeciesID != pokemon.SpeciesID || transaction.IVPercentage != pokemon.IVs.IVPercentage() {
+		t.Errorf("Expected the sale to record species %d at %.1f%% IVs, got %d at %.1f%%",
+			pokemon.SpeciesID, pokemon.IVs.IVPercentage(), transaction.SpeciesID, transaction.IVPercentage)
+	}
+}
+
+func mustValuation(t *testing.T, svc *service.ValuationService, speciesID int) *domain.SpeciesValuation {
+	t.Helper()
+	valuations, err := svc.GetValuations(context.Background(), []int{speciesID})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if valuations[speciesID] == nil {
+		t.Fatal("Expected a valuation")
+	}
+	return valuations[speciesID]
+}
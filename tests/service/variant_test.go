@@ -0,0 +1,272 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/danielyang21/GoBattleServer/internal/domain"
+	"github.com/danielyang21/GoBattleServer/internal/service"
+	"github.com/danielyang21/GoBattleServer/internal/validators"
+	"github.com/danielyang21/GoBattleServer/tests/mocks"
+	"github.com/google/uuid"
+)
+
+// setShinyRate changes the shiny rate for one test
+func setShinyRate(t *testing.T, rate float64) {
+	t.Helper()
+	previous := domain.ShinyRate()
+	if err := domain.SetShinyRate(rate); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	t.Cleanup(func() { domain.SetShinyRate(previous) })
+}
+
+// alolanSpecies returns a species that always comes in its Alolan form
+func alolanSpecies(id int, name string) *domain.PokemonSpecies {
+	species := mocks.CreateTestSpecies(id, name, domain.Common)
+	species.ShinySpriteURL = "https://example.com/shiny.png"
+	steel := domain.Steel
+	species.Forms = []*domain.PokemonForm{{
+		ID: id * 10, SpeciesID: id, Name: "alola", Type1: domain.Ice, Type2: &steel,
+		BaseHP: 50, BaseAttack: 75, BaseDefense: 150, BaseSpAttack: 10, BaseSpDefense: 35, BaseSpeed: 40,
+		SpriteURL: "https://example.com/alola.png", ShinySpriteURL: "https://example.com/alola-shiny.png",
+		RollChance: 1,
+	}}
+	return species
+}
+
+func TestVariant_ShinyRate(t *testing.T) {
+	species := mocks.CreateTestSpecies(25, "Pikachu", domain.Rare)
+
+	setShinyRate(t, 1)
+	if p := domain.NewUserPokemon(uuid.New(), species); !p.IsShiny {
+		t.Error("Expected every Pokemon to be shiny at a rate of 1")
+	}
+
+	setShinyRate(t, 0)
+	if p := domain.NewUserPokemon(uuid.New(), species); p.IsShiny {
+		t.Error("Expected no Pokemon to be shiny at a rate of 0")
+	}
+
+	if err := domain.SetShinyRate(1.5); !errors.Is(err, domain.ErrInvalidShinyRate) {
+		t.Errorf("Expected ErrInvalidShinyRate, got %v", err)
+	}
+	if domain.ShinyRate() != 0 {
+		t.Errorf("Expected a rejected rate to leave the rate unchanged, got %v", domain.ShinyRate())
+	}
+}
+
+func TestVariant_GenderFollowsSpeciesRatio(t *testing.T) {
+	tests := []struct {
+		rate   int
+		gender domain.Gender
+	}{
+		{domain.GenderlessRate, domain.Genderless},
+		{0, domain.Male},
+		{8, domain.Female},
+	}
+
+	for _, tt := range tests {
+		species := mocks.CreateTestSpecies(81, "Magnemite", domain.Common)
+		species.GenderRate = tt.rate
+		for i := 0; i < 20; i++ {
+			p := domain.NewUserPokemon(uuid.New(), species)
+			if p.Gender != tt.gender {
+				t.Fatalf("Expected gender rate %d to give %s, got %s", tt.rate, tt.gender, p.Gender)
+			}
+			if err := validators.ValidateUserPokemon(p); err != nil {
+				t.Fatalf("Expected a rolled Pokemon to be valid, got %v", err)
+			}
+		}
+	}
+
+	// An even ratio gives both genders
+	species := mocks.CreateTestSpecies(25, "Pikachu", domain.Rare)
+	seen := make(map[domain.Gender]bool)
+	for i := 0; i < 200; i++ {
+		seen[domain.NewUserPokemon(uuid.New(), species).Gender] = true
+	}
+	if !seen[domain.Male] || !seen[domain.Female] || seen[domain.Genderless] {
+		t.Errorf("Expected an even ratio to give males and females only, got %v", seen)
+	}
+}
+
+func TestVariant_FormReplacesTypesStatsAndSprites(t *testing.T) {
+	setShinyRate(t, 0)
+	species := alolanSpecies(27, "Sandshrew")
+
+	p := domain.NewUserPokemon(uuid.New(), species)
+	if p.Form == nil || p.FormName() != "alola" || p.FormID == nil || *p.FormID != 270 {
+		t.Fatalf("Expected an Alolan form, got %+v", p.Form)
+	}
+
+	effective := p.EffectiveSpecies()
+	if effective.Type1 != domain.Ice || effective.Type2 == nil || *effective.Type2 != domain.Steel {
+		t.Errorf("Expected Ice/Steel types, got %v/%v", effective.Type1, effective.Type2)
+	}
+	if species.Type1 == domain.Ice || species.BaseDefense != 100 {
+		t.Error("Expected the form not to change the species itself")
+	}
+
+	plain := p.AtLevel(p.Level)
+	plain.SetForm(nil)
+	if p.GetStats().Defense <= plain.GetStats().Defense || p.GetStats().SpAttack >= plain.GetStats().SpAttack {
+		t.Errorf("Expected the form's base stats to be used, got %+v vs %+v", p.GetStats(), plain.GetStats())
+	}
+
+	if p.SpriteURL() != "https://example.com/alola.png" {
+		t.Errorf("Expected the form's sprite, got %s", p.SpriteURL())
+	}
+	p.IsShiny = true
+	if p.SpriteURL() != "https://example.com/alola-shiny.png" {
+		t.Errorf("Expected the form's shiny sprite, got %s", p.SpriteURL())
+	}
+	plain.IsShiny = true
+	if plain.SpriteURL() != "https://example.com/shiny.png" {
+		t.Errorf("Expected the species' shiny sprite, got %s", plain.SpriteURL())
+	}
+}
+
+func TestVariant_FormulaValue(t *testing.T) {
+	setShinyRate(t, 0)
+	p := domain.NewUserPokemon(uuid.New(), mocks.CreateTestSpecies(25, "Pikachu", domain.Rare))
+	p.IVs = domain.IVs{}
+	p.Nature = domain.Hardy
+	base := p.FormulaValue()
+
+	p.IsShiny = true
+	if got, want := p.FormulaValue(), int(float64(base)*domain.ShinyValueFactor); got != want {
+		t.Errorf("Expected a shiny to be worth %d, got %d", want, got)
+	}
+
+	alolan := domain.NewUserPokemon(uuid.New(), alolanSpecies(26, "Raichu"))
+	alolan.IVs = domain.IVs{}
+	alolan.Nature = domain.Hardy
+	if got, want := alolan.FormulaValue(), int(10*domain.FormValueFactor); got != want {
+		t.Errorf("Expected an Alolan form to be worth %d, got %d", want, got)
+	}
+}
+
+func TestVariant_EvolveKeepsMatchingForm(t *testing.T) {
+	setShinyRate(t, 1)
+	p := domain.NewUserPokemon(uuid.New(), alolanSpecies(74, "Geodude"))
+	gender := p.Gender
+
+	p.Evolve(alolanSpecies(76, "Golem"))
+	if p.FormName() != "alola" || p.Form.SpeciesID != 76 || *p.FormID != 760 {
+		t.Errorf("Expected an Alolan Golem, got %+v", p.Form)
+	}
+	if !p.IsShiny || p.Gender != gender {
+		t.Error("Expected shininess and gender to carry over")
+	}
+
+	// Species without a matching form evolve into the base form
+	p.Evolve(mocks.CreateTestSpecies(75, "Graveler", domain.Uncommon))
+	if p.Form != nil || p.FormID != nil {
+		t.Errorf("Expected the base form, got %+v", p.Form)
+	}
+	if err := validators.ValidateUserPokemon(p); err != nil {
+		t.Errorf("Expected the evolved Pokemon to be valid, got %v", err)
+	}
+}
+
+func TestVariant_Validation(t *testing.T) {
+	species := mocks.CreateTestSpecies(81, "Magnemite", domain.Common)
+	species.GenderRate = domain.GenderlessRate
+	p := domain.NewUserPokemon(uuid.New(), species)
+
+	p.Gender = domain.Male
+	if err := validators.ValidateUserPokemon(p); !errors.Is(err, validators.ErrInvalidGender) {
+		t.Errorf("Expected ErrInvalidGender for a male Magnemite, got %v", err)
+	}
+
+	p.Gender = domain.Genderless
+	p.SetForm(alolanSpecies(27, "Sandshrew").Forms[0])
+	if err := validators.ValidateUserPokemon(p); !errors.Is(err, validators.ErrInvalidForm) {
+		t.Errorf("Expected ErrInvalidForm for another species' form, got %v", err)
+	}
+
+	sandshrew := alolanSpecies(27, "Sandshrew")
+	sandshrew.Forms[0].RollChance = 0.6
+	sandshrew.Forms = append(sandshrew.Forms, &domain.PokemonForm{
+		ID: 271, SpeciesID: 27, Name: "galar", BaseHP: 50, RollChance: 0.6,
+	})
+	if err := validators.ValidatePokemonSpecies(sandshrew); !errors.Is(err, validators.ErrInvalidRollChance) {
+		t.Errorf("Expected ErrInvalidRollChance, got %v", err)
+	}
+}
+
+func TestVariant_RollsAreSaved(t *testing.T) {
+	setShinyRate(t, 1)
+	ctx := context.Background()
+	userRepo := mocks.NewMockUserRepository()
+	speciesRepo := mocks.NewMockPokemonSpeciesRepository()
+	pokemonRepo := mocks.NewMockUserPokemonRepository()
+	svc := service.NewGachaService(userRepo, speciesRepo, pokemonRepo, mocks.NewMockRollReceiptRepository(), mocks.NewMockBannerRepository(), mocks.NewMockPityRepository(), mocks.NewMockFairSeedRepository(), mocks.NewMockGachaPullRepository(), mocks.NewMockTxManager(userRepo, pokemonRepo))
+
+	for i, rarity := range []domain.Rarity{domain.Common, domain.Uncommon, domain.Rare, domain.Epic, domain.Legendary, domain.Mythic} {
+		species := alolanSpecies(i+1, string(rarity))
+		species.Rarity = rarity
+		speciesRepo.Create(ctx, species)
+	}
+	user := mocks.CreateTestUser("variant-user")
+	userRepo.Create(ctx, user)
+
+	pokemons, err := svc.DailyRoll(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, p := range pokemons {
+		saved, err := pokemonRepo.GetByID(ctx, p.ID)
+		if err != nil {
+			t.Fatalf("Expected the roll to be saved, got %v", err)
+		}
+		if !saved.IsShiny || saved.FormName() != "alola" || !saved.Gender.IsValid() {
+			t.Errorf("Expected a saved shiny Alolan Pokemon with a gender, got shiny=%v form=%q gender=%q",
+				saved.IsShiny, saved.FormName(), saved.Gender)
+		}
+	}
+}
+
+func TestValuation_VariantsAdjustMarketValue(t *testing.T) {
+	setShinyRate(t, 0)
+	repo := mocks.NewMockMarketTransactionRepository()
+	svc := service.NewValuationService(repo)
+
+	// Shiny sales at four times the price are valued like plain ones
+	recordSales(repo, 25, domain.ReferenceIVPercent, 1600, 1600, 1600, 1600, 1600)
+	for _, tx := range repo.Transactions {
+		tx.VariantFactor = domain.ShinyValueFactor
+	}
+
+	valuations, err := svc.GetValuations(context.Background(), []int{25})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if valuations[25].MedianPrice != 400 {
+		t.Errorf("Expected a plain median of 400, got %d", valuations[25].MedianPrice)
+	}
+
+	userID := uuid.New()
+	species := mocks.CreateTestSpecies(25, "Pikachu", domain.Rare)
+	plain := domain.NewUserPokemon(userID, species)
+	shiny := domain.NewUserPokemon(userID, species)
+	shiny.IsShiny = true
+	for _, p := range []*domain.UserPokemon{plain, shiny} {
+		p.IVs = domain.IVs{HP: 31, Attack: 31, Defense: 31, SpAttack: 31, SpDefense: 31, Speed: 31}
+	}
+
+	if err := svc.ApplyMarketValues(context.Background(), []*domain.UserPokemon{plain, shiny}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if plain.EstimatedValue() != 480 || shiny.EstimatedValue() != 1920 {
+		t.Errorf("Expected 480 plain and 1920 shiny, got %d and %d", plain.EstimatedValue(), shiny.EstimatedValue())
+	}
+
+	// Sales record the variant they sold as
+	listing := domain.NewMarketListing(shiny, 2000)
+	if tx := domain.NewMarketTransaction(listing, uuid.New()); tx.VariantFactor != domain.ShinyValueFactor {
+		t.Errorf("Expected the sale to record a factor of %v, got %v", domain.ShinyValueFactor, tx.VariantFactor)
+	}
+}